@@ -1,12 +1,12 @@
 package test
 
 import (
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
-	"github.com/mark3labs/mcp-go/server"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 )
@@ -17,7 +17,7 @@ type McpClient struct {
 	*client.Client
 }
 
-func NewMcpClient(t *testing.T, mcpHttpServer *server.StreamableHTTPServer) *McpClient {
+func NewMcpClient(t *testing.T, mcpHttpServer http.Handler) *McpClient {
 	require.NotNil(t, mcpHttpServer, "McpHttpServer must be provided")
 	var err error
 	ret := &McpClient{ctx: t.Context()}
@@ -50,3 +50,18 @@ func (m *McpClient) CallTool(name string, args map[string]interface{}) (*mcp.Cal
 	callToolRequest.Params.Arguments = args
 	return m.Client.CallTool(m.ctx, callToolRequest)
 }
+
+// ReadResource helper function to read a resource by URI
+func (m *McpClient) ReadResource(uri string) (*mcp.ReadResourceResult, error) {
+	readResourceRequest := mcp.ReadResourceRequest{}
+	readResourceRequest.Params.URI = uri
+	return m.Client.ReadResource(m.ctx, readResourceRequest)
+}
+
+// GetPrompt helper function to get a prompt by name with arguments
+func (m *McpClient) GetPrompt(name string, args map[string]string) (*mcp.GetPromptResult, error) {
+	getPromptRequest := mcp.GetPromptRequest{}
+	getPromptRequest.Params.Name = name
+	getPromptRequest.Params.Arguments = args
+	return m.Client.GetPrompt(m.ctx, getPromptRequest)
+}
@@ -12,9 +12,15 @@ import (
 	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
 
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/acm"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/config"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/core"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/cost"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/crd"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/helm"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/metrics"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/network"
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/storage"
 )
 
 type OpenShift struct{}
@@ -2,86 +2,91 @@ package acm
 
 import (
 	"context"
-	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
+	"sync"
 
+	"k8s.io/client-go/transport"
 	"k8s.io/klog/v2"
 )
 
 // ProxyClient handles communication with ACM cluster-proxy API
 type ProxyClient struct {
-	httpClient     *http.Client
+	transports     *Transports
 	serverURL      string
 	bearerToken    string
 	proxyRouteHost string // Dynamically discovered cluster-proxy route
+	routeOnce      sync.Once
+	routes         *RouteCache
+	clusters       *ClusterCache
+	impersonate    transport.ImpersonationConfig
 }
 
-// NewProxyClient creates a new ACM proxy client
-func NewProxyClient(serverURL, bearerToken string) *ProxyClient {
-	client := &ProxyClient{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: true, // ACM typically uses self-signed certs
-				},
-			},
-		},
+// NewProxyClient creates a new ACM proxy client.
+// The provided transports are shared with other clients to reuse connections, a new pool is created if nil.
+// The cluster-proxy route is discovered on the first proxied request.
+func NewProxyClient(serverURL, bearerToken string, transports *Transports) *ProxyClient {
+	if transports == nil {
+		transports = NewTransports(DefaultTransportOptions())
+	}
+	return &ProxyClient{
+		transports:  transports,
 		serverURL:   strings.TrimSuffix(serverURL, "/"),
 		bearerToken: bearerToken,
 	}
+}
 
-	// Dynamically discover the cluster-proxy route
-	client.discoverProxyRoute()
-
-	return client
+// routeHost returns the host of the cluster-proxy route, discovering it (or retrieving it from the route cache) once
+func (c *ProxyClient) routeHost(ctx context.Context) string {
+	c.routeOnce.Do(func() {
+		if c.proxyRouteHost != "" {
+			return
+		}
+		if c.routes != nil {
+			c.proxyRouteHost = c.routes.get(ctx, c.serverURL, c.discoverProxyRoute)
+		} else {
+			c.proxyRouteHost = c.discoverProxyRoute(ctx)
+		}
+	})
+	return c.proxyRouteHost
 }
 
-// ProxyRequest makes a request to the specified cluster via ACM proxy
-func (c *ProxyClient) ProxyRequest(ctx context.Context, cluster, apiPath string) (*http.Response, error) {
-	// Use cluster-proxy-addon-user external route for direct API access to managed clusters
-	// Format: https://<route-host>/<clusterName><apiPath>
+// WithImpersonation sets the identity the requests impersonate (e.g. the OIDC user of the MCP client),
+// so that the requests routed through the cluster-proxy aren't performed with the privileges of the bearer token
+func (c *ProxyClient) WithImpersonation(impersonate transport.ImpersonationConfig) *ProxyClient {
+	c.impersonate = impersonate
+	return c
+}
 
-	// Use dynamically discovered cluster-proxy route
-	if c.proxyRouteHost == "" {
-		return nil, fmt.Errorf("cluster-proxy route not discovered - ensure ACM cluster-proxy addon is installed")
+// authorize sets the authentication header of the request, along with the impersonation headers if any
+func (c *ProxyClient) authorize(header http.Header) {
+	header.Set("Authorization", "Bearer "+c.bearerToken)
+	if c.impersonate.UserName == "" {
+		return
 	}
-
-	// Build the route-based cluster proxy URL
-	fullURL := fmt.Sprintf("https://%s/%s%s", c.proxyRouteHost, cluster, apiPath)
-
-	klog.V(3).Infof("ACM proxy request: %s", fullURL)
-	fmt.Printf("DEBUG: ACM proxy service URL: %s\n", fullURL)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create proxy request: %w", err)
+	header.Set(transport.ImpersonateUserHeader, c.impersonate.UserName)
+	if c.impersonate.UID != "" {
+		header.Set(transport.ImpersonateUIDHeader, c.impersonate.UID)
 	}
-
-	// Set authentication header
-	req.Header.Set("Authorization", "Bearer "+c.bearerToken)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "kubernetes-mcp-server/acm-proxy")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("ACM proxy request failed for cluster %s: %w", cluster, err)
+	header.Del(transport.ImpersonateGroupHeader)
+	for _, group := range c.impersonate.Groups {
+		header.Add(transport.ImpersonateGroupHeader, group)
 	}
-
-	// Check for HTTP errors
-	if resp.StatusCode >= 400 {
-		defer func() { _ = resp.Body.Close() }()
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("ACM proxy returned %d for cluster %s: %s",
-			resp.StatusCode, cluster, string(body))
+	for key, values := range c.impersonate.Extra {
+		for _, value := range values {
+			header.Add(transport.ImpersonateUserExtraHeaderPrefix+url.PathEscape(key), value)
+		}
 	}
+}
 
-	return resp, nil
+// ProxyRequest makes a GET request to the specified cluster via ACM proxy.
+// Use NewRequest for any other verb, custom headers, or a request body.
+func (c *ProxyClient) ProxyRequest(ctx context.Context, cluster, apiPath string) (*http.Response, error) {
+	return c.NewRequest(cluster).AbsPath(apiPath).Do(ctx)
 }
 
 // ProxyLogRequest makes a log request to the specified pod via ACM proxy
@@ -111,11 +116,11 @@ func (c *ProxyClient) ProxyLogRequest(ctx context.Context, cluster, namespace, p
 	}
 
 	// Set authentication header
-	req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	c.authorize(req.Header)
 	req.Header.Set("Accept", "text/plain")
 	req.Header.Set("User-Agent", "kubernetes-mcp-server/acm-proxy")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.transports.Client(hubCluster, OperationLogs).Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("ACM proxy log request failed for cluster %s: %w", cluster, err)
 	}
@@ -144,6 +149,25 @@ func (c *ProxyClient) ValidateCluster(ctx context.Context, cluster string) error
 	return nil
 }
 
+// CheckProxyRoute verifies the cluster-proxy route was discovered and is reachable,
+// any HTTP response proves the route is reachable regardless of its status code
+func (c *ProxyClient) CheckProxyRoute(ctx context.Context) error {
+	routeHost := c.routeHost(ctx)
+	if routeHost == "" {
+		return fmt.Errorf("cluster-proxy route not discovered - ensure ACM cluster-proxy addon is installed")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+routeHost+"/", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster-proxy route request: %w", err)
+	}
+	resp, err := c.transports.Client(hubCluster, OperationDefault).Do(req)
+	if err != nil {
+		return fmt.Errorf("cluster-proxy route %s is not reachable: %w", routeHost, err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
 // IsACMEnvironment checks if we're running in an ACM environment
 func (c *ProxyClient) IsACMEnvironment(ctx context.Context) bool {
 	// Check for ACM APIs availability
@@ -154,9 +178,9 @@ func (c *ProxyClient) IsACMEnvironment(ctx context.Context) bool {
 		return false
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	c.authorize(req.Header)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.transports.Client(hubCluster, OperationDefault).Do(req)
 	if err != nil {
 		return false
 	}
@@ -174,10 +198,10 @@ func (c *ProxyClient) ListManagedClusters(ctx context.Context) ([]string, error)
 		return nil, fmt.Errorf("failed to create managed clusters request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	c.authorize(req.Header)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.transports.Client(hubCluster, OperationDefault).Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list managed clusters: %w", err)
 	}
@@ -187,53 +211,66 @@ func (c *ProxyClient) ListManagedClusters(ctx context.Context) ([]string, error)
 		return nil, fmt.Errorf("failed to list managed clusters, status: %d", resp.StatusCode)
 	}
 
-	// TODO: Parse the JSON response to extract cluster names
-	// For now, return empty list - this will be implemented when we add JSON parsing
-	klog.V(2).Info("Successfully connected to ACM managed clusters API")
-	return []string{}, nil
+	var managedClusters struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&managedClusters); err != nil {
+		return nil, fmt.Errorf("failed to parse managed clusters: %w", err)
+	}
+	clusters := make([]string, 0, len(managedClusters.Items))
+	for _, item := range managedClusters.Items {
+		clusters = append(clusters, item.Metadata.Name)
+	}
+	klog.V(2).InfoS("Listed ACM managed clusters", "count", len(clusters))
+	return clusters, nil
 }
 
-// discoverProxyRoute dynamically discovers the cluster-proxy-user route
-func (c *ProxyClient) discoverProxyRoute() {
+// discoverProxyRoute dynamically discovers the host of the cluster-proxy-user route, empty if it can't be discovered.
+// The route is retrieved with the identity of the client, including the impersonated identity if any.
+func (c *ProxyClient) discoverProxyRoute(ctx context.Context) string {
 	// Try to get the cluster-proxy-user route from the multicluster-engine namespace
 	routeURL := c.serverURL + "/apis/route.openshift.io/v1/namespaces/multicluster-engine/routes/cluster-proxy-addon-user"
 
-	req, err := http.NewRequest("GET", routeURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", routeURL, nil)
 	if err != nil {
 		klog.V(2).Infof("Failed to create route discovery request: %v", err)
-		return
+		return ""
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	c.authorize(req.Header)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.transports.Client(hubCluster, OperationDefault).Do(req)
 	if err != nil {
 		klog.V(2).Infof("Failed to discover cluster-proxy route: %v", err)
-		return
+		return ""
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != 200 {
 		klog.V(2).Infof("Failed to get cluster-proxy route, status: %d", resp.StatusCode)
-		return
+		return ""
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		klog.V(2).Infof("Failed to read route response: %v", err)
-		return
+		return ""
 	}
 
 	// Parse the route spec.host field from the JSON response
 	// Simple extraction - in production, would use proper JSON parsing
 	route := parseRouteHost(string(body))
 	if route != "" {
-		c.proxyRouteHost = route
 		klog.V(2).Infof("Discovered cluster-proxy route: %s", route)
 	} else {
 		klog.V(2).Info("Could not extract route host from response")
 	}
+	return route
 }
 
 // parseRouteHost extracts the host from a route JSON response
@@ -0,0 +1,152 @@
+package acm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/metrics"
+)
+
+// DefaultClusterCacheTTL is the time the list of managed clusters is cached for before it's retrieved again
+const DefaultClusterCacheTTL = 30 * time.Second
+
+// clusterCacheName is the cache label of the ClusterCache metrics
+const clusterCacheName = "acm_clusters"
+
+// maxClusterSuggestions is the maximum number of "did you mean" suggestions for an unknown cluster name
+const maxClusterSuggestions = 3
+
+// ClusterCache caches the ManagedCluster names visible to each bearer token.
+// It's shared across ProxyClients (one is created per tool call) to avoid listing the managed clusters on every call.
+type ClusterCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]clusterCacheEntry
+}
+
+type clusterCacheEntry struct {
+	clusters []string
+	expires  time.Time
+}
+
+// NewClusterCache creates an empty cache whose entries expire after the provided TTL
+func NewClusterCache(ttl time.Duration) *ClusterCache {
+	return &ClusterCache{
+		ttl:     ttl,
+		entries: make(map[string]clusterCacheEntry),
+	}
+}
+
+// get returns the cached clusters for the provided bearer token, loading them if missing or expired
+func (cc *ClusterCache) get(ctx context.Context, bearerToken string, load func(ctx context.Context) ([]string, error)) ([]string, error) {
+	hash := sha256.Sum256([]byte(bearerToken))
+	key := hex.EncodeToString(hash[:])
+	cc.mu.Lock()
+	entry, ok := cc.entries[key]
+	cc.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		metrics.CacheRequests.WithLabelValues(clusterCacheName, metrics.CacheHit).Inc()
+		return entry.clusters, nil
+	}
+	metrics.CacheRequests.WithLabelValues(clusterCacheName, metrics.CacheMiss).Inc()
+	clusters, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cc.mu.Lock()
+	cc.entries[key] = clusterCacheEntry{clusters: clusters, expires: time.Now().Add(cc.ttl)}
+	cc.mu.Unlock()
+	return clusters, nil
+}
+
+// WithClusterCache sets the cache used by ValidateClusterName
+func (c *ProxyClient) WithClusterCache(cache *ClusterCache) *ProxyClient {
+	c.clusters = cache
+	return c
+}
+
+// ValidateClusterName checks that the provided cluster is a known ManagedCluster before routing any request to it.
+// The returned error suggests similar cluster names in case of a typo.
+// If the managed clusters can't be listed (e.g. missing permissions), the validation is skipped and the proxy
+// route reports any problem instead.
+func (c *ProxyClient) ValidateClusterName(ctx context.Context, cluster string) error {
+	var clusters []string
+	var err error
+	if c.clusters != nil {
+		clusters, err = c.clusters.get(ctx, c.bearerToken, c.ListManagedClusters)
+	} else {
+		clusters, err = c.ListManagedClusters(ctx)
+	}
+	if err != nil {
+		klog.V(2).InfoS("Skipping managed cluster name validation", "cluster", cluster, "err", err)
+		return nil
+	}
+	if slices.Contains(clusters, cluster) {
+		return nil
+	}
+	if suggestions := SuggestClusters(cluster, clusters); len(suggestions) > 0 {
+		return fmt.Errorf("managed cluster %q not found, did you mean: %s?", cluster, strings.Join(suggestions, ", "))
+	}
+	if len(clusters) == 0 {
+		return fmt.Errorf("managed cluster %q not found, no managed clusters are available", cluster)
+	}
+	return fmt.Errorf("managed cluster %q not found, available managed clusters: %s", cluster, strings.Join(clusters, ", "))
+}
+
+// SuggestClusters returns the cluster names most similar to the provided (unknown) name, closest first
+func SuggestClusters(name string, clusters []string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+	lowerName := strings.ToLower(name)
+	// Allow roughly one typo every three characters
+	maxDistance := max(2, len(name)/3)
+	var candidates []candidate
+	for _, cluster := range clusters {
+		lowerCluster := strings.ToLower(cluster)
+		distance := levenshtein(lowerName, lowerCluster)
+		if distance > maxDistance && !strings.Contains(lowerCluster, lowerName) && !strings.Contains(lowerName, lowerCluster) {
+			continue
+		}
+		candidates = append(candidates, candidate{name: cluster, distance: distance})
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return a.distance - b.distance
+	})
+	suggestions := make([]string, 0, maxClusterSuggestions)
+	for i := 0; i < len(candidates) && i < maxClusterSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+// levenshtein computes the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,136 @@
+package acm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClustersSuite struct {
+	suite.Suite
+	server        *httptest.Server
+	listRequests  int
+	listForbidden bool
+	client        *ProxyClient
+}
+
+func (s *ClustersSuite) SetupTest() {
+	s.listRequests = 0
+	s.listForbidden = false
+	s.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/cluster.open-cluster-management.io/v1/managedclusters" {
+			http.NotFound(w, r)
+			return
+		}
+		s.listRequests++
+		if s.listForbidden {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"kind":"ManagedClusterList","items":[`+
+			`{"metadata":{"name":"local-cluster"}},`+
+			`{"metadata":{"name":"prod-east-1"}},`+
+			`{"metadata":{"name":"prod-west-1"}}]}`)
+	}))
+	s.client = &ProxyClient{
+		transports:  NewTransports(DefaultTransportOptions()),
+		serverURL:   s.server.URL,
+		bearerToken: "the-token",
+	}
+}
+
+func (s *ClustersSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ClustersSuite) TestListManagedClusters() {
+	clusters, err := s.client.ListManagedClusters(s.T().Context())
+	s.Require().NoError(err, "Expected no error listing managed clusters")
+	s.Equal([]string{"local-cluster", "prod-east-1", "prod-west-1"}, clusters)
+}
+
+func (s *ClustersSuite) TestValidateClusterName() {
+	s.Run("accepts known cluster", func() {
+		s.NoError(s.client.ValidateClusterName(s.T().Context(), "prod-east-1"))
+	})
+	s.Run("suggests similar clusters for typos", func() {
+		err := s.client.ValidateClusterName(s.T().Context(), "prod-est-1")
+		s.Require().Error(err)
+		s.Equal(`managed cluster "prod-est-1" not found, did you mean: prod-east-1, prod-west-1?`, err.Error())
+	})
+	s.Run("lists available clusters when nothing is similar", func() {
+		err := s.client.ValidateClusterName(s.T().Context(), "staging")
+		s.Require().Error(err)
+		s.Equal(`managed cluster "staging" not found, available managed clusters: local-cluster, prod-east-1, prod-west-1`, err.Error())
+	})
+	s.Run("skips validation when clusters can't be listed", func() {
+		s.listForbidden = true
+		s.NoError(s.client.ValidateClusterName(s.T().Context(), "staging"))
+	})
+}
+
+func (s *ClustersSuite) TestValidateClusterNameWithCache() {
+	s.client.WithClusterCache(NewClusterCache(time.Minute))
+	s.Require().NoError(s.client.ValidateClusterName(s.T().Context(), "prod-east-1"))
+	s.Require().NoError(s.client.ValidateClusterName(s.T().Context(), "prod-west-1"))
+	s.Run("lists managed clusters once", func() {
+		s.Equal(1, s.listRequests)
+	})
+}
+
+func (s *ClustersSuite) TestClusterCache() {
+	cache := NewClusterCache(time.Minute)
+	loads := 0
+	load := func(ctx context.Context) ([]string, error) {
+		loads++
+		return []string{"cluster-1"}, nil
+	}
+	s.Run("caches per bearer token", func() {
+		_, _ = cache.get(s.T().Context(), "token-1", load)
+		_, _ = cache.get(s.T().Context(), "token-1", load)
+		_, _ = cache.get(s.T().Context(), "token-2", load)
+		s.Equal(2, loads)
+	})
+	s.Run("does not cache errors", func() {
+		_, err := cache.get(s.T().Context(), "token-3", func(ctx context.Context) ([]string, error) {
+			return nil, errors.New("failed")
+		})
+		s.Error(err)
+		clusters, err := cache.get(s.T().Context(), "token-3", load)
+		s.NoError(err)
+		s.Equal([]string{"cluster-1"}, clusters)
+	})
+	s.Run("expires entries", func() {
+		expiring := NewClusterCache(0)
+		loads = 0
+		_, _ = expiring.get(s.T().Context(), "token-1", load)
+		_, _ = expiring.get(s.T().Context(), "token-1", load)
+		s.Equal(2, loads)
+	})
+}
+
+func (s *ClustersSuite) TestSuggestClusters() {
+	clusters := []string{"local-cluster", "prod-east-1", "prod-east-2", "prod-west-1", "dev"}
+	s.Run("suggests closest first", func() {
+		s.Equal([]string{"prod-east-1", "prod-east-2", "prod-west-1"}, SuggestClusters("prod-east1", clusters))
+	})
+	s.Run("is case insensitive", func() {
+		s.Equal([]string{"local-cluster"}, SuggestClusters("Local-Cluster", clusters)[:1])
+	})
+	s.Run("suggests clusters containing the name", func() {
+		s.Contains(SuggestClusters("local", clusters), "local-cluster")
+	})
+	s.Run("returns nothing for unrelated names", func() {
+		s.Empty(SuggestClusters("staging-europe", clusters))
+	})
+}
+
+func TestClusters(t *testing.T) {
+	suite.Run(t, new(ClustersSuite))
+}
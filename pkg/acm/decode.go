@@ -0,0 +1,110 @@
+package acm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+)
+
+// PruneFunc removes fields from a decoded object (or list item) in place
+type PruneFunc func(obj map[string]interface{})
+
+// PruneManagedFields drops metadata.managedFields, which are never displayed and usually account
+// for a large part of the size of each object
+func PruneManagedFields(obj map[string]interface{}) {
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "managedFields")
+	}
+}
+
+// DecodeObject decodes a Kubernetes JSON object or list from the provided reader without buffering the whole document.
+// List items are decoded and pruned one by one, so the memory used is bounded by the pruned result and the largest item.
+// Numbers are decoded following the Kubernetes conventions (int64 when possible, float64 otherwise).
+func DecodeObject(r io.Reader, prune PruneFunc) (map[string]interface{}, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected JSON token %v, expected object key", token)
+		}
+		if key == "items" {
+			items, err := decodeItems(dec, prune)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode items: %w", err)
+			}
+			obj[key] = items
+			continue
+		}
+		value, err := decodeValue(dec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		obj[key] = value
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	if _, isList := obj["items"]; !isList && prune != nil {
+		prune(obj)
+	}
+	return obj, nil
+}
+
+func decodeItems(dec *json.Decoder, prune PruneFunc) ([]interface{}, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	// items may be null for empty lists
+	if token == nil {
+		return []interface{}{}, nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("unexpected JSON token %v, expected [", token)
+	}
+	items := make([]interface{}, 0)
+	for dec.More() {
+		value, err := decodeValue(dec)
+		if err != nil {
+			return nil, err
+		}
+		if item, ok := value.(map[string]interface{}); ok && prune != nil {
+			prune(item)
+		}
+		items = append(items, value)
+	}
+	return items, expectDelim(dec, ']')
+}
+
+func decodeValue(dec *json.Decoder) (interface{}, error) {
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := utiljson.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func expectDelim(dec *json.Decoder, expected json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("unexpected JSON token %v, expected %s", token, expected)
+	}
+	return nil
+}
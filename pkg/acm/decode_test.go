@@ -0,0 +1,71 @@
+package acm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DecodeSuite struct {
+	suite.Suite
+}
+
+func (s *DecodeSuite) TestDecodeList() {
+	obj, err := DecodeObject(strings.NewReader(`{
+		"apiVersion": "v1",
+		"kind": "PodList",
+		"metadata": {"resourceVersion": "1337", "continue": "next"},
+		"items": [
+			{"metadata": {"name": "pod-1", "managedFields": [{"manager": "kubectl"}]}, "spec": {"priority": 1000}},
+			{"metadata": {"name": "pod-2"}, "status": {"ratio": 0.5}}
+		]
+	}`), PruneManagedFields)
+	s.Require().NoError(err, "Expected no error decoding list")
+	s.Run("decodes top level fields", func() {
+		s.Equal("PodList", obj["kind"])
+		s.Equal("next", obj["metadata"].(map[string]interface{})["continue"])
+	})
+	items := obj["items"].([]interface{})
+	s.Run("decodes all items", func() {
+		s.Len(items, 2)
+	})
+	s.Run("prunes items", func() {
+		s.NotContains(items[0].(map[string]interface{})["metadata"], "managedFields")
+	})
+	s.Run("decodes integers as int64", func() {
+		s.Equal(int64(1000), items[0].(map[string]interface{})["spec"].(map[string]interface{})["priority"])
+	})
+	s.Run("decodes decimals as float64", func() {
+		s.Equal(0.5, items[1].(map[string]interface{})["status"].(map[string]interface{})["ratio"])
+	})
+}
+
+func (s *DecodeSuite) TestDecodeObject() {
+	obj, err := DecodeObject(strings.NewReader(`{"kind":"Pod","metadata":{"name":"pod-1","managedFields":[{}]}}`), PruneManagedFields)
+	s.Require().NoError(err, "Expected no error decoding object")
+	s.Run("prunes the object", func() {
+		s.Equal(map[string]interface{}{"name": "pod-1"}, obj["metadata"])
+	})
+}
+
+func (s *DecodeSuite) TestDecodeNullItems() {
+	obj, err := DecodeObject(strings.NewReader(`{"kind":"PodList","items":null}`), nil)
+	s.Require().NoError(err, "Expected no error decoding list with null items")
+	s.Empty(obj["items"])
+}
+
+func (s *DecodeSuite) TestDecodeInvalid() {
+	s.Run("not an object", func() {
+		_, err := DecodeObject(strings.NewReader(`[]`), nil)
+		s.Error(err)
+	})
+	s.Run("truncated", func() {
+		_, err := DecodeObject(strings.NewReader(`{"kind":"PodList","items":[{"metadata":`), nil)
+		s.Error(err)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	suite.Run(t, new(DecodeSuite))
+}
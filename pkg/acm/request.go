@@ -0,0 +1,238 @@
+package acm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/metrics"
+	"github.com/containers/kubernetes-mcp-server/pkg/tracing"
+)
+
+// Request is a builder for a single call to a managed cluster API server through the cluster-proxy route.
+// It mirrors the shape of client-go's rest.Request so that any verb, path, query, header, and body combination
+// can be expressed without adding bespoke plumbing to the ProxyClient for every new operation.
+type Request struct {
+	client    *ProxyClient
+	cluster   string
+	verb      string
+	operation Operation
+	gvk       *schema.GroupVersionKind
+	path      string
+	params    url.Values
+	headers   http.Header
+	body      io.Reader
+	err       error
+}
+
+// NewRequest creates a GET request builder targeting the specified managed cluster
+func (c *ProxyClient) NewRequest(cluster string) *Request {
+	return &Request{
+		client:    c,
+		cluster:   cluster,
+		verb:      http.MethodGet,
+		operation: OperationDefault,
+		params:    url.Values{},
+		headers:   http.Header{},
+	}
+}
+
+// Verb sets the HTTP method of the request (GET, POST, PUT, PATCH, DELETE, ...)
+func (r *Request) Verb(verb string) *Request {
+	r.verb = strings.ToUpper(verb)
+	return r
+}
+
+// Operation sets the kind of call performed by the request, which determines its timeout
+func (r *Request) Operation(operation Operation) *Request {
+	r.operation = operation
+	return r
+}
+
+// Resource records the GroupVersionKind targeted by the request, used for tracing only
+func (r *Request) Resource(gvk *schema.GroupVersionKind) *Request {
+	r.gvk = gvk
+	return r
+}
+
+// AbsPath sets the Kubernetes API path of the request (e.g. /api/v1/namespaces/default/pods).
+// Any query string included in the path is merged into the request parameters.
+func (r *Request) AbsPath(path string) *Request {
+	u, err := url.Parse(path)
+	if err != nil {
+		r.err = fmt.Errorf("invalid proxy request path %s: %w", path, err)
+		return r
+	}
+	r.path = u.Path
+	for key, values := range u.Query() {
+		for _, value := range values {
+			r.params.Add(key, value)
+		}
+	}
+	return r
+}
+
+// Param adds a query parameter to the request, empty values are ignored
+func (r *Request) Param(name, value string) *Request {
+	if value != "" {
+		r.params.Add(name, value)
+	}
+	return r
+}
+
+// SetHeader sets a header of the request, replacing any previous value
+func (r *Request) SetHeader(key string, values ...string) *Request {
+	r.headers.Del(key)
+	for _, value := range values {
+		r.headers.Add(key, value)
+	}
+	return r
+}
+
+// Body sets the payload of the request
+func (r *Request) Body(body io.Reader) *Request {
+	r.body = body
+	return r
+}
+
+// URL returns the fully resolved cluster-proxy URL for the request
+func (r *Request) URL(ctx context.Context) (*url.URL, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	// Use dynamically discovered cluster-proxy route
+	routeHost := r.client.routeHost(ctx)
+	if routeHost == "" {
+		return nil, fmt.Errorf("cluster-proxy route not discovered - ensure ACM cluster-proxy addon is installed")
+	}
+	// Format: https://<route-host>/<clusterName><apiPath>
+	return &url.URL{
+		Scheme:   "https",
+		Host:     routeHost,
+		Path:     "/" + r.cluster + r.path,
+		RawQuery: r.params.Encode(),
+	}, nil
+}
+
+// StatusError is returned when the proxied request fails with a status code >= 400
+type StatusError struct {
+	Cluster    string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("ACM proxy returned %d for cluster %s: %s", e.StatusCode, e.Cluster, e.Body)
+}
+
+// Do performs the request and returns the raw response.
+// Responses with a status code >= 400 are consumed and returned as an error.
+func (r *Request) Do(ctx context.Context) (*http.Response, error) {
+	u, err := r.URL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := r.startSpan(ctx)
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, r.verb, u.String(), r.body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proxy request: %w", err)
+	}
+	for key, values := range r.headers {
+		req.Header[key] = values
+	}
+
+	// Set authentication header
+	r.client.authorize(req.Header)
+	req.Header.Set("User-Agent", "kubernetes-mcp-server/acm-proxy")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	if r.body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := r.client.transports.Client(r.cluster, r.operation).Do(req)
+	if err != nil {
+		r.trace(ctx, start, 0, err)
+		return nil, fmt.Errorf("ACM proxy request failed for cluster %s: %w", r.cluster, err)
+	}
+
+	// Check for HTTP errors
+	if resp.StatusCode >= 400 {
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		err = &StatusError{Cluster: r.cluster, StatusCode: resp.StatusCode, Body: string(body)}
+		r.trace(ctx, start, resp.StatusCode, err)
+		return nil, err
+	}
+
+	r.trace(ctx, start, resp.StatusCode, nil)
+	return resp, nil
+}
+
+// startSpan starts the span of the request, a child span of the tool call span in the provided context
+func (r *Request) startSpan(ctx context.Context) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		attribute.String("acm.cluster", r.cluster),
+		attribute.String("acm.operation", string(r.operation)),
+		attribute.String("http.request.method", r.verb),
+		attribute.String("url.path", r.path),
+	}
+	if r.gvk != nil {
+		attributes = append(attributes, attribute.String("k8s.gvk", r.gvk.String()))
+	}
+	return tracing.Tracer().Start(ctx, "acm-proxy "+r.verb, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+// trace logs the outcome of the request and records it in the metrics and in the request span,
+// failed requests are logged at a lower verbosity level than successful ones.
+// The latency covers the time until the response headers are received (the body is streamed afterward).
+func (r *Request) trace(ctx context.Context, start time.Time, status int, err error) {
+	keysAndValues := []any{
+		"requestID", RequestID(ctx),
+		"cluster", r.cluster,
+		"operation", r.operation,
+		"verb", r.verb,
+		"path", r.path,
+	}
+	if r.gvk != nil {
+		keysAndValues = append(keysAndValues, "gvk", r.gvk.String())
+	}
+	latency := time.Since(start)
+	code := "<error>"
+	if status > 0 {
+		code = strconv.Itoa(status)
+	}
+	metrics.ACMProxyRequests.WithLabelValues(string(r.operation), code).Inc()
+	metrics.ACMProxyRequestDuration.WithLabelValues(string(r.operation)).Observe(latency.Seconds())
+	keysAndValues = append(keysAndValues, "status", status, "latency", latency)
+	span := trace.SpanFromContext(ctx)
+	if status > 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		klog.V(2).InfoS("ACM proxy request failed", append(keysAndValues, "err", err)...)
+		return
+	}
+	klog.V(3).InfoS("ACM proxy request", keysAndValues...)
+}
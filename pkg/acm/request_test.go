@@ -0,0 +1,208 @@
+package acm
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/client-go/transport"
+)
+
+type RequestSuite struct {
+	suite.Suite
+	server   *httptest.Server
+	client   *ProxyClient
+	received *http.Request
+	body     string
+}
+
+func (s *RequestSuite) SetupTest() {
+	s.received = nil
+	s.body = ""
+	s.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.received = r
+		body, _ := io.ReadAll(r.Body)
+		s.body = string(body)
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			http.Error(w, `{"kind":"Status","code":404}`, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1"}`))
+	}))
+	serverURL, _ := url.Parse(s.server.URL)
+	s.client = &ProxyClient{
+		transports:     NewTransports(DefaultTransportOptions()),
+		serverURL:      s.server.URL,
+		bearerToken:    "the-token",
+		proxyRouteHost: serverURL.Host,
+	}
+}
+
+func (s *RequestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *RequestSuite) TestProxyRequestDefaults() {
+	resp, err := s.client.ProxyRequest(s.T().Context(), "managed-1", "/api/v1/namespaces/default/pods?labelSelector=app%3Dnginx")
+	s.Require().NoError(err, "Expected no error from ProxyRequest")
+	_ = resp.Body.Close()
+	s.Run("uses GET", func() {
+		s.Equal(http.MethodGet, s.received.Method)
+	})
+	s.Run("prefixes path with cluster name", func() {
+		s.Equal("/managed-1/api/v1/namespaces/default/pods", s.received.URL.Path)
+	})
+	s.Run("preserves query in path", func() {
+		s.Equal("app=nginx", s.received.URL.Query().Get("labelSelector"))
+	})
+	s.Run("sets bearer token", func() {
+		s.Equal("Bearer the-token", s.received.Header.Get("Authorization"))
+	})
+	s.Run("accepts json by default", func() {
+		s.Equal("application/json", s.received.Header.Get("Accept"))
+	})
+}
+
+func (s *RequestSuite) TestProxyRequestWithImpersonation() {
+	s.client.WithImpersonation(transport.ImpersonationConfig{
+		UserName: "alice@example.com",
+		Groups:   []string{"developers", "oidc:sre"},
+		Extra:    map[string][]string{"scopes.example.com/team": {"payments"}},
+	})
+	resp, err := s.client.NewRequest("managed-1").
+		AbsPath("/api/v1/namespaces/default/pods").
+		SetHeader("Impersonate-Group", "system:masters").
+		Do(s.T().Context())
+	s.Require().NoError(err, "Expected no error from Do")
+	_ = resp.Body.Close()
+	s.Run("keeps the bearer token", func() {
+		s.Equal("Bearer the-token", s.received.Header.Get("Authorization"))
+	})
+	s.Run("impersonates the user", func() {
+		s.Equal("alice@example.com", s.received.Header.Get("Impersonate-User"))
+	})
+	s.Run("impersonates only the groups of the user", func() {
+		s.Equal([]string{"developers", "oidc:sre"}, s.received.Header.Values("Impersonate-Group"))
+	})
+	s.Run("impersonates the extra of the user", func() {
+		s.Equal("payments", s.received.Header.Get("Impersonate-Extra-scopes.example.com%2fteam"))
+	})
+	s.Run("impersonates the user in the log requests", func() {
+		resp, err = s.client.ProxyLogRequest(s.T().Context(), "managed-1", "default", "web", "nginx", 10)
+		s.Require().NoError(err, "Expected no error from ProxyLogRequest")
+		_ = resp.Body.Close()
+		s.Equal("alice@example.com", s.received.Header.Get("Impersonate-User"))
+	})
+}
+
+func (s *RequestSuite) TestProxyRequestWithoutImpersonation() {
+	resp, err := s.client.ProxyRequest(s.T().Context(), "managed-1", "/api/v1")
+	s.Require().NoError(err, "Expected no error from ProxyRequest")
+	_ = resp.Body.Close()
+	s.Empty(s.received.Header.Get("Impersonate-User"))
+	s.Empty(s.received.Header.Values("Impersonate-Group"))
+}
+
+func (s *RequestSuite) TestNewRequestWithVerbBodyAndHeaders() {
+	resp, err := s.client.NewRequest("managed-1").
+		Verb("patch").
+		AbsPath("/apis/apps/v1/namespaces/default/deployments/web").
+		Param("fieldManager", "kubernetes-mcp-server").
+		Param("dryRun", "").
+		SetHeader("Content-Type", "application/merge-patch+json").
+		Body(strings.NewReader(`{"spec":{"replicas":3}}`)).
+		Do(s.T().Context())
+	s.Require().NoError(err, "Expected no error from Do")
+	_ = resp.Body.Close()
+	s.Run("uses provided verb", func() {
+		s.Equal(http.MethodPatch, s.received.Method)
+	})
+	s.Run("encodes params", func() {
+		s.Equal("fieldManager=kubernetes-mcp-server", s.received.URL.RawQuery)
+	})
+	s.Run("sets provided content type", func() {
+		s.Equal("application/merge-patch+json", s.received.Header.Get("Content-Type"))
+	})
+	s.Run("sends body", func() {
+		s.Equal(`{"spec":{"replicas":3}}`, s.body)
+	})
+}
+
+func (s *RequestSuite) TestNewRequestWithBodyDefaultsContentType() {
+	resp, err := s.client.NewRequest("managed-1").
+		Verb(http.MethodPost).
+		AbsPath("/api/v1/namespaces/default/configmaps").
+		Body(strings.NewReader(`{}`)).
+		Do(s.T().Context())
+	s.Require().NoError(err, "Expected no error from Do")
+	_ = resp.Body.Close()
+	s.Equal("application/json", s.received.Header.Get("Content-Type"))
+}
+
+func (s *RequestSuite) TestNewRequestWithErrorStatus() {
+	_, err := s.client.NewRequest("managed-1").AbsPath("/api/v1/namespaces/default/pods/missing").Do(s.T().Context())
+	s.Require().Error(err, "Expected error for 404 response")
+	s.Contains(err.Error(), "ACM proxy returned 404 for cluster managed-1")
+}
+
+func (s *RequestSuite) TestRequestTracing() {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+	resp, err := s.client.NewRequest("managed-1").AbsPath("/api/v1/namespaces/default/pods").Do(s.T().Context())
+	s.Require().NoError(err, "Expected no error from Do")
+	_ = resp.Body.Close()
+	traceparent := s.received.Header.Get("traceparent")
+	_, _ = s.client.NewRequest("managed-1").AbsPath("/api/v1/namespaces/default/pods/missing").Do(s.T().Context())
+	spans := recorder.Ended()
+	s.Require().Len(spans, 2, "Expected a span per request")
+	s.Run("records a span per request", func() {
+		s.Equal("acm-proxy GET", spans[0].Name())
+	})
+	s.Run("propagates the trace context", func() {
+		s.Contains(traceparent, spans[0].SpanContext().TraceID().String())
+	})
+	s.Run("records the failed requests", func() {
+		s.Equal(codes.Error, spans[1].Status().Code)
+	})
+}
+
+func (s *RequestSuite) TestNewRequestWithoutRoute() {
+	s.client.proxyRouteHost = ""
+	_, err := s.client.NewRequest("managed-1").AbsPath("/api/v1").Do(s.T().Context())
+	s.Require().Error(err, "Expected error when route is not discovered")
+	s.Contains(err.Error(), "cluster-proxy route not discovered")
+}
+
+func (s *RequestSuite) TestCheckProxyRoute() {
+	s.Run("reachable route", func() {
+		s.NoError(s.client.CheckProxyRoute(s.T().Context()))
+	})
+	s.Run("unreachable route", func() {
+		s.server.Close()
+		s.ErrorContains(s.client.CheckProxyRoute(s.T().Context()), "is not reachable")
+	})
+	s.Run("undiscovered route", func() {
+		s.client.proxyRouteHost = ""
+		s.ErrorContains(s.client.CheckProxyRoute(s.T().Context()), "cluster-proxy route not discovered")
+	})
+}
+
+func TestRequest(t *testing.T) {
+	suite.Run(t, new(RequestSuite))
+}
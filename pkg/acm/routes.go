@@ -0,0 +1,49 @@
+package acm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/metrics"
+)
+
+// routeCacheName is the cache label of the RouteCache metrics
+const routeCacheName = "acm_proxy_routes"
+
+// RouteCache caches the cluster-proxy route host discovered for each hub API server.
+// It's shared across ProxyClients (one is created per tool call) so that the route is discovered once instead of on
+// every call. Failed discoveries aren't cached, the route is discovered again by the next client.
+type RouteCache struct {
+	mu    sync.Mutex
+	hosts map[string]string
+}
+
+// NewRouteCache creates an empty route cache
+func NewRouteCache() *RouteCache {
+	return &RouteCache{hosts: make(map[string]string)}
+}
+
+// get returns the cached route host for the provided API server, discovering it if missing
+func (rc *RouteCache) get(ctx context.Context, serverURL string, discover func(ctx context.Context) string) string {
+	rc.mu.Lock()
+	host, ok := rc.hosts[serverURL]
+	rc.mu.Unlock()
+	if ok {
+		metrics.CacheRequests.WithLabelValues(routeCacheName, metrics.CacheHit).Inc()
+		return host
+	}
+	metrics.CacheRequests.WithLabelValues(routeCacheName, metrics.CacheMiss).Inc()
+	host = discover(ctx)
+	if host != "" {
+		rc.mu.Lock()
+		rc.hosts[serverURL] = host
+		rc.mu.Unlock()
+	}
+	return host
+}
+
+// WithRouteCache sets the cache of the discovered cluster-proxy routes
+func (c *ProxyClient) WithRouteCache(cache *RouteCache) *ProxyClient {
+	c.routes = cache
+	return c
+}
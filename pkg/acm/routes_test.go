@@ -0,0 +1,72 @@
+package acm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/client-go/transport"
+)
+
+type RoutesSuite struct {
+	suite.Suite
+	server      *httptest.Server
+	discoveries []*http.Request
+}
+
+func (s *RoutesSuite) SetupTest() {
+	s.discoveries = nil
+	s.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/route.openshift.io/v1/namespaces/multicluster-engine/routes/cluster-proxy-addon-user" {
+			http.NotFound(w, r)
+			return
+		}
+		s.discoveries = append(s.discoveries, r)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Route","spec":{"host":"cluster-proxy-user.apps.example.com"}}`))
+	}))
+}
+
+func (s *RoutesSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *RoutesSuite) TestRouteDiscovery() {
+	s.Run("is deferred to the first proxied request", func() {
+		NewProxyClient(s.server.URL, "the-token", nil)
+		s.Empty(s.discoveries)
+	})
+	s.Run("impersonates the identity of the client", func() {
+		client := NewProxyClient(s.server.URL, "the-token", nil).
+			WithImpersonation(transport.ImpersonationConfig{UserName: "alice", Groups: []string{"team-a"}})
+		s.Equal("cluster-proxy-user.apps.example.com", client.routeHost(s.T().Context()))
+		s.Require().Len(s.discoveries, 1)
+		s.Equal("Bearer the-token", s.discoveries[0].Header.Get("Authorization"))
+		s.Equal("alice", s.discoveries[0].Header.Get(transport.ImpersonateUserHeader))
+		s.Equal([]string{"team-a"}, s.discoveries[0].Header.Values(transport.ImpersonateGroupHeader))
+	})
+}
+
+func (s *RoutesSuite) TestRouteCache() {
+	routes := NewRouteCache()
+	for i := 0; i < 3; i++ {
+		client := NewProxyClient(s.server.URL, "the-token", nil).WithRouteCache(routes)
+		s.Equal("cluster-proxy-user.apps.example.com", client.routeHost(s.T().Context()))
+	}
+	s.Run("discovers the route once per server", func() {
+		s.Len(s.discoveries, 1)
+	})
+	s.Run("doesn't cache failed discoveries", func() {
+		unknown := httptest.NewTLSServer(http.NotFoundHandler())
+		defer unknown.Close()
+		s.Empty(NewProxyClient(unknown.URL, "the-token", nil).WithRouteCache(routes).routeHost(s.T().Context()))
+		routes.mu.Lock()
+		defer routes.mu.Unlock()
+		s.NotContains(routes.hosts, unknown.URL)
+	})
+}
+
+func TestRoutes(t *testing.T) {
+	suite.Run(t, new(RoutesSuite))
+}
@@ -0,0 +1,28 @@
+package acm
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type requestIDContextKey struct{}
+
+// WithRequestID returns a context carrying the provided request ID.
+// All the proxy requests performed with the returned context are traced with the same ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestID returns the request ID carried by the context or a new one if none is set
+func RequestID(ctx context.Context) string {
+	if requestID, ok := ctx.Value(requestIDContextKey{}).(string); ok && requestID != "" {
+		return requestID
+	}
+	return NewRequestID()
+}
+
+// NewRequestID generates a new random request ID
+func NewRequestID() string {
+	return uuid.NewString()
+}
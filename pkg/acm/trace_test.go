@@ -0,0 +1,21 @@
+package acm
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Run("returns the request ID from the context", func(t *testing.T) {
+		ctx := WithRequestID(context.Background(), "the-request-id")
+		if RequestID(ctx) != "the-request-id" {
+			t.Errorf("expected the-request-id, got %s", RequestID(ctx))
+		}
+	})
+	t.Run("generates a request ID when missing", func(t *testing.T) {
+		first, second := RequestID(context.Background()), RequestID(context.Background())
+		if first == "" || first == second {
+			t.Errorf("expected unique non-empty request IDs, got %s and %s", first, second)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package acm
+
+import (
+	"crypto/tls"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Operation identifies the kind of proxied call so that each one can use a suitable timeout
+type Operation string
+
+const (
+	// OperationDefault is used for get, create, update, and delete calls
+	OperationDefault Operation = "default"
+	// OperationList is used for (potentially large and paginated) list calls
+	OperationList Operation = "list"
+	// OperationLogs is used for pod log retrieval
+	OperationLogs Operation = "logs"
+	// OperationWatch is used for long-running watch calls
+	OperationWatch Operation = "watch"
+)
+
+// hubCluster is the key of the transport used for requests to the hub API server (not proxied)
+const hubCluster = ""
+
+// TransportOptions tunes the HTTP transports and timeouts of the ACM proxy client
+type TransportOptions struct {
+	// MaxIdleConnsPerHost is the maximum number of idle (keep-alive) connections kept per managed cluster
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is the maximum amount of time an idle connection remains open
+	IdleConnTimeout time.Duration
+	// DisableHTTP2 forces HTTP/1.1 (HTTP/2 is negotiated by default)
+	DisableHTTP2 bool
+	// Timeouts per operation type, a zero value means no timeout
+	Timeouts map[Operation]time.Duration
+}
+
+// DefaultTransportOptions returns the TransportOptions used when none are provided
+func DefaultTransportOptions() TransportOptions {
+	return TransportOptions{
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+		Timeouts: map[Operation]time.Duration{
+			OperationDefault: 30 * time.Second,
+			OperationList:    60 * time.Second,
+			OperationLogs:    120 * time.Second,
+			OperationWatch:   0,
+		},
+	}
+}
+
+// Transports keeps a pooled HTTP transport per managed cluster.
+// It's meant to be shared across ProxyClients (one is created per tool call) so that connections are reused,
+// while a slow or unresponsive managed cluster can't exhaust the connections available to the others.
+type Transports struct {
+	options    TransportOptions
+	mu         sync.Mutex
+	transports map[string]*http.Transport
+}
+
+// NewTransports creates an empty transport pool with the provided options
+func NewTransports(options TransportOptions) *Transports {
+	return &Transports{
+		options:    options,
+		transports: make(map[string]*http.Transport),
+	}
+}
+
+// ForCluster returns the pooled transport for the provided managed cluster, creating it if needed
+func (t *Transports) ForCluster(cluster string) *http.Transport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if transport, ok := t.transports[cluster]; ok {
+		return transport
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: true, // ACM typically uses self-signed certs
+	}
+	transport.MaxIdleConnsPerHost = t.options.MaxIdleConnsPerHost
+	transport.IdleConnTimeout = t.options.IdleConnTimeout
+	// A custom TLSClientConfig disables the automatic HTTP/2 upgrade unless explicitly requested
+	transport.ForceAttemptHTTP2 = !t.options.DisableHTTP2
+	if t.options.DisableHTTP2 {
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	t.transports[cluster] = transport
+	return transport
+}
+
+// Client returns an HTTP client for the provided managed cluster and operation
+func (t *Transports) Client(cluster string, operation Operation) *http.Client {
+	return &http.Client{
+		Transport: t.ForCluster(cluster),
+		Timeout:   t.options.Timeouts[operation],
+	}
+}
+
+// CloseIdleConnections closes the idle connections of every pooled transport
+func (t *Transports) CloseIdleConnections() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, transport := range t.transports {
+		transport.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,57 @@
+package acm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TransportsSuite struct {
+	suite.Suite
+}
+
+func (s *TransportsSuite) TestForCluster() {
+	transports := NewTransports(DefaultTransportOptions())
+	s.Run("reuses transport for the same cluster", func() {
+		s.Same(transports.ForCluster("managed-1"), transports.ForCluster("managed-1"))
+	})
+	s.Run("uses a different transport per cluster", func() {
+		s.NotSame(transports.ForCluster("managed-1"), transports.ForCluster("managed-2"))
+	})
+	s.Run("applies connection pool settings", func() {
+		transport := transports.ForCluster("managed-1")
+		s.Equal(10, transport.MaxIdleConnsPerHost)
+		s.Equal(90*time.Second, transport.IdleConnTimeout)
+		s.True(transport.TLSClientConfig.InsecureSkipVerify)
+	})
+	s.Run("enables HTTP/2 by default", func() {
+		s.True(transports.ForCluster("managed-1").ForceAttemptHTTP2)
+	})
+}
+
+func (s *TransportsSuite) TestForClusterWithHTTP2Disabled() {
+	options := DefaultTransportOptions()
+	options.DisableHTTP2 = true
+	transport := NewTransports(options).ForCluster("managed-1")
+	s.False(transport.ForceAttemptHTTP2)
+	s.NotNil(transport.TLSNextProto, "Expected empty TLSNextProto to disable HTTP/2")
+	s.Empty(transport.TLSNextProto)
+}
+
+func (s *TransportsSuite) TestClient() {
+	transports := NewTransports(DefaultTransportOptions())
+	s.Run("uses pooled transport", func() {
+		s.Same(transports.ForCluster("managed-1"), transports.Client("managed-1", OperationList).Transport)
+	})
+	s.Run("applies timeout per operation", func() {
+		s.Equal(30*time.Second, transports.Client("managed-1", OperationDefault).Timeout)
+		s.Equal(60*time.Second, transports.Client("managed-1", OperationList).Timeout)
+		s.Equal(120*time.Second, transports.Client("managed-1", OperationLogs).Timeout)
+		s.Zero(transports.Client("managed-1", OperationWatch).Timeout)
+	})
+}
+
+func TestTransports(t *testing.T) {
+	suite.Run(t, new(TransportsSuite))
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// ArgumentError is the uniform error returned when the arguments of a tool call don't match its input schema
+type ArgumentError struct {
+	Argument string
+	// Reason the argument is invalid, empty if the argument is missing
+	Reason string
+}
+
+func (e *ArgumentError) Error() string {
+	if e.Reason == "" {
+		return "missing argument " + e.Argument
+	}
+	return fmt.Sprintf("invalid argument %s: %s", e.Argument, e.Reason)
+}
+
+// BindArguments decodes the arguments of the tool call into target, a pointer to a struct with json tags.
+// The arguments are validated against the input schema of the tool (required properties, types, and enums)
+// and the defaults of the schema are applied to the missing ones.
+// Null arguments, and empty strings for required arguments, are considered missing.
+func BindArguments(params ToolHandlerParams, target any) error {
+	var schema *jsonschema.Schema
+	if params.Tool != nil {
+		schema = params.Tool.Tool.InputSchema
+	}
+	return bindArguments(schema, params.GetArguments(), target)
+}
+
+func bindArguments(schema *jsonschema.Schema, arguments map[string]any, target any) error {
+	args := make(map[string]any, len(arguments))
+	for name, value := range arguments {
+		if value != nil {
+			args[name] = value
+		}
+	}
+	if schema != nil {
+		for _, name := range schema.Required {
+			if value, ok := args[name]; !ok || value == "" {
+				return &ArgumentError{Argument: name}
+			}
+		}
+		for name, property := range schema.Properties {
+			value, ok := args[name]
+			if !ok {
+				if len(property.Default) > 0 {
+					var defaultValue any
+					if err := json.Unmarshal(property.Default, &defaultValue); err != nil {
+						return fmt.Errorf("invalid default for argument %s: %w", name, err)
+					}
+					args[name] = defaultValue
+				}
+				continue
+			}
+			if err := validateArgument(name, property, value); err != nil {
+				return err
+			}
+		}
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("failed to serialize arguments: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(target); err != nil {
+		var typeError *json.UnmarshalTypeError
+		if errors.As(err, &typeError) {
+			return &ArgumentError{Argument: typeError.Field, Reason: fmt.Sprintf("expected %s, got %s", typeError.Type, typeError.Value)}
+		}
+		return fmt.Errorf("failed to decode arguments: %w", err)
+	}
+	return nil
+}
+
+func validateArgument(name string, property *jsonschema.Schema, value any) error {
+	if property.Type != "" && !isType(property.Type, value) {
+		return &ArgumentError{Argument: name, Reason: fmt.Sprintf("expected %s, got %s", property.Type, typeName(value))}
+	}
+	if len(property.Enum) > 0 && !slices.ContainsFunc(property.Enum, func(e any) bool { return equalValues(e, value) }) {
+		allowed := make([]string, 0, len(property.Enum))
+		for _, e := range property.Enum {
+			allowed = append(allowed, fmt.Sprint(e))
+		}
+		return &ArgumentError{Argument: name, Reason: fmt.Sprintf("expected one of [%s], got %v", strings.Join(allowed, ", "), value)}
+	}
+	return nil
+}
+
+// isType returns whether the value decoded from JSON (or provided natively by an in-process caller) matches the schema type
+func isType(schemaType string, value any) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		switch v := value.(type) {
+		case float64:
+			return v == math.Trunc(v)
+		case json.Number:
+			_, err := v.Int64()
+			return err == nil
+		case int, int32, int64:
+			return true
+		}
+		return false
+	case "number":
+		switch value.(type) {
+		case float64, float32, json.Number, int, int32, int64:
+			return true
+		}
+		return false
+	case "array":
+		_, ok := value.([]any)
+		if !ok {
+			_, ok = value.([]string)
+		}
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	}
+	return true
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, float32, json.Number, int, int32, int64:
+		return "number"
+	case []any, []string:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func equalValues(a, b any) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
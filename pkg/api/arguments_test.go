@@ -0,0 +1,122 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/suite"
+)
+
+type ArgumentsSuite struct {
+	suite.Suite
+	tool *ServerTool
+}
+
+type testArgs struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Tail      int64    `json:"tail"`
+	Previous  bool     `json:"previous"`
+	Output    string   `json:"output"`
+	Command   []string `json:"command"`
+}
+
+func (s *ArgumentsSuite) SetupTest() {
+	s.tool = &ServerTool{Tool: Tool{
+		Name: "test_tool",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"namespace": {Type: "string"},
+				"name":      {Type: "string"},
+				"tail":      {Type: "integer", Default: ToRawMessage(100)},
+				"previous":  {Type: "boolean"},
+				"output":    {Type: "string", Enum: []any{"yaml", "table"}, Default: ToRawMessage("table")},
+				"command":   {Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+			},
+			Required: []string{"name"},
+		},
+	}}
+}
+
+func (s *ArgumentsSuite) bind(arguments map[string]any) (testArgs, error) {
+	args := testArgs{}
+	err := BindArguments(ToolHandlerParams{Tool: s.tool, ToolCallRequest: toolCallRequest(arguments)}, &args)
+	return args, err
+}
+
+func (s *ArgumentsSuite) TestBindArguments() {
+	s.Run("decodes arguments", func() {
+		args, err := s.bind(map[string]any{"namespace": "ns-1", "name": "pod-1", "tail": float64(10), "previous": true, "command": []any{"ls", "-l"}})
+		s.Require().NoError(err)
+		s.Equal(testArgs{Namespace: "ns-1", Name: "pod-1", Tail: 10, Previous: true, Output: "table", Command: []string{"ls", "-l"}}, args)
+	})
+	s.Run("applies defaults to missing arguments", func() {
+		args, err := s.bind(map[string]any{"name": "pod-1"})
+		s.Require().NoError(err)
+		s.Equal(int64(100), args.Tail)
+		s.Equal("table", args.Output)
+	})
+	s.Run("applies defaults to null arguments", func() {
+		args, err := s.bind(map[string]any{"name": "pod-1", "tail": nil})
+		s.Require().NoError(err)
+		s.Equal(int64(100), args.Tail)
+	})
+	s.Run("accepts native integers", func() {
+		args, err := s.bind(map[string]any{"name": "pod-1", "tail": 5})
+		s.Require().NoError(err)
+		s.Equal(int64(5), args.Tail)
+	})
+	s.Run("decodes without schema", func() {
+		args := testArgs{}
+		err := BindArguments(ToolHandlerParams{ToolCallRequest: toolCallRequest{"name": "pod-1"}}, &args)
+		s.Require().NoError(err)
+		s.Equal("pod-1", args.Name)
+	})
+}
+
+func (s *ArgumentsSuite) TestBindArgumentsValidation() {
+	s.Run("fails for missing required argument", func() {
+		_, err := s.bind(map[string]any{"namespace": "ns-1"})
+		s.Require().Error(err)
+		s.Equal("missing argument name", err.Error())
+		var argumentError *ArgumentError
+		s.Require().True(errors.As(err, &argumentError))
+		s.Equal("name", argumentError.Argument)
+	})
+	s.Run("fails for null required argument", func() {
+		_, err := s.bind(map[string]any{"name": nil})
+		s.Require().Error(err)
+		s.Equal("missing argument name", err.Error())
+	})
+	s.Run("fails for empty required argument", func() {
+		_, err := s.bind(map[string]any{"name": ""})
+		s.Require().Error(err)
+		s.Equal("missing argument name", err.Error())
+	})
+	s.Run("fails for invalid type", func() {
+		_, err := s.bind(map[string]any{"name": "pod-1", "tail": "invalid"})
+		s.Require().Error(err)
+		s.Equal("invalid argument tail: expected integer, got string", err.Error())
+	})
+	s.Run("fails for non integral number", func() {
+		_, err := s.bind(map[string]any{"name": "pod-1", "tail": 1.5})
+		s.Require().Error(err)
+		s.Equal("invalid argument tail: expected integer, got number", err.Error())
+	})
+	s.Run("fails for value not in enum", func() {
+		_, err := s.bind(map[string]any{"name": "pod-1", "output": "json"})
+		s.Require().Error(err)
+		s.Equal("invalid argument output: expected one of [yaml, table], got json", err.Error())
+	})
+	s.Run("fails for invalid array items", func() {
+		_, err := s.bind(map[string]any{"name": "pod-1", "command": []any{"ls", float64(1)}})
+		s.Require().Error(err)
+		s.Equal("invalid argument command.1: expected string, got number", err.Error())
+	})
+}
+
+func TestArguments(t *testing.T) {
+	suite.Run(t, new(ArgumentsSuite))
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/acm"
+	"github.com/containers/kubernetes-mcp-server/pkg/ratelimit"
+)
+
+// ErrorCode is the machine-readable classification of a failed tool call.
+// Agent frameworks can rely on it (along with the retryable flag) to implement retry and escalation policies.
+type ErrorCode string
+
+const (
+	// ErrorCodeNotFound the requested resource (or cluster) doesn't exist
+	ErrorCodeNotFound ErrorCode = "NotFound"
+	// ErrorCodeForbidden the current identity is not allowed to perform the operation
+	ErrorCodeForbidden ErrorCode = "Forbidden"
+	// ErrorCodeConflict the operation conflicts with the current state of the resource (e.g. already exists, stale version)
+	ErrorCodeConflict ErrorCode = "Conflict"
+	// ErrorCodeClusterUnreachable the Kubernetes API server (or the ACM cluster proxy) can't be reached
+	ErrorCodeClusterUnreachable ErrorCode = "ClusterUnreachable"
+	// ErrorCodeTimeout the operation didn't complete in time
+	ErrorCodeTimeout ErrorCode = "Timeout"
+	// ErrorCodeValidationFailed the tool arguments or the submitted resources are invalid
+	ErrorCodeValidationFailed ErrorCode = "ValidationFailed"
+	// ErrorCodeRateLimited the tool call (or the Kubernetes API request) was throttled
+	ErrorCodeRateLimited ErrorCode = "RateLimited"
+	// ErrorCodeUnavailable the server is shutting down and doesn't accept new tool calls
+	ErrorCodeUnavailable ErrorCode = "Unavailable"
+	// ErrorCodeUnknown the error could not be classified
+	ErrorCodeUnknown ErrorCode = "Unknown"
+)
+
+// ToolError is an error with an explicit classification, for errors that can't be classified from their type.
+type ToolError struct {
+	Code      ErrorCode
+	Retryable bool
+	Err       error
+}
+
+// NewToolError wraps the provided error with an explicit classification
+func NewToolError(code ErrorCode, retryable bool, err error) *ToolError {
+	return &ToolError{Code: code, Retryable: retryable, Err: err}
+}
+
+func (e *ToolError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ToolError) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError returns the error code of the provided error and whether the failed operation can be retried as is.
+// Errors must be wrapped with %w for the underlying Kubernetes API errors to be classified.
+func ClassifyError(err error) (ErrorCode, bool) {
+	if err == nil {
+		return "", false
+	}
+	var toolError *ToolError
+	if errors.As(err, &toolError) {
+		return toolError.Code, toolError.Retryable
+	}
+	var argumentError *ArgumentError
+	if errors.As(err, &argumentError) {
+		return ErrorCodeValidationFailed, false
+	}
+	var exceededError *ratelimit.ExceededError
+	if errors.As(err, &exceededError) {
+		return ErrorCodeRateLimited, true
+	}
+	var statusError *acm.StatusError
+	if errors.As(err, &statusError) {
+		return classifyStatusCode(statusError.StatusCode)
+	}
+	switch {
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		return ErrorCodeNotFound, false
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return ErrorCodeForbidden, false
+	case apierrors.IsConflict(err):
+		return ErrorCodeConflict, true
+	case apierrors.IsAlreadyExists(err):
+		return ErrorCodeConflict, false
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err), apierrors.IsMethodNotSupported(err), apierrors.IsNotAcceptable(err):
+		return ErrorCodeValidationFailed, false
+	case apierrors.IsTooManyRequests(err):
+		return ErrorCodeRateLimited, true
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeTimeout, true
+	case apierrors.IsServiceUnavailable(err):
+		return ErrorCodeClusterUnreachable, true
+	}
+	var netError net.Error
+	if errors.As(err, &netError) && netError.Timeout() {
+		return ErrorCodeTimeout, true
+	}
+	var dnsError *net.DNSError
+	var opError *net.OpError
+	if errors.As(err, &dnsError) || errors.As(err, &opError) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return ErrorCodeClusterUnreachable, true
+	}
+	if _, retry := apierrors.SuggestsClientDelay(err); retry || apierrors.IsInternalError(err) {
+		return ErrorCodeUnknown, true
+	}
+	return ErrorCodeUnknown, false
+}
+
+// classifyStatusCode classifies the HTTP status code of a failed (proxied) Kubernetes API request
+func classifyStatusCode(statusCode int) (ErrorCode, bool) {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusMethodNotAllowed, http.StatusNotAcceptable, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity:
+		return ErrorCodeValidationFailed, false
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrorCodeForbidden, false
+	case http.StatusNotFound:
+		return ErrorCodeNotFound, false
+	case http.StatusConflict:
+		return ErrorCodeConflict, true
+	case http.StatusTooManyRequests:
+		return ErrorCodeRateLimited, true
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return ErrorCodeClusterUnreachable, true
+	case http.StatusGatewayTimeout:
+		return ErrorCodeTimeout, true
+	}
+	return ErrorCodeUnknown, statusCode >= http.StatusInternalServerError
+}
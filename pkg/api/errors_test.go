@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/acm"
+	"github.com/containers/kubernetes-mcp-server/pkg/ratelimit"
+)
+
+type ErrorsSuite struct {
+	suite.Suite
+}
+
+func (s *ErrorsSuite) TestClassifyError() {
+	configMaps := schema.GroupResource{Resource: "configmaps"}
+	cases := []struct {
+		name      string
+		err       error
+		code      ErrorCode
+		retryable bool
+	}{
+		{"not found", apierrors.NewNotFound(configMaps, "a-cm"), ErrorCodeNotFound, false},
+		{"forbidden", apierrors.NewForbidden(configMaps, "a-cm", errors.New("denied")), ErrorCodeForbidden, false},
+		{"unauthorized", apierrors.NewUnauthorized("expired token"), ErrorCodeForbidden, false},
+		{"conflict", apierrors.NewConflict(configMaps, "a-cm", errors.New("stale")), ErrorCodeConflict, true},
+		{"already exists", apierrors.NewAlreadyExists(configMaps, "a-cm"), ErrorCodeConflict, false},
+		{"invalid", apierrors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "a-cm", nil), ErrorCodeValidationFailed, false},
+		{"bad request", apierrors.NewBadRequest("bad"), ErrorCodeValidationFailed, false},
+		{"server timeout", apierrors.NewServerTimeout(configMaps, "get", 1), ErrorCodeTimeout, true},
+		{"context deadline", context.DeadlineExceeded, ErrorCodeTimeout, true},
+		{"service unavailable", apierrors.NewServiceUnavailable("down"), ErrorCodeClusterUnreachable, true},
+		{"too many requests", apierrors.NewTooManyRequests("slow down", 1), ErrorCodeRateLimited, true},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, ErrorCodeClusterUnreachable, true},
+		{"dns", &net.DNSError{Err: "no such host", Name: "api.example.com"}, ErrorCodeClusterUnreachable, true},
+		{"argument", &ArgumentError{Argument: "name"}, ErrorCodeValidationFailed, false},
+		{"rate limit", &ratelimit.ExceededError{Tool: "pods_list"}, ErrorCodeRateLimited, true},
+		{"proxy not found", &acm.StatusError{Cluster: "c1", StatusCode: 404}, ErrorCodeNotFound, false},
+		{"proxy bad gateway", &acm.StatusError{Cluster: "c1", StatusCode: 502}, ErrorCodeClusterUnreachable, true},
+		{"proxy internal error", &acm.StatusError{Cluster: "c1", StatusCode: 500}, ErrorCodeUnknown, true},
+		{"explicit", NewToolError(ErrorCodeForbidden, false, errors.New("read-only")), ErrorCodeForbidden, false},
+		{"unknown", errors.New("something went wrong"), ErrorCodeUnknown, false},
+	}
+	for _, c := range cases {
+		s.Run(c.name, func() {
+			code, retryable := ClassifyError(c.err)
+			s.Equal(c.code, code)
+			s.Equal(c.retryable, retryable)
+		})
+		s.Run(c.name+" wrapped", func() {
+			code, retryable := ClassifyError(fmt.Errorf("failed to get resource: %w", c.err))
+			s.Equal(c.code, code)
+			s.Equal(c.retryable, retryable)
+		})
+	}
+	s.Run("nil error is not classified", func() {
+		code, retryable := ClassifyError(nil)
+		s.Empty(code)
+		s.False(retryable)
+	})
+}
+
+func (s *ErrorsSuite) TestNewToolCallResult() {
+	s.Run("classifies the error", func() {
+		result := NewToolCallResult("", fmt.Errorf("failed to delete resource: %w", apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "a-pod")))
+		s.Equal(ErrorCodeNotFound, result.ErrorCode)
+		s.False(result.Retryable)
+	})
+	s.Run("successful result has no error code", func() {
+		result := NewToolCallResult("ok", nil)
+		s.Empty(result.ErrorCode)
+	})
+	s.Run("explicit classification preserves the error message", func() {
+		result := NewToolCallResult("", NewToolError(ErrorCodeTimeout, true, errors.New("timed out")))
+		s.EqualError(result.Error, "timed out")
+		s.Equal(ErrorCodeTimeout, result.ErrorCode)
+		s.True(result.Retryable)
+	})
+}
+
+func TestErrors(t *testing.T) {
+	suite.Run(t, new(ErrorsSuite))
+}
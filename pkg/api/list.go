@@ -0,0 +1,59 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// ListArgs are the field selector, pagination, and sort arguments of the list tools (see WithListProperties)
+type ListArgs struct {
+	FieldSelector string `json:"fieldSelector"`
+	Limit         int64  `json:"limit"`
+	Continue      string `json:"continue"`
+	SortBy        string `json:"sortBy"`
+}
+
+// WithListProperties adds the fieldSelector, limit, continue, and sortBy properties of ListArgs to the input schema properties of a list tool
+func WithListProperties(properties map[string]*jsonschema.Schema) map[string]*jsonschema.Schema {
+	properties["fieldSelector"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Optional Kubernetes field selector (e.g. 'status.phase=Running' or 'spec.nodeName=node-1,metadata.namespace!=default'), the supported fields depend on the kind of the resources",
+	}
+	properties["limit"] = &jsonschema.Schema{
+		Type:        "integer",
+		Description: "Optional maximum number of resources to return, if there are more resources the output includes the continue token to retrieve the next page",
+		Minimum:     ptr.To(1.0),
+	}
+	properties["continue"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Optional continue token returned by a previous call with a limit, to retrieve the next page (the other arguments must be the same)",
+	}
+	sortBy := make([]any, 0, len(internalk8s.SortByValues))
+	for _, value := range internalk8s.SortByValues {
+		sortBy = append(sortBy, value)
+	}
+	properties["sortBy"] = &jsonschema.Schema{
+		Type: "string",
+		Description: "Optional sort order of the returned resources: name (namespace and name), age (newest first), or status (phase or status column). " +
+			"When a limit is provided, the sort applies to each page, the pages are returned in the server order",
+		Enum: sortBy,
+	}
+	return properties
+}
+
+// ResourceListOptions returns the list options with the arguments and the provided label selector
+func (a ListArgs) ResourceListOptions(labelSelector string, asTable bool) (internalk8s.ResourceListOptions, error) {
+	if a.Limit < 0 {
+		return internalk8s.ResourceListOptions{}, &ArgumentError{Argument: "limit", Reason: fmt.Sprintf("expected a positive integer, got %d", a.Limit)}
+	}
+	options := internalk8s.ResourceListOptions{AsTable: asTable, SortBy: a.SortBy}
+	options.LabelSelector = labelSelector
+	options.FieldSelector = a.FieldSelector
+	options.Limit = a.Limit
+	options.Continue = a.Continue
+	return options, nil
+}
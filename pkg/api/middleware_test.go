@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ToolMiddlewareSuite struct {
+	suite.Suite
+	calls []string
+}
+
+func (s *ToolMiddlewareSuite) SetupTest() {
+	s.calls = nil
+}
+
+func (s *ToolMiddlewareSuite) handler(params ToolHandlerParams) (*ToolCallResult, error) {
+	s.calls = append(s.calls, "handler")
+	return NewToolCallResult("handled", nil), nil
+}
+
+func (s *ToolMiddlewareSuite) recording(name string) ToolMiddleware {
+	return func(next ToolHandlerFunc) ToolHandlerFunc {
+		return func(params ToolHandlerParams) (*ToolCallResult, error) {
+			s.calls = append(s.calls, name+":before")
+			result, err := next(params)
+			s.calls = append(s.calls, name+":after")
+			return result, err
+		}
+	}
+}
+
+func (s *ToolMiddlewareSuite) TestChainToolMiddleware() {
+	s.Run("without middlewares invokes handler", func() {
+		s.calls = nil
+		result, err := ChainToolMiddleware(s.handler)(ToolHandlerParams{ToolCallRequest: toolCallRequest{}})
+		s.Require().NoError(err)
+		s.Equal("handled", result.Content)
+		s.Equal([]string{"handler"}, s.calls)
+	})
+	s.Run("invokes middlewares in order, first is outermost", func() {
+		s.calls = nil
+		handler := ChainToolMiddleware(s.handler, s.recording("first"), s.recording("second"))
+		result, err := handler(ToolHandlerParams{ToolCallRequest: toolCallRequest{}})
+		s.Require().NoError(err)
+		s.Equal("handled", result.Content)
+		s.Equal([]string{"first:before", "second:before", "handler", "second:after", "first:after"}, s.calls)
+	})
+	s.Run("middleware can short-circuit the call", func() {
+		s.calls = nil
+		reject := func(next ToolHandlerFunc) ToolHandlerFunc {
+			return func(params ToolHandlerParams) (*ToolCallResult, error) {
+				return NewToolCallResult("", errors.New("rejected")), nil
+			}
+		}
+		handler := ChainToolMiddleware(s.handler, s.recording("first"), reject, s.recording("last"))
+		result, err := handler(ToolHandlerParams{ToolCallRequest: toolCallRequest{}})
+		s.Require().NoError(err)
+		s.EqualError(result.Error, "rejected")
+		s.Equal([]string{"first:before", "first:after"}, s.calls)
+	})
+}
+
+func TestToolMiddleware(t *testing.T) {
+	suite.Run(t, new(ToolMiddlewareSuite))
+}
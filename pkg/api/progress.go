@@ -0,0 +1,15 @@
+package api
+
+import "context"
+
+// Progress notifies the MCP client of the progress of a long-running tool call (MCP progress notifications)
+type Progress interface {
+	Notify(ctx context.Context, progress, total float64, message string)
+}
+
+// NotifyProgress notifies the client of the progress of the tool call, nothing is sent if the client didn't request it
+func (p ToolHandlerParams) NotifyProgress(progress, total float64, message string) {
+	if p.Progress != nil {
+		p.Progress.Notify(p.Context, progress, total, message)
+	}
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SummarizeArgument is the standard argument of the tools returning large payloads (e.g. logs and events) that can be
+// summarized by the LLM of the client
+const SummarizeArgument = "summarize"
+
+// SummaryChunkSize is the maximum size of the records summarized by each sampling request
+const SummaryChunkSize = 32 * 1024
+
+// summaryMaxTokens is the maximum number of tokens of the summary of each chunk
+const summaryMaxTokens = 512
+
+// Sampler requests a completion from the LLM of the MCP client (MCP sampling)
+type Sampler interface {
+	Sample(ctx context.Context, systemPrompt, prompt string, maxTokens int) (string, error)
+}
+
+// SummaryChunk is the summary of the records From-To (1-based, inclusive)
+type SummaryChunk struct {
+	From    int    `json:"from"`
+	To      int    `json:"to"`
+	Summary string `json:"summary"`
+}
+
+// Summarize asks the LLM of the client to summarize the records (e.g. the lines of a log or the events) in chunks of
+// at most SummaryChunkSize bytes, so that the payload never exceeds the context of the client LLM.
+// Each summary is returned with the offsets of its records so that the raw records can be retrieved.
+func Summarize(params ToolHandlerParams, kind string, records []string) ([]SummaryChunk, error) {
+	if params.Sampler == nil {
+		return nil, NewToolError(ErrorCodeValidationFailed, false,
+			errors.New("the client doesn't support MCP sampling, call the tool again without the summarize argument"))
+	}
+	systemPrompt := fmt.Sprintf("You summarize Kubernetes %s for a site reliability engineer. "+
+		"Report the errors, warnings, and anomalies with their timestamps and the affected objects, concisely. "+
+		"Only report what is in the %s, don't speculate.", kind, kind)
+	var chunks []SummaryChunk
+	for from := 0; from < len(records); {
+		to, size := from, 0
+		for to < len(records) && (to == from || size+len(records[to]) < SummaryChunkSize) {
+			size += len(records[to]) + 1
+			to++
+		}
+		summary, err := params.Sampler.Sample(params.Context, systemPrompt, strings.Join(records[from:to], "\n"), summaryMaxTokens)
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize %s %d-%d: %w", kind, from+1, to, err)
+		}
+		chunks = append(chunks, SummaryChunk{From: from + 1, To: to, Summary: strings.TrimSpace(summary)})
+		from = to
+	}
+	return chunks, nil
+}
+
+// SummaryContent renders the summaries as the content of a tool call result, each summary is headed by the offsets of
+// its records (e.g. "## Lines 1-350")
+func SummaryContent(header, records string, chunks []SummaryChunk) string {
+	content := strings.Builder{}
+	content.WriteString("# " + header + "\n")
+	for _, chunk := range chunks {
+		_, _ = fmt.Fprintf(&content, "## %s %d-%d\n%s\n", records, chunk.From, chunk.To, chunk.Summary)
+	}
+	return content.String()
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SummarizeSuite struct {
+	suite.Suite
+	sampler *testSampler
+}
+
+type testSampler struct {
+	prompts []string
+	err     error
+}
+
+func (ts *testSampler) Sample(_ context.Context, systemPrompt, prompt string, maxTokens int) (string, error) {
+	ts.prompts = append(ts.prompts, prompt)
+	return "summary of " + strings.SplitN(prompt, "\n", 2)[0] + "\n", ts.err
+}
+
+func (s *SummarizeSuite) SetupTest() {
+	s.sampler = &testSampler{}
+}
+
+func (s *SummarizeSuite) TestSummarize() {
+	s.Run("summarizes small payloads in a single request", func() {
+		chunks, err := Summarize(ToolHandlerParams{Context: s.T().Context(), Sampler: s.sampler}, "logs", []string{"line 1", "line 2"})
+		s.Require().NoError(err)
+		s.Equal([]SummaryChunk{{From: 1, To: 2, Summary: "summary of line 1"}}, chunks)
+		s.Equal([]string{"line 1\nline 2"}, s.sampler.prompts)
+	})
+	s.Run("summarizes large payloads in chunks with their offsets", func() {
+		s.sampler.prompts = nil
+		line := strings.Repeat("x", SummaryChunkSize/3)
+		chunks, err := Summarize(ToolHandlerParams{Context: s.T().Context(), Sampler: s.sampler}, "logs", []string{line, line, line, line})
+		s.Require().NoError(err)
+		s.Len(chunks, 2)
+		s.Equal(1, chunks[0].From)
+		s.Equal(2, chunks[0].To)
+		s.Equal(3, chunks[1].From)
+		s.Equal(4, chunks[1].To)
+		for _, prompt := range s.sampler.prompts {
+			s.LessOrEqual(len(prompt), SummaryChunkSize)
+		}
+	})
+	s.Run("summarizes records larger than a chunk on their own", func() {
+		chunks, err := Summarize(ToolHandlerParams{Context: s.T().Context(), Sampler: s.sampler}, "logs", []string{strings.Repeat("x", 2*SummaryChunkSize), "line 2"})
+		s.Require().NoError(err)
+		s.Equal([]SummaryChunk{{From: 1, To: 1, Summary: chunks[0].Summary}, {From: 2, To: 2, Summary: "summary of line 2"}}, chunks)
+	})
+	s.Run("fails without sampler", func() {
+		_, err := Summarize(ToolHandlerParams{Context: s.T().Context()}, "logs", []string{"line 1"})
+		var toolError *ToolError
+		s.Require().ErrorAs(err, &toolError)
+		s.Equal(ErrorCodeValidationFailed, toolError.Code)
+	})
+	s.Run("fails if the client fails to sample", func() {
+		s.sampler.err = errors.New("rejected by the user")
+		_, err := Summarize(ToolHandlerParams{Context: s.T().Context(), Sampler: s.sampler}, "events", []string{"event 1"})
+		s.EqualError(err, "failed to summarize events 1-1: rejected by the user")
+	})
+}
+
+func (s *SummarizeSuite) TestSummaryContent() {
+	content := SummaryContent("Summary of the logs", "Lines", []SummaryChunk{{From: 1, To: 10, Summary: "first"}, {From: 11, To: 12, Summary: "second"}})
+	s.Equal("# Summary of the logs\n## Lines 1-10\nfirst\n## Lines 11-12\nsecond\n", content)
+}
+
+func TestSummarize(t *testing.T) {
+	suite.Run(t, new(SummarizeSuite))
+}
@@ -1,23 +1,68 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/containers/kubernetes-mcp-server/pkg/acm"
 	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
 	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
 )
 
 type ServerTool struct {
 	Tool    Tool
 	Handler ToolHandlerFunc
+	// Kubernetes API accesses performed by the tool, used for the pre-flight authorization checks (optional)
+	Access []ResourceAccess
+	// DryRun is true if the tool accepts the standard DryRunArgument to preview its changes without persisting them
+	DryRun bool
+	// Timeout of the tool calls (optional, DefaultListTimeout for read-only tools and no timeout for the rest)
+	Timeout time.Duration
+	// AdvertisedAnnotations replace the Tool annotations advertised to the clients (optional, e.g. configured tool overrides).
+	// The server keeps enforcing the read-only, destructive, and confirmation checks with the Tool annotations.
+	AdvertisedAnnotations *ToolAnnotations
+}
+
+const (
+	// DefaultListTimeout is the default timeout of the read-only tools
+	DefaultListTimeout = 30 * time.Second
+	// DefaultLogsTimeout is the default timeout of the tools retrieving logs
+	DefaultLogsTimeout = 60 * time.Second
+	// DefaultExecTimeout is the default timeout of the tools executing commands
+	DefaultExecTimeout = 120 * time.Second
+)
+
+// DryRunArgument is the standard argument of the mutating tools that support server-side dry-run
+const DryRunArgument = "dryRun"
+
+// ResourceAccess describes a Kubernetes API access performed by a tool.
+// The namespace of the access is resolved from the namespace argument of the tool call.
+type ResourceAccess struct {
+	Verb        string
+	Group       string
+	Resource    string
+	Subresource string
+	// ClusterScoped is true for cluster-scoped resources (e.g. namespaces, nodes)
+	ClusterScoped bool
+	// AllNamespaces is true when the access spans all the namespaces unless a namespace argument is provided (e.g. list operations).
+	// Otherwise, the current namespace is used.
+	AllNamespaces bool
+	// Optional is true when the tool still works without the access (e.g. resources of optional APIs skipped when
+	// they can't be listed), optional accesses are not checked upfront
+	Optional bool
 }
 
 type Toolset interface {
@@ -29,6 +74,13 @@ type Toolset interface {
 	GetTools(o internalk8s.Openshift) []ServerTool
 }
 
+// ToolsetWithPrerequisites is implemented by the toolsets that require optional capabilities of the target cluster.
+// When capability detection is enabled, these toolsets are only advertised if all their prerequisites are available.
+type ToolsetWithPrerequisites interface {
+	Toolset
+	GetPrerequisites() []internalk8s.Capability
+}
+
 type ToolCallRequest interface {
 	GetArguments() map[string]any
 }
@@ -36,14 +88,37 @@ type ToolCallRequest interface {
 type ToolCallResult struct {
 	// Raw content returned by the tool.
 	Content string
+	// Structured content (a JSON object) returned by the tool alongside the raw content.
+	// It must conform to the OutputSchema of the tool.
+	StructuredContent any
 	// Error (non-protocol) to send back to the LLM.
 	Error error
+	// ErrorCode is the machine-readable classification of the Error.
+	ErrorCode ErrorCode
+	// Retryable is true if the failed tool call can be retried as is.
+	Retryable bool
 }
 
 func NewToolCallResult(content string, err error) *ToolCallResult {
+	errorCode, retryable := ClassifyError(err)
+	return &ToolCallResult{
+		Content:   content,
+		Error:     err,
+		ErrorCode: errorCode,
+		Retryable: retryable,
+	}
+}
+
+// NewStructuredToolCallResult returns a result with both the raw content (for clients without structured content support)
+// and the structured content for tools declaring an OutputSchema.
+func NewStructuredToolCallResult(content string, structuredContent any, err error) *ToolCallResult {
+	errorCode, retryable := ClassifyError(err)
 	return &ToolCallResult{
-		Content: content,
-		Error:   err,
+		Content:           content,
+		StructuredContent: structuredContent,
+		Error:             err,
+		ErrorCode:         errorCode,
+		Retryable:         retryable,
 	}
 }
 
@@ -51,14 +126,48 @@ type ToolHandlerParams struct {
 	context.Context
 	*internalk8s.Kubernetes
 	ToolCallRequest
+	// The tool being called, mostly useful for middlewares
+	Tool       *ServerTool
 	ListOutput output.Output
 	// Multi-cluster support
 	ACMProxyClient interface{} // ACM proxy client for multi-cluster operations
 	IsACMMode      bool        // Whether ACM multi-cluster mode is enabled
+	// Session of the tool call, nil for the transports without sessions (e.g. stateless streamable HTTP)
+	Session Session
+	// Sampler of the client LLM, nil if the client doesn't support MCP sampling
+	Sampler Sampler
+	// Progress of the tool call, nil if the client didn't request progress notifications
+	Progress Progress
+}
+
+// SessionDefaults are the argument values inherited by the tool calls of an MCP session that don't provide them
+type SessionDefaults struct {
+	Namespace string `json:"namespace,omitempty"`
+	Cluster   string `json:"cluster,omitempty"`
+	Output    string `json:"output,omitempty"`
+}
+
+// Session keeps the state of an MCP session across its tool calls
+type Session interface {
+	Defaults() SessionDefaults
+	SetDefaults(defaults SessionDefaults)
 }
 
 type ToolHandlerFunc func(params ToolHandlerParams) (*ToolCallResult, error)
 
+// ToolMiddleware wraps a ToolHandlerFunc to add behavior before and/or after the tool handler is invoked
+// (e.g. logging, metrics, authorization, rate limiting).
+// A middleware may short-circuit the call by returning a result without invoking the next handler.
+type ToolMiddleware func(next ToolHandlerFunc) ToolHandlerFunc
+
+// ChainToolMiddleware composes the middlewares around the handler, the first middleware is the outermost one
+func ChainToolMiddleware(handler ToolHandlerFunc, middlewares ...ToolMiddleware) ToolHandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
 type Tool struct {
 	// The name of the tool.
 	// Intended for programmatic or logical use, but used as a display name in past
@@ -73,6 +182,9 @@ type Tool struct {
 	Annotations ToolAnnotations `json:"annotations"`
 	// A JSON Schema object defining the expected parameters for the tool.
 	InputSchema *jsonschema.Schema
+	// An optional JSON Schema object defining the structure of the tool's structured content.
+	// The schema must be of type object.
+	OutputSchema *jsonschema.Schema
 }
 
 type ToolAnnotations struct {
@@ -96,6 +208,13 @@ type ToolAnnotations struct {
 	OpenWorldHint *bool `json:"openWorldHint,omitempty"`
 }
 
+const (
+	// ProxyListPageSize is the number of items requested per page when listing resources through the ACM proxy
+	ProxyListPageSize int64 = 500
+	// ProxyListMaxPages is the maximum number of pages followed when listing resources through the ACM proxy
+	ProxyListMaxPages = 20
+)
+
 // GetClusterParameter extracts the optional cluster parameter from tool arguments
 func GetClusterParameter(params ToolHandlerParams) (string, bool) {
 	args := params.GetArguments()
@@ -124,6 +243,16 @@ func ShouldUseACMProxyForLogs(params ToolHandlerParams) (string, bool) {
 	return GetClusterParameter(params)
 }
 
+// RejectClusterParameter returns a validation error if the cluster argument is provided to a tool operating only on the
+// current cluster (its operations aren't routed through the ACM proxy), so that the tool never acts on the hub by mistake
+func RejectClusterParameter(params ToolHandlerParams) error {
+	if cluster, ok := GetClusterParameter(params); ok {
+		return NewToolError(ErrorCodeValidationFailed, false,
+			fmt.Errorf("cluster %q is not supported, the operation can only be performed on the current cluster", cluster))
+	}
+	return nil
+}
+
 func ToRawMessage(v any) json.RawMessage {
 	if v == nil {
 		return nil
@@ -139,12 +268,9 @@ func ToRawMessage(v any) json.RawMessage {
 
 // ResourcesList routes through ACM proxy when cluster parameter is provided
 func (p ToolHandlerParams) ResourcesList(ctx context.Context, gvk *schema.GroupVersionKind, namespace string, options internalk8s.ResourceListOptions) (runtime.Unstructured, error) {
-	fmt.Printf("DEBUG: ResourcesList shadow method called with gvk=%v, namespace=%s\n", gvk, namespace)
 	if cluster, shouldUse := ShouldUseACMProxy(p); shouldUse {
-		fmt.Printf("DEBUG: Using ACM proxy for cluster=%s\n", cluster)
 		return p.routeResourcesListThroughProxy(ctx, cluster, gvk, namespace, options)
 	}
-	fmt.Printf("DEBUG: Using direct Kubernetes client\n")
 	return p.Kubernetes.ResourcesList(ctx, gvk, namespace, options)
 }
 
@@ -157,11 +283,11 @@ func (p ToolHandlerParams) ResourcesGet(ctx context.Context, gvk *schema.GroupVe
 }
 
 // ResourcesCreateOrUpdate routes through ACM proxy when cluster parameter is provided
-func (p ToolHandlerParams) ResourcesCreateOrUpdate(ctx context.Context, resource string) ([]*unstructured.Unstructured, error) {
+func (p ToolHandlerParams) ResourcesCreateOrUpdate(ctx context.Context, resource string, options internalk8s.ApplyOptions) ([]internalk8s.ApplyResult, error) {
 	if cluster, shouldUse := ShouldUseACMProxy(p); shouldUse {
-		return p.routeResourcesCreateOrUpdateThroughProxy(ctx, cluster, resource)
+		return p.routeResourcesCreateOrUpdateThroughProxy(ctx, cluster, resource, options)
 	}
-	return p.Kubernetes.ResourcesCreateOrUpdate(ctx, resource)
+	return p.Kubernetes.ResourcesCreateOrUpdate(ctx, resource, options)
 }
 
 // ResourcesDelete routes through ACM proxy when cluster parameter is provided
@@ -176,12 +302,9 @@ func (p ToolHandlerParams) ResourcesDelete(ctx context.Context, gvk *schema.Grou
 
 // PodsListInNamespace routes through ACM proxy when cluster parameter is provided
 func (p ToolHandlerParams) PodsListInNamespace(ctx context.Context, namespace string, options internalk8s.ResourceListOptions) (runtime.Unstructured, error) {
-	fmt.Printf("DEBUG: PodsListInNamespace shadow method called with namespace=%s\n", namespace)
 	if cluster, shouldUse := ShouldUseACMProxy(p); shouldUse {
-		fmt.Printf("DEBUG: Using ACM proxy for cluster=%s\n", cluster)
 		return p.routePodsListInNamespaceThroughProxy(ctx, cluster, namespace, options)
 	}
-	fmt.Printf("DEBUG: Using direct Kubernetes client\n")
 	return p.Kubernetes.PodsListInNamespace(ctx, namespace, options)
 }
 
@@ -208,8 +331,8 @@ func (p ToolHandlerParams) PodsListInNamespaceThroughProxy(ctx context.Context,
 
 // Helper methods for ACM proxy routing
 
-func (p ToolHandlerParams) routeResourcesListThroughProxy(ctx context.Context, cluster string, gvk *schema.GroupVersionKind, namespace string, options internalk8s.ResourceListOptions) (runtime.Unstructured, error) {
-	// Build Kubernetes API path for list operation
+// proxyResourcePath builds the Kubernetes API path for the provided GVK, optional namespace, and optional name
+func (p ToolHandlerParams) proxyResourcePath(gvk *schema.GroupVersionKind, namespace, name string) string {
 	var apiPath string
 	if len(gvk.Group) == 0 {
 		apiPath = fmt.Sprintf("/api/%s", gvk.Version)
@@ -223,36 +346,74 @@ func (p ToolHandlerParams) routeResourcesListThroughProxy(ctx context.Context, c
 	}
 
 	// Add resource type (convert Kind to resource name)
-	resourceName := p.kindToResourceName(gvk.Kind)
-	apiPath = fmt.Sprintf("%s/%s", apiPath, resourceName)
+	apiPath = fmt.Sprintf("%s/%s", apiPath, p.kindToResourceName(gvk.Kind))
 
-	// Add query parameters
-	if options.LabelSelector != "" {
-		apiPath = fmt.Sprintf("%s?labelSelector=%s", apiPath, options.LabelSelector)
+	// Add resource name if provided
+	if name != "" {
+		apiPath = fmt.Sprintf("%s/%s", apiPath, name)
 	}
-
-	return p.makeProxyRequest(ctx, cluster, apiPath)
+	return apiPath
 }
 
-func (p ToolHandlerParams) routeResourcesGetThroughProxy(ctx context.Context, cluster string, gvk *schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
-	// Build Kubernetes API path for get operation
-	var apiPath string
-	if len(gvk.Group) == 0 {
-		apiPath = fmt.Sprintf("/api/%s", gvk.Version)
-	} else {
-		apiPath = fmt.Sprintf("/apis/%s/%s", gvk.Group, gvk.Version)
+func (p ToolHandlerParams) routeResourcesListThroughProxy(ctx context.Context, cluster string, gvk *schema.GroupVersionKind, namespace string, options internalk8s.ResourceListOptions) (runtime.Unstructured, error) {
+	// When the caller doesn't request a specific page, follow the continue tokens (up to ProxyListMaxPages)
+	// so that large lists are retrieved in chunks instead of in a single huge response
+	paginate := options.Limit == 0
+	limit := options.Limit
+	if paginate {
+		limit = ProxyListPageSize
 	}
-
-	// Add namespace if provided
-	if namespace != "" {
-		apiPath = fmt.Sprintf("%s/namespaces/%s", apiPath, namespace)
+	list := &unstructured.UnstructuredList{}
+	continueToken := options.Continue
+	for page := 1; ; page++ {
+		req, err := p.newProxyRequest(cluster)
+		if err != nil {
+			return nil, err
+		}
+		req.Operation(acm.OperationList).
+			Resource(gvk).
+			AbsPath(p.proxyResourcePath(gvk, namespace, "")).
+			Param("labelSelector", options.LabelSelector).
+			Param("fieldSelector", options.FieldSelector).
+			Param("limit", strconv.FormatInt(limit, 10)).
+			Param("continue", continueToken)
+		obj, err := p.doProxyRequest(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("unexpected response type from proxy")
+		}
+		pageList, err := u.ToList()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ACM proxy list response: %w", err)
+		}
+		if page == 1 {
+			list.Object = pageList.Object
+		}
+		list.Items = append(list.Items, pageList.Items...)
+		continueToken = pageList.GetContinue()
+		list.SetContinue(continueToken)
+		list.SetRemainingItemCount(pageList.GetRemainingItemCount())
+		if !paginate || continueToken == "" {
+			break
+		}
+		if page >= ProxyListMaxPages {
+			// The continue token is preserved in the returned list metadata so callers can detect the truncation
+			klog.V(1).Infof("ACM proxy list of %s in cluster %s truncated after %d pages (%d items)", gvk.Kind, cluster, page, len(list.Items))
+			break
+		}
 	}
+	return list, internalk8s.SortList(list, options.SortBy)
+}
 
-	// Add resource type and name
-	resourceName := p.kindToResourceName(gvk.Kind)
-	apiPath = fmt.Sprintf("%s/%s/%s", apiPath, resourceName, name)
-
-	obj, err := p.makeProxyRequest(ctx, cluster, apiPath)
+func (p ToolHandlerParams) routeResourcesGetThroughProxy(ctx context.Context, cluster string, gvk *schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	req, err := p.newProxyRequest(cluster)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := p.doProxyRequest(ctx, req.Resource(gvk).AbsPath(p.proxyResourcePath(gvk, namespace, name)))
 	if err != nil {
 		return nil, err
 	}
@@ -265,86 +426,120 @@ func (p ToolHandlerParams) routeResourcesGetThroughProxy(ctx context.Context, cl
 	return nil, fmt.Errorf("unexpected response type from proxy")
 }
 
-func (p ToolHandlerParams) routeResourcesCreateOrUpdateThroughProxy(ctx context.Context, cluster string, resource string) ([]*unstructured.Unstructured, error) {
-	// For now, return an error as this requires more complex implementation
-	return nil, fmt.Errorf("create/update operations via ACM proxy not yet implemented")
+func (p ToolHandlerParams) routeResourcesCreateOrUpdateThroughProxy(ctx context.Context, cluster string, resource string, options internalk8s.ApplyOptions) ([]internalk8s.ApplyResult, error) {
+	documents := internalk8s.ParseDocuments(resource)
+	if len(documents) == 0 {
+		return nil, errors.New("no resources found in the provided YAML or JSON")
+	}
+	fieldManager := options.FieldManager
+	if fieldManager == "" {
+		fieldManager = version.BinaryName
+	}
+	results := make([]internalk8s.ApplyResult, 0, len(documents))
+	for _, document := range documents {
+		if document.Err != nil {
+			results = append(results, internalk8s.ApplyResult{Status: internalk8s.ApplyStatusError, Err: document.Err, Error: document.Err.Error()})
+			continue
+		}
+		obj := document.Object
+		result := internalk8s.ApplyResult{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Name: obj.GetName(), Namespace: obj.GetNamespace()}
+		body, err := obj.MarshalJSON()
+		if err != nil {
+			result.Status = internalk8s.ApplyStatusError
+			result.Err, result.Error = err, err.Error()
+			results = append(results, result)
+			continue
+		}
+		gvk := obj.GroupVersionKind()
+		live, _ := p.routeResourcesGetThroughProxy(ctx, cluster, &gvk, obj.GetNamespace(), obj.GetName())
+		req, err := p.newProxyRequest(cluster)
+		if err != nil {
+			return nil, err
+		}
+		// Server-side apply, same semantics as the direct Kubernetes client
+		req.Verb(http.MethodPatch).
+			Resource(&gvk).
+			AbsPath(p.proxyResourcePath(&gvk, obj.GetNamespace(), obj.GetName())).
+			Param("fieldManager", fieldManager).
+			SetHeader("Content-Type", "application/apply-patch+yaml").
+			Body(bytes.NewReader(body))
+		if options.Force {
+			req.Param("force", "true")
+		}
+		if internalk8s.IsDryRun(ctx) {
+			req.Param("dryRun", metav1.DryRunAll)
+		}
+		applied, err := p.doProxyRequest(ctx, req)
+		if err != nil {
+			result.Status = internalk8s.ApplyStatusError
+			result.Err, result.Error = err, err.Error()
+		} else if u, ok := applied.(*unstructured.Unstructured); ok {
+			result.Object = u
+			result.Namespace = u.GetNamespace()
+			result.Status = internalk8s.ApplyStatus(live, u)
+		}
+		results = append(results, result)
+	}
+	return results, nil
 }
 
 func (p ToolHandlerParams) routeResourcesDeleteThroughProxy(ctx context.Context, cluster string, gvk *schema.GroupVersionKind, namespace, name string) error {
-	// For now, return an error as this requires more complex implementation
-	return fmt.Errorf("delete operations via ACM proxy not yet implemented")
+	req, err := p.newProxyRequest(cluster)
+	if err != nil {
+		return err
+	}
+	req.Verb(http.MethodDelete).Resource(gvk).AbsPath(p.proxyResourcePath(gvk, namespace, name))
+	if internalk8s.IsDryRun(ctx) {
+		req.Param("dryRun", metav1.DryRunAll)
+	}
+	_, err = p.doProxyRequest(ctx, req)
+	return err
 }
 
 // Pod-specific proxy routing methods
 
 func (p ToolHandlerParams) routePodsListInNamespaceThroughProxy(ctx context.Context, cluster string, namespace string, options internalk8s.ResourceListOptions) (runtime.Unstructured, error) {
-	// Build Kubernetes API path for pod list in namespace
-	apiPath := fmt.Sprintf("/api/v1/namespaces/%s/pods", namespace)
-
-	// Add query parameters
-	if options.LabelSelector != "" {
-		apiPath = fmt.Sprintf("%s?labelSelector=%s", apiPath, options.LabelSelector)
-	}
-
-	fmt.Printf("DEBUG: Constructed API path: %s\n", apiPath)
-	return p.makeProxyRequest(ctx, cluster, apiPath)
+	return p.routeResourcesListThroughProxy(ctx, cluster, &schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, namespace, options)
 }
 
 func (p ToolHandlerParams) routePodsListInAllNamespacesThroughProxy(ctx context.Context, cluster string, options internalk8s.ResourceListOptions) (runtime.Unstructured, error) {
-	// Build Kubernetes API path for pod list in all namespaces
-	apiPath := "/api/v1/pods"
-
-	// Add query parameters
-	if options.LabelSelector != "" {
-		apiPath = fmt.Sprintf("%s?labelSelector=%s", apiPath, options.LabelSelector)
-	}
-
-	return p.makeProxyRequest(ctx, cluster, apiPath)
+	return p.routeResourcesListThroughProxy(ctx, cluster, &schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "", options)
 }
 
 func (p ToolHandlerParams) routeNamespacesListThroughProxy(ctx context.Context, cluster string, options internalk8s.ResourceListOptions) (runtime.Unstructured, error) {
-	// Build Kubernetes API path for namespace list
-	apiPath := "/api/v1/namespaces"
-
-	// Add query parameters
-	if options.LabelSelector != "" {
-		apiPath = fmt.Sprintf("%s?labelSelector=%s", apiPath, options.LabelSelector)
-	}
-
-	return p.makeProxyRequest(ctx, cluster, apiPath)
+	return p.routeResourcesListThroughProxy(ctx, cluster, &schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, "", options)
 }
 
-func (p ToolHandlerParams) makeProxyRequest(ctx context.Context, cluster, apiPath string) (runtime.Unstructured, error) {
+// newProxyRequest creates a request builder for the provided cluster from the ACM proxy client
+func (p ToolHandlerParams) newProxyRequest(cluster string) (*acm.Request, error) {
 	// Cast ACMProxyClient to the actual ProxyClient type
 	type ProxyClient interface {
-		ProxyRequest(ctx context.Context, cluster, apiPath string) (*http.Response, error)
+		NewRequest(cluster string) *acm.Request
 	}
 
 	proxyClient, ok := p.ACMProxyClient.(ProxyClient)
 	if !ok {
-		return nil, fmt.Errorf("ACMProxyClient does not implement ProxyRequest method")
+		return nil, fmt.Errorf("ACMProxyClient does not implement NewRequest method")
 	}
+	return proxyClient.NewRequest(cluster), nil
+}
 
+// doProxyRequest performs the proxy request and decodes the streamed response as an unstructured object
+func (p ToolHandlerParams) doProxyRequest(ctx context.Context, req *acm.Request) (runtime.Unstructured, error) {
 	// Make the proxy request
-	resp, err := proxyClient.ProxyRequest(ctx, cluster, apiPath)
+	resp, err := req.Do(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("ACM proxy request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	// Decode the response while reading it, pruning fields that are never displayed
+	obj, err := acm.DecodeObject(resp.Body, acm.PruneManagedFields)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read ACM proxy response: %w", err)
-	}
-
-	// Parse JSON response to unstructured object
-	var obj unstructured.Unstructured
-	if err := json.Unmarshal(body, &obj); err != nil {
 		return nil, fmt.Errorf("failed to parse ACM proxy response: %w", err)
 	}
 
-	return &obj, nil
+	return &unstructured.Unstructured{Object: obj}, nil
 }
 
 func (p ToolHandlerParams) kindToResourceName(kind string) string {
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/acm"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type toolCallRequest map[string]any
+
+func (r toolCallRequest) GetArguments() map[string]any {
+	return r
+}
+
+type ProxyRoutingSuite struct {
+	suite.Suite
+	server *httptest.Server
+	// totalItems is the number of pods served by the fake proxy
+	totalItems int
+	requests   []*http.Request
+	// handler replaces the list responses of the fake proxy (optional)
+	handler http.HandlerFunc
+	params  ToolHandlerParams
+}
+
+func (s *ProxyRoutingSuite) SetupTest() {
+	s.requests = nil
+	s.handler = nil
+	s.totalItems = 3
+	s.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/apis/route.openshift.io/v1/namespaces/multicluster-engine/routes/cluster-proxy-addon-user" {
+			_, _ = fmt.Fprintf(w, `{"spec":{"host":"%s"}}`, r.Host)
+			return
+		}
+		s.requests = append(s.requests, r)
+		if s.handler != nil {
+			s.handler(w, r)
+			return
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		start, _ := strconv.Atoi(r.URL.Query().Get("continue"))
+		end := min(start+limit, s.totalItems)
+		items := ""
+		for i := start; i < end; i++ {
+			if i > start {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod-%d"}}`, i)
+		}
+		continueToken := ""
+		if end < s.totalItems {
+			continueToken = strconv.Itoa(end)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"apiVersion":"v1","kind":"PodList","metadata":{"continue":"%s"},"items":[%s]}`, continueToken, items)
+	}))
+	s.params = ToolHandlerParams{
+		Context:         s.T().Context(),
+		ToolCallRequest: toolCallRequest{"cluster": "managed-1"},
+		ACMProxyClient:  acm.NewProxyClient(s.server.URL, "the-token", nil),
+		IsACMMode:       true,
+	}
+}
+
+func (s *ProxyRoutingSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ProxyRoutingSuite) list(options internalk8s.ResourceListOptions) *unstructured.UnstructuredList {
+	ret, err := s.params.ResourcesList(s.params, &schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "default", options)
+	s.Require().NoError(err, "Expected no error listing through proxy")
+	list, ok := ret.(*unstructured.UnstructuredList)
+	s.Require().True(ok, "Expected an UnstructuredList, got %T", ret)
+	return list
+}
+
+func (s *ProxyRoutingSuite) TestResourcesListSinglePage() {
+	list := s.list(internalk8s.ResourceListOptions{})
+	s.Run("performs a single request", func() {
+		s.Len(s.requests, 1)
+	})
+	s.Run("requests default page size", func() {
+		s.Equal(strconv.FormatInt(ProxyListPageSize, 10), s.requests[0].URL.Query().Get("limit"))
+	})
+	s.Run("routes to the cluster", func() {
+		s.Equal("/managed-1/api/v1/namespaces/default/pods", s.requests[0].URL.Path)
+	})
+	s.Run("returns all items", func() {
+		s.Len(list.Items, 3)
+		s.Equal("PodList", list.GetKind())
+		s.Empty(list.GetContinue())
+	})
+}
+
+func (s *ProxyRoutingSuite) TestResourcesListFollowsContinue() {
+	s.totalItems = int(ProxyListPageSize)*2 + 1
+	list := s.list(internalk8s.ResourceListOptions{})
+	s.Run("follows continue tokens", func() {
+		s.Len(s.requests, 3)
+		s.Equal(strconv.FormatInt(ProxyListPageSize, 10), s.requests[1].URL.Query().Get("continue"))
+	})
+	s.Run("aggregates all items", func() {
+		s.Len(list.Items, s.totalItems)
+		s.Equal("pod-0", list.Items[0].GetName())
+		s.Equal(fmt.Sprintf("pod-%d", s.totalItems-1), list.Items[s.totalItems-1].GetName())
+	})
+	s.Run("clears continue token", func() {
+		s.Empty(list.GetContinue())
+	})
+}
+
+func (s *ProxyRoutingSuite) TestResourcesListStopsAtMaxPages() {
+	s.totalItems = int(ProxyListPageSize)*ProxyListMaxPages + 10
+	list := s.list(internalk8s.ResourceListOptions{})
+	s.Run("stops after max pages", func() {
+		s.Len(s.requests, ProxyListMaxPages)
+		s.Len(list.Items, int(ProxyListPageSize)*ProxyListMaxPages)
+	})
+	s.Run("exposes continue token", func() {
+		s.Equal(strconv.Itoa(int(ProxyListPageSize)*ProxyListMaxPages), list.GetContinue())
+	})
+}
+
+func (s *ProxyRoutingSuite) TestResourcesListWithExplicitLimit() {
+	options := internalk8s.ResourceListOptions{}
+	options.Limit = 2
+	list := s.list(options)
+	s.Run("performs a single request", func() {
+		s.Len(s.requests, 1)
+		s.Equal("2", s.requests[0].URL.Query().Get("limit"))
+	})
+	s.Run("returns the requested page with continue token", func() {
+		s.Len(list.Items, 2)
+		s.Equal("2", list.GetContinue())
+	})
+}
+
+func (s *ProxyRoutingSuite) TestResourcesCreateOrUpdateRecordsTheDocumentErrors() {
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			http.Error(w, `{"kind":"Status","code":404}`, http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/rejected"):
+			http.Error(w, `{"kind":"Status","code":422}`, http.StatusUnprocessableEntity)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(body)
+		}
+	}
+	resource := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: rejected\n  namespace: default\n" +
+		"---\nnot: [valid\n" +
+		"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: applied\n  namespace: default\n"
+	results, err := s.params.ResourcesCreateOrUpdate(s.params, resource, internalk8s.ApplyOptions{})
+	s.Require().NoError(err, "Expected the document errors to be recorded in the results")
+	s.Require().Len(results, 3, "Expected a result per document")
+	s.Run("records the error of the rejected document", func() {
+		s.Equal(internalk8s.ApplyStatusError, results[0].Status)
+		s.Equal("rejected", results[0].Name)
+		s.Contains(results[0].Error, "ACM proxy returned 422 for cluster managed-1")
+	})
+	s.Run("records the error of the invalid document", func() {
+		s.Equal(internalk8s.ApplyStatusError, results[1].Status)
+		s.Contains(results[1].Error, "document 2")
+	})
+	s.Run("applies the remaining documents", func() {
+		s.Equal(internalk8s.ApplyStatusCreated, results[2].Status)
+		s.Equal("applied", results[2].Object.GetName())
+	})
+}
+
+func TestRejectClusterParameter(t *testing.T) {
+	t.Run("accepts calls without cluster", func(t *testing.T) {
+		if err := RejectClusterParameter(ToolHandlerParams{ToolCallRequest: toolCallRequest{"name": "node-1"}}); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+	t.Run("accepts calls with empty cluster", func(t *testing.T) {
+		if err := RejectClusterParameter(ToolHandlerParams{ToolCallRequest: toolCallRequest{"cluster": ""}}); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+	t.Run("rejects calls with cluster, even outside of ACM mode", func(t *testing.T) {
+		err := RejectClusterParameter(ToolHandlerParams{ToolCallRequest: toolCallRequest{"cluster": "managed-1"}})
+		if err == nil || err.Error() != `cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+			t.Fatalf("expected cluster not supported error, got %v", err)
+		}
+		if code, retryable := ClassifyError(err); code != ErrorCodeValidationFailed || retryable {
+			t.Errorf("expected non-retryable %s, got %s (retryable %t)", ErrorCodeValidationFailed, code, retryable)
+		}
+	})
+}
+
+func TestProxyRouting(t *testing.T) {
+	suite.Run(t, new(ProxyRoutingSuite))
+}
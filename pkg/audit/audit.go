@@ -0,0 +1,136 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+// identityTTL is the time a resolved caller identity is cached for
+const identityTTL = 5 * time.Minute
+
+// Event is the audit record of a single tool call
+type Event struct {
+	Time time.Time `json:"time"`
+	Tool string    `json:"tool"`
+	// Arguments of the tool call with the sensitive values redacted
+	Arguments map[string]any `json:"arguments,omitempty"`
+	User      string         `json:"user,omitempty"`
+	// Cluster targeted by the call (ACM managed cluster), empty for the current cluster
+	Cluster    string `json:"cluster,omitempty"`
+	ReadOnly   bool   `json:"readOnly"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	ErrorCode  string `json:"errorCode,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// Sink is a destination for the audit events
+type Sink interface {
+	Write(event *Event) error
+	Close() error
+}
+
+// Options configures the audit subsystem
+type Options struct {
+	// File is the path of the JSONL audit log file
+	File string
+	// MaxSizeMB is the size in megabytes the audit log file is rotated at
+	MaxSizeMB int
+	// MaxBackups is the number of rotated audit log files retained
+	MaxBackups int
+	// WebhookURL is the URL the audit events are POSTed to
+	WebhookURL string
+	// MutatingOnly restricts the audit to the calls of tools that are not read-only
+	MutatingOnly bool
+}
+
+// Auditor records the tool calls to the configured sinks
+type Auditor struct {
+	sinks        []Sink
+	mutatingOnly bool
+	mu           sync.Mutex
+	identities   map[string]identity
+}
+
+type identity struct {
+	user    string
+	expires time.Time
+}
+
+// New creates an Auditor for the provided options, it returns nil if no sink is configured
+func New(options Options) (*Auditor, error) {
+	var sinks []Sink
+	if options.File != "" {
+		sink, err := NewFileSink(options.File, options.MaxSizeMB, options.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if options.WebhookURL != "" {
+		sinks = append(sinks, NewWebhookSink(options.WebhookURL))
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+	return &Auditor{
+		sinks:        sinks,
+		mutatingOnly: options.MutatingOnly,
+		identities:   make(map[string]identity),
+	}, nil
+}
+
+// Enabled returns whether the calls of a tool with the provided read-only hint must be audited
+func (a *Auditor) Enabled(readOnly bool) bool {
+	return !a.mutatingOnly || !readOnly
+}
+
+// Record writes the event to all the sinks, failures are logged but never fail the tool call
+func (a *Auditor) Record(event *Event) {
+	for _, sink := range a.sinks {
+		if err := sink.Write(event); err != nil {
+			klog.Errorf("failed to write audit event for tool %s: %v", event.Tool, err)
+		}
+	}
+}
+
+// Identity returns the cached identity for the provided bearer token, resolving it if missing or expired.
+// Unresolvable identities are reported as empty and not cached.
+func (a *Auditor) Identity(bearerToken string, resolve func() (string, error)) string {
+	hash := sha256.Sum256([]byte(bearerToken))
+	key := hex.EncodeToString(hash[:])
+	a.mu.Lock()
+	cached, ok := a.identities[key]
+	a.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.user
+	}
+	user, err := resolve()
+	if err != nil {
+		klog.V(3).Infof("failed to resolve caller identity for audit: %v", err)
+		return ""
+	}
+	a.mu.Lock()
+	a.identities[key] = identity{user: user, expires: time.Now().Add(identityTTL)}
+	a.mu.Unlock()
+	return user
+}
+
+// Close flushes and closes all the sinks
+func (a *Auditor) Close() error {
+	var errs []error
+	for _, sink := range a.sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
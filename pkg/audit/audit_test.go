@@ -0,0 +1,239 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditSuite struct {
+	suite.Suite
+}
+
+func readEvents(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		event := Event{}
+		if err = json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+func (s *AuditSuite) TestNew() {
+	s.Run("returns nil auditor if no sink is configured", func() {
+		auditor, err := New(Options{MutatingOnly: true})
+		s.Require().NoError(err)
+		s.Nil(auditor)
+	})
+	s.Run("fails if log file can't be opened", func() {
+		_, err := New(Options{File: filepath.Join(s.T().TempDir(), "missing", "audit.log")})
+		s.Require().Error(err)
+		s.Contains(err.Error(), "failed to open audit log file")
+	})
+}
+
+func (s *AuditSuite) TestEnabled() {
+	s.Run("audits all tools by default", func() {
+		auditor := &Auditor{}
+		s.True(auditor.Enabled(true))
+		s.True(auditor.Enabled(false))
+	})
+	s.Run("audits only mutating tools if mutating only", func() {
+		auditor := &Auditor{mutatingOnly: true}
+		s.False(auditor.Enabled(true))
+		s.True(auditor.Enabled(false))
+	})
+}
+
+func (s *AuditSuite) TestFileSink() {
+	path := filepath.Join(s.T().TempDir(), "audit.log")
+	auditor, err := New(Options{File: path})
+	s.Require().NoError(err)
+	auditor.Record(&Event{Tool: "pods_delete", User: "alice", Cluster: "managed-1", Status: StatusSuccess, DurationMs: 12})
+	auditor.Record(&Event{Tool: "pods_list", Status: StatusError, Error: "forbidden", ReadOnly: true})
+	s.Require().NoError(auditor.Close())
+	events, err := readEvents(path)
+	s.Require().NoError(err)
+	s.Run("writes one JSON line per event", func() {
+		s.Require().Len(events, 2)
+		s.Equal("pods_delete", events[0].Tool)
+		s.Equal("alice", events[0].User)
+		s.Equal("managed-1", events[0].Cluster)
+		s.Equal(int64(12), events[0].DurationMs)
+		s.Equal(StatusError, events[1].Status)
+		s.Equal("forbidden", events[1].Error)
+	})
+	s.Run("appends to existing file", func() {
+		auditor, err = New(Options{File: path})
+		s.Require().NoError(err)
+		auditor.Record(&Event{Tool: "pods_run", Status: StatusSuccess})
+		s.Require().NoError(auditor.Close())
+		events, err = readEvents(path)
+		s.Require().NoError(err)
+		s.Len(events, 3)
+	})
+	s.Run("fails to write once closed", func() {
+		sink, err := NewFileSink(path, 0, 0)
+		s.Require().NoError(err)
+		s.Require().NoError(sink.Close())
+		s.Error(sink.Write(&Event{Tool: "pods_run"}))
+	})
+}
+
+func (s *AuditSuite) TestFileSinkRotation() {
+	path := filepath.Join(s.T().TempDir(), "audit.log")
+	sink, err := NewFileSink(path, 1, 2)
+	s.Require().NoError(err)
+	// Reduce the maximum size so that each event is written to its own file
+	sink.maxSize = 10
+	for _, tool := range []string{"first", "second", "third", "fourth"} {
+		s.Require().NoError(sink.Write(&Event{Tool: tool}))
+	}
+	s.Require().NoError(sink.Close())
+	s.Run("current file contains the latest event", func() {
+		events, err := readEvents(path)
+		s.Require().NoError(err)
+		s.Require().Len(events, 1)
+		s.Equal("fourth", events[0].Tool)
+	})
+	s.Run("backups are shifted by generation", func() {
+		events, err := readEvents(path + ".1")
+		s.Require().NoError(err)
+		s.Require().Len(events, 1)
+		s.Equal("third", events[0].Tool)
+		events, err = readEvents(path + ".2")
+		s.Require().NoError(err)
+		s.Require().Len(events, 1)
+		s.Equal("second", events[0].Tool)
+	})
+	s.Run("oldest backup is dropped", func() {
+		_, err := os.Stat(path + ".3")
+		s.True(os.IsNotExist(err))
+	})
+}
+
+func (s *AuditSuite) TestWebhookSink() {
+	var mu sync.Mutex
+	var received []Event
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := Event{}
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if event.Tool == "rejected" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		mu.Lock()
+		received = append(received, event)
+		mu.Unlock()
+	}))
+	s.T().Cleanup(webhook.Close)
+	sink := NewWebhookSink(webhook.URL)
+	s.T().Cleanup(func() { _ = sink.Close() })
+	s.Run("posts event as JSON", func() {
+		s.Require().NoError(sink.Write(&Event{Tool: "resources_delete", User: "bob", Status: StatusSuccess}))
+		s.Require().Len(received, 1)
+		s.Equal("resources_delete", received[0].Tool)
+		s.Equal("bob", received[0].User)
+	})
+	s.Run("fails on error status", func() {
+		err := sink.Write(&Event{Tool: "rejected"})
+		s.Require().Error(err)
+		s.Equal("audit webhook returned 500", err.Error())
+	})
+}
+
+func (s *AuditSuite) TestIdentity() {
+	auditor := &Auditor{identities: make(map[string]identity)}
+	resolutions := 0
+	resolve := func() (string, error) {
+		resolutions++
+		return "system:serviceaccount:default:mcp", nil
+	}
+	s.Run("resolves identity", func() {
+		s.Equal("system:serviceaccount:default:mcp", auditor.Identity("token-1", resolve))
+		s.Equal(1, resolutions)
+	})
+	s.Run("caches identity per token", func() {
+		s.Equal("system:serviceaccount:default:mcp", auditor.Identity("token-1", resolve))
+		s.Equal(1, resolutions)
+		auditor.Identity("token-2", resolve)
+		s.Equal(2, resolutions)
+	})
+	s.Run("does not cache unresolvable identities", func() {
+		failing := func() (string, error) {
+			resolutions++
+			return "", errors.New("selfsubjectreviews is forbidden")
+		}
+		s.Empty(auditor.Identity("token-3", failing))
+		s.Empty(auditor.Identity("token-3", failing))
+		s.Equal(4, resolutions)
+	})
+	s.Run("does not keep tokens in memory", func() {
+		for key := range auditor.identities {
+			s.False(strings.HasPrefix(key, "token-"))
+		}
+	})
+}
+
+func (s *AuditSuite) TestRedact() {
+	redacted := Redact(map[string]any{
+		"namespace":          "default",
+		"confirmation_token": "0f6b2c",
+		"password":           "hunter2",
+		"values": map[string]any{
+			"adminPassword": "hunter2",
+			"replicas":      3,
+			"auth":          []any{map[string]any{"apiKey": "abc"}},
+		},
+		"resource": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: db\nstringData:\n  password: hunter2\n",
+		"json":     `{"apiVersion":"v1","kind":"Secret","data":{"token":"YWJj"}}`,
+		"config":   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: SecretStore\n",
+	})
+	s.Run("keeps regular arguments", func() {
+		s.Equal("default", redacted["namespace"])
+		s.Equal(3, redacted["values"].(map[string]any)["replicas"])
+	})
+	s.Run("redacts sensitive arguments", func() {
+		s.Equal(Redacted, redacted["confirmation_token"])
+		s.Equal(Redacted, redacted["password"])
+	})
+	s.Run("redacts nested sensitive arguments", func() {
+		s.Equal(Redacted, redacted["values"].(map[string]any)["adminPassword"])
+		s.Equal(Redacted, redacted["values"].(map[string]any)["auth"].([]any)[0].(map[string]any)["apiKey"])
+	})
+	s.Run("redacts Secret manifests", func() {
+		s.Equal(Redacted, redacted["resource"])
+		s.Equal(Redacted, redacted["json"])
+	})
+	s.Run("keeps other manifests", func() {
+		s.Contains(redacted["config"], "kind: ConfigMap")
+	})
+	s.Run("returns nil for nil arguments", func() {
+		s.Nil(Redact(nil))
+	})
+}
+
+func TestAudit(t *testing.T) {
+	suite.Run(t, new(AuditSuite))
+}
@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the sensitive values in the audit events
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys matches the argument names whose values must never be audited
+var sensitiveKeys = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|apikey|api_key|private_?key|authorization)`)
+
+// secretManifest matches serialized Kubernetes Secret manifests (YAML or JSON)
+var secretManifest = regexp.MustCompile(`(?m)(^\s*kind:\s*["']?Secret["']?\s*$|"kind"\s*:\s*"Secret")`)
+
+// Redact returns a copy of the tool call arguments with the sensitive values replaced
+func Redact(arguments map[string]any) map[string]any {
+	if arguments == nil {
+		return nil
+	}
+	ret := make(map[string]any, len(arguments))
+	for key, value := range arguments {
+		if sensitiveKeys.MatchString(key) {
+			ret[key] = Redacted
+			continue
+		}
+		ret[key] = redactValue(value)
+	}
+	return ret
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return Redact(v)
+	case []any:
+		ret := make([]any, len(v))
+		for i := range v {
+			ret[i] = redactValue(v[i])
+		}
+		return ret
+	case string:
+		// Secret manifests (e.g. resources_create_or_update) are redacted as a whole
+		if strings.Contains(v, "Secret") && secretManifest.MatchString(v) {
+			return Redacted
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -14,21 +15,140 @@ type StaticConfig struct {
 	LogLevel   int    `toml:"log_level,omitempty"`
 	Port       string `toml:"port,omitempty"`
 	SSEBaseURL string `toml:"sse_base_url,omitempty"`
+	// When true and a Port is set, the stdio transport is served too, sharing the server with the HTTP transports
+	Stdio      bool   `toml:"stdio,omitempty"`
 	KubeConfig string `toml:"kubeconfig,omitempty"`
 	ListOutput string `toml:"list_output,omitempty"`
+	// When true, the streamable HTTP transport is stateful: sessions are identified by the Mcp-Session-Id header and
+	// their event streams can be resumed with the Last-Event-ID header
+	StatefulHTTP bool `toml:"stateful_http,omitempty"`
+	// MaxHTTPSessions is the maximum number of concurrent sessions of the stateful streamable HTTP transport, zero is unlimited
+	MaxHTTPSessions int `toml:"max_http_sessions,omitempty"`
+	// TLSCertFile and TLSKeyFile are the certificate and key files the HTTP transport is served with over TLS, they're
+	// reloaded when they change
+	TLSCertFile string `toml:"tls_cert_file,omitempty"`
+	TLSKeyFile  string `toml:"tls_key_file,omitempty"`
+	// TLSClientCAFile is the CA bundle the client certificates are verified with, when set the MCP clients must
+	// authenticate with a client certificate (mTLS)
+	TLSClientCAFile string `toml:"tls_client_ca_file,omitempty"`
+	// ShutdownGracePeriod is the time the in-flight tool calls are given to complete on shutdown, zero falls back to the default
+	ShutdownGracePeriod time.Duration `toml:"shutdown_grace_period,omitempty"`
 	// When true, expose only tools annotated with readOnlyHint=true
 	ReadOnly bool `toml:"read_only,omitempty"`
 	// When true, disable tools annotated with destructiveHint=true
-	DisableDestructive bool     `toml:"disable_destructive,omitempty"`
+	DisableDestructive bool `toml:"disable_destructive,omitempty"`
+	// When true, destructive tools return a one-time confirmation token and only execute when it's echoed back
+	RequireConfirmation bool `toml:"require_confirmation,omitempty"`
+	// When true, check the RBAC permissions required by the tools before executing them and hide the unusable ones
+	PreflightAuthorization bool `toml:"preflight_authorization,omitempty"`
+	// When true, secrets_get returns the values of the Secrets when explicitly requested with revealValues.
+	// The Secret data embedded in the rest of the tool outputs is always redacted.
+	RevealSecretValues bool `toml:"reveal_secret_values,omitempty"`
+	// When true, serviceaccounts_token can mint short-lived ServiceAccount tokens (TokenRequest)
+	AllowServiceAccountTokens bool `toml:"allow_service_account_tokens,omitempty"`
+	// ProbeImage is the image of the short-lived pods launched by the troubleshooting tools (e.g. dns_check),
+	// it must provide sh, nslookup, nc, and wget (defaults to busybox)
+	ProbeImage string `toml:"probe_image,omitempty"`
+	// PrometheusURL is the URL of the Prometheus HTTP API queried by the metrics tools (e.g. https://thanos-querier.example.com),
+	// the requests are authenticated with the bearer token of the Kubernetes credentials if any.
+	// If not set, the Prometheus of the cluster is discovered (Routes and Services of the distributions and of the Prometheus Operator).
+	PrometheusURL string `toml:"prometheus_url,omitempty"`
+	// AlertmanagerURL is the URL of the Alertmanager HTTP API queried by the alerts tools (e.g. https://alertmanager.example.com),
+	// the requests are authenticated like the ones to the PrometheusURL. If not set, the Alertmanager of the cluster is discovered.
+	AlertmanagerURL string `toml:"alertmanager_url,omitempty"`
+	// LokiURL is the URL of the Loki HTTP API queried by the logs_query tool (e.g. https://loki-gateway.example.com, or the URL of a
+	// LokiStack gateway with the tenant path https://logging-loki-openshift-logging.apps.example.com/api/logs/v1/application),
+	// the requests are authenticated like the ones to the PrometheusURL. If not set, the Loki of the cluster is discovered.
+	LokiURL string `toml:"loki_url,omitempty"`
+	// LokiTenant is the tenant sent in the X-Scope-OrgID header of the requests to Loki (multi-tenant Loki only)
+	LokiTenant string `toml:"loki_tenant,omitempty"`
+	// TempoURL is the URL of the Tempo HTTP API queried by the traces_search tool (e.g. https://tempo.example.com),
+	// the requests are authenticated like the ones to the PrometheusURL. If not set, the Tempo of the cluster is discovered.
+	TempoURL string `toml:"tempo_url,omitempty"`
+	// JaegerURL is the URL of the Jaeger query HTTP API queried by the traces_search tool when no Tempo is configured or found
+	// (e.g. https://jaeger-query.example.com). If not set, the Jaeger of the cluster is discovered.
+	JaegerURL string `toml:"jaeger_url,omitempty"`
+	// OpenCostURL is the URL of the OpenCost (or Kubecost) allocation API queried by the cost tools (e.g. https://opencost.example.com,
+	// or https://kubecost.example.com/model for the Kubecost frontend), the requests are authenticated like the ones to the PrometheusURL.
+	// If not set, the OpenCost of the cluster is discovered, and the costs are estimated from the resource requests if none is found.
+	OpenCostURL string `toml:"opencost_url,omitempty"`
+	// CostPricing is the pricing of the nodes the costs are estimated with when no OpenCost is found (defaults to the OpenCost default pricing)
+	CostPricing CostPricing `toml:"cost_pricing,omitempty"`
+	// GrafanaURL is the URL of the Grafana the grafana_links tool links to (e.g. https://grafana.example.com), no request is sent to it
+	GrafanaURL string `toml:"grafana_url,omitempty"`
+	// GrafanaDashboards override the UIDs of the dashboards of the grafana_links tool, keyed by scope (cluster, namespace, workload, pod, node),
+	// an empty UID omits the scope. The dashboards default to the kubernetes-mixin ones and are passed its variables (e.g. var-namespace, var-pod)
+	GrafanaDashboards map[string]string `toml:"grafana_dashboards,omitempty"`
+	// When true, the TLS certificate of the monitoring stack (Prometheus, Alertmanager, Loki, Tempo, Jaeger) queried directly
+	// (configured URL or Route) isn't verified
+	PrometheusInsecure bool     `toml:"prometheus_insecure,omitempty"`
 	Toolsets           []string `toml:"toolsets,omitempty"`
-	EnabledTools       []string `toml:"enabled_tools,omitempty"`
-	DisabledTools      []string `toml:"disabled_tools,omitempty"`
+	// Toolsets that are never exposed, even if listed in Toolsets
+	DisabledToolsets []string `toml:"disabled_toolsets,omitempty"`
+	EnabledTools     []string `toml:"enabled_tools,omitempty"`
+	DisabledTools    []string `toml:"disabled_tools,omitempty"`
+	// ToolOverrides rename tools and override their description and annotations, keyed by the original tool name.
+	// EnabledTools and DisabledTools refer to the original tool names.
+	ToolOverrides map[string]ToolOverride `toml:"tool_overrides,omitempty"`
+	// RateLimit is the token bucket applied to the calls of each tool in each session, zero disables rate limiting
+	RateLimit RateLimit `toml:"rate_limit,omitempty"`
+	// ToolRateLimits override the RateLimit of specific tools, keyed by tool name
+	ToolRateLimits map[string]RateLimit `toml:"tool_rate_limits,omitempty"`
+	// ToolTimeouts override the default timeout of specific tools, keyed by tool name, zero disables the timeout
+	ToolTimeouts map[string]time.Duration `toml:"tool_timeouts,omitempty"`
+	// ResourceSubscriptionLimit is the maximum number of resources each session can subscribe to, zero falls back to the default
+	ResourceSubscriptionLimit int `toml:"resource_subscription_limit,omitempty"`
+	// When true, probe the cluster capabilities (OpenShift, metrics-server, Prometheus operator, ACM) and only expose
+	// the toolsets whose prerequisites are available
+	CapabilityDetection bool `toml:"capability_detection,omitempty"`
+	// CapabilityDetectionInterval is the interval between two probes of the cluster capabilities
+	CapabilityDetectionInterval time.Duration `toml:"capability_detection_interval,omitempty"`
+	// MetricsHistoryInterval is the interval between two samples of the Pods metrics (metrics.k8s.io) kept in memory, so that pods_top
+	// reports the consumption over a window even without Prometheus, zero disables the sampling
+	MetricsHistoryInterval time.Duration `toml:"metrics_history_interval,omitempty"`
+	// MetricsHistoryRetention is how long the samples of the Pods metrics are kept (defaults to 1h)
+	MetricsHistoryRetention time.Duration `toml:"metrics_history_retention,omitempty"`
+	// External toolset plugins, the toolsets they provide can be enabled like any built-in toolset
+	Plugins []Plugin `toml:"plugins,omitempty"`
+
+	// Audit configuration, tool calls are audited when a log file or a webhook is configured
+	// AuditLogFile is the path of the JSONL file the tool calls are recorded to
+	AuditLogFile string `toml:"audit_log_file,omitempty"`
+	// AuditLogMaxSize is the size in megabytes the audit log file is rotated at
+	AuditLogMaxSize int `toml:"audit_log_max_size,omitempty"`
+	// AuditLogMaxBackups is the number of rotated audit log files retained
+	AuditLogMaxBackups int `toml:"audit_log_max_backups,omitempty"`
+	// AuditWebhookURL is the URL each tool call record is POSTed to as JSON
+	AuditWebhookURL string `toml:"audit_webhook_url,omitempty"`
+	// When true, only the calls of tools not annotated with readOnlyHint=true are audited
+	AuditMutatingOnly bool `toml:"audit_mutating_only,omitempty"`
+
+	// Tracing configuration, tool calls and Kubernetes and ACM proxy requests are traced when an endpoint is configured
+	// TracingEndpoint is the URL of the OTLP/HTTP endpoint the spans are exported to (e.g. http://localhost:4318)
+	TracingEndpoint string `toml:"tracing_endpoint,omitempty"`
+	// TracingSampleRatio is the ratio of the traces started by the server that are sampled, zero falls back to sampling every trace
+	TracingSampleRatio float64 `toml:"tracing_sample_ratio,omitempty"`
 
 	// ACM multi-cluster configuration
 	// When true, enable ACM multi-cluster mode with cluster-proxy support
 	ACMMode bool `toml:"acm_mode,omitempty"`
 	// When true, auto-detect ACM environment by checking for ManagedCluster CRDs
 	ACMAutoDetect bool `toml:"acm_auto_detect,omitempty"`
+	// ACM cluster-proxy client tuning, zero values fall back to the defaults
+	// ACMProxyMaxIdleConnsPerHost is the maximum number of idle (keep-alive) connections kept per managed cluster
+	ACMProxyMaxIdleConnsPerHost int `toml:"acm_proxy_max_idle_conns_per_host,omitempty"`
+	// ACMProxyIdleConnTimeout is the maximum amount of time an idle connection to a managed cluster remains open
+	ACMProxyIdleConnTimeout time.Duration `toml:"acm_proxy_idle_conn_timeout,omitempty"`
+	// When true, force HTTP/1.1 for the cluster-proxy connections
+	ACMProxyDisableHTTP2 bool `toml:"acm_proxy_disable_http2,omitempty"`
+	// ACMProxyTimeout is the timeout for get, create, update, and delete requests through the cluster-proxy
+	ACMProxyTimeout time.Duration `toml:"acm_proxy_timeout,omitempty"`
+	// ACMProxyListTimeout is the timeout for each page of a list request through the cluster-proxy
+	ACMProxyListTimeout time.Duration `toml:"acm_proxy_list_timeout,omitempty"`
+	// ACMProxyLogsTimeout is the timeout for pod log requests through the cluster-proxy
+	ACMProxyLogsTimeout time.Duration `toml:"acm_proxy_logs_timeout,omitempty"`
+	// ACMProxyWatchTimeout is the timeout for watch requests through the cluster-proxy (no timeout by default)
+	ACMProxyWatchTimeout time.Duration `toml:"acm_proxy_watch_timeout,omitempty"`
 
 	// Authorization-related fields
 	// RequireOAuth indicates whether the server requires OAuth for authentication.
@@ -52,9 +172,20 @@ type StaticConfig struct {
 	// StsAudience is the audience for the STS token exchange.
 	StsAudience string `toml:"sts_audience,omitempty"`
 	// StsScopes is the scopes for the STS token exchange.
-	StsScopes            []string `toml:"sts_scopes,omitempty"`
-	CertificateAuthority string   `toml:"certificate_authority,omitempty"`
-	ServerURL            string   `toml:"server_url,omitempty"`
+	StsScopes []string `toml:"sts_scopes,omitempty"`
+	// OAuthImpersonation indicates whether the Kubernetes requests are performed with the server credentials
+	// impersonating the user (and groups) of the token validated by the OIDC provider, instead of passing the token through.
+	OAuthImpersonation bool `toml:"oauth_impersonation,omitempty"`
+	// OAuthUsernameClaim is the token claim used as the impersonated user name (defaults to "sub").
+	OAuthUsernameClaim string `toml:"oauth_username_claim,omitempty"`
+	// OAuthUsernamePrefix is prepended to the impersonated user name (e.g. "oidc:") to prevent clashes with existing users.
+	OAuthUsernamePrefix string `toml:"oauth_username_prefix,omitempty"`
+	// OAuthGroupsClaim is the token claim used as the impersonated groups (defaults to "groups").
+	OAuthGroupsClaim string `toml:"oauth_groups_claim,omitempty"`
+	// OAuthGroupsPrefix is prepended to each of the impersonated groups.
+	OAuthGroupsPrefix    string `toml:"oauth_groups_prefix,omitempty"`
+	CertificateAuthority string `toml:"certificate_authority,omitempty"`
+	ServerURL            string `toml:"server_url,omitempty"`
 }
 
 func Default() *StaticConfig {
@@ -71,6 +202,55 @@ type GroupVersionKind struct {
 	Kind    string `toml:"kind,omitempty"`
 }
 
+// ToolOverride customizes how a tool is exposed to the clients, empty fields keep the built-in values.
+// Annotation overrides are only advertised, they don't affect which tools are exposed in read-only mode or with destructive tools disabled,
+// nor which calls are rejected in read-only mode or require a confirmation.
+type ToolOverride struct {
+	// Name under which the tool is exposed
+	Name        string `toml:"name,omitempty"`
+	Description string `toml:"description,omitempty"`
+	// AppendDescription is appended to the (overridden) description, e.g. to inject organization-specific guidance
+	AppendDescription string `toml:"append_description,omitempty"`
+	Title             string `toml:"title,omitempty"`
+	ReadOnlyHint      *bool  `toml:"read_only_hint,omitempty"`
+	DestructiveHint   *bool  `toml:"destructive_hint,omitempty"`
+	IdempotentHint    *bool  `toml:"idempotent_hint,omitempty"`
+	OpenWorldHint     *bool  `toml:"open_world_hint,omitempty"`
+}
+
+// RateLimit is a token bucket configuration
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of calls, zero disables the limit
+	RequestsPerSecond float64 `toml:"requests_per_second,omitempty"`
+	// Burst is the number of calls allowed at once, defaults to the rate rounded up
+	Burst int `toml:"burst,omitempty"`
+}
+
+// CostPricing is the hourly pricing of the resources requested on the nodes, the zero prices fall back to the defaults
+type CostPricing struct {
+	NodePricing
+	// InstanceTypes override the pricing of the nodes of the instance types (node.kubernetes.io/instance-type label)
+	InstanceTypes map[string]NodePricing `toml:"instance_types,omitempty"`
+}
+
+// NodePricing is the hourly price of a core, of a GiB of memory, and of a GPU
+type NodePricing struct {
+	CPUHourly       float64 `toml:"cpu_hourly,omitempty"`
+	MemoryGiBHourly float64 `toml:"memory_gib_hourly,omitempty"`
+	GPUHourly       float64 `toml:"gpu_hourly,omitempty"`
+}
+
+// Plugin is an external executable serving a toolset over MCP stdio
+type Plugin struct {
+	Command string   `toml:"command"`
+	Args    []string `toml:"args,omitempty"`
+	// Additional environment variables for the plugin process in KEY=VALUE form
+	Env []string `toml:"env,omitempty"`
+	// Tools of the plugin whose read-only and destructive annotations are trusted,
+	// the other tools of the plugin are treated as destructive
+	TrustedTools []string `toml:"trusted_tools,omitempty"`
+}
+
 // Read reads the toml file and returns the StaticConfig.
 func Read(configPath string) (*StaticConfig, error) {
 	configData, err := os.ReadFile(configPath)
@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"k8s.io/utils/ptr"
 )
 
 type ConfigSuite struct {
@@ -58,14 +60,52 @@ func (s *ConfigSuite) TestReadConfigValid() {
 		sse_base_url = "https://example.com"
 		kubeconfig = "./path/to/config"
 		list_output = "yaml"
+		stdio = true
+		stateful_http = true
+		max_http_sessions = 50
+		shutdown_grace_period = "30s"
+		tls_cert_file = "/etc/tls/tls.crt"
+		tls_key_file = "/etc/tls/tls.key"
+		tls_client_ca_file = "/etc/tls/ca.crt"
+		oauth_impersonation = true
+		oauth_username_claim = "email"
+		oauth_username_prefix = "oidc:"
+		oauth_groups_claim = "roles"
+		oauth_groups_prefix = "oidc:"
 		read_only = true
 		disable_destructive = true
+		require_confirmation = true
+		preflight_authorization = true
+		capability_detection = true
+		capability_detection_interval = "1m"
+		resource_subscription_limit = 20
+		audit_log_file = "/var/log/mcp-audit.log"
+		audit_log_max_size = 10
+		audit_log_max_backups = 3
+		audit_webhook_url = "https://audit.example.com/events"
+		audit_mutating_only = true
+		tracing_endpoint = "http://otel-collector:4318"
+		tracing_sample_ratio = 0.25
+		acm_proxy_max_idle_conns_per_host = 20
+		acm_proxy_idle_conn_timeout = "2m"
+		acm_proxy_disable_http2 = true
+		acm_proxy_list_timeout = "90s"
 
 		toolsets = ["core", "config", "helm", "metrics"]
+		disabled_toolsets = ["helm"]
 		
 		enabled_tools = ["configuration_view", "events_list", "namespaces_list", "pods_list", "resources_list", "resources_get", "resources_create_or_update", "resources_delete"]
 		disabled_tools = ["pods_delete", "pods_top", "pods_log", "pods_run", "pods_exec"]
 
+		rate_limit = { requests_per_second = 5, burst = 10 }
+		tool_rate_limits = { pods_log = { requests_per_second = 0.5 } }
+		tool_timeouts = { pods_log = "5m", pods_exec = "0s" }
+		tool_overrides = { pods_list = { name = "list_pods", append_description = "Prefer pods_list_in_namespace for team namespaces.", destructive_hint = false } }
+
+		plugins = [
+			{command = "/usr/local/bin/kubevirt-toolset", args = ["--serve"], env = ["KUBEVIRT_NAMESPACE=kubevirt"], trusted_tools = ["vms_list"]}
+		]
+
 		denied_resources = [
 			{group = "apps", version = "v1", kind = "Deployment"},
 			{group = "rbac.authorization.k8s.io", version = "v1", kind = "Role"}
@@ -100,12 +140,91 @@ func (s *ConfigSuite) TestReadConfigValid() {
 	s.Run("disable_destructive parsed correctly", func() {
 		s.Truef(config.DisableDestructive, "Expected DisableDestructive to be true, got %v", config.DisableDestructive)
 	})
+	s.Run("require_confirmation parsed correctly", func() {
+		s.Truef(config.RequireConfirmation, "Expected RequireConfirmation to be true, got %v", config.RequireConfirmation)
+	})
+	s.Run("preflight_authorization parsed correctly", func() {
+		s.Truef(config.PreflightAuthorization, "Expected PreflightAuthorization to be true, got %v", config.PreflightAuthorization)
+	})
+	s.Run("stdio parsed correctly", func() {
+		s.Truef(config.Stdio, "Expected Stdio to be true, got %v", config.Stdio)
+	})
+	s.Run("stateful_http parsed correctly", func() {
+		s.Truef(config.StatefulHTTP, "Expected StatefulHTTP to be true, got %v", config.StatefulHTTP)
+	})
+	s.Run("max_http_sessions parsed correctly", func() {
+		s.Equalf(50, config.MaxHTTPSessions, "Expected MaxHTTPSessions to be 50, got %d", config.MaxHTTPSessions)
+	})
+	s.Run("tls_cert_file parsed correctly", func() {
+		s.Equalf("/etc/tls/tls.crt", config.TLSCertFile, "Expected TLSCertFile to be /etc/tls/tls.crt, got %s", config.TLSCertFile)
+	})
+	s.Run("tls_key_file parsed correctly", func() {
+		s.Equalf("/etc/tls/tls.key", config.TLSKeyFile, "Expected TLSKeyFile to be /etc/tls/tls.key, got %s", config.TLSKeyFile)
+	})
+	s.Run("tls_client_ca_file parsed correctly", func() {
+		s.Equalf("/etc/tls/ca.crt", config.TLSClientCAFile, "Expected TLSClientCAFile to be /etc/tls/ca.crt, got %s", config.TLSClientCAFile)
+	})
+	s.Run("oauth_impersonation parsed correctly", func() {
+		s.Truef(config.OAuthImpersonation, "Expected OAuthImpersonation to be true, got %v", config.OAuthImpersonation)
+		s.Equalf("email", config.OAuthUsernameClaim, "Expected OAuthUsernameClaim to be email, got %s", config.OAuthUsernameClaim)
+		s.Equalf("oidc:", config.OAuthUsernamePrefix, "Expected OAuthUsernamePrefix to be oidc:, got %s", config.OAuthUsernamePrefix)
+		s.Equalf("roles", config.OAuthGroupsClaim, "Expected OAuthGroupsClaim to be roles, got %s", config.OAuthGroupsClaim)
+		s.Equalf("oidc:", config.OAuthGroupsPrefix, "Expected OAuthGroupsPrefix to be oidc:, got %s", config.OAuthGroupsPrefix)
+	})
+	s.Run("shutdown_grace_period parsed correctly", func() {
+		s.Equalf(30*time.Second, config.ShutdownGracePeriod, "Expected ShutdownGracePeriod to be 30s, got %s", config.ShutdownGracePeriod)
+	})
+	s.Run("capability_detection parsed correctly", func() {
+		s.Truef(config.CapabilityDetection, "Expected CapabilityDetection to be true, got %v", config.CapabilityDetection)
+	})
+	s.Run("capability_detection_interval parsed correctly", func() {
+		s.Equalf(time.Minute, config.CapabilityDetectionInterval, "Expected CapabilityDetectionInterval to be 1m, got %s", config.CapabilityDetectionInterval)
+	})
+	s.Run("resource_subscription_limit parsed correctly", func() {
+		s.Equalf(20, config.ResourceSubscriptionLimit, "Expected ResourceSubscriptionLimit to be 20, got %d", config.ResourceSubscriptionLimit)
+	})
+	s.Run("audit_log_file parsed correctly", func() {
+		s.Equalf("/var/log/mcp-audit.log", config.AuditLogFile, "Expected AuditLogFile to be /var/log/mcp-audit.log, got %s", config.AuditLogFile)
+	})
+	s.Run("audit_log_max_size parsed correctly", func() {
+		s.Equalf(10, config.AuditLogMaxSize, "Expected AuditLogMaxSize to be 10, got %d", config.AuditLogMaxSize)
+	})
+	s.Run("audit_log_max_backups parsed correctly", func() {
+		s.Equalf(3, config.AuditLogMaxBackups, "Expected AuditLogMaxBackups to be 3, got %d", config.AuditLogMaxBackups)
+	})
+	s.Run("audit_webhook_url parsed correctly", func() {
+		s.Equalf("https://audit.example.com/events", config.AuditWebhookURL, "Expected AuditWebhookURL to be https://audit.example.com/events, got %s", config.AuditWebhookURL)
+	})
+	s.Run("audit_mutating_only parsed correctly", func() {
+		s.Truef(config.AuditMutatingOnly, "Expected AuditMutatingOnly to be true, got %v", config.AuditMutatingOnly)
+	})
+	s.Run("tracing_endpoint parsed correctly", func() {
+		s.Equalf("http://otel-collector:4318", config.TracingEndpoint, "Expected TracingEndpoint to be http://otel-collector:4318, got %s", config.TracingEndpoint)
+	})
+	s.Run("tracing_sample_ratio parsed correctly", func() {
+		s.Equalf(0.25, config.TracingSampleRatio, "Expected TracingSampleRatio to be 0.25, got %v", config.TracingSampleRatio)
+	})
+	s.Run("acm_proxy_max_idle_conns_per_host parsed correctly", func() {
+		s.Equalf(20, config.ACMProxyMaxIdleConnsPerHost, "Expected ACMProxyMaxIdleConnsPerHost to be 20, got %d", config.ACMProxyMaxIdleConnsPerHost)
+	})
+	s.Run("acm_proxy_idle_conn_timeout parsed correctly", func() {
+		s.Equalf(2*time.Minute, config.ACMProxyIdleConnTimeout, "Expected ACMProxyIdleConnTimeout to be 2m, got %s", config.ACMProxyIdleConnTimeout)
+	})
+	s.Run("acm_proxy_disable_http2 parsed correctly", func() {
+		s.Truef(config.ACMProxyDisableHTTP2, "Expected ACMProxyDisableHTTP2 to be true, got false")
+	})
+	s.Run("acm_proxy_list_timeout parsed correctly", func() {
+		s.Equalf(90*time.Second, config.ACMProxyListTimeout, "Expected ACMProxyListTimeout to be 90s, got %s", config.ACMProxyListTimeout)
+	})
 	s.Run("toolsets", func() {
 		s.Require().Lenf(config.Toolsets, 4, "Expected 4 toolsets, got %d", len(config.Toolsets))
 		for _, toolset := range []string{"core", "config", "helm", "metrics"} {
 			s.Containsf(config.Toolsets, toolset, "Expected toolsets to contain %s", toolset)
 		}
 	})
+	s.Run("disabled_toolsets", func() {
+		s.Equalf([]string{"helm"}, config.DisabledToolsets, "Expected DisabledToolsets to be [helm], got %v", config.DisabledToolsets)
+	})
 	s.Run("enabled_tools", func() {
 		s.Require().Lenf(config.EnabledTools, 8, "Expected 8 enabled tools, got %d", len(config.EnabledTools))
 		for _, tool := range []string{"configuration_view", "events_list", "namespaces_list", "pods_list", "resources_list", "resources_get", "resources_create_or_update", "resources_delete"} {
@@ -118,6 +237,32 @@ func (s *ConfigSuite) TestReadConfigValid() {
 			s.Containsf(config.DisabledTools, tool, "Expected disabled tools to contain %s", tool)
 		}
 	})
+	s.Run("rate_limit parsed correctly", func() {
+		s.Equal(RateLimit{RequestsPerSecond: 5, Burst: 10}, config.RateLimit)
+	})
+	s.Run("tool_rate_limits parsed correctly", func() {
+		s.Equal(map[string]RateLimit{"pods_log": {RequestsPerSecond: 0.5}}, config.ToolRateLimits)
+	})
+	s.Run("tool_timeouts parsed correctly", func() {
+		s.Equal(map[string]time.Duration{"pods_log": 5 * time.Minute, "pods_exec": 0}, config.ToolTimeouts)
+	})
+	s.Run("tool_overrides parsed correctly", func() {
+		s.Require().Lenf(config.ToolOverrides, 1, "Expected 1 tool override, got %d", len(config.ToolOverrides))
+		s.Equal(ToolOverride{
+			Name:              "list_pods",
+			AppendDescription: "Prefer pods_list_in_namespace for team namespaces.",
+			DestructiveHint:   ptr.To(false),
+		}, config.ToolOverrides["pods_list"])
+	})
+	s.Run("plugins", func() {
+		s.Require().Lenf(config.Plugins, 1, "Expected 1 plugin, got %d", len(config.Plugins))
+		s.Equal(Plugin{
+			Command:      "/usr/local/bin/kubevirt-toolset",
+			Args:         []string{"--serve"},
+			Env:          []string{"KUBEVIRT_NAMESPACE=kubevirt"},
+			TrustedTools: []string{"vms_list"},
+		}, config.Plugins[0])
+	})
 	s.Run("denied_resources", func() {
 		s.Require().Lenf(config.DeniedResources, 2, "Expected 2 denied resources, got %d", len(config.DeniedResources))
 		s.Run("contains apps/v1/Deployment", func() {
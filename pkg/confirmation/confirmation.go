@@ -0,0 +1,120 @@
+package confirmation
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenArgument is the tool argument used to echo back the confirmation token of a destructive operation
+const TokenArgument = "confirmation_token"
+
+// DefaultTTL is the time a confirmation token remains valid after being issued
+const DefaultTTL = 5 * time.Minute
+
+var (
+	ErrInvalidToken     = errors.New("invalid or expired confirmation token")
+	ErrArgumentsChanged = errors.New("confirmation token was issued for a different operation, the tool name and arguments must not change")
+	ErrCallerChanged    = errors.New("confirmation token was issued to a different session or identity")
+)
+
+// Store keeps track of the issued one-time confirmation tokens.
+// Each token is bound to the tool and the exact arguments it was issued for, so that a confirmed operation
+// can't be swapped for another one, and to the caller it was issued to, so that a token leaked to another session
+// or identity can't be used to confirm the operation.
+type Store struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	pending map[string]pendingOperation
+}
+
+type pendingOperation struct {
+	caller    [sha256.Size]byte
+	tool      string
+	arguments string
+	expires   time.Time
+}
+
+// NewStore creates an empty store whose tokens expire after the provided TTL
+func NewStore(ttl time.Duration) *Store {
+	return &Store{
+		ttl:     ttl,
+		pending: make(map[string]pendingOperation),
+	}
+}
+
+// TTL returns the time a confirmation token remains valid after being issued
+func (s *Store) TTL() time.Duration {
+	return s.ttl
+}
+
+// Issue returns a new confirmation token for the provided tool call.
+// The caller is an opaque key identifying who the token is issued to (e.g. the MCP session and the credentials).
+func (s *Store) Issue(caller string, tool string, arguments map[string]any) (string, error) {
+	hash, err := argumentsHash(arguments)
+	if err != nil {
+		return "", err
+	}
+	token := uuid.NewString()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	s.pending[token] = pendingOperation{caller: sha256.Sum256([]byte(caller)), tool: tool, arguments: hash, expires: time.Now().Add(s.ttl)}
+	return token, nil
+}
+
+// Consume validates the confirmation token echoed back for the provided tool call.
+// Tokens are one-time, a token is invalidated once consumed even if it doesn't match the tool call.
+func (s *Store) Consume(token string, caller string, tool string, arguments map[string]any) error {
+	hash, err := argumentsHash(arguments)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	operation, ok := s.pending[token]
+	if !ok {
+		return ErrInvalidToken
+	}
+	delete(s.pending, token)
+	callerHash := sha256.Sum256([]byte(caller))
+	if subtle.ConstantTimeCompare(operation.caller[:], callerHash[:]) != 1 {
+		return ErrCallerChanged
+	}
+	if operation.tool != tool || operation.arguments != hash {
+		return ErrArgumentsChanged
+	}
+	return nil
+}
+
+// expire removes the expired tokens, the caller must hold the lock
+func (s *Store) expire() {
+	now := time.Now()
+	for token, operation := range s.pending {
+		if now.After(operation.expires) {
+			delete(s.pending, token)
+		}
+	}
+}
+
+// argumentsHash returns a stable hash of the tool call arguments, ignoring the confirmation token
+func argumentsHash(arguments map[string]any) (string, error) {
+	args := maps.Clone(arguments)
+	delete(args, TokenArgument)
+	// encoding/json sorts the map keys, so the resulting hash is stable
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize tool arguments: %w", err)
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
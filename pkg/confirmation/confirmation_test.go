@@ -0,0 +1,64 @@
+package confirmation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfirmationSuite struct {
+	suite.Suite
+}
+
+func (s *ConfirmationSuite) TestConsume() {
+	store := NewStore(DefaultTTL)
+	arguments := map[string]any{"namespace": "default", "name": "nginx"}
+	s.Run("accepts token for the same tool and arguments", func() {
+		token, err := store.Issue("session-1", "pods_delete", arguments)
+		s.Require().NoError(err)
+		s.NoError(store.Consume(token, "session-1", "pods_delete", map[string]any{"name": "nginx", "namespace": "default", TokenArgument: token}))
+	})
+	s.Run("rejects token already consumed", func() {
+		token, err := store.Issue("session-1", "pods_delete", arguments)
+		s.Require().NoError(err)
+		s.Require().NoError(store.Consume(token, "session-1", "pods_delete", arguments))
+		s.ErrorIs(store.Consume(token, "session-1", "pods_delete", arguments), ErrInvalidToken)
+	})
+	s.Run("rejects unknown token", func() {
+		s.ErrorIs(store.Consume("unknown", "session-1", "pods_delete", arguments), ErrInvalidToken)
+	})
+	s.Run("rejects token for a different tool", func() {
+		token, err := store.Issue("session-1", "pods_delete", arguments)
+		s.Require().NoError(err)
+		s.ErrorIs(store.Consume(token, "session-1", "resources_delete", arguments), ErrArgumentsChanged)
+	})
+	s.Run("rejects token for different arguments", func() {
+		token, err := store.Issue("session-1", "pods_delete", arguments)
+		s.Require().NoError(err)
+		s.ErrorIs(store.Consume(token, "session-1", "pods_delete", map[string]any{"namespace": "kube-system", "name": "nginx"}), ErrArgumentsChanged)
+	})
+	s.Run("rejects token issued to a different caller", func() {
+		token, err := store.Issue("session-1", "pods_delete", arguments)
+		s.Require().NoError(err)
+		s.ErrorIs(store.Consume(token, "session-2", "pods_delete", arguments), ErrCallerChanged)
+	})
+	s.Run("invalidates token after a mismatch", func() {
+		token, err := store.Issue("session-1", "pods_delete", arguments)
+		s.Require().NoError(err)
+		s.Require().ErrorIs(store.Consume(token, "session-1", "resources_delete", arguments), ErrArgumentsChanged)
+		s.ErrorIs(store.Consume(token, "session-1", "pods_delete", arguments), ErrInvalidToken)
+	})
+}
+
+func (s *ConfirmationSuite) TestExpiration() {
+	store := NewStore(time.Millisecond)
+	token, err := store.Issue("session-1", "pods_delete", nil)
+	s.Require().NoError(err)
+	time.Sleep(5 * time.Millisecond)
+	s.ErrorIs(store.Consume(token, "session-1", "pods_delete", nil), ErrInvalidToken)
+}
+
+func TestConfirmation(t *testing.T) {
+	suite.Run(t, new(ConfirmationSuite))
+}
@@ -1,19 +1,30 @@
 package helm
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"log"
+	"maps"
 	"sigs.k8s.io/yaml"
+	"slices"
+	"sort"
 	"time"
 )
 
+// DefaultTimeout is the default time to wait for the resources of a release to be ready
+const DefaultTimeout = 5 * time.Minute
+
 type Kubernetes interface {
 	genericclioptions.RESTClientGetter
 	NamespaceOrDefault(namespace string) string
@@ -28,51 +39,137 @@ func NewHelm(kubernetes Kubernetes) *Helm {
 	return &Helm{kubernetes: kubernetes}
 }
 
-func (h *Helm) Install(ctx context.Context, chart string, values map[string]interface{}, name string, namespace string) (string, error) {
-	cfg, err := h.newAction(h.kubernetes.NamespaceOrDefault(namespace), false)
+// ReleaseOptions are the options of the installation or the upgrade of a release
+type ReleaseOptions struct {
+	// Chart is the chart reference: a chart of a configured repository (repo/chart), of the RepoURL, an OCI reference (oci://...), a URL, or a local path
+	Chart string
+	// Version is the version (or the version constraint) of the chart, the latest if empty
+	Version string
+	// RepoURL is the URL of the chart repository the chart is located in
+	RepoURL   string
+	Values    map[string]interface{}
+	Name      string
+	Namespace string
+	// CreateNamespace creates the namespace of the release if it doesn't exist (install only)
+	CreateNamespace bool
+	// Atomic uninstalls the failed installation or rolls back the failed upgrade, it implies Wait
+	Atomic bool
+	// Wait waits until the resources of the release are ready, up to the Timeout
+	Wait    bool
+	Timeout time.Duration
+	// DryRun renders and validates the release against the cluster without installing or upgrading it
+	DryRun bool
+	// Install installs the release if it doesn't exist (upgrade only, like helm upgrade --install)
+	Install bool
+	// ReuseValues merges the values with the ones of the last release instead of the default values of the chart (upgrade only)
+	ReuseValues bool
+	// ResetValues resets the values to the default values of the chart (upgrade only)
+	ResetValues bool
+}
+
+// Install installs the provided chart, if dryRun is true the release is rendered and validated against the cluster without being installed
+func (h *Helm) Install(ctx context.Context, options ReleaseOptions) (string, error) {
+	namespace := h.kubernetes.NamespaceOrDefault(options.Namespace)
+	cfg, err := h.newAction(namespace, false)
 	if err != nil {
 		return "", err
 	}
 	install := action.NewInstall(cfg)
-	if name == "" {
+	if options.Name == "" {
 		install.GenerateName = true
-		install.ReleaseName, _, _ = install.NameAndChart([]string{chart})
+		install.ReleaseName, _, _ = install.NameAndChart([]string{options.Chart})
 	} else {
-		install.ReleaseName = name
+		install.ReleaseName = options.Name
+	}
+	install.Namespace = namespace
+	install.CreateNamespace = options.CreateNamespace
+	install.Atomic = options.Atomic && !options.DryRun
+	install.Wait = (options.Wait || options.Atomic) && !options.DryRun
+	install.Timeout = cmp.Or(options.Timeout, DefaultTimeout)
+	install.DryRun = options.DryRun
+	if options.DryRun {
+		// Equivalent to helm install --dry-run=server, chart lookups are performed against the cluster
+		install.DryRunOption = "server"
 	}
-	install.Namespace = h.kubernetes.NamespaceOrDefault(namespace)
-	install.Wait = true
-	install.Timeout = 5 * time.Minute
-	install.DryRun = false
+	install.Version = options.Version
+	install.RepoURL = options.RepoURL
 
-	chartRequested, err := install.LocateChart(chart, cli.New())
+	chartLoaded, err := loadChart(&install.ChartPathOptions, options.Chart)
 	if err != nil {
 		return "", err
 	}
-	chartLoaded, err := loader.Load(chartRequested)
+	installedRelease, err := install.RunWithContext(ctx, chartLoaded, valuesOrEmpty(options.Values))
 	if err != nil {
 		return "", err
 	}
+	return marshalDeployed(installedRelease)
+}
 
-	installedRelease, err := install.RunWithContext(ctx, chartLoaded, values)
+// Upgrade upgrades the release to the provided chart, or installs it if it doesn't exist and options.Install is true.
+// If dryRun is true the release is rendered and validated against the cluster without being upgraded
+func (h *Helm) Upgrade(ctx context.Context, options ReleaseOptions) (string, error) {
+	namespace := h.kubernetes.NamespaceOrDefault(options.Namespace)
+	cfg, err := h.newAction(namespace, false)
 	if err != nil {
 		return "", err
 	}
-	ret, err := yaml.Marshal(simplify(installedRelease))
+	if options.Install {
+		history := action.NewHistory(cfg)
+		history.Max = 1
+		if _, err = history.Run(options.Name); errors.Is(err, driver.ErrReleaseNotFound) {
+			return h.Install(ctx, options)
+		} else if err != nil {
+			return "", err
+		}
+	}
+	upgrade := action.NewUpgrade(cfg)
+	upgrade.Namespace = namespace
+	upgrade.Atomic = options.Atomic && !options.DryRun
+	upgrade.Wait = (options.Wait || options.Atomic) && !options.DryRun
+	upgrade.Timeout = cmp.Or(options.Timeout, DefaultTimeout)
+	upgrade.ReuseValues = options.ReuseValues
+	upgrade.ResetValues = options.ResetValues
+	upgrade.DryRun = options.DryRun
+	if options.DryRun {
+		upgrade.DryRunOption = "server"
+	}
+	upgrade.Version = options.Version
+	upgrade.RepoURL = options.RepoURL
+
+	chartLoaded, err := loadChart(&upgrade.ChartPathOptions, options.Chart)
 	if err != nil {
 		return "", err
 	}
-	return string(ret), nil
+	upgradedRelease, err := upgrade.RunWithContext(ctx, options.Name, chartLoaded, valuesOrEmpty(options.Values))
+	if err != nil {
+		return "", err
+	}
+	return marshalDeployed(upgradedRelease)
 }
 
+// ListStatuses are the statuses the releases can be listed by, the deployed and failed releases are listed if none is provided
+var ListStatuses = []string{"deployed", "failed", "pending", "superseded", "uninstalling", "uninstalled", "all"}
+
 // List lists all the releases for the specified namespace (or current namespace if). Or allNamespaces is true, it lists all releases across all namespaces.
-func (h *Helm) List(namespace string, allNamespaces bool) (string, error) {
+// If status is provided, only the releases with the status (one of ListStatuses) are listed
+func (h *Helm) List(namespace string, allNamespaces bool, status string) (string, error) {
 	cfg, err := h.newAction(namespace, allNamespaces)
 	if err != nil {
 		return "", err
 	}
 	list := action.NewList(cfg)
 	list.AllNamespaces = allNamespaces
+	switch {
+	case status == "":
+	case status == "all":
+		list.StateMask = action.ListAll
+	case status == "pending":
+		list.StateMask = action.ListPendingInstall | action.ListPendingUpgrade | action.ListPendingRollback
+	case slices.Contains(ListStatuses, status):
+		list.StateMask = list.StateMask.FromName(status)
+	default:
+		return "", fmt.Errorf("unknown status %q, expected one of %v", status, ListStatuses)
+	}
 	releases, err := list.Run()
 	if err != nil {
 		return "", err
@@ -86,15 +183,140 @@ func (h *Helm) List(namespace string, allNamespaces bool) (string, error) {
 	return string(ret), nil
 }
 
-func (h *Helm) Uninstall(name string, namespace string) (string, error) {
+// Status returns the provided revision of the release (the latest one if 0), with its description, the resources of its manifest, and its notes
+func (h *Helm) Status(name string, namespace string, revision int) (string, error) {
+	cfg, err := h.newAction(h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return "", err
+	}
+	status := action.NewStatus(cfg)
+	status.Version = revision
+	r, err := status.Run(name)
+	if err != nil {
+		return "", err
+	}
+	return marshalDeployed(r)
+}
+
+// History returns the revisions of the release, the oldest first, up to maxRevisions revisions (the most recent ones) if positive
+func (h *Helm) History(name string, namespace string, maxRevisions int) (string, error) {
+	cfg, err := h.newAction(h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return "", err
+	}
+	releases, err := action.NewHistory(cfg).Run(name)
+	if err != nil {
+		return "", err
+	}
+	slices.SortFunc(releases, func(a, b *release.Release) int { return cmp.Compare(a.Version, b.Version) })
+	if maxRevisions > 0 && len(releases) > maxRevisions {
+		releases = releases[len(releases)-maxRevisions:]
+	}
+	ret := simplify(releases...)
+	for i, r := range releases {
+		delete(ret[i], "name")
+		delete(ret[i], "namespace")
+		if r.Info != nil && r.Info.Description != "" {
+			ret[i]["description"] = r.Info.Description
+		}
+	}
+	out, err := yaml.Marshal(ret)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// GetValues returns the values supplied by the user to the provided revision of the release (the latest one if 0),
+// or if all is true the computed values (the user-supplied ones merged with the default values of the chart)
+func (h *Helm) GetValues(name string, namespace string, revision int, all bool) (string, error) {
+	cfg, err := h.newAction(h.kubernetes.NamespaceOrDefault(namespace), false)
+	if err != nil {
+		return "", err
+	}
+	getValues := action.NewGetValues(cfg)
+	getValues.Version = revision
+	getValues.AllValues = all
+	values, err := getValues.Run(name)
+	if err != nil {
+		return "", err
+	} else if len(values) == 0 && all {
+		return fmt.Sprintf("Release %s has no values", name), nil
+	} else if len(values) == 0 {
+		return fmt.Sprintf("Release %s has no user-supplied values", name), nil
+	}
+	ret, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+// RollbackOptions are the options of the rollback of a release
+type RollbackOptions struct {
+	Name      string
+	Namespace string
+	// Revision is the revision to roll back to, the previous one if 0
+	Revision int
+	// Wait waits until the resources of the release are ready, up to the Timeout
+	Wait    bool
+	Timeout time.Duration
+	// DryRun looks up the revision to roll back to without rolling back
+	DryRun bool
+}
+
+// Rollback rolls the release back to the provided revision (or the previous one), as a new revision of the release.
+// Returns the new revision, or the revision to roll back to if dryRun is true
+func (h *Helm) Rollback(options RollbackOptions) (string, error) {
+	cfg, err := h.newAction(h.kubernetes.NamespaceOrDefault(options.Namespace), false)
+	if err != nil {
+		return "", err
+	}
+	current, err := cfg.Releases.Last(options.Name)
+	if err != nil {
+		return "", err
+	}
+	revision := options.Revision
+	if revision == 0 {
+		revision = current.Version - 1
+	}
+	if revision < 1 {
+		return "", fmt.Errorf("release %s has no previous revision to roll back to", options.Name)
+	}
+	target, err := cfg.Releases.Get(options.Name, revision)
+	if err != nil {
+		return "", fmt.Errorf("revision %d of release %s not found: %w", revision, options.Name, err)
+	}
+	if options.DryRun {
+		return marshalDeployed(target)
+	}
+	rollback := action.NewRollback(cfg)
+	rollback.Version = revision
+	rollback.Wait = options.Wait
+	rollback.Timeout = cmp.Or(options.Timeout, DefaultTimeout)
+	if err = rollback.Run(options.Name); err != nil {
+		return "", err
+	}
+	rolledBack, err := cfg.Releases.Last(options.Name)
+	if err != nil {
+		return "", err
+	}
+	return marshalDeployed(rolledBack)
+}
+
+// Uninstall uninstalls the provided release, if keepHistory is true the history of the release is kept (and the release can be rolled back),
+// if dryRun is true the release is only looked up
+func (h *Helm) Uninstall(name string, namespace string, keepHistory, dryRun bool) (string, error) {
 	cfg, err := h.newAction(h.kubernetes.NamespaceOrDefault(namespace), false)
 	if err != nil {
 		return "", err
 	}
 	uninstall := action.NewUninstall(cfg)
 	uninstall.IgnoreNotFound = true
-	uninstall.Wait = true
-	uninstall.Timeout = 5 * time.Minute
+	uninstall.Wait = !dryRun
+	uninstall.DryRun = dryRun
+	uninstall.KeepHistory = keepHistory
+	uninstall.Timeout = DefaultTimeout
 	uninstalledRelease, err := uninstall.Run(name)
 	if uninstalledRelease == nil && err == nil {
 		return fmt.Sprintf("Release %s not found", name), nil
@@ -104,6 +326,22 @@ func (h *Helm) Uninstall(name string, namespace string) (string, error) {
 	return fmt.Sprintf("Uninstalled release %s %s", uninstalledRelease.Release.Name, uninstalledRelease.Info), nil
 }
 
+// loadChart locates the chart (downloading it from its repository or OCI registry if needed) and loads it
+func loadChart(chartPathOptions *action.ChartPathOptions, reference string) (*chart.Chart, error) {
+	chartRequested, err := chartPathOptions.LocateChart(reference, cli.New())
+	if err != nil {
+		return nil, err
+	}
+	return loader.Load(chartRequested)
+}
+
+func valuesOrEmpty(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return map[string]interface{}{}
+	}
+	return values
+}
+
 func (h *Helm) newAction(namespace string, allNamespaces bool) (*action.Configuration, error) {
 	cfg := new(action.Configuration)
 	applicableNamespace := ""
@@ -118,6 +356,49 @@ func (h *Helm) newAction(namespace string, allNamespaces bool) (*action.Configur
 	return cfg, cfg.Init(h.kubernetes, applicableNamespace, "", log.Printf)
 }
 
+// marshalDeployed returns the simplified release along with the summary of the resources of its manifest and its notes
+func marshalDeployed(r *release.Release) (string, error) {
+	ret := simplify(r)
+	if resources := manifestResources(r.Manifest); len(resources) > 0 {
+		ret[0]["resources"] = resources
+	}
+	if r.Info != nil && r.Info.Notes != "" {
+		ret[0]["notes"] = r.Info.Notes
+	}
+	out, err := yaml.Marshal(ret)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// manifestResources returns the resources of the manifest of a release (Kind/name, namespace/Kind/name if the namespace is set),
+// in the order of the manifest, which is the install order of Helm
+func manifestResources(manifest string) []string {
+	manifests := releaseutil.SplitManifests(manifest)
+	keys := slices.Collect(maps.Keys(manifests))
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+	var ret []string
+	for _, key := range keys {
+		head := struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}{}
+		if err := yaml.Unmarshal([]byte(manifests[key]), &head); err != nil || head.Kind == "" {
+			continue
+		}
+		resource := head.Kind + "/" + head.Metadata.Name
+		if head.Metadata.Namespace != "" {
+			resource = head.Metadata.Namespace + "/" + resource
+		}
+		ret = append(ret, resource)
+	}
+	return ret
+}
+
 func simplify(release ...*release.Release) []map[string]interface{} {
 	ret := make([]map[string]interface{}, len(release))
 	for i, r := range release {
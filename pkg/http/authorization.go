@@ -15,6 +15,7 @@ import (
 	"k8s.io/utils/strings/slices"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/mcp"
 )
 
@@ -23,6 +24,12 @@ type KubernetesApiTokenVerifier interface {
 	KubernetesApiVerifyToken(ctx context.Context, token, audience string) (*authenticationapiv1.UserInfo, []string, error)
 }
 
+// unprotectedEndpoint returns true for the endpoints served without authentication: the probes, the metrics, and the
+// well-known endpoints
+func unprotectedEndpoint(r *http.Request) bool {
+	return r.URL.Path == healthEndpoint || r.URL.Path == readyEndpoint || r.URL.Path == metricsEndpoint || slices.Contains(WellKnownEndpoints, r.URL.EscapedPath())
+}
+
 // AuthorizationMiddleware validates the OAuth flow for protected resources.
 //
 // The flow is skipped for unprotected resources, such as health checks and well-known endpoints.
@@ -62,10 +69,18 @@ type KubernetesApiTokenVerifier interface {
 //	         - If ValidateToken is set, the exchanged token is then used against the Kubernetes API Server for TokenReview.
 //
 //	         see TestAuthorizationOidcTokenExchange
+//
+//	    2.4. OIDC Impersonation (oidcProvider is not nil, OAuthImpersonation is set):
+//	         - The token is validated against the OIDC Provider as in 2.2.
+//	         - The user and groups are mapped from the verified token claims (OAuthUsernameClaim and OAuthGroupsClaim).
+//	         - The Kubernetes API Server requests are performed with the server credentials impersonating them,
+//	           the token is not passed through.
+//
+//	         see TestAuthorizationOidcImpersonation
 func AuthorizationMiddleware(staticConfig *config.StaticConfig, oidcProvider *oidc.Provider, verifier KubernetesApiTokenVerifier) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if r.URL.Path == healthEndpoint || slices.Contains(WellKnownEndpoints, r.URL.EscapedPath()) {
+			if unprotectedEndpoint(r) {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -130,6 +145,15 @@ func AuthorizationMiddleware(staticConfig *config.StaticConfig, oidcProvider *oi
 			if err == nil && staticConfig.ValidateToken {
 				err = claims.ValidateWithKubernetesApi(r.Context(), staticConfig.OAuthAudience, verifier)
 			}
+			// Impersonation of the identity verified by the OIDC provider
+			if err == nil && staticConfig.OAuthImpersonation {
+				var identity *internalk8s.ImpersonatedIdentity
+				identity, err = claims.ImpersonatedIdentity(staticConfig)
+				if err == nil {
+					klog.V(2).Infof("JWT token validated - Impersonating: %s %v", identity.UserName, identity.Groups)
+					r = r.WithContext(internalk8s.WithImpersonatedIdentity(r.Context(), identity))
+				}
+			}
 			if err != nil {
 				klog.V(1).Infof("Authentication failed - JWT validation error: %s %s from %s, error: %v", r.Method, r.URL.Path, r.RemoteAddr, err)
 
@@ -163,6 +187,8 @@ type JWTClaims struct {
 	jwt.Claims
 	Token string `json:"-"`
 	Scope string `json:"scope,omitempty"`
+	// verified are the raw claims of the token once validated by the OIDC provider
+	verified map[string]any
 }
 
 func (c *JWTClaims) GetScopes() []string {
@@ -190,14 +216,49 @@ func (c *JWTClaims) ValidateWithProvider(ctx context.Context, audience string, p
 		verifier := provider.Verifier(&oidc.Config{
 			ClientID: audience,
 		})
-		_, err := verifier.Verify(ctx, c.Token)
+		idToken, err := verifier.Verify(ctx, c.Token)
 		if err != nil {
 			return fmt.Errorf("OIDC token validation error: %v", err)
 		}
+		if err = idToken.Claims(&c.verified); err != nil {
+			return fmt.Errorf("OIDC token claims error: %v", err)
+		}
 	}
 	return nil
 }
 
+// ImpersonatedIdentity maps the claims verified by the OIDC provider to the Kubernetes user and groups to impersonate.
+// The claims of a token not verified by the OIDC provider are never trusted.
+func (c *JWTClaims) ImpersonatedIdentity(staticConfig *config.StaticConfig) (*internalk8s.ImpersonatedIdentity, error) {
+	if c.verified == nil {
+		return nil, fmt.Errorf("impersonation requires a token verified by the OIDC provider")
+	}
+	usernameClaim := staticConfig.OAuthUsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = "sub"
+	}
+	username, _ := c.verified[usernameClaim].(string)
+	if username == "" {
+		return nil, fmt.Errorf("impersonation error: missing %s claim", usernameClaim)
+	}
+	groupsClaim := staticConfig.OAuthGroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = "groups"
+	}
+	var groups []string
+	switch claim := c.verified[groupsClaim].(type) {
+	case string:
+		groups = append(groups, staticConfig.OAuthGroupsPrefix+claim)
+	case []any:
+		for _, group := range claim {
+			if group, ok := group.(string); ok && group != "" {
+				groups = append(groups, staticConfig.OAuthGroupsPrefix+group)
+			}
+		}
+	}
+	return &internalk8s.ImpersonatedIdentity{UserName: staticConfig.OAuthUsernamePrefix + username, Groups: groups}, nil
+}
+
 func (c *JWTClaims) ValidateWithKubernetesApi(ctx context.Context, audience string, verifier KubernetesApiTokenVerifier) error {
 	if verifier != nil {
 		_, _, err := verifier.KubernetesApiVerifyToken(ctx, c.Token, audience)
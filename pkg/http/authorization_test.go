@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/go-jose/go-jose/v4/jwt"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
 )
 
 const (
@@ -218,3 +220,54 @@ func TestJWTClaimsGetScopes(t *testing.T) {
 		}
 	})
 }
+
+func TestJWTClaimsImpersonatedIdentity(t *testing.T) {
+	t.Run("unverified token", func(t *testing.T) {
+		claims, err := ParseJWTClaims(tokenBasicNotExpired)
+		if err != nil {
+			t.Fatalf("expected no error for parsing token, got %v", err)
+		}
+		_, err = claims.ImpersonatedIdentity(&config.StaticConfig{})
+		if err == nil || !strings.Contains(err.Error(), "verified by the OIDC provider") {
+			t.Errorf("expected unverified token error, got %v", err)
+		}
+	})
+	t.Run("default claims", func(t *testing.T) {
+		claims := &JWTClaims{verified: map[string]any{"sub": "alice", "groups": []any{"admins", "developers"}}}
+		identity, err := claims.ImpersonatedIdentity(&config.StaticConfig{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if identity.UserName != "alice" {
+			t.Errorf("expected user alice, got %s", identity.UserName)
+		}
+		if strings.Join(identity.Groups, ",") != "admins,developers" {
+			t.Errorf("expected groups [admins developers], got %v", identity.Groups)
+		}
+	})
+	t.Run("custom claims with prefixes", func(t *testing.T) {
+		claims := &JWTClaims{verified: map[string]any{"sub": "1234", "email": "alice@example.com", "role": "admins"}}
+		identity, err := claims.ImpersonatedIdentity(&config.StaticConfig{
+			OAuthUsernameClaim:  "email",
+			OAuthUsernamePrefix: "oidc:",
+			OAuthGroupsClaim:    "role",
+			OAuthGroupsPrefix:   "oidc:",
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if identity.UserName != "oidc:alice@example.com" {
+			t.Errorf("expected user oidc:alice@example.com, got %s", identity.UserName)
+		}
+		if len(identity.Groups) != 1 || identity.Groups[0] != "oidc:admins" {
+			t.Errorf("expected groups [oidc:admins], got %v", identity.Groups)
+		}
+	})
+	t.Run("missing username claim", func(t *testing.T) {
+		claims := &JWTClaims{verified: map[string]any{"sub": "alice"}}
+		_, err := claims.ImpersonatedIdentity(&config.StaticConfig{OAuthUsernameClaim: "email"})
+		if err == nil || !strings.Contains(err.Error(), "missing email claim") {
+			t.Errorf("expected missing claim error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/mcp"
+)
+
+// readyTimeout bounds the time spent by the readiness checks so that the probes of the kubelet don't time out first
+const readyTimeout = 5 * time.Second
+
+// ReadyHandler reports whether the server is ready to serve tool calls, the output mimics the /readyz endpoint of the
+// Kubernetes API server: one line per check, followed by the overall status
+func ReadyHandler(mcpServer *mcp.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+		defer cancel()
+		report := strings.Builder{}
+		ready := true
+		for _, check := range mcpServer.Ready(ctx) {
+			if check.Err != nil {
+				ready = false
+				klog.V(1).Infof("readiness check %s failed: %v", check.Name, check.Err)
+				_, _ = fmt.Fprintf(&report, "[-]%s failed: %v\n", check.Name, check.Err)
+				continue
+			}
+			_, _ = fmt.Fprintf(&report, "[+]%s ok\n", check.Name)
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if !ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprintf(w, "%sreadyz check failed\n", report.String())
+			return
+		}
+		_, _ = fmt.Fprintf(w, "%sreadyz check passed\n", report.String())
+	}
+}
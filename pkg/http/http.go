@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -15,47 +16,80 @@ import (
 
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/mcp"
+	"github.com/containers/kubernetes-mcp-server/pkg/metrics"
 )
 
 const (
 	healthEndpoint     = "/healthz"
+	readyEndpoint      = "/readyz"
+	metricsEndpoint    = "/metrics"
 	mcpEndpoint        = "/mcp"
 	sseEndpoint        = "/sse"
 	sseMessageEndpoint = "/message"
 )
 
+// httpShutdownTimeout is the time the HTTP requests are given to complete once the in-flight tool calls are drained
+const httpShutdownTimeout = 5 * time.Second
+
 func Serve(ctx context.Context, mcpServer *mcp.Server, staticConfig *config.StaticConfig, oidcProvider *oidc.Provider) error {
 	mux := http.NewServeMux()
 
 	wrappedMux := RequestMiddleware(
-		AuthorizationMiddleware(staticConfig, oidcProvider, mcpServer)(mux),
+		ClientCertificateMiddleware(staticConfig)(
+			AuthorizationMiddleware(staticConfig, oidcProvider, mcpServer)(mux),
+		),
 	)
 
+	// The event streams (SSE and streamable HTTP) never complete on their own, they're closed once the server is drained
+	streamsCtx, closeStreams := context.WithCancel(context.Background())
+	defer closeStreams()
 	httpServer := &http.Server{
-		Addr:    ":" + staticConfig.Port,
-		Handler: wrappedMux,
+		Addr:        ":" + staticConfig.Port,
+		Handler:     wrappedMux,
+		BaseContext: func(net.Listener) context.Context { return streamsCtx },
 	}
 
 	sseServer := mcpServer.ServeSse(staticConfig.SSEBaseURL, httpServer)
 	streamableHttpServer := mcpServer.ServeHTTP(httpServer)
-	mux.Handle(sseEndpoint, sseServer)
-	mux.Handle(sseMessageEndpoint, sseServer)
+	sseHandler := mcpServer.SseSubscriptions(sseServer)
+	mux.Handle(sseEndpoint, sseHandler)
+	mux.Handle(sseMessageEndpoint, sseHandler)
 	mux.Handle(mcpEndpoint, streamableHttpServer)
 	mux.HandleFunc(healthEndpoint, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
+	mux.Handle(readyEndpoint, ReadyHandler(mcpServer))
+	mux.Handle(metricsEndpoint, metrics.Handler())
 	mux.Handle("/.well-known/", WellKnownHandler(staticConfig))
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	if staticConfig.TLSCertFile != "" {
+		certificates, err := newCertificateReloader(staticConfig)
+		if err != nil {
+			return err
+		}
+		if err = certificates.watch(ctx); err != nil {
+			return err
+		}
+		httpServer.TLSConfig = certificates.tlsConfig()
+	}
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGHUP, syscall.SIGTERM)
 
 	serverErr := make(chan error, 1)
 	go func() {
 		klog.V(0).Infof("Streaming and SSE HTTP servers starting on port %s and paths /mcp, /sse, /message", staticConfig.Port)
-		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		var err error
+		if httpServer.TLSConfig != nil {
+			klog.V(0).Infof("Serving TLS with certificate %s", staticConfig.TLSCertFile)
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			serverErr <- err
 		}
 	}()
@@ -71,7 +105,12 @@ func Serve(ctx context.Context, mcpServer *mcp.Server, staticConfig *config.Stat
 		return err
 	}
 
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := mcpServer.Shutdown(context.Background()); err != nil {
+		klog.Errorf("MCP server shutdown error: %v", err)
+	}
+	closeStreams()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
 	defer shutdownCancel()
 
 	klog.V(0).Infof("Shutting down HTTP server gracefully...")
@@ -179,6 +179,11 @@ func TestGracefulShutdown(t *testing.T) {
 				t.Errorf("Context cancelled, initiating graceful shutdown, got: %s", ctx.LogBuffer.String())
 			}
 		})
+		t.Run("Drains in-flight tool calls", func(t *testing.T) {
+			if !strings.Contains(ctx.LogBuffer.String(), "Draining in-flight tool calls (grace period 10s)") {
+				t.Errorf("Expected draining log, got: %s", ctx.LogBuffer.String())
+			}
+		})
 		t.Run("Starts server shutdown", func(t *testing.T) {
 			if !strings.Contains(ctx.LogBuffer.String(), "Shutting down HTTP server gracefully") {
 				t.Errorf("Expected graceful shutdown log, got: %s", ctx.LogBuffer.String())
@@ -192,6 +197,32 @@ func TestGracefulShutdown(t *testing.T) {
 	})
 }
 
+func TestGracefulShutdownWithOpenStreams(t *testing.T) {
+	testCase(t, func(ctx *httpContext) {
+		sseResp, sseErr := http.Get(fmt.Sprintf("http://%s/sse", ctx.HttpAddress))
+		if sseErr != nil {
+			t.Fatalf("Failed to open SSE stream: %v", sseErr)
+		}
+		t.Cleanup(func() { _ = sseResp.Body.Close() })
+		start := time.Now()
+		ctx.StopServer()
+		err := ctx.WaitForShutdown()
+		t.Run("Closes the open event streams", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected graceful shutdown, but got error: %v", err)
+			}
+			if !strings.Contains(ctx.LogBuffer.String(), "HTTP server shutdown complete") {
+				t.Errorf("Expected HTTP server shutdown completed log, got: %s", ctx.LogBuffer.String())
+			}
+		})
+		t.Run("Doesn't wait for the event streams to time out", func(t *testing.T) {
+			if elapsed := time.Since(start); elapsed > httpShutdownTimeout {
+				t.Errorf("Expected shutdown to complete before %s, took %s", httpShutdownTimeout, elapsed)
+			}
+		})
+	})
+}
+
 func TestSseTransport(t *testing.T) {
 	testCase(t, func(ctx *httpContext) {
 		sseResp, sseErr := http.Get(fmt.Sprintf("http://%s/sse", ctx.HttpAddress))
@@ -306,6 +337,86 @@ func TestHealthCheck(t *testing.T) {
 	})
 }
 
+func TestReadyCheck(t *testing.T) {
+	testCase(t, func(ctx *httpContext) {
+		ctx.mockServer.Handle(&test.InOpenShiftHandler{})
+		resp, err := http.Get(fmt.Sprintf("http://%s/readyz", ctx.HttpAddress))
+		if err != nil {
+			t.Fatalf("Failed to get ready check endpoint: %v", err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		body, _ := io.ReadAll(resp.Body)
+		t.Run("Exposes ready check endpoint at /readyz", func(t *testing.T) {
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected HTTP 200 OK, got %d: %s", resp.StatusCode, body)
+			}
+		})
+		t.Run("Reports the outcome of each check", func(t *testing.T) {
+			expected := "[+]api-server ok\n[+]discovery ok\nreadyz check passed\n"
+			if string(body) != expected {
+				t.Errorf("Expected %q, got %q", expected, body)
+			}
+		})
+	})
+	testCase(t, func(ctx *httpContext) {
+		ctx.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "API server is shutting down", http.StatusServiceUnavailable)
+		}))
+		resp, err := http.Get(fmt.Sprintf("http://%s/readyz", ctx.HttpAddress))
+		if err != nil {
+			t.Fatalf("Failed to get ready check endpoint: %v", err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		body, _ := io.ReadAll(resp.Body)
+		t.Run("Unreachable API server returns HTTP 503 Service Unavailable", func(t *testing.T) {
+			if resp.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("Expected HTTP 503 Service Unavailable, got %d", resp.StatusCode)
+			}
+		})
+		t.Run("Unreachable API server reports the failed checks", func(t *testing.T) {
+			if !strings.Contains(string(body), "[-]api-server failed: API server") || !strings.HasSuffix(string(body), "readyz check failed\n") {
+				t.Errorf("Expected failed api-server check, got %q", body)
+			}
+		})
+	})
+	// Ready check exposed even when require Authorization
+	testCaseWithContext(t, &httpContext{StaticConfig: &config.StaticConfig{RequireOAuth: true, ValidateToken: true}}, func(ctx *httpContext) {
+		ctx.mockServer.Handle(&test.InOpenShiftHandler{})
+		resp, err := http.Get(fmt.Sprintf("http://%s/readyz", ctx.HttpAddress))
+		if err != nil {
+			t.Fatalf("Failed to get ready check endpoint with OAuth: %v", err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		t.Run("Ready check with OAuth returns HTTP 200 OK", func(t *testing.T) {
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected HTTP 200 OK, got %d", resp.StatusCode)
+			}
+		})
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	// Metrics exposed even when require Authorization
+	testCaseWithContext(t, &httpContext{StaticConfig: &config.StaticConfig{RequireOAuth: true, ValidateToken: true}}, func(ctx *httpContext) {
+		resp, err := http.Get(fmt.Sprintf("http://%s/metrics", ctx.HttpAddress))
+		if err != nil {
+			t.Fatalf("Failed to get metrics endpoint: %v", err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		body, _ := io.ReadAll(resp.Body)
+		t.Run("Exposes metrics endpoint at /metrics", func(t *testing.T) {
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected HTTP 200 OK, got %d", resp.StatusCode)
+			}
+		})
+		t.Run("Exposes the MCP server metrics", func(t *testing.T) {
+			if !strings.Contains(string(body), "kubernetes_mcp_server_active_sessions") {
+				t.Errorf("Expected MCP server metrics, got %s", body)
+			}
+		})
+	})
+}
+
 func TestWellKnownReverseProxy(t *testing.T) {
 	cases := []string{
 		".well-known/oauth-authorization-server",
@@ -814,6 +925,50 @@ func TestAuthorizationOidcToken(t *testing.T) {
 	}
 }
 
+func TestAuthorizationOidcImpersonation(t *testing.T) {
+	oidcTestServer := NewOidcTestServer(t)
+	t.Cleanup(oidcTestServer.Close)
+	rawClaims := `{
+		"iss": "` + oidcTestServer.URL + `",
+		"exp": ` + strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10) + `,
+		"aud": "mcp-server"%s
+	}`
+	staticConfig := &config.StaticConfig{RequireOAuth: true, OAuthAudience: "mcp-server", OAuthImpersonation: true, OAuthUsernameClaim: "email"}
+	testCaseWithContext(t, &httpContext{StaticConfig: staticConfig, OidcProvider: oidcTestServer.Provider}, func(ctx *httpContext) {
+		get := func(token string) *http.Response {
+			req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/mcp", ctx.HttpAddress), nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to get protected endpoint: %v", err)
+			}
+			t.Cleanup(func() { _ = resp.Body.Close() })
+			return resp
+		}
+		t.Run("Protected resource with VALID OIDC Authorization header with username claim returns 200 - OK", func(t *testing.T) {
+			resp := get(oidctest.SignIDToken(oidcTestServer.PrivateKey, "test-oidc-key-id", oidc.RS256,
+				fmt.Sprintf(rawClaims, `, "email": "alice@example.com", "groups": ["admins"]`)))
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected HTTP 200 OK, got %d", resp.StatusCode)
+			}
+		})
+		t.Run("Protected resource with VALID OIDC Authorization header without username claim returns 401 - Unauthorized", func(t *testing.T) {
+			resp := get(oidctest.SignIDToken(oidcTestServer.PrivateKey, "test-oidc-key-id", oidc.RS256, fmt.Sprintf(rawClaims, "")))
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("Expected HTTP 401 Unauthorized, got %d", resp.StatusCode)
+			}
+		})
+		t.Run("Protected resource with VALID OIDC Authorization header logs the impersonated identity", func(t *testing.T) {
+			if !strings.Contains(ctx.LogBuffer.String(), "Impersonating: alice@example.com [admins]") {
+				t.Errorf("Expected impersonated identity to be logged, got: %s", ctx.LogBuffer.String())
+			}
+		})
+	})
+}
+
 func TestAuthorizationOidcTokenExchange(t *testing.T) {
 	oidcTestServer := NewOidcTestServer(t)
 	t.Cleanup(oidcTestServer.Close)
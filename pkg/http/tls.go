@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+// certificateReloader serves the TLS certificate, and the client CA bundle used to authenticate the MCP clients (mTLS),
+// from files that are re-read when they change (e.g. when cert-manager renews the certificate of a mounted Secret)
+type certificateReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	mu           sync.RWMutex
+	certificate  *tls.Certificate
+	clientCAs    *x509.CertPool
+}
+
+func newCertificateReloader(staticConfig *config.StaticConfig) (*certificateReloader, error) {
+	cr := &certificateReloader{
+		certFile:     staticConfig.TLSCertFile,
+		keyFile:      staticConfig.TLSKeyFile,
+		clientCAFile: staticConfig.TLSClientCAFile,
+	}
+	if err := cr.load(); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// load reads the certificate and the client CA bundle, the current ones are kept if any of them is invalid
+func (cr *certificateReloader) load() error {
+	certificate, err := tls.LoadX509KeyPair(cr.certFile, cr.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate %s: %w", cr.certFile, err)
+	}
+	var clientCAs *x509.CertPool
+	if cr.clientCAFile != "" {
+		pem, err := os.ReadFile(cr.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read TLS client CA %s: %w", cr.clientCAFile, err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("failed to load TLS client CA %s: no valid certificate found", cr.clientCAFile)
+		}
+	}
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.certificate = &certificate
+	cr.clientCAs = clientCAs
+	return nil
+}
+
+// watch reloads the files when they change until the context is done.
+// The directories are watched instead of the files to catch the atomic symlink swaps of the Secret and ConfigMap volumes.
+func (cr *certificateReloader) watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	for _, file := range []string{cr.certFile, cr.keyFile, cr.clientCAFile} {
+		if file == "" {
+			continue
+		}
+		if err = watcher.Add(filepath.Dir(file)); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("failed to watch TLS file %s: %w", file, err)
+		}
+	}
+	go func() {
+		defer func() { _ = watcher.Close() }()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// The files may be partially written, the next event reloads them once complete
+				if err := cr.load(); err != nil {
+					klog.V(2).Infof("failed to reload the TLS certificate, keeping the current one: %v", err)
+					continue
+				}
+				klog.V(3).Infof("TLS certificate reloaded from %s", cr.certFile)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				klog.Errorf("TLS certificate watch error: %v", err)
+			}
+		}
+	}()
+	return nil
+}
+
+func (cr *certificateReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	return cr.certificate, nil
+}
+
+// tlsConfig returns the TLS configuration of the HTTP server, resolving the current certificate and client CA bundle
+// on each handshake
+func (cr *certificateReloader) tlsConfig() *tls.Config {
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: cr.getCertificate,
+	}
+	if cr.clientCAFile != "" {
+		tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cr.mu.RLock()
+			defer cr.mu.RUnlock()
+			return &tls.Config{
+				MinVersion:     tls.VersionTLS12,
+				GetCertificate: cr.getCertificate,
+				// Clients without certificate are rejected by the ClientCertificateMiddleware, except for the
+				// unprotected endpoints (e.g. the probes of the kubelet, which doesn't present a certificate)
+				ClientAuth: tls.VerifyClientCertIfGiven,
+				ClientCAs:  cr.clientCAs,
+			}, nil
+		}
+	}
+	return tlsConfig
+}
+
+// ClientCertificateMiddleware authenticates the MCP clients with their TLS client certificate (mTLS) when a client
+// CA is configured, the requests to the protected endpoints without a certificate signed by the CA are rejected
+func ClientCertificateMiddleware(staticConfig *config.StaticConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if staticConfig.TLSClientCAFile == "" || unprotectedEndpoint(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+				klog.V(1).Infof("Authentication failed - missing or invalid client certificate: %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+				http.Error(w, "Unauthorized: client certificate required", http.StatusUnauthorized)
+				return
+			}
+			klog.V(5).Infof("client certificate authenticated: %s", r.TLS.VerifiedChains[0][0].Subject)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
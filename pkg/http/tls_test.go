@@ -0,0 +1,195 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPEM     []byte
+	keyPEM      []byte
+}
+
+// newTestCertificate issues a certificate for the provided common name, self-signed if parent is nil
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:     []string{"localhost"},
+	}
+	issuer, issuerKey := template, key
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+	} else {
+		issuer, issuerKey = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certificate, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func (tc *testCertificate) write(t *testing.T, certFile, keyFile string) {
+	t.Helper()
+	if err := os.WriteFile(certFile, tc.certPEM, 0600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, tc.keyPEM, 0600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+}
+
+func TestCertificateReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	newTestCertificate(t, "first", nil).write(t, certFile, keyFile)
+	reloader, err := newCertificateReloader(&config.StaticConfig{TLSCertFile: certFile, TLSKeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("failed to load certificate: %v", err)
+	}
+	if err = reloader.watch(t.Context()); err != nil {
+		t.Fatalf("failed to watch certificate: %v", err)
+	}
+	commonName := func() string {
+		certificate, _ := reloader.getCertificate(nil)
+		leaf, _ := x509.ParseCertificate(certificate.Certificate[0])
+		return leaf.Subject.CommonName
+	}
+	t.Run("Loads the certificate", func(t *testing.T) {
+		if commonName() != "first" {
+			t.Errorf("expected the first certificate, got %s", commonName())
+		}
+	})
+	t.Run("Reloads the certificate when it changes", func(t *testing.T) {
+		newTestCertificate(t, "second", nil).write(t, certFile, keyFile)
+		for i := 0; i < 100 && commonName() != "second"; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if commonName() != "second" {
+			t.Errorf("expected the renewed certificate, got %s", commonName())
+		}
+	})
+	t.Run("Keeps the current certificate if the new one is invalid", func(t *testing.T) {
+		if err := os.WriteFile(certFile, []byte("invalid"), 0600); err != nil {
+			t.Fatalf("failed to write certificate: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+		if commonName() != "second" {
+			t.Errorf("expected the current certificate to be kept, got %s", commonName())
+		}
+	})
+	t.Run("Fails with missing files", func(t *testing.T) {
+		_, err := newCertificateReloader(&config.StaticConfig{TLSCertFile: filepath.Join(dir, "missing.crt"), TLSKeyFile: keyFile})
+		if err == nil || !strings.Contains(err.Error(), "failed to load TLS certificate") {
+			t.Errorf("expected a load error, got %v", err)
+		}
+	})
+}
+
+func TestTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "ca", nil)
+	caFile, certFile, keyFile := filepath.Join(dir, "ca.crt"), filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	if err := os.WriteFile(caFile, ca.certPEM, 0600); err != nil {
+		t.Fatalf("failed to write CA: %v", err)
+	}
+	newTestCertificate(t, "server", ca).write(t, certFile, keyFile)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.certificate)
+	client := func(clientCertificate *testCertificate) *http.Client {
+		tlsConfig := &tls.Config{RootCAs: roots, ServerName: "localhost"}
+		if clientCertificate != nil {
+			certificate, _ := tls.X509KeyPair(clientCertificate.certPEM, clientCertificate.keyPEM)
+			tlsConfig.Certificates = []tls.Certificate{certificate}
+		}
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	}
+	t.Run("Serves over TLS", func(t *testing.T) {
+		staticConfig := &config.StaticConfig{TLSCertFile: certFile, TLSKeyFile: keyFile}
+		testCaseWithContext(t, &httpContext{StaticConfig: staticConfig}, func(ctx *httpContext) {
+			resp, err := client(nil).Get(fmt.Sprintf("https://%s/healthz", ctx.HttpAddress))
+			if err != nil {
+				t.Fatalf("failed to get health endpoint over TLS: %v", err)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("expected status 200, got %d", resp.StatusCode)
+			}
+		})
+	})
+	t.Run("With client CA", func(t *testing.T) {
+		staticConfig := &config.StaticConfig{TLSCertFile: certFile, TLSKeyFile: keyFile, TLSClientCAFile: caFile}
+		testCaseWithContext(t, &httpContext{StaticConfig: staticConfig}, func(ctx *httpContext) {
+			mcpRequest := func(httpClient *http.Client) (*http.Response, error) {
+				resp, err := httpClient.Post(fmt.Sprintf("https://%s/mcp", ctx.HttpAddress), "application/json",
+					strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+				if err == nil {
+					_ = resp.Body.Close()
+				}
+				return resp, err
+			}
+			t.Run("Rejects clients without certificate", func(t *testing.T) {
+				resp, err := mcpRequest(client(nil))
+				if err != nil || resp.StatusCode != http.StatusUnauthorized {
+					t.Errorf("expected status 401, got %v %v", resp, err)
+				}
+			})
+			t.Run("Rejects clients with a certificate not signed by the client CA", func(t *testing.T) {
+				// The certificates not issued by an acceptable CA are not sent by the client, or rejected in the handshake
+				resp, err := mcpRequest(client(newTestCertificate(t, "untrusted", nil)))
+				if err == nil && resp.StatusCode != http.StatusUnauthorized {
+					t.Errorf("expected the client to be rejected, got %d", resp.StatusCode)
+				}
+			})
+			t.Run("Accepts clients with a certificate signed by the client CA", func(t *testing.T) {
+				resp, err := mcpRequest(client(newTestCertificate(t, "client", ca)))
+				if err != nil || resp.StatusCode != http.StatusOK {
+					t.Errorf("expected status 200, got %v %v", resp, err)
+				}
+			})
+			t.Run("Serves the health endpoint without certificate", func(t *testing.T) {
+				resp, err := client(nil).Get(fmt.Sprintf("https://%s/healthz", ctx.HttpAddress))
+				if err != nil || resp.StatusCode != http.StatusOK {
+					t.Errorf("expected status 200, got %v %v", resp, err)
+				}
+			})
+		})
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/spf13/cobra"
@@ -27,6 +28,8 @@ import (
 	"github.com/containers/kubernetes-mcp-server/pkg/mcp"
 	"github.com/containers/kubernetes-mcp-server/pkg/output"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/plugin"
+	"github.com/containers/kubernetes-mcp-server/pkg/tracing"
 	"github.com/containers/kubernetes-mcp-server/pkg/version"
 )
 
@@ -51,23 +54,38 @@ kubernetes-mcp-server --port 8443 --sse-base-url https://example.com:8443
 )
 
 type MCPServerOptions struct {
-	Version              bool
-	LogLevel             int
-	Port                 string
-	SSEPort              int
-	HttpPort             int
-	SSEBaseUrl           string
-	Kubeconfig           string
-	Toolsets             []string
-	ListOutput           string
-	ReadOnly             bool
-	DisableDestructive   bool
-	RequireOAuth         bool
-	OAuthAudience        string
-	ValidateToken        bool
-	AuthorizationURL     string
-	CertificateAuthority string
-	ServerURL            string
+	Version                bool
+	LogLevel               int
+	Port                   string
+	SSEPort                int
+	HttpPort               int
+	SSEBaseUrl             string
+	Stdio                  bool
+	StatefulHTTP           bool
+	MaxHTTPSessions        int
+	TLSCertFile            string
+	TLSKeyFile             string
+	TLSClientCAFile        string
+	Kubeconfig             string
+	Toolsets               []string
+	DisabledToolsets       []string
+	ListOutput             string
+	ReadOnly               bool
+	DisableDestructive     bool
+	RequireConfirmation    bool
+	PreflightAuthorization bool
+	CapabilityDetection    bool
+	AuditLogFile           string
+	AuditWebhookURL        string
+	AuditMutatingOnly      bool
+	TracingEndpoint        string
+	RequireOAuth           bool
+	OAuthAudience          string
+	ValidateToken          bool
+	AuthorizationURL       string
+	OAuthImpersonation     bool
+	CertificateAuthority   string
+	ServerURL              string
 
 	// ACM multi-cluster options
 	ACMMode       bool
@@ -76,6 +94,9 @@ type MCPServerOptions struct {
 	ConfigPath   string
 	StaticConfig *config.StaticConfig
 
+	// plugins are the running external toolset plugins, stopped when the server exits
+	plugins []*plugin.Toolset
+
 	genericiooptions.IOStreams
 }
 
@@ -117,11 +138,25 @@ func NewMCPServer(streams genericiooptions.IOStreams) *cobra.Command {
 	cmd.Flag("http-port").Deprecated = "Use --port instead"
 	cmd.Flags().StringVar(&o.Port, "port", o.Port, "Start a streamable HTTP and SSE HTTP server on the specified port (e.g. 8080)")
 	cmd.Flags().StringVar(&o.SSEBaseUrl, "sse-base-url", o.SSEBaseUrl, "SSE public base URL to use when sending the endpoint message (e.g. https://example.com)")
+	cmd.Flags().BoolVar(&o.Stdio, "stdio", o.Stdio, "If true, also serve the stdio transport when --port is set, stdio and HTTP clients share the same server")
+	cmd.Flags().BoolVar(&o.StatefulHTTP, "stateful-http", o.StatefulHTTP, "If true, the streamable HTTP transport issues session IDs and its event streams can be resumed with the Last-Event-ID header")
+	cmd.Flags().IntVar(&o.MaxHTTPSessions, "max-http-sessions", o.MaxHTTPSessions, "Maximum number of concurrent sessions of the stateful streamable HTTP transport (0 for unlimited)")
+	cmd.Flags().StringVar(&o.TLSCertFile, "tls-cert-file", o.TLSCertFile, "Path of the certificate file the HTTP transport is served with over TLS, reloaded when it changes (requires --tls-key-file)")
+	cmd.Flags().StringVar(&o.TLSKeyFile, "tls-key-file", o.TLSKeyFile, "Path of the private key file of the TLS certificate, reloaded when it changes (requires --tls-cert-file)")
+	cmd.Flags().StringVar(&o.TLSClientCAFile, "tls-client-ca-file", o.TLSClientCAFile, "Path of the CA bundle the client certificates are verified with, if set the MCP clients must authenticate with a TLS client certificate (mTLS)")
 	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to the kubeconfig file to use for authentication")
 	cmd.Flags().StringSliceVar(&o.Toolsets, "toolsets", o.Toolsets, "Comma-separated list of MCP toolsets to use (available toolsets: "+strings.Join(toolsets.ToolsetNames(), ", ")+"). Defaults to "+strings.Join(o.StaticConfig.Toolsets, ", ")+".")
+	cmd.Flags().StringSliceVar(&o.DisabledToolsets, "disabled-toolsets", o.DisabledToolsets, "Comma-separated list of MCP toolsets to disable, takes precedence over --toolsets")
 	cmd.Flags().StringVar(&o.ListOutput, "list-output", o.ListOutput, "Output format for resource list operations (one of: "+strings.Join(output.Names, ", ")+"). Defaults to "+o.StaticConfig.ListOutput+".")
 	cmd.Flags().BoolVar(&o.ReadOnly, "read-only", o.ReadOnly, "If true, only tools annotated with readOnlyHint=true are exposed")
 	cmd.Flags().BoolVar(&o.DisableDestructive, "disable-destructive", o.DisableDestructive, "If true, tools annotated with destructiveHint=true are disabled")
+	cmd.Flags().BoolVar(&o.PreflightAuthorization, "preflight-authorization", o.PreflightAuthorization, "If true, check the RBAC permissions required by the tools with SelfSubjectAccessReviews before executing them, and hide the tools the current identity can't use")
+	cmd.Flags().BoolVar(&o.CapabilityDetection, "capability-detection", o.CapabilityDetection, "If true, probe the cluster capabilities (OpenShift, metrics-server, Prometheus operator, ACM) at startup and periodically, and only expose the toolsets whose prerequisites are available")
+	cmd.Flags().StringVar(&o.AuditLogFile, "audit-log-file", o.AuditLogFile, "Path of the JSONL file every tool call is audited to (rotated by size, see audit_log_max_size and audit_log_max_backups)")
+	cmd.Flags().StringVar(&o.AuditWebhookURL, "audit-webhook-url", o.AuditWebhookURL, "URL every tool call audit record is POSTed to as JSON")
+	cmd.Flags().BoolVar(&o.AuditMutatingOnly, "audit-mutating-only", o.AuditMutatingOnly, "If true, only the calls of tools that are not annotated with readOnlyHint=true are audited")
+	cmd.Flags().StringVar(&o.TracingEndpoint, "tracing-endpoint", o.TracingEndpoint, "URL of the OTLP/HTTP endpoint the OpenTelemetry spans of the tool calls and Kubernetes requests are exported to (e.g. http://localhost:4318), tracing is disabled if not set")
+	cmd.Flags().BoolVar(&o.RequireConfirmation, "require-confirmation", o.RequireConfirmation, "If true, tools annotated with destructiveHint=true require a confirmation token returned by a first call before executing")
 	cmd.Flags().BoolVar(&o.RequireOAuth, "require-oauth", o.RequireOAuth, "If true, requires OAuth authorization as defined in the Model Context Protocol (MCP) specification. This flag is ignored if transport type is stdio")
 	_ = cmd.Flags().MarkHidden("require-oauth")
 	cmd.Flags().StringVar(&o.OAuthAudience, "oauth-audience", o.OAuthAudience, "OAuth audience for token claims validation. Optional. If not set, the audience is not validated. Only valid if require-oauth is enabled.")
@@ -130,6 +165,8 @@ func NewMCPServer(streams genericiooptions.IOStreams) *cobra.Command {
 	_ = cmd.Flags().MarkHidden("validate-token")
 	cmd.Flags().StringVar(&o.AuthorizationURL, "authorization-url", o.AuthorizationURL, "OAuth authorization server URL for protected resource endpoint. If not provided, the Kubernetes API server host will be used. Only valid if require-oauth is enabled.")
 	_ = cmd.Flags().MarkHidden("authorization-url")
+	cmd.Flags().BoolVar(&o.OAuthImpersonation, "oauth-impersonation", o.OAuthImpersonation, "If true, the Kubernetes API Server requests are performed with the server credentials impersonating the user and groups of the token validated by the OIDC provider, instead of passing the token through. Only valid if require-oauth and authorization-url are set.")
+	_ = cmd.Flags().MarkHidden("oauth-impersonation")
 	cmd.Flags().StringVar(&o.ServerURL, "server-url", o.ServerURL, "Server URL of this application. Optional. If set, this url will be served in protected resource metadata endpoint and tokens will be validated with this audience. If not set, expected audience is kubernetes-mcp-server. Only valid if require-oauth is enabled.")
 	_ = cmd.Flags().MarkHidden("server-url")
 	cmd.Flags().StringVar(&o.CertificateAuthority, "certificate-authority", o.CertificateAuthority, "Certificate authority path to verify certificates. Optional. Only valid if require-oauth is enabled.")
@@ -153,6 +190,15 @@ func (m *MCPServerOptions) Complete(cmd *cobra.Command) error {
 
 	m.initializeLogging()
 
+	// Plugins are loaded before validation so that their toolsets can be referenced like the built-in ones
+	if len(m.StaticConfig.Plugins) > 0 && !m.Version {
+		plugins, err := plugin.Load(context.Background(), m.StaticConfig.Plugins)
+		if err != nil {
+			return err
+		}
+		m.plugins = plugins
+	}
+
 	if m.StaticConfig.RequireOAuth && m.StaticConfig.Port == "" {
 		// RequireOAuth is not relevant flow for STDIO transport
 		m.StaticConfig.RequireOAuth = false
@@ -175,6 +221,24 @@ func (m *MCPServerOptions) loadFlags(cmd *cobra.Command) {
 	if cmd.Flag("sse-base-url").Changed {
 		m.StaticConfig.SSEBaseURL = m.SSEBaseUrl
 	}
+	if cmd.Flag("stdio").Changed {
+		m.StaticConfig.Stdio = m.Stdio
+	}
+	if cmd.Flag("stateful-http").Changed {
+		m.StaticConfig.StatefulHTTP = m.StatefulHTTP
+	}
+	if cmd.Flag("max-http-sessions").Changed {
+		m.StaticConfig.MaxHTTPSessions = m.MaxHTTPSessions
+	}
+	if cmd.Flag("tls-cert-file").Changed {
+		m.StaticConfig.TLSCertFile = m.TLSCertFile
+	}
+	if cmd.Flag("tls-key-file").Changed {
+		m.StaticConfig.TLSKeyFile = m.TLSKeyFile
+	}
+	if cmd.Flag("tls-client-ca-file").Changed {
+		m.StaticConfig.TLSClientCAFile = m.TLSClientCAFile
+	}
 	if cmd.Flag("kubeconfig").Changed {
 		m.StaticConfig.KubeConfig = m.Kubeconfig
 	}
@@ -187,9 +251,33 @@ func (m *MCPServerOptions) loadFlags(cmd *cobra.Command) {
 	if cmd.Flag("disable-destructive").Changed {
 		m.StaticConfig.DisableDestructive = m.DisableDestructive
 	}
+	if cmd.Flag("require-confirmation").Changed {
+		m.StaticConfig.RequireConfirmation = m.RequireConfirmation
+	}
+	if cmd.Flag("preflight-authorization").Changed {
+		m.StaticConfig.PreflightAuthorization = m.PreflightAuthorization
+	}
+	if cmd.Flag("capability-detection").Changed {
+		m.StaticConfig.CapabilityDetection = m.CapabilityDetection
+	}
+	if cmd.Flag("audit-log-file").Changed {
+		m.StaticConfig.AuditLogFile = m.AuditLogFile
+	}
+	if cmd.Flag("audit-webhook-url").Changed {
+		m.StaticConfig.AuditWebhookURL = m.AuditWebhookURL
+	}
+	if cmd.Flag("audit-mutating-only").Changed {
+		m.StaticConfig.AuditMutatingOnly = m.AuditMutatingOnly
+	}
+	if cmd.Flag("tracing-endpoint").Changed {
+		m.StaticConfig.TracingEndpoint = m.TracingEndpoint
+	}
 	if cmd.Flag("toolsets").Changed {
 		m.StaticConfig.Toolsets = m.Toolsets
 	}
+	if cmd.Flag("disabled-toolsets").Changed {
+		m.StaticConfig.DisabledToolsets = m.DisabledToolsets
+	}
 	if cmd.Flag("require-oauth").Changed {
 		m.StaticConfig.RequireOAuth = m.RequireOAuth
 	}
@@ -202,6 +290,9 @@ func (m *MCPServerOptions) loadFlags(cmd *cobra.Command) {
 	if cmd.Flag("authorization-url").Changed {
 		m.StaticConfig.AuthorizationURL = m.AuthorizationURL
 	}
+	if cmd.Flag("oauth-impersonation").Changed {
+		m.StaticConfig.OAuthImpersonation = m.OAuthImpersonation
+	}
 	if cmd.Flag("server-url").Changed {
 		m.StaticConfig.ServerURL = m.ServerURL
 	}
@@ -225,7 +316,12 @@ func (m *MCPServerOptions) initializeLogging() {
 		_ = flagSet.Parse([]string{"-logtostderr=false", "-alsologtostderr=false", "-stderrthreshold=FATAL"})
 		return
 	}
-	loggerOptions := []textlogger.ConfigOption{textlogger.Output(m.Out)}
+	out := m.Out
+	if m.StaticConfig.Stdio {
+		// stdout is reserved for the stdio transport
+		out = m.ErrOut
+	}
+	loggerOptions := []textlogger.ConfigOption{textlogger.Output(out)}
 	if m.StaticConfig.LogLevel >= 0 {
 		loggerOptions = append(loggerOptions, textlogger.Verbosity(m.StaticConfig.LogLevel))
 		_ = flagSet.Parse([]string{"--v", strconv.Itoa(m.StaticConfig.LogLevel)})
@@ -238,14 +334,30 @@ func (m *MCPServerOptions) Validate() error {
 	if m.Port != "" && (m.SSEPort > 0 || m.HttpPort > 0) {
 		return fmt.Errorf("--port is mutually exclusive with deprecated --http-port and --sse-port flags")
 	}
+	if (m.StaticConfig.TLSCertFile == "") != (m.StaticConfig.TLSKeyFile == "") {
+		return fmt.Errorf("--tls-cert-file and --tls-key-file must be provided together")
+	}
+	if m.StaticConfig.TLSClientCAFile != "" && m.StaticConfig.TLSCertFile == "" {
+		return fmt.Errorf("--tls-client-ca-file is only valid if --tls-cert-file and --tls-key-file are provided")
+	}
 	if output.FromString(m.StaticConfig.ListOutput) == nil {
 		return fmt.Errorf("invalid output name: %s, valid names are: %s", m.StaticConfig.ListOutput, strings.Join(output.Names, ", "))
 	}
 	if err := toolsets.Validate(m.StaticConfig.Toolsets); err != nil {
 		return err
 	}
-	if !m.StaticConfig.RequireOAuth && (m.StaticConfig.ValidateToken || m.StaticConfig.OAuthAudience != "" || m.StaticConfig.AuthorizationURL != "" || m.StaticConfig.ServerURL != "" || m.StaticConfig.CertificateAuthority != "") {
-		return fmt.Errorf("validate-token, oauth-audience, authorization-url, server-url and certificate-authority are only valid if require-oauth is enabled. Missing --port may implicitly set require-oauth to false")
+	if err := toolsets.Validate(m.StaticConfig.DisabledToolsets); err != nil {
+		return err
+	}
+	if !m.StaticConfig.RequireOAuth && (m.StaticConfig.ValidateToken || m.StaticConfig.OAuthAudience != "" || m.StaticConfig.AuthorizationURL != "" || m.StaticConfig.ServerURL != "" || m.StaticConfig.CertificateAuthority != "" || m.StaticConfig.OAuthImpersonation) {
+		return fmt.Errorf("validate-token, oauth-audience, authorization-url, server-url, certificate-authority and oauth-impersonation are only valid if require-oauth is enabled. Missing --port may implicitly set require-oauth to false")
+	}
+	if m.StaticConfig.OAuthImpersonation && m.StaticConfig.AuthorizationURL == "" {
+		// The identity of tokens not verified by the OIDC provider can't be trusted
+		return fmt.Errorf("--oauth-impersonation is only valid if --authorization-url is provided")
+	}
+	if m.StaticConfig.OAuthImpersonation && (m.StaticConfig.StsClientId != "" || m.StaticConfig.StsAudience != "") {
+		return fmt.Errorf("--oauth-impersonation can't be combined with the STS token exchange (sts_client_id, sts_audience)")
 	}
 	if m.StaticConfig.AuthorizationURL != "" {
 		u, err := url.Parse(m.StaticConfig.AuthorizationURL)
@@ -263,12 +375,52 @@ func (m *MCPServerOptions) Validate() error {
 }
 
 func (m *MCPServerOptions) Run() error {
+	defer func() {
+		for _, p := range m.plugins {
+			_ = p.Close()
+		}
+	}()
 	klog.V(1).Info("Starting kubernetes-mcp-server")
 	klog.V(1).Infof(" - Config: %s", m.ConfigPath)
 	klog.V(1).Infof(" - Toolsets: %s", strings.Join(m.StaticConfig.Toolsets, ", "))
+	for _, p := range m.plugins {
+		klog.V(1).Infof(" - Plugin toolset: %s", p.GetName())
+	}
+	if len(m.StaticConfig.DisabledToolsets) > 0 {
+		klog.V(1).Infof(" - Disabled toolsets: %s", strings.Join(m.StaticConfig.DisabledToolsets, ", "))
+	}
 	klog.V(1).Infof(" - ListOutput: %s", m.StaticConfig.ListOutput)
+	if m.StaticConfig.Port != "" {
+		klog.V(1).Infof(" - Stdio transport: %t", m.StaticConfig.Stdio)
+	}
+	klog.V(1).Infof(" - Stateful HTTP: %t", m.StaticConfig.StatefulHTTP)
+	if m.StaticConfig.MaxHTTPSessions > 0 {
+		klog.V(1).Infof(" - Max HTTP sessions: %d", m.StaticConfig.MaxHTTPSessions)
+	}
+	if m.StaticConfig.TLSCertFile != "" {
+		klog.V(1).Infof(" - TLS certificate: %s", m.StaticConfig.TLSCertFile)
+	}
+	if m.StaticConfig.TLSClientCAFile != "" {
+		klog.V(1).Infof(" - TLS client CA (mTLS): %s", m.StaticConfig.TLSClientCAFile)
+	}
 	klog.V(1).Infof(" - Read-only mode: %t", m.StaticConfig.ReadOnly)
 	klog.V(1).Infof(" - Disable destructive tools: %t", m.StaticConfig.DisableDestructive)
+	klog.V(1).Infof(" - Require confirmation of destructive tools: %t", m.StaticConfig.RequireConfirmation)
+	klog.V(1).Infof(" - Pre-flight authorization checks: %t", m.StaticConfig.PreflightAuthorization)
+	klog.V(1).Infof(" - Capability detection: %t", m.StaticConfig.CapabilityDetection)
+	if m.StaticConfig.AuditLogFile != "" {
+		klog.V(1).Infof(" - Audit log file: %s", m.StaticConfig.AuditLogFile)
+	}
+	if m.StaticConfig.AuditWebhookURL != "" {
+		klog.V(1).Infof(" - Audit webhook: %s", m.StaticConfig.AuditWebhookURL)
+	}
+	klog.V(1).Infof(" - Audit mutating tool calls only: %t", m.StaticConfig.AuditMutatingOnly)
+	if m.StaticConfig.TracingEndpoint != "" {
+		klog.V(1).Infof(" - Tracing endpoint: %s", m.StaticConfig.TracingEndpoint)
+	}
+	if m.StaticConfig.OAuthImpersonation {
+		klog.V(1).Info(" - OAuth impersonation: true")
+	}
 
 	if m.Version {
 		_, _ = fmt.Fprintf(m.Out, "%s\n", version.Version)
@@ -308,6 +460,18 @@ func (m *MCPServerOptions) Run() error {
 		oidcProvider = provider
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), m.StaticConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			klog.Errorf("failed to flush the tracing spans: %v", err)
+		}
+	}()
+
 	mcpServer, err := mcp.NewServer(mcp.Configuration{StaticConfig: m.StaticConfig})
 	if err != nil {
 		return fmt.Errorf("failed to initialize MCP server: %w", err)
@@ -315,11 +479,20 @@ func (m *MCPServerOptions) Run() error {
 	defer mcpServer.Close()
 
 	if m.StaticConfig.Port != "" {
-		ctx := context.Background()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		if m.StaticConfig.Stdio {
+			go func() {
+				if err := mcpServer.ServeStdio(ctx); err != nil && !errors.Is(err, context.Canceled) {
+					klog.Errorf("stdio transport error: %v", err)
+				}
+				klog.V(1).Info("stdio transport closed, the HTTP transports keep serving")
+			}()
+		}
 		return internalhttp.Serve(ctx, mcpServer, m.StaticConfig, oidcProvider)
 	}
 
-	if err := mcpServer.ServeStdio(); err != nil && !errors.Is(err, context.Canceled) {
+	if err := mcpServer.ServeStdio(context.Background()); err != nil && !errors.Is(err, context.Canceled) {
 		return err
 	}
 
@@ -137,7 +137,7 @@ func TestToolsets(t *testing.T) {
 		rootCmd := NewMCPServer(ioStreams)
 		rootCmd.SetArgs([]string{"--help"})
 		o, err := captureOutput(rootCmd.Execute) // --help doesn't use logger/klog, cobra prints directly to stdout
-		if !strings.Contains(o, "Comma-separated list of MCP toolsets to use (available toolsets: config, core, helm).") {
+		if !strings.Contains(o, "Comma-separated list of MCP toolsets to use (available toolsets: acm, config, core, cost, crd, helm, metrics, network, storage).") {
 			t.Fatalf("Expected all available toolsets, got %s %v", o, err)
 		}
 	})
@@ -159,6 +159,24 @@ func TestToolsets(t *testing.T) {
 			t.Fatalf("Expected toolset to be %s, got %s %v", expected, out.String(), err)
 		}
 	})
+	t.Run("disabled with --disabled-toolsets", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1", "--disabled-toolsets", "helm"})
+		_ = rootCmd.Execute()
+		expected := `(?m)\" - Disabled toolsets\: helm\"`
+		if m, err := regexp.MatchString(expected, out.String()); !m || err != nil {
+			t.Fatalf("Expected disabled toolsets to be %s, got %s %v", expected, out.String(), err)
+		}
+	})
+	t.Run("invalid --disabled-toolsets", func(t *testing.T) {
+		ioStreams, _ := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--disabled-toolsets", "invalid"})
+		if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid toolset name: invalid") {
+			t.Fatalf("Expected invalid toolset error, got %v", err)
+		}
+	})
 }
 
 func TestListOutput(t *testing.T) {
@@ -233,6 +251,186 @@ func TestDisableDestructive(t *testing.T) {
 	})
 }
 
+func TestRequireConfirmation(t *testing.T) {
+	t.Run("defaults to false", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Require confirmation of destructive tools: false") {
+			t.Fatalf("Expected require confirmation false, got %s %v", out, err)
+		}
+	})
+	t.Run("set with --require-confirmation", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1", "--require-confirmation"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Require confirmation of destructive tools: true") {
+			t.Fatalf("Expected require confirmation true, got %s %v", out, err)
+		}
+	})
+}
+
+func TestPreflightAuthorization(t *testing.T) {
+	t.Run("defaults to false", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Pre-flight authorization checks: false") {
+			t.Fatalf("Expected pre-flight authorization false, got %s %v", out, err)
+		}
+	})
+	t.Run("set with --preflight-authorization", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1", "--preflight-authorization"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Pre-flight authorization checks: true") {
+			t.Fatalf("Expected pre-flight authorization true, got %s %v", out, err)
+		}
+	})
+}
+
+func TestCapabilityDetection(t *testing.T) {
+	t.Run("defaults to false", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Capability detection: false") {
+			t.Fatalf("Expected capability detection false, got %s %v", out, err)
+		}
+	})
+	t.Run("set with --capability-detection", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1", "--capability-detection"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Capability detection: true") {
+			t.Fatalf("Expected capability detection true, got %s %v", out, err)
+		}
+	})
+}
+
+func TestStdio(t *testing.T) {
+	t.Run("defaults to false", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Stdio transport: false") {
+			t.Fatalf("Expected stdio transport false, got %s %v", out, err)
+		}
+	})
+	t.Run("set with --stdio logs to stderr", func(t *testing.T) {
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		rootCmd := NewMCPServer(genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: out, ErrOut: errOut})
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1", "--stdio"})
+		if err := rootCmd.Execute(); !strings.Contains(errOut.String(), " - Stdio transport: true") {
+			t.Fatalf("Expected stdio transport true, got %s %v", errOut, err)
+		}
+		if strings.Contains(out.String(), "Starting kubernetes-mcp-server") {
+			t.Fatalf("Expected stdout to be reserved for the stdio transport, got %s", out)
+		}
+	})
+}
+
+func TestStatefulHTTP(t *testing.T) {
+	t.Run("defaults to false", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Stateful HTTP: false") {
+			t.Fatalf("Expected stateful HTTP false, got %s %v", out, err)
+		}
+	})
+	t.Run("set with --stateful-http and --max-http-sessions", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1", "--stateful-http", "--max-http-sessions=50"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Stateful HTTP: true") {
+			t.Fatalf("Expected stateful HTTP true, got %s %v", out, err)
+		}
+		if !strings.Contains(out.String(), " - Max HTTP sessions: 50") {
+			t.Fatalf("Expected max HTTP sessions 50, got %s", out)
+		}
+	})
+}
+
+func TestAudit(t *testing.T) {
+	t.Run("mutating only defaults to false", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Audit mutating tool calls only: false") {
+			t.Fatalf("Expected audit mutating only false, got %s %v", out, err)
+		}
+	})
+	t.Run("set with --audit-log-file and --audit-mutating-only", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1", "--audit-log-file=/var/log/mcp-audit.log", "--audit-mutating-only"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Audit log file: /var/log/mcp-audit.log") {
+			t.Fatalf("Expected audit log file, got %s %v", out, err)
+		}
+		if !strings.Contains(out.String(), " - Audit mutating tool calls only: true") {
+			t.Fatalf("Expected audit mutating only true, got %s", out)
+		}
+	})
+	t.Run("set with --audit-webhook-url", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1", "--audit-webhook-url=https://audit.example.com/events"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Audit webhook: https://audit.example.com/events") {
+			t.Fatalf("Expected audit webhook, got %s %v", out, err)
+		}
+	})
+}
+
+func TestTLS(t *testing.T) {
+	t.Run("set with --tls-cert-file, --tls-key-file, and --tls-client-ca-file", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1", "--tls-cert-file=/etc/tls/tls.crt", "--tls-key-file=/etc/tls/tls.key", "--tls-client-ca-file=/etc/tls/ca.crt"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - TLS certificate: /etc/tls/tls.crt") {
+			t.Fatalf("Expected TLS certificate, got %s %v", out, err)
+		}
+		if !strings.Contains(out.String(), " - TLS client CA (mTLS): /etc/tls/ca.crt") {
+			t.Fatalf("Expected TLS client CA, got %s", out)
+		}
+	})
+	t.Run("--tls-cert-file requires --tls-key-file", func(t *testing.T) {
+		ioStreams, _ := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--tls-cert-file=/etc/tls/tls.crt"})
+		if err := rootCmd.Execute(); err == nil || err.Error() != "--tls-cert-file and --tls-key-file must be provided together" {
+			t.Fatalf("Expected error for missing key file, got %v", err)
+		}
+	})
+	t.Run("--tls-client-ca-file requires a certificate", func(t *testing.T) {
+		ioStreams, _ := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--tls-client-ca-file=/etc/tls/ca.crt"})
+		if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--tls-client-ca-file is only valid") {
+			t.Fatalf("Expected error for client CA without certificate, got %v", err)
+		}
+	})
+}
+
+func TestTracing(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1"})
+		if err := rootCmd.Execute(); strings.Contains(out.String(), " - Tracing endpoint:") {
+			t.Fatalf("Expected no tracing endpoint, got %s %v", out, err)
+		}
+	})
+	t.Run("set with --tracing-endpoint", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=1337", "--log-level=1", "--tracing-endpoint=http://localhost:4318"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Tracing endpoint: http://localhost:4318") {
+			t.Fatalf("Expected tracing endpoint, got %s %v", out, err)
+		}
+	})
+}
+
 func TestAuthorizationURL(t *testing.T) {
 	t.Run("invalid authorization-url without protocol", func(t *testing.T) {
 		ioStreams, _ := testStream()
@@ -258,6 +456,48 @@ func TestAuthorizationURL(t *testing.T) {
 	})
 }
 
+func TestOAuthImpersonation(t *testing.T) {
+	t.Run("requires require-oauth", func(t *testing.T) {
+		ioStreams, _ := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--port=8080", "--oauth-impersonation"})
+		err := rootCmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "are only valid if require-oauth is enabled") {
+			t.Fatalf("Expected require-oauth error, got %v", err)
+		}
+	})
+	t.Run("requires authorization-url", func(t *testing.T) {
+		ioStreams, _ := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--require-oauth", "--port=8080", "--oauth-impersonation"})
+		err := rootCmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "--oauth-impersonation is only valid if --authorization-url is provided") {
+			t.Fatalf("Expected authorization-url error, got %v", err)
+		}
+	})
+	t.Run("can't be combined with the STS token exchange", func(t *testing.T) {
+		stsConfigPath := filepath.Join(t.TempDir(), "sts.toml")
+		if err := os.WriteFile(stsConfigPath, []byte(`sts_client_id = "mcp-server"`), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		ioStreams, _ := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--require-oauth", "--port=8080", "--oauth-impersonation", "--authorization-url", "https://example.com/auth", "--config", stsConfigPath})
+		err := rootCmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "can't be combined with the STS token exchange") {
+			t.Fatalf("Expected STS error, got %v", err)
+		}
+	})
+	t.Run("set with --oauth-impersonation", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--require-oauth", "--port=8080", "--log-level=1", "--oauth-impersonation", "--authorization-url", "https://example.com/auth"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - OAuth impersonation: true") {
+			t.Fatalf("Expected OAuth impersonation, got %s %v", out, err)
+		}
+	})
+}
+
 func TestStdioLogging(t *testing.T) {
 	t.Run("stdio disables klog", func(t *testing.T) {
 		ioStreams, out := testStream()
@@ -3,9 +3,11 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	authenticationv1api "k8s.io/api/authentication/v1"
 	authorizationv1api "k8s.io/api/authorization/v1"
+	certificatesv1api "k8s.io/api/certificates/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -14,9 +16,12 @@ import (
 	"k8s.io/client-go/kubernetes"
 	authenticationv1 "k8s.io/client-go/kubernetes/typed/authentication/v1"
 	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	certificatesv1 "k8s.io/client-go/kubernetes/typed/certificates/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
 	"k8s.io/metrics/pkg/apis/metrics"
 	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
@@ -74,6 +79,33 @@ func (a *AccessControlClientset) PodsExec(namespace, name string, podExecOptions
 	})
 }
 
+// PodsPortForward returns the dialer of the port-forward streams of the pod, WebSockets are preferred with a fallback to SPDY
+func (a *AccessControlClientset) PodsPortForward(namespace, name string) (httpstream.Dialer, error) {
+	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	// https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/cmd/portforward/portforward.go#L138-L157
+	portForwardRequest := a.delegate.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("portforward")
+	transport, upgrader, err := spdy.RoundTripperFor(a.cfg)
+	if err != nil {
+		return nil, err
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", portForwardRequest.URL())
+	tunnelingDialer, err := portforward.NewSPDYOverWebsocketDialer(portForwardRequest.URL(), a.cfg)
+	if err != nil {
+		return nil, err
+	}
+	return portforward.NewFallbackDialer(tunnelingDialer, spdyDialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	}), nil
+}
+
 func (a *AccessControlClientset) PodsMetricses(ctx context.Context, namespace, name string, listOptions metav1.ListOptions) (*metrics.PodMetricsList, error) {
 	gvk := &schema.GroupVersionKind{Group: metrics.GroupName, Version: metricsv1beta1api.SchemeGroupVersion.Version, Kind: "PodMetrics"}
 	if !isAllowed(a.staticConfig, gvk) {
@@ -97,6 +129,37 @@ func (a *AccessControlClientset) PodsMetricses(ctx context.Context, namespace, n
 	return convertedMetrics, metricsv1beta1api.Convert_v1beta1_PodMetricsList_To_metrics_PodMetricsList(versionedMetrics, convertedMetrics, nil)
 }
 
+func (a *AccessControlClientset) Nodes() (corev1.NodeInterface, error) {
+	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Node"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	return a.delegate.CoreV1().Nodes(), nil
+}
+
+func (a *AccessControlClientset) NodesMetricses(ctx context.Context, name string, listOptions metav1.ListOptions) (*metrics.NodeMetricsList, error) {
+	gvk := &schema.GroupVersionKind{Group: metrics.GroupName, Version: metricsv1beta1api.SchemeGroupVersion.Version, Kind: "NodeMetrics"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	versionedMetrics := &metricsv1beta1api.NodeMetricsList{}
+	var err error
+	if name != "" {
+		m, err := a.metricsV1beta1.NodeMetricses().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metrics for node %s: %w", name, err)
+		}
+		versionedMetrics.Items = []metricsv1beta1api.NodeMetrics{*m}
+	} else {
+		versionedMetrics, err = a.metricsV1beta1.NodeMetricses().List(ctx, listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list node metrics: %w", err)
+		}
+	}
+	convertedMetrics := &metrics.NodeMetricsList{}
+	return convertedMetrics, metricsv1beta1api.Convert_v1beta1_NodeMetricsList_To_metrics_NodeMetricsList(versionedMetrics, convertedMetrics, nil)
+}
+
 func (a *AccessControlClientset) Services(namespace string) (corev1.ServiceInterface, error) {
 	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Service"}
 	if !isAllowed(a.staticConfig, gvk) {
@@ -105,6 +168,34 @@ func (a *AccessControlClientset) Services(namespace string) (corev1.ServiceInter
 	return a.delegate.CoreV1().Services(namespace), nil
 }
 
+// ServicesProxy returns a request with the verb to the proxy subresource of the Service (name is scheme:name:port),
+// used to reach the HTTP API of the Service with any verb (ProxyGet only supports GET)
+func (a *AccessControlClientset) ServicesProxy(verb, namespace, name string) (*rest.Request, error) {
+	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Service"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	return a.delegate.CoreV1().RESTClient().Verb(verb).Namespace(namespace).Resource("services").Name(name).SubResource("proxy"), nil
+}
+
+// CertificateSigningRequests returns CertificateSigningRequestInterface, used to approve or deny the requests
+func (a *AccessControlClientset) CertificateSigningRequests() (certificatesv1.CertificateSigningRequestInterface, error) {
+	gvk := &schema.GroupVersionKind{Group: certificatesv1api.GroupName, Version: certificatesv1api.SchemeGroupVersion.Version, Kind: "CertificateSigningRequest"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	return a.delegate.CertificatesV1().CertificateSigningRequests(), nil
+}
+
+// ServiceAccounts returns ServiceAccountInterface, used to request ServiceAccount tokens
+func (a *AccessControlClientset) ServiceAccounts(namespace string) (corev1.ServiceAccountInterface, error) {
+	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ServiceAccount"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	return a.delegate.CoreV1().ServiceAccounts(namespace), nil
+}
+
 func (a *AccessControlClientset) SelfSubjectAccessReviews() (authorizationv1.SelfSubjectAccessReviewInterface, error) {
 	gvk := &schema.GroupVersionKind{Group: authorizationv1api.GroupName, Version: authorizationv1api.SchemeGroupVersion.Version, Kind: "SelfSubjectAccessReview"}
 	if !isAllowed(a.staticConfig, gvk) {
@@ -113,6 +204,24 @@ func (a *AccessControlClientset) SelfSubjectAccessReviews() (authorizationv1.Sel
 	return a.delegate.AuthorizationV1().SelfSubjectAccessReviews(), nil
 }
 
+// SubjectAccessReviews returns SubjectAccessReviewInterface, used to check the permissions of other users
+func (a *AccessControlClientset) SubjectAccessReviews() (authorizationv1.SubjectAccessReviewInterface, error) {
+	gvk := &schema.GroupVersionKind{Group: authorizationv1api.GroupName, Version: authorizationv1api.SchemeGroupVersion.Version, Kind: "SubjectAccessReview"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	return a.delegate.AuthorizationV1().SubjectAccessReviews(), nil
+}
+
+// SelfSubjectReviews returns SelfSubjectReviewInterface
+func (a *AccessControlClientset) SelfSubjectReviews() (authenticationv1.SelfSubjectReviewInterface, error) {
+	gvk := &schema.GroupVersionKind{Group: authenticationv1api.GroupName, Version: authenticationv1api.SchemeGroupVersion.Version, Kind: "SelfSubjectReview"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	return a.delegate.AuthenticationV1().SelfSubjectReviews(), nil
+}
+
 // TokenReview returns TokenReviewInterface
 func (a *AccessControlClientset) TokenReview() (authenticationv1.TokenReviewInterface, error) {
 	gvk := &schema.GroupVersionKind{Group: authenticationv1api.GroupName, Version: authorizationv1api.SchemeGroupVersion.Version, Kind: "TokenReview"}
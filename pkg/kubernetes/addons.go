@@ -0,0 +1,134 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	ObservabilityAddonName = "observability-controller"
+	// observabilityClusterLabel disables the observability addon of a ManagedCluster when set to "disabled"
+	observabilityClusterLabel = "observability"
+)
+
+var (
+	ManagedClusterGVK        = schema.GroupVersionKind{Group: "cluster.open-cluster-management.io", Version: "v1", Kind: "ManagedCluster"}
+	ManagedClusterAddOnGVK   = schema.GroupVersionKind{Group: "addon.open-cluster-management.io", Version: "v1alpha1", Kind: "ManagedClusterAddOn"}
+	KlusterletAddonConfigGVK = schema.GroupVersionKind{Group: "agent.open-cluster-management.io", Version: "v1", Kind: "KlusterletAddonConfig"}
+)
+
+// klusterletAddonConfigFields maps the ManagedClusterAddOn names to the KlusterletAddonConfig spec field that enables them
+var klusterletAddonConfigFields = map[string]string{
+	"application-manager":         "applicationManager",
+	"cert-policy-controller":      "certPolicyController",
+	"config-policy-controller":    "policyController",
+	"governance-policy-framework": "policyController",
+	"iam-policy-controller":       "iamPolicyController",
+	"search-collector":            "searchCollector",
+}
+
+// ConfigurableAddons returns the names of the addons that can be enabled or disabled with AddonSetEnabled
+func ConfigurableAddons() []string {
+	addons := []string{ObservabilityAddonName}
+	for addon := range klusterletAddonConfigFields {
+		addons = append(addons, addon)
+	}
+	slices.Sort(addons)
+	return addons
+}
+
+// AddonsList returns the ManagedClusterAddOns of the provided managed cluster with their availability conditions
+func (k *Kubernetes) AddonsList(ctx context.Context, cluster string) ([]map[string]any, error) {
+	addons, err := k.ResourcesList(ctx, &ManagedClusterAddOnGVK, cluster, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	list, ok := addons.(*unstructured.UnstructuredList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected ManagedClusterAddOn list type %T", addons)
+	}
+	ret := make([]map[string]any, 0, len(list.Items))
+	for i := range list.Items {
+		ret = append(ret, AddonSummary(&list.Items[i]))
+	}
+	return ret, nil
+}
+
+// AddonSummary extracts the availability information from a ManagedClusterAddOn
+func AddonSummary(addon *unstructured.Unstructured) map[string]any {
+	summary := map[string]any{
+		"name":      addon.GetName(),
+		"available": "Unknown",
+	}
+	if installNamespace, _, _ := unstructured.NestedString(addon.Object, "spec", "installNamespace"); installNamespace != "" {
+		summary["installNamespace"] = installNamespace
+	}
+	conditions, _, _ := unstructured.NestedSlice(addon.Object, "status", "conditions")
+	var reported []map[string]any
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		switch condition["type"] {
+		case "Available":
+			summary["available"] = condition["status"]
+		case "Degraded":
+			if condition["status"] == string(metav1.ConditionTrue) {
+				summary["degraded"] = true
+			}
+		case "Progressing":
+			if condition["status"] == string(metav1.ConditionTrue) {
+				summary["progressing"] = true
+			}
+		}
+		reported = append(reported, map[string]any{
+			"type":    condition["type"],
+			"status":  condition["status"],
+			"reason":  condition["reason"],
+			"message": condition["message"],
+		})
+	}
+	if len(reported) > 0 {
+		summary["conditions"] = reported
+	}
+	return summary
+}
+
+// AddonSetEnabled enables or disables the provided addon for the provided managed cluster.
+// Addons managed by the KlusterletAddonConfig are toggled through its spec, observability is toggled through
+// the observability label of the ManagedCluster.
+func (k *Kubernetes) AddonSetEnabled(ctx context.Context, cluster, addon string, enabled bool) (*unstructured.Unstructured, error) {
+	if addon == ObservabilityAddonName {
+		labelValue := "enabled"
+		if !enabled {
+			labelValue = "disabled"
+		}
+		patch, err := json.Marshal(map[string]any{
+			"metadata": map[string]any{"labels": map[string]any{observabilityClusterLabel: labelValue}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return k.resourcesPatch(ctx, &ManagedClusterGVK, "", cluster, types.MergePatchType, patch)
+	}
+	field, ok := klusterletAddonConfigFields[addon]
+	if !ok {
+		return nil, fmt.Errorf("addon %s can't be enabled or disabled, supported addons: %s", addon, strings.Join(ConfigurableAddons(), ", "))
+	}
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{field: map[string]any{"enabled": enabled}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return k.resourcesPatch(ctx, &KlusterletAddonConfigGVK, cluster, cluster, types.MergePatchType, patch)
+}
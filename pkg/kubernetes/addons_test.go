@@ -0,0 +1,53 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestAddonSummary(t *testing.T) {
+	t.Run("with conditions", func(t *testing.T) {
+		addon := &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "search-collector", "namespace": "spoke-1"},
+			"spec":     map[string]interface{}{"installNamespace": "open-cluster-management-agent-addon"},
+			"status": map[string]interface{}{"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "False", "reason": "ManagedClusterAddOnLeaseUpdateStopped", "message": "lease not updated"},
+				map[string]interface{}{"type": "Degraded", "status": "True"},
+				map[string]interface{}{"type": "Progressing", "status": "False"},
+			}},
+		}}
+		summary := AddonSummary(addon)
+		if summary["name"] != "search-collector" || summary["installNamespace"] != "open-cluster-management-agent-addon" {
+			t.Errorf("unexpected summary: %v", summary)
+		}
+		if summary["available"] != "False" || summary["degraded"] != true {
+			t.Errorf("expected unavailable and degraded addon, got %v", summary)
+		}
+		if _, ok := summary["progressing"]; ok {
+			t.Errorf("expected addon not progressing")
+		}
+		if conditions, ok := summary["conditions"].([]map[string]any); !ok || len(conditions) != 3 {
+			t.Errorf("expected 3 conditions, got %v", summary["conditions"])
+		}
+	})
+	t.Run("without conditions", func(t *testing.T) {
+		summary := AddonSummary(&unstructured.Unstructured{Object: map[string]interface{}{"metadata": map[string]interface{}{"name": "work-manager"}}})
+		if summary["available"] != "Unknown" {
+			t.Errorf("expected Unknown availability, got %v", summary["available"])
+		}
+	})
+}
+
+func TestConfigurableAddons(t *testing.T) {
+	addons := ConfigurableAddons()
+	for _, expected := range []string{"application-manager", "observability-controller", "search-collector"} {
+		found := false
+		for _, addon := range addons {
+			found = found || addon == expected
+		}
+		if !found {
+			t.Errorf("expected %s to be configurable, got %v", expected, addons)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+const (
+	// AlertmanagerDefaultSilenceDuration is the default duration of the silences
+	AlertmanagerDefaultSilenceDuration = "2h"
+	// alertmanagerSilenceCreator is the creator of the silences if not provided
+	alertmanagerSilenceCreator = "kubernetes-mcp-server"
+)
+
+// ErrAlertmanagerNotFound is returned when no Alertmanager Service is found in the cluster
+var ErrAlertmanagerNotFound = errors.New("no Alertmanager service found in the cluster")
+
+// alertmanagerBackend finds the Alertmanager of the cluster
+var alertmanagerBackend = &monitoringBackend{
+	name:          "Alertmanager",
+	url:           func(c *config.StaticConfig) string { return c.AlertmanagerURL },
+	knownRoutes:   []monitoringService{{namespace: "openshift-monitoring", name: "alertmanager-main"}},
+	knownServices: []monitoringService{{namespace: "openshift-monitoring", name: "alertmanager-main", scheme: "https", port: "web"}},
+	selectors:     []string{"operated-alertmanager=true", "app.kubernetes.io/name=alertmanager", "app=alertmanager"},
+	components:    []string{"alertmanager"},
+	portNames:     []string{"web", "http-web", "http"},
+	notFound:      ErrAlertmanagerNotFound,
+}
+
+// AlertmanagerAlertsOptions filters the alerts of Alertmanager, the silenced and inhibited alerts are excluded unless Suppressed is set
+type AlertmanagerAlertsOptions struct {
+	Name        string
+	Severity    string
+	Namespace   string
+	Fingerprint string
+	Suppressed  bool
+	// Details includes the labels, annotations, and receivers of the alerts
+	Details bool
+}
+
+type AlertmanagerAlerts struct {
+	// Source is the Alertmanager that answered
+	Source string `json:"source"`
+	// Total is the number of alerts before filtering, Severities the number of matching alerts by severity
+	Total      int                 `json:"total"`
+	Severities map[string]int      `json:"severities,omitempty"`
+	Alerts     []AlertmanagerAlert `json:"alerts"`
+}
+
+type AlertmanagerAlert struct {
+	Name      string `json:"name"`
+	Severity  string `json:"severity,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// State is active, suppressed (silenced or inhibited), or unprocessed
+	State    string    `json:"state"`
+	StartsAt time.Time `json:"startsAt"`
+	// Summary is the summary (or message, or description) annotation of the alert
+	Summary     string `json:"summary,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+	// SilencedBy are the IDs of the silences of the alert, InhibitedBy the fingerprints of the alerts inhibiting it
+	SilencedBy  []string `json:"silencedBy,omitempty"`
+	InhibitedBy []string `json:"inhibitedBy,omitempty"`
+	// RunbookURL is the runbook_url annotation of the alert
+	RunbookURL   string            `json:"runbookURL,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	Receivers    []string          `json:"receivers,omitempty"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+// AlertmanagerSilence is a silence of Alertmanager, its matchers are rendered like PromQL label matchers (e.g. alertname="KubePodCrashLooping")
+type AlertmanagerSilence struct {
+	ID        string    `json:"id"`
+	State     string    `json:"state"`
+	Matchers  []string  `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	Comment   string    `json:"comment,omitempty"`
+}
+
+// AlertmanagerSilenceOptions creates a silence of the alerts with all the labels equal to the Matchers
+type AlertmanagerSilenceOptions struct {
+	Matchers map[string]string
+	// Duration is the duration of the silence starting now (e.g. 2h, 1d)
+	Duration  string
+	Comment   string
+	CreatedBy string
+}
+
+type alertmanagerMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual *bool  `json:"isEqual,omitempty"`
+}
+
+func (m alertmanagerMatcher) String() string {
+	operator := "="
+	if m.IsEqual != nil && !*m.IsEqual {
+		operator = "!"
+	}
+	if m.IsRegex {
+		operator += "~"
+	} else if operator == "!" {
+		operator = "!="
+	}
+	return fmt.Sprintf("%s%s%q", m.Name, operator, m.Value)
+}
+
+// AlertmanagerAlerts returns the alerts of the Alertmanager of the cluster matching the options, the most severe and oldest first
+func (k *Kubernetes) AlertmanagerAlerts(ctx context.Context, options AlertmanagerAlertsOptions) (*AlertmanagerAlerts, error) {
+	body, source, err := k.monitoringRequest(ctx, alertmanagerBackend, http.MethodGet, "/api/v2/alerts", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := parseAlertmanagerAlerts(body, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the alerts of %s: %w", source, err)
+	}
+	ret.Source = source
+	return ret, nil
+}
+
+// AlertmanagerSilences returns the silences of the Alertmanager of the cluster, the expired ones only if expired is set
+func (k *Kubernetes) AlertmanagerSilences(ctx context.Context, expired bool) ([]AlertmanagerSilence, error) {
+	body, source, err := k.monitoringRequest(ctx, alertmanagerBackend, http.MethodGet, "/api/v2/silences", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := parseAlertmanagerSilences(body, expired)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the silences of %s: %w", source, err)
+	}
+	return ret, nil
+}
+
+// AlertmanagerSilenceCreate silences the alerts matching the options from now on, and returns the created silence
+func (k *Kubernetes) AlertmanagerSilenceCreate(ctx context.Context, options AlertmanagerSilenceOptions) (*AlertmanagerSilence, error) {
+	if len(options.Matchers) == 0 {
+		return nil, errors.New("at least one matcher is required")
+	}
+	duration, err := parsePromQLDuration(cmp.Or(options.Duration, AlertmanagerDefaultSilenceDuration))
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("invalid duration %q, expected a duration (e.g. 30m, 2h, 1d)", options.Duration)
+	}
+	if strings.TrimSpace(options.Comment) == "" {
+		return nil, errors.New("a comment explaining the silence is required")
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	silence := struct {
+		Matchers  []alertmanagerMatcher `json:"matchers"`
+		StartsAt  time.Time             `json:"startsAt"`
+		EndsAt    time.Time             `json:"endsAt"`
+		CreatedBy string                `json:"createdBy"`
+		Comment   string                `json:"comment"`
+	}{StartsAt: now, EndsAt: now.Add(duration), CreatedBy: cmp.Or(options.CreatedBy, alertmanagerSilenceCreator), Comment: options.Comment}
+	for _, name := range slices.Sorted(maps.Keys(options.Matchers)) {
+		silence.Matchers = append(silence.Matchers, alertmanagerMatcher{Name: name, Value: options.Matchers[name], IsEqual: ptr.To(true)})
+	}
+	request, err := json.Marshal(silence)
+	if err != nil {
+		return nil, err
+	}
+	body, source, err := k.monitoringRequest(ctx, alertmanagerBackend, http.MethodPost, "/api/v2/silences", nil, nil, request)
+	if err != nil {
+		return nil, err
+	}
+	response := struct {
+		SilenceID string `json:"silenceID"`
+	}{}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse the silence created by %s: %w", source, err)
+	}
+	ret := &AlertmanagerSilence{ID: response.SilenceID, State: "active", StartsAt: silence.StartsAt, EndsAt: silence.EndsAt,
+		CreatedBy: silence.CreatedBy, Comment: silence.Comment}
+	for _, matcher := range silence.Matchers {
+		ret.Matchers = append(ret.Matchers, matcher.String())
+	}
+	return ret, nil
+}
+
+// AlertmanagerSilenceExpire expires the silence, the alerts it silenced are notified again
+func (k *Kubernetes) AlertmanagerSilenceExpire(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("the silence id is required")
+	}
+	_, _, err := k.monitoringRequest(ctx, alertmanagerBackend, http.MethodDelete, "/api/v2/silence/"+url.PathEscape(id), nil, nil, nil)
+	return err
+}
+
+// parseAlertmanagerAlerts parses the alerts of the Alertmanager HTTP API, keeping the ones matching the options
+func parseAlertmanagerAlerts(body []byte, options AlertmanagerAlertsOptions) (*AlertmanagerAlerts, error) {
+	var alerts []struct {
+		Labels       map[string]string `json:"labels"`
+		Annotations  map[string]string `json:"annotations"`
+		StartsAt     time.Time         `json:"startsAt"`
+		Fingerprint  string            `json:"fingerprint"`
+		GeneratorURL string            `json:"generatorURL"`
+		Receivers    []struct {
+			Name string `json:"name"`
+		} `json:"receivers"`
+		Status struct {
+			State       string   `json:"state"`
+			SilencedBy  []string `json:"silencedBy"`
+			InhibitedBy []string `json:"inhibitedBy"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(body, &alerts); err != nil {
+		return nil, err
+	}
+	ret := &AlertmanagerAlerts{Total: len(alerts), Severities: make(map[string]int), Alerts: []AlertmanagerAlert{}}
+	for _, alert := range alerts {
+		switch {
+		case options.Fingerprint != "" && alert.Fingerprint != options.Fingerprint,
+			options.Name != "" && alert.Labels["alertname"] != options.Name,
+			options.Severity != "" && !strings.EqualFold(alert.Labels["severity"], options.Severity),
+			options.Namespace != "" && alert.Labels["namespace"] != options.Namespace,
+			!options.Suppressed && options.Fingerprint == "" && alert.Status.State == "suppressed":
+			continue
+		}
+		summary := alert.Annotations["summary"]
+		for _, annotation := range []string{"message", "description"} {
+			if summary == "" {
+				summary = alert.Annotations[annotation]
+			}
+		}
+		a := AlertmanagerAlert{
+			Name:        alert.Labels["alertname"],
+			Severity:    alert.Labels["severity"],
+			Namespace:   alert.Labels["namespace"],
+			State:       alert.Status.State,
+			StartsAt:    alert.StartsAt,
+			Summary:     summary,
+			Fingerprint: alert.Fingerprint,
+			SilencedBy:  alert.Status.SilencedBy,
+			InhibitedBy: alert.Status.InhibitedBy,
+			RunbookURL:  alert.Annotations["runbook_url"],
+		}
+		if options.Details {
+			a.Labels, a.Annotations, a.GeneratorURL = alert.Labels, alert.Annotations, alert.GeneratorURL
+			for _, receiver := range alert.Receivers {
+				a.Receivers = append(a.Receivers, receiver.Name)
+			}
+		}
+		ret.Severities[cmp.Or(a.Severity, "none")]++
+		ret.Alerts = append(ret.Alerts, a)
+	}
+	slices.SortStableFunc(ret.Alerts, func(a, b AlertmanagerAlert) int {
+		return cmp.Or(
+			cmp.Compare(alertSeverityRank(a.Severity), alertSeverityRank(b.Severity)),
+			a.StartsAt.Compare(b.StartsAt),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+	return ret, nil
+}
+
+// alertSeverityRank ranks the usual severities of the alerting rules, the most severe first
+func alertSeverityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return 0
+	case "warning":
+		return 1
+	case "info":
+		return 2
+	default:
+		return 3
+	}
+}
+
+// parseAlertmanagerSilences parses the silences of the Alertmanager HTTP API, the active and pending ones first, ending soonest first
+func parseAlertmanagerSilences(body []byte, expired bool) ([]AlertmanagerSilence, error) {
+	var silences []struct {
+		ID       string                `json:"id"`
+		Matchers []alertmanagerMatcher `json:"matchers"`
+		StartsAt time.Time             `json:"startsAt"`
+		EndsAt   time.Time             `json:"endsAt"`
+		Status   struct {
+			State string `json:"state"`
+		} `json:"status"`
+		CreatedBy string `json:"createdBy"`
+		Comment   string `json:"comment"`
+	}
+	if err := json.Unmarshal(body, &silences); err != nil {
+		return nil, err
+	}
+	ret := []AlertmanagerSilence{}
+	for _, silence := range silences {
+		if silence.Status.State == "expired" && !expired {
+			continue
+		}
+		s := AlertmanagerSilence{ID: silence.ID, State: silence.Status.State, StartsAt: silence.StartsAt, EndsAt: silence.EndsAt,
+			CreatedBy: silence.CreatedBy, Comment: silence.Comment}
+		for _, matcher := range silence.Matchers {
+			s.Matchers = append(s.Matchers, matcher.String())
+		}
+		ret = append(ret, s)
+	}
+	slices.SortStableFunc(ret, func(a, b AlertmanagerSilence) int {
+		return cmp.Or(cmp.Compare(silenceStateRank(a.State), silenceStateRank(b.State)), a.EndsAt.Compare(b.EndsAt))
+	})
+	return ret, nil
+}
+
+// silenceStateRank ranks the active silences first, then the pending ones, then the expired ones
+func silenceStateRank(state string) int {
+	switch state {
+	case "active":
+		return 0
+	case "pending":
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,150 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+const alertmanagerAlertsResponse = `[
+	{"labels":{"alertname":"KubePodCrashLooping","severity":"warning","namespace":"ns-1","pod":"p"},"annotations":{"description":"Pod ns-1/p is crash looping","runbook_url":"https://runbooks/KubePodCrashLooping"},
+	 "startsAt":"2025-06-01T10:00:00Z","fingerprint":"a1","receivers":[{"name":"default"}],"status":{"state":"active"}},
+	{"labels":{"alertname":"KubeAPIDown","severity":"critical"},"annotations":{"summary":"API down"},
+	 "startsAt":"2025-06-01T11:00:00Z","fingerprint":"b2","status":{"state":"active"}},
+	{"labels":{"alertname":"Watchdog","severity":"none"},"annotations":{},
+	 "startsAt":"2025-06-01T09:00:00Z","fingerprint":"c3","status":{"state":"suppressed","silencedBy":["s1"]}}
+]`
+
+func TestParseAlertmanagerAlerts(t *testing.T) {
+	t.Run("active alerts, the most severe first", func(t *testing.T) {
+		ret, err := parseAlertmanagerAlerts([]byte(alertmanagerAlertsResponse), AlertmanagerAlertsOptions{})
+		if err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+		if ret.Total != 3 || len(ret.Alerts) != 2 || ret.Alerts[0].Name != "KubeAPIDown" || ret.Alerts[1].Name != "KubePodCrashLooping" {
+			t.Fatalf("unexpected alerts %+v", ret)
+		}
+		if ret.Alerts[1].Summary != "Pod ns-1/p is crash looping" || ret.Alerts[1].RunbookURL != "https://runbooks/KubePodCrashLooping" || ret.Alerts[1].Labels != nil {
+			t.Errorf("unexpected alert %+v", ret.Alerts[1])
+		}
+		if ret.Severities["critical"] != 1 || ret.Severities["warning"] != 1 {
+			t.Errorf("unexpected severities %v", ret.Severities)
+		}
+	})
+	t.Run("filtered by severity and namespace", func(t *testing.T) {
+		ret, _ := parseAlertmanagerAlerts([]byte(alertmanagerAlertsResponse), AlertmanagerAlertsOptions{Severity: "Warning", Namespace: "ns-1"})
+		if len(ret.Alerts) != 1 || ret.Alerts[0].Fingerprint != "a1" {
+			t.Errorf("unexpected alerts %+v", ret.Alerts)
+		}
+	})
+	t.Run("suppressed alerts", func(t *testing.T) {
+		ret, _ := parseAlertmanagerAlerts([]byte(alertmanagerAlertsResponse), AlertmanagerAlertsOptions{Suppressed: true})
+		if len(ret.Alerts) != 3 || ret.Alerts[2].Name != "Watchdog" || ret.Alerts[2].SilencedBy[0] != "s1" {
+			t.Errorf("unexpected alerts %+v", ret.Alerts)
+		}
+	})
+	t.Run("details by fingerprint", func(t *testing.T) {
+		ret, _ := parseAlertmanagerAlerts([]byte(alertmanagerAlertsResponse), AlertmanagerAlertsOptions{Fingerprint: "a1", Details: true})
+		if len(ret.Alerts) != 1 || ret.Alerts[0].Labels["pod"] != "p" || ret.Alerts[0].Receivers[0] != "default" {
+			t.Errorf("unexpected alerts %+v", ret.Alerts)
+		}
+	})
+}
+
+func TestParseAlertmanagerSilences(t *testing.T) {
+	body := []byte(`[
+		{"id":"s1","matchers":[{"name":"alertname","value":"Watchdog","isRegex":false,"isEqual":true}],"startsAt":"2025-06-01T09:00:00Z","endsAt":"2025-06-01T10:00:00Z","status":{"state":"expired"}},
+		{"id":"s2","matchers":[{"name":"namespace","value":"ns-.*","isRegex":true}],"startsAt":"2025-06-01T09:00:00Z","endsAt":"2025-06-02T10:00:00Z","status":{"state":"active"},"createdBy":"me","comment":"maintenance"},
+		{"id":"s3","matchers":[{"name":"severity","value":"info","isRegex":false,"isEqual":false}],"startsAt":"2025-06-01T09:00:00Z","endsAt":"2025-06-01T12:00:00Z","status":{"state":"active"}}
+	]`)
+	silences, err := parseAlertmanagerSilences(body, false)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if len(silences) != 2 || silences[0].ID != "s3" || silences[0].Matchers[0] != `severity!="info"` || silences[1].Matchers[0] != `namespace=~"ns-.*"` {
+		t.Errorf("unexpected silences %+v", silences)
+	}
+	if silences, _ = parseAlertmanagerSilences(body, true); len(silences) != 3 || silences[2].ID != "s1" {
+		t.Errorf("unexpected silences with the expired ones %+v", silences)
+	}
+}
+
+func TestAlertmanagerMatcherString(t *testing.T) {
+	for expected, matcher := range map[string]alertmanagerMatcher{
+		`alertname="Watchdog"`:   {Name: "alertname", Value: "Watchdog"},
+		`alertname!="Watchdog"`:  {Name: "alertname", Value: "Watchdog", IsEqual: ptr.To(false)},
+		`namespace=~"ns-.*"`:     {Name: "namespace", Value: "ns-.*", IsRegex: true, IsEqual: ptr.To(true)},
+		`namespace!~"openshift"`: {Name: "namespace", Value: "openshift", IsRegex: true, IsEqual: ptr.To(false)},
+	} {
+		if s := matcher.String(); s != expected {
+			t.Errorf("expected %s, got %s", expected, s)
+		}
+	}
+}
+
+func TestAlertmanagerSilences(t *testing.T) {
+	var method, path string
+	var silence map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &silence)
+			_, _ = w.Write([]byte(`{"silenceID":"new-id"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v2/silence/unknown":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`"silence unknown not found"`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	k := &Kubernetes{manager: &Manager{cfg: &rest.Config{}, staticConfig: &config.StaticConfig{AlertmanagerURL: server.URL}}}
+	t.Run("create", func(t *testing.T) {
+		ret, err := k.AlertmanagerSilenceCreate(context.Background(), AlertmanagerSilenceOptions{
+			Matchers: map[string]string{"namespace": "ns-1", "alertname": "KubePodCrashLooping"}, Duration: "1d", Comment: "known issue"})
+		if err != nil {
+			t.Fatalf("failed to create the silence: %v", err)
+		}
+		if ret.ID != "new-id" || ret.EndsAt.Sub(ret.StartsAt) != 24*time.Hour || ret.CreatedBy != "kubernetes-mcp-server" ||
+			strings.Join(ret.Matchers, ",") != `alertname="KubePodCrashLooping",namespace="ns-1"` {
+			t.Errorf("unexpected silence %+v", ret)
+		}
+		if matchers := silence["matchers"].([]any); len(matchers) != 2 || silence["comment"] != "known issue" {
+			t.Errorf("unexpected request %v", silence)
+		}
+	})
+	t.Run("create without matchers", func(t *testing.T) {
+		if _, err := k.AlertmanagerSilenceCreate(context.Background(), AlertmanagerSilenceOptions{Comment: "all"}); err == nil {
+			t.Errorf("expected an error without matchers")
+		}
+	})
+	t.Run("create without comment", func(t *testing.T) {
+		if _, err := k.AlertmanagerSilenceCreate(context.Background(), AlertmanagerSilenceOptions{Matchers: map[string]string{"a": "b"}}); err == nil {
+			t.Errorf("expected an error without comment")
+		}
+	})
+	t.Run("expire", func(t *testing.T) {
+		if err := k.AlertmanagerSilenceExpire(context.Background(), "s1"); err != nil || method != http.MethodDelete || path != "/api/v2/silence/s1" {
+			t.Errorf("unexpected request %s %s %v", method, path, err)
+		}
+	})
+	t.Run("expire unknown", func(t *testing.T) {
+		if err := k.AlertmanagerSilenceExpire(context.Background(), "unknown"); err == nil || !strings.Contains(err.Error(), "silence unknown not found (404)") {
+			t.Errorf("expected the Alertmanager error, got %v", err)
+		}
+	})
+}
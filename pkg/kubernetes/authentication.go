@@ -0,0 +1,21 @@
+package kubernetes
+
+import (
+	"context"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WhoAmI returns the username of the current identity using a SelfSubjectReview
+func (k *Kubernetes) WhoAmI(ctx context.Context) (string, error) {
+	selfSubjectReviews, err := k.manager.accessControlClientSet.SelfSubjectReviews()
+	if err != nil {
+		return "", err
+	}
+	response, err := selfSubjectReviews.Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	return response.Status.UserInfo.Username, nil
+}
@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"context"
+
+	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CanI checks whether the current identity is allowed to perform the provided access using a SelfSubjectAccessReview
+func (k *Kubernetes) CanI(ctx context.Context, attributes *authv1.ResourceAttributes) (bool, error) {
+	accessReviews, err := k.manager.accessControlClientSet.SelfSubjectAccessReviews()
+	if err != nil {
+		return false, err
+	}
+	response, err := accessReviews.Create(ctx, &authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attributes},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return response.Status.Allowed, nil
+}
+
+// AccessReview is the access checked by AuthCanI, for the current identity or for the impersonated user and groups
+type AccessReview struct {
+	AuthorizationAttributes
+	// NonResourcePath is the checked non-resource URL (e.g. /healthz), the resource attributes are ignored if provided
+	NonResourcePath string
+	// User and Groups are the checked identity, the current identity if both are empty
+	User   string
+	Groups []string
+}
+
+// AccessReviewResult is the outcome of an access review
+type AccessReviewResult struct {
+	Allowed bool `json:"allowed"`
+	// Denied is whether the access is explicitly denied, a request can be neither allowed nor denied (no opinion of the authorizers)
+	Denied          bool   `json:"denied,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+	EvaluationError string `json:"evaluationError,omitempty"`
+	// User is the identity the access was checked for, empty for the current identity
+	User   string   `json:"user,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// AuthCanI checks whether the current identity (SelfSubjectAccessReview), or the provided user and groups (SubjectAccessReview),
+// are allowed to perform the access, same as kubectl auth can-i [--as user --as-group group]
+func (k *Kubernetes) AuthCanI(ctx context.Context, review AccessReview) (*AccessReviewResult, error) {
+	var resourceAttributes *authv1.ResourceAttributes
+	var nonResourceAttributes *authv1.NonResourceAttributes
+	if review.NonResourcePath != "" {
+		nonResourceAttributes = &authv1.NonResourceAttributes{Path: review.NonResourcePath, Verb: review.Verb}
+	} else {
+		resourceAttributes = &authv1.ResourceAttributes{
+			Verb:        review.Verb,
+			Group:       review.Group,
+			Resource:    review.Resource,
+			Subresource: review.Subresource,
+			Namespace:   review.Namespace,
+			Name:        review.Name,
+		}
+	}
+	ret := &AccessReviewResult{User: review.User, Groups: review.Groups}
+	var status authv1.SubjectAccessReviewStatus
+	if review.User == "" && len(review.Groups) == 0 {
+		accessReviews, err := k.manager.accessControlClientSet.SelfSubjectAccessReviews()
+		if err != nil {
+			return nil, err
+		}
+		response, err := accessReviews.Create(ctx, &authv1.SelfSubjectAccessReview{
+			Spec: authv1.SelfSubjectAccessReviewSpec{ResourceAttributes: resourceAttributes, NonResourceAttributes: nonResourceAttributes},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+		status = response.Status
+	} else {
+		accessReviews, err := k.manager.accessControlClientSet.SubjectAccessReviews()
+		if err != nil {
+			return nil, err
+		}
+		response, err := accessReviews.Create(ctx, &authv1.SubjectAccessReview{
+			Spec: authv1.SubjectAccessReviewSpec{
+				ResourceAttributes:    resourceAttributes,
+				NonResourceAttributes: nonResourceAttributes,
+				User:                  review.User,
+				Groups:                review.Groups,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+		status = response.Status
+	}
+	ret.Allowed, ret.Denied, ret.Reason, ret.EvaluationError = status.Allowed, status.Denied, status.Reason, status.EvaluationError
+	return ret, nil
+}
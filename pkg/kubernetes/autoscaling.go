@@ -0,0 +1,279 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+var horizontalPodAutoscalerGVK = &schema.GroupVersionKind{Group: "autoscaling", Version: "v2", Kind: "HorizontalPodAutoscaler"}
+
+// HorizontalPodAutoscaler is the summary of a HorizontalPodAutoscaler with its current and target metrics
+type HorizontalPodAutoscaler struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Target is the Kind/name of the scaled resource
+	Target          string `json:"target"`
+	MinReplicas     int32  `json:"minReplicas"`
+	MaxReplicas     int32  `json:"maxReplicas"`
+	CurrentReplicas int32  `json:"currentReplicas"`
+	DesiredReplicas int32  `json:"desiredReplicas"`
+	LastScaleTime   string `json:"lastScaleTime,omitempty"`
+	// Metrics are the metrics of the autoscaler as name: current / target
+	Metrics    []string                           `json:"metrics,omitempty"`
+	Conditions []HorizontalPodAutoscalerCondition `json:"conditions,omitempty"`
+	// Events are the scaling events of the autoscaler (e.g. SuccessfulRescale, FailedGetResourceMetric)
+	Events []string `json:"events,omitempty"`
+	// Issues explains why the autoscaler is not scaling the target
+	Issues []string `json:"issues,omitempty"`
+}
+
+type HorizontalPodAutoscalerCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// HorizontalPodAutoscalersList lists the HorizontalPodAutoscalers with their current and target metrics, conditions,
+// scaling events, and the issues explaining why they are not scaling, in all namespaces if no namespace is provided
+func (k *Kubernetes) HorizontalPodAutoscalersList(ctx context.Context, namespace string) ([]HorizontalPodAutoscaler, error) {
+	list, err := k.ResourcesList(ctx, horizontalPodAutoscalerGVK, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]HorizontalPodAutoscaler, 0)
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, hpa); err != nil {
+			return nil, err
+		}
+		ret = append(ret, horizontalPodAutoscaler(hpa))
+	}
+	if len(ret) == 0 {
+		return ret, nil
+	}
+	events, err := k.horizontalPodAutoscalerEvents(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ret {
+		ret[i].Events = events[ret[i].Namespace+"/"+ret[i].Name]
+	}
+	return ret, nil
+}
+
+// HorizontalPodAutoscalersGet returns the HorizontalPodAutoscaler with its scaling events and the issues explaining why it is not scaling
+func (k *Kubernetes) HorizontalPodAutoscalersGet(ctx context.Context, namespace, name string) (*HorizontalPodAutoscaler, error) {
+	namespace = k.NamespaceOrDefault(namespace)
+	item, err := k.ResourcesGet(ctx, horizontalPodAutoscalerGVK, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, hpa); err != nil {
+		return nil, err
+	}
+	ret := horizontalPodAutoscaler(hpa)
+	events, err := k.horizontalPodAutoscalerEvents(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	ret.Events = events[namespace+"/"+name]
+	return &ret, nil
+}
+
+// horizontalPodAutoscalerEvents returns the distinct event messages of the HorizontalPodAutoscalers by namespace/name
+func (k *Kubernetes) horizontalPodAutoscalerEvents(ctx context.Context, namespace string) (map[string][]string, error) {
+	events, err := k.ResourcesList(ctx, eventGVK, namespace, ResourceListOptions{ListOptions: metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.kind", horizontalPodAutoscalerGVK.Kind).String(),
+	}})
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string][]string)
+	for _, item := range events.(*unstructured.UnstructuredList).Items {
+		event := &v1.Event{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, event); err != nil {
+			return nil, err
+		}
+		key := event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name
+		message := fmt.Sprintf("%s %s: %s", event.Type, event.Reason, strings.TrimSpace(event.Message))
+		if !slices.Contains(ret[key], message) {
+			ret[key] = append(ret[key], message)
+		}
+	}
+	return ret, nil
+}
+
+func horizontalPodAutoscaler(hpa *autoscalingv2.HorizontalPodAutoscaler) HorizontalPodAutoscaler {
+	ret := HorizontalPodAutoscaler{
+		Namespace:       hpa.Namespace,
+		Name:            hpa.Name,
+		Target:          hpa.Spec.ScaleTargetRef.Kind + "/" + hpa.Spec.ScaleTargetRef.Name,
+		MinReplicas:     ptr.Deref(hpa.Spec.MinReplicas, 1),
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+	}
+	if hpa.Status.LastScaleTime != nil {
+		ret.LastScaleTime = hpa.Status.LastScaleTime.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	var missing []string
+	for _, metric := range hpa.Spec.Metrics {
+		name := metricSpecName(&metric)
+		current := "<unknown>"
+		for _, status := range hpa.Status.CurrentMetrics {
+			if metricStatusName(&status) == name {
+				current = metricCurrent(&status)
+			}
+		}
+		if current == "<unknown>" {
+			missing = append(missing, name)
+		}
+		ret.Metrics = append(ret.Metrics, fmt.Sprintf("%s: %s / %s", name, current, metricTarget(&metric)))
+	}
+	for _, condition := range hpa.Status.Conditions {
+		ret.Conditions = append(ret.Conditions, HorizontalPodAutoscalerCondition{
+			Type:    string(condition.Type),
+			Status:  string(condition.Status),
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		})
+	}
+	ret.Issues = horizontalPodAutoscalerIssues(hpa, missing)
+	return ret
+}
+
+// horizontalPodAutoscalerIssues explains why the autoscaler is not scaling the target from its conditions, replicas and metrics
+func horizontalPodAutoscalerIssues(hpa *autoscalingv2.HorizontalPodAutoscaler, missingMetrics []string) []string {
+	var issues []string
+	for _, condition := range hpa.Status.Conditions {
+		switch {
+		case condition.Type == autoscalingv2.AbleToScale && condition.Status == v1.ConditionFalse:
+			issues = append(issues, fmt.Sprintf("the autoscaler is not able to scale %s/%s (%s): %s",
+				hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name, condition.Reason, condition.Message))
+		case condition.Type == autoscalingv2.AbleToScale && condition.Reason == "ScaleDownStabilized":
+			issues = append(issues, "the scale down is held by the stabilization window: "+condition.Message)
+		case condition.Type == autoscalingv2.ScalingActive && condition.Status == v1.ConditionFalse && condition.Reason == "ScalingDisabled":
+			issues = append(issues, "scaling is disabled because the target has zero replicas: "+condition.Message)
+		case condition.Type == autoscalingv2.ScalingActive && condition.Status == v1.ConditionFalse:
+			issues = append(issues, fmt.Sprintf("the autoscaler can't compute the desired replicas (%s): %s%s",
+				condition.Reason, condition.Message, metricsHint(condition.Reason, condition.Message)))
+		case condition.Type == autoscalingv2.ScalingLimited && condition.Status == v1.ConditionTrue:
+			issues = append(issues, fmt.Sprintf("the desired replicas are limited by the autoscaler bounds (%s): %s", condition.Reason, condition.Message))
+		}
+	}
+	for _, metric := range missingMetrics {
+		issues = append(issues, fmt.Sprintf("the metric %s has no current value, check the metrics pipeline (metrics-server or the custom/external metrics adapter)", metric))
+	}
+	if len(hpa.Status.Conditions) == 0 {
+		issues = append(issues, "the autoscaler has no status conditions, check that the controller manager is running and the target exists")
+	}
+	return issues
+}
+
+// metricsHint suggests the most common causes of a failure to get the metrics
+func metricsHint(reason, message string) string {
+	switch {
+	case strings.Contains(message, "missing request for"):
+		return ", the containers of the target must define resource requests for utilization targets"
+	case strings.Contains(message, "unable to fetch metrics from resource metrics API") || strings.Contains(message, "the server could not find the requested resource"):
+		return ", check that metrics-server (metrics.k8s.io) is installed and available"
+	case reason == "FailedGetPodsMetric" || reason == "FailedGetObjectMetric" || reason == "FailedGetExternalMetric":
+		return ", check that the custom or external metrics adapter is installed and exposes the metric"
+	}
+	return ""
+}
+
+func metricSpecName(metric *autoscalingv2.MetricSpec) string {
+	switch {
+	case metric.Resource != nil:
+		return "resource " + string(metric.Resource.Name)
+	case metric.ContainerResource != nil:
+		return fmt.Sprintf("container resource %s of %s", metric.ContainerResource.Name, metric.ContainerResource.Container)
+	case metric.Pods != nil:
+		return "pods metric " + metric.Pods.Metric.Name
+	case metric.Object != nil:
+		return fmt.Sprintf("object %s/%s metric %s", metric.Object.DescribedObject.Kind, metric.Object.DescribedObject.Name, metric.Object.Metric.Name)
+	case metric.External != nil:
+		return "external metric " + metric.External.Metric.Name
+	}
+	return string(metric.Type)
+}
+
+func metricStatusName(status *autoscalingv2.MetricStatus) string {
+	switch {
+	case status.Resource != nil:
+		return "resource " + string(status.Resource.Name)
+	case status.ContainerResource != nil:
+		return fmt.Sprintf("container resource %s of %s", status.ContainerResource.Name, status.ContainerResource.Container)
+	case status.Pods != nil:
+		return "pods metric " + status.Pods.Metric.Name
+	case status.Object != nil:
+		return fmt.Sprintf("object %s/%s metric %s", status.Object.DescribedObject.Kind, status.Object.DescribedObject.Name, status.Object.Metric.Name)
+	case status.External != nil:
+		return "external metric " + status.External.Metric.Name
+	}
+	return string(status.Type)
+}
+
+func metricTarget(metric *autoscalingv2.MetricSpec) string {
+	var target autoscalingv2.MetricTarget
+	switch {
+	case metric.Resource != nil:
+		target = metric.Resource.Target
+	case metric.ContainerResource != nil:
+		target = metric.ContainerResource.Target
+	case metric.Pods != nil:
+		target = metric.Pods.Target
+	case metric.Object != nil:
+		target = metric.Object.Target
+	case metric.External != nil:
+		target = metric.External.Target
+	}
+	switch {
+	case target.Type == autoscalingv2.UtilizationMetricType && target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.Type == autoscalingv2.AverageValueMetricType && target.AverageValue != nil:
+		return target.AverageValue.String() + " (average)"
+	case target.Value != nil:
+		return target.Value.String()
+	}
+	return "<unknown>"
+}
+
+func metricCurrent(status *autoscalingv2.MetricStatus) string {
+	var current autoscalingv2.MetricValueStatus
+	switch {
+	case status.Resource != nil:
+		current = status.Resource.Current
+	case status.ContainerResource != nil:
+		current = status.ContainerResource.Current
+	case status.Pods != nil:
+		current = status.Pods.Current
+	case status.Object != nil:
+		current = status.Object.Current
+	case status.External != nil:
+		current = status.External.Current
+	}
+	switch {
+	case current.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *current.AverageUtilization)
+	case current.AverageValue != nil:
+		return current.AverageValue.String() + " (average)"
+	case current.Value != nil:
+		return current.Value.String()
+	}
+	return "<unknown>"
+}
@@ -0,0 +1,78 @@
+package kubernetes
+
+import (
+	"slices"
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestHorizontalPodAutoscaler(t *testing.T) {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web"},
+			MaxReplicas:    5,
+			Metrics: []autoscalingv2.MetricSpec{
+				{Type: autoscalingv2.ResourceMetricSourceType, Resource: &autoscalingv2.ResourceMetricSource{
+					Name:   v1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: ptr.To(int32(80))},
+				}},
+				{Type: autoscalingv2.PodsMetricSourceType, Pods: &autoscalingv2.PodsMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{Name: "requests_per_second"},
+					Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: ptr.To(resource.MustParse("10"))},
+				}},
+			},
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 5,
+			DesiredReplicas: 5,
+			CurrentMetrics: []autoscalingv2.MetricStatus{{Type: autoscalingv2.ResourceMetricSourceType, Resource: &autoscalingv2.ResourceMetricStatus{
+				Name:    v1.ResourceCPU,
+				Current: autoscalingv2.MetricValueStatus{AverageUtilization: ptr.To(int32(95))},
+			}}},
+			Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{Type: autoscalingv2.AbleToScale, Status: v1.ConditionTrue, Reason: "ReadyForNewScale"},
+				{Type: autoscalingv2.ScalingActive, Status: v1.ConditionTrue, Reason: "ValidMetricFound"},
+				{Type: autoscalingv2.ScalingLimited, Status: v1.ConditionTrue, Reason: "TooManyReplicas", Message: "the desired replica count is more than the maximum replica count"},
+			},
+		},
+	}
+	ret := horizontalPodAutoscaler(hpa)
+	t.Run("describes the current and target metrics", func(t *testing.T) {
+		expected := []string{"resource cpu: 95% / 80%", "pods metric requests_per_second: <unknown> / 10 (average)"}
+		if !slices.Equal(ret.Metrics, expected) {
+			t.Errorf("unexpected metrics %v", ret.Metrics)
+		}
+		if ret.Target != "Deployment/web" || ret.MinReplicas != 1 || ret.MaxReplicas != 5 {
+			t.Errorf("unexpected autoscaler %v", ret)
+		}
+	})
+	t.Run("reports the limited replicas and the missing metrics", func(t *testing.T) {
+		expected := []string{
+			"the desired replicas are limited by the autoscaler bounds (TooManyReplicas): the desired replica count is more than the maximum replica count",
+			"the metric pods metric requests_per_second has no current value, check the metrics pipeline (metrics-server or the custom/external metrics adapter)",
+		}
+		if !slices.Equal(ret.Issues, expected) {
+			t.Errorf("unexpected issues %v", ret.Issues)
+		}
+	})
+	t.Run("reports the missing resource requests", func(t *testing.T) {
+		failing := hpa.DeepCopy()
+		failing.Spec.Metrics = failing.Spec.Metrics[:1]
+		failing.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{{
+			Type: autoscalingv2.ScalingActive, Status: v1.ConditionFalse, Reason: "FailedGetResourceMetric",
+			Message: "the HPA was unable to compute the replica count: failed to get cpu utilization: missing request for cpu in container nginx",
+		}}
+		expected := []string{"the autoscaler can't compute the desired replicas (FailedGetResourceMetric): " +
+			"the HPA was unable to compute the replica count: failed to get cpu utilization: missing request for cpu in container nginx, " +
+			"the containers of the target must define resource requests for utilization targets"}
+		if issues := horizontalPodAutoscaler(failing).Issues; !slices.Equal(issues, expected) {
+			t.Errorf("unexpected issues %v", issues)
+		}
+	})
+}
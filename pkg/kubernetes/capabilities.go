@@ -0,0 +1,62 @@
+package kubernetes
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// Capability is an optional feature of the target cluster that toolsets may depend on
+type Capability string
+
+const (
+	CapabilityOpenShift          Capability = "openshift"
+	CapabilityMetricsServer      Capability = "metrics-server"
+	CapabilityPrometheusOperator Capability = "prometheus-operator"
+	CapabilityACM                Capability = "acm"
+)
+
+// capabilityGroupVersions are the API group versions served by the cluster when the capability is available
+var capabilityGroupVersions = map[Capability]schema.GroupVersion{
+	CapabilityOpenShift:          {Group: "project.openshift.io", Version: "v1"},
+	CapabilityMetricsServer:      {Group: "metrics.k8s.io", Version: "v1beta1"},
+	CapabilityPrometheusOperator: {Group: "monitoring.coreos.com", Version: "v1"},
+	CapabilityACM:                {Group: "cluster.open-cluster-management.io", Version: "v1"},
+}
+
+// Capabilities is the sorted list of capabilities available in the target cluster
+type Capabilities []Capability
+
+// Has returns whether the capability is available
+func (c Capabilities) Has(capability Capability) bool {
+	return slices.Contains(c, capability)
+}
+
+func (c Capabilities) String() string {
+	names := make([]string, 0, len(c))
+	for _, capability := range c {
+		names = append(names, string(capability))
+	}
+	return strings.Join(names, ", ")
+}
+
+// Capabilities probes the target cluster for the available capabilities.
+// The cached discovery information is refreshed, so that capabilities installed or removed since the last probe are detected.
+func (m *Manager) Capabilities(_ context.Context) Capabilities {
+	m.discoveryClient.Invalidate()
+	return detectCapabilities(m.discoveryClient)
+}
+
+func detectCapabilities(discoveryClient discovery.ServerResourcesInterface) Capabilities {
+	capabilities := make(Capabilities, 0, len(capabilityGroupVersions))
+	for capability, groupVersion := range capabilityGroupVersions {
+		if _, err := discoveryClient.ServerResourcesForGroupVersion(groupVersion.String()); err == nil {
+			capabilities = append(capabilities, capability)
+		}
+	}
+	slices.Sort(capabilities)
+	return capabilities
+}
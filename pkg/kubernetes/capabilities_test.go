@@ -0,0 +1,34 @@
+package kubernetes
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestDetectCapabilities(t *testing.T) {
+	t.Run("without optional APIs", func(t *testing.T) {
+		discoveryClient := &fake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+			{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "pods"}}},
+		}}}
+		if capabilities := detectCapabilities(discoveryClient); len(capabilities) != 0 {
+			t.Errorf("expected no capabilities, got %s", capabilities)
+		}
+	})
+	t.Run("with optional APIs", func(t *testing.T) {
+		discoveryClient := &fake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+			{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "pods"}}},
+			{GroupVersion: "metrics.k8s.io/v1beta1", APIResources: []metav1.APIResource{{Name: "pods"}}},
+			{GroupVersion: "cluster.open-cluster-management.io/v1", APIResources: []metav1.APIResource{{Name: "managedclusters"}}},
+		}}}
+		capabilities := detectCapabilities(discoveryClient)
+		if capabilities.String() != "acm, metrics-server" {
+			t.Errorf("expected acm and metrics-server capabilities, got %s", capabilities)
+		}
+		if !capabilities.Has(CapabilityACM) || capabilities.Has(CapabilityOpenShift) {
+			t.Errorf("unexpected capabilities %s", capabilities)
+		}
+	})
+}
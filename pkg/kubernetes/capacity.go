@@ -0,0 +1,363 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	resourcehelper "k8s.io/kubectl/pkg/util/resource"
+)
+
+// capacityMaxReplicas bounds the simulation of the replicas the cluster can host (e.g. pods without requests on nodes without a pods limit)
+const capacityMaxReplicas = 10000
+
+// nodePoolLabels are the well-known labels of the node pools of the managed Kubernetes services and autoscalers, the first one set wins
+var nodePoolLabels = []string{
+	"cloud.google.com/gke-nodepool",
+	"eks.amazonaws.com/nodegroup",
+	"kubernetes.azure.com/agentpool",
+	"karpenter.sh/nodepool",
+	"node.kubernetes.io/pool",
+}
+
+// capacityWorkloadKinds are the workloads whose pod template can be simulated, with the path of the template
+var capacityWorkloadKinds = map[string]struct {
+	gvk      schema.GroupVersionKind
+	template []string
+}{
+	"Deployment":  {schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, []string{"spec", "template"}},
+	"StatefulSet": {schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}, []string{"spec", "template"}},
+	"ReplicaSet":  {schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}, []string{"spec", "template"}},
+	"Job":         {schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}, []string{"spec", "template"}},
+	"CronJob":     {schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"}, []string{"spec", "jobTemplate", "spec", "template"}},
+}
+
+type CapacityReportOptions struct {
+	// PoolLabel is the node label the nodes are grouped by, the well-known node pool labels (or the node roles) if empty
+	PoolLabel string
+	// Kind, Namespace, and Name are the workload whose pod template is simulated, optional
+	Kind      string
+	Namespace string
+	Name      string
+	// Manifest is the YAML or JSON of the Pod (or of a workload with a pod template) to simulate, optional
+	Manifest string
+}
+
+// CapacityReport is the schedulable headroom of the node pools, with the simulation of a pod if provided
+type CapacityReport struct {
+	Pools      []NodePoolCapacity  `json:"pools"`
+	Simulation *CapacitySimulation `json:"simulation,omitempty"`
+}
+
+// NodePoolCapacity is the capacity of the schedulable (ready and not cordoned) nodes of a pool
+type NodePoolCapacity struct {
+	Pool             string                     `json:"pool"`
+	Nodes            int                        `json:"nodes"`
+	SchedulableNodes int                        `json:"schedulableNodes"`
+	Resources        []NodePoolResourceHeadroom `json:"resources"`
+}
+
+// NodePoolResourceHeadroom is the allocatable amount of a resource minus the requests of the pods of the schedulable nodes of a pool
+type NodePoolResourceHeadroom struct {
+	Resource        string `json:"resource"`
+	Allocatable     string `json:"allocatable"`
+	Requests        string `json:"requests"`
+	RequestsPercent int64  `json:"requestsPercent"`
+	Headroom        string `json:"headroom"`
+	// LargestNodeHeadroom is the headroom of the node with the most headroom, the largest request a single pod can have in the pool
+	LargestNodeHeadroom string `json:"largestNodeHeadroom"`
+}
+
+// CapacitySimulation is whether a pod fits in the cluster now, and how many replicas of it the cluster can still host
+type CapacitySimulation struct {
+	Pod      string            `json:"pod"`
+	Requests map[string]string `json:"requests,omitempty"`
+	Fits     bool              `json:"fits"`
+	// FittingNodes is the number of nodes the pod fits on now
+	FittingNodes int `json:"fittingNodes"`
+	// Replicas is the number of additional replicas of the pod the cluster can host, per pool in ReplicasPerPool
+	Replicas        int            `json:"replicas"`
+	ReplicasPerPool map[string]int `json:"replicasPerPool,omitempty"`
+	// LimitedBy are the constraints preventing more replicas, the number of nodes rejecting one more replica by each constraint
+	LimitedBy   []string `json:"limitedBy,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// CapacityReport computes the schedulable headroom (allocatable minus the requests of the scheduled pods) of the ready and not cordoned nodes
+// per node pool. If a workload or a manifest is provided, simulates whether its pod fits on the nodes with the constraints of pods_why_pending,
+// and estimates how many more replicas the cluster can host by placing them one by one on the nodes (round-robin, so that the topology spread
+// and anti-affinity constraints between the replicas are honored). The volumes of the pod are ignored.
+func (k *Kubernetes) CapacityReport(ctx context.Context, options CapacityReportOptions) (*CapacityReport, error) {
+	var pod *v1.Pod
+	var source string
+	var err error
+	switch {
+	case options.Manifest != "" && options.Name != "":
+		return nil, errors.New("either a workload or a manifest can be simulated, not both")
+	case options.Manifest != "":
+		pod, source, err = capacityManifestPod(options.Manifest, k.NamespaceOrDefault(options.Namespace))
+	case options.Name != "":
+		pod, source, err = k.capacityWorkloadPod(ctx, options.Kind, k.NamespaceOrDefault(options.Namespace), options.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sc := &schedulingContext{pod: pod, pods: make(map[string][]v1.Pod)}
+	if err = k.schedulingCluster(ctx, sc); err != nil {
+		return nil, err
+	}
+	ret := &CapacityReport{Pools: nodePoolsCapacity(sc, options.PoolLabel)}
+	if pod != nil {
+		ret.Simulation = simulateCapacity(sc, options.PoolLabel, source)
+	}
+	return ret, nil
+}
+
+func (k *Kubernetes) capacityWorkloadPod(ctx context.Context, kind, namespace, name string) (*v1.Pod, string, error) {
+	workload, ok := capacityWorkloadKinds[kind]
+	if !ok {
+		return nil, "", fmt.Errorf("kind %s has no pod template, supported kinds are %v", kind, slices.Sorted(maps.Keys(capacityWorkloadKinds)))
+	}
+	obj, err := k.ResourcesGet(ctx, &workload.gvk, namespace, name)
+	if err != nil {
+		return nil, "", err
+	}
+	pod, err := capacityTemplatePod(obj, workload.template)
+	if err != nil {
+		return nil, "", err
+	}
+	return pod, fmt.Sprintf("%s %s/%s", kind, namespace, name), nil
+}
+
+// capacityManifestPod returns the Pod of the manifest, or the pod of the template of the workload of the manifest
+func capacityManifestPod(manifest, namespace string) (*v1.Pod, string, error) {
+	obj := &unstructured.Unstructured{}
+	if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096).Decode(&obj.Object); err != nil {
+		return nil, "", fmt.Errorf("failed to parse the manifest: %w", err)
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(namespace)
+	}
+	source := fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	if obj.GetKind() == "Pod" {
+		pod := &v1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pod); err != nil {
+			return nil, "", err
+		}
+		pod.Spec.NodeName = ""
+		return pod, source, nil
+	}
+	workload, ok := capacityWorkloadKinds[obj.GetKind()]
+	if !ok {
+		return nil, "", fmt.Errorf("the manifest must be a Pod or a workload with a pod template (%v), got %q", slices.Sorted(maps.Keys(capacityWorkloadKinds)), obj.GetKind())
+	}
+	pod, err := capacityTemplatePod(obj, workload.template)
+	return pod, source, err
+}
+
+func capacityTemplatePod(obj *unstructured.Unstructured, path []string) (*v1.Pod, error) {
+	template, found, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil || !found {
+		return nil, fmt.Errorf("%s %s has no pod template", obj.GetKind(), obj.GetName())
+	}
+	podTemplate := &v1.PodTemplateSpec{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(template, podTemplate); err != nil {
+		return nil, err
+	}
+	pod := &v1.Pod{ObjectMeta: podTemplate.ObjectMeta, Spec: podTemplate.Spec}
+	pod.Namespace, pod.Name = obj.GetNamespace(), obj.GetName()
+	return pod, nil
+}
+
+// nodePool returns the pool of the node: the value of the pool label, of the well-known node pool labels, or the roles of the node
+func nodePool(node *v1.Node, poolLabel string) string {
+	if poolLabel != "" {
+		return cmp.Or(node.Labels[poolLabel], "<none>")
+	}
+	for _, label := range nodePoolLabels {
+		if pool, ok := node.Labels[label]; ok && pool != "" {
+			return pool
+		}
+	}
+	var roles []string
+	for label := range node.Labels {
+		if role, ok := strings.CutPrefix(label, "node-role.kubernetes.io/"); ok && role != "" {
+			roles = append(roles, role)
+		}
+	}
+	slices.Sort(roles)
+	return cmp.Or(strings.Join(roles, ","), "<none>")
+}
+
+// nodeSchedulable returns whether the node is ready and not cordoned
+func nodeSchedulable(node *v1.Node) bool {
+	return !node.Spec.Unschedulable && slices.ContainsFunc(node.Status.Conditions, func(c v1.NodeCondition) bool {
+		return c.Type == v1.NodeReady && c.Status == v1.ConditionTrue
+	})
+}
+
+// nodeHeadroom returns the allocatable resources of the node minus the requests of its pods, the number of pods for the pods resource
+func nodeHeadroom(node *v1.Node, pods []v1.Pod) (requests, headroom v1.ResourceList) {
+	requests = v1.ResourceList{v1.ResourcePods: *resource.NewQuantity(int64(len(pods)), resource.DecimalSI)}
+	for _, pod := range pods {
+		podRequests, _ := resourcehelper.PodRequestsAndLimits(&pod)
+		for name, quantity := range podRequests {
+			addResource(requests, name, quantity)
+		}
+	}
+	headroom = v1.ResourceList{}
+	for name, allocatable := range node.Status.Allocatable {
+		available := allocatable.DeepCopy()
+		available.Sub(requests[name])
+		if available.Sign() < 0 {
+			available = resource.Quantity{}
+		}
+		headroom[name] = available
+	}
+	return requests, headroom
+}
+
+func nodePoolsCapacity(sc *schedulingContext, poolLabel string) []NodePoolCapacity {
+	type totals struct {
+		capacity                        *NodePoolCapacity
+		allocatable, requests, headroom v1.ResourceList
+		largestHeadroom                 v1.ResourceList
+	}
+	pools := make(map[string]*totals)
+	for _, node := range sc.nodes {
+		pool := nodePool(&node, poolLabel)
+		t, ok := pools[pool]
+		if !ok {
+			t = &totals{capacity: &NodePoolCapacity{Pool: pool}, allocatable: v1.ResourceList{}, requests: v1.ResourceList{},
+				headroom: v1.ResourceList{}, largestHeadroom: v1.ResourceList{}}
+			pools[pool] = t
+		}
+		t.capacity.Nodes++
+		if !nodeSchedulable(&node) {
+			continue
+		}
+		t.capacity.SchedulableNodes++
+		requests, headroom := nodeHeadroom(&node, sc.pods[node.Name])
+		for _, name := range nodesHealthResources {
+			addResource(t.allocatable, name, node.Status.Allocatable[name])
+			addResource(t.requests, name, requests[name])
+			addResource(t.headroom, name, headroom[name])
+			if available, largest := headroom[name], t.largestHeadroom[name]; available.Cmp(largest) > 0 {
+				t.largestHeadroom[name] = available
+			}
+		}
+	}
+	var ret []NodePoolCapacity
+	for _, pool := range slices.Sorted(maps.Keys(pools)) {
+		t := pools[pool]
+		for _, name := range nodesHealthResources {
+			allocatable, requests := t.allocatable[name], t.requests[name]
+			headroom := NodePoolResourceHeadroom{
+				Resource:            string(name),
+				Allocatable:         formatCapacity(name, allocatable),
+				Requests:            formatCapacity(name, requests),
+				Headroom:            formatCapacity(name, t.headroom[name]),
+				LargestNodeHeadroom: formatCapacity(name, t.largestHeadroom[name]),
+			}
+			if !allocatable.IsZero() {
+				headroom.RequestsPercent = requests.MilliValue() * 100 / allocatable.MilliValue()
+			}
+			t.capacity.Resources = append(t.capacity.Resources, headroom)
+		}
+		ret = append(ret, *t.capacity)
+	}
+	return ret
+}
+
+// simulateCapacity evaluates the fit of the pod of the scheduling context on each schedulable node, then places replicas of the pod
+// on the nodes round-robin until no node accepts one more
+func simulateCapacity(sc *schedulingContext, poolLabel, source string) *CapacitySimulation {
+	ret := &CapacitySimulation{Pod: source, Requests: make(map[string]string), ReplicasPerPool: make(map[string]int)}
+	requests, _ := resourcehelper.PodRequestsAndLimits(sc.pod)
+	for name, quantity := range requests {
+		if !quantity.IsZero() {
+			ret.Requests[string(name)] = formatCapacity(name, quantity)
+		}
+	}
+	var candidates []*v1.Node
+	for i := range sc.nodes {
+		if node := &sc.nodes[i]; nodeSchedulable(node) && len(nodeSchedulingReasons(sc, node)) == 0 {
+			candidates = append(candidates, node)
+		}
+	}
+	ret.FittingNodes = len(candidates)
+	ret.Fits = len(candidates) > 0
+	for len(candidates) > 0 && ret.Replicas < capacityMaxReplicas {
+		var next []*v1.Node
+		for _, node := range candidates {
+			if ret.Replicas >= capacityMaxReplicas || len(nodeSchedulingReasons(sc, node)) > 0 {
+				continue
+			}
+			replica := sc.pod.DeepCopy()
+			replica.Name = fmt.Sprintf("%s-capacity-%d", sc.pod.Name, ret.Replicas)
+			replica.Spec.NodeName = node.Name
+			sc.pods[node.Name] = append(sc.pods[node.Name], *replica)
+			ret.Replicas++
+			ret.ReplicasPerPool[nodePool(node, poolLabel)]++
+			next = append(next, node)
+		}
+		candidates = next
+	}
+	// The constraints rejecting one more replica on the schedulable nodes
+	limitedBy := make(map[string]int)
+	suggestions := make(map[string]string)
+	schedulable := 0
+	for i := range sc.nodes {
+		node := &sc.nodes[i]
+		if !nodeSchedulable(node) {
+			continue
+		}
+		schedulable++
+		for _, reason := range nodeSchedulingReasons(sc, node) {
+			limitedBy[reason.constraint]++
+			suggestions[reason.constraint] = reason.suggestion
+		}
+	}
+	constraints := slices.Collect(maps.Keys(limitedBy))
+	slices.SortFunc(constraints, func(a, b string) int {
+		return cmp.Or(limitedBy[b]-limitedBy[a], strings.Compare(a, b))
+	})
+	for _, constraint := range constraints {
+		ret.LimitedBy = append(ret.LimitedBy, fmt.Sprintf("%d/%d schedulable node(s) %s", limitedBy[constraint], schedulable, constraint))
+		if !ret.Fits && !slices.Contains(ret.Suggestions, suggestions[constraint]) {
+			ret.Suggestions = append(ret.Suggestions, suggestions[constraint])
+		}
+	}
+	if schedulable == 0 {
+		ret.Suggestions = append(ret.Suggestions, "the cluster has no ready and uncordoned node")
+	}
+	return ret
+}
+
+func addResource(list v1.ResourceList, name v1.ResourceName, quantity resource.Quantity) {
+	total := list[name]
+	total.Add(quantity)
+	list[name] = total
+}
+
+// formatCapacity formats the quantity like kubectl top (millicores and mebibytes), the number of pods as an integer
+func formatCapacity(name v1.ResourceName, quantity resource.Quantity) string {
+	switch name {
+	case v1.ResourceCPU:
+		return resource.NewMilliQuantity(quantity.MilliValue(), resource.DecimalSI).String()
+	case v1.ResourceMemory:
+		return formatMemoryMi(quantity.Value())
+	default:
+		return quantity.String()
+	}
+}
@@ -0,0 +1,138 @@
+package kubernetes
+
+import (
+	"slices"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func capacityTestNode(name string, labels map[string]string, cpu string) v1.Node {
+	node := schedulingTestNode(name, labels, cpu)
+	node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}
+	return node
+}
+
+func capacityTestContext(pod *v1.Pod) *schedulingContext {
+	cordoned := capacityTestNode("node-4", map[string]string{"cloud.google.com/gke-nodepool": "pool-b"}, "8")
+	cordoned.Spec.Unschedulable = true
+	sc := &schedulingContext{
+		pod: pod,
+		nodes: []v1.Node{
+			capacityTestNode("node-1", map[string]string{"cloud.google.com/gke-nodepool": "pool-a", "zone": "a"}, "2"),
+			capacityTestNode("node-2", map[string]string{"cloud.google.com/gke-nodepool": "pool-a", "zone": "b"}, "2"),
+			capacityTestNode("node-3", map[string]string{"cloud.google.com/gke-nodepool": "pool-b", "zone": "a"}, "4"),
+			cordoned,
+		},
+		pods: map[string][]v1.Pod{
+			"node-1": {schedulingTestPod("ns", "a", nil, "1500m")},
+			"node-3": {schedulingTestPod("ns", "b", nil, "1")},
+		},
+	}
+	return sc
+}
+
+func TestNodePool(t *testing.T) {
+	for _, c := range []struct {
+		labels    map[string]string
+		poolLabel string
+		expected  string
+	}{
+		{map[string]string{"eks.amazonaws.com/nodegroup": "ng-1", "node-role.kubernetes.io/worker": ""}, "", "ng-1"},
+		{map[string]string{"node-role.kubernetes.io/worker": "", "node-role.kubernetes.io/infra": ""}, "", "infra,worker"},
+		{map[string]string{"node.kubernetes.io/instance-type": "m5.large"}, "node.kubernetes.io/instance-type", "m5.large"},
+		{map[string]string{}, "", "<none>"},
+	} {
+		if actual := nodePool(&v1.Node{ObjectMeta: metav1.ObjectMeta{Labels: c.labels}}, c.poolLabel); actual != c.expected {
+			t.Errorf("expected pool %s for %v, got %s", c.expected, c.labels, actual)
+		}
+	}
+}
+
+func TestNodePoolsCapacity(t *testing.T) {
+	ret := nodePoolsCapacity(capacityTestContext(nil), "")
+	if len(ret) != 2 || ret[0].Pool != "pool-a" || ret[0].Nodes != 2 || ret[0].SchedulableNodes != 2 || ret[1].Nodes != 2 || ret[1].SchedulableNodes != 1 {
+		t.Fatalf("unexpected pools %+v", ret)
+	}
+	if cpu := ret[0].Resources[0]; cpu != (NodePoolResourceHeadroom{
+		Resource: "cpu", Allocatable: "4", Requests: "1500m", RequestsPercent: 37, Headroom: "2500m", LargestNodeHeadroom: "2",
+	}) {
+		t.Errorf("unexpected cpu headroom of pool-a %+v", cpu)
+	}
+	if cpu := ret[1].Resources[0]; cpu.Allocatable != "4" || cpu.Headroom != "3" {
+		t.Errorf("expected the cordoned node to be excluded from the headroom of pool-b, got %+v", cpu)
+	}
+	if pods := ret[0].Resources[2]; pods.Resource != "pods" || pods.Requests != "1" || pods.Headroom != "219" {
+		t.Errorf("unexpected pods headroom of pool-a %+v", pods)
+	}
+}
+
+func TestSimulateCapacity(t *testing.T) {
+	t.Run("estimates the replicas by resources", func(t *testing.T) {
+		pod := schedulingTestPod("ns", "web", map[string]string{"app": "web"}, "1")
+		ret := simulateCapacity(capacityTestContext(&pod), "", "Deployment ns/web")
+		if !ret.Fits || ret.FittingNodes != 2 || ret.Replicas != 5 || ret.ReplicasPerPool["pool-a"] != 2 || ret.ReplicasPerPool["pool-b"] != 3 {
+			t.Errorf("unexpected simulation %+v", ret)
+		}
+		if ret.Requests["cpu"] != "1" || !slices.Equal(ret.LimitedBy, []string{"3/3 schedulable node(s) Insufficient cpu"}) || len(ret.Suggestions) != 0 {
+			t.Errorf("unexpected simulation %+v", ret)
+		}
+	})
+	t.Run("honors the anti-affinity between the replicas", func(t *testing.T) {
+		pod := schedulingTestPod("ns", "web", map[string]string{"app": "web"}, "100m")
+		pod.Spec.Affinity = &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}, TopologyKey: "zone",
+		}}}}
+		ret := simulateCapacity(capacityTestContext(&pod), "", "Deployment ns/web")
+		if !ret.Fits || ret.Replicas != 2 {
+			t.Errorf("expected a replica per zone, got %+v", ret)
+		}
+	})
+	t.Run("reports the constraints of a pod that doesn't fit", func(t *testing.T) {
+		pod := schedulingTestPod("ns", "big", nil, "6")
+		ret := simulateCapacity(capacityTestContext(&pod), "", "Pod ns/big")
+		if ret.Fits || ret.Replicas != 0 || len(ret.ReplicasPerPool) != 0 || len(ret.Suggestions) != 1 {
+			t.Errorf("unexpected simulation %+v", ret)
+		}
+	})
+}
+
+func TestCapacityManifestPod(t *testing.T) {
+	t.Run("workload manifest", func(t *testing.T) {
+		pod, source, err := capacityManifestPod(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        resources:
+          requests:
+            cpu: 250m
+`, "ns-1")
+		if err != nil {
+			t.Fatalf("failed to read the manifest: %v", err)
+		}
+		if source != "Deployment ns-1/web" || pod.Namespace != "ns-1" || pod.Labels["app"] != "web" || pod.Spec.Containers[0].Resources.Requests.Cpu().String() != "250m" {
+			t.Errorf("unexpected pod %s %+v", source, pod)
+		}
+	})
+	t.Run("pod manifest", func(t *testing.T) {
+		pod, source, err := capacityManifestPod(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"p","namespace":"ns-2"},"spec":{"nodeName":"node-1","containers":[{"name":"c"}]}}`, "ns-1")
+		if err != nil || source != "Pod ns-2/p" || pod.Spec.NodeName != "" {
+			t.Errorf("unexpected pod %s %+v %v", source, pod, err)
+		}
+	})
+	t.Run("manifest without pod template", func(t *testing.T) {
+		if _, _, err := capacityManifestPod("apiVersion: v1\nkind: Service\nmetadata:\n  name: s\n", "ns-1"); err == nil {
+			t.Error("expected an error for a Service manifest")
+		}
+	})
+}
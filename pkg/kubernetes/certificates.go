@@ -0,0 +1,354 @@
+package kubernetes
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/acm"
+)
+
+const (
+	// certManagerInjectCAFrom and certManagerInjectCAFromSecret are the annotations of the webhook configurations
+	// whose caBundle is injected by the cert-manager CA injector, from a Certificate or a Secret (namespace/name)
+	certManagerInjectCAFrom       = "cert-manager.io/inject-ca-from"
+	certManagerInjectCAFromSecret = "cert-manager.io/inject-ca-from-secret"
+)
+
+var (
+	certManagerCertificateGVK = &schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+	openShiftRouteGVK         = &schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}
+	webhookConfigurationGVKs  = []*schema.GroupVersionKind{
+		{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingWebhookConfiguration"},
+		{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "MutatingWebhookConfiguration"},
+	}
+)
+
+// CertificateExpiry is the expiry of a TLS certificate stored in a Secret (or inlined in a Route) with the resources consuming it
+type CertificateExpiry struct {
+	Cluster string `json:"cluster,omitempty"`
+	// Kind is where the certificate is stored: Secret, Route (inlined certificate), or Certificate (cert-manager Certificate without Secret)
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Subject   string `json:"subject,omitempty"`
+	Issuer    string `json:"issuer,omitempty"`
+	// DNSNames are the subject alternative names of the certificate
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// NotAfter is the expiry of the certificate chain, nil if the certificate couldn't be read
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+	// DaysLeft is the number of days before the expiry, negative if expired
+	DaysLeft int  `json:"daysLeft"`
+	Expired  bool `json:"expired,omitempty"`
+	// ExpiringFirst is the subject of the certificate of the chain expiring first, if it isn't the leaf certificate
+	ExpiringFirst string `json:"expiringFirst,omitempty"`
+	// Certificate is the cert-manager Certificate issuing the Secret
+	Certificate *CertManagerCertificate `json:"certificate,omitempty"`
+	// ConsumedBy are the Ingresses, Routes, Gateways, and webhook configurations using the certificate (Kind namespace/name)
+	ConsumedBy []string `json:"consumedBy,omitempty"`
+	// Error reports why the certificate couldn't be read (e.g. invalid PEM, missing Secret of a Certificate)
+	Error string `json:"error,omitempty"`
+}
+
+// CertManagerCertificate is the status of a cert-manager Certificate
+type CertManagerCertificate struct {
+	Name string `json:"name"`
+	// Issuer is the Kind/name of the issuer reference
+	Issuer  string `json:"issuer"`
+	Ready   bool   `json:"ready"`
+	Message string `json:"message,omitempty"`
+	// RenewalTime is when cert-manager renews the certificate
+	RenewalTime *time.Time `json:"renewalTime,omitempty"`
+}
+
+// CertificatesExpiry scans the TLS Secrets (and the cert-manager Certificates and the certificates inlined in OpenShift Routes
+// if installed) of the namespace (all namespaces if empty) and returns the certificates expiring within the days, or that
+// couldn't be read, along with the Ingresses, Routes, Gateways, and webhook configurations consuming them, the expired first
+func CertificatesExpiry(ctx context.Context, list ResourcesLister, namespace string, days int) ([]CertificateExpiry, error) {
+	secrets, err := list(ctx, secretGVK, namespace, ResourceListOptions{ListOptions: metav1.ListOptions{FieldSelector: "type=" + string(v1.SecretTypeTLS)}})
+	if err != nil {
+		return nil, err
+	}
+	scan := newCertificatesScan(time.Now())
+	for _, item := range secrets.(*unstructured.UnstructuredList).Items {
+		secret := v1.Secret{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &secret); err != nil {
+			return nil, err
+		}
+		scan.secrets = append(scan.secrets, secret)
+	}
+	optional := map[*schema.GroupVersionKind]string{certManagerCertificateGVK: namespace, ingressGVK: namespace, gatewayGVK: namespace, openShiftRouteGVK: namespace}
+	for _, gvk := range webhookConfigurationGVKs {
+		optional[gvk] = ""
+	}
+	items := make(map[*schema.GroupVersionKind][]unstructured.Unstructured)
+	for gvk, ns := range optional {
+		if items[gvk], err = listOptional(ctx, list, gvk, ns); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range items[certManagerCertificateGVK] {
+		scan.addCertManagerCertificate(item)
+	}
+	for _, item := range items[ingressGVK] {
+		ingress := networkingv1.Ingress{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &ingress); err != nil {
+			return nil, err
+		}
+		for _, tls := range ingress.Spec.TLS {
+			if tls.SecretName != "" {
+				scan.addConsumer(ingress.Namespace, tls.SecretName, "Ingress "+ingress.Namespace+"/"+ingress.Name)
+			}
+		}
+	}
+	for _, item := range items[gatewayGVK] {
+		scan.addGatewayConsumer(item)
+	}
+	var ret []CertificateExpiry
+	for _, item := range items[openShiftRouteGVK] {
+		ret = append(ret, scan.addRoute(item)...)
+	}
+	for _, gvk := range webhookConfigurationGVKs {
+		for _, item := range items[gvk] {
+			scan.addWebhookConsumer(gvk.Kind, item)
+		}
+	}
+	ret = append(ret, scan.expiries()...)
+	return FilterCertificatesExpiry(ret, days), nil
+}
+
+// FilterCertificatesExpiry returns the certificates expiring within the days or that couldn't be read, the expired first
+func FilterCertificatesExpiry(certificates []CertificateExpiry, days int) []CertificateExpiry {
+	ret := slices.DeleteFunc(certificates, func(c CertificateExpiry) bool { return c.Error == "" && c.DaysLeft >= days })
+	slices.SortFunc(ret, func(a, b CertificateExpiry) int {
+		return cmp.Or(
+			ptr.Deref(a.NotAfter, time.Time{}).Compare(ptr.Deref(b.NotAfter, time.Time{})),
+			strings.Compare(a.Cluster, b.Cluster),
+			strings.Compare(a.Namespace, b.Namespace),
+			strings.Compare(a.Kind, b.Kind),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+	return ret
+}
+
+// listOptional lists the resources, none if their API is not installed in the cluster or not allowed
+func listOptional(ctx context.Context, list ResourcesLister, gvk *schema.GroupVersionKind, namespace string) ([]unstructured.Unstructured, error) {
+	ret, err := list(ctx, gvk, namespace, ResourceListOptions{})
+	var statusError *acm.StatusError
+	switch {
+	case meta.IsNoMatchError(err), apierrors.IsNotFound(err), apierrors.IsForbidden(err):
+		return nil, nil
+	case errors.As(err, &statusError) && (statusError.StatusCode == http.StatusNotFound || statusError.StatusCode == http.StatusForbidden):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return ret.(*unstructured.UnstructuredList).Items, nil
+}
+
+type certificatesScan struct {
+	now     time.Time
+	secrets []v1.Secret
+	// certificates are the cert-manager Certificates by namespace/secret name, and their secret names by namespace/certificate name
+	certificates       map[string]*CertManagerCertificate
+	certificateSecrets map[string]string
+	// consumers are the resources consuming the Secrets by namespace/secret name
+	consumers map[string][]string
+}
+
+func newCertificatesScan(now time.Time) *certificatesScan {
+	return &certificatesScan{
+		now:                now,
+		certificates:       make(map[string]*CertManagerCertificate),
+		certificateSecrets: make(map[string]string),
+		consumers:          make(map[string][]string),
+	}
+}
+
+func (s *certificatesScan) addConsumer(namespace, secret, consumer string) {
+	key := namespace + "/" + secret
+	if !slices.Contains(s.consumers[key], consumer) {
+		s.consumers[key] = append(s.consumers[key], consumer)
+	}
+}
+
+func (s *certificatesScan) addCertManagerCertificate(item unstructured.Unstructured) {
+	secretName, _, _ := unstructured.NestedString(item.Object, "spec", "secretName")
+	issuerKind, _, _ := unstructured.NestedString(item.Object, "spec", "issuerRef", "kind")
+	issuerName, _, _ := unstructured.NestedString(item.Object, "spec", "issuerRef", "name")
+	certificate := &CertManagerCertificate{Name: item.GetName(), Issuer: cmp.Or(issuerKind, "Issuer") + "/" + issuerName}
+	conditions, _, _ := unstructured.NestedSlice(item.Object, "status", "conditions")
+	for _, condition := range conditions {
+		c, ok := condition.(map[string]interface{})
+		if !ok || c["type"] != "Ready" {
+			continue
+		}
+		certificate.Ready = c["status"] == "True"
+		if !certificate.Ready {
+			certificate.Message, _ = c["message"].(string)
+		}
+	}
+	if renewalTime, _, _ := unstructured.NestedString(item.Object, "status", "renewalTime"); renewalTime != "" {
+		if t, err := time.Parse(time.RFC3339, renewalTime); err == nil {
+			certificate.RenewalTime = &t
+		}
+	}
+	s.certificates[item.GetNamespace()+"/"+secretName] = certificate
+	s.certificateSecrets[item.GetNamespace()+"/"+item.GetName()] = secretName
+}
+
+// addGatewayConsumer adds the Gateway as consumer of the Secrets referenced by the TLS configuration of its listeners
+func (s *certificatesScan) addGatewayConsumer(item unstructured.Unstructured) {
+	listeners, _, _ := unstructured.NestedSlice(item.Object, "spec", "listeners")
+	for _, listener := range listeners {
+		l, ok := listener.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		refs, _, _ := unstructured.NestedSlice(l, "tls", "certificateRefs")
+		for _, ref := range refs {
+			r, ok := ref.(map[string]interface{})
+			if !ok || (r["kind"] != nil && r["kind"] != "Secret") {
+				continue
+			}
+			name, _ := r["name"].(string)
+			namespace, _ := r["namespace"].(string)
+			s.addConsumer(cmp.Or(namespace, item.GetNamespace()), name, "Gateway "+item.GetNamespace()+"/"+item.GetName())
+		}
+	}
+}
+
+// addRoute adds the Route as consumer of its external certificate Secret, and returns the expiry of its inlined certificate
+func (s *certificatesScan) addRoute(item unstructured.Unstructured) []CertificateExpiry {
+	if secretName, _, _ := unstructured.NestedString(item.Object, "spec", "tls", "externalCertificate", "name"); secretName != "" {
+		s.addConsumer(item.GetNamespace(), secretName, "Route "+item.GetNamespace()+"/"+item.GetName())
+	}
+	certificate, _, _ := unstructured.NestedString(item.Object, "spec", "tls", "certificate")
+	if certificate == "" {
+		return nil
+	}
+	expiry := CertificateExpiry{Kind: "Route", Namespace: item.GetNamespace(), Name: item.GetName()}
+	s.parse(&expiry, []byte(certificate))
+	return []CertificateExpiry{expiry}
+}
+
+// addWebhookConsumer adds the webhook configuration as consumer of the Secrets its caBundle is injected from (cert-manager
+// annotations) or whose certificate is in its caBundle
+func (s *certificatesScan) addWebhookConsumer(kind string, item unstructured.Unstructured) {
+	consumer := kind + " " + item.GetName()
+	annotations := item.GetAnnotations()
+	if namespace, name, ok := strings.Cut(annotations[certManagerInjectCAFromSecret], "/"); ok {
+		s.addConsumer(namespace, name, consumer)
+	}
+	if namespace, name, ok := strings.Cut(annotations[certManagerInjectCAFrom], "/"); ok && s.certificateSecrets[namespace+"/"+name] != "" {
+		s.addConsumer(namespace, s.certificateSecrets[namespace+"/"+name], consumer)
+	}
+	webhooks, _, _ := unstructured.NestedSlice(item.Object, "webhooks")
+	for _, webhook := range webhooks {
+		w, ok := webhook.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		caBundle, _, _ := unstructured.NestedString(w, "clientConfig", "caBundle")
+		bundle, err := base64.StdEncoding.DecodeString(caBundle)
+		if err != nil || len(bundle) == 0 {
+			continue
+		}
+		for _, secret := range s.secrets {
+			for _, key := range []string{v1.ServiceAccountRootCAKey, v1.TLSCertKey} {
+				if data := bytes.TrimSpace(secret.Data[key]); len(data) > 0 && bytes.Contains(bundle, data) {
+					s.addConsumer(secret.Namespace, secret.Name, consumer)
+				}
+			}
+		}
+	}
+}
+
+// expiries returns the expiry of the certificates of the Secrets, and the cert-manager Certificates without Secret
+func (s *certificatesScan) expiries() []CertificateExpiry {
+	var ret []CertificateExpiry
+	found := make(map[string]bool)
+	for _, secret := range s.secrets {
+		key := secret.Namespace + "/" + secret.Name
+		found[key] = true
+		expiry := CertificateExpiry{
+			Kind:        "Secret",
+			Namespace:   secret.Namespace,
+			Name:        secret.Name,
+			Certificate: s.certificates[key],
+			ConsumedBy:  s.consumers[key],
+		}
+		s.parse(&expiry, secret.Data[v1.TLSCertKey])
+		ret = append(ret, expiry)
+	}
+	for key, certificate := range s.certificates {
+		if found[key] {
+			continue
+		}
+		namespace, secretName, _ := strings.Cut(key, "/")
+		ret = append(ret, CertificateExpiry{
+			Kind:        "Certificate",
+			Namespace:   namespace,
+			Name:        certificate.Name,
+			Certificate: certificate,
+			ConsumedBy:  s.consumers[key],
+			Error:       fmt.Sprintf("the Secret %s of the Certificate doesn't exist, it wasn't issued yet", secretName),
+		})
+	}
+	return ret
+}
+
+// parse sets the expiry from the PEM certificate chain, the leaf certificate first
+func (s *certificatesScan) parse(expiry *CertificateExpiry, data []byte) {
+	var chain []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			expiry.Error = fmt.Sprintf("invalid certificate: %v", err)
+			return
+		}
+		chain = append(chain, certificate)
+	}
+	if len(chain) == 0 {
+		expiry.Error = "no PEM certificate found in " + v1.TLSCertKey
+		return
+	}
+	leaf := chain[0]
+	expiry.Subject = leaf.Subject.String()
+	expiry.Issuer = leaf.Issuer.String()
+	expiry.DNSNames = leaf.DNSNames
+	notAfter := leaf.NotAfter
+	for _, certificate := range chain[1:] {
+		if certificate.NotAfter.Before(notAfter) {
+			notAfter = certificate.NotAfter
+			expiry.ExpiringFirst = certificate.Subject.String()
+		}
+	}
+	expiry.NotAfter = &notAfter
+	expiry.DaysLeft = int(math.Floor(notAfter.Sub(s.now).Hours() / 24))
+	expiry.Expired = !s.now.Before(notAfter)
+}
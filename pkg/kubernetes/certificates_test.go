@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func testCertificate(t *testing.T, commonName string, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func testTLSSecret(namespace, name, certificate string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"namespace": namespace, "name": name},
+		"type":       "kubernetes.io/tls",
+		"data":       map[string]interface{}{"tls.crt": base64.StdEncoding.EncodeToString([]byte(certificate))},
+	}
+}
+
+func TestCertificatesExpiry(t *testing.T) {
+	now := time.Now()
+	expiring := testCertificate(t, "expiring.example.com", now.Add(10*24*time.Hour+time.Hour))
+	objects := map[string][]map[string]interface{}{
+		"Secret": {
+			testTLSSecret("default", "expiring", expiring),
+			testTLSSecret("default", "expired", testCertificate(t, "expired.example.com", now.Add(-time.Hour))),
+			testTLSSecret("default", "valid", testCertificate(t, "valid.example.com", now.Add(365*24*time.Hour))),
+			testTLSSecret("default", "invalid", "not a certificate"),
+		},
+		"Ingress": {{
+			"apiVersion": "networking.k8s.io/v1", "kind": "Ingress",
+			"metadata": map[string]interface{}{"namespace": "default", "name": "web"},
+			"spec":     map[string]interface{}{"tls": []interface{}{map[string]interface{}{"secretName": "expiring"}}},
+		}},
+		"Certificate": {{
+			"apiVersion": "cert-manager.io/v1", "kind": "Certificate",
+			"metadata": map[string]interface{}{"namespace": "default", "name": "pending"},
+			"spec":     map[string]interface{}{"secretName": "pending-tls", "issuerRef": map[string]interface{}{"name": "letsencrypt", "kind": "ClusterIssuer"}},
+			"status": map[string]interface{}{"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "False", "message": "Issuing certificate as Secret does not exist"},
+			}},
+		}},
+		"ValidatingWebhookConfiguration": {{
+			"apiVersion": "admissionregistration.k8s.io/v1", "kind": "ValidatingWebhookConfiguration",
+			"metadata": map[string]interface{}{"name": "policy"},
+			"webhooks": []interface{}{map[string]interface{}{
+				"name":         "policy.example.com",
+				"clientConfig": map[string]interface{}{"caBundle": base64.StdEncoding.EncodeToString([]byte(expiring))},
+			}},
+		}},
+	}
+	list := func(_ context.Context, gvk *schema.GroupVersionKind, _ string, _ ResourceListOptions) (runtime.Unstructured, error) {
+		if gvk.Kind == "Route" || gvk.Kind == "Gateway" {
+			return nil, &meta.NoKindMatchError{GroupKind: gvk.GroupKind()}
+		}
+		ret := &unstructured.UnstructuredList{}
+		for _, object := range objects[gvk.Kind] {
+			ret.Items = append(ret.Items, unstructured.Unstructured{Object: object})
+		}
+		return ret, nil
+	}
+	certificates, err := CertificatesExpiry(context.Background(), list, "", 30)
+	if err != nil {
+		t.Fatalf("failed to scan certificates expiry: %v", err)
+	}
+	var names []string
+	for _, certificate := range certificates {
+		names = append(names, certificate.Kind+"/"+certificate.Name)
+	}
+	t.Run("reports the certificates expiring within the days and the errors, the expired first", func(t *testing.T) {
+		expected := []string{"Certificate/pending", "Secret/invalid", "Secret/expired", "Secret/expiring"}
+		if !slices.Equal(names, expected) {
+			t.Errorf("expected %v, got %v", expected, names)
+		}
+	})
+	t.Run("reports the expiry of the certificate", func(t *testing.T) {
+		certificate := certificates[2]
+		if !certificate.Expired || certificate.DaysLeft != -1 || certificate.Subject != "CN=expired.example.com" {
+			t.Errorf("unexpected certificate %v", certificate)
+		}
+		certificate = certificates[3]
+		if certificate.Expired || certificate.DaysLeft != 10 || !slices.Equal(certificate.DNSNames, []string{"expiring.example.com"}) {
+			t.Errorf("unexpected certificate %v", certificate)
+		}
+	})
+	t.Run("reports the Ingresses and webhooks consuming the certificate", func(t *testing.T) {
+		expected := []string{"Ingress default/web", "ValidatingWebhookConfiguration policy"}
+		if !slices.Equal(certificates[3].ConsumedBy, expected) {
+			t.Errorf("expected %v, got %v", expected, certificates[3].ConsumedBy)
+		}
+	})
+	t.Run("reports the cert-manager Certificates not issued yet", func(t *testing.T) {
+		certificate := certificates[0].Certificate
+		if certificate == nil || certificate.Ready || certificate.Issuer != "ClusterIssuer/letsencrypt" || certificate.Message != "Issuing certificate as Secret does not exist" {
+			t.Errorf("unexpected certificate %v", certificate)
+		}
+	})
+	t.Run("reports the invalid certificates", func(t *testing.T) {
+		if certificates[1].Error != "no PEM certificate found in tls.crt" {
+			t.Errorf("unexpected error %q", certificates[1].Error)
+		}
+	})
+}
@@ -0,0 +1,187 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"strings"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+var certificateSigningRequestGVK = &schema.GroupVersionKind{Group: "certificates.k8s.io", Version: "v1", Kind: "CertificateSigningRequest"}
+
+const (
+	CertificateSigningRequestPending  = "Pending"
+	CertificateSigningRequestApproved = "Approved"
+	CertificateSigningRequestDenied   = "Denied"
+	CertificateSigningRequestFailed   = "Failed"
+	CertificateSigningRequestIssued   = "Issued"
+)
+
+// CertificateSigningRequest is a summary of a CertificateSigningRequest with its requester and the subject of the request
+type CertificateSigningRequest struct {
+	Name              string      `json:"name"`
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+	// Status is Pending, Approved, Issued (approved and signed), Denied, or Failed
+	Status     string   `json:"status"`
+	SignerName string   `json:"signerName"`
+	Username   string   `json:"username"`
+	Groups     []string `json:"groups,omitempty"`
+	Usages     []string `json:"usages,omitempty"`
+	// ExpirationSeconds is the requested duration of the certificate
+	ExpirationSeconds *int32 `json:"expirationSeconds,omitempty"`
+	// Subject is the subject of the certificate request, decoded from the PEM encoded request
+	Subject *CertificateSigningRequestSubject `json:"subject,omitempty"`
+	// Reason and Message of the Approved, Denied, or Failed condition
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// CertificateSigningRequestSubject is the subject and the subject alternative names of a certificate request
+type CertificateSigningRequestSubject struct {
+	CommonName     string   `json:"commonName"`
+	Organizations  []string `json:"organizations,omitempty"`
+	DNSNames       []string `json:"dnsNames,omitempty"`
+	IPAddresses    []string `json:"ipAddresses,omitempty"`
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+	URIs           []string `json:"uris,omitempty"`
+}
+
+// CertificateSigningRequestsList returns the CertificateSigningRequests sorted by creation, only the pending ones unless all is requested
+func (k *Kubernetes) CertificateSigningRequestsList(ctx context.Context, all bool) ([]CertificateSigningRequest, error) {
+	list, err := k.ResourcesList(ctx, certificateSigningRequestGVK, "", ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	csrList := &certificatesv1.CertificateSigningRequestList{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(list.UnstructuredContent(), csrList); err != nil {
+		return nil, err
+	}
+	ret := make([]CertificateSigningRequest, 0, len(csrList.Items))
+	for _, csr := range csrList.Items {
+		summary := certificateSigningRequestSummary(&csr)
+		if all || summary.Status == CertificateSigningRequestPending {
+			ret = append(ret, summary)
+		}
+	}
+	slices.SortStableFunc(ret, func(a, b CertificateSigningRequest) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return ret, nil
+}
+
+// CertificateSigningRequestsApprove approves (or denies) the CertificateSigningRequest (same as kubectl certificate approve/deny).
+// Returns false if the request already has the condition, fails if it's already approved or denied the other way around.
+// The signer issues the certificate of the approved requests, the approval doesn't check the request is legitimate.
+func (k *Kubernetes) CertificateSigningRequestsApprove(ctx context.Context, name string, approve bool, reason, message string) (bool, error) {
+	csrs, err := k.manager.accessControlClientSet.CertificateSigningRequests()
+	if err != nil {
+		return false, err
+	}
+	csr, err := csrs.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	conditionType := certificatesv1.CertificateDenied
+	if approve {
+		conditionType = certificatesv1.CertificateApproved
+	}
+	for _, condition := range csr.Status.Conditions {
+		switch condition.Type {
+		case conditionType:
+			return false, nil
+		case certificatesv1.CertificateApproved, certificatesv1.CertificateDenied:
+			return false, fmt.Errorf("CertificateSigningRequest %s is already %s", name, strings.ToLower(string(condition.Type)))
+		}
+	}
+	if reason == "" {
+		reason = "KubernetesMCPServer" + string(conditionType)
+	}
+	csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:           conditionType,
+		Status:         v1.ConditionTrue,
+		Reason:         reason,
+		Message:        message,
+		LastUpdateTime: metav1.Now(),
+	})
+	if _, err = csrs.UpdateApproval(ctx, name, csr, metav1.UpdateOptions{
+		FieldManager: version.BinaryName,
+		DryRun:       dryRun(ctx),
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func certificateSigningRequestSummary(csr *certificatesv1.CertificateSigningRequest) CertificateSigningRequest {
+	ret := CertificateSigningRequest{
+		Name:              csr.Name,
+		CreationTimestamp: csr.CreationTimestamp,
+		Status:            CertificateSigningRequestPending,
+		SignerName:        csr.Spec.SignerName,
+		Username:          csr.Spec.Username,
+		Groups:            csr.Spec.Groups,
+		ExpirationSeconds: csr.Spec.ExpirationSeconds,
+		Subject:           certificateSigningRequestSubject(csr.Spec.Request),
+	}
+	for _, usage := range csr.Spec.Usages {
+		ret.Usages = append(ret.Usages, string(usage))
+	}
+	for _, condition := range csr.Status.Conditions {
+		if condition.Status == v1.ConditionFalse {
+			continue
+		}
+		switch condition.Type {
+		case certificatesv1.CertificateApproved:
+			ret.Status = CertificateSigningRequestApproved
+		case certificatesv1.CertificateDenied:
+			ret.Status = CertificateSigningRequestDenied
+		case certificatesv1.CertificateFailed:
+			ret.Status = CertificateSigningRequestFailed
+		default:
+			continue
+		}
+		ret.Reason, ret.Message = condition.Reason, condition.Message
+	}
+	if ret.Status == CertificateSigningRequestApproved && len(csr.Status.Certificate) > 0 {
+		ret.Status = CertificateSigningRequestIssued
+	}
+	return ret
+}
+
+// certificateSigningRequestSubject decodes the PEM encoded certificate request, nil if it can't be decoded
+func certificateSigningRequestSubject(request []byte) *CertificateSigningRequestSubject {
+	block, _ := pem.Decode(request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil
+	}
+	certificateRequest, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	ret := &CertificateSigningRequestSubject{
+		CommonName:     certificateRequest.Subject.CommonName,
+		Organizations:  certificateRequest.Subject.Organization,
+		DNSNames:       certificateRequest.DNSNames,
+		EmailAddresses: certificateRequest.EmailAddresses,
+	}
+	for _, ip := range certificateRequest.IPAddresses {
+		ret.IPAddresses = append(ret.IPAddresses, ip.String())
+	}
+	for _, uri := range certificateRequest.URIs {
+		ret.URIs = append(ret.URIs, uri.String())
+	}
+	return ret
+}
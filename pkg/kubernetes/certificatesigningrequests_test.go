@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"testing"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testCertificateRequest(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	request, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: "system:node:node-1", Organization: []string{"system:nodes"}},
+		DNSNames:    []string{"node-1.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+	}, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate request: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: request})
+}
+
+func TestCertificateSigningRequestSummary(t *testing.T) {
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "csr-1"},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    testCertificateRequest(t),
+			SignerName: certificatesv1.KubeletServingSignerName,
+			Username:   "system:node:node-1",
+			Groups:     []string{"system:nodes", "system:authenticated"},
+			Usages:     []certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature, certificatesv1.UsageServerAuth},
+		},
+	}
+	t.Run("pending request", func(t *testing.T) {
+		summary := certificateSigningRequestSummary(csr)
+		if summary.Status != CertificateSigningRequestPending {
+			t.Errorf("expected status Pending, got %s", summary.Status)
+		}
+		if summary.Subject == nil || summary.Subject.CommonName != "system:node:node-1" {
+			t.Fatalf("expected subject common name system:node:node-1, got %v", summary.Subject)
+		}
+		if len(summary.Subject.Organizations) != 1 || summary.Subject.Organizations[0] != "system:nodes" {
+			t.Errorf("expected organization system:nodes, got %v", summary.Subject.Organizations)
+		}
+		if len(summary.Subject.IPAddresses) != 1 || summary.Subject.IPAddresses[0] != "10.0.0.1" {
+			t.Errorf("expected IP address 10.0.0.1, got %v", summary.Subject.IPAddresses)
+		}
+		if len(summary.Usages) != 2 || summary.Usages[1] != "server auth" {
+			t.Errorf("expected usages digital signature and server auth, got %v", summary.Usages)
+		}
+	})
+	t.Run("approved and issued request", func(t *testing.T) {
+		issued := csr.DeepCopy()
+		issued.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+			{Type: certificatesv1.CertificateApproved, Status: v1.ConditionTrue, Reason: "NodeVerified"},
+		}
+		if summary := certificateSigningRequestSummary(issued); summary.Status != CertificateSigningRequestApproved || summary.Reason != "NodeVerified" {
+			t.Errorf("expected status Approved with reason NodeVerified, got %s %s", summary.Status, summary.Reason)
+		}
+		issued.Status.Certificate = []byte("certificate")
+		if summary := certificateSigningRequestSummary(issued); summary.Status != CertificateSigningRequestIssued {
+			t.Errorf("expected status Issued, got %s", summary.Status)
+		}
+	})
+	t.Run("denied request", func(t *testing.T) {
+		denied := csr.DeepCopy()
+		denied.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+			{Type: certificatesv1.CertificateDenied, Status: v1.ConditionTrue, Message: "unknown node"},
+		}
+		if summary := certificateSigningRequestSummary(denied); summary.Status != CertificateSigningRequestDenied || summary.Message != "unknown node" {
+			t.Errorf("expected status Denied with message, got %s %s", summary.Status, summary.Message)
+		}
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		invalid := csr.DeepCopy()
+		invalid.Spec.Request = []byte("not a certificate request")
+		if summary := certificateSigningRequestSummary(invalid); summary.Subject != nil {
+			t.Errorf("expected no subject, got %v", summary.Subject)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	ManagedClusterInfoGVK = schema.GroupVersionKind{Group: "internal.open-cluster-management.io", Version: "v1beta1", Kind: "ManagedClusterInfo"}
+	ClusterCuratorGVK     = schema.GroupVersionKind{Group: "cluster.open-cluster-management.io", Version: "v1beta1", Kind: "ClusterCurator"}
+)
+
+// ClusterUpgradeVersions returns the current OpenShift version, channel and the available upgrade versions
+// reported by the ManagedClusterInfo of the provided managed cluster.
+// ManagedClusterInfo and ClusterCurator resources live in the namespace named after the managed cluster.
+func (k *Kubernetes) ClusterUpgradeVersions(ctx context.Context, cluster string) (map[string]any, error) {
+	info, err := k.ResourcesGet(ctx, &ManagedClusterInfoGVK, cluster, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return ClusterUpgradeVersionsSummary(info), nil
+}
+
+// ClusterUpgradeVersionsSummary extracts the upgrade information from a ManagedClusterInfo
+func ClusterUpgradeVersionsSummary(info *unstructured.Unstructured) map[string]any {
+	ocp, _, _ := unstructured.NestedMap(info.Object, "status", "distributionInfo", "ocp")
+	version, _, _ := unstructured.NestedString(ocp, "version")
+	channel, _, _ := unstructured.NestedString(ocp, "channel")
+	desired, _, _ := unstructured.NestedString(ocp, "desired", "version")
+	upgradeFailed, _, _ := unstructured.NestedBool(ocp, "upgradeFailed")
+	available, _, _ := unstructured.NestedStringSlice(ocp, "availableUpdates")
+	if len(available) == 0 {
+		updates, _, _ := unstructured.NestedSlice(ocp, "versionAvailableUpdates")
+		for _, u := range updates {
+			if update, ok := u.(map[string]any); ok {
+				if v, ok := update["version"].(string); ok && v != "" {
+					available = append(available, v)
+				}
+			}
+		}
+	}
+	summary := map[string]any{
+		"cluster":          info.GetName(),
+		"version":          version,
+		"channel":          channel,
+		"availableUpdates": available,
+	}
+	if channels, _, _ := unstructured.NestedStringSlice(ocp, "desired", "channels"); len(channels) > 0 {
+		summary["availableChannels"] = channels
+	}
+	if desired != "" && desired != version {
+		summary["desiredVersion"] = desired
+	}
+	if upgradeFailed {
+		summary["upgradeFailed"] = true
+	}
+	return summary
+}
+
+// ClusterUpgradePlan previews the upgrade of the provided managed cluster to the provided version without performing any change.
+// It validates the version against the available updates and returns the ClusterCurator that would be applied.
+func (k *Kubernetes) ClusterUpgradePlan(ctx context.Context, cluster, version, channel string) (map[string]any, error) {
+	versions, err := k.ClusterUpgradeVersions(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	available, _ := versions["availableUpdates"].([]string)
+	if !slices.Contains(available, version) {
+		if len(available) == 0 {
+			return nil, fmt.Errorf("version %s is not an available update for cluster %s: no updates available", version, cluster)
+		}
+		return nil, fmt.Errorf("version %s is not an available update for cluster %s, available updates: %s",
+			version, cluster, strings.Join(available, ", "))
+	}
+	plan := map[string]any{
+		"cluster":        cluster,
+		"currentVersion": versions["version"],
+		"targetVersion":  version,
+		"channel":        versions["channel"],
+	}
+	if channel != "" {
+		plan["channel"] = channel
+	}
+	var warnings []string
+	if versions["upgradeFailed"] == true {
+		warnings = append(warnings, "the previous upgrade of this cluster failed")
+	}
+	if curator, err := k.ResourcesGet(ctx, &ClusterCuratorGVK, cluster, cluster); err == nil {
+		if desiredCuration, _, _ := unstructured.NestedString(curator.Object, "spec", "desiredCuration"); desiredCuration != "" && !clusterCuratorJobCompleted(curator) {
+			warnings = append(warnings, fmt.Sprintf("a %s curation is already in progress for this cluster", desiredCuration))
+		}
+	}
+	if len(warnings) > 0 {
+		plan["warnings"] = warnings
+	}
+	plan["clusterCurator"] = ClusterCuratorUpgrade(cluster, version, channel).Object
+	return plan, nil
+}
+
+// ClusterUpgrade starts the upgrade of the provided managed cluster by applying an upgrade ClusterCurator
+func (k *Kubernetes) ClusterUpgrade(ctx context.Context, cluster, version, channel string) ([]*unstructured.Unstructured, error) {
+	if _, err := k.ClusterUpgradePlan(ctx, cluster, version, channel); err != nil {
+		return nil, err
+	}
+	return k.resourcesCreateOrUpdate(ctx, []*unstructured.Unstructured{ClusterCuratorUpgrade(cluster, version, channel)})
+}
+
+// ClusterCuratorStatus returns the requested curation and the status conditions of the ClusterCurator of the provided managed cluster
+func (k *Kubernetes) ClusterCuratorStatus(ctx context.Context, cluster string) (map[string]any, error) {
+	curator, err := k.ResourcesGet(ctx, &ClusterCuratorGVK, cluster, cluster)
+	if err != nil {
+		return nil, err
+	}
+	desiredCuration, _, _ := unstructured.NestedString(curator.Object, "spec", "desiredCuration")
+	desiredUpdate, _, _ := unstructured.NestedString(curator.Object, "spec", "upgrade", "desiredUpdate")
+	conditions, _, _ := unstructured.NestedSlice(curator.Object, "status", "conditions")
+	status := map[string]any{
+		"cluster":         cluster,
+		"desiredCuration": desiredCuration,
+		"completed":       clusterCuratorJobCompleted(curator),
+		"conditions":      conditions,
+	}
+	if desiredUpdate != "" {
+		status["desiredUpdate"] = desiredUpdate
+	}
+	return status, nil
+}
+
+// ClusterCuratorUpgrade returns a ClusterCurator that requests the upgrade of the provided managed cluster
+func ClusterCuratorUpgrade(cluster, version, channel string) *unstructured.Unstructured {
+	upgrade := map[string]interface{}{
+		"desiredUpdate": version,
+	}
+	if channel != "" {
+		upgrade["channel"] = channel
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": ClusterCuratorGVK.GroupVersion().String(),
+		"kind":       ClusterCuratorGVK.Kind,
+		"metadata": map[string]interface{}{
+			"name":      cluster,
+			"namespace": cluster,
+		},
+		"spec": map[string]interface{}{
+			"desiredCuration": "upgrade",
+			"upgrade":         upgrade,
+		},
+	}}
+}
+
+// clusterCuratorJobCompleted reports whether the overall curation job (clustercurator-job condition) has finished
+func clusterCuratorJobCompleted(curator *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(curator.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok || condition["type"] != "clustercurator-job" {
+			continue
+		}
+		return condition["status"] == "True"
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestClusterUpgradeVersionsSummary(t *testing.T) {
+	t.Run("with availableUpdates", func(t *testing.T) {
+		info := &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "spoke-1", "namespace": "spoke-1"},
+			"status": map[string]interface{}{"distributionInfo": map[string]interface{}{"ocp": map[string]interface{}{
+				"version":          "4.15.10",
+				"channel":          "stable-4.15",
+				"availableUpdates": []interface{}{"4.15.11", "4.15.12"},
+				"desired":          map[string]interface{}{"version": "4.15.10", "channels": []interface{}{"stable-4.15", "stable-4.16"}},
+			}}},
+		}}
+		summary := ClusterUpgradeVersionsSummary(info)
+		if summary["version"] != "4.15.10" || summary["channel"] != "stable-4.15" {
+			t.Errorf("unexpected version or channel: %v", summary)
+		}
+		if !reflect.DeepEqual(summary["availableUpdates"], []string{"4.15.11", "4.15.12"}) {
+			t.Errorf("unexpected available updates: %v", summary["availableUpdates"])
+		}
+		if !reflect.DeepEqual(summary["availableChannels"], []string{"stable-4.15", "stable-4.16"}) {
+			t.Errorf("unexpected available channels: %v", summary["availableChannels"])
+		}
+		if _, ok := summary["desiredVersion"]; ok {
+			t.Errorf("expected no desired version when desired matches current version")
+		}
+	})
+	t.Run("with versionAvailableUpdates and upgrade in progress", func(t *testing.T) {
+		info := &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{"distributionInfo": map[string]interface{}{"ocp": map[string]interface{}{
+				"version":                 "4.15.10",
+				"versionAvailableUpdates": []interface{}{map[string]interface{}{"version": "4.16.0", "image": "quay.io/ocp:4.16.0"}},
+				"desired":                 map[string]interface{}{"version": "4.16.0"},
+				"upgradeFailed":           true,
+			}}},
+		}}
+		summary := ClusterUpgradeVersionsSummary(info)
+		if !reflect.DeepEqual(summary["availableUpdates"], []string{"4.16.0"}) {
+			t.Errorf("unexpected available updates: %v", summary["availableUpdates"])
+		}
+		if summary["desiredVersion"] != "4.16.0" || summary["upgradeFailed"] != true {
+			t.Errorf("unexpected upgrade progress: %v", summary)
+		}
+	})
+}
+
+func TestClusterCuratorUpgrade(t *testing.T) {
+	t.Run("without channel", func(t *testing.T) {
+		curator := ClusterCuratorUpgrade("spoke-1", "4.15.11", "")
+		if curator.GetName() != "spoke-1" || curator.GetNamespace() != "spoke-1" {
+			t.Errorf("expected curator spoke-1/spoke-1, got %s/%s", curator.GetNamespace(), curator.GetName())
+		}
+		if curation, _, _ := unstructured.NestedString(curator.Object, "spec", "desiredCuration"); curation != "upgrade" {
+			t.Errorf("expected desiredCuration upgrade, got %s", curation)
+		}
+		if _, found, _ := unstructured.NestedString(curator.Object, "spec", "upgrade", "channel"); found {
+			t.Errorf("expected no channel")
+		}
+	})
+	t.Run("with channel", func(t *testing.T) {
+		curator := ClusterCuratorUpgrade("spoke-1", "4.16.0", "stable-4.16")
+		if channel, _, _ := unstructured.NestedString(curator.Object, "spec", "upgrade", "channel"); channel != "stable-4.16" {
+			t.Errorf("expected channel stable-4.16, got %s", channel)
+		}
+		if update, _, _ := unstructured.NestedString(curator.Object, "spec", "upgrade", "desiredUpdate"); update != "4.16.0" {
+			t.Errorf("expected desiredUpdate 4.16.0, got %s", update)
+		}
+	})
+}
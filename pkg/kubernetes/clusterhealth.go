@@ -0,0 +1,374 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	ClusterHealthy  = "Healthy"
+	ClusterDegraded = "Degraded"
+	ClusterCritical = "Critical"
+	// clusterHealthPendingGrace is the time a pod can be pending before being reported, to ignore the pods being scheduled or pulling images
+	clusterHealthPendingGrace = 5 * time.Minute
+	// clusterHealthEventsWindow is the time window of the reported Warning events
+	clusterHealthEventsWindow = time.Hour
+	// clusterHealthMaxExamples is the maximum number of unhealthy pods and critical alerts reported
+	clusterHealthMaxExamples = 10
+)
+
+var (
+	clusterOperatorGVK = &schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "ClusterOperator"}
+	// clusterHealthWaitingReasons are the reasons of the containers failing to start
+	clusterHealthWaitingReasons = []string{"CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "CreateContainerConfigError", "CreateContainerError", "InvalidImageName"}
+	// clusterHealthIgnoredAlerts are the alerts always firing by design (dead man's switches and inhibitors)
+	clusterHealthIgnoredAlerts = []string{"Watchdog", "InfoInhibitor", "AlertmanagerReceiversNotConfigured"}
+)
+
+// ClusterHealth is the health of a cluster rolled up in a score from 0 to 100 with a one-line summary
+type ClusterHealth struct {
+	Cluster string `json:"cluster,omitempty"`
+	// Score is 100 minus the penalties listed in Deductions
+	Score int `json:"score"`
+	// Status is Healthy (score >= 90), Degraded (score >= 70), or Critical
+	Status       string                    `json:"status"`
+	Summary      string                    `json:"summary"`
+	Deductions   []string                  `json:"deductions,omitempty"`
+	Nodes        ClusterHealthNodes        `json:"nodes"`
+	ControlPlane ClusterHealthControlPlane `json:"controlPlane"`
+	Pods         ClusterHealthPods         `json:"pods"`
+	// Alerts are the firing alerts of the Prometheus of the cluster, nil if no Prometheus was found
+	Alerts *ClusterHealthAlerts `json:"alerts,omitempty"`
+	Events ClusterHealthEvents  `json:"events"`
+}
+
+type ClusterHealthNodes struct {
+	Total    int      `json:"total"`
+	Ready    int      `json:"ready"`
+	NotReady []string `json:"notReady,omitempty"`
+	// Pressure are the nodes with a pressure condition (node: MemoryPressure)
+	Pressure      []string `json:"pressure,omitempty"`
+	Unschedulable []string `json:"unschedulable,omitempty"`
+}
+
+type ClusterHealthControlPlane struct {
+	// Visible is false if the control plane pods aren't visible (e.g. managed control planes)
+	Visible bool `json:"visible"`
+	// Components are the control plane pods of kube-system (tier=control-plane) and the OpenShift ClusterOperators
+	Components int `json:"components"`
+	// Unhealthy are the components not ready, degraded, or not available, with the reason
+	Unhealthy []string `json:"unhealthy,omitempty"`
+}
+
+type ClusterHealthPods struct {
+	Total   int `json:"total"`
+	Running int `json:"running"`
+	// Pending are the pods pending for more than 5 minutes
+	Pending int `json:"pending"`
+	// Failed are the failed pods, not counting the ones of Jobs
+	Failed int `json:"failed"`
+	// CrashLooping are the pods with a container failing to start (e.g. CrashLoopBackOff, ImagePullBackOff)
+	CrashLooping int `json:"crashLooping"`
+	// Examples are some of the unhealthy pods with the reason (namespace/name: reason)
+	Examples []string `json:"examples,omitempty"`
+}
+
+type ClusterHealthAlerts struct {
+	// Source is the Prometheus Service the alerts were retrieved from
+	Source   string `json:"source,omitempty"`
+	Firing   int    `json:"firing"`
+	Critical int    `json:"critical"`
+	Warning  int    `json:"warning"`
+	// CriticalAlerts are some of the critical alerts firing (name (namespace): summary)
+	CriticalAlerts []string `json:"criticalAlerts,omitempty"`
+	// Error reports why the alerts couldn't be retrieved, they are not scored
+	Error string `json:"error,omitempty"`
+}
+
+type ClusterHealthEvents struct {
+	// Warnings is the number of Warning events of the last hour
+	Warnings int `json:"warnings"`
+	// TopReasons are the most frequent reasons of the Warning events (reason (count))
+	TopReasons []string `json:"topReasons,omitempty"`
+}
+
+// ClusterHealthCheck rolls up the node conditions, the control plane health, the pending and failed pods, the firing alerts
+// (if alerts is provided and a Prometheus is found), and the recent Warning events into a scored summary
+func ClusterHealthCheck(ctx context.Context, list ResourcesLister, alerts PrometheusAlertsGetter) (*ClusterHealth, error) {
+	now := time.Now()
+	ret := &ClusterHealth{}
+	nodes, err := list(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "Node"}, "", ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range nodes.(*unstructured.UnstructuredList).Items {
+		node := &v1.Node{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, node); err != nil {
+			return nil, err
+		}
+		addNodeHealth(&ret.Nodes, node)
+	}
+	controlPlane, err := list(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "kube-system", ResourceListOptions{ListOptions: metav1.ListOptions{LabelSelector: "tier=control-plane"}})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range controlPlane.(*unstructured.UnstructuredList).Items {
+		pod := &v1.Pod{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, pod); err != nil {
+			return nil, err
+		}
+		ret.ControlPlane.Components++
+		if !podReady(*pod) {
+			ret.ControlPlane.Unhealthy = append(ret.ControlPlane.Unhealthy, fmt.Sprintf("%s: not ready (%s)", pod.Name, podHealthReason(pod)))
+		}
+	}
+	clusterOperators, err := listOptional(ctx, list, clusterOperatorGVK, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range clusterOperators {
+		ret.ControlPlane.Components++
+		if reason := clusterOperatorUnhealthy(item); reason != "" {
+			ret.ControlPlane.Unhealthy = append(ret.ControlPlane.Unhealthy, "ClusterOperator "+item.GetName()+": "+reason)
+		}
+	}
+	ret.ControlPlane.Visible = ret.ControlPlane.Components > 0
+	pods, err := list(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "", ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range pods.(*unstructured.UnstructuredList).Items {
+		pod := &v1.Pod{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, pod); err != nil {
+			return nil, err
+		}
+		addPodHealth(&ret.Pods, pod, now)
+	}
+	events, err := list(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "Event"}, "", ResourceListOptions{ListOptions: metav1.ListOptions{FieldSelector: "type=" + v1.EventTypeWarning}})
+	if err != nil {
+		return nil, err
+	}
+	reasons := make(map[string]int)
+	for _, item := range events.(*unstructured.UnstructuredList).Items {
+		event := &v1.Event{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, event); err != nil {
+			return nil, err
+		}
+		if now.Sub(eventTimestamp(event)) <= clusterHealthEventsWindow {
+			ret.Events.Warnings++
+			reasons[event.Reason]++
+		}
+	}
+	ret.Events.TopReasons = topReasons(reasons, 5)
+	if alerts != nil {
+		source, firing, err := alerts(ctx)
+		switch {
+		case errors.Is(err, ErrPrometheusNotFound):
+		case err != nil:
+			ret.Alerts = &ClusterHealthAlerts{Error: err.Error()}
+		default:
+			ret.Alerts = clusterHealthAlerts(source, firing)
+		}
+	}
+	scoreClusterHealth(ret)
+	return ret, nil
+}
+
+func addNodeHealth(nodes *ClusterHealthNodes, node *v1.Node) {
+	nodes.Total++
+	ready := false
+	for _, condition := range node.Status.Conditions {
+		switch {
+		case condition.Type == v1.NodeReady:
+			ready = condition.Status == v1.ConditionTrue
+		case condition.Status == v1.ConditionTrue && strings.HasSuffix(string(condition.Type), "Pressure"):
+			nodes.Pressure = append(nodes.Pressure, node.Name+": "+string(condition.Type))
+		case condition.Type == v1.NodeNetworkUnavailable && condition.Status == v1.ConditionTrue:
+			nodes.Pressure = append(nodes.Pressure, node.Name+": "+string(condition.Type))
+		}
+	}
+	if ready {
+		nodes.Ready++
+	} else {
+		nodes.NotReady = append(nodes.NotReady, node.Name)
+	}
+	if node.Spec.Unschedulable {
+		nodes.Unschedulable = append(nodes.Unschedulable, node.Name)
+	}
+}
+
+func addPodHealth(pods *ClusterHealthPods, pod *v1.Pod, now time.Time) {
+	pods.Total++
+	example := func(reason string) {
+		if len(pods.Examples) < clusterHealthMaxExamples {
+			pods.Examples = append(pods.Examples, pod.Namespace+"/"+pod.Name+": "+reason)
+		}
+	}
+	for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		if status.State.Waiting != nil && slices.Contains(clusterHealthWaitingReasons, status.State.Waiting.Reason) {
+			pods.CrashLooping++
+			example(status.State.Waiting.Reason)
+			return
+		}
+	}
+	switch pod.Status.Phase {
+	case v1.PodRunning:
+		pods.Running++
+	case v1.PodPending:
+		if now.Sub(pod.CreationTimestamp.Time) > clusterHealthPendingGrace {
+			pods.Pending++
+			example("Pending (" + podHealthReason(pod) + ")")
+		}
+	case v1.PodFailed:
+		// The failed pods of Jobs are retried or kept for inspection, the Job reports its own failure
+		if kind, _ := podWorkload(pod); kind != "Job" {
+			pods.Failed++
+			example("Failed (" + cmp.Or(pod.Status.Reason, "Error") + ")")
+		}
+	}
+}
+
+// podHealthReason returns the reason of the pod not being ready: the reason of a waiting container or of a false condition
+func podHealthReason(pod *v1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			return status.State.Waiting.Reason
+		}
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Status != v1.ConditionTrue && condition.Reason != "" {
+			return condition.Reason
+		}
+	}
+	return cmp.Or(pod.Status.Reason, string(pod.Status.Phase))
+}
+
+// clusterOperatorUnhealthy returns why the ClusterOperator is unhealthy (Degraded or not Available), empty if healthy
+func clusterOperatorUnhealthy(item unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(item.Object, "status", "conditions")
+	for _, condition := range conditions {
+		c, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if (c["type"] == "Degraded" && c["status"] == "True") || (c["type"] == "Available" && c["status"] == "False") {
+			message, _ := c["message"].(string)
+			return strings.TrimSpace(fmt.Sprintf("%s=%s %s", c["type"], c["status"], message))
+		}
+	}
+	return ""
+}
+
+func clusterHealthAlerts(source string, alerts []PrometheusAlert) *ClusterHealthAlerts {
+	ret := &ClusterHealthAlerts{Source: source}
+	for _, alert := range alerts {
+		if alert.State != "firing" || slices.Contains(clusterHealthIgnoredAlerts, alert.Name) {
+			continue
+		}
+		ret.Firing++
+		switch alert.Severity {
+		case "critical":
+			ret.Critical++
+			if len(ret.CriticalAlerts) < clusterHealthMaxExamples {
+				name := alert.Name
+				if alert.Namespace != "" {
+					name += " (" + alert.Namespace + ")"
+				}
+				if alert.Summary != "" {
+					name += ": " + alert.Summary
+				}
+				ret.CriticalAlerts = append(ret.CriticalAlerts, name)
+			}
+		case "warning":
+			ret.Warning++
+		}
+	}
+	return ret
+}
+
+// topReasons returns the most frequent reasons as reason (count)
+func topReasons(reasons map[string]int, limit int) []string {
+	keys := slices.SortedFunc(maps.Keys(reasons), func(a, b string) int {
+		return cmp.Or(cmp.Compare(reasons[b], reasons[a]), strings.Compare(a, b))
+	})
+	var ret []string
+	for _, reason := range keys[:min(limit, len(keys))] {
+		ret = append(ret, fmt.Sprintf("%s (%d)", reason, reasons[reason]))
+	}
+	return ret
+}
+
+// scoreClusterHealth computes the score, the status, and the summary of the health with the penalties of each problem
+func scoreClusterHealth(health *ClusterHealth) {
+	health.Score = 100
+	var problems []string
+	deduct := func(penalty int, problem string) {
+		if penalty <= 0 {
+			return
+		}
+		health.Score -= penalty
+		health.Deductions = append(health.Deductions, fmt.Sprintf("-%d: %s", penalty, problem))
+		problems = append(problems, problem)
+	}
+	nodes := health.Nodes
+	if nodes.Total == 0 {
+		deduct(50, "no node found")
+	} else if notReady := len(nodes.NotReady); notReady > 0 {
+		deduct(max(10, 40*notReady/nodes.Total), fmt.Sprintf("%d/%d nodes not ready", notReady, nodes.Total))
+	}
+	if pressure := len(nodes.Pressure); pressure > 0 {
+		deduct(min(15, 5*pressure), fmt.Sprintf("%d node pressure conditions", pressure))
+	}
+	if unhealthy := len(health.ControlPlane.Unhealthy); unhealthy > 0 {
+		deduct(min(30, 15*unhealthy), fmt.Sprintf("%d control plane components unhealthy", unhealthy))
+	}
+	pods := health.Pods
+	if pods.CrashLooping > 0 {
+		deduct(min(15, 3*pods.CrashLooping), fmt.Sprintf("%d pods crash looping or failing to start", pods.CrashLooping))
+	}
+	if pods.Pending > 0 {
+		deduct(min(10, 2*pods.Pending), fmt.Sprintf("%d pods pending", pods.Pending))
+	}
+	if pods.Failed > 0 {
+		deduct(min(5, pods.Failed), fmt.Sprintf("%d pods failed", pods.Failed))
+	}
+	if alerts := health.Alerts; alerts != nil && alerts.Error == "" {
+		if alerts.Critical > 0 {
+			deduct(min(30, 10*alerts.Critical), fmt.Sprintf("%d critical alerts firing", alerts.Critical))
+		}
+		if alerts.Warning > 0 {
+			deduct(min(10, alerts.Warning), fmt.Sprintf("%d warning alerts firing", alerts.Warning))
+		}
+	}
+	switch {
+	case health.Events.Warnings >= 100:
+		deduct(10, fmt.Sprintf("%d Warning events in the last hour", health.Events.Warnings))
+	case health.Events.Warnings >= 20:
+		deduct(5, fmt.Sprintf("%d Warning events in the last hour", health.Events.Warnings))
+	}
+	health.Score = max(0, health.Score)
+	switch {
+	case health.Score >= 90:
+		health.Status = ClusterHealthy
+	case health.Score >= 70:
+		health.Status = ClusterDegraded
+	default:
+		health.Status = ClusterCritical
+	}
+	if len(problems) == 0 {
+		problems = append(problems, fmt.Sprintf("%d/%d nodes ready, no issue found", nodes.Ready, nodes.Total))
+	}
+	health.Summary = fmt.Sprintf("%s (%d/100): %s", health.Status, health.Score, strings.Join(problems, ", "))
+}
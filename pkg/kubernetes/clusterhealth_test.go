@@ -0,0 +1,166 @@
+package kubernetes
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+func TestClusterHealthCheck(t *testing.T) {
+	old := metav1.NewTime(time.Now().Add(-time.Hour))
+	objects := map[string][]runtime.Object{
+		"Node": {
+			&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue},
+				{Type: v1.NodeMemoryPressure, Status: v1.ConditionTrue},
+			}}},
+			&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}, Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionUnknown},
+			}}},
+		},
+		"Pod": {
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "running"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "crash"}, Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{
+				{Name: "app", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			}}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pending", CreationTimestamp: old}, Status: v1.PodStatus{Phase: v1.PodPending, Conditions: []v1.PodCondition{
+				{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: "Unschedulable"},
+			}}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "scheduling", CreationTimestamp: metav1.Now()}, Status: v1.PodStatus{Phase: v1.PodPending}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "job-1", OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Job", Name: "job", Controller: ptr.To(true)},
+			}}, Status: v1.PodStatus{Phase: v1.PodFailed}},
+		},
+		"Event": {
+			&v1.Event{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "recent"}, Type: v1.EventTypeWarning, Reason: "BackOff", FirstTimestamp: metav1.Now()},
+			&v1.Event{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "old"}, Type: v1.EventTypeWarning, Reason: "FailedMount", FirstTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour))},
+		},
+	}
+	list := func(_ context.Context, gvk *schema.GroupVersionKind, namespace string, _ ResourceListOptions) (runtime.Unstructured, error) {
+		if gvk.Kind == "ClusterOperator" {
+			return nil, &meta.NoKindMatchError{GroupKind: gvk.GroupKind()}
+		}
+		ret := &unstructured.UnstructuredList{}
+		if namespace == "kube-system" {
+			return ret, nil
+		}
+		for _, object := range objects[gvk.Kind] {
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+			if err != nil {
+				return nil, err
+			}
+			ret.Items = append(ret.Items, unstructured.Unstructured{Object: u})
+		}
+		return ret, nil
+	}
+	alerts := func(context.Context) (string, []PrometheusAlert, error) {
+		return "monitoring/prometheus-operated", []PrometheusAlert{
+			{Name: "Watchdog", State: "firing", Severity: "none"},
+			{Name: "KubePodCrashLooping", State: "firing", Severity: "warning", Namespace: "default"},
+			{Name: "TargetDown", State: "firing", Severity: "critical", Namespace: "monitoring", Summary: "Some targets were not reachable"},
+			{Name: "KubeNodeNotReady", State: "pending", Severity: "critical"},
+		}, nil
+	}
+	health, err := ClusterHealthCheck(context.Background(), list, alerts)
+	if err != nil {
+		t.Fatalf("failed to check cluster health: %v", err)
+	}
+	t.Run("reports the node conditions", func(t *testing.T) {
+		if health.Nodes.Total != 2 || health.Nodes.Ready != 1 || !slices.Equal(health.Nodes.NotReady, []string{"node-2"}) ||
+			!slices.Equal(health.Nodes.Pressure, []string{"node-1: MemoryPressure"}) {
+			t.Errorf("unexpected nodes %v", health.Nodes)
+		}
+	})
+	t.Run("reports the unhealthy pods, ignoring the recent pending pods and the failed pods of Jobs", func(t *testing.T) {
+		expected := []string{"default/crash: CrashLoopBackOff", "default/pending: Pending (Unschedulable)"}
+		if health.Pods.Total != 5 || health.Pods.CrashLooping != 1 || health.Pods.Pending != 1 || health.Pods.Failed != 0 || !slices.Equal(health.Pods.Examples, expected) {
+			t.Errorf("unexpected pods %v", health.Pods)
+		}
+	})
+	t.Run("reports the firing alerts, ignoring the Watchdog", func(t *testing.T) {
+		expected := []string{"TargetDown (monitoring): Some targets were not reachable"}
+		if health.Alerts == nil || health.Alerts.Firing != 2 || health.Alerts.Critical != 1 || health.Alerts.Warning != 1 || !slices.Equal(health.Alerts.CriticalAlerts, expected) {
+			t.Errorf("unexpected alerts %v", health.Alerts)
+		}
+	})
+	t.Run("reports the Warning events of the last hour", func(t *testing.T) {
+		if health.Events.Warnings != 1 || !slices.Equal(health.Events.TopReasons, []string{"BackOff (1)"}) {
+			t.Errorf("unexpected events %v", health.Events)
+		}
+	})
+	t.Run("scores the health with the deductions", func(t *testing.T) {
+		expected := []string{
+			"-20: 1/2 nodes not ready",
+			"-5: 1 node pressure conditions",
+			"-3: 1 pods crash looping or failing to start",
+			"-2: 1 pods pending",
+			"-10: 1 critical alerts firing",
+			"-1: 1 warning alerts firing",
+		}
+		if health.Score != 59 || health.Status != ClusterCritical || !slices.Equal(health.Deductions, expected) {
+			t.Errorf("unexpected score %d %s %v", health.Score, health.Status, health.Deductions)
+		}
+		if health.Summary != "Critical (59/100): 1/2 nodes not ready, 1 node pressure conditions, 1 pods crash looping or failing to start, "+
+			"1 pods pending, 1 critical alerts firing, 1 warning alerts firing" {
+			t.Errorf("unexpected summary %q", health.Summary)
+		}
+	})
+}
+
+func TestScoreClusterHealth(t *testing.T) {
+	t.Run("healthy cluster", func(t *testing.T) {
+		health := &ClusterHealth{Nodes: ClusterHealthNodes{Total: 3, Ready: 3}}
+		scoreClusterHealth(health)
+		if health.Score != 100 || health.Status != ClusterHealthy || health.Summary != "Healthy (100/100): 3/3 nodes ready, no issue found" {
+			t.Errorf("unexpected health %v", health)
+		}
+	})
+	t.Run("alerts not retrieved are not scored", func(t *testing.T) {
+		health := &ClusterHealth{Nodes: ClusterHealthNodes{Total: 1, Ready: 1}, Alerts: &ClusterHealthAlerts{Critical: 5, Error: "forbidden"}}
+		scoreClusterHealth(health)
+		if health.Score != 100 {
+			t.Errorf("unexpected score %d", health.Score)
+		}
+	})
+	t.Run("the penalties are capped", func(t *testing.T) {
+		health := &ClusterHealth{
+			Nodes:        ClusterHealthNodes{Total: 1, Ready: 1},
+			ControlPlane: ClusterHealthControlPlane{Unhealthy: []string{"a", "b", "c"}},
+			Pods:         ClusterHealthPods{CrashLooping: 100},
+			Events:       ClusterHealthEvents{Warnings: 150},
+		}
+		scoreClusterHealth(health)
+		if health.Score != 45 || health.Status != ClusterCritical {
+			t.Errorf("unexpected score %d %v", health.Score, health.Deductions)
+		}
+	})
+}
+
+func TestPrometheusServiceCandidate(t *testing.T) {
+	t.Run("prefers the web port", func(t *testing.T) {
+		candidate, ok := prometheusBackend.serviceCandidate(&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "prometheus-operated"},
+			Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Name: "grpc", Port: 10901}, {Name: "web", Port: 9090}}},
+		})
+		if !ok || candidate != (monitoringService{namespace: "monitoring", name: "prometheus-operated", scheme: "http", port: "web"}) {
+			t.Errorf("unexpected candidate %v", candidate)
+		}
+	})
+	t.Run("ignores the other components", func(t *testing.T) {
+		if _, ok := prometheusBackend.serviceCandidate(&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app.kubernetes.io/component": "alertmanager"}},
+			Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 9093}}},
+		}); ok {
+			t.Errorf("expected the service to be ignored")
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/kubectl/pkg/util/hash"
+)
+
+// ConfigDataFile is the content of a file stored as a key of a ConfigMap or a Secret (same as kubectl create --from-file)
+type ConfigDataFile struct {
+	// Key is the key the file is stored with, usually the file name
+	Key     string `json:"key"`
+	Content string `json:"content"`
+	// Base64 is true if the content is base64 encoded (e.g. binary files)
+	Base64 bool `json:"base64,omitempty"`
+}
+
+type ConfigDataOptions struct {
+	// Literals are the key-value pairs to store (same as kubectl create --from-literal)
+	Literals map[string]string
+	Files    []ConfigDataFile
+	Labels   map[string]string
+	// Immutable prevents the data from being updated, a new object must be created instead
+	Immutable bool
+	// AppendHash appends the hash of the data to the name (same as kubectl create --append-hash), so that each change
+	// results in a new object and the workloads referencing it are rolled out
+	AppendHash bool
+	// Type is the type of the Secret (e.g. Opaque, kubernetes.io/tls), ignored for ConfigMaps
+	Type string
+}
+
+// ConfigMapsCreateOrUpdate creates or updates (server-side apply) the ConfigMap with the literals and files of the options.
+// Text files are stored as data and binary files as binaryData.
+func (k *Kubernetes) ConfigMapsCreateOrUpdate(ctx context.Context, namespace, name string, options ConfigDataOptions) (*unstructured.Unstructured, error) {
+	data, err := configData(options)
+	if err != nil {
+		return nil, err
+	}
+	configMap := &v1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: options.Labels},
+	}
+	for key, value := range data {
+		if utf8.Valid(value) {
+			if configMap.Data == nil {
+				configMap.Data = map[string]string{}
+			}
+			configMap.Data[key] = string(value)
+		} else {
+			if configMap.BinaryData == nil {
+				configMap.BinaryData = map[string][]byte{}
+			}
+			configMap.BinaryData[key] = value
+		}
+	}
+	if options.Immutable {
+		configMap.Immutable = &options.Immutable
+	}
+	if options.AppendHash {
+		h, err := hash.ConfigMapHash(configMap)
+		if err != nil {
+			return nil, err
+		}
+		configMap.Name = fmt.Sprintf("%s-%s", name, h)
+	}
+	return k.configDataApply(ctx, configMap)
+}
+
+// SecretsCreateOrUpdate creates or updates (server-side apply) the Secret with the literals and files of the options,
+// the values are base64 encoded by the server
+func (k *Kubernetes) SecretsCreateOrUpdate(ctx context.Context, namespace, name string, options ConfigDataOptions) (*unstructured.Unstructured, error) {
+	data, err := configData(options)
+	if err != nil {
+		return nil, err
+	}
+	secret := &v1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: options.Labels},
+		Type:       v1.SecretType(options.Type),
+		Data:       data,
+	}
+	if secret.Type == "" {
+		secret.Type = v1.SecretTypeOpaque
+	}
+	if options.Immutable {
+		secret.Immutable = &options.Immutable
+	}
+	if options.AppendHash {
+		h, err := hash.SecretHash(secret)
+		if err != nil {
+			return nil, err
+		}
+		secret.Name = fmt.Sprintf("%s-%s", name, h)
+	}
+	return k.configDataApply(ctx, secret)
+}
+
+func (k *Kubernetes) configDataApply(ctx context.Context, obj runtime.Object) (*unstructured.Unstructured, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	// The conversion of the empty ObjectMeta sets creationTimestamp: null, which is not part of the desired state
+	unstructured.RemoveNestedField(u, "metadata", "creationTimestamp")
+	return k.resourcesApply(ctx, &unstructured.Unstructured{Object: u}, ApplyOptions{})
+}
+
+// configData returns the data of the literals and the (decoded) files of the options, keyed by their validated keys
+func configData(options ConfigDataOptions) (map[string][]byte, error) {
+	if len(options.Literals) == 0 && len(options.Files) == 0 {
+		return nil, fmt.Errorf("no literals or files provided")
+	}
+	data := make(map[string][]byte, len(options.Literals)+len(options.Files))
+	add := func(key string, value []byte) error {
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if _, ok := data[key]; ok {
+			return fmt.Errorf("duplicate key %s", key)
+		}
+		data[key] = value
+		return nil
+	}
+	for key, value := range options.Literals {
+		if err := add(key, []byte(value)); err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range options.Files {
+		content := []byte(file.Content)
+		if file.Base64 {
+			decoded, err := base64.StdEncoding.DecodeString(file.Content)
+			if err != nil {
+				return nil, fmt.Errorf("invalid base64 content of file %s: %w", file.Key, err)
+			}
+			content = decoded
+		}
+		if err := add(file.Key, content); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
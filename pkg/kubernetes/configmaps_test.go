@@ -0,0 +1,52 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigData(t *testing.T) {
+	t.Run("merges the literals and the decoded files", func(t *testing.T) {
+		data, err := configData(ConfigDataOptions{
+			Literals: map[string]string{"LOG_LEVEL": "debug"},
+			Files: []ConfigDataFile{
+				{Key: "app.properties", Content: "port=8080"},
+				{Key: "logo.png", Content: "iVBORw0KGgo=", Base64: true},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if string(data["LOG_LEVEL"]) != "debug" || string(data["app.properties"]) != "port=8080" {
+			t.Errorf("unexpected data %v", data)
+		}
+		if string(data["logo.png"]) != "\x89PNG\r\n\x1a\n" {
+			t.Errorf("expected decoded file, got %q", data["logo.png"])
+		}
+	})
+	t.Run("fails without literals or files", func(t *testing.T) {
+		if _, err := configData(ConfigDataOptions{}); err == nil || err.Error() != "no literals or files provided" {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+	t.Run("fails with invalid keys", func(t *testing.T) {
+		if _, err := configData(ConfigDataOptions{Literals: map[string]string{"a/b": "c"}}); err == nil || !strings.HasPrefix(err.Error(), `invalid key "a/b"`) {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+	t.Run("fails with duplicate keys", func(t *testing.T) {
+		_, err := configData(ConfigDataOptions{
+			Literals: map[string]string{"config": "a"},
+			Files:    []ConfigDataFile{{Key: "config", Content: "b"}},
+		})
+		if err == nil || err.Error() != "duplicate key config" {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+	t.Run("fails with invalid base64 content", func(t *testing.T) {
+		_, err := configData(ConfigDataOptions{Files: []ConfigDataFile{{Key: "bin", Content: "not base64!", Base64: true}}})
+		if err == nil || !strings.HasPrefix(err.Error(), "invalid base64 content of file bin") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
@@ -0,0 +1,299 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// connectivityCheckConnectTimeout is the time in seconds to wait for the connection to the target
+	connectivityCheckConnectTimeout = 5
+	ConnectivityCheckTCP            = "tcp"
+	ConnectivityCheckHTTP           = "http"
+)
+
+var (
+	// connectivityCheckPathPattern restricts the HTTP paths, they are interpolated in the script of the source pod
+	connectivityCheckPathPattern = regexp.MustCompile(`^/[A-Za-z0-9._~/?&=%:@+,-]*$`)
+	httpStatusPattern            = regexp.MustCompile(`HTTP/[0-9.]+ ([0-9]{3})`)
+)
+
+type ConnectivityCheckOptions struct {
+	// ProbeOptions configure the probe pod launched if no source pod is provided, its namespace is the source namespace
+	ProbeOptions
+	// Pod is the source pod to exec the check in, a probe pod is launched if empty
+	Pod       string
+	Container string
+	// TargetNamespace is the namespace of the target Service or Pod, the source namespace if empty
+	TargetNamespace string
+	// TargetService, TargetPod, or TargetHost is the target of the check, exactly one is required
+	TargetService string
+	TargetPod     string
+	TargetHost    string
+	// Port is the target port, the first port of the Service if not provided
+	Port int32
+	// Protocol is tcp (connection only) or http (GET request), tcp if empty
+	Protocol string
+	// Path is the path of the HTTP request, / if empty
+	Path string
+}
+
+// ConnectivityCheck is the result of a reachability test along with the NetworkPolicies and endpoints explaining it
+type ConnectivityCheck struct {
+	// Source is the pod the check ran from
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Protocol string `json:"protocol"`
+	// Reachable is true if the connection was established (an HTTP error status is reachable)
+	Reachable  bool `json:"reachable"`
+	HTTPStatus int  `json:"httpStatus,omitempty"`
+	// Output is the output of nc or wget
+	Output string `json:"output,omitempty"`
+	// Service is the target Service with its endpoints, only reported for Service targets
+	Service *ServiceDescription `json:"service,omitempty"`
+	// NetworkPolicies is the verdict of the NetworkPolicies for the traffic, not reported for host targets
+	NetworkPolicies *NetworkPolicySimulation `json:"networkPolicies,omitempty"`
+	// Issues are the problems found, with the suggested checks
+	Issues []string `json:"issues,omitempty"`
+	// port is the requested target port, validated against the ports of the Service
+	port int32
+}
+
+// ConnectivityCheck tests the TCP or HTTP reachability of a Service, Pod, or host from a source pod (exec) or a short-lived
+// probe pod, and correlates the failures with the NetworkPolicies and the readiness of the endpoints
+func (k *Kubernetes) ConnectivityCheck(ctx context.Context, options ConnectivityCheckOptions) (*ConnectivityCheck, error) {
+	ret := &ConnectivityCheck{Protocol: strings.ToLower(options.Protocol), port: options.Port}
+	if ret.Protocol == "" {
+		ret.Protocol = ConnectivityCheckTCP
+	}
+	if ret.Protocol != ConnectivityCheckTCP && ret.Protocol != ConnectivityCheckHTTP {
+		return nil, fmt.Errorf("unsupported protocol %q, expected tcp or http", options.Protocol)
+	}
+	if options.Path != "" && !connectivityCheckPathPattern.MatchString(options.Path) {
+		return nil, fmt.Errorf("invalid HTTP path %q", options.Path)
+	}
+	targets := 0
+	for _, target := range []string{options.TargetService, options.TargetPod, options.TargetHost} {
+		if target != "" {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return nil, fmt.Errorf("exactly one of the target service, pod, or host is required")
+	}
+	options.Namespace = k.NamespaceOrDefault(options.Namespace)
+	if options.TargetNamespace == "" {
+		options.TargetNamespace = options.Namespace
+	}
+	source := NetworkPolicyEndpoint{Namespace: options.Namespace, Pod: options.Pod}
+	if options.Pod == "" {
+		source.Labels = probeLabels("")
+	}
+	destination := NetworkPolicyEndpoint{Namespace: options.TargetNamespace}
+	host, policyPort, err := k.connectivityCheckTarget(ctx, ret, &options, &destination)
+	if err != nil {
+		return nil, err
+	}
+	ret.Target = net.JoinHostPort(host, strconv.Itoa(int(options.Port)))
+	script := connectivityCheckScript(ret.Protocol, host, options.Port, options.Path)
+	var logs string
+	if options.Pod != "" {
+		ret.Source = "pod " + options.Namespace + "/" + options.Pod
+		logs, err = k.PodsExec(ctx, options.Namespace, options.Pod, options.Container, []string{"sh", "-c", script})
+	} else {
+		ret.Source = "probe pod in " + options.Namespace
+		logs, err = k.runProbe(ctx, "connectivity-check", script, options.ProbeOptions)
+	}
+	if err != nil {
+		return nil, err
+	}
+	parseConnectivityCheck(ret, logs)
+	if destination.Pod != "" || len(destination.Labels) > 0 {
+		ret.NetworkPolicies, err = k.NetworkPoliciesSimulate(ctx, NetworkPolicySimulateOptions{
+			Source:      source,
+			Destination: destination,
+			Port:        policyPort,
+		})
+		if err != nil {
+			ret.Issues = append(ret.Issues, fmt.Sprintf("the NetworkPolicies were not evaluated: %v", err))
+		}
+	}
+	ret.Issues = append(ret.Issues, connectivityCheckIssues(ret)...)
+	return ret, nil
+}
+
+// connectivityCheckTarget resolves the host and port to connect to, the destination of the NetworkPolicies,
+// and the port of the pods the NetworkPolicies apply to (the target port of a Service)
+func (k *Kubernetes) connectivityCheckTarget(ctx context.Context, check *ConnectivityCheck, options *ConnectivityCheckOptions, destination *NetworkPolicyEndpoint) (string, int32, error) {
+	switch {
+	case options.TargetService != "":
+		service, err := k.ServicesDescribe(ctx, options.TargetNamespace, options.TargetService)
+		if err != nil {
+			return "", 0, err
+		}
+		check.Service = service
+		if options.Port <= 0 {
+			if len(service.Ports) == 0 {
+				return "", 0, fmt.Errorf("service %s/%s has no ports, a port is required", service.Namespace, service.Name)
+			}
+			options.Port = service.Ports[0].Port
+		}
+		// The NetworkPolicies apply to a pod backing the Service, preferably a ready one
+		for _, endpoint := range service.Endpoints {
+			if endpoint.Pod != "" && (destination.Pod == "" || endpoint.Ready) {
+				destination.Pod = endpoint.Pod
+				if endpoint.Ready {
+					break
+				}
+			}
+		}
+		if destination.Pod == "" {
+			destination.Labels = service.Selector
+		}
+		host := service.Name + "." + service.Namespace + ".svc"
+		return host, serviceTargetPort(service, options.Port), nil
+	case options.TargetPod != "":
+		if options.Port <= 0 {
+			return "", 0, fmt.Errorf("port is required")
+		}
+		pods, err := k.manager.accessControlClientSet.Pods(options.TargetNamespace)
+		if err != nil {
+			return "", 0, err
+		}
+		pod, err := pods.Get(ctx, options.TargetPod, metav1.GetOptions{})
+		if err != nil {
+			return "", 0, err
+		}
+		if pod.Status.PodIP == "" {
+			return "", 0, fmt.Errorf("pod %s/%s has no IP (phase %s)", pod.Namespace, pod.Name, pod.Status.Phase)
+		}
+		destination.Pod = pod.Name
+		return pod.Status.PodIP, options.Port, nil
+	default:
+		if options.Port <= 0 {
+			return "", 0, fmt.Errorf("port is required")
+		}
+		if !dnsNamePattern.MatchString(options.TargetHost) {
+			return "", 0, fmt.Errorf("invalid host %q", options.TargetHost)
+		}
+		return options.TargetHost, options.Port, nil
+	}
+}
+
+// serviceTargetPort returns the port of the pods backing the Service port, resolving the named target ports from the EndpointSlices
+func serviceTargetPort(service *ServiceDescription, port int32) int32 {
+	for _, servicePort := range service.Ports {
+		if servicePort.Port != port {
+			continue
+		}
+		if servicePort.TargetPort == "" {
+			return port
+		}
+		if targetPort, err := strconv.Atoi(servicePort.TargetPort); err == nil {
+			return int32(targetPort)
+		}
+		// EndpointSlice ports are name:port/protocol, named after the Service port
+		for _, endpoint := range service.Endpoints {
+			for _, endpointPort := range endpoint.Ports {
+				name, value, _ := strings.Cut(endpointPort, ":")
+				value, _, _ = strings.Cut(value, "/")
+				if targetPort, err := strconv.Atoi(value); err == nil && name == servicePort.Name {
+					return int32(targetPort)
+				}
+			}
+		}
+	}
+	return port
+}
+
+func connectivityCheckScript(protocol, host string, port int32, path string) string {
+	if protocol == ConnectivityCheckHTTP {
+		if path == "" {
+			path = "/"
+		}
+		url := "http://" + net.JoinHostPort(host, strconv.Itoa(int(port))) + path
+		return fmt.Sprintf("wget -S -O /dev/null -T %d '%s' 2>&1; echo \"### exit $?\"", connectivityCheckConnectTimeout, url)
+	}
+	return fmt.Sprintf("nc -z -v -w %d %s %d 2>&1; echo \"### exit $?\"", connectivityCheckConnectTimeout, host, port)
+}
+
+// parseConnectivityCheck parses the output of nc or wget followed by the ### exit marker
+func parseConnectivityCheck(check *ConnectivityCheck, logs string) {
+	output, exit, _ := strings.Cut(logs, "### exit ")
+	exitCode, err := strconv.Atoi(strings.TrimSpace(exit))
+	check.Output = strings.TrimSpace(output)
+	check.Reachable = err == nil && exitCode == 0
+	if check.Protocol == ConnectivityCheckHTTP {
+		// wget fails on HTTP error statuses, the connection was established anyway
+		if matches := httpStatusPattern.FindAllStringSubmatch(check.Output, -1); len(matches) > 0 {
+			check.HTTPStatus, _ = strconv.Atoi(matches[len(matches)-1][1])
+			check.Reachable = true
+		}
+	}
+}
+
+// connectivityCheckIssues correlates the result of the check with the endpoints of the Service and the NetworkPolicies
+func connectivityCheckIssues(check *ConnectivityCheck) []string {
+	var issues []string
+	output := strings.ToLower(check.Output)
+	for _, tool := range []string{"nc", "wget"} {
+		if strings.Contains(output, tool+": not found") || strings.Contains(output, tool+": command not found") {
+			return []string{fmt.Sprintf("the source container has no %s, omit the source pod to run the check from a probe pod", tool)}
+		}
+	}
+	policiesDeny := check.NetworkPolicies != nil && !check.NetworkPolicies.Allowed
+	if check.Service != nil && check.port > 0 && !serviceExposesPort(check.Service, check.port) {
+		issues = append(issues, fmt.Sprintf("service %s doesn't expose port %d", check.Service.Name, check.port))
+	}
+	if check.Reachable {
+		if check.HTTPStatus >= 400 {
+			issues = append(issues, fmt.Sprintf("the target answered with HTTP status %d, the network path works but the application rejected the request", check.HTTPStatus))
+		}
+		if policiesDeny {
+			issues = append(issues, "the traffic went through although the NetworkPolicies deny it, the network plugin of the cluster may not enforce NetworkPolicies")
+		}
+		return issues
+	}
+	if strings.Contains(output, "bad address") || strings.Contains(output, "unknown host") || strings.Contains(output, "name or service not known") {
+		issues = append(issues, "the target name doesn't resolve from the source pod, run dns_check")
+	}
+	if check.Service != nil {
+		ready := 0
+		for _, endpoint := range check.Service.Endpoints {
+			if endpoint.Ready {
+				ready++
+			}
+		}
+		if ready == 0 {
+			issues = append(issues, fmt.Sprintf("service %s has no ready endpoints", check.Service.Name))
+		}
+		for _, issue := range check.Service.Issues {
+			issues = append(issues, fmt.Sprintf("service %s: %s", check.Service.Name, issue))
+		}
+	}
+	switch {
+	case policiesDeny:
+		issues = append(issues, "the NetworkPolicies deny the traffic: "+check.NetworkPolicies.Explanation)
+	case strings.Contains(output, "refused"):
+		issues = append(issues, "the connection was refused, nothing listens on the target port (check the containerPort of the pods and the targetPort of the Service)")
+	case strings.Contains(output, "timed out") || strings.Contains(output, "timeout"):
+		issues = append(issues, "the connection timed out although the NetworkPolicies allow it, check the network plugin, "+
+			"the cluster-wide policies (e.g. AdminNetworkPolicy, EgressFirewall), and the firewalls between the nodes")
+	}
+	return issues
+}
+
+func serviceExposesPort(service *ServiceDescription, port int32) bool {
+	for _, servicePort := range service.Ports {
+		if servicePort.Port == port {
+			return true
+		}
+	}
+	return false
+}
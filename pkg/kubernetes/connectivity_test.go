@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConnectivityCheckScript(t *testing.T) {
+	t.Run("tcp opens a connection with nc", func(t *testing.T) {
+		script := connectivityCheckScript(ConnectivityCheckTCP, "web.default.svc", 80, "")
+		if !strings.HasPrefix(script, "nc -z -v -w 5 web.default.svc 80 2>&1;") {
+			t.Errorf("unexpected script %q", script)
+		}
+	})
+	t.Run("http sends a GET request with wget", func(t *testing.T) {
+		script := connectivityCheckScript(ConnectivityCheckHTTP, "fd00::1", 8080, "/healthz")
+		if !strings.HasPrefix(script, "wget -S -O /dev/null -T 5 'http://[fd00::1]:8080/healthz' 2>&1;") {
+			t.Errorf("unexpected script %q", script)
+		}
+	})
+	t.Run("rejects the paths escaping the quotes", func(t *testing.T) {
+		if connectivityCheckPathPattern.MatchString("/'; id; '") {
+			t.Errorf("expected the path to be rejected")
+		}
+	})
+}
+
+func TestParseConnectivityCheck(t *testing.T) {
+	t.Run("http error status is reachable", func(t *testing.T) {
+		check := &ConnectivityCheck{Protocol: ConnectivityCheckHTTP}
+		parseConnectivityCheck(check, "Connecting to web:80 (10.96.0.20:80)\n  HTTP/1.1 404 Not Found\nwget: server returned error: HTTP/1.1 404 Not Found\n### exit 1\n")
+		if !check.Reachable || check.HTTPStatus != 404 {
+			t.Errorf("unexpected check %v", check)
+		}
+	})
+	t.Run("tcp connection refused is unreachable", func(t *testing.T) {
+		check := &ConnectivityCheck{Protocol: ConnectivityCheckTCP}
+		parseConnectivityCheck(check, "nc: 10.244.0.7 (10.244.0.7:8080): Connection refused\n### exit 1\n")
+		if check.Reachable || check.Output != "nc: 10.244.0.7 (10.244.0.7:8080): Connection refused" {
+			t.Errorf("unexpected check %v", check)
+		}
+	})
+}
+
+func TestServiceTargetPort(t *testing.T) {
+	service := &ServiceDescription{
+		Ports: []ServicePort{{Name: "http", Port: 80, TargetPort: "web"}, {Name: "metrics", Port: 9090, TargetPort: "9091"}},
+		Endpoints: []ServiceEndpoint{
+			{Addresses: []string{"10.244.0.7"}, Ready: true, Ports: []string{"metrics:9091/TCP", "http:8080/TCP"}},
+		},
+	}
+	for port, expected := range map[int32]int32{80: 8080, 9090: 9091, 443: 443} {
+		if actual := serviceTargetPort(service, port); actual != expected {
+			t.Errorf("expected target port %d for port %d, got %d", expected, port, actual)
+		}
+	}
+}
+
+func TestConnectivityCheckIssues(t *testing.T) {
+	t.Run("unreachable service without ready endpoints denied by NetworkPolicies", func(t *testing.T) {
+		issues := connectivityCheckIssues(&ConnectivityCheck{
+			Output:          "nc: web.default.svc (10.96.0.20:80): Connection timed out",
+			Service:         &ServiceDescription{Name: "web", Ports: []ServicePort{{Port: 80}}, Issues: []string{"no pod matches the selector"}},
+			NetworkPolicies: &NetworkPolicySimulation{Explanation: "the ingress of default/web-0 is denied"},
+			port:            80,
+		})
+		if len(issues) != 3 ||
+			issues[0] != "service web has no ready endpoints" ||
+			issues[1] != "service web: no pod matches the selector" ||
+			issues[2] != "the NetworkPolicies deny the traffic: the ingress of default/web-0 is denied" {
+			t.Errorf("unexpected issues %v", issues)
+		}
+	})
+	t.Run("reachable although denied by NetworkPolicies", func(t *testing.T) {
+		issues := connectivityCheckIssues(&ConnectivityCheck{
+			Reachable:       true,
+			NetworkPolicies: &NetworkPolicySimulation{},
+		})
+		if len(issues) != 1 || !strings.Contains(issues[0], "may not enforce NetworkPolicies") {
+			t.Errorf("unexpected issues %v", issues)
+		}
+	})
+	t.Run("source container without nc", func(t *testing.T) {
+		issues := connectivityCheckIssues(&ConnectivityCheck{Output: "sh: nc: not found"})
+		if len(issues) != 1 || !strings.HasPrefix(issues[0], "the source container has no nc") {
+			t.Errorf("unexpected issues %v", issues)
+		}
+	})
+	t.Run("connection refused to a port not exposed by the service", func(t *testing.T) {
+		issues := connectivityCheckIssues(&ConnectivityCheck{
+			Output:  "nc: web.default.svc (10.96.0.20:81): Connection refused",
+			Service: &ServiceDescription{Name: "web", Ports: []ServicePort{{Port: 80}}, Endpoints: []ServiceEndpoint{{Ready: true}}},
+			port:    81,
+		})
+		if len(issues) != 2 || issues[0] != "service web doesn't expose port 81" || !strings.HasPrefix(issues[1], "the connection was refused") {
+			t.Errorf("unexpected issues %v", issues)
+		}
+	})
+}
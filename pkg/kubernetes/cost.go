@@ -0,0 +1,317 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	resourcehelper "k8s.io/kubectl/pkg/util/resource"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+const (
+	// CostDefaultWindow is the default window of the costs
+	CostDefaultWindow = "1d"
+	// CostDefaultTop is the default maximum number of allocations returned
+	CostDefaultTop = 20
+	// CostAggregateNamespace and CostAggregateWorkload are the aggregations of the costs
+	CostAggregateNamespace = "namespace"
+	CostAggregateWorkload  = "workload"
+	// openCostIdle is the allocation of the idle resources of the nodes reported by OpenCost
+	openCostIdle = "__idle__"
+)
+
+// CostDefaultPricing is the default pricing of OpenCost (custom pricing of the clusters without cloud billing)
+var CostDefaultPricing = config.NodePricing{CPUHourly: 0.031611, MemoryGiBHourly: 0.004237, GPUHourly: 0.95}
+
+// costGPUResources are the extended resources of the GPUs priced with the GPU pricing
+var costGPUResources = []v1.ResourceName{"nvidia.com/gpu", "amd.com/gpu"}
+
+// openCostControllerKinds are the kinds of the controllers reported in lower case by OpenCost
+var openCostControllerKinds = map[string]string{
+	"deployment":  "Deployment",
+	"statefulset": "StatefulSet",
+	"daemonset":   "DaemonSet",
+	"replicaset":  "ReplicaSet",
+	"job":         "Job",
+	"cronjob":     "CronJob",
+}
+
+var errOpenCostNotFound = errors.New("no OpenCost service found in the cluster")
+
+// openCostBackend finds the OpenCost of the cluster, or the cost model of Kubecost, both serving the allocation API
+var openCostBackend = &monitoringBackend{
+	name: "OpenCost",
+	url:  func(c *config.StaticConfig) string { return c.OpenCostURL },
+	knownServices: []monitoringService{
+		{namespace: "opencost", name: "opencost", scheme: "http", port: "9003"},
+		{namespace: "kubecost", name: "kubecost-cost-analyzer", scheme: "http", port: "9003"},
+	},
+	selectors: []string{"app.kubernetes.io/name=opencost", "app=opencost", "app=cost-analyzer"},
+	portNames: []string{"http", "tcp-model"},
+	notFound:  errOpenCostNotFound,
+}
+
+type CostAllocationOptions struct {
+	// Namespace only reports the costs of the namespace if set
+	Namespace string
+	// Aggregate is the aggregation of the costs, namespace or workload
+	Aggregate string
+	// Window is the duration of the costs until now (e.g. 1d, 7d, 24h)
+	Window string
+	Top    int
+}
+
+// CostReport are the costs of the namespaces or of the workloads over the window, the most expensive first
+type CostReport struct {
+	// Source is the OpenCost that answered, or how the costs were estimated
+	Source    string `json:"source"`
+	Window    string `json:"window"`
+	Aggregate string `json:"aggregate"`
+	// Estimated is set if the costs are estimated from the resource requests of the running Pods over the window
+	Estimated bool `json:"estimated,omitempty"`
+	// Total is the cost of the allocations (including the truncated ones), Idle the cost of the unrequested resources of the nodes
+	Total       float64          `json:"total"`
+	Idle        float64          `json:"idle,omitempty"`
+	Truncated   bool             `json:"truncated,omitempty"`
+	Allocations []CostAllocation `json:"allocations"`
+}
+
+type CostAllocation struct {
+	Namespace string `json:"namespace"`
+	// Workload is the kind and the name of the workload (e.g. Deployment/web)
+	Workload    string  `json:"workload,omitempty"`
+	CPUCost     float64 `json:"cpuCost"`
+	RAMCost     float64 `json:"ramCost"`
+	GPUCost     float64 `json:"gpuCost,omitempty"`
+	PVCost      float64 `json:"pvCost,omitempty"`
+	NetworkCost float64 `json:"networkCost,omitempty"`
+	// OtherCost are the load balancer, shared, and external costs
+	OtherCost float64 `json:"otherCost,omitempty"`
+	TotalCost float64 `json:"totalCost"`
+	// Efficiency is the usage over the requests of the resources weighted by their cost (OpenCost only)
+	Efficiency string `json:"efficiency,omitempty"`
+}
+
+// openCostAllocation is an allocation of the OpenCost allocation API
+type openCostAllocation struct {
+	Name       string `json:"name"`
+	Properties struct {
+		Namespace      string `json:"namespace"`
+		ControllerKind string `json:"controllerKind"`
+		Controller     string `json:"controller"`
+		Pod            string `json:"pod"`
+	} `json:"properties"`
+	CPUCost          float64  `json:"cpuCost"`
+	GPUCost          float64  `json:"gpuCost"`
+	RAMCost          float64  `json:"ramCost"`
+	PVCost           float64  `json:"pvCost"`
+	NetworkCost      float64  `json:"networkCost"`
+	LoadBalancerCost float64  `json:"loadBalancerCost"`
+	SharedCost       float64  `json:"sharedCost"`
+	ExternalCost     float64  `json:"externalCost"`
+	TotalCost        float64  `json:"totalCost"`
+	TotalEfficiency  *float64 `json:"totalEfficiency"`
+}
+
+// CostAllocation returns the costs of the namespaces or of the workloads over the window with the OpenCost of the cluster,
+// or estimates them from the resource requests of the running Pods and the pricing of their nodes if no OpenCost is found
+func (k *Kubernetes) CostAllocation(ctx context.Context, options CostAllocationOptions) (*CostReport, error) {
+	options.Window = cmp.Or(options.Window, CostDefaultWindow)
+	options.Aggregate = cmp.Or(options.Aggregate, CostAggregateNamespace)
+	options.Top = cmp.Or(options.Top, CostDefaultTop)
+	window, err := parsePromQLDuration(options.Window)
+	if err != nil || window <= 0 {
+		return nil, fmt.Errorf("invalid window %q, expected a duration (e.g. 1d, 7d, 12h)", options.Window)
+	}
+	if options.Aggregate != CostAggregateNamespace && options.Aggregate != CostAggregateWorkload {
+		return nil, fmt.Errorf("invalid aggregate %q, expected %s or %s", options.Aggregate, CostAggregateNamespace, CostAggregateWorkload)
+	}
+	aggregate := "namespace"
+	if options.Aggregate == CostAggregateWorkload {
+		aggregate = "namespace,controllerKind,controller"
+	}
+	response, source, err := k.monitoringRequest(ctx, openCostBackend, http.MethodGet, "/allocation", map[string]string{
+		"window":     options.Window,
+		"aggregate":  aggregate,
+		"accumulate": "true",
+	}, nil, nil)
+	var ret *CostReport
+	if errors.Is(err, errOpenCostNotFound) {
+		pricing := config.CostPricing{}
+		if k.manager.staticConfig != nil {
+			pricing = k.manager.staticConfig.CostPricing
+		}
+		sc := &schedulingContext{pods: make(map[string][]v1.Pod)}
+		if err = k.schedulingCluster(ctx, sc); err != nil {
+			return nil, err
+		}
+		ret = estimateCosts(sc, pricing, options, window)
+	} else if err != nil {
+		return nil, err
+	} else if ret, err = parseOpenCostAllocations(response, options); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	} else {
+		ret.Source = "OpenCost " + source
+	}
+	sortCostAllocations(ret, options.Top)
+	return ret, nil
+}
+
+// parseOpenCostAllocations parses the response of the allocation API, the allocations of the sets of the window are summed
+func parseOpenCostAllocations(body []byte, options CostAllocationOptions) (*CostReport, error) {
+	response := struct {
+		Code    int                             `json:"code"`
+		Message string                          `json:"message"`
+		Data    []map[string]openCostAllocation `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse the allocations: %w", err)
+	}
+	if response.Code != 0 && response.Code != http.StatusOK {
+		return nil, fmt.Errorf("failed to compute the allocations: %s (%d)", response.Message, response.Code)
+	}
+	ret := &CostReport{Window: options.Window, Aggregate: options.Aggregate, Allocations: []CostAllocation{}}
+	allocations := make(map[string]*CostAllocation)
+	efficiencies := make(map[string][2]float64)
+	var keys []string
+	for _, set := range response.Data {
+		for key, allocation := range set {
+			if key == openCostIdle {
+				ret.Idle += allocation.TotalCost
+				continue
+			}
+			namespace := cmp.Or(allocation.Properties.Namespace, strings.Split(key, "/")[0])
+			if options.Namespace != "" && namespace != options.Namespace {
+				continue
+			}
+			cost, ok := allocations[key]
+			if !ok {
+				cost = &CostAllocation{Namespace: namespace}
+				if options.Aggregate == CostAggregateWorkload {
+					cost.Workload = openCostWorkload(key, allocation)
+				}
+				allocations[key] = cost
+				keys = append(keys, key)
+			}
+			cost.CPUCost += allocation.CPUCost
+			cost.RAMCost += allocation.RAMCost
+			cost.GPUCost += allocation.GPUCost
+			cost.PVCost += allocation.PVCost
+			cost.NetworkCost += allocation.NetworkCost
+			cost.OtherCost += allocation.LoadBalancerCost + allocation.SharedCost + allocation.ExternalCost
+			cost.TotalCost += allocation.TotalCost
+			if allocation.TotalEfficiency != nil && allocation.TotalCost > 0 {
+				weighted := efficiencies[key]
+				efficiencies[key] = [2]float64{weighted[0] + *allocation.TotalEfficiency*allocation.TotalCost, weighted[1] + allocation.TotalCost}
+			}
+		}
+	}
+	for _, key := range keys {
+		if weighted, ok := efficiencies[key]; ok && weighted[1] > 0 {
+			allocations[key].Efficiency = fmt.Sprintf("%.0f%%", 100*weighted[0]/weighted[1])
+		}
+		ret.Allocations = append(ret.Allocations, *allocations[key])
+	}
+	return ret, nil
+}
+
+// openCostWorkload returns the kind and the name of the workload of the allocation aggregated by namespace, controller kind, and controller,
+// the allocations of the Pods without controller are reported as is (__unallocated__)
+func openCostWorkload(key string, allocation openCostAllocation) string {
+	if allocation.Properties.Controller == "" {
+		parts := strings.SplitN(key, "/", 3)
+		return parts[len(parts)-1]
+	}
+	kind := allocation.Properties.ControllerKind
+	return cmp.Or(openCostControllerKinds[kind], kind) + "/" + allocation.Properties.Controller
+}
+
+// estimateCosts estimates the costs of the running Pods as their requests over the whole window, priced with the pricing of their node,
+// which is a projection of the current requests rather than the actual costs (no storage, network, or idle costs)
+func estimateCosts(sc *schedulingContext, pricing config.CostPricing, options CostAllocationOptions, window time.Duration) *CostReport {
+	ret := &CostReport{
+		Source:      "estimate (current resource requests of the running Pods over the window, priced per node)",
+		Window:      options.Window,
+		Aggregate:   options.Aggregate,
+		Estimated:   true,
+		Allocations: []CostAllocation{},
+	}
+	hours := window.Hours()
+	allocations := make(map[string]*CostAllocation)
+	var keys []string
+	for _, node := range sc.nodes {
+		nodePricing := costNodePricing(&node, pricing)
+		for _, pod := range sc.pods[node.Name] {
+			if options.Namespace != "" && pod.Namespace != options.Namespace {
+				continue
+			}
+			key, workload := pod.Namespace, ""
+			if options.Aggregate == CostAggregateWorkload {
+				kind, name := podWorkload(&pod)
+				workload = kind + "/" + name
+				key += "/" + workload
+			}
+			cost, ok := allocations[key]
+			if !ok {
+				cost = &CostAllocation{Namespace: pod.Namespace, Workload: workload}
+				allocations[key] = cost
+				keys = append(keys, key)
+			}
+			requests, _ := resourcehelper.PodRequestsAndLimits(&pod)
+			cost.CPUCost += requests.Cpu().AsApproximateFloat64() * nodePricing.CPUHourly * hours
+			cost.RAMCost += requests.Memory().AsApproximateFloat64() / (1 << 30) * nodePricing.MemoryGiBHourly * hours
+			for _, gpu := range costGPUResources {
+				if quantity, found := requests[gpu]; found {
+					cost.GPUCost += quantity.AsApproximateFloat64() * nodePricing.GPUHourly * hours
+				}
+			}
+			cost.TotalCost = cost.CPUCost + cost.RAMCost + cost.GPUCost
+		}
+	}
+	for _, key := range keys {
+		ret.Allocations = append(ret.Allocations, *allocations[key])
+	}
+	return ret
+}
+
+// costNodePricing returns the pricing of the instance type of the node, the prices not set fall back to the configured ones, then to the defaults
+func costNodePricing(node *v1.Node, pricing config.CostPricing) config.NodePricing {
+	instanceType := pricing.InstanceTypes[node.Labels[v1.LabelInstanceTypeStable]]
+	return config.NodePricing{
+		CPUHourly:       cmp.Or(instanceType.CPUHourly, pricing.CPUHourly, CostDefaultPricing.CPUHourly),
+		MemoryGiBHourly: cmp.Or(instanceType.MemoryGiBHourly, pricing.MemoryGiBHourly, CostDefaultPricing.MemoryGiBHourly),
+		GPUHourly:       cmp.Or(instanceType.GPUHourly, pricing.GPUHourly, CostDefaultPricing.GPUHourly),
+	}
+}
+
+// sortCostAllocations rounds the costs to the cent, computes the total, and keeps the top most expensive allocations
+func sortCostAllocations(report *CostReport, top int) {
+	round := func(value float64) float64 {
+		return math.Round(value*100) / 100
+	}
+	for i := range report.Allocations {
+		a := &report.Allocations[i]
+		report.Total += a.TotalCost
+		for _, cost := range []*float64{&a.CPUCost, &a.RAMCost, &a.GPUCost, &a.PVCost, &a.NetworkCost, &a.OtherCost, &a.TotalCost} {
+			*cost = round(*cost)
+		}
+	}
+	report.Total, report.Idle = round(report.Total), round(report.Idle)
+	slices.SortStableFunc(report.Allocations, func(a, b CostAllocation) int {
+		return cmp.Or(cmp.Compare(b.TotalCost, a.TotalCost), strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Workload, b.Workload))
+	})
+	if top > 0 && len(report.Allocations) > top {
+		report.Allocations = report.Allocations[:top]
+		report.Truncated = true
+	}
+}
@@ -0,0 +1,126 @@
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+const openCostAllocationResponse = `{"code":200,"data":[{
+	"ns-1/deployment/web":{"name":"ns-1/deployment/web","properties":{"namespace":"ns-1","controllerKind":"deployment","controller":"web"},
+		"cpuCost":3.5,"ramCost":1.25,"pvCost":0.5,"networkCost":0.1,"loadBalancerCost":0.4,"totalCost":5.75,"totalEfficiency":0.4},
+	"ns-1/__unallocated__/__unallocated__":{"name":"ns-1/__unallocated__/__unallocated__","properties":{"namespace":"ns-1"},
+		"cpuCost":0.2,"ramCost":0.1,"totalCost":0.3},
+	"ns-2/statefulset/db":{"name":"ns-2/statefulset/db","properties":{"namespace":"ns-2","controllerKind":"statefulset","controller":"db"},
+		"cpuCost":7,"ramCost":3,"totalCost":10,"totalEfficiency":0.8},
+	"__idle__":{"name":"__idle__","cpuCost":10,"ramCost":2.345,"totalCost":12.345}
+}]}`
+
+func TestCostAllocationOpenCost(t *testing.T) {
+	var aggregate, window string
+	openCost := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/allocation" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		aggregate, window = r.URL.Query().Get("aggregate"), r.URL.Query().Get("window")
+		_, _ = w.Write([]byte(openCostAllocationResponse))
+	}))
+	defer openCost.Close()
+	k := &Kubernetes{manager: &Manager{cfg: &rest.Config{}, staticConfig: &config.StaticConfig{OpenCostURL: openCost.URL}}}
+	t.Run("workloads", func(t *testing.T) {
+		ret, err := k.CostAllocation(context.Background(), CostAllocationOptions{Aggregate: CostAggregateWorkload, Window: "7d"})
+		if err != nil {
+			t.Fatalf("failed to get costs: %v", err)
+		}
+		if aggregate != "namespace,controllerKind,controller" || window != "7d" || ret.Source != "OpenCost "+openCost.URL || ret.Estimated {
+			t.Errorf("unexpected request %s %s or source %s", aggregate, window, ret.Source)
+		}
+		if ret.Total != 16.05 || ret.Idle != 12.35 || len(ret.Allocations) != 3 {
+			t.Fatalf("unexpected costs %+v", ret)
+		}
+		expected := []CostAllocation{
+			{Namespace: "ns-2", Workload: "StatefulSet/db", CPUCost: 7, RAMCost: 3, TotalCost: 10, Efficiency: "80%"},
+			{Namespace: "ns-1", Workload: "Deployment/web", CPUCost: 3.5, RAMCost: 1.25, PVCost: 0.5, NetworkCost: 0.1, OtherCost: 0.4, TotalCost: 5.75, Efficiency: "40%"},
+			{Namespace: "ns-1", Workload: "__unallocated__", CPUCost: 0.2, RAMCost: 0.1, TotalCost: 0.3},
+		}
+		for i, e := range expected {
+			if ret.Allocations[i] != e {
+				t.Errorf("expected allocation %d to be %+v, got %+v", i, e, ret.Allocations[i])
+			}
+		}
+	})
+	t.Run("namespace", func(t *testing.T) {
+		ret, err := k.CostAllocation(context.Background(), CostAllocationOptions{Namespace: "ns-1", Top: 1})
+		if err != nil {
+			t.Fatalf("failed to get costs: %v", err)
+		}
+		if aggregate != "namespace" || window != CostDefaultWindow || !ret.Truncated || len(ret.Allocations) != 1 || ret.Total != 6.05 {
+			t.Errorf("unexpected request %s %s or costs %+v", aggregate, window, ret)
+		}
+	})
+	t.Run("invalid window", func(t *testing.T) {
+		if _, err := k.CostAllocation(context.Background(), CostAllocationOptions{Window: "lastweek"}); err == nil {
+			t.Errorf("expected an invalid window error")
+		}
+	})
+}
+
+func TestEstimateCosts(t *testing.T) {
+	node := func(name, instanceType string) v1.Node {
+		n := schedulingTestNode(name, map[string]string{v1.LabelInstanceTypeStable: instanceType}, "4")
+		return n
+	}
+	pod := func(namespace, name, cpu, memory string, controller *metav1.OwnerReference) v1.Pod {
+		p := schedulingTestPod(namespace, name, map[string]string{"pod-template-hash": "abc"}, cpu)
+		p.Spec.Containers[0].Resources.Requests[v1.ResourceMemory] = resource.MustParse(memory)
+		if controller != nil {
+			p.OwnerReferences = []metav1.OwnerReference{*controller}
+		}
+		return p
+	}
+	replicaSet := &metav1.OwnerReference{Kind: "ReplicaSet", Name: "web-abc", Controller: ptr.To(true)}
+	sc := &schedulingContext{
+		nodes: []v1.Node{node("node-1", "m5.large"), node("node-2", "c5.large")},
+		pods: map[string][]v1.Pod{
+			"node-1": {pod("ns-1", "web-abc-1", "1", "2Gi", replicaSet), pod("ns-2", "job-1", "500m", "1Gi", nil)},
+			"node-2": {pod("ns-1", "web-abc-2", "1", "2Gi", replicaSet)},
+		},
+	}
+	pricing := config.CostPricing{
+		NodePricing:   config.NodePricing{CPUHourly: 0.05},
+		InstanceTypes: map[string]config.NodePricing{"c5.large": {CPUHourly: 0.1, MemoryGiBHourly: 0.01}},
+	}
+	t.Run("workloads priced per node", func(t *testing.T) {
+		ret := estimateCosts(sc, pricing, CostAllocationOptions{Aggregate: CostAggregateWorkload, Window: "10h"}, 10*time.Hour)
+		sortCostAllocations(ret, 0)
+		if !ret.Estimated || len(ret.Allocations) != 2 {
+			t.Fatalf("unexpected costs %+v", ret)
+		}
+		// web: 1 core at 0.05 and 2Gi at the default 0.004237 on node-1, 1 core at 0.1 and 2Gi at 0.01 on node-2, over 10h
+		web := ret.Allocations[0]
+		if web.Namespace != "ns-1" || web.Workload != "Deployment/web" || web.CPUCost != 1.5 || web.RAMCost != 0.28 || web.TotalCost != 1.78 {
+			t.Errorf("unexpected web costs %+v", web)
+		}
+		job := ret.Allocations[1]
+		if job.Workload != "Pod/job-1" || job.CPUCost != 0.25 || job.RAMCost != 0.04 || ret.Total != 2.08 {
+			t.Errorf("unexpected job costs %+v, total %v", job, ret.Total)
+		}
+	})
+	t.Run("namespace", func(t *testing.T) {
+		ret := estimateCosts(sc, pricing, CostAllocationOptions{Namespace: "ns-2", Aggregate: CostAggregateNamespace, Window: "10h"}, 10*time.Hour)
+		if len(ret.Allocations) != 1 || ret.Allocations[0].Namespace != "ns-2" || ret.Allocations[0].Workload != "" {
+			t.Errorf("unexpected costs %+v", ret)
+		}
+	})
+}
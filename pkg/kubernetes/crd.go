@@ -0,0 +1,171 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+var customResourceDefinitionGVK = &schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// CustomResourceDefinition is the summary of a CRD with its versions, conditions, and detected issues
+type CustomResourceDefinition struct {
+	Name           string                              `json:"name"`
+	Group          string                              `json:"group"`
+	Kind           string                              `json:"kind"`
+	Scope          string                              `json:"scope"`
+	Versions       []CustomResourceDefinitionVersion   `json:"versions"`
+	StoredVersions []string                            `json:"storedVersions,omitempty"`
+	Conditions     []CustomResourceDefinitionCondition `json:"conditions,omitempty"`
+	// Issues reports the deprecated, unserved, or pending storage migration versions and the unhealthy conditions
+	Issues []string `json:"issues,omitempty"`
+}
+
+type CustomResourceDefinitionVersion struct {
+	Name               string `json:"name"`
+	Served             bool   `json:"served"`
+	Storage            bool   `json:"storage"`
+	Deprecated         bool   `json:"deprecated,omitempty"`
+	DeprecationWarning string `json:"deprecationWarning,omitempty"`
+}
+
+type CustomResourceDefinitionCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// CustomResourceDefinitionsList lists the CRDs of the cluster, optionally only the ones of the provided API group
+func (k *Kubernetes) CustomResourceDefinitionsList(ctx context.Context, group string) ([]CustomResourceDefinition, error) {
+	list, err := k.ResourcesList(ctx, customResourceDefinitionGVK, "", ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]CustomResourceDefinition, 0)
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, crd); err != nil {
+			return nil, err
+		}
+		if group != "" && crd.Spec.Group != group {
+			continue
+		}
+		ret = append(ret, customResourceDefinition(crd))
+	}
+	return ret, nil
+}
+
+// CustomResourceDefinitionSchema returns the OpenAPI v3 schema of the provided version of the CRD, the storage version if not provided
+func (k *Kubernetes) CustomResourceDefinitionSchema(ctx context.Context, name, version string) (*apiextensionsv1.JSONSchemaProps, error) {
+	crd, err := k.customResourceDefinitionGet(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range crd.Spec.Versions {
+		if (version == "" && v.Storage) || v.Name == version {
+			if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+				return nil, fmt.Errorf("version %s of %s has no schema", v.Name, name)
+			}
+			return v.Schema.OpenAPIV3Schema, nil
+		}
+	}
+	return nil, fmt.Errorf("version %s not found in %s", version, name)
+}
+
+// CustomResourcesList lists the instances of the CRD (e.g. widgets.example.com) using its storage version,
+// in all namespaces if no namespace is provided
+func (k *Kubernetes) CustomResourcesList(ctx context.Context, name, namespace string, options ResourceListOptions) (runtime.Unstructured, error) {
+	crd, err := k.customResourceDefinitionGet(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	version := ""
+	for _, v := range crd.Spec.Versions {
+		if v.Served && (version == "" || v.Storage) {
+			version = v.Name
+		}
+	}
+	if version == "" {
+		return nil, fmt.Errorf("%s has no served versions", name)
+	}
+	gvk := &schema.GroupVersionKind{Group: crd.Spec.Group, Version: version, Kind: crd.Spec.Names.Kind}
+	if crd.Spec.Scope == apiextensionsv1.ClusterScoped {
+		namespace = ""
+	}
+	return k.ResourcesList(ctx, gvk, namespace, options)
+}
+
+func (k *Kubernetes) customResourceDefinitionGet(ctx context.Context, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	obj, err := k.ResourcesGet(ctx, customResourceDefinitionGVK, "", name)
+	if err != nil {
+		return nil, err
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func customResourceDefinition(crd *apiextensionsv1.CustomResourceDefinition) CustomResourceDefinition {
+	ret := CustomResourceDefinition{
+		Name:           crd.Name,
+		Group:          crd.Spec.Group,
+		Kind:           crd.Spec.Names.Kind,
+		Scope:          string(crd.Spec.Scope),
+		StoredVersions: crd.Status.StoredVersions,
+	}
+	served := 0
+	for _, v := range crd.Spec.Versions {
+		ret.Versions = append(ret.Versions, CustomResourceDefinitionVersion{
+			Name:               v.Name,
+			Served:             v.Served,
+			Storage:            v.Storage,
+			Deprecated:         v.Deprecated,
+			DeprecationWarning: ptr.Deref(v.DeprecationWarning, ""),
+		})
+		switch {
+		case v.Served:
+			served++
+			if v.Deprecated && v.Storage {
+				ret.Issues = append(ret.Issues, fmt.Sprintf("storage version %s is deprecated, migrate to a non-deprecated version", v.Name))
+			} else if v.Deprecated {
+				ret.Issues = append(ret.Issues, fmt.Sprintf("version %s is deprecated but still served", v.Name))
+			}
+		case v.Storage:
+			ret.Issues = append(ret.Issues, fmt.Sprintf("storage version %s is not served", v.Name))
+		}
+	}
+	if served == 0 {
+		ret.Issues = append(ret.Issues, "no version is served")
+	}
+	for _, storedVersion := range crd.Status.StoredVersions {
+		i := slices.IndexFunc(crd.Spec.Versions, func(v apiextensionsv1.CustomResourceDefinitionVersion) bool { return v.Name == storedVersion })
+		switch {
+		case i < 0:
+			ret.Issues = append(ret.Issues, fmt.Sprintf("stored version %s is no longer defined, objects stored with it must be migrated", storedVersion))
+		case !crd.Spec.Versions[i].Storage:
+			ret.Issues = append(ret.Issues, fmt.Sprintf("objects may still be stored as %s, migrate them to the storage version before removing it", storedVersion))
+		}
+	}
+	for _, condition := range crd.Status.Conditions {
+		ret.Conditions = append(ret.Conditions, CustomResourceDefinitionCondition{
+			Type: string(condition.Type), Status: string(condition.Status), Reason: condition.Reason, Message: condition.Message,
+		})
+		switch {
+		case condition.Type == apiextensionsv1.Established && condition.Status != apiextensionsv1.ConditionTrue,
+			condition.Type == apiextensionsv1.NamesAccepted && condition.Status != apiextensionsv1.ConditionTrue,
+			condition.Type == apiextensionsv1.NonStructuralSchema && condition.Status == apiextensionsv1.ConditionTrue,
+			condition.Type == apiextensionsv1.Terminating && condition.Status == apiextensionsv1.ConditionTrue:
+			ret.Issues = append(ret.Issues, fmt.Sprintf("condition %s is %s: %s", condition.Type, condition.Status, condition.Message))
+		}
+	}
+	return ret
+}
@@ -0,0 +1,105 @@
+package kubernetes
+
+import (
+	"slices"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func crdFixture(versions []apiextensionsv1.CustomResourceDefinitionVersion, storedVersions ...string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group:    "example.com",
+			Names:    apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget", Plural: "widgets"},
+			Scope:    apiextensionsv1.NamespaceScoped,
+			Versions: versions,
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			StoredVersions: storedVersions,
+			Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+				{Type: apiextensionsv1.NamesAccepted, Status: apiextensionsv1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func TestCustomResourceDefinition(t *testing.T) {
+	t.Run("healthy CRD has no issues", func(t *testing.T) {
+		crd := customResourceDefinition(crdFixture([]apiextensionsv1.CustomResourceDefinitionVersion{
+			{Name: "v1", Served: true, Storage: true},
+		}, "v1"))
+		if len(crd.Issues) != 0 {
+			t.Errorf("expected no issues, got %v", crd.Issues)
+		}
+		if crd.Name != "widgets.example.com" || crd.Group != "example.com" || crd.Kind != "Widget" || crd.Scope != "Namespaced" {
+			t.Errorf("unexpected summary %+v", crd)
+		}
+		if len(crd.Versions) != 1 || len(crd.Conditions) != 2 {
+			t.Errorf("expected 1 version and 2 conditions, got %v and %v", crd.Versions, crd.Conditions)
+		}
+	})
+	t.Run("deprecated version still served", func(t *testing.T) {
+		crd := customResourceDefinition(crdFixture([]apiextensionsv1.CustomResourceDefinitionVersion{
+			{Name: "v1beta1", Served: true, Deprecated: true, DeprecationWarning: ptr.To("use v1")},
+			{Name: "v1", Served: true, Storage: true},
+		}, "v1"))
+		if !slices.Equal(crd.Issues, []string{"version v1beta1 is deprecated but still served"}) {
+			t.Errorf("unexpected issues %v", crd.Issues)
+		}
+		if crd.Versions[0].DeprecationWarning != "use v1" {
+			t.Errorf("expected deprecation warning, got %q", crd.Versions[0].DeprecationWarning)
+		}
+	})
+	t.Run("deprecated storage version", func(t *testing.T) {
+		crd := customResourceDefinition(crdFixture([]apiextensionsv1.CustomResourceDefinitionVersion{
+			{Name: "v1alpha1", Served: true, Storage: true, Deprecated: true},
+		}, "v1alpha1"))
+		if !slices.Equal(crd.Issues, []string{"storage version v1alpha1 is deprecated, migrate to a non-deprecated version"}) {
+			t.Errorf("unexpected issues %v", crd.Issues)
+		}
+	})
+	t.Run("storage version not served", func(t *testing.T) {
+		crd := customResourceDefinition(crdFixture([]apiextensionsv1.CustomResourceDefinitionVersion{
+			{Name: "v1", Storage: true},
+		}, "v1"))
+		if !slices.Equal(crd.Issues, []string{"storage version v1 is not served", "no version is served"}) {
+			t.Errorf("unexpected issues %v", crd.Issues)
+		}
+	})
+	t.Run("stored versions pending migration", func(t *testing.T) {
+		crd := customResourceDefinition(crdFixture([]apiextensionsv1.CustomResourceDefinitionVersion{
+			{Name: "v1beta1", Served: true},
+			{Name: "v1", Served: true, Storage: true},
+		}, "v1alpha1", "v1beta1", "v1"))
+		expected := []string{
+			"stored version v1alpha1 is no longer defined, objects stored with it must be migrated",
+			"objects may still be stored as v1beta1, migrate them to the storage version before removing it",
+		}
+		if !slices.Equal(crd.Issues, expected) {
+			t.Errorf("unexpected issues %v", crd.Issues)
+		}
+	})
+	t.Run("unhealthy conditions", func(t *testing.T) {
+		fixture := crdFixture([]apiextensionsv1.CustomResourceDefinitionVersion{
+			{Name: "v1", Served: true, Storage: true},
+		}, "v1")
+		fixture.Status.Conditions = []apiextensionsv1.CustomResourceDefinitionCondition{
+			{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionFalse, Message: "not yet"},
+			{Type: apiextensionsv1.NamesAccepted, Status: apiextensionsv1.ConditionTrue},
+			{Type: apiextensionsv1.NonStructuralSchema, Status: apiextensionsv1.ConditionTrue, Message: "missing type"},
+		}
+		crd := customResourceDefinition(fixture)
+		expected := []string{
+			"condition Established is False: not yet",
+			"condition NonStructuralSchema is True: missing type",
+		}
+		if !slices.Equal(crd.Issues, expected) {
+			t.Errorf("unexpected issues %v", crd.Issues)
+		}
+	})
+}
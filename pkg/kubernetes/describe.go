@@ -0,0 +1,185 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+// MaxOwnerChainDepth limits the number of owners followed from an object (e.g. Pod -> ReplicaSet -> Deployment)
+const MaxOwnerChainDepth = 10
+
+// Description is the machine-readable counterpart of kubectl describe
+type Description struct {
+	Object     map[string]any         `json:"object"`
+	Owners     []Owner                `json:"owners,omitempty"`
+	Containers []ContainerDescription `json:"containers,omitempty"`
+	Events     []map[string]any       `json:"events,omitempty"`
+	// Warnings reports the parts of the description that couldn't be retrieved (e.g. forbidden owners)
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Owner is an object of the owner chain, the closest owner first
+type Owner struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+}
+
+type ContainerDescription struct {
+	Name         string            `json:"name"`
+	Init         bool              `json:"init,omitempty"`
+	Image        string            `json:"image"`
+	Ready        bool              `json:"ready"`
+	Started      *bool             `json:"started,omitempty"`
+	RestartCount int32             `json:"restartCount"`
+	State        string            `json:"state"`
+	LastState    string            `json:"lastState,omitempty"`
+	Probes       map[string]string `json:"probes,omitempty"`
+}
+
+// ResourcesDescribe combines the object with its owner chain, its events, and (for pods) the state and probes of its containers
+func (k *Kubernetes) ResourcesDescribe(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*Description, error) {
+	obj, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	obj.SetManagedFields(nil)
+	ret := &Description{Object: obj.Object}
+	ret.Owners, err = k.ResourcesOwnerChain(ctx, obj)
+	if err != nil {
+		ret.Warnings = append(ret.Warnings, fmt.Sprintf("owner chain is incomplete: %v", err))
+	}
+	if gvk.Group == "" && gvk.Kind == "Pod" {
+		pod := &v1.Pod{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pod); err != nil {
+			return nil, err
+		}
+		ret.Containers = describeContainers(pod)
+	}
+	ret.Events, err = k.EventsList(ctx, obj.GetNamespace(), EventsListOptions{InvolvedObjectKind: gvk.Kind, InvolvedObjectName: name})
+	if err != nil {
+		ret.Warnings = append(ret.Warnings, fmt.Sprintf("events are not available: %v", err))
+	}
+	return ret, nil
+}
+
+// ResourcesOwnerChain follows the controller owner references of the object up to its top-level owner
+func (k *Kubernetes) ResourcesOwnerChain(ctx context.Context, obj *unstructured.Unstructured) ([]Owner, error) {
+	var owners []Owner
+	for range MaxOwnerChainDepth {
+		ownerReference := controllerOf(obj.GetOwnerReferences())
+		if ownerReference == nil {
+			return owners, nil
+		}
+		owner := Owner{APIVersion: ownerReference.APIVersion, Kind: ownerReference.Kind, Name: ownerReference.Name, Namespace: obj.GetNamespace()}
+		owners = append(owners, owner)
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err != nil {
+			return owners, err
+		}
+		if obj, err = k.ResourcesGet(ctx, ptr.To(gv.WithKind(owner.Kind)), owner.Namespace, owner.Name); err != nil {
+			return owners, err
+		}
+	}
+	return owners, nil
+}
+
+// controllerOf returns the managing controller reference, or the first owner reference if none is flagged as controller
+func controllerOf(ownerReferences []metav1.OwnerReference) *metav1.OwnerReference {
+	for i := range ownerReferences {
+		if ownerReferences[i].Controller != nil && *ownerReferences[i].Controller {
+			return &ownerReferences[i]
+		}
+	}
+	if len(ownerReferences) > 0 {
+		return &ownerReferences[0]
+	}
+	return nil
+}
+
+func describeContainers(pod *v1.Pod) []ContainerDescription {
+	statuses := make(map[string]v1.ContainerStatus)
+	for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		statuses[status.Name] = status
+	}
+	var ret []ContainerDescription
+	describe := func(container v1.Container, init bool) {
+		status := statuses[container.Name]
+		description := ContainerDescription{
+			Name:         container.Name,
+			Init:         init,
+			Image:        container.Image,
+			Ready:        status.Ready,
+			Started:      status.Started,
+			RestartCount: status.RestartCount,
+			State:        describeContainerState(status.State),
+			Probes:       make(map[string]string),
+		}
+		if status.LastTerminationState.Terminated != nil {
+			description.LastState = describeContainerState(status.LastTerminationState)
+		}
+		for probeType, probe := range map[string]*v1.Probe{"liveness": container.LivenessProbe, "readiness": container.ReadinessProbe, "startup": container.StartupProbe} {
+			if probe != nil {
+				description.Probes[probeType] = describeProbe(probe)
+			}
+		}
+		ret = append(ret, description)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		describe(container, true)
+	}
+	for _, container := range pod.Spec.Containers {
+		describe(container, false)
+	}
+	return ret
+}
+
+// describeContainerState https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/describe/describe.go#L2051-L2084
+func describeContainerState(state v1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return fmt.Sprintf("Running (started %s)", state.Running.StartedAt.UTC().Format("2006-01-02T15:04:05Z"))
+	case state.Waiting != nil:
+		return strings.TrimSpace(fmt.Sprintf("Waiting: %s %s", state.Waiting.Reason, state.Waiting.Message))
+	case state.Terminated != nil:
+		terminated := fmt.Sprintf("Terminated: %s (exit code %d", state.Terminated.Reason, state.Terminated.ExitCode)
+		if state.Terminated.Signal != 0 {
+			terminated += fmt.Sprintf(", signal %d", state.Terminated.Signal)
+		}
+		terminated += fmt.Sprintf(", finished %s)", state.Terminated.FinishedAt.UTC().Format("2006-01-02T15:04:05Z"))
+		if state.Terminated.Message != "" {
+			terminated += " " + state.Terminated.Message
+		}
+		return terminated
+	default:
+		return "Waiting"
+	}
+}
+
+// describeProbe https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/describe/describe.go#L2103-L2118
+func describeProbe(probe *v1.Probe) string {
+	attrs := fmt.Sprintf("delay=%ds timeout=%ds period=%ds #success=%d #failure=%d",
+		probe.InitialDelaySeconds, probe.TimeoutSeconds, probe.PeriodSeconds, probe.SuccessThreshold, probe.FailureThreshold)
+	switch {
+	case probe.Exec != nil:
+		return fmt.Sprintf("exec %v %s", probe.Exec.Command, attrs)
+	case probe.HTTPGet != nil:
+		return fmt.Sprintf("http-get %s://%s:%s%s %s", strings.ToLower(string(probe.HTTPGet.Scheme)), probe.HTTPGet.Host, probe.HTTPGet.Port.String(), probe.HTTPGet.Path, attrs)
+	case probe.TCPSocket != nil:
+		return fmt.Sprintf("tcp-socket %s:%s %s", probe.TCPSocket.Host, probe.TCPSocket.Port.String(), attrs)
+	case probe.GRPC != nil:
+		return fmt.Sprintf("grpc <pod>:%d %s %s", probe.GRPC.Port, ptr.Deref(probe.GRPC.Service, ""), attrs)
+	default:
+		return fmt.Sprintf("unknown %s", attrs)
+	}
+}
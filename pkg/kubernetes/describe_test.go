@@ -0,0 +1,85 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestControllerOf(t *testing.T) {
+	t.Run("prefers controller reference", func(t *testing.T) {
+		owner := controllerOf([]metav1.OwnerReference{
+			{Kind: "ConfigMap", Name: "a-config"},
+			{Kind: "ReplicaSet", Name: "a-replicaset", Controller: ptr.To(true)},
+		})
+		if owner == nil || owner.Name != "a-replicaset" {
+			t.Errorf("expected a-replicaset controller, got %v", owner)
+		}
+	})
+	t.Run("falls back to first reference", func(t *testing.T) {
+		if owner := controllerOf([]metav1.OwnerReference{{Kind: "ConfigMap", Name: "a-config"}}); owner == nil || owner.Name != "a-config" {
+			t.Errorf("expected a-config owner, got %v", owner)
+		}
+	})
+	t.Run("no owners", func(t *testing.T) {
+		if owner := controllerOf(nil); owner != nil {
+			t.Errorf("expected no owner, got %v", owner)
+		}
+	})
+}
+
+func TestDescribeContainers(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init", Image: "busybox"}},
+			Containers: []v1.Container{{
+				Name:  "app",
+				Image: "nginx",
+				ReadinessProbe: &v1.Probe{
+					ProbeHandler:     v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8080), Scheme: v1.URISchemeHTTP}},
+					PeriodSeconds:    10,
+					FailureThreshold: 3,
+				},
+			}},
+		},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{{
+				Name:  "init",
+				State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Completed"}},
+			}},
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:                 "app",
+				RestartCount:         4,
+				State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+			}},
+		},
+	}
+	containers := describeContainers(pod)
+	if len(containers) != 2 {
+		t.Fatalf("expected 2 containers, got %d", len(containers))
+	}
+	t.Run("init container", func(t *testing.T) {
+		if !containers[0].Init || !strings.HasPrefix(containers[0].State, "Terminated: Completed (exit code 0") {
+			t.Errorf("unexpected init container %v", containers[0])
+		}
+	})
+	t.Run("container state", func(t *testing.T) {
+		if containers[1].State != "Waiting: CrashLoopBackOff" || containers[1].RestartCount != 4 {
+			t.Errorf("unexpected container %v", containers[1])
+		}
+		if !strings.HasPrefix(containers[1].LastState, "Terminated: OOMKilled (exit code 137") {
+			t.Errorf("unexpected last state %s", containers[1].LastState)
+		}
+	})
+	t.Run("container probes", func(t *testing.T) {
+		expected := "http-get http://:8080/healthz delay=0s timeout=0s period=10s #success=0 #failure=3"
+		if containers[1].Probes["readiness"] != expected {
+			t.Errorf("expected readiness probe %s, got %s", expected, containers[1].Probes["readiness"])
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	DiffOperationCreate    = "create"
+	DiffOperationUpdate    = "update"
+	DiffOperationUnchanged = "unchanged"
+)
+
+// ResourceDiff is the difference between the live state of an object and the result of applying its manifest
+type ResourceDiff struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	// Operation is the operation an apply would perform (create, update, or unchanged)
+	Operation string        `json:"operation,omitempty"`
+	Changes   []FieldChange `json:"changes,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	// Live is the live object, nil if the object doesn't exist
+	Live *unstructured.Unstructured `json:"-"`
+	// Merged is the object resulting from the (dry-run) apply
+	Merged *unstructured.Unstructured `json:"-"`
+}
+
+// FieldChange is a field that would be added, removed, or changed by an apply
+type FieldChange struct {
+	Path   string `json:"path"`
+	Live   any    `json:"live,omitempty"`
+	Merged any    `json:"merged,omitempty"`
+}
+
+// ResourcesDiff compares the live objects with the result of server-side applying the objects in the provided YAML or JSON
+// (multiple YAML documents are supported). The apply is performed with dry-run, so nothing is persisted.
+// The same as kubectl diff --server-side, the managedFields are ignored.
+func (k *Kubernetes) ResourcesDiff(ctx context.Context, resource string, options ApplyOptions) ([]ResourceDiff, error) {
+	resources, err := ParseResources(resource)
+	if err != nil {
+		return nil, err
+	}
+	diffs := make([]ResourceDiff, 0, len(resources))
+	for _, obj := range resources {
+		diff := ResourceDiff{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Name: obj.GetName(), Namespace: obj.GetNamespace()}
+		gvk := obj.GroupVersionKind()
+		diff.Live, err = k.ResourcesGet(ctx, &gvk, obj.GetNamespace(), obj.GetName())
+		if err != nil && !apierrors.IsNotFound(err) {
+			diff.Error = err.Error()
+			diffs = append(diffs, diff)
+			continue
+		}
+		if diff.Merged, err = k.resourcesApply(WithDryRun(ctx), obj.DeepCopy(), options); err != nil {
+			diff.Error = err.Error()
+			diffs = append(diffs, diff)
+			continue
+		}
+		diff.Namespace = diff.Merged.GetNamespace()
+		diff.Merged.SetManagedFields(nil)
+		if diff.Live == nil {
+			diff.Operation = DiffOperationCreate
+			diffs = append(diffs, diff)
+			continue
+		}
+		diff.Live.SetManagedFields(nil)
+		diff.Changes = fieldChanges("", diff.Live.Object, diff.Merged.Object)
+		diff.Operation = DiffOperationUpdate
+		if len(diff.Changes) == 0 {
+			diff.Operation = DiffOperationUnchanged
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// fieldChanges returns the fields that differ between the live and the merged values, nested maps are compared field by field
+func fieldChanges(path string, live, merged any) []FieldChange {
+	liveMap, liveIsMap := live.(map[string]any)
+	mergedMap, mergedIsMap := merged.(map[string]any)
+	if !liveIsMap || !mergedIsMap {
+		if reflect.DeepEqual(live, merged) {
+			return nil
+		}
+		return []FieldChange{{Path: path, Live: live, Merged: merged}}
+	}
+	keys := make([]string, 0, len(liveMap)+len(mergedMap))
+	for key := range liveMap {
+		keys = append(keys, key)
+	}
+	for key := range mergedMap {
+		if _, ok := liveMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	var changes []FieldChange
+	for _, key := range keys {
+		changes = append(changes, fieldChanges(fieldPath(path, key), liveMap[key], mergedMap[key])...)
+	}
+	return changes
+}
+
+// fieldPath appends the key to the path, keys containing dots or slashes are enclosed in brackets (e.g. metadata.labels[app.kubernetes.io/name])
+func fieldPath(path, key string) string {
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%s]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,39 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldChanges(t *testing.T) {
+	live := map[string]any{
+		"metadata": map[string]any{"name": "a", "labels": map[string]any{"app.kubernetes.io/name": "a"}},
+		"spec":     map[string]any{"replicas": int64(1), "paused": true, "template": map[string]any{"image": "nginx"}},
+	}
+	merged := map[string]any{
+		"metadata": map[string]any{"name": "a", "labels": map[string]any{"app.kubernetes.io/name": "b"}},
+		"spec":     map[string]any{"replicas": int64(2), "strategy": "Recreate", "template": map[string]any{"image": "nginx"}},
+	}
+	t.Run("returns the added, removed, and changed fields sorted by path", func(t *testing.T) {
+		expected := []FieldChange{
+			{Path: "metadata.labels[app.kubernetes.io/name]", Live: "a", Merged: "b"},
+			{Path: "spec.paused", Live: true},
+			{Path: "spec.replicas", Live: int64(1), Merged: int64(2)},
+			{Path: "spec.strategy", Merged: "Recreate"},
+		}
+		if changes := fieldChanges("", live, merged); !reflect.DeepEqual(changes, expected) {
+			t.Errorf("unexpected changes:\n%v\nexpected:\n%v", changes, expected)
+		}
+	})
+	t.Run("returns no changes for equal objects", func(t *testing.T) {
+		if changes := fieldChanges("", live, live); len(changes) != 0 {
+			t.Errorf("expected no changes, got %v", changes)
+		}
+	})
+	t.Run("compares lists as a whole", func(t *testing.T) {
+		changes := fieldChanges("", map[string]any{"items": []any{"a"}}, map[string]any{"items": []any{"a", "b"}})
+		if len(changes) != 1 || changes[0].Path != "items" {
+			t.Errorf("expected items change, got %v", changes)
+		}
+	})
+}
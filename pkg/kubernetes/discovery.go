@@ -0,0 +1,251 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// maxSchemaRefDepth limits the number of chained references followed when resolving an OpenAPI schema
+const maxSchemaRefDepth = 10
+
+// APIResource is a resource type served by the cluster, the same information as kubectl api-resources
+type APIResource struct {
+	Name       string   `json:"name"`
+	ShortNames []string `json:"shortNames,omitempty"`
+	APIVersion string   `json:"apiVersion"`
+	Namespaced bool     `json:"namespaced"`
+	Kind       string   `json:"kind"`
+	Verbs      []string `json:"verbs"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+type APIResourcesOptions struct {
+	// APIGroup only lists the resources of the provided API group, "core" for the legacy core group (v1)
+	APIGroup string
+	// Namespaced only lists the namespaced (true) or cluster scoped (false) resources
+	Namespaced *bool
+	// Verbs only lists the resources supporting all the provided verbs
+	Verbs []string
+}
+
+// APIResources lists the preferred version of the resources served by the cluster, excluding subresources and denied resources
+func (k *Kubernetes) APIResources(_ context.Context, options APIResourcesOptions) ([]APIResource, error) {
+	apiResourceLists, err := k.manager.discoveryClient.ServerPreferredResources()
+	// Partial discovery failures (e.g. an unavailable aggregated API) still return the rest of the resources
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, err
+	}
+	var ret []APIResource
+	for _, apiResourceList := range apiResourceLists {
+		gv, gvErr := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if gvErr != nil {
+			continue
+		}
+		if options.APIGroup != "" && gv.Group != options.APIGroup && !(options.APIGroup == "core" && gv.Group == "") {
+			continue
+		}
+		for _, apiResource := range apiResourceList.APIResources {
+			if strings.Contains(apiResource.Name, "/") {
+				continue
+			}
+			if options.Namespaced != nil && apiResource.Namespaced != *options.Namespaced {
+				continue
+			}
+			if !slices.ContainsFunc(options.Verbs, func(verb string) bool { return !slices.Contains(apiResource.Verbs, verb) }) &&
+				isAllowed(k.manager.staticConfig, &schema.GroupVersionKind{Group: gv.Group, Version: gv.Version, Kind: apiResource.Kind}) {
+				ret = append(ret, APIResource{
+					Name:       apiResource.Name,
+					ShortNames: apiResource.ShortNames,
+					APIVersion: gv.String(),
+					Namespaced: apiResource.Namespaced,
+					Kind:       apiResource.Kind,
+					Verbs:      apiResource.Verbs,
+					Categories: apiResource.Categories,
+				})
+			}
+		}
+	}
+	slices.SortFunc(ret, func(a, b APIResource) int {
+		return strings.Compare(a.APIVersion+"/"+a.Name, b.APIVersion+"/"+b.Name)
+	})
+	return ret, nil
+}
+
+// Explanation is the documentation of a resource kind or one of its fields, the same information as kubectl explain
+type Explanation struct {
+	APIVersion  string             `json:"apiVersion"`
+	Kind        string             `json:"kind"`
+	Field       string             `json:"field,omitempty"`
+	Type        string             `json:"type"`
+	Description string             `json:"description,omitempty"`
+	Enum        []any              `json:"enum,omitempty"`
+	Fields      []ExplanationField `json:"fields,omitempty"`
+}
+
+type ExplanationField struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ResourcesExplain returns the OpenAPI v3 documentation of the kind, or of the provided field path (e.g. spec.template.spec.containers)
+func (k *Kubernetes) ResourcesExplain(_ context.Context, gvk *schema.GroupVersionKind, fieldPath string) (*Explanation, error) {
+	if !isAllowed(k.manager.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	paths, err := k.manager.discoveryClient.OpenAPIV3().Paths()
+	if err != nil {
+		return nil, err
+	}
+	path := "apis/" + gvk.GroupVersion().String()
+	if gvk.Group == "" {
+		path = "api/" + gvk.Version
+	}
+	groupVersion, ok := paths[path]
+	if !ok {
+		return nil, fmt.Errorf("the server doesn't have a resource type %s in %s", gvk.Kind, gvk.GroupVersion().String())
+	}
+	data, err := groupVersion.Schema("application/json")
+	if err != nil {
+		return nil, err
+	}
+	var document map[string]any
+	if err = json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return explain(document, gvk, fieldPath)
+}
+
+// explain navigates the OpenAPI v3 document of the group version to the schema of the field path of the kind
+func explain(document map[string]any, gvk *schema.GroupVersionKind, fieldPath string) (*Explanation, error) {
+	schemas, _, _ := unstructured.NestedMap(document, "components", "schemas")
+	var current map[string]any
+	for _, s := range schemas {
+		if openAPISchema, ok := s.(map[string]any); ok && hasGroupVersionKind(openAPISchema, gvk) {
+			current = openAPISchema
+			break
+		}
+	}
+	if current == nil {
+		return nil, fmt.Errorf("the server doesn't have a resource type %s in %s", gvk.Kind, gvk.GroupVersion().String())
+	}
+	ret := &Explanation{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind, Field: fieldPath}
+	if fieldPath != "" {
+		for _, field := range strings.Split(fieldPath, ".") {
+			current = elementSchema(schemas, resolveSchema(schemas, current))
+			properties, _ := current["properties"].(map[string]any)
+			property, ok := properties[field].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("field %q does not exist in %s", fieldPath, gvk.Kind)
+			}
+			current = property
+		}
+	}
+	// The description of a referenced field is set next to the reference
+	ret.Description, _ = current["description"].(string)
+	ret.Type = schemaType(current)
+	current = resolveSchema(schemas, current)
+	ret.Enum, _ = current["enum"].([]any)
+	current = elementSchema(schemas, current)
+	if ret.Description == "" {
+		ret.Description, _ = current["description"].(string)
+	}
+	properties, _ := current["properties"].(map[string]any)
+	required, _ := current["required"].([]any)
+	for name, property := range properties {
+		propertySchema, _ := property.(map[string]any)
+		field := ExplanationField{Name: name, Type: schemaType(propertySchema), Required: slices.Contains(required, any(name))}
+		if field.Description, _ = propertySchema["description"].(string); field.Description == "" {
+			field.Description, _ = elementSchema(schemas, resolveSchema(schemas, propertySchema))["description"].(string)
+		}
+		ret.Fields = append(ret.Fields, field)
+	}
+	slices.SortFunc(ret.Fields, func(a, b ExplanationField) int { return strings.Compare(a.Name, b.Name) })
+	return ret, nil
+}
+
+func hasGroupVersionKind(openAPISchema map[string]any, gvk *schema.GroupVersionKind) bool {
+	gvks, _ := openAPISchema["x-kubernetes-group-version-kind"].([]any)
+	for _, g := range gvks {
+		if m, ok := g.(map[string]any); ok && m["group"] == gvk.Group && m["version"] == gvk.Version && m["kind"] == gvk.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveSchema follows the $ref of the schema, directly or wrapped in allOf (used by Kubernetes to add a description or default next to the reference)
+func resolveSchema(schemas map[string]any, openAPISchema map[string]any) map[string]any {
+	for range maxSchemaRefDepth {
+		ref := schemaRef(openAPISchema)
+		if ref == "" {
+			return openAPISchema
+		}
+		referenced, ok := schemas[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]any)
+		if !ok {
+			return openAPISchema
+		}
+		openAPISchema = referenced
+	}
+	return openAPISchema
+}
+
+// elementSchema returns the (resolved) schema of the elements of arrays and maps, or the schema itself for the rest of types
+func elementSchema(schemas map[string]any, openAPISchema map[string]any) map[string]any {
+	for range maxSchemaRefDepth {
+		items, isArray := openAPISchema["items"].(map[string]any)
+		if !isArray {
+			items, isArray = openAPISchema["additionalProperties"].(map[string]any)
+		}
+		if !isArray {
+			return openAPISchema
+		}
+		openAPISchema = resolveSchema(schemas, items)
+	}
+	return openAPISchema
+}
+
+// schemaType returns the type of the schema in the kubectl explain notation (e.g. string, []Container, map[string]string)
+func schemaType(openAPISchema map[string]any) string {
+	if ref := schemaRef(openAPISchema); ref != "" {
+		return ref[strings.LastIndex(ref, ".")+1:]
+	}
+	switch t, _ := openAPISchema["type"].(string); t {
+	case "array":
+		items, _ := openAPISchema["items"].(map[string]any)
+		return "[]" + schemaType(items)
+	case "object":
+		if additionalProperties, ok := openAPISchema["additionalProperties"].(map[string]any); ok {
+			return "map[string]" + schemaType(additionalProperties)
+		}
+		return "Object"
+	case "":
+		if openAPISchema["x-kubernetes-int-or-string"] == true {
+			return "IntOrString"
+		}
+		return "Object"
+	default:
+		return t
+	}
+}
+
+func schemaRef(openAPISchema map[string]any) string {
+	if ref, ok := openAPISchema["$ref"].(string); ok {
+		return ref
+	}
+	if allOf, ok := openAPISchema["allOf"].([]any); ok && len(allOf) == 1 {
+		if inner, isMap := allOf[0].(map[string]any); isMap {
+			ref, _ := inner["$ref"].(string)
+			return ref
+		}
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const explainDocument = `{
+  "components": {
+    "schemas": {
+      "io.k8s.api.apps.v1.Deployment": {
+        "description": "Deployment enables declarative updates for Pods and ReplicaSets.",
+        "type": "object",
+        "properties": {
+          "kind": {"description": "Kind is a string value representing the REST resource this object represents.", "type": "string"},
+          "spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}], "default": {}, "description": "Specification of the desired behavior of the Deployment."}
+        },
+        "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "Deployment", "version": "v1"}]
+      },
+      "io.k8s.api.apps.v1.DeploymentSpec": {
+        "description": "DeploymentSpec is the specification of the desired behavior of the Deployment.",
+        "type": "object",
+        "required": ["selector", "template"],
+        "properties": {
+          "replicas": {"description": "Number of desired pods.", "type": "integer", "format": "int32"},
+          "selector": {"allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelector"}]},
+          "template": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.core.v1.PodTemplateSpec"}], "description": "Template describes the pods that will be created."},
+          "strategy": {"type": "string", "enum": ["Recreate", "RollingUpdate"]}
+        }
+      },
+      "io.k8s.apimachinery.pkg.apis.meta.v1.LabelSelector": {
+        "description": "A label selector is a label query over a set of resources.",
+        "type": "object",
+        "properties": {
+          "matchLabels": {"type": "object", "additionalProperties": {"type": "string", "default": ""}}
+        }
+      },
+      "io.k8s.api.core.v1.PodTemplateSpec": {
+        "type": "object",
+        "properties": {
+          "containers": {"type": "array", "items": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.core.v1.Container"}], "default": {}}}
+        }
+      },
+      "io.k8s.api.core.v1.Container": {
+        "description": "A single application container that you want to run within a pod.",
+        "type": "object",
+        "required": ["name"],
+        "properties": {
+          "name": {"description": "Name of the container.", "type": "string"},
+          "port": {"x-kubernetes-int-or-string": true}
+        }
+      }
+    }
+  }
+}`
+
+func TestExplain(t *testing.T) {
+	var document map[string]any
+	if err := json.Unmarshal([]byte(explainDocument), &document); err != nil {
+		t.Fatalf("invalid document: %v", err)
+	}
+	deployment := &schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	t.Run("explains the kind", func(t *testing.T) {
+		explanation, err := explain(document, deployment, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if explanation.Type != "Object" || !strings.HasPrefix(explanation.Description, "Deployment enables") {
+			t.Errorf("unexpected explanation: %v", explanation)
+		}
+		if len(explanation.Fields) != 2 || explanation.Fields[0].Name != "kind" || explanation.Fields[1].Name != "spec" || explanation.Fields[1].Type != "DeploymentSpec" {
+			t.Errorf("unexpected fields: %v", explanation.Fields)
+		}
+	})
+	t.Run("explains a referenced field with its required fields", func(t *testing.T) {
+		explanation, err := explain(document, deployment, "spec")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if explanation.Type != "DeploymentSpec" || explanation.Description != "Specification of the desired behavior of the Deployment." {
+			t.Errorf("unexpected explanation: %v", explanation)
+		}
+		expected := map[string]ExplanationField{
+			"replicas": {Name: "replicas", Type: "integer", Description: "Number of desired pods."},
+			"selector": {Name: "selector", Type: "LabelSelector", Required: true, Description: "A label selector is a label query over a set of resources."},
+			"strategy": {Name: "strategy", Type: "string"},
+			"template": {Name: "template", Type: "PodTemplateSpec", Required: true, Description: "Template describes the pods that will be created."},
+		}
+		if len(explanation.Fields) != len(expected) {
+			t.Fatalf("unexpected fields: %v", explanation.Fields)
+		}
+		for _, field := range explanation.Fields {
+			if field != expected[field.Name] {
+				t.Errorf("unexpected field %s: %v", field.Name, field)
+			}
+		}
+	})
+	t.Run("explains nested fields through arrays", func(t *testing.T) {
+		explanation, err := explain(document, deployment, "spec.template.containers")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if explanation.Type != "[]Container" || explanation.Description != "A single application container that you want to run within a pod." {
+			t.Errorf("unexpected explanation: %v", explanation)
+		}
+		if len(explanation.Fields) != 2 || !explanation.Fields[0].Required || explanation.Fields[1].Type != "IntOrString" {
+			t.Errorf("unexpected fields: %v", explanation.Fields)
+		}
+	})
+	t.Run("explains maps and enums", func(t *testing.T) {
+		explanation, err := explain(document, deployment, "spec.selector.matchLabels")
+		if err != nil || explanation.Type != "map[string]string" {
+			t.Errorf("unexpected explanation: %v (%v)", explanation, err)
+		}
+		explanation, err = explain(document, deployment, "spec.strategy")
+		if err != nil || len(explanation.Enum) != 2 {
+			t.Errorf("unexpected explanation: %v (%v)", explanation, err)
+		}
+	})
+	t.Run("returns error for missing field", func(t *testing.T) {
+		if _, err := explain(document, deployment, "spec.missing"); err == nil || err.Error() != `field "spec.missing" does not exist in Deployment` {
+			t.Errorf("expected missing field error, got %v", err)
+		}
+	})
+	t.Run("returns error for missing kind", func(t *testing.T) {
+		if _, err := explain(document, &schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Missing"}, ""); err == nil {
+			t.Error("expected missing kind error")
+		}
+	})
+}
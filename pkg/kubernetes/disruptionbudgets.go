@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var podDisruptionBudgetGVK = &schema.GroupVersionKind{Group: "policy", Version: "v1", Kind: "PodDisruptionBudget"}
+
+// PodDisruptionBudget is the summary of a PodDisruptionBudget with the number of disruptions it allows
+type PodDisruptionBudget struct {
+	Namespace          string `json:"namespace"`
+	Name               string `json:"name"`
+	Selector           string `json:"selector"`
+	MinAvailable       string `json:"minAvailable,omitempty"`
+	MaxUnavailable     string `json:"maxUnavailable,omitempty"`
+	ExpectedPods       int32  `json:"expectedPods"`
+	CurrentHealthy     int32  `json:"currentHealthy"`
+	DesiredHealthy     int32  `json:"desiredHealthy"`
+	AllowedDisruptions int32  `json:"allowedDisruptions"`
+	// BlocksDrain is true if the budget allows no disruptions, the evictions of its pods are rejected and node drains can't complete
+	BlocksDrain bool `json:"blocksDrain"`
+	// Pods are the pods protected by the budget that would block a drain as namespace/name (node, readiness)
+	Pods []string `json:"pods,omitempty"`
+	// Issues explains why the budget allows no disruptions
+	Issues []string `json:"issues,omitempty"`
+}
+
+// PodDisruptionBudgetsList lists the PodDisruptionBudgets with their allowed disruptions, in all namespaces if no namespace is provided.
+// The budgets allowing no disruptions are flagged as blocking node drains and include the pods they protect.
+func (k *Kubernetes) PodDisruptionBudgetsList(ctx context.Context, namespace string) ([]PodDisruptionBudget, error) {
+	return k.podDisruptionBudgets(ctx, namespace, "", false)
+}
+
+// PodDisruptionBudgetsCheck returns the PodDisruptionBudgets that would block a node drain, with the pods involved.
+// If a node is provided, only the budgets protecting pods scheduled on the node are checked, and a budget blocks the drain
+// if it allows fewer disruptions than the number of its pods on the node.
+func (k *Kubernetes) PodDisruptionBudgetsCheck(ctx context.Context, namespace, node string) ([]PodDisruptionBudget, error) {
+	return k.podDisruptionBudgets(ctx, namespace, node, true)
+}
+
+func (k *Kubernetes) podDisruptionBudgets(ctx context.Context, namespace, node string, blockingOnly bool) ([]PodDisruptionBudget, error) {
+	list, err := k.ResourcesList(ctx, podDisruptionBudgetGVK, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]PodDisruptionBudget, 0)
+	if len(list.(*unstructured.UnstructuredList).Items) == 0 {
+		return ret, nil
+	}
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := pods.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		pdb := &policyv1.PodDisruptionBudget{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, pdb); err != nil {
+			return nil, err
+		}
+		budget := podDisruptionBudget(pdb, podList.Items, node)
+		if blockingOnly && !budget.BlocksDrain {
+			continue
+		}
+		ret = append(ret, budget)
+	}
+	return ret, nil
+}
+
+// podDisruptionBudget summarizes the budget, if a node is provided only the pods scheduled on the node are considered for the drain
+func podDisruptionBudget(pdb *policyv1.PodDisruptionBudget, pods []v1.Pod, node string) PodDisruptionBudget {
+	ret := PodDisruptionBudget{
+		Namespace:          pdb.Namespace,
+		Name:               pdb.Name,
+		Selector:           describeSelector(pdb.Spec.Selector, "all pods in the namespace"),
+		ExpectedPods:       pdb.Status.ExpectedPods,
+		CurrentHealthy:     pdb.Status.CurrentHealthy,
+		DesiredHealthy:     pdb.Status.DesiredHealthy,
+		AllowedDisruptions: pdb.Status.DisruptionsAllowed,
+	}
+	if pdb.Spec.MinAvailable != nil {
+		ret.MinAvailable = pdb.Spec.MinAvailable.String()
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		ret.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+	}
+	var protected []v1.Pod
+	for _, pod := range pods {
+		if pod.Namespace != pdb.Namespace || !selectorMatches(pdb.Spec.Selector, pod.Labels) {
+			continue
+		}
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed || (node != "" && pod.Spec.NodeName != node) {
+			continue
+		}
+		protected = append(protected, pod)
+	}
+	ret.BlocksDrain = len(protected) > 0 && int(ret.AllowedDisruptions) < len(protected)
+	if node == "" {
+		ret.BlocksDrain = ret.AllowedDisruptions == 0 && ret.ExpectedPods > 0
+	}
+	if !ret.BlocksDrain {
+		return ret
+	}
+	for _, pod := range protected {
+		readiness := "not ready"
+		if podReady(pod) {
+			readiness = "ready"
+		}
+		ret.Pods = append(ret.Pods, fmt.Sprintf("%s/%s (%s, %s)", pod.Namespace, pod.Name, pod.Spec.NodeName, readiness))
+	}
+	ret.Issues = podDisruptionBudgetIssues(&ret)
+	return ret
+}
+
+// podDisruptionBudgetIssues explains why the budget allows no disruptions
+func podDisruptionBudgetIssues(budget *PodDisruptionBudget) []string {
+	var issues []string
+	switch {
+	case budget.CurrentHealthy < budget.DesiredHealthy:
+		issues = append(issues, fmt.Sprintf("only %d of the %d desired healthy pods are healthy, fix the unhealthy pods before draining",
+			budget.CurrentHealthy, budget.DesiredHealthy))
+	case budget.MaxUnavailable == "0" || budget.MaxUnavailable == "0%":
+		issues = append(issues, "maxUnavailable is 0, the budget never allows voluntary disruptions")
+	case budget.DesiredHealthy >= budget.ExpectedPods:
+		issues = append(issues, fmt.Sprintf("minAvailable %s requires all the %d expected pods to be healthy, scale up the workload or lower minAvailable",
+			budget.MinAvailable, budget.ExpectedPods))
+	}
+	if budget.AllowedDisruptions > 0 {
+		issues = append(issues, fmt.Sprintf("the budget allows %d disruptions but more of its pods are scheduled on the node, the drain will be blocked until they are rescheduled",
+			budget.AllowedDisruptions))
+	}
+	return issues
+}
@@ -0,0 +1,68 @@
+package kubernetes
+
+import (
+	"slices"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodDisruptionBudget(t *testing.T) {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: ptr.To(intstr.FromInt32(2)),
+			Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{ExpectedPods: 2, CurrentHealthy: 2, DesiredHealthy: 2, DisruptionsAllowed: 0},
+	}
+	pod := func(name, node string) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{"app": "web"}},
+			Spec:       v1.PodSpec{NodeName: node},
+			Status:     v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}},
+		}
+	}
+	pods := []v1.Pod{pod("web-1", "node-1"), pod("web-2", "node-2"), {ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"}}}
+	t.Run("flags the budget allowing no disruptions with its pods", func(t *testing.T) {
+		budget := podDisruptionBudget(pdb, pods, "")
+		if !budget.BlocksDrain || budget.Selector != "app=web" || budget.MinAvailable != "2" {
+			t.Errorf("unexpected budget %v", budget)
+		}
+		if !slices.Equal(budget.Pods, []string{"default/web-1 (node-1, ready)", "default/web-2 (node-2, ready)"}) {
+			t.Errorf("unexpected pods %v", budget.Pods)
+		}
+		expected := []string{"minAvailable 2 requires all the 2 expected pods to be healthy, scale up the workload or lower minAvailable"}
+		if !slices.Equal(budget.Issues, expected) {
+			t.Errorf("unexpected issues %v", budget.Issues)
+		}
+	})
+	t.Run("only considers the pods on the node", func(t *testing.T) {
+		budget := podDisruptionBudget(pdb, pods, "node-2")
+		if !budget.BlocksDrain || !slices.Equal(budget.Pods, []string{"default/web-2 (node-2, ready)"}) {
+			t.Errorf("unexpected budget %v", budget)
+		}
+		if budget := podDisruptionBudget(pdb, pods, "node-3"); budget.BlocksDrain {
+			t.Errorf("unexpected budget blocking the drain of a node without its pods %v", budget)
+		}
+	})
+	t.Run("doesn't flag the budget allowing disruptions", func(t *testing.T) {
+		allowing := pdb.DeepCopy()
+		allowing.Status.DisruptionsAllowed = 1
+		if budget := podDisruptionBudget(allowing, pods, ""); budget.BlocksDrain || len(budget.Pods) > 0 {
+			t.Errorf("unexpected budget %v", budget)
+		}
+	})
+	t.Run("reports the unhealthy pods", func(t *testing.T) {
+		unhealthy := pdb.DeepCopy()
+		unhealthy.Status.CurrentHealthy = 1
+		expected := []string{"only 1 of the 2 desired healthy pods are healthy, fix the unhealthy pods before draining"}
+		if issues := podDisruptionBudget(unhealthy, pods, "").Issues; !slices.Equal(issues, expected) {
+			t.Errorf("unexpected issues %v", issues)
+		}
+	})
+}
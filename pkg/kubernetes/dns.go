@@ -0,0 +1,279 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// dnsNamePattern restricts the names and servers of the lookups, they are interpolated in the script of the probe pod
+var dnsNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_.:-]*[A-Za-z0-9.])?$`)
+
+// DNSCheckDefaultNames are the names looked up if none is provided, a cluster name and an external name
+var DNSCheckDefaultNames = []string{"kubernetes.default.svc", "kubernetes.io"}
+
+type DNSCheckOptions struct {
+	ProbeOptions
+	// Names to look up, DNSCheckDefaultNames if empty
+	Names []string
+	// Server is the DNS server to query instead of the nameserver of the probe pod (e.g. the IP of a CoreDNS pod)
+	Server string
+}
+
+// DNSCheck is the result of the DNS lookups of a probe pod along with the health of the cluster DNS
+type DNSCheck struct {
+	// ResolvConf is the /etc/resolv.conf of the probe pod (nameserver, search domains, and ndots)
+	ResolvConf string      `json:"resolvConf,omitempty"`
+	Lookups    []DNSLookup `json:"lookups"`
+	// DNS is the health of the cluster DNS (CoreDNS pods, Service, and Corefile), nil if it wasn't found
+	DNS *ClusterDNS `json:"dns,omitempty"`
+	// Issues are the problems found, with the suggested checks
+	Issues []string `json:"issues,omitempty"`
+}
+
+type DNSLookup struct {
+	Name string `json:"name"`
+	// Cluster is true for the names of the cluster domain (e.g. <service>.<namespace>.svc)
+	Cluster   bool     `json:"cluster"`
+	Resolved  bool     `json:"resolved"`
+	Addresses []string `json:"addresses,omitempty"`
+	// Output is the output of nslookup, only reported if the name didn't resolve
+	Output string `json:"output,omitempty"`
+}
+
+// ClusterDNS is the health of the cluster DNS, CoreDNS in kube-system or the OpenShift DNS operator pods in openshift-dns
+type ClusterDNS struct {
+	Namespace string              `json:"namespace"`
+	Service   *ServiceDescription `json:"service,omitempty"`
+	Pods      []DNSPod            `json:"pods"`
+	// Corefile is the configuration of CoreDNS, empty if the ConfigMap wasn't found
+	Corefile string `json:"corefile,omitempty"`
+}
+
+type DNSPod struct {
+	Name     string `json:"name"`
+	Node     string `json:"node,omitempty"`
+	Phase    string `json:"phase"`
+	Ready    bool   `json:"ready"`
+	Restarts int32  `json:"restarts"`
+}
+
+// clusterDNSLocation is where the cluster DNS is deployed by a distribution
+type clusterDNSLocation struct {
+	namespace, podSelector, service, configMap string
+}
+
+var clusterDNSLocations = []clusterDNSLocation{
+	{namespace: "kube-system", podSelector: "k8s-app=kube-dns", service: "kube-dns", configMap: "coredns"},
+	{namespace: "openshift-dns", podSelector: "dns.operator.openshift.io/daemonset-dns=default", service: "dns-default", configMap: "dns-default"},
+}
+
+// DNSCheck launches a short-lived probe pod that looks up the names with nslookup, and reports the resolution results
+// along with the health of the cluster DNS pods, Service, and Corefile
+func (k *Kubernetes) DNSCheck(ctx context.Context, options DNSCheckOptions) (*DNSCheck, error) {
+	names := options.Names
+	if len(names) == 0 {
+		names = DNSCheckDefaultNames
+	}
+	for _, name := range append(slices.Clone(names), options.Server) {
+		if name != "" && !dnsNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid DNS name %q", name)
+		}
+	}
+	logs, err := k.runProbe(ctx, "dns-check", dnsCheckScript(names, options.Server), options.ProbeOptions)
+	if err != nil {
+		return nil, err
+	}
+	ret := parseDNSCheck(logs)
+	if ret.DNS, err = k.clusterDNS(ctx); err != nil {
+		return nil, err
+	}
+	ret.Issues = dnsCheckIssues(ret)
+	return ret, nil
+}
+
+func dnsCheckScript(names []string, server string) string {
+	script := "echo '### resolv.conf'; cat /etc/resolv.conf; "
+	for _, name := range names {
+		script += fmt.Sprintf("echo '### lookup %s'; nslookup %s %s 2>&1; echo \"### exit $?\"; ", name, name, server)
+	}
+	return script
+}
+
+// parseDNSCheck parses the output of the probe pod, sections starting with ### markers
+func parseDNSCheck(logs string) *DNSCheck {
+	ret := &DNSCheck{}
+	var section string
+	var lookup *DNSLookup
+	var output []string
+	for _, line := range strings.Split(logs, "\n") {
+		switch {
+		case line == "### resolv.conf":
+			section = "resolv.conf"
+		case strings.HasPrefix(line, "### lookup "):
+			section = "lookup"
+			lookup = &DNSLookup{Name: strings.TrimPrefix(line, "### lookup ")}
+			lookup.Cluster = strings.Contains(lookup.Name+".", ".svc.") || strings.HasSuffix(lookup.Name, ".cluster.local")
+			output = nil
+		case strings.HasPrefix(line, "### exit ") && lookup != nil:
+			exitCode, _ := strconv.Atoi(strings.TrimPrefix(line, "### exit "))
+			lookup.Addresses = nslookupAddresses(output)
+			lookup.Resolved = exitCode == 0 && len(lookup.Addresses) > 0
+			if !lookup.Resolved {
+				lookup.Output = strings.TrimSpace(strings.Join(output, "\n"))
+			}
+			ret.Lookups = append(ret.Lookups, *lookup)
+			section, lookup = "", nil
+		case section == "resolv.conf" && strings.TrimSpace(line) != "":
+			ret.ResolvConf += line + "\n"
+		case section == "lookup":
+			output = append(output, line)
+		}
+	}
+	return ret
+}
+
+// nslookupAddresses returns the addresses of the answers of nslookup, the ones following the Name lines (the first Address is the server)
+func nslookupAddresses(output []string) []string {
+	var ret []string
+	answer := false
+	for _, line := range output {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Name:"):
+			answer = true
+		case answer && strings.HasPrefix(line, "Address"):
+			// Address: 10.96.0.1 (busybox), Address 1: 10.96.0.1 name (older busybox)
+			_, value, _ := strings.Cut(line, ":")
+			if fields := strings.Fields(value); len(fields) > 0 && !slices.Contains(ret, fields[0]) {
+				ret = append(ret, fields[0])
+			}
+		}
+	}
+	return ret
+}
+
+// clusterDNS returns the health of the cluster DNS pods, Service, and Corefile, nil if none of the known locations has DNS pods
+func (k *Kubernetes) clusterDNS(ctx context.Context) (*ClusterDNS, error) {
+	for _, location := range clusterDNSLocations {
+		pods, err := k.manager.accessControlClientSet.Pods(location.namespace)
+		if err != nil {
+			return nil, err
+		}
+		podList, err := pods.List(ctx, metav1.ListOptions{LabelSelector: location.podSelector})
+		if apierrors.IsForbidden(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if len(podList.Items) == 0 {
+			continue
+		}
+		ret := &ClusterDNS{Namespace: location.namespace}
+		for _, pod := range podList.Items {
+			ret.Pods = append(ret.Pods, dnsPod(pod))
+		}
+		if ret.Service, err = k.ServicesDescribe(ctx, location.namespace, location.service); err != nil && !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+			return nil, err
+		}
+		configMap, err := k.ResourcesGet(ctx, configMapGVK, location.namespace, location.configMap)
+		if err == nil {
+			ret.Corefile, _, _ = unstructured.NestedString(configMap.Object, "data", "Corefile")
+		} else if !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+			return nil, err
+		}
+		return ret, nil
+	}
+	return nil, nil
+}
+
+func dnsPod(pod v1.Pod) DNSPod {
+	ret := DNSPod{Name: pod.Name, Node: pod.Spec.NodeName, Phase: string(pod.Status.Phase), Ready: podReady(pod)}
+	for _, status := range pod.Status.ContainerStatuses {
+		ret.Restarts += status.RestartCount
+	}
+	return ret
+}
+
+// dnsCheckIssues correlates the failed lookups with the health of the cluster DNS
+func dnsCheckIssues(check *DNSCheck) []string {
+	var issues []string
+	if !strings.Contains(check.ResolvConf, "nameserver") {
+		issues = append(issues, "the /etc/resolv.conf of the probe pod has no nameserver, check the dnsPolicy and dnsConfig of the pods and the kubelet --cluster-dns")
+	}
+	var clusterFailed, externalFailed, clusterResolved, externalResolved int
+	for _, lookup := range check.Lookups {
+		switch {
+		case lookup.Cluster && lookup.Resolved:
+			clusterResolved++
+		case lookup.Cluster:
+			clusterFailed++
+		case lookup.Resolved:
+			externalResolved++
+		default:
+			externalFailed++
+		}
+	}
+	failed := clusterFailed + externalFailed
+	switch {
+	case failed > 0 && clusterResolved+externalResolved == 0:
+		issues = append(issues, "no name resolved, the probe pod can't reach the cluster DNS: check the DNS pods and Service below, "+
+			"and the NetworkPolicies restricting the egress to UDP and TCP port 53")
+	case externalFailed > 0 && clusterResolved > 0:
+		issues = append(issues, "cluster names resolve but external names don't, check the forward plugin of the Corefile and the reachability of the upstream resolvers from the nodes")
+	case clusterFailed > 0 && externalResolved > 0:
+		issues = append(issues, "external names resolve but some cluster names don't, check that the services exist and the kubernetes plugin and cluster domain of the Corefile")
+	}
+	if check.DNS == nil {
+		if failed > 0 {
+			issues = append(issues, "the cluster DNS pods were not found in kube-system (k8s-app=kube-dns) nor openshift-dns")
+		}
+		return issues
+	}
+	ready := 0
+	for _, pod := range check.DNS.Pods {
+		if pod.Ready {
+			ready++
+		}
+		if pod.Restarts > 0 {
+			issues = append(issues, fmt.Sprintf("DNS pod %s restarted %d times, check its logs (e.g. loop detected, OOMKilled)", pod.Name, pod.Restarts))
+		}
+	}
+	if ready == 0 {
+		issues = append(issues, fmt.Sprintf("none of the %d DNS pods in %s is ready", len(check.DNS.Pods), check.DNS.Namespace))
+	}
+	if check.DNS.Service != nil {
+		for _, issue := range check.DNS.Service.Issues {
+			issues = append(issues, fmt.Sprintf("DNS service %s: %s", check.DNS.Service.Name, issue))
+		}
+	}
+	if check.DNS.Corefile != "" {
+		for _, plugin := range []struct{ name, issue string }{
+			{"kubernetes", "the Corefile has no kubernetes plugin, the cluster names don't resolve"},
+			{"forward", "the Corefile has no forward plugin, the external names don't resolve"},
+		} {
+			if !corefileHasPlugin(check.DNS.Corefile, plugin.name) {
+				issues = append(issues, plugin.issue)
+			}
+		}
+	}
+	return issues
+}
+
+// corefileHasPlugin returns whether a line of the Corefile starts with the plugin directive
+func corefileHasPlugin(corefile, plugin string) bool {
+	for _, line := range strings.Split(corefile, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == plugin {
+			return true
+		}
+	}
+	return false
+}
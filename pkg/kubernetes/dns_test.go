@@ -0,0 +1,90 @@
+package kubernetes
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+const dnsCheckLogs = `### resolv.conf
+search default.svc.cluster.local svc.cluster.local cluster.local
+nameserver 10.96.0.10
+options ndots:5
+### lookup kubernetes.default.svc
+Server:		10.96.0.10
+Address:	10.96.0.10:53
+
+Name:	kubernetes.default.svc.cluster.local
+Address: 10.96.0.1
+
+### exit 0
+### lookup kubernetes.io
+Server:		10.96.0.10
+Address:	10.96.0.10:53
+
+;; connection timed out; no servers could be reached
+
+### exit 1
+`
+
+func TestParseDNSCheck(t *testing.T) {
+	check := parseDNSCheck(dnsCheckLogs)
+	t.Run("reports the resolv.conf of the probe pod", func(t *testing.T) {
+		if !strings.Contains(check.ResolvConf, "nameserver 10.96.0.10") || !strings.Contains(check.ResolvConf, "options ndots:5") {
+			t.Errorf("unexpected resolv.conf %q", check.ResolvConf)
+		}
+	})
+	t.Run("reports the resolved addresses without the server", func(t *testing.T) {
+		lookup := check.Lookups[0]
+		if !lookup.Cluster || !lookup.Resolved || !slices.Equal(lookup.Addresses, []string{"10.96.0.1"}) || lookup.Output != "" {
+			t.Errorf("unexpected lookup %v", lookup)
+		}
+	})
+	t.Run("reports the output of the failed lookups", func(t *testing.T) {
+		lookup := check.Lookups[1]
+		if lookup.Cluster || lookup.Resolved || len(lookup.Addresses) > 0 || !strings.Contains(lookup.Output, "connection timed out") {
+			t.Errorf("unexpected lookup %v", lookup)
+		}
+	})
+}
+
+func TestDNSCheckIssues(t *testing.T) {
+	t.Run("external names not resolved with a Corefile without forward plugin", func(t *testing.T) {
+		check := parseDNSCheck(dnsCheckLogs)
+		check.DNS = &ClusterDNS{
+			Namespace: "kube-system",
+			Pods:      []DNSPod{{Name: "coredns-1", Ready: true}, {Name: "coredns-2", Ready: true, Restarts: 3}},
+			Corefile:  ".:53 {\n    errors\n    kubernetes cluster.local in-addr.arpa ip6.arpa\n    cache 30\n}\n",
+		}
+		issues := dnsCheckIssues(check)
+		if len(issues) != 3 ||
+			!strings.HasPrefix(issues[0], "cluster names resolve but external names don't") ||
+			!strings.HasPrefix(issues[1], "DNS pod coredns-2 restarted 3 times") ||
+			issues[2] != "the Corefile has no forward plugin, the external names don't resolve" {
+			t.Errorf("unexpected issues %v", issues)
+		}
+	})
+	t.Run("no name resolved with DNS pods not ready", func(t *testing.T) {
+		check := &DNSCheck{
+			ResolvConf: "nameserver 10.96.0.10\n",
+			Lookups:    []DNSLookup{{Name: "kubernetes.default.svc", Cluster: true}},
+			DNS:        &ClusterDNS{Namespace: "kube-system", Pods: []DNSPod{{Name: "coredns-1"}}},
+		}
+		issues := dnsCheckIssues(check)
+		if len(issues) != 2 || !strings.HasPrefix(issues[0], "no name resolved") || issues[1] != "none of the 1 DNS pods in kube-system is ready" {
+			t.Errorf("unexpected issues %v", issues)
+		}
+	})
+}
+
+func TestDNSCheckScript(t *testing.T) {
+	script := dnsCheckScript([]string{"kubernetes.io"}, "10.244.0.5")
+	if !strings.Contains(script, "nslookup kubernetes.io 10.244.0.5 2>&1") {
+		t.Errorf("unexpected script %q", script)
+	}
+	for _, name := range []string{"kubernetes.io; rm -rf /", "$(id)", "a b"} {
+		if dnsNamePattern.MatchString(name) {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+const (
+	// drainDefaultTimeout is the time to keep retrying the evictions blocked by PodDisruptionBudgets
+	drainDefaultTimeout = 5 * time.Minute
+	// drainRetryInterval is the time to wait before retrying the evictions blocked by PodDisruptionBudgets
+	drainRetryInterval  = 5 * time.Second
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+)
+
+type NodesDrainOptions struct {
+	// GracePeriodSeconds overrides the termination grace period of the evicted pods, the one of each pod is used if nil
+	GracePeriodSeconds *int64
+	// Force evicts the pods not managed by a controller, which won't be recreated in another node
+	Force bool
+	// DeleteEmptyDirData evicts the pods using emptyDir volumes, whose data is lost
+	DeleteEmptyDirData bool
+	// Timeout is the time to keep retrying the evictions blocked by PodDisruptionBudgets, 5 minutes if not provided
+	Timeout time.Duration
+	// OnEvicted is called after each pod eviction with the number of pods processed so far and the number of pods to evict
+	OnEvicted func(pod DrainPod, processed, total int)
+}
+
+// NodeDrain is the result of draining a node, the same as kubectl drain --ignore-daemonsets
+type NodeDrain struct {
+	Node string `json:"node"`
+	// Cordoned is true if the node was schedulable and has been cordoned by the drain
+	Cordoned bool       `json:"cordoned"`
+	Evicted  []DrainPod `json:"evicted,omitempty"`
+	// Skipped are the DaemonSet and mirror (static) pods, which are not evicted
+	Skipped []DrainPod `json:"skipped,omitempty"`
+	// Failed are the pods that could not be evicted (e.g. blocked by a PodDisruptionBudget until the timeout)
+	Failed []DrainPod `json:"failed,omitempty"`
+}
+
+type DrainPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// NodesDrain cordons the node and evicts its pods through the Eviction API, so that PodDisruptionBudgets are honored.
+// DaemonSet and mirror pods are skipped. Unmanaged pods and pods with emptyDir volumes block the drain unless forced,
+// in that case nothing is cordoned nor evicted. The evictions blocked by PodDisruptionBudgets are retried until the timeout.
+// The termination of the evicted pods is not awaited.
+func (k *Kubernetes) NodesDrain(ctx context.Context, name string, options NodesDrainOptions) (*NodeDrain, error) {
+	pods, err := k.manager.accessControlClientSet.Pods("")
+	if err != nil {
+		return nil, err
+	}
+	podList, err := pods.List(ctx, metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String()})
+	if err != nil {
+		return nil, err
+	}
+	ret := &NodeDrain{Node: name}
+	toEvict, err := drainFilter(podList.Items, options, ret)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Cordoned, err = k.NodesCordon(ctx, name, true); err != nil {
+		return nil, err
+	}
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = drainDefaultTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	processed, total := 0, len(toEvict)
+	for len(toEvict) > 0 {
+		var blocked []v1.Pod
+		for _, pod := range toEvict {
+			drainPod := DrainPod{Namespace: pod.Namespace, Name: pod.Name}
+			evictErr := k.evict(ctx, &pod, options.GracePeriodSeconds)
+			switch {
+			case apierrors.IsTooManyRequests(evictErr) && time.Now().Before(deadline):
+				blocked = append(blocked, pod)
+				continue
+			case evictErr == nil, apierrors.IsNotFound(evictErr):
+				ret.Evicted = append(ret.Evicted, drainPod)
+			default:
+				drainPod.Reason = evictErr.Error()
+				ret.Failed = append(ret.Failed, drainPod)
+			}
+			processed++
+			if options.OnEvicted != nil {
+				options.OnEvicted(drainPod, processed, total)
+			}
+		}
+		toEvict = blocked
+		if len(toEvict) == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			for _, pod := range toEvict {
+				ret.Failed = append(ret.Failed, DrainPod{Namespace: pod.Namespace, Name: pod.Name, Reason: ctx.Err().Error()})
+			}
+			return ret, nil
+		case <-time.After(drainRetryInterval):
+		}
+	}
+	return ret, nil
+}
+
+func (k *Kubernetes) evict(ctx context.Context, pod *v1.Pod, gracePeriodSeconds *int64) error {
+	pods, err := k.manager.accessControlClientSet.Pods(pod.Namespace)
+	if err != nil {
+		return err
+	}
+	return pods.EvictV1(ctx, &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name},
+		DeleteOptions: &metav1.DeleteOptions{
+			GracePeriodSeconds: gracePeriodSeconds,
+			DryRun:             dryRun(ctx),
+		},
+	})
+}
+
+// drainFilter returns the pods to evict and records the skipped pods in the drain,
+// fails with the pods blocking the drain (unmanaged or with emptyDir volumes) unless the options allow them
+func drainFilter(pods []v1.Pod, options NodesDrainOptions, drain *NodeDrain) ([]v1.Pod, error) {
+	var toEvict []v1.Pod
+	var blocking []string
+	for _, pod := range pods {
+		drainPod := DrainPod{Namespace: pod.Namespace, Name: pod.Name}
+		controller := metav1.GetControllerOf(&pod)
+		finished := pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed
+		switch {
+		case pod.Annotations[mirrorPodAnnotation] != "":
+			drainPod.Reason = "mirror pod"
+			drain.Skipped = append(drain.Skipped, drainPod)
+			continue
+		case controller != nil && controller.Kind == "DaemonSet":
+			drainPod.Reason = "managed by DaemonSet " + controller.Name
+			drain.Skipped = append(drain.Skipped, drainPod)
+			continue
+		case controller == nil && !finished && !options.Force:
+			blocking = append(blocking, fmt.Sprintf("%s/%s (not managed by a controller, enable force to evict it)", pod.Namespace, pod.Name))
+		case hasEmptyDir(&pod) && !finished && !options.DeleteEmptyDirData:
+			blocking = append(blocking, fmt.Sprintf("%s/%s (uses emptyDir volumes, enable deleteEmptyDirData to evict it)", pod.Namespace, pod.Name))
+		}
+		toEvict = append(toEvict, pod)
+	}
+	if len(blocking) > 0 {
+		return nil, errors.New("cannot drain the node, the following pods block the drain: " + strings.Join(blocking, ", "))
+	}
+	return toEvict, nil
+}
+
+func hasEmptyDir(pod *v1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
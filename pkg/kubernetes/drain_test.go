@@ -0,0 +1,60 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func drainPodFixture(name, controllerKind string, volumes ...v1.Volume) v1.Pod {
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       v1.PodSpec{Volumes: volumes},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	if controllerKind != "" {
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: controllerKind, Name: "owner", Controller: ptr.To(true)}}
+	}
+	return pod
+}
+
+func TestDrainFilter(t *testing.T) {
+	emptyDir := v1.Volume{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}
+	mirror := drainPodFixture("static", "Node")
+	mirror.Annotations = map[string]string{mirrorPodAnnotation: "hash"}
+	finished := drainPodFixture("finished", "")
+	finished.Status.Phase = v1.PodSucceeded
+	t.Run("skips DaemonSet and mirror pods", func(t *testing.T) {
+		drain := &NodeDrain{}
+		toEvict, err := drainFilter([]v1.Pod{drainPodFixture("managed", "ReplicaSet"), drainPodFixture("agent", "DaemonSet"), mirror, finished}, NodesDrainOptions{}, drain)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if len(toEvict) != 2 || toEvict[0].Name != "managed" || toEvict[1].Name != "finished" {
+			t.Errorf("unexpected pods to evict %v", toEvict)
+		}
+		if len(drain.Skipped) != 2 || drain.Skipped[0].Reason != "managed by DaemonSet owner" || drain.Skipped[1].Reason != "mirror pod" {
+			t.Errorf("unexpected skipped pods %v", drain.Skipped)
+		}
+	})
+	t.Run("unmanaged and emptyDir pods block the drain", func(t *testing.T) {
+		_, err := drainFilter([]v1.Pod{drainPodFixture("unmanaged", ""), drainPodFixture("cache", "ReplicaSet", emptyDir)}, NodesDrainOptions{}, &NodeDrain{})
+		if err == nil {
+			t.Fatalf("expected error")
+		}
+		if !strings.Contains(err.Error(), "default/unmanaged (not managed by a controller, enable force to evict it)") ||
+			!strings.Contains(err.Error(), "default/cache (uses emptyDir volumes, enable deleteEmptyDirData to evict it)") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+	t.Run("unmanaged and emptyDir pods are evicted when allowed", func(t *testing.T) {
+		toEvict, err := drainFilter([]v1.Pod{drainPodFixture("unmanaged", ""), drainPodFixture("cache", "ReplicaSet", emptyDir)},
+			NodesDrainOptions{Force: true, DeleteEmptyDirData: true}, &NodeDrain{})
+		if err != nil || len(toEvict) != 2 {
+			t.Errorf("expected both pods to be evicted, got %v %v", toEvict, err)
+		}
+	})
+}
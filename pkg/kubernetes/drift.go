@@ -0,0 +1,342 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	DriftInSync  = "in-sync"
+	DriftDrifted = "drifted"
+	DriftMissing = "missing"
+)
+
+// driftManualFieldsMax is the maximum number of fields reported for each manual change
+const driftManualFieldsMax = 20
+
+// driftIgnoredAnnotations are the annotations populated by the clients and the controllers, never part of the desired state
+var driftIgnoredAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+}
+
+// DriftCheckOptions are the sources of the desired manifests, exactly one of Manifests, ConfigMapName, or URL must be provided
+type DriftCheckOptions struct {
+	// Manifests is a YAML or JSON containing the desired objects
+	Manifests string
+	// ConfigMapNamespace and ConfigMapName identify the ConfigMap storing the desired manifests,
+	// the YAML and JSON keys are read (or only ConfigMapKey if provided)
+	ConfigMapNamespace string
+	ConfigMapName      string
+	ConfigMapKey       string
+	// URL is the HTTPS URL of the desired manifests (e.g. the raw URL of a file of a Git repository at a given commit)
+	URL string
+}
+
+// ResourceDrift is the difference between the desired and the live state of an object
+type ResourceDrift struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	// Status is in-sync, drifted (a desired field differs or fields were changed manually), or missing
+	Status string `json:"status,omitempty"`
+	// Differences are the desired fields whose live value differs
+	Differences []FieldDrift `json:"differences,omitempty"`
+	// ManualChanges are the fields changed by imperative commands (kubectl edit, patch, label, scale...)
+	ManualChanges []ManualChange `json:"manualChanges,omitempty"`
+	Error         string         `json:"error,omitempty"`
+}
+
+// FieldDrift is a desired field whose live value differs, a nil live value means the field is missing from the live object
+type FieldDrift struct {
+	Path    string `json:"path"`
+	Desired any    `json:"desired"`
+	Live    any    `json:"live"`
+}
+
+// ManualChange are the fields owned by a field manager of an imperative command
+type ManualChange struct {
+	Manager string     `json:"manager"`
+	Time    *time.Time `json:"time,omitempty"`
+	Fields  []string   `json:"fields,omitempty"`
+}
+
+// DriftCheck compares the desired objects with their live state. Only the fields of the desired objects are compared, so the defaults and
+// the fields populated by the server (status, uid, resourceVersion, managedFields...) are ignored.
+// The fields owned by the field managers of imperative commands (e.g. kubectl-edit) are reported as manual changes.
+func (k *Kubernetes) DriftCheck(ctx context.Context, options DriftCheckOptions) ([]ResourceDrift, error) {
+	manifests, err := k.driftManifests(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := ParseResources(manifests)
+	if err != nil {
+		return nil, err
+	}
+	if len(desired) == 0 {
+		return nil, errors.New("no object found in the desired manifests")
+	}
+	drifts := make([]ResourceDrift, 0, len(desired))
+	for _, obj := range desired {
+		drift := ResourceDrift{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Name: obj.GetName(), Namespace: obj.GetNamespace()}
+		gvk := obj.GroupVersionKind()
+		live, err := k.ResourcesGet(ctx, &gvk, obj.GetNamespace(), obj.GetName())
+		switch {
+		case apierrors.IsNotFound(err):
+			drift.Status = DriftMissing
+		case err != nil:
+			drift.Error = err.Error()
+		default:
+			drift.Namespace = live.GetNamespace()
+			resourceDrift(&drift, obj, live)
+		}
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// driftManifests returns the desired manifests of the source
+func (k *Kubernetes) driftManifests(ctx context.Context, options DriftCheckOptions) (string, error) {
+	sources := 0
+	for _, source := range []string{options.Manifests, options.ConfigMapName, options.URL} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return "", errors.New("exactly one of the manifests, the ConfigMap, or the URL must be provided")
+	}
+	switch {
+	case options.URL != "":
+		manifests, _, err := fetchManifests(ctx, options.URL)
+		return manifests, err
+	case options.ConfigMapName != "":
+		files, err := k.kustomizeConfigMapFiles(ctx, options.ConfigMapNamespace, options.ConfigMapName)
+		if err != nil {
+			return "", err
+		}
+		return configMapManifests(files, options.ConfigMapKey)
+	}
+	return options.Manifests, nil
+}
+
+// configMapManifests joins the YAML and JSON files of the ConfigMap (sorted by key), or returns the file of the key if provided
+func configMapManifests(files map[string]string, key string) (string, error) {
+	if key != "" {
+		manifests, ok := files[key]
+		if !ok {
+			return "", fmt.Errorf("key %s not found in the ConfigMap", key)
+		}
+		return manifests, nil
+	}
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		if slices.Contains([]string{".yaml", ".yml", ".json"}, strings.ToLower(path.Ext(key))) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return "", errors.New("no YAML or JSON key found in the ConfigMap")
+	}
+	sort.Strings(keys)
+	documents := make([]string, 0, len(keys))
+	for _, key := range keys {
+		documents = append(documents, strings.TrimSpace(files[key]))
+	}
+	return strings.Join(documents, "\n---\n"), nil
+}
+
+// resourceDrift compares the desired fields with the live object, only the labels and annotations of the metadata are compared
+func resourceDrift(drift *ResourceDrift, desired, live *unstructured.Unstructured) {
+	desired = desired.DeepCopy()
+	for _, annotation := range driftIgnoredAnnotations {
+		unstructured.RemoveNestedField(desired.Object, "metadata", "annotations", annotation)
+	}
+	if annotations, ok, _ := unstructured.NestedMap(desired.Object, "metadata", "annotations"); ok && len(annotations) == 0 {
+		unstructured.RemoveNestedField(desired.Object, "metadata", "annotations")
+	}
+	for key, value := range desired.Object {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			metadata, _ := value.(map[string]any)
+			for _, field := range []string{"labels", "annotations"} {
+				if desiredField, ok := metadata[field]; ok {
+					liveField, _, _ := unstructured.NestedFieldNoCopy(live.Object, "metadata", field)
+					drift.Differences = append(drift.Differences, fieldDrifts(fieldPath("metadata", field), desiredField, liveField)...)
+				}
+			}
+		default:
+			drift.Differences = append(drift.Differences, fieldDrifts(key, value, live.Object[key])...)
+		}
+	}
+	slices.SortFunc(drift.Differences, func(a, b FieldDrift) int { return strings.Compare(a.Path, b.Path) })
+	drift.ManualChanges = manualChanges(live.GetManagedFields())
+	drift.Status = DriftInSync
+	if len(drift.Differences) > 0 || len(drift.ManualChanges) > 0 {
+		drift.Status = DriftDrifted
+	}
+}
+
+// fieldDrifts returns the desired fields whose live value differs. The fields of the live value missing from the desired value are ignored,
+// the items of the lists of objects are matched by name (e.g. containers, ports) or by index if they have no name
+func fieldDrifts(path string, desired, live any) []FieldDrift {
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		liveMap, ok := live.(map[string]any)
+		if !ok {
+			return []FieldDrift{{Path: path, Desired: desired, Live: live}}
+		}
+		var drifts []FieldDrift
+		for key, value := range desiredValue {
+			drifts = append(drifts, fieldDrifts(fieldPath(path, key), value, liveMap[key])...)
+		}
+		return drifts
+	case []any:
+		liveList, ok := live.([]any)
+		if !ok {
+			return []FieldDrift{{Path: path, Desired: desired, Live: live}}
+		}
+		if names, ok := listItemNames(desiredValue); ok {
+			liveNames, _ := listItemNames(liveList)
+			var drifts []FieldDrift
+			for i, name := range names {
+				var liveItem any
+				if j := slices.Index(liveNames, name); j >= 0 {
+					liveItem = liveList[j]
+				}
+				drifts = append(drifts, fieldDrifts(fmt.Sprintf("%s[name=%s]", path, name), desiredValue[i], liveItem)...)
+			}
+			return drifts
+		}
+		if len(desiredValue) != len(liveList) {
+			return []FieldDrift{{Path: path, Desired: desired, Live: live}}
+		}
+		var drifts []FieldDrift
+		for i := range desiredValue {
+			drifts = append(drifts, fieldDrifts(fmt.Sprintf("%s[%d]", path, i), desiredValue[i], liveList[i])...)
+		}
+		return drifts
+	}
+	if scalarEqual(desired, live) {
+		return nil
+	}
+	return []FieldDrift{{Path: path, Desired: desired, Live: live}}
+}
+
+// listItemNames returns the names of the items of a list of named objects
+func listItemNames(list []any) ([]string, bool) {
+	names := make([]string, 0, len(list))
+	for _, item := range list {
+		object, _ := item.(map[string]any)
+		name, ok := object["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+	return names, len(names) > 0
+}
+
+// scalarEqual compares the scalar values, the numbers are compared by value and the quantities in their canonical form (e.g. 1000m and 1)
+func scalarEqual(desired, live any) bool {
+	if reflect.DeepEqual(desired, live) || (desired != nil && live != nil && fmt.Sprint(desired) == fmt.Sprint(live)) {
+		return true
+	}
+	desiredQuantity, err := resource.ParseQuantity(fmt.Sprint(desired))
+	if err != nil || live == nil {
+		return false
+	}
+	liveQuantity, err := resource.ParseQuantity(fmt.Sprint(live))
+	return err == nil && desiredQuantity.Cmp(liveQuantity) == 0
+}
+
+// manualChanges returns the fields owned by the field managers of the imperative kubectl commands and of the web consoles,
+// kubectl apply (client-side and server-side) is declarative and isn't reported
+func manualChanges(managedFields []metav1.ManagedFieldsEntry) []ManualChange {
+	var ret []ManualChange
+	for _, entry := range managedFields {
+		if entry.Operation != metav1.ManagedFieldsOperationUpdate || entry.Subresource == "status" || !manualFieldManager(entry.Manager) {
+			continue
+		}
+		change := ManualChange{Manager: entry.Manager}
+		if entry.Time != nil {
+			change.Time = &entry.Time.Time
+		}
+		if entry.FieldsV1 != nil {
+			fields := map[string]any{}
+			if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err == nil {
+				change.Fields = managedFieldPaths("", fields)
+				sort.Strings(change.Fields)
+			}
+		}
+		if len(change.Fields) > driftManualFieldsMax {
+			change.Fields = append(change.Fields[:driftManualFieldsMax], fmt.Sprintf("... %d more", len(change.Fields)-driftManualFieldsMax))
+		}
+		ret = append(ret, change)
+	}
+	return ret
+}
+
+func manualFieldManager(manager string) bool {
+	if manager == "kubectl-client-side-apply" {
+		return false
+	}
+	return strings.HasPrefix(manager, "kubectl-") || strings.HasPrefix(manager, "Mozilla")
+}
+
+// managedFieldPaths returns the paths of the leaf fields of the FieldsV1 set (f:<field>, k:<keys>, v:<value>, and i:<index> entries)
+func managedFieldPaths(prefix string, fields map[string]any) []string {
+	var paths []string
+	for key, value := range fields {
+		var p string
+		switch {
+		case key == ".":
+			continue
+		case strings.HasPrefix(key, "f:"):
+			p = fieldPath(prefix, key[2:])
+		case strings.HasPrefix(key, "k:"):
+			p = prefix + "[" + managedFieldKey(key[2:]) + "]"
+		case strings.HasPrefix(key, "v:"), strings.HasPrefix(key, "i:"):
+			p = prefix + "[" + key[2:] + "]"
+		default:
+			continue
+		}
+		children, _ := value.(map[string]any)
+		if leaf := len(children) == 0 || (len(children) == 1 && children["."] != nil); leaf {
+			paths = append(paths, p)
+			continue
+		}
+		paths = append(paths, managedFieldPaths(p, children)...)
+	}
+	return paths
+}
+
+// managedFieldKey formats the keys of a list item (e.g. {"name":"app"} as name=app)
+func managedFieldKey(raw string) string {
+	keys := map[string]any{}
+	if err := json.Unmarshal([]byte(raw), &keys); err != nil {
+		return raw
+	}
+	parts := make([]string, 0, len(keys))
+	for key, value := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", key, value))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
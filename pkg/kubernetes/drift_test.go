@@ -0,0 +1,179 @@
+package kubernetes
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceDrift(t *testing.T) {
+	desired, err := ParseResources(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    app: app
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: "{}"
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:1.0
+        resources:
+          limits:
+            cpu: 1
+            memory: 512Mi
+      - name: sidecar
+        image: sidecar:1.0
+status:
+  replicas: 2
+`)
+	if err != nil {
+		t.Fatalf("failed to parse the desired manifests: %v", err)
+	}
+	live, err := ParseResources(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: default
+  uid: 8b7f6c1e
+  resourceVersion: "42"
+  labels:
+    app: app
+    extra: label
+spec:
+  replicas: 5
+  revisionHistoryLimit: 10
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:1.0
+        imagePullPolicy: IfNotPresent
+        resources:
+          limits:
+            cpu: 1000m
+            memory: 512Mi
+status:
+  replicas: 5
+`)
+	if err != nil {
+		t.Fatalf("failed to parse the live object: %v", err)
+	}
+	t.Run("reports the differences of the desired fields", func(t *testing.T) {
+		drift := &ResourceDrift{}
+		resourceDrift(drift, desired[0], live[0])
+		expected := []FieldDrift{
+			{Path: "spec.replicas", Desired: int64(2), Live: int64(5)},
+			{Path: "spec.template.spec.containers[name=sidecar]", Desired: map[string]any{"name": "sidecar", "image": "sidecar:1.0"}},
+		}
+		if drift.Status != DriftDrifted || len(drift.Differences) != len(expected) {
+			t.Fatalf("unexpected drift %v", drift)
+		}
+		for i := range expected {
+			if drift.Differences[i].Path != expected[i].Path || !scalarEqual(drift.Differences[i].Live, expected[i].Live) {
+				t.Errorf("unexpected difference %v, expected %v", drift.Differences[i], expected[i])
+			}
+		}
+	})
+	t.Run("in sync when only the server populated fields differ", func(t *testing.T) {
+		desired[0].Object["spec"].(map[string]any)["replicas"] = int64(5)
+		podSpec := desired[0].Object["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)
+		podSpec["containers"] = podSpec["containers"].([]any)[:1]
+		drift := &ResourceDrift{}
+		resourceDrift(drift, desired[0], live[0])
+		if drift.Status != DriftInSync || len(drift.Differences) != 0 {
+			t.Errorf("unexpected drift %v", drift)
+		}
+	})
+	t.Run("drifted when fields were changed manually", func(t *testing.T) {
+		live[0].SetManagedFields([]metav1.ManagedFieldsEntry{
+			{Manager: "kubectl-client-side-apply", Operation: metav1.ManagedFieldsOperationUpdate},
+			{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status"},
+			{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, Time: &metav1.Time{Time: time.Now()},
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:extra":{}}}}`)}},
+		})
+		drift := &ResourceDrift{}
+		resourceDrift(drift, desired[0], live[0])
+		if drift.Status != DriftDrifted || len(drift.ManualChanges) != 1 || drift.ManualChanges[0].Manager != "kubectl-edit" ||
+			!slices.Equal(drift.ManualChanges[0].Fields, []string{"metadata.labels.extra"}) {
+			t.Errorf("unexpected drift %v", drift)
+		}
+	})
+}
+
+func TestFieldDrifts(t *testing.T) {
+	t.Run("compares the lists without names by index", func(t *testing.T) {
+		drifts := fieldDrifts("args", []any{"--a", "--b"}, []any{"--a", "--c"})
+		if len(drifts) != 1 || drifts[0].Path != "args[1]" {
+			t.Errorf("unexpected drifts %v", drifts)
+		}
+	})
+	t.Run("compares the lists of different lengths as a whole", func(t *testing.T) {
+		drifts := fieldDrifts("args", []any{"--a"}, []any{"--a", "--b"})
+		if len(drifts) != 1 || drifts[0].Path != "args" {
+			t.Errorf("unexpected drifts %v", drifts)
+		}
+	})
+	t.Run("compares the numbers and quantities by value", func(t *testing.T) {
+		if drifts := fieldDrifts("", map[string]any{"port": int64(80), "cpu": "0.5", "memory": "1Gi"},
+			map[string]any{"port": float64(80), "cpu": "500m", "memory": "1024Mi"}); len(drifts) != 0 {
+			t.Errorf("unexpected drifts %v", drifts)
+		}
+	})
+	t.Run("reports the fields missing from the live object", func(t *testing.T) {
+		drifts := fieldDrifts("data", map[string]any{"key": "value"}, nil)
+		if len(drifts) != 1 || drifts[0].Path != "data" {
+			t.Errorf("unexpected drifts %v", drifts)
+		}
+	})
+}
+
+func TestManagedFieldPaths(t *testing.T) {
+	paths := managedFieldPaths("", map[string]any{
+		"f:metadata": map[string]any{"f:annotations": map[string]any{".": map[string]any{}, "f:example.com/owner": map[string]any{}}},
+		"f:spec": map[string]any{
+			"f:replicas": map[string]any{},
+			"f:template": map[string]any{"f:spec": map[string]any{"f:containers": map[string]any{
+				`k:{"name":"app"}`: map[string]any{".": map[string]any{}, "f:image": map[string]any{}},
+			}}},
+			"f:finalizers": map[string]any{"v:example.com/cleanup": map[string]any{}},
+		},
+	})
+	slices.Sort(paths)
+	expected := []string{
+		"metadata.annotations[example.com/owner]",
+		"spec.finalizers[example.com/cleanup]",
+		"spec.replicas",
+		"spec.template.spec.containers[name=app].image",
+	}
+	if !slices.Equal(paths, expected) {
+		t.Errorf("unexpected paths %v", paths)
+	}
+}
+
+func TestConfigMapManifests(t *testing.T) {
+	files := map[string]string{"b.yaml": "kind: B\n", "a.json": `{"kind": "A"}`, "README.md": "docs"}
+	t.Run("joins the YAML and JSON keys sorted", func(t *testing.T) {
+		manifests, err := configMapManifests(files, "")
+		if err != nil || manifests != "{\"kind\": \"A\"}\n---\nkind: B" {
+			t.Errorf("unexpected manifests %q %v", manifests, err)
+		}
+	})
+	t.Run("reads only the key if provided", func(t *testing.T) {
+		manifests, err := configMapManifests(files, "b.yaml")
+		if err != nil || manifests != "kind: B\n" {
+			t.Errorf("unexpected manifests %q %v", manifests, err)
+		}
+	})
+	t.Run("fails if the key is missing", func(t *testing.T) {
+		if _, err := configMapManifests(files, "c.yaml"); err == nil || err.Error() != "key c.yaml not found in the ConfigMap" {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package kubernetes
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context in which the mutating operations are submitted with server-side dry-run.
+// The API server validates and admits the requests and returns the would-be result, but nothing is persisted.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun returns true if the mutating operations performed with the provided context must not be persisted
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// dryRun returns the dry-run option for the mutating operations performed with the provided context
+func dryRun(ctx context.Context) []string {
+	if IsDryRun(ctx) {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
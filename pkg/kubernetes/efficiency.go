@@ -0,0 +1,221 @@
+package kubernetes
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EfficiencyFlag flags a container of a workload whose requests and limits don't match its usage
+type EfficiencyFlag string
+
+const (
+	// EfficiencyFlagCPUOverRequested the CPU usage is below ProvisioningOverThreshold of the request
+	EfficiencyFlagCPUOverRequested EfficiencyFlag = "cpu-over-requested"
+	// EfficiencyFlagCPUUnderRequested the CPU usage is above the request
+	EfficiencyFlagCPUUnderRequested    EfficiencyFlag = "cpu-under-requested"
+	EfficiencyFlagMemoryOverRequested  EfficiencyFlag = "memory-over-requested"
+	EfficiencyFlagMemoryUnderRequested EfficiencyFlag = "memory-under-requested"
+	EfficiencyFlagNoCPURequest         EfficiencyFlag = "no-cpu-request"
+	EfficiencyFlagNoMemoryRequest      EfficiencyFlag = "no-memory-request"
+	EfficiencyFlagNoCPULimit           EfficiencyFlag = "no-cpu-limit"
+	EfficiencyFlagNoMemoryLimit        EfficiencyFlag = "no-memory-limit"
+	// EfficiencyFlagCPUThrottled the CPU usage of a pod is above ProvisioningNearLimitThreshold of its limit, the container is likely throttled
+	EfficiencyFlagCPUThrottled EfficiencyFlag = "cpu-throttled"
+	// EfficiencyFlagMemoryNearLimit the memory usage of a pod is above ProvisioningNearLimitThreshold of its limit
+	EfficiencyFlagMemoryNearLimit EfficiencyFlag = "memory-near-limit"
+	// EfficiencyFlagOOMKilled the last termination of the container in a pod was an OOM kill
+	EfficiencyFlagOOMKilled EfficiencyFlag = "oom-killed"
+)
+
+// ResourceEfficiency is the usage of a resource by the containers of the pods of a workload compared to their requests and limits (summed across the pods)
+type ResourceEfficiency struct {
+	Usage resource.Quantity
+	// Request and Limit are nil if the container doesn't set them
+	Request *resource.Quantity
+	Limit   *resource.Quantity
+}
+
+// Efficiency returns the ratio of the usage to the request, false if there is no request
+func (r ResourceEfficiency) Efficiency() (float64, bool) {
+	if r.Request == nil || r.Request.IsZero() {
+		return 0, false
+	}
+	return float64(r.Usage.MilliValue()) / float64(r.Request.MilliValue()), true
+}
+
+// ContainerEfficiency is the efficiency of a container across the running pods of a workload
+type ContainerEfficiency struct {
+	Namespace string
+	// Kind and Workload are the controller of the pods (e.g. Deployment, StatefulSet, DaemonSet, Job), or Pod for standalone pods
+	Kind      string
+	Workload  string
+	Container string
+	Pods      int
+	Restarts  int32
+	CPU       ResourceEfficiency
+	Memory    ResourceEfficiency
+	Flags     []EfficiencyFlag
+}
+
+// NamespaceEfficiency is the usage of the containers of a namespace compared to their requests
+type NamespaceEfficiency struct {
+	Namespace         string
+	CPU               ResourceEfficiency
+	Memory            ResourceEfficiency
+	Containers        int
+	FlaggedContainers int
+}
+
+// WorkloadsEfficiency compares the resource consumption of the containers of the running pods, aggregated per workload, with their requests and limits,
+// flagging the containers with large request/usage gaps, missing requests or limits, close to their limits, or OOM killed
+func (k *Kubernetes) WorkloadsEfficiency(ctx context.Context, options PodsTopOptions) ([]ContainerEfficiency, error) {
+	podMetrics, pods, err := k.podsTopWithSpecs(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	byContainer := make(map[string]*ContainerEfficiency)
+	var keys []string
+	for _, podMetric := range podMetrics.Items {
+		pod, ok := pods[podMetric.Namespace+"/"+podMetric.Name]
+		if !ok {
+			continue
+		}
+		kind, workload := podWorkload(pod)
+		for _, containerMetric := range podMetric.Containers {
+			index := slices.IndexFunc(pod.Spec.Containers, func(c v1.Container) bool { return c.Name == containerMetric.Name })
+			if index < 0 {
+				continue
+			}
+			key := strings.Join([]string{pod.Namespace, kind, workload, containerMetric.Name}, "/")
+			efficiency := byContainer[key]
+			if efficiency == nil {
+				efficiency = &ContainerEfficiency{Namespace: pod.Namespace, Kind: kind, Workload: workload, Container: containerMetric.Name}
+				byContainer[key] = efficiency
+				keys = append(keys, key)
+			}
+			addContainerEfficiency(efficiency, pod, pod.Spec.Containers[index], containerMetric.Usage)
+		}
+	}
+	slices.Sort(keys)
+	ret := make([]ContainerEfficiency, 0, len(keys))
+	for _, key := range keys {
+		efficiency := byContainer[key]
+		efficiency.Flags = append(efficiencyFlags(*efficiency), efficiency.Flags...)
+		ret = append(ret, *efficiency)
+	}
+	return ret, nil
+}
+
+// WorkloadsEfficiencyByNamespace sums the usage and the requests of the containers per namespace
+func WorkloadsEfficiencyByNamespace(containers []ContainerEfficiency) []NamespaceEfficiency {
+	var ret []NamespaceEfficiency
+	for _, container := range containers {
+		if len(ret) == 0 || ret[len(ret)-1].Namespace != container.Namespace {
+			ret = append(ret, NamespaceEfficiency{
+				Namespace: container.Namespace,
+				CPU:       ResourceEfficiency{Request: &resource.Quantity{}},
+				Memory:    ResourceEfficiency{Request: &resource.Quantity{}},
+			})
+		}
+		namespace := &ret[len(ret)-1]
+		namespace.Containers++
+		if len(container.Flags) > 0 {
+			namespace.FlaggedContainers++
+		}
+		namespace.CPU.Usage.Add(container.CPU.Usage)
+		namespace.Memory.Usage.Add(container.Memory.Usage)
+		if container.CPU.Request != nil {
+			namespace.CPU.Request.Add(*container.CPU.Request)
+		}
+		if container.Memory.Request != nil {
+			namespace.Memory.Request.Add(*container.Memory.Request)
+		}
+	}
+	return ret
+}
+
+// podWorkload returns the workload of the pod, the Deployment of the pods created by a ReplicaSet with a pod-template-hash
+func podWorkload(pod *v1.Pod) (string, string) {
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil {
+		return "Pod", pod.Name
+	}
+	if hash, ok := pod.Labels["pod-template-hash"]; ok && controller.Kind == "ReplicaSet" && strings.HasSuffix(controller.Name, "-"+hash) {
+		return "Deployment", strings.TrimSuffix(controller.Name, "-"+hash)
+	}
+	return controller.Kind, controller.Name
+}
+
+func addContainerEfficiency(efficiency *ContainerEfficiency, pod *v1.Pod, container v1.Container, usage v1.ResourceList) {
+	efficiency.Pods++
+	for _, r := range []struct {
+		name     v1.ResourceName
+		resource *ResourceEfficiency
+		flag     EfficiencyFlag
+	}{
+		{v1.ResourceCPU, &efficiency.CPU, EfficiencyFlagCPUThrottled},
+		{v1.ResourceMemory, &efficiency.Memory, EfficiencyFlagMemoryNearLimit},
+	} {
+		// The flags of the usage of each pod against its limit, a single pod close to its limit is enough
+		if resourceProvisioning(r.name, usage, container.Resources).Status == ProvisioningStatusNearLimit && !slices.Contains(efficiency.Flags, r.flag) {
+			efficiency.Flags = append(efficiency.Flags, r.flag)
+		}
+		r.resource.Usage.Add(usage[r.name])
+		r.resource.Request = addQuantity(r.resource.Request, container.Resources.Requests, r.name, efficiency.Pods == 1)
+		r.resource.Limit = addQuantity(r.resource.Limit, container.Resources.Limits, r.name, efficiency.Pods == 1)
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != container.Name {
+			continue
+		}
+		efficiency.Restarts += status.RestartCount
+		if status.LastTerminationState.Terminated != nil && status.LastTerminationState.Terminated.Reason == "OOMKilled" &&
+			!slices.Contains(efficiency.Flags, EfficiencyFlagOOMKilled) {
+			efficiency.Flags = append(efficiency.Flags, EfficiencyFlagOOMKilled)
+		}
+	}
+}
+
+// addQuantity adds the quantity of the resource to the total, nil if any of the pods doesn't set it
+func addQuantity(total *resource.Quantity, resources v1.ResourceList, name v1.ResourceName, first bool) *resource.Quantity {
+	quantity, ok := resources[name]
+	if !ok || (!first && total == nil) {
+		return nil
+	}
+	if first {
+		return &quantity
+	}
+	total.Add(quantity)
+	return total
+}
+
+// efficiencyFlags returns the flags of the aggregated usage against the requests and the missing requests and limits
+func efficiencyFlags(efficiency ContainerEfficiency) []EfficiencyFlag {
+	var ret []EfficiencyFlag
+	for _, r := range []struct {
+		resource                                          ResourceEfficiency
+		overRequested, underRequested, noRequest, noLimit EfficiencyFlag
+	}{
+		{efficiency.CPU, EfficiencyFlagCPUOverRequested, EfficiencyFlagCPUUnderRequested, EfficiencyFlagNoCPURequest, EfficiencyFlagNoCPULimit},
+		{efficiency.Memory, EfficiencyFlagMemoryOverRequested, EfficiencyFlagMemoryUnderRequested, EfficiencyFlagNoMemoryRequest, EfficiencyFlagNoMemoryLimit},
+	} {
+		ratio, ok := r.resource.Efficiency()
+		switch {
+		case !ok:
+			ret = append(ret, r.noRequest)
+		case ratio > 1:
+			ret = append(ret, r.underRequested)
+		case ratio < ProvisioningOverThreshold:
+			ret = append(ret, r.overRequested)
+		}
+		if r.resource.Limit == nil {
+			ret = append(ret, r.noLimit)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,120 @@
+package kubernetes
+
+import (
+	"slices"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodWorkload(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		pod          *v1.Pod
+		kind, expect string
+	}{
+		{"standalone pod", &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}}, "Pod", "pod-1"},
+		{"deployment pod", &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-7d4b9c-abcde",
+			Labels:          map[string]string{"pod-template-hash": "7d4b9c"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-7d4b9c", Controller: ptr.To(true)}},
+		}}, "Deployment", "web"},
+		{"bare replicaset pod", &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            "rs-abcde",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "rs", Controller: ptr.To(true)}},
+		}}, "ReplicaSet", "rs"},
+		{"statefulset pod", &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            "db-0",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "db", Controller: ptr.To(true)}},
+		}}, "StatefulSet", "db"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, name := podWorkload(tc.pod)
+			if kind != tc.kind || name != tc.expect {
+				t.Errorf("expected %s/%s, got %s/%s", tc.kind, tc.expect, kind, name)
+			}
+		})
+	}
+}
+
+func TestContainerEfficiency(t *testing.T) {
+	resources := v1.ResourceRequirements{
+		Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m"), v1.ResourceMemory: resource.MustParse("64Mi")},
+		Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("1"), v1.ResourceMemory: resource.MustParse("128Mi")},
+	}
+	usage := func(cpu, memory string) v1.ResourceList {
+		return v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu), v1.ResourceMemory: resource.MustParse(memory)}
+	}
+	t.Run("aggregates the pods of the workload", func(t *testing.T) {
+		efficiency := &ContainerEfficiency{}
+		container := v1.Container{Name: "web", Resources: resources}
+		addContainerEfficiency(efficiency, &v1.Pod{}, container, usage("10m", "20Mi"))
+		addContainerEfficiency(efficiency, &v1.Pod{}, container, usage("20m", "30Mi"))
+		efficiency.Flags = append(efficiencyFlags(*efficiency), efficiency.Flags...)
+		if efficiency.Pods != 2 {
+			t.Errorf("expected 2 pods, got %d", efficiency.Pods)
+		}
+		if efficiency.CPU.Usage.MilliValue() != 30 || efficiency.CPU.Request.MilliValue() != 1000 || efficiency.CPU.Limit.MilliValue() != 2000 {
+			t.Errorf("expected CPU 30m/1/2, got %s/%s/%s", efficiency.CPU.Usage.String(), efficiency.CPU.Request.String(), efficiency.CPU.Limit.String())
+		}
+		if ratio, _ := efficiency.Memory.Efficiency(); ratio < 0.39 || ratio > 0.40 {
+			t.Errorf("expected memory efficiency 39%%, got %f", ratio)
+		}
+		if !slices.Equal(efficiency.Flags, []EfficiencyFlag{EfficiencyFlagCPUOverRequested}) {
+			t.Errorf("expected cpu-over-requested flag, got %v", efficiency.Flags)
+		}
+	})
+	t.Run("flags missing requests, limits, throttling, and OOM kills", func(t *testing.T) {
+		efficiency := &ContainerEfficiency{}
+		container := v1.Container{Name: "worker", Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+			Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+		}}
+		pod := &v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+			Name:                 "worker",
+			RestartCount:         3,
+			LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled"}},
+		}}}}
+		addContainerEfficiency(efficiency, pod, container, usage("950m", "60Mi"))
+		efficiency.Flags = append(efficiencyFlags(*efficiency), efficiency.Flags...)
+		expected := []EfficiencyFlag{EfficiencyFlagCPUUnderRequested, EfficiencyFlagNoMemoryRequest, EfficiencyFlagNoMemoryLimit, EfficiencyFlagCPUThrottled, EfficiencyFlagOOMKilled}
+		if !slices.Equal(efficiency.Flags, expected) {
+			t.Errorf("expected flags %v, got %v", expected, efficiency.Flags)
+		}
+		if efficiency.Restarts != 3 {
+			t.Errorf("expected 3 restarts, got %d", efficiency.Restarts)
+		}
+	})
+	t.Run("missing request in one of the pods", func(t *testing.T) {
+		efficiency := &ContainerEfficiency{}
+		addContainerEfficiency(efficiency, &v1.Pod{}, v1.Container{Name: "web", Resources: resources}, usage("10m", "20Mi"))
+		addContainerEfficiency(efficiency, &v1.Pod{}, v1.Container{Name: "web"}, usage("10m", "20Mi"))
+		if efficiency.CPU.Request != nil || efficiency.Memory.Limit != nil {
+			t.Errorf("expected no request and limit, got %v and %v", efficiency.CPU.Request, efficiency.Memory.Limit)
+		}
+	})
+}
+
+func TestWorkloadsEfficiencyByNamespace(t *testing.T) {
+	request := resource.MustParse("500m")
+	namespaces := WorkloadsEfficiencyByNamespace([]ContainerEfficiency{
+		{Namespace: "default", CPU: ResourceEfficiency{Usage: resource.MustParse("100m"), Request: &request}},
+		{Namespace: "default", CPU: ResourceEfficiency{Usage: resource.MustParse("50m")}, Flags: []EfficiencyFlag{EfficiencyFlagNoCPURequest}},
+		{Namespace: "ns-1", CPU: ResourceEfficiency{Usage: resource.MustParse("10m")}},
+	})
+	if len(namespaces) != 2 {
+		t.Fatalf("expected 2 namespaces, got %d", len(namespaces))
+	}
+	if namespaces[0].Containers != 2 || namespaces[0].FlaggedContainers != 1 {
+		t.Errorf("expected 2 containers with 1 flagged, got %d and %d", namespaces[0].Containers, namespaces[0].FlaggedContainers)
+	}
+	if namespaces[0].CPU.Usage.MilliValue() != 150 || namespaces[0].CPU.Request.MilliValue() != 500 {
+		t.Errorf("expected CPU 150m/500m, got %s/%s", namespaces[0].CPU.Usage.String(), namespaces[0].CPU.Request.String())
+	}
+	if _, ok := namespaces[1].CPU.Efficiency(); ok {
+		t.Errorf("expected no CPU efficiency for ns-1 without requests")
+	}
+}
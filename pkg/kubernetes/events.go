@@ -2,18 +2,60 @@ package kubernetes
 
 import (
 	"context"
+	"slices"
+	"strings"
+	"time"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"strings"
 )
 
-func (k *Kubernetes) EventsList(ctx context.Context, namespace string) ([]map[string]any, error) {
+type EventsListOptions struct {
+	// InvolvedObjectKind only lists the events of the objects of the provided kind (e.g. Pod)
+	InvolvedObjectKind string
+	// InvolvedObjectName only lists the events of the objects with the provided name
+	InvolvedObjectName string
+	// Type only lists the events of the provided type (Normal or Warning)
+	Type string
+	// Since only lists the events last observed within the provided duration
+	Since time.Duration
+}
+
+// eventsListKey identifies the repeated occurrences of the same event
+type eventsListKey struct {
+	namespace, apiVersion, kind, name, eventType, reason, message string
+}
+
+type eventsListItem struct {
+	key       eventsListKey
+	timestamp time.Time
+	count     int32
+}
+
+// EventsList returns the events sorted by their last occurrence, the repeated occurrences of the same event
+// (same involved object, type, reason, and message) are deduplicated and counted
+func (k *Kubernetes) EventsList(ctx context.Context, namespace string, options EventsListOptions) ([]map[string]any, error) {
 	var eventMap []map[string]any
+	selector := make(fields.Set)
+	if options.InvolvedObjectKind != "" {
+		selector["involvedObject.kind"] = options.InvolvedObjectKind
+	}
+	if options.InvolvedObjectName != "" {
+		selector["involvedObject.name"] = options.InvolvedObjectName
+	}
+	if options.Type != "" {
+		selector["type"] = options.Type
+	}
+	listOptions := ResourceListOptions{}
+	if len(selector) > 0 {
+		listOptions.FieldSelector = selector.AsSelector().String()
+	}
 	raw, err := k.ResourcesList(ctx, &schema.GroupVersionKind{
 		Group: "", Version: "v1", Kind: "Event",
-	}, namespace, ResourceListOptions{})
+	}, namespace, listOptions)
 	if err != nil {
 		return eventMap, err
 	}
@@ -21,31 +63,71 @@ func (k *Kubernetes) EventsList(ctx context.Context, namespace string) ([]map[st
 	if len(unstructuredList.Items) == 0 {
 		return eventMap, nil
 	}
+	var items []*eventsListItem
+	index := make(map[eventsListKey]*eventsListItem)
 	for _, item := range unstructuredList.Items {
 		event := &v1.Event{}
 		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, event); err != nil {
 			return eventMap, err
 		}
-		timestamp := event.EventTime.Time
-		if timestamp.IsZero() && event.Series != nil {
-			timestamp = event.Series.LastObservedTime.Time
-		} else if timestamp.IsZero() && event.Count > 1 {
-			timestamp = event.LastTimestamp.Time
-		} else if timestamp.IsZero() {
-			timestamp = event.FirstTimestamp.Time
+		timestamp := eventTimestamp(event)
+		if options.Since > 0 && time.Since(timestamp) > options.Since {
+			continue
+		}
+		count := max(event.Count, 1)
+		if event.Series != nil {
+			count = max(event.Series.Count, count)
 		}
-		eventMap = append(eventMap, map[string]any{
-			"Namespace": event.Namespace,
-			"Timestamp": timestamp.String(),
-			"Type":      event.Type,
-			"Reason":    event.Reason,
+		key := eventsListKey{
+			namespace:  event.Namespace,
+			apiVersion: event.InvolvedObject.APIVersion,
+			kind:       event.InvolvedObject.Kind,
+			name:       event.InvolvedObject.Name,
+			eventType:  event.Type,
+			reason:     event.Reason,
+			message:    strings.TrimSpace(event.Message),
+		}
+		if existing, ok := index[key]; ok {
+			existing.count += count
+			if timestamp.After(existing.timestamp) {
+				existing.timestamp = timestamp
+			}
+			continue
+		}
+		index[key] = &eventsListItem{key: key, timestamp: timestamp, count: count}
+		items = append(items, index[key])
+	}
+	slices.SortStableFunc(items, func(a, b *eventsListItem) int { return a.timestamp.Compare(b.timestamp) })
+	for _, item := range items {
+		event := map[string]any{
+			"Namespace": item.key.namespace,
+			"Timestamp": item.timestamp.String(),
+			"Type":      item.key.eventType,
+			"Reason":    item.key.reason,
 			"InvolvedObject": map[string]string{
-				"apiVersion": event.InvolvedObject.APIVersion,
-				"Kind":       event.InvolvedObject.Kind,
-				"Name":       event.InvolvedObject.Name,
+				"apiVersion": item.key.apiVersion,
+				"Kind":       item.key.kind,
+				"Name":       item.key.name,
 			},
-			"Message": strings.TrimSpace(event.Message),
-		})
+			"Message": item.key.message,
+		}
+		if item.count > 1 {
+			event["Count"] = item.count
+		}
+		eventMap = append(eventMap, event)
 	}
 	return eventMap, nil
 }
+
+// eventTimestamp returns the last occurrence of the event
+func eventTimestamp(event *v1.Event) time.Time {
+	timestamp := event.EventTime.Time
+	if timestamp.IsZero() && event.Series != nil {
+		timestamp = event.Series.LastObservedTime.Time
+	} else if timestamp.IsZero() && event.Count > 1 {
+		timestamp = event.LastTimestamp.Time
+	} else if timestamp.IsZero() {
+		timestamp = event.FirstTimestamp.Time
+	}
+	return timestamp
+}
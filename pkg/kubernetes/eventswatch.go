@@ -0,0 +1,295 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/utils/ptr"
+)
+
+// EventSeverity is the severity assigned to the events by their reason
+type EventSeverity string
+
+const (
+	EventSeverityCritical EventSeverity = "critical"
+	EventSeverityHigh     EventSeverity = "high"
+	EventSeverityMedium   EventSeverity = "medium"
+	EventSeverityLow      EventSeverity = "low"
+)
+
+// eventSeverityWeights are the base scores of the severities, the groups of events are ranked by score
+var eventSeverityWeights = map[EventSeverity]int{
+	EventSeverityCritical: 100,
+	EventSeverityHigh:     50,
+	EventSeverityMedium:   20,
+	EventSeverityLow:      5,
+}
+
+// eventReasonSeverities are the severities of the well-known reasons, the other Warning events are medium and the Normal events low
+var eventReasonSeverities = map[string]EventSeverity{
+	"OOMKilling":                   EventSeverityCritical,
+	"SystemOOM":                    EventSeverityCritical,
+	"NodeNotReady":                 EventSeverityCritical,
+	"Rebooted":                     EventSeverityCritical,
+	"Evicted":                      EventSeverityCritical,
+	"EvictionThresholdMet":         EventSeverityCritical,
+	"FreeDiskSpaceFailed":          EventSeverityCritical,
+	"ContainerGCFailed":            EventSeverityCritical,
+	"FailedAttachVolume":           EventSeverityCritical,
+	"BackOff":                      EventSeverityHigh,
+	"CrashLoopBackOff":             EventSeverityHigh,
+	"Failed":                       EventSeverityHigh,
+	"FailedScheduling":             EventSeverityHigh,
+	"FailedMount":                  EventSeverityHigh,
+	"FailedCreate":                 EventSeverityHigh,
+	"FailedCreatePodSandBox":       EventSeverityHigh,
+	"FailedKillPod":                EventSeverityHigh,
+	"Unhealthy":                    EventSeverityHigh,
+	"ProgressDeadlineExceeded":     EventSeverityHigh,
+	"ProvisioningFailed":           EventSeverityHigh,
+	"FailedBinding":                EventSeverityHigh,
+	"NetworkNotReady":              EventSeverityHigh,
+	"Preempted":                    EventSeverityHigh,
+	"FailedPreStopHook":            EventSeverityMedium,
+	"FailedPostStartHook":          EventSeverityMedium,
+	"NodeHasInsufficientMemory":    EventSeverityCritical,
+	"NodeHasDiskPressure":          EventSeverityCritical,
+	"NodeHasInsufficientPID":       EventSeverityCritical,
+	"FailedGetResourceMetric":      EventSeverityMedium,
+	"FailedComputeMetricsReplicas": EventSeverityMedium,
+}
+
+const (
+	// eventsWatchOngoingScore is added to the score of the groups of events observed during the watch
+	eventsWatchOngoingScore = 20
+	// eventsWatchMaxCountScore bounds the score added by the number of occurrences of the events of a group
+	eventsWatchMaxCountScore = 50
+	// eventsWatchMaxEventsPerGroup bounds the distinct events reported per involved object, the most severe and recent first
+	eventsWatchMaxEventsPerGroup = 5
+)
+
+type EventsWatchOptions struct {
+	// Duration is how long the events are watched for
+	Duration time.Duration
+	// Since includes the events last observed within the provided duration before the watch
+	Since time.Duration
+	// IncludeNormal includes the Normal events, only the Warning events are reported otherwise
+	IncludeNormal bool
+	// Top is the maximum number of groups of events returned, the highest scores first
+	Top int
+}
+
+// EventsDigest is the ranked digest of the events grouped by involved object
+type EventsDigest struct {
+	Watched string `json:"watched"`
+	Since   string `json:"since"`
+	// Events is the number of (deduplicated) events, New the number of events created or updated during the watch
+	Events     int                   `json:"events"`
+	New        int                   `json:"new"`
+	Objects    int                   `json:"objects"`
+	Severities map[EventSeverity]int `json:"severities,omitempty"`
+	// Truncated is set if more groups than Top were found
+	Truncated bool          `json:"truncated,omitempty"`
+	Groups    []EventsGroup `json:"groups"`
+}
+
+// EventsGroup are the events of an involved object, with the highest severity of its events and its score
+type EventsGroup struct {
+	Severity  EventSeverity `json:"severity"`
+	Score     int           `json:"score"`
+	Namespace string        `json:"namespace,omitempty"`
+	Object    string        `json:"object"`
+	Count     int32         `json:"count"`
+	// Ongoing is set if some events of the object were created or updated during the watch
+	Ongoing  bool               `json:"ongoing,omitempty"`
+	LastSeen time.Time          `json:"lastSeen"`
+	Events   []EventsGroupEntry `json:"events"`
+}
+
+type EventsGroupEntry struct {
+	Severity EventSeverity `json:"severity"`
+	Type     string        `json:"type"`
+	Reason   string        `json:"reason"`
+	Message  string        `json:"message"`
+	Count    int32         `json:"count"`
+	LastSeen time.Time     `json:"lastSeen"`
+}
+
+// eventsWatchItem is the latest version of an event, watched is set if it was created or updated during the watch
+type eventsWatchItem struct {
+	event   *v1.Event
+	watched bool
+}
+
+// EventsWatch lists the events last observed within the since window, then watches the new and updated events for the duration,
+// in all namespaces if no namespace is provided. The events are deduplicated, grouped by involved object, scored by the severity
+// of their reason, their number of occurrences, and whether they are ongoing, and returned as a digest ranked by score.
+func (k *Kubernetes) EventsWatch(ctx context.Context, namespace string, options EventsWatchOptions) (*EventsDigest, error) {
+	gvk := &schema.GroupVersionKind{Version: "v1", Kind: "Event"}
+	list, err := k.ResourcesList(ctx, gvk, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[types.UID]*eventsWatchItem)
+	var order []types.UID
+	record := func(obj *unstructured.Unstructured, watched bool) error {
+		event := &v1.Event{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, event); err != nil {
+			return err
+		}
+		if _, ok := items[event.UID]; !ok {
+			order = append(order, event.UID)
+		}
+		items[event.UID] = &eventsWatchItem{event: event, watched: watched}
+		return nil
+	}
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		if err = record(&item, false); err != nil {
+			return nil, err
+		}
+	}
+	gvr, err := k.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+	watchCtx, cancel := context.WithTimeout(ctx, options.Duration)
+	defer cancel()
+	watcher, err := k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Watch(watchCtx, metav1.ListOptions{
+		ResourceVersion: list.(*unstructured.UnstructuredList).GetResourceVersion(),
+		TimeoutSeconds:  ptr.To(int64(options.Duration.Seconds())),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch events: %w", err)
+	}
+	defer watcher.Stop()
+	start := time.Now()
+watchLoop:
+	for {
+		select {
+		case <-watchCtx.Done():
+			break watchLoop
+		case e, ok := <-watcher.ResultChan():
+			if !ok || e.Type == watch.Error {
+				// The watch expired or was closed by the server, the events received so far are reported
+				break watchLoop
+			}
+			obj, isUnstructured := e.Object.(*unstructured.Unstructured)
+			if !isUnstructured || (e.Type != watch.Added && e.Type != watch.Modified) {
+				continue
+			}
+			if err = record(obj, true); err != nil {
+				return nil, err
+			}
+		}
+	}
+	watched := make([]*eventsWatchItem, 0, len(order))
+	for _, uid := range order {
+		watched = append(watched, items[uid])
+	}
+	ret := digestEvents(watched, options, time.Now())
+	ret.Watched = time.Since(start).Round(time.Second).String()
+	return ret, nil
+}
+
+// eventSeverity returns the severity of the well-known reason of the event, medium for the other Warning events and low for the Normal events
+func eventSeverity(event *v1.Event) EventSeverity {
+	if severity, ok := eventReasonSeverities[event.Reason]; ok && (event.Type == v1.EventTypeWarning || severity == EventSeverityCritical) {
+		return severity
+	}
+	if event.Type == v1.EventTypeWarning {
+		return EventSeverityMedium
+	}
+	return EventSeverityLow
+}
+
+// digestEvents groups the events by involved object, skipping the events last observed before the since window (unless watched)
+// and the Normal events unless included, and ranks the groups by score
+func digestEvents(items []*eventsWatchItem, options EventsWatchOptions, now time.Time) *EventsDigest {
+	ret := &EventsDigest{Since: options.Since.String(), Severities: make(map[EventSeverity]int), Groups: []EventsGroup{}}
+	type groupKey struct {
+		namespace, kind, name string
+	}
+	type entryKey struct {
+		eventType, reason, message string
+	}
+	groups := make(map[groupKey]*EventsGroup)
+	var keys []groupKey
+	for _, item := range items {
+		event := item.event
+		timestamp := eventTimestamp(event)
+		if (!options.IncludeNormal && event.Type != v1.EventTypeWarning) || (!item.watched && options.Since > 0 && now.Sub(timestamp) > options.Since) {
+			continue
+		}
+		count := max(event.Count, 1)
+		if event.Series != nil {
+			count = max(event.Series.Count, count)
+		}
+		ret.Events++
+		if item.watched {
+			ret.New++
+		}
+		key := groupKey{namespace: event.InvolvedObject.Namespace, kind: event.InvolvedObject.Kind, name: event.InvolvedObject.Name}
+		group, ok := groups[key]
+		if !ok {
+			group = &EventsGroup{Namespace: key.namespace, Object: key.kind + "/" + key.name, Severity: EventSeverityLow}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		severity := eventSeverity(event)
+		if eventSeverityWeights[severity] > eventSeverityWeights[group.Severity] {
+			group.Severity = severity
+		}
+		group.Count += count
+		group.Ongoing = group.Ongoing || item.watched
+		if timestamp.After(group.LastSeen) {
+			group.LastSeen = timestamp
+		}
+		entry := entryKey{eventType: event.Type, reason: event.Reason, message: strings.TrimSpace(event.Message)}
+		index := slices.IndexFunc(group.Events, func(e EventsGroupEntry) bool {
+			return entryKey{eventType: e.Type, reason: e.Reason, message: e.Message} == entry
+		})
+		if index < 0 {
+			group.Events = append(group.Events, EventsGroupEntry{Severity: severity, Type: entry.eventType, Reason: entry.reason, Message: entry.message})
+			index = len(group.Events) - 1
+		}
+		group.Events[index].Count += count
+		if timestamp.After(group.Events[index].LastSeen) {
+			group.Events[index].LastSeen = timestamp
+		}
+	}
+	for _, key := range keys {
+		group := groups[key]
+		group.Score = eventSeverityWeights[group.Severity] + int(min(group.Count, eventsWatchMaxCountScore))
+		if group.Ongoing {
+			group.Score += eventsWatchOngoingScore
+		}
+		slices.SortStableFunc(group.Events, func(a, b EventsGroupEntry) int {
+			return cmp.Or(cmp.Compare(eventSeverityWeights[b.Severity], eventSeverityWeights[a.Severity]), b.LastSeen.Compare(a.LastSeen))
+		})
+		if len(group.Events) > eventsWatchMaxEventsPerGroup {
+			group.Events = group.Events[:eventsWatchMaxEventsPerGroup]
+		}
+		ret.Severities[group.Severity]++
+		ret.Groups = append(ret.Groups, *group)
+	}
+	ret.Objects = len(ret.Groups)
+	slices.SortStableFunc(ret.Groups, func(a, b EventsGroup) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), b.LastSeen.Compare(a.LastSeen))
+	})
+	if options.Top > 0 && len(ret.Groups) > options.Top {
+		ret.Groups = ret.Groups[:options.Top]
+		ret.Truncated = true
+	}
+	return ret
+}
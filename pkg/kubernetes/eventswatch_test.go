@@ -0,0 +1,83 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDigestEvents(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	event := func(kind, name, eventType, reason, message string, count int32, age time.Duration, watched bool) *eventsWatchItem {
+		return &eventsWatchItem{watched: watched, event: &v1.Event{
+			InvolvedObject: v1.ObjectReference{Kind: kind, Namespace: "ns-1", Name: name},
+			Type:           eventType,
+			Reason:         reason,
+			Message:        message,
+			Count:          count,
+			FirstTimestamp: metav1.NewTime(now.Add(-age)),
+			LastTimestamp:  metav1.NewTime(now.Add(-age)),
+		}}
+	}
+	items := []*eventsWatchItem{
+		event("Pod", "web", v1.EventTypeWarning, "BackOff", "Back-off restarting failed container", 12, time.Minute, false),
+		event("Pod", "web", v1.EventTypeWarning, "BackOff", "Back-off restarting failed container", 3, 10*time.Second, true),
+		event("Pod", "web", v1.EventTypeWarning, "Unhealthy", "Liveness probe failed", 4, 2*time.Minute, false),
+		event("Node", "node-1", v1.EventTypeWarning, "OOMKilling", "Out of memory: Killed process 42", 1, 5*time.Minute, false),
+		event("Pod", "api", v1.EventTypeWarning, "SomethingUnusual", "Unusual", 1, time.Minute, false),
+		event("Pod", "db", v1.EventTypeNormal, "Pulled", "Image pulled", 1, time.Minute, true),
+		event("Pod", "old", v1.EventTypeWarning, "FailedScheduling", "0/3 nodes are available", 5, 2*time.Hour, false),
+	}
+	t.Run("groups and ranks the warning events by score", func(t *testing.T) {
+		digest := digestEvents(items, EventsWatchOptions{Since: 15 * time.Minute}, now)
+		if digest.Objects != 3 || len(digest.Groups) != 3 {
+			t.Fatalf("expected 3 objects, got %d: %v", digest.Objects, digest.Groups)
+		}
+		if digest.Events != 5 || digest.New != 1 {
+			t.Errorf("expected 5 events and 1 new, got %d and %d", digest.Events, digest.New)
+		}
+		// web: high (50) + 19 occurrences + ongoing (20), node-1: critical (100) + 1 occurrence, api: medium (20) + 1 occurrence
+		expected := []struct {
+			object   string
+			severity EventSeverity
+			score    int
+		}{{"Node/node-1", EventSeverityCritical, 101}, {"Pod/web", EventSeverityHigh, 89}, {"Pod/api", EventSeverityMedium, 21}}
+		for i, e := range expected {
+			g := digest.Groups[i]
+			if g.Object != e.object || g.Severity != e.severity || g.Score != e.score {
+				t.Errorf("expected group %d to be %s %s %d, got %s %s %d", i, e.object, e.severity, e.score, g.Object, g.Severity, g.Score)
+			}
+		}
+		web := digest.Groups[1]
+		if !web.Ongoing || web.Count != 19 || len(web.Events) != 2 {
+			t.Fatalf("expected the ongoing web Pod with 19 occurrences of 2 distinct events, got %+v", web)
+		}
+		if web.Events[0].Reason != "BackOff" || web.Events[0].Count != 15 || !web.Events[0].LastSeen.Equal(now.Add(-10*time.Second)) {
+			t.Errorf("expected the deduplicated BackOff event first, got %+v", web.Events[0])
+		}
+		if digest.Severities[EventSeverityCritical] != 1 || digest.Severities[EventSeverityHigh] != 1 || digest.Severities[EventSeverityMedium] != 1 {
+			t.Errorf("unexpected severities %v", digest.Severities)
+		}
+	})
+	t.Run("includes the normal events", func(t *testing.T) {
+		digest := digestEvents(items, EventsWatchOptions{Since: 15 * time.Minute, IncludeNormal: true}, now)
+		// db: low (5) + 1 occurrence + ongoing (20)
+		if digest.Objects != 4 || digest.Groups[2].Object != "Pod/db" || digest.Groups[2].Severity != EventSeverityLow || digest.Groups[2].Score != 26 {
+			t.Errorf("expected the ongoing low severity db Pod third, got %+v", digest.Groups)
+		}
+	})
+	t.Run("includes the older events without since", func(t *testing.T) {
+		digest := digestEvents(items, EventsWatchOptions{}, now)
+		if digest.Objects != 4 {
+			t.Errorf("expected 4 objects, got %d", digest.Objects)
+		}
+	})
+	t.Run("truncates to the top groups", func(t *testing.T) {
+		digest := digestEvents(items, EventsWatchOptions{Since: 15 * time.Minute, Top: 1}, now)
+		if !digest.Truncated || len(digest.Groups) != 1 || digest.Objects != 3 || digest.Groups[0].Object != "Node/node-1" {
+			t.Errorf("expected the node-1 Node only, got %+v", digest)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// PodEvictionBlockedError is returned when the eviction of a pod is refused because it would violate a PodDisruptionBudget
+type PodEvictionBlockedError struct {
+	Namespace string
+	Name      string
+	// Budgets are the PodDisruptionBudgets protecting the pod
+	Budgets []PodDisruptionBudget
+	// Cause is the error returned by the Eviction API
+	Cause error
+}
+
+func (e *PodEvictionBlockedError) Error() string {
+	if len(e.Budgets) == 0 {
+		return fmt.Sprintf("eviction of pod %s/%s was refused: %v", e.Namespace, e.Name, e.Cause)
+	}
+	explanations := make([]string, 0, len(e.Budgets))
+	for _, budget := range e.Budgets {
+		explanation := fmt.Sprintf("PodDisruptionBudget %s/%s (%d of %d desired healthy pods are healthy, %d disruptions allowed)",
+			budget.Namespace, budget.Name, budget.CurrentHealthy, budget.DesiredHealthy, budget.AllowedDisruptions)
+		if len(budget.Issues) > 0 {
+			explanation += ": " + strings.Join(budget.Issues, ", ")
+		}
+		explanations = append(explanations, explanation)
+	}
+	return fmt.Sprintf("eviction of pod %s/%s is blocked by %s (%v)", e.Namespace, e.Name, strings.Join(explanations, "; "), e.Cause)
+}
+
+func (e *PodEvictionBlockedError) Unwrap() error {
+	return e.Cause
+}
+
+// PodsEvict evicts the pod through the Eviction API, so that the PodDisruptionBudgets protecting it are honored (unlike PodsDelete).
+// If the eviction is refused, a PodEvictionBlockedError is returned with the budgets protecting the pod and why they allow no disruptions.
+// The termination of the evicted pod is not awaited.
+func (k *Kubernetes) PodsEvict(ctx context.Context, namespace, name string, gracePeriodSeconds *int64) error {
+	namespace = k.NamespaceOrDefault(namespace)
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return err
+	}
+	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	evictErr := k.evict(ctx, pod, gracePeriodSeconds)
+	if !apierrors.IsTooManyRequests(evictErr) {
+		return evictErr
+	}
+	budgets, err := k.podDisruptionBudgetsOf(ctx, pod)
+	if err != nil {
+		return fmt.Errorf("%w (failed to list the PodDisruptionBudgets of the pod: %v)", evictErr, err)
+	}
+	return &PodEvictionBlockedError{Namespace: namespace, Name: name, Budgets: budgets, Cause: evictErr}
+}
+
+// podDisruptionBudgetsOf returns the PodDisruptionBudgets protecting the pod, with the issues of the budgets allowing no disruptions
+func (k *Kubernetes) podDisruptionBudgetsOf(ctx context.Context, pod *v1.Pod) ([]PodDisruptionBudget, error) {
+	list, err := k.ResourcesList(ctx, podDisruptionBudgetGVK, pod.Namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var ret []PodDisruptionBudget
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		pdb := &policyv1.PodDisruptionBudget{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, pdb); err != nil {
+			return nil, err
+		}
+		if selectorMatches(pdb.Spec.Selector, pod.Labels) {
+			ret = append(ret, podDisruptionBudget(pdb, []v1.Pod{*pod}, ""))
+		}
+	}
+	return ret, nil
+}
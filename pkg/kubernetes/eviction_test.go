@@ -0,0 +1,32 @@
+package kubernetes
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPodEvictionBlockedError(t *testing.T) {
+	cause := errors.New("Cannot evict pod as it would violate the pod's disruption budget.")
+	t.Run("with budgets", func(t *testing.T) {
+		err := &PodEvictionBlockedError{Namespace: "ns-1", Name: "pod-1", Cause: cause, Budgets: []PodDisruptionBudget{{
+			Namespace: "ns-1", Name: "pdb-1", CurrentHealthy: 2, DesiredHealthy: 2, AllowedDisruptions: 0,
+			Issues: []string{"minAvailable 2 requires all the 2 expected pods to be healthy, scale up the workload or lower minAvailable"},
+		}}}
+		expected := "eviction of pod ns-1/pod-1 is blocked by PodDisruptionBudget ns-1/pdb-1 (2 of 2 desired healthy pods are healthy, 0 disruptions allowed): " +
+			"minAvailable 2 requires all the 2 expected pods to be healthy, scale up the workload or lower minAvailable " +
+			"(Cannot evict pod as it would violate the pod's disruption budget.)"
+		if err.Error() != expected {
+			t.Errorf("expected %q, got %q", expected, err.Error())
+		}
+		if !errors.Is(err, cause) {
+			t.Errorf("expected the error to wrap the cause")
+		}
+	})
+	t.Run("without budgets", func(t *testing.T) {
+		err := &PodEvictionBlockedError{Namespace: "ns-1", Name: "pod-1", Cause: cause}
+		expected := "eviction of pod ns-1/pod-1 was refused: Cannot evict pod as it would violate the pod's disruption budget."
+		if err.Error() != expected {
+			t.Errorf("expected %q, got %q", expected, err.Error())
+		}
+	})
+}
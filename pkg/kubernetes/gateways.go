@@ -0,0 +1,306 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+var (
+	gatewayGVK   = &schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+	httpRouteGVK = &schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	// ErrGatewayAPINotInstalled is returned when the Gateway API CRDs are not installed in the cluster
+	ErrGatewayAPINotInstalled = errors.New("the Gateway API (gateway.networking.k8s.io/v1) is not installed in the cluster")
+)
+
+// Gateway is the summary of a Gateway API Gateway with its listeners and conditions
+type Gateway struct {
+	Namespace  string             `json:"namespace"`
+	Name       string             `json:"name"`
+	Class      string             `json:"class"`
+	Addresses  []string           `json:"addresses,omitempty"`
+	Listeners  []GatewayListener  `json:"listeners,omitempty"`
+	Conditions []GatewayCondition `json:"conditions,omitempty"`
+	// Issues reports the Gateway and listener conditions that are not healthy (e.g. not Accepted or not Programmed)
+	Issues []string `json:"issues,omitempty"`
+}
+
+type GatewayListener struct {
+	Name     string `json:"name"`
+	Protocol string `json:"protocol"`
+	Port     int32  `json:"port"`
+	Hostname string `json:"hostname,omitempty"`
+	// AttachedRoutes is the number of routes attached to the listener
+	AttachedRoutes int32 `json:"attachedRoutes"`
+}
+
+type GatewayCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// HTTPRoute is the summary of a Gateway API HTTPRoute with its rules and its attachment to the parent Gateways
+type HTTPRoute struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Hostnames []string `json:"hostnames,omitempty"`
+	// Rules are the routing rules as path (type) -> service:port
+	Rules   []string          `json:"rules,omitempty"`
+	Parents []HTTPRouteParent `json:"parents,omitempty"`
+	// Issues reports the parents that have not accepted the route or could not resolve its backends
+	Issues []string `json:"issues,omitempty"`
+}
+
+type HTTPRouteParent struct {
+	// Gateway is the namespace/name of the parent Gateway, with the listener section if provided
+	Gateway    string             `json:"gateway"`
+	Accepted   bool               `json:"accepted"`
+	Conditions []GatewayCondition `json:"conditions,omitempty"`
+}
+
+// gatewayObject is the subset of the Gateway API Gateway used by the tools
+type gatewayObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		GatewayClassName string `json:"gatewayClassName"`
+		Listeners        []struct {
+			Name     string  `json:"name"`
+			Hostname *string `json:"hostname"`
+			Port     int32   `json:"port"`
+			Protocol string  `json:"protocol"`
+		} `json:"listeners"`
+	} `json:"spec"`
+	Status struct {
+		Addresses []struct {
+			Value string `json:"value"`
+		} `json:"addresses"`
+		Conditions []metav1.Condition `json:"conditions"`
+		Listeners  []struct {
+			Name           string             `json:"name"`
+			AttachedRoutes int32              `json:"attachedRoutes"`
+			Conditions     []metav1.Condition `json:"conditions"`
+		} `json:"listeners"`
+	} `json:"status"`
+}
+
+// httpRouteObject is the subset of the Gateway API HTTPRoute used by the tools
+type httpRouteObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		ParentRefs []httpRouteParentRef `json:"parentRefs"`
+		Hostnames  []string             `json:"hostnames"`
+		Rules      []struct {
+			Matches []struct {
+				Path *struct {
+					Type  *string `json:"type"`
+					Value *string `json:"value"`
+				} `json:"path"`
+			} `json:"matches"`
+			BackendRefs []struct {
+				Kind      *string `json:"kind"`
+				Namespace *string `json:"namespace"`
+				Name      string  `json:"name"`
+				Port      *int32  `json:"port"`
+				Weight    *int32  `json:"weight"`
+			} `json:"backendRefs"`
+		} `json:"rules"`
+	} `json:"spec"`
+	Status struct {
+		Parents []struct {
+			ParentRef  httpRouteParentRef `json:"parentRef"`
+			Conditions []metav1.Condition `json:"conditions"`
+		} `json:"parents"`
+	} `json:"status"`
+}
+
+type httpRouteParentRef struct {
+	Namespace   *string `json:"namespace"`
+	Name        string  `json:"name"`
+	SectionName *string `json:"sectionName"`
+}
+
+// GatewaysList lists the Gateway API Gateways with their listeners and conditions, in all namespaces if no namespace is provided
+func (k *Kubernetes) GatewaysList(ctx context.Context, namespace string) ([]Gateway, error) {
+	if !k.supportsGroupVersion(gatewayGVK.GroupVersion().String()) {
+		return nil, ErrGatewayAPINotInstalled
+	}
+	list, err := k.ResourcesList(ctx, gatewayGVK, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]Gateway, 0)
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		gateway := &gatewayObject{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, gateway); err != nil {
+			return nil, err
+		}
+		ret = append(ret, gatewaySummary(gateway))
+	}
+	return ret, nil
+}
+
+// HTTPRoutesList lists the Gateway API HTTPRoutes with their rules and parent attachment, in all namespaces if no namespace is provided
+func (k *Kubernetes) HTTPRoutesList(ctx context.Context, namespace string) ([]HTTPRoute, error) {
+	routes, err := k.httpRoutes(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]HTTPRoute, 0, len(routes))
+	for _, route := range routes {
+		ret = append(ret, httpRouteSummary(&route))
+	}
+	return ret, nil
+}
+
+func (k *Kubernetes) httpRoutes(ctx context.Context, namespace string) ([]httpRouteObject, error) {
+	if !k.supportsGroupVersion(httpRouteGVK.GroupVersion().String()) {
+		return nil, ErrGatewayAPINotInstalled
+	}
+	list, err := k.ResourcesList(ctx, httpRouteGVK, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var ret []httpRouteObject
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		route := httpRouteObject{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &route); err != nil {
+			return nil, err
+		}
+		ret = append(ret, route)
+	}
+	return ret, nil
+}
+
+func gatewaySummary(gateway *gatewayObject) Gateway {
+	ret := Gateway{Namespace: gateway.Namespace, Name: gateway.Name, Class: gateway.Spec.GatewayClassName}
+	for _, address := range gateway.Status.Addresses {
+		ret.Addresses = append(ret.Addresses, address.Value)
+	}
+	for _, condition := range gateway.Status.Conditions {
+		ret.Conditions = append(ret.Conditions, gatewayCondition(condition))
+		if !gatewayConditionHealthy(condition) {
+			ret.Issues = append(ret.Issues, fmt.Sprintf("gateway condition %s is %s: %s", condition.Type, condition.Status, condition.Message))
+		}
+	}
+	for _, listener := range gateway.Spec.Listeners {
+		l := GatewayListener{Name: listener.Name, Protocol: listener.Protocol, Port: listener.Port, Hostname: ptr.Deref(listener.Hostname, "")}
+		for _, status := range gateway.Status.Listeners {
+			if status.Name != listener.Name {
+				continue
+			}
+			l.AttachedRoutes = status.AttachedRoutes
+			for _, condition := range status.Conditions {
+				if !gatewayConditionHealthy(condition) {
+					ret.Issues = append(ret.Issues, fmt.Sprintf("listener %s condition %s is %s: %s", listener.Name, condition.Type, condition.Status, condition.Message))
+				}
+			}
+		}
+		ret.Listeners = append(ret.Listeners, l)
+	}
+	return ret
+}
+
+func httpRouteSummary(route *httpRouteObject) HTTPRoute {
+	ret := HTTPRoute{Namespace: route.Namespace, Name: route.Name, Hostnames: route.Spec.Hostnames}
+	for _, rule := range route.Spec.Rules {
+		var backends []string
+		for _, backendRef := range rule.BackendRefs {
+			backends = append(backends, httpRouteBackend(route.Namespace, backendRef.Kind, backendRef.Namespace, backendRef.Name, backendRef.Port))
+		}
+		for _, path := range httpRouteRulePaths(rule.Matches) {
+			ret.Rules = append(ret.Rules, fmt.Sprintf("%s (%s) -> %s", path[1], path[0], strings.Join(backends, ", ")))
+		}
+	}
+	for _, parentRef := range route.Spec.ParentRefs {
+		parent := HTTPRouteParent{Gateway: httpRouteParentName(route.Namespace, parentRef)}
+		found := false
+		for _, status := range route.Status.Parents {
+			if httpRouteParentName(route.Namespace, status.ParentRef) != parent.Gateway {
+				continue
+			}
+			found = true
+			for _, condition := range status.Conditions {
+				parent.Conditions = append(parent.Conditions, gatewayCondition(condition))
+				if condition.Type == "Accepted" && condition.Status == metav1.ConditionTrue {
+					parent.Accepted = true
+				}
+				if !gatewayConditionHealthy(condition) {
+					ret.Issues = append(ret.Issues, fmt.Sprintf("parent %s condition %s is %s: %s", parent.Gateway, condition.Type, condition.Status, condition.Message))
+				}
+			}
+		}
+		if !found {
+			ret.Issues = append(ret.Issues, fmt.Sprintf("parent %s has not reported the status of the route, check that the Gateway exists and its controller is running", parent.Gateway))
+		}
+		ret.Parents = append(ret.Parents, parent)
+	}
+	return ret
+}
+
+// httpRouteRulePaths returns the type and value of the path matches of a rule, rules without matches match all the paths
+func httpRouteRulePaths(matches []struct {
+	Path *struct {
+		Type  *string `json:"type"`
+		Value *string `json:"value"`
+	} `json:"path"`
+}) [][2]string {
+	var ret [][2]string
+	for _, match := range matches {
+		if match.Path == nil {
+			ret = append(ret, [2]string{"PathPrefix", "/"})
+			continue
+		}
+		ret = append(ret, [2]string{ptr.Deref(match.Path.Type, "PathPrefix"), ptr.Deref(match.Path.Value, "/")})
+	}
+	if len(ret) == 0 {
+		ret = append(ret, [2]string{"PathPrefix", "/"})
+	}
+	return ret
+}
+
+func httpRouteParentName(routeNamespace string, parentRef httpRouteParentRef) string {
+	name := ptr.Deref(parentRef.Namespace, routeNamespace) + "/" + parentRef.Name
+	if parentRef.SectionName != nil {
+		name += "/" + *parentRef.SectionName
+	}
+	return name
+}
+
+func httpRouteBackend(routeNamespace string, kind, namespace *string, name string, port *int32) string {
+	ret := name
+	if ns := ptr.Deref(namespace, routeNamespace); ns != routeNamespace {
+		ret = ns + "/" + name
+	}
+	if k := ptr.Deref(kind, "Service"); k != "Service" {
+		ret = k + " " + ret
+	}
+	if port != nil {
+		ret = fmt.Sprintf("%s:%d", ret, *port)
+	}
+	return ret
+}
+
+func gatewayCondition(condition metav1.Condition) GatewayCondition {
+	return GatewayCondition{Type: condition.Type, Status: string(condition.Status), Reason: condition.Reason, Message: condition.Message}
+}
+
+// gatewayConditionHealthy returns false for the positive polarity conditions (Accepted, Programmed, ResolvedRefs) that
+// are not True, and for the negative polarity conditions (Conflicted) that are True
+func gatewayConditionHealthy(condition metav1.Condition) bool {
+	switch condition.Type {
+	case "Accepted", "Programmed", "ResolvedRefs":
+		return condition.Status == metav1.ConditionTrue
+	case "Conflicted":
+		return condition.Status != metav1.ConditionTrue
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const GrafanaDefaultStart = "-1h"
+
+// GrafanaDashboard is a dashboard the grafana_links tool links to, with the variables of its scope
+type GrafanaDashboard struct {
+	Scope string
+	Title string
+	UID   string
+}
+
+// GrafanaDefaultDashboards are the kubernetes-mixin dashboards (provisioned by kube-prometheus-stack), from the narrowest scope to the broadest
+var GrafanaDefaultDashboards = []GrafanaDashboard{
+	{Scope: "pod", Title: "Kubernetes / Compute Resources / Pod", UID: "6581e46e4e5c7ba40a07646395ef7b23"},
+	{Scope: "workload", Title: "Kubernetes / Compute Resources / Workload", UID: "a164a7f0339f99e89cea5cb47e9be617"},
+	{Scope: "node", Title: "Kubernetes / Compute Resources / Node (Pods)", UID: "200ac8fdbfbb74b39aff88118e4d1c2c"},
+	{Scope: "namespace", Title: "Kubernetes / Compute Resources / Namespace (Pods)", UID: "85a562078cdf77779eaa1add43ccec1e"},
+	{Scope: "cluster", Title: "Kubernetes / Compute Resources / Cluster", UID: "efa86fd1d0c121a26444b636a3f509a8"},
+}
+
+var ErrGrafanaNotConfigured = errors.New("no Grafana is configured, configure its URL with grafana_url")
+
+// GrafanaLinksOptions are the objects and the time range of the links, the pod and the workload (kind and name) require the namespace
+type GrafanaLinksOptions struct {
+	Namespace string
+	Kind      string
+	Name      string
+	Pod       string
+	Node      string
+	// Start and End are the time range of the dashboards (RFC3339, unix timestamp, or relative to now, e.g. -1h), they default to the last hour
+	Start, End string
+}
+
+type GrafanaLink struct {
+	Scope string `json:"scope"`
+	// Dashboard is the title of the default dashboard of the scope, omitted if its UID is overridden
+	Dashboard string `json:"dashboard,omitempty"`
+	URL       string `json:"url"`
+}
+
+// GrafanaLinks returns the URLs of the dashboards of the configured Grafana showing the provided objects over the time range,
+// from the narrowest scope to the broadest (e.g. the pod, its namespace, and the cluster)
+func (k *Kubernetes) GrafanaLinks(options GrafanaLinksOptions) ([]GrafanaLink, error) {
+	if k.manager.staticConfig == nil || k.manager.staticConfig.GrafanaURL == "" {
+		return nil, ErrGrafanaNotConfigured
+	}
+	if (options.Pod != "" || options.Name != "") && options.Namespace == "" {
+		options.Namespace = k.NamespaceOrDefault("")
+	}
+	return grafanaLinks(k.manager.staticConfig.GrafanaURL, k.manager.staticConfig.GrafanaDashboards, options, time.Now())
+}
+
+func grafanaLinks(grafanaURL string, dashboards map[string]string, options GrafanaLinksOptions, now time.Time) ([]GrafanaLink, error) {
+	base, err := url.Parse(grafanaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grafana_url: %w", err)
+	}
+	if (options.Kind == "") != (options.Name == "") {
+		return nil, errors.New("the kind and the name of the workload must be provided together")
+	}
+	from, err := grafanaTime(options.Start, GrafanaDefaultStart, now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start: %w", err)
+	}
+	to, err := grafanaTime(options.End, "now", now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end: %w", err)
+	}
+	// The variables of the dashboards of each scope, a scope without variables isn't linked unless it's the cluster one
+	variables := map[string]url.Values{"cluster": {}}
+	if options.Pod != "" {
+		variables["pod"] = url.Values{"var-namespace": {options.Namespace}, "var-pod": {options.Pod}}
+	}
+	if options.Name != "" {
+		variables["workload"] = url.Values{"var-namespace": {options.Namespace}, "var-type": {strings.ToLower(options.Kind)}, "var-workload": {options.Name}}
+	}
+	if options.Node != "" {
+		variables["node"] = url.Values{"var-node": {options.Node}}
+	}
+	if options.Namespace != "" {
+		variables["namespace"] = url.Values{"var-namespace": {options.Namespace}}
+	}
+	var ret []GrafanaLink
+	for _, dashboard := range GrafanaDefaultDashboards {
+		query, ok := variables[dashboard.Scope]
+		if !ok {
+			continue
+		}
+		uid, title := dashboard.UID, dashboard.Title
+		if override, overridden := dashboards[dashboard.Scope]; overridden && override != uid {
+			uid, title = override, ""
+		}
+		if uid == "" {
+			continue
+		}
+		query.Set("from", from)
+		query.Set("to", to)
+		link := base.JoinPath("d", uid)
+		link.RawQuery = query.Encode()
+		ret = append(ret, GrafanaLink{Scope: dashboard.Scope, Dashboard: title, URL: link.String()})
+	}
+	return ret, nil
+}
+
+// grafanaTime converts a time (RFC3339, unix timestamp, or relative to now) to the from and to parameters of Grafana,
+// the relative times are kept relative (e.g. now-1h) so that the links stay meaningful
+func grafanaTime(value, defaultValue string, now time.Time) (string, error) {
+	if value == "" {
+		value = defaultValue
+	}
+	if relative, ok := strings.CutPrefix(value, "now-"); ok {
+		value = "-" + relative
+	}
+	t, err := parsePromQLTime(value, now)
+	if err != nil {
+		return "", err
+	}
+	if value == "now" {
+		return value, nil
+	}
+	if relative, ok := strings.CutPrefix(value, "-"); ok {
+		return "now-" + relative, nil
+	}
+	return strconv.FormatInt(t.UnixMilli(), 10), nil
+}
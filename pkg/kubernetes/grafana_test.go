@@ -0,0 +1,62 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGrafanaLinks(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	t.Run("links the pod, its namespace, and the cluster dashboards", func(t *testing.T) {
+		links, err := grafanaLinks("https://grafana.example.com/", nil, GrafanaLinksOptions{Namespace: "ns-1", Pod: "web-1"}, now)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		expected := []string{
+			"https://grafana.example.com/d/6581e46e4e5c7ba40a07646395ef7b23?from=now-1h&to=now&var-namespace=ns-1&var-pod=web-1",
+			"https://grafana.example.com/d/85a562078cdf77779eaa1add43ccec1e?from=now-1h&to=now&var-namespace=ns-1",
+			"https://grafana.example.com/d/efa86fd1d0c121a26444b636a3f509a8?from=now-1h&to=now",
+		}
+		if len(links) != len(expected) {
+			t.Fatalf("expected %d links, got %v", len(expected), links)
+		}
+		for i, e := range expected {
+			if links[i].URL != e {
+				t.Errorf("expected link %d to be %s, got %s", i, e, links[i].URL)
+			}
+		}
+		if links[0].Scope != "pod" || links[0].Dashboard != "Kubernetes / Compute Resources / Pod" {
+			t.Errorf("unexpected pod link %+v", links[0])
+		}
+	})
+	t.Run("links the workload and the node with an absolute time range", func(t *testing.T) {
+		links, err := grafanaLinks("https://example.com/grafana", map[string]string{"workload": "my-workloads", "cluster": ""},
+			GrafanaLinksOptions{Namespace: "ns-1", Kind: "Deployment", Name: "web", Node: "node-1", Start: "2026-01-01T10:00:00Z", End: "now-30m"}, now)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		expected := []string{
+			"https://example.com/grafana/d/my-workloads?from=1767261600000&to=now-30m&var-namespace=ns-1&var-type=deployment&var-workload=web",
+			"https://example.com/grafana/d/200ac8fdbfbb74b39aff88118e4d1c2c?from=1767261600000&to=now-30m&var-node=node-1",
+			"https://example.com/grafana/d/85a562078cdf77779eaa1add43ccec1e?from=1767261600000&to=now-30m&var-namespace=ns-1",
+		}
+		if len(links) != len(expected) {
+			t.Fatalf("expected %d links, got %v", len(expected), links)
+		}
+		for i, e := range expected {
+			if links[i].URL != e {
+				t.Errorf("expected link %d to be %s, got %s", i, e, links[i].URL)
+			}
+		}
+		if links[0].Dashboard != "" {
+			t.Errorf("expected no title for the overridden workload dashboard, got %s", links[0].Dashboard)
+		}
+	})
+	t.Run("validates the options", func(t *testing.T) {
+		for _, options := range []GrafanaLinksOptions{{Kind: "Deployment"}, {Start: "yesterday"}, {End: "-1y"}} {
+			if _, err := grafanaLinks("https://grafana.example.com", nil, options, now); err == nil {
+				t.Errorf("expected an error for %+v", options)
+			}
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+)
+
+// CheckAPIServer verifies the connectivity to the API server
+func (m *Manager) CheckAPIServer(ctx context.Context) error {
+	if err := m.accessControlClientSet.DiscoveryClient().RESTClient().Get().AbsPath("/version").Do(ctx).Error(); err != nil {
+		return fmt.Errorf("API server %s is not reachable: %w", m.GetAPIServerHost(), err)
+	}
+	return nil
+}
+
+// CheckDiscovery verifies the cached discovery information is populated, it's refreshed if it was invalidated
+// (e.g. by a capability probe) so that the tools resolve the resource types against the current API groups
+func (m *Manager) CheckDiscovery(_ context.Context) error {
+	if m.discoveryClient.Fresh() {
+		return nil
+	}
+	if _, err := m.discoveryClient.ServerGroups(); err != nil {
+		return fmt.Errorf("failed to refresh the discovery cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+var (
+	ClusterDeploymentGVK = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "ClusterDeployment"}
+	ClusterPoolGVK       = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "ClusterPool"}
+	ClusterClaimGVK      = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "ClusterClaim"}
+	ClusterProvisionGVK  = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "ClusterProvision"}
+)
+
+// hiveFailureConditions are the ClusterDeployment condition types that signal a provisioning problem when their status is True
+var hiveFailureConditions = map[string]bool{
+	"ProvisionFailed":                 true,
+	"ProvisionStopped":                true,
+	"InstallLaunchError":              true,
+	"InstallImagesNotResolved":        true,
+	"DNSNotReady":                     true,
+	"AuthenticationFailure":           true,
+	"DeprovisionLaunchError":          true,
+	"ControlPlaneCertificateNotFound": true,
+	"SyncSetFailed":                   true,
+	"Unreachable":                     true,
+}
+
+func (k *Kubernetes) ClusterDeploymentsList(ctx context.Context, namespace string, options ResourceListOptions) (runtime.Unstructured, error) {
+	return k.ResourcesList(ctx, &ClusterDeploymentGVK, namespace, options)
+}
+
+func (k *Kubernetes) ClusterPoolsList(ctx context.Context, namespace string, options ResourceListOptions) (runtime.Unstructured, error) {
+	return k.ResourcesList(ctx, &ClusterPoolGVK, namespace, options)
+}
+
+func (k *Kubernetes) ClusterClaimsList(ctx context.Context, namespace string, options ResourceListOptions) (runtime.Unstructured, error) {
+	return k.ResourcesList(ctx, &ClusterClaimGVK, namespace, options)
+}
+
+// ClusterDeploymentStatus returns a summary of the provisioning status of a Hive ClusterDeployment
+// including the failure conditions and the current ClusterProvision stage (if any).
+func (k *Kubernetes) ClusterDeploymentStatus(ctx context.Context, namespace, name string) (map[string]any, error) {
+	cd, err := k.ResourcesGet(ctx, &ClusterDeploymentGVK, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	status := ClusterDeploymentSummary(cd)
+	if provisionName, _, _ := unstructured.NestedString(cd.Object, "status", "provisionRef", "name"); provisionName != "" {
+		provision, err := k.ResourcesGet(ctx, &ClusterProvisionGVK, cd.GetNamespace(), provisionName)
+		if err == nil {
+			stage, _, _ := unstructured.NestedString(provision.Object, "spec", "stage")
+			attempt, _, _ := unstructured.NestedInt64(provision.Object, "spec", "attempt")
+			status["provision"] = map[string]any{
+				"name":    provisionName,
+				"stage":   stage,
+				"attempt": attempt,
+			}
+		}
+	}
+	return status, nil
+}
+
+// ClusterDeploymentSummary extracts the relevant provisioning information from a Hive ClusterDeployment
+func ClusterDeploymentSummary(cd *unstructured.Unstructured) map[string]any {
+	installed, _, _ := unstructured.NestedBool(cd.Object, "spec", "installed")
+	baseDomain, _, _ := unstructured.NestedString(cd.Object, "spec", "baseDomain")
+	clusterName, _, _ := unstructured.NestedString(cd.Object, "spec", "clusterName")
+	powerState, _, _ := unstructured.NestedString(cd.Object, "status", "powerState")
+	apiURL, _, _ := unstructured.NestedString(cd.Object, "status", "apiURL")
+	pool, _, _ := unstructured.NestedString(cd.Object, "spec", "clusterPoolRef", "poolName")
+	claim, _, _ := unstructured.NestedString(cd.Object, "spec", "clusterPoolRef", "claimName")
+	summary := map[string]any{
+		"name":        cd.GetName(),
+		"namespace":   cd.GetNamespace(),
+		"clusterName": clusterName,
+		"baseDomain":  baseDomain,
+		"installed":   installed,
+		"powerState":  powerState,
+		"apiURL":      apiURL,
+	}
+	if pool != "" {
+		summary["clusterPool"] = pool
+		summary["clusterClaim"] = claim
+	}
+	if platform, found, _ := unstructured.NestedMap(cd.Object, "spec", "platform"); found {
+		for p := range platform {
+			summary["platform"] = p
+		}
+	}
+	conditions, _, _ := unstructured.NestedSlice(cd.Object, "status", "conditions")
+	var failures []map[string]any
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if hiveFailureConditions[fmt.Sprint(condition["type"])] && condition["status"] == string(metav1.ConditionTrue) {
+			failures = append(failures, map[string]any{
+				"type":    condition["type"],
+				"reason":  condition["reason"],
+				"message": condition["message"],
+			})
+		}
+	}
+	if len(failures) > 0 {
+		summary["failureConditions"] = failures
+	}
+	return summary
+}
+
+// ClusterClaimCreate claims a cluster from the provided Hive ClusterPool
+func (k *Kubernetes) ClusterClaimCreate(ctx context.Context, namespace, pool, name, lifetime string) ([]*unstructured.Unstructured, error) {
+	if name == "" {
+		name = pool + "-" + rand.String(5)
+	}
+	spec := map[string]interface{}{
+		"clusterPoolName": pool,
+	}
+	if lifetime != "" {
+		spec["lifetime"] = lifetime
+	}
+	claim := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": ClusterClaimGVK.GroupVersion().String(),
+		"kind":       ClusterClaimGVK.Kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": k.NamespaceOrDefault(namespace),
+		},
+		"spec": spec,
+	}}
+	return k.resourcesCreateOrUpdate(ctx, []*unstructured.Unstructured{claim})
+}
+
+// ClusterClaimDelete releases a claimed cluster back to Hive by deleting its ClusterClaim
+func (k *Kubernetes) ClusterClaimDelete(ctx context.Context, namespace, name string) error {
+	return k.ResourcesDelete(ctx, &ClusterClaimGVK, namespace, name)
+}
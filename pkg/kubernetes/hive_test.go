@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestClusterDeploymentSummary(t *testing.T) {
+	cd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "hive.openshift.io/v1",
+		"kind":       "ClusterDeployment",
+		"metadata": map[string]interface{}{
+			"name":      "spoke-1",
+			"namespace": "spoke-1",
+		},
+		"spec": map[string]interface{}{
+			"clusterName": "spoke-1",
+			"baseDomain":  "example.com",
+			"installed":   false,
+			"platform":    map[string]interface{}{"aws": map[string]interface{}{"region": "us-east-1"}},
+			"clusterPoolRef": map[string]interface{}{
+				"poolName":  "aws-pool",
+				"claimName": "aws-pool-abcde",
+			},
+		},
+		"status": map[string]interface{}{
+			"powerState": "Running",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "ProvisionFailed", "status": "True", "reason": "InstallerFailed", "message": "install failed"},
+				map[string]interface{}{"type": "DNSNotReady", "status": "False"},
+				map[string]interface{}{"type": "Hibernating", "status": "True"},
+			},
+		},
+	}}
+	summary := ClusterDeploymentSummary(cd)
+	t.Run("extracts spec fields", func(t *testing.T) {
+		if summary["clusterName"] != "spoke-1" || summary["baseDomain"] != "example.com" || summary["installed"] != false {
+			t.Errorf("unexpected summary: %v", summary)
+		}
+	})
+	t.Run("extracts platform", func(t *testing.T) {
+		if summary["platform"] != "aws" {
+			t.Errorf("expected platform aws, got %v", summary["platform"])
+		}
+	})
+	t.Run("extracts cluster pool reference", func(t *testing.T) {
+		if summary["clusterPool"] != "aws-pool" || summary["clusterClaim"] != "aws-pool-abcde" {
+			t.Errorf("unexpected cluster pool reference: %v/%v", summary["clusterPool"], summary["clusterClaim"])
+		}
+	})
+	t.Run("reports only true failure conditions", func(t *testing.T) {
+		failures, ok := summary["failureConditions"].([]map[string]any)
+		if !ok || len(failures) != 1 {
+			t.Fatalf("expected 1 failure condition, got %v", summary["failureConditions"])
+		}
+		if failures[0]["type"] != "ProvisionFailed" || failures[0]["reason"] != "InstallerFailed" {
+			t.Errorf("unexpected failure condition: %v", failures[0])
+		}
+	})
+	t.Run("omits failure conditions for healthy deployments", func(t *testing.T) {
+		healthy := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{"installed": true}}}
+		if _, ok := ClusterDeploymentSummary(healthy)["failureConditions"]; ok {
+			t.Errorf("expected no failure conditions")
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Image is an image of the inventory with the workload containers using it
+type Image struct {
+	Image string `json:"image"`
+	// PullPolicies are the distinct pull policies of the containers using the image
+	PullPolicies []string        `json:"pullPolicies,omitempty"`
+	Workloads    []ImageWorkload `json:"workloads"`
+}
+
+// ImageWorkload is a container of a workload using an image
+type ImageWorkload struct {
+	// Image is omitted from the output, the workloads are grouped by image
+	Image      string `json:"-"`
+	Cluster    string `json:"cluster,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Container  string `json:"container"`
+	Init       bool   `json:"init,omitempty"`
+	PullPolicy string `json:"pullPolicy,omitempty"`
+	// ImagePullSecrets is whether the pod template references imagePullSecrets (the ones of the ServiceAccount are not included)
+	ImagePullSecrets bool `json:"imagePullSecrets"`
+}
+
+// ImagesList returns the image of each container of the Deployments, StatefulSets, DaemonSets, CronJobs, and standalone Jobs and Pods
+// of the namespace (all namespaces if empty). The pods and jobs created by these workloads are not listed again.
+func ImagesList(ctx context.Context, list ResourcesLister, namespace string) ([]ImageWorkload, error) {
+	templates, err := workloadTemplates(ctx, list, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var ret []ImageWorkload
+	for _, template := range templates {
+		ret = append(ret, imageWorkloads(template)...)
+	}
+	return ret, nil
+}
+
+// ImagesInventory deduplicates the images of the workloads, sorted by image
+func ImagesInventory(workloads []ImageWorkload) []Image {
+	byImage := make(map[string]*Image)
+	for _, workload := range workloads {
+		image := byImage[workload.Image]
+		if image == nil {
+			image = &Image{Image: workload.Image}
+			byImage[workload.Image] = image
+		}
+		if workload.PullPolicy != "" && !slices.Contains(image.PullPolicies, workload.PullPolicy) {
+			image.PullPolicies = append(image.PullPolicies, workload.PullPolicy)
+		}
+		image.Workloads = append(image.Workloads, workload)
+	}
+	ret := make([]Image, 0, len(byImage))
+	for _, image := range byImage {
+		slices.Sort(image.PullPolicies)
+		ret = append(ret, *image)
+	}
+	slices.SortFunc(ret, func(a, b Image) int { return strings.Compare(a.Image, b.Image) })
+	return ret
+}
+
+func imageWorkloads(template workloadTemplate) []ImageWorkload {
+	var ret []ImageWorkload
+	add := func(container v1.Container, init bool) {
+		ret = append(ret, ImageWorkload{
+			Image:            container.Image,
+			Namespace:        template.namespace,
+			Kind:             template.kind,
+			Name:             template.name,
+			Container:        container.Name,
+			Init:             init,
+			PullPolicy:       string(container.ImagePullPolicy),
+			ImagePullSecrets: len(template.spec.ImagePullSecrets) > 0,
+		})
+	}
+	for _, container := range template.spec.InitContainers {
+		add(container, true)
+	}
+	for _, container := range template.spec.Containers {
+		add(container, false)
+	}
+	return ret
+}
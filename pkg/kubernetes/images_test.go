@@ -0,0 +1,50 @@
+package kubernetes
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestImagesInventory(t *testing.T) {
+	var workloads []ImageWorkload
+	for _, template := range []workloadTemplate{
+		{namespace: "ns-1", kind: "Deployment", name: "web", spec: &v1.PodSpec{
+			InitContainers:   []v1.Container{{Name: "migrate", Image: "registry.example.com/app:1.0", ImagePullPolicy: v1.PullIfNotPresent}},
+			Containers:       []v1.Container{{Name: "nginx", Image: "nginx:1.27", ImagePullPolicy: v1.PullAlways}},
+			ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry"}},
+		}},
+		{namespace: "ns-2", kind: "Pod", name: "debug", spec: &v1.PodSpec{
+			Containers: []v1.Container{{Name: "shell", Image: "nginx:1.27", ImagePullPolicy: v1.PullIfNotPresent}},
+		}},
+	} {
+		workloads = append(workloads, imageWorkloads(template)...)
+	}
+	inventory := ImagesInventory(workloads)
+	t.Run("deduplicates images sorted by image", func(t *testing.T) {
+		if len(inventory) != 2 || inventory[0].Image != "nginx:1.27" || inventory[1].Image != "registry.example.com/app:1.0" {
+			t.Fatalf("unexpected inventory %v", inventory)
+		}
+	})
+	t.Run("reports the workloads using the image", func(t *testing.T) {
+		if len(inventory[0].Workloads) != 2 {
+			t.Fatalf("expected 2 workloads, got %v", inventory[0].Workloads)
+		}
+		if w := inventory[0].Workloads[0]; w.Namespace != "ns-1" || w.Kind != "Deployment" || w.Name != "web" || w.Container != "nginx" || !w.ImagePullSecrets {
+			t.Errorf("unexpected workload %v", w)
+		}
+		if w := inventory[0].Workloads[1]; w.Name != "debug" || w.ImagePullSecrets {
+			t.Errorf("unexpected workload %v", w)
+		}
+	})
+	t.Run("reports the distinct pull policies", func(t *testing.T) {
+		if policies := inventory[0].PullPolicies; len(policies) != 2 || policies[0] != "Always" || policies[1] != "IfNotPresent" {
+			t.Errorf("unexpected pull policies %v", policies)
+		}
+	})
+	t.Run("reports init containers", func(t *testing.T) {
+		if w := inventory[1].Workloads[0]; !w.Init || w.Container != "migrate" {
+			t.Errorf("expected init container, got %v", w)
+		}
+	})
+}
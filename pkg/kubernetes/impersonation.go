@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/v2"
+)
+
+// ImpersonatedIdentity is the identity of an MCP client validated by the OIDC provider.
+// The Kubernetes requests of the client are performed with the server credentials impersonating this identity.
+type ImpersonatedIdentity struct {
+	UserName string
+	Groups   []string
+}
+
+type impersonatedIdentityKey struct{}
+
+// WithImpersonatedIdentity returns a context carrying the identity the Kubernetes requests must impersonate,
+// it takes precedence over the bearer token of the request
+func WithImpersonatedIdentity(ctx context.Context, identity *ImpersonatedIdentity) context.Context {
+	return context.WithValue(ctx, impersonatedIdentityKey{}, identity)
+}
+
+// impersonated returns a Kubernetes client performing the requests with the server credentials impersonating the
+// provided identity, the server identity must be allowed to impersonate users and groups
+func (m *Manager) impersonated(identity *ImpersonatedIdentity) (*Kubernetes, error) {
+	klog.V(5).Infof("Impersonating user %s (groups %v)", identity.UserName, identity.Groups)
+	impersonate := rest.ImpersonationConfig{UserName: identity.UserName, Groups: identity.Groups}
+	cfg := rest.CopyConfig(m.cfg)
+	cfg.Impersonate = impersonate
+	rawConfig, err := m.clientCmdConfig.RawConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	derived := &Manager{
+		clientCmdConfig: clientcmd.NewDefaultClientConfig(rawConfig, &clientcmd.ConfigOverrides{
+			AuthInfo: clientcmdapi.AuthInfo{Impersonate: impersonate.UserName, ImpersonateGroups: impersonate.Groups},
+		}),
+		cfg:          cfg,
+		staticConfig: m.staticConfig,
+	}
+	derived.accessControlClientSet, err = NewAccessControlClientset(derived.cfg, derived.staticConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize impersonating client: %w", err)
+	}
+	derived.discoveryClient = memory.NewMemCacheClient(derived.accessControlClientSet.DiscoveryClient())
+	derived.accessControlRESTMapper = NewAccessControlRESTMapper(
+		restmapper.NewDeferredDiscoveryRESTMapper(derived.discoveryClient),
+		derived.staticConfig,
+	)
+	derived.dynamicClient, err = dynamic.NewForConfig(derived.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize impersonating dynamic client: %w", err)
+	}
+	return &Kubernetes{manager: derived}, nil
+}
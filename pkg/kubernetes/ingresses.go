@@ -0,0 +1,119 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+var ingressGVK = &schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+
+// Ingress is the summary of an Ingress with its routing rules described in plain text
+type Ingress struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Class     string `json:"class,omitempty"`
+	// Rules are the routing rules as host/path (pathType) -> service:port
+	Rules          []string `json:"rules,omitempty"`
+	DefaultBackend string   `json:"defaultBackend,omitempty"`
+	TLSHosts       []string `json:"tlsHosts,omitempty"`
+	// Addresses are the load balancer addresses assigned by the ingress controller
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// IngressesList lists the Ingresses with their routing rules, in all namespaces if no namespace is provided
+func (k *Kubernetes) IngressesList(ctx context.Context, namespace string) ([]Ingress, error) {
+	ingresses, err := k.ingresses(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]Ingress, 0, len(ingresses))
+	for _, ingress := range ingresses {
+		ret = append(ret, ingressSummary(&ingress))
+	}
+	return ret, nil
+}
+
+func (k *Kubernetes) ingresses(ctx context.Context, namespace string) ([]networkingv1.Ingress, error) {
+	list, err := k.ResourcesList(ctx, ingressGVK, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var ret []networkingv1.Ingress
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		ingress := networkingv1.Ingress{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &ingress); err != nil {
+			return nil, err
+		}
+		ret = append(ret, ingress)
+	}
+	return ret, nil
+}
+
+func ingressSummary(ingress *networkingv1.Ingress) Ingress {
+	ret := Ingress{
+		Namespace: ingress.Namespace,
+		Name:      ingress.Name,
+		Class:     ptr.Deref(ingress.Spec.IngressClassName, ingress.Annotations["kubernetes.io/ingress.class"]),
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		for _, path := range rule.HTTP.Paths {
+			ret.Rules = append(ret.Rules, fmt.Sprintf("%s%s (%s) -> %s", host, path.Path,
+				ptr.Deref(path.PathType, networkingv1.PathTypeImplementationSpecific), ingressBackend(&path.Backend)))
+		}
+	}
+	if ingress.Spec.DefaultBackend != nil {
+		ret.DefaultBackend = ingressBackend(ingress.Spec.DefaultBackend)
+	}
+	for _, tls := range ingress.Spec.TLS {
+		ret.TLSHosts = append(ret.TLSHosts, tls.Hosts...)
+	}
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		ret.Addresses = append(ret.Addresses, lb.IP+lb.Hostname)
+	}
+	return ret
+}
+
+func ingressBackend(backend *networkingv1.IngressBackend) string {
+	switch {
+	case backend.Service != nil && backend.Service.Port.Name != "":
+		return backend.Service.Name + ":" + backend.Service.Port.Name
+	case backend.Service != nil:
+		return fmt.Sprintf("%s:%d", backend.Service.Name, backend.Service.Port.Number)
+	case backend.Resource != nil:
+		return fmt.Sprintf("%s %s", backend.Resource.Kind, backend.Resource.Name)
+	}
+	return ""
+}
+
+// hostMatches returns true if the host matches the hostname of a rule, empty hostnames match all the hosts and
+// wildcard hostnames (*.example.com) match a single DNS label
+func hostMatches(hostname, host string) bool {
+	switch {
+	case hostname == "" || hostname == "*":
+		return true
+	case strings.HasPrefix(hostname, "*."):
+		prefix, suffix, found := strings.Cut(host, ".")
+		return found && prefix != "" && suffix == hostname[2:]
+	}
+	return strings.EqualFold(hostname, host)
+}
+
+// pathPrefixMatches returns true if the path matches the prefix element-wise (/foo matches /foo and /foo/bar but not /foobar)
+func pathPrefixMatches(prefix, path string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/")
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/helm"
+	"github.com/containers/kubernetes-mcp-server/pkg/tracing"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
 )
@@ -51,6 +52,8 @@ type Manager struct {
 
 	staticConfig         *config.StaticConfig
 	CloseWatchKubeConfig CloseWatchKubeConfig
+	// metricsHistory holds the samples of the Pods metrics if the sampling is enabled
+	metricsHistory *metricsHistory
 }
 
 var _ helm.Kubernetes = (*Manager)(nil)
@@ -70,6 +73,7 @@ func NewManager(config *config.StaticConfig) (*Manager, error) {
 	//k8s.cfg.Wrap(func(original http.RoundTripper) http.RoundTripper {
 	//	return &impersonateRoundTripper{original}
 	//})
+	k8s.cfg.Wrap(tracing.WrapKubernetesTransport)
 	var err error
 	k8s.accessControlClientSet, err = NewAccessControlClientset(k8s.cfg, k8s.staticConfig)
 	if err != nil {
@@ -127,6 +131,7 @@ func (m *Manager) Close() {
 	if m.CloseWatchKubeConfig != nil {
 		_ = m.CloseWatchKubeConfig()
 	}
+	m.StopMetricsHistory()
 }
 
 func (m *Manager) GetAPIServerHost() string {
@@ -136,6 +141,22 @@ func (m *Manager) GetAPIServerHost() string {
 	return m.cfg.Host
 }
 
+// GetBearerToken returns the bearer token from the configuration
+func (m *Manager) GetBearerToken() string {
+	if m.cfg == nil {
+		return ""
+	}
+	return m.cfg.BearerToken
+}
+
+// GetImpersonation returns the identity the requests impersonate, empty unless the client impersonates the OIDC user of the MCP client
+func (m *Manager) GetImpersonation() rest.ImpersonationConfig {
+	if m.cfg == nil {
+		return rest.ImpersonationConfig{}
+	}
+	return m.cfg.Impersonate
+}
+
 func (m *Manager) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
 	return m.discoveryClient, nil
 }
@@ -145,6 +166,9 @@ func (m *Manager) ToRESTMapper() (meta.RESTMapper, error) {
 }
 
 func (m *Manager) Derived(ctx context.Context) (*Kubernetes, error) {
+	if identity, ok := ctx.Value(impersonatedIdentityKey{}).(*ImpersonatedIdentity); ok {
+		return m.impersonated(identity)
+	}
 	authorization, ok := ctx.Value(OAuthAuthorizationHeader).(string)
 	if !ok || !strings.HasPrefix(authorization, "Bearer ") {
 		if m.staticConfig.RequireOAuth {
@@ -171,6 +195,7 @@ func (m *Manager) Derived(ctx context.Context) (*Kubernetes, error) {
 		Timeout:     m.cfg.Timeout,
 		Impersonate: rest.ImpersonationConfig{},
 	}
+	derivedCfg.Wrap(tracing.WrapKubernetesTransport)
 	clientCmdApiConfig, err := m.clientCmdConfig.RawConfig()
 	if err != nil {
 		if m.staticConfig.RequireOAuth {
@@ -221,8 +246,10 @@ func (k *Kubernetes) GetAPIServerHost() string {
 
 // GetBearerToken returns the bearer token from the configuration
 func (k *Kubernetes) GetBearerToken() string {
-	if k.manager.cfg == nil {
-		return ""
-	}
-	return k.manager.cfg.BearerToken
+	return k.manager.GetBearerToken()
+}
+
+// GetImpersonation returns the identity the requests impersonate, if any
+func (k *Kubernetes) GetImpersonation() rest.ImpersonationConfig {
+	return k.manager.GetImpersonation()
 }
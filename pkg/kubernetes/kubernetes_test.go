@@ -313,4 +313,44 @@ users:
 			t.Errorf("expected BearerToken %s, got %s", testBearerToken, derivedCfg.BearerToken)
 		}
 	})
+
+	t.Run("with impersonated identity creates derived manager impersonating it with the server credentials", func(t *testing.T) {
+		testStaticConfig := &config.StaticConfig{
+			KubeConfig:   kubeconfigPath,
+			RequireOAuth: true,
+		}
+
+		testManager, err := NewManager(testStaticConfig)
+		if err != nil {
+			t.Fatalf("failed to create manager: %v", err)
+		}
+		defer testManager.Close()
+		ctx := context.WithValue(context.Background(), OAuthAuthorizationHeader, "Bearer test-bearer-token-123")
+		ctx = WithImpersonatedIdentity(ctx, &ImpersonatedIdentity{UserName: "oidc:alice", Groups: []string{"oidc:admins"}})
+		derived, err := testManager.Derived(ctx)
+		if err != nil {
+			t.Fatalf("failed to create manager: %v", err)
+		}
+
+		if derived.manager == testManager {
+			t.Error("expected new derived manager, got original manager")
+		}
+
+		derivedCfg := derived.manager.cfg
+		if derivedCfg.Impersonate.UserName != "oidc:alice" {
+			t.Errorf("expected impersonated user oidc:alice, got %s", derivedCfg.Impersonate.UserName)
+		}
+		if len(derivedCfg.Impersonate.Groups) != 1 || derivedCfg.Impersonate.Groups[0] != "oidc:admins" {
+			t.Errorf("expected impersonated groups [oidc:admins], got %v", derivedCfg.Impersonate.Groups)
+		}
+		if derivedCfg.BearerToken != "" {
+			t.Errorf("expected the client token not to be passed through, got %s", derivedCfg.BearerToken)
+		}
+		if derivedCfg.Username != testManager.cfg.Username || derivedCfg.Password != testManager.cfg.Password {
+			t.Error("expected the server credentials to be kept")
+		}
+		if testManager.cfg.Impersonate.UserName != "" {
+			t.Error("expected the original manager not to be modified")
+		}
+	})
 }
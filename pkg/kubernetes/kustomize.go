@@ -0,0 +1,131 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// kustomizeConfigMapPathSeparator replaces the path separator in the keys of the ConfigMaps storing a kustomization,
+// ConfigMap keys can't contain slashes (e.g. overlays__prod__kustomization.yaml is overlays/prod/kustomization.yaml)
+const kustomizeConfigMapPathSeparator = "__"
+
+var configMapGVK = &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"}
+
+// KustomizeBuildOptions are the sources of the kustomization to render and whether to apply the result
+type KustomizeBuildOptions struct {
+	// Files are the contents of the kustomization files by their path relative to the root of the kustomization
+	Files map[string]string
+	// ConfigMapNamespace and ConfigMapName identify the ConfigMap storing the kustomization files, used if no Files are provided
+	ConfigMapNamespace string
+	ConfigMapName      string
+	// Path is the directory of the kustomization to build relative to the root (e.g. overlays/prod), the root if not provided
+	Path string
+	// Apply server-side applies the rendered objects
+	Apply bool
+	ApplyOptions
+}
+
+// KustomizeBuild is the rendered kustomization and the apply results of its objects if it was applied
+type KustomizeBuild struct {
+	Rendered string
+	Results  []ApplyResult
+}
+
+// KustomizeBuild renders a kustomization provided inline or stored in a ConfigMap, and optionally server-side applies the rendered objects.
+// Plugins are disabled and the files can't be loaded from outside the root of the kustomization.
+func (k *Kubernetes) KustomizeBuild(ctx context.Context, options KustomizeBuildOptions) (*KustomizeBuild, error) {
+	files := options.Files
+	if len(files) == 0 {
+		if options.ConfigMapName == "" {
+			return nil, errors.New("either the kustomization files or the ConfigMap storing them must be provided")
+		}
+		var err error
+		if files, err = k.kustomizeConfigMapFiles(ctx, options.ConfigMapNamespace, options.ConfigMapName); err != nil {
+			return nil, err
+		}
+	}
+	rendered, err := kustomizeBuild(files, options.Path)
+	if err != nil {
+		return nil, err
+	}
+	ret := &KustomizeBuild{Rendered: rendered}
+	if options.Apply && strings.TrimSpace(rendered) != "" {
+		if ret.Results, err = k.ResourcesCreateOrUpdate(ctx, rendered, options.ApplyOptions); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func (k *Kubernetes) kustomizeConfigMapFiles(ctx context.Context, namespace, name string) (map[string]string, error) {
+	item, err := k.ResourcesGet(ctx, configMapGVK, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	configMap := &v1.ConfigMap{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, configMap); err != nil {
+		return nil, err
+	}
+	files := make(map[string]string, len(configMap.Data)+len(configMap.BinaryData))
+	for key, value := range configMap.Data {
+		files[strings.ReplaceAll(key, kustomizeConfigMapPathSeparator, "/")] = value
+	}
+	for key, value := range configMap.BinaryData {
+		files[strings.ReplaceAll(key, kustomizeConfigMapPathSeparator, "/")] = string(value)
+	}
+	return files, nil
+}
+
+// kustomizeBuild renders the kustomization in the directory of the in-memory files
+func kustomizeBuild(files map[string]string, dir string) (string, error) {
+	fs := filesys.MakeFsInMemory()
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		cleaned, err := kustomizePath(p)
+		if err != nil {
+			return "", err
+		}
+		if err = fs.MkdirAll(path.Dir(cleaned)); err != nil {
+			return "", err
+		}
+		if err = fs.WriteFile(cleaned, []byte(files[p])); err != nil {
+			return "", err
+		}
+	}
+	root, err := kustomizePath(dir)
+	if err != nil {
+		return "", err
+	}
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fs, root)
+	if err != nil {
+		return "", err
+	}
+	rendered, err := resMap.AsYaml()
+	if err != nil {
+		return "", err
+	}
+	return string(rendered), nil
+}
+
+// kustomizePath returns the absolute in-memory path of a path relative to the root of the kustomization
+func kustomizePath(p string) (string, error) {
+	cleaned := path.Clean("/" + p)
+	if strings.HasPrefix(path.Clean(p), "..") {
+		return "", fmt.Errorf("path %s is outside the root of the kustomization", p)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,37 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKustomizeBuild(t *testing.T) {
+	files := map[string]string{
+		"base/kustomization.yaml": "resources:\n- configmap.yaml\n",
+		"base/configmap.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\ndata:\n  env: dev\n",
+		"overlays/prod/kustomization.yaml": "resources:\n- ../../base\nnamePrefix: prod-\nnamespace: prod\n" +
+			"patches:\n- patch: |-\n    apiVersion: v1\n    kind: ConfigMap\n    metadata:\n      name: settings\n    data:\n      env: prod\n",
+	}
+	t.Run("renders the overlay", func(t *testing.T) {
+		rendered, err := kustomizeBuild(files, "overlays/prod")
+		if err != nil {
+			t.Fatalf("failed to build kustomization: %v", err)
+		}
+		for _, expected := range []string{"name: prod-settings", "namespace: prod", "env: prod"} {
+			if !strings.Contains(rendered, expected) {
+				t.Errorf("expected %s in rendered kustomization:\n%s", expected, rendered)
+			}
+		}
+	})
+	t.Run("fails without a kustomization file", func(t *testing.T) {
+		if _, err := kustomizeBuild(files, "overlays/dev"); err == nil {
+			t.Error("expected error for missing kustomization")
+		}
+	})
+	t.Run("rejects the paths outside the root", func(t *testing.T) {
+		_, err := kustomizeBuild(map[string]string{"../kustomization.yaml": "resources: []\n"}, "")
+		if err == nil || err.Error() != "path ../kustomization.yaml is outside the root of the kustomization" {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+const (
+	// LokiDefaultStart is the default start of the range of the LogQL queries
+	LokiDefaultStart = "-1h"
+	// LokiDefaultLimit is the default maximum number of log lines returned by the LogQL queries
+	LokiDefaultLimit = 100
+	// lokiMaxLineLength is the maximum length of the log lines returned, the longer ones are truncated
+	lokiMaxLineLength = 2000
+	// lokiTenantHeader is the header of the tenant of the multi-tenant Loki
+	lokiTenantHeader = "X-Scope-OrgID"
+)
+
+// ErrLokiNotFound is returned when no Loki Service is found in the cluster
+var ErrLokiNotFound = errors.New("no Loki service found in the cluster, configure its URL with loki_url")
+
+// lokiBackend finds the Loki of the cluster, the gateway and read path of the Helm chart first
+var lokiBackend = &monitoringBackend{
+	name:       "Loki",
+	url:        func(c *config.StaticConfig) string { return c.LokiURL },
+	selectors:  []string{"app.kubernetes.io/name=loki", "app=loki"},
+	components: []string{"gateway", "read", "query-frontend", "single-binary"},
+	portNames:  []string{"http-metrics", "http"},
+	notFound:   ErrLokiNotFound,
+}
+
+// LokiQueryOptions is a LogQL query over a range, either a log query (e.g. {namespace="my-app"} |= "error")
+// or a metric query (e.g. sum by (pod) (rate({namespace="my-app"}[5m])))
+type LokiQueryOptions struct {
+	Query string
+	// Start and End are the range of the query (RFC3339, unix timestamp, or relative to now, e.g. -1h), they default to the last hour
+	Start, End string
+	// Limit is the maximum number of log lines of the log queries
+	Limit int
+	// Direction is backward (the newest lines first) or forward (the oldest lines first)
+	Direction string
+	// Step is the resolution of the metric queries, computed to return at most PromQLDefaultMaxPoints points if not provided
+	Step string
+	// Tenant overrides the configured tenant of the multi-tenant Loki
+	Tenant string
+}
+
+// LokiQueryResult is the result of a LogQL query, the series of the metric queries are truncated and downsampled like the PromQL ones
+type LokiQueryResult struct {
+	*PromQLResult `json:",inline"`
+	// Streams are the log lines of the log queries, grouped by stream
+	Streams []LokiStream `json:"streams,omitempty"`
+	// Entries is the number of log lines returned, Limited is set if the limit was reached (narrow the query or the range)
+	Entries int  `json:"entries,omitempty"`
+	Limited bool `json:"limited,omitempty"`
+}
+
+type LokiStream struct {
+	Labels  map[string]string `json:"labels"`
+	Entries []LokiEntry       `json:"entries"`
+}
+
+type LokiEntry struct {
+	Time time.Time `json:"time"`
+	Line string    `json:"line"`
+}
+
+// LokiQuery evaluates the LogQL query over the range with the Loki of the cluster (the configured one, or the first one discovered)
+func (k *Kubernetes) LokiQuery(ctx context.Context, options LokiQueryOptions) (*LokiQueryResult, error) {
+	now := time.Now()
+	start, err := parsePromQLTime(cmp.Or(options.Start, LokiDefaultStart), now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start: %w", err)
+	}
+	end := now
+	if options.End != "" {
+		if end, err = parsePromQLTime(options.End, now); err != nil {
+			return nil, fmt.Errorf("invalid end: %w", err)
+		}
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("end %s must be after start %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+	step, err := promQLStep(options.Step, end.Sub(start), PromQLDefaultMaxPoints)
+	if err != nil {
+		return nil, err
+	}
+	direction := cmp.Or(options.Direction, "backward")
+	if direction != "backward" && direction != "forward" {
+		return nil, fmt.Errorf("invalid direction %q, expected backward or forward", options.Direction)
+	}
+	limit := cmp.Or(options.Limit, LokiDefaultLimit)
+	params := map[string]string{
+		"query":     options.Query,
+		"start":     strconv.FormatInt(start.UnixNano(), 10),
+		"end":       strconv.FormatInt(end.UnixNano(), 10),
+		"limit":     strconv.Itoa(limit),
+		"direction": direction,
+		"step":      strconv.FormatFloat(step.Seconds(), 'f', -1, 64),
+	}
+	var headers map[string]string
+	tenant := options.Tenant
+	if tenant == "" && k.manager.staticConfig != nil {
+		tenant = k.manager.staticConfig.LokiTenant
+	}
+	if tenant != "" {
+		headers = map[string]string{lokiTenantHeader: tenant}
+	}
+	body, source, err := k.monitoringRequest(ctx, lokiBackend, http.MethodGet, "/loki/api/v1/query_range", params, headers, nil)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := parseLokiResponse(body, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the response of %s: %w", source, err)
+	}
+	ret.Source = source
+	ret.Query = options.Query
+	if ret.Streams == nil {
+		ret.Step = step.String()
+	}
+	return ret, nil
+}
+
+// parseLokiResponse parses the response of the Loki query API, the streams of the log queries, or the series of the metric queries
+func parseLokiResponse(body []byte, limit int) (*LokiQueryResult, error) {
+	response := struct {
+		Data struct {
+			ResultType string          `json:"resultType"`
+			Result     json.RawMessage `json:"result"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+	if response.Data.ResultType != "streams" {
+		metrics, err := parsePromQLResponse(body, PromQLDefaultMaxSeries, PromQLDefaultMaxPoints)
+		if err != nil {
+			return nil, err
+		}
+		return &LokiQueryResult{PromQLResult: metrics}, nil
+	}
+	var streams []struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	}
+	if err := json.Unmarshal(response.Data.Result, &streams); err != nil {
+		return nil, err
+	}
+	ret := &LokiQueryResult{PromQLResult: &PromQLResult{ResultType: response.Data.ResultType, TotalSeries: len(streams)}, Streams: []LokiStream{}}
+	for _, stream := range streams {
+		s := LokiStream{Labels: stream.Stream, Entries: make([]LokiEntry, 0, len(stream.Values))}
+		for _, value := range stream.Values {
+			entry := LokiEntry{Line: value[1]}
+			if nanoseconds, err := strconv.ParseInt(value[0], 10, 64); err == nil {
+				entry.Time = time.Unix(0, nanoseconds).UTC()
+			}
+			if len(entry.Line) > lokiMaxLineLength {
+				entry.Line = strings.ToValidUTF8(entry.Line[:lokiMaxLineLength], "") + "... (truncated)"
+			}
+			s.Entries = append(s.Entries, entry)
+		}
+		ret.Entries += len(s.Entries)
+		ret.Streams = append(ret.Streams, s)
+	}
+	ret.Limited = ret.Entries >= limit
+	return ret, nil
+}
@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+func TestParseLokiResponse(t *testing.T) {
+	t.Run("streams", func(t *testing.T) {
+		ret, err := parseLokiResponse([]byte(`{"status":"success","data":{"resultType":"streams","result":[
+			{"stream":{"namespace":"ns-1","pod":"a"},"values":[["1748772060000000000","error: timeout"],["1748772000000000000","`+strings.Repeat("x", 3000)+`"]]},
+			{"stream":{"namespace":"ns-1","pod":"b"},"values":[["1748772030000000000","error: refused"]]}]}}`), 3)
+		if err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+		if ret.ResultType != "streams" || ret.TotalSeries != 2 || ret.Entries != 3 || !ret.Limited || len(ret.Streams) != 2 {
+			t.Fatalf("unexpected result %+v", ret)
+		}
+		if ret.Streams[0].Labels["pod"] != "a" || ret.Streams[0].Entries[0].Line != "error: timeout" || ret.Streams[0].Entries[0].Time.Unix() != 1748772060 {
+			t.Errorf("unexpected stream %+v", ret.Streams[0])
+		}
+		if line := ret.Streams[0].Entries[1].Line; len(line) != 2000+len("... (truncated)") {
+			t.Errorf("expected the long line to be truncated, got %d characters", len(line))
+		}
+	})
+	t.Run("matrix", func(t *testing.T) {
+		ret, err := parseLokiResponse([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"pod":"a"},"values":[[1748772000,"2"],[1748772060,"5"]]}]}}`), 100)
+		if err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+		if ret.ResultType != "matrix" || ret.Streams != nil || len(ret.Series) != 1 || ret.Series[0].Max != "5" || ret.Limited {
+			t.Errorf("unexpected result %+v", ret)
+		}
+	})
+}
+
+func TestLokiQuery(t *testing.T) {
+	var tenant, direction, limit string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant, direction, limit = r.Header.Get("X-Scope-OrgID"), r.URL.Query().Get("direction"), r.URL.Query().Get("limit")
+		if r.URL.Path != "/loki/api/v1/query_range" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if !strings.HasPrefix(r.URL.Query().Get("query"), "{") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("parse error at line 1, col 1: syntax error: unexpected IDENTIFIER\n"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[{"stream":{"pod":"a"},"values":[["1748772000000000000","started"]]}]}}`))
+	}))
+	defer server.Close()
+	k := &Kubernetes{manager: &Manager{cfg: &rest.Config{}, staticConfig: &config.StaticConfig{LokiURL: server.URL, LokiTenant: "application"}}}
+	t.Run("with the configured tenant", func(t *testing.T) {
+		ret, err := k.LokiQuery(context.Background(), LokiQueryOptions{Query: `{namespace="ns-1"}`})
+		if err != nil {
+			t.Fatalf("failed to query: %v", err)
+		}
+		if tenant != "application" || direction != "backward" || limit != "100" || ret.Source != server.URL || ret.Entries != 1 || ret.Step != "" {
+			t.Errorf("unexpected request %s %s %s or result %+v", tenant, direction, limit, ret)
+		}
+	})
+	t.Run("with the tenant override", func(t *testing.T) {
+		if _, err := k.LokiQuery(context.Background(), LokiQueryOptions{Query: `{namespace="ns-1"}`, Tenant: "infrastructure", Direction: "forward"}); err != nil || tenant != "infrastructure" || direction != "forward" {
+			t.Errorf("unexpected request %s %s %v", tenant, direction, err)
+		}
+	})
+	t.Run("rejected query", func(t *testing.T) {
+		_, err := k.LokiQuery(context.Background(), LokiQueryOptions{Query: "error"})
+		if err == nil || !strings.HasSuffix(err.Error(), "syntax error: unexpected IDENTIFIER") {
+			t.Errorf("expected the Loki error, got %v", err)
+		}
+	})
+	t.Run("invalid direction", func(t *testing.T) {
+		if _, err := k.LokiQuery(context.Background(), LokiQueryOptions{Query: "{}", Direction: "up"}); err == nil || !strings.Contains(err.Error(), "invalid direction") {
+			t.Errorf("expected an invalid direction error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ManifestMaxSize is the maximum size of the manifests downloaded by ResourcesApplyURL
+const ManifestMaxSize = 10 * 1024 * 1024
+
+var (
+	// manifestHTTPClient downloads the manifests, the redirects are followed as long as they are HTTPS.
+	// The connections to the loopback, private, and link-local addresses are refused (including after a redirect or a DNS change)
+	// so that the tool can't reach the internal services of the server network (e.g. cloud metadata endpoints).
+	// The manifests are downloaded without the proxy of the environment so that the dialed addresses are the resolved ones.
+	manifestHTTPClient = &http.Client{
+		Timeout: 60 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         (&net.Dialer{Timeout: 30 * time.Second, Control: manifestDialControl}).DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to non HTTPS URL %s is not allowed", req.URL.Redacted())
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		},
+	}
+	// manifestEnvVariable matches the ${NAME} placeholders of the manifests, the $NAME form is not substituted
+	// to preserve the shell variables embedded in the manifests (e.g. scripts in ConfigMaps)
+	manifestEnvVariable = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)}`)
+)
+
+// ResourcesApplyURLOptions are the source and apply options of the manifests applied by ResourcesApplyURL
+type ResourcesApplyURLOptions struct {
+	// URL is the HTTPS URL of the manifests (multiple YAML documents are supported)
+	URL string
+	// SHA256 is the expected hex encoded sha256 checksum of the downloaded manifests, not verified if not provided
+	SHA256 string
+	// Env are the values substituted for the ${NAME} placeholders of the manifests, placeholders without value are kept
+	Env map[string]string
+	// DiffOnly only compares the manifests with the live objects, nothing is applied
+	DiffOnly bool
+	ApplyOptions
+}
+
+// ManifestApply is the outcome of applying the manifests downloaded from a URL
+type ManifestApply struct {
+	// SHA256 is the hex encoded sha256 checksum of the downloaded manifests (before the substitutions)
+	SHA256 string
+	// Diffs are the differences between the live objects and the manifests, computed before applying them
+	Diffs []ResourceDiff
+	// Results are the apply results, empty if DiffOnly
+	Results []ApplyResult
+}
+
+// ResourcesApplyURL downloads the manifests from an HTTPS URL (up to ManifestMaxSize), verifies their checksum if provided,
+// substitutes the ${NAME} placeholders with the provided values, and server-side applies them (same as kubectl apply -f https://...).
+// The diff against the live objects is computed before applying.
+func (k *Kubernetes) ResourcesApplyURL(ctx context.Context, options ResourcesApplyURLOptions) (*ManifestApply, error) {
+	manifests, checksum, err := fetchManifests(ctx, options.URL)
+	if err != nil {
+		return nil, err
+	}
+	if options.SHA256 != "" && !strings.EqualFold(strings.TrimPrefix(options.SHA256, "sha256:"), checksum) {
+		return nil, fmt.Errorf("checksum mismatch, expected sha256 %s but downloaded %s", options.SHA256, checksum)
+	}
+	manifests = substituteManifestEnv(manifests, options.Env)
+	ret := &ManifestApply{SHA256: checksum}
+	if ret.Diffs, err = k.ResourcesDiff(ctx, manifests, options.ApplyOptions); err != nil {
+		return nil, err
+	}
+	if options.DiffOnly {
+		return ret, nil
+	}
+	if ret.Results, err = k.ResourcesCreateOrUpdate(ctx, manifests, options.ApplyOptions); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// fetchManifests downloads the manifests from the HTTPS URL and returns them with their hex encoded sha256 checksum
+func fetchManifests(ctx context.Context, rawURL string) (string, string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Scheme != "https" {
+		return "", "", fmt.Errorf("only HTTPS URLs are supported, got %s", u.Redacted())
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", "", err
+	}
+	resp, err := manifestHTTPClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("failed to download %s: %s", u.Redacted(), resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, ManifestMaxSize+1))
+	if err != nil {
+		return "", "", err
+	}
+	if len(body) > ManifestMaxSize {
+		return "", "", fmt.Errorf("the manifests exceed the maximum size of %d bytes", ManifestMaxSize)
+	}
+	checksum := sha256.Sum256(body)
+	return string(body), hex.EncodeToString(checksum[:]), nil
+}
+
+// manifestDialControl refuses the connections to the addresses that aren't publicly routable
+func manifestDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if isRestrictedAddr(ip) {
+		return fmt.Errorf("downloading the manifests from %s is not allowed, the address is not public", ip)
+	}
+	return nil
+}
+
+func isRestrictedAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), not covered by netip.Addr.IsPrivate
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func substituteManifestEnv(manifests string, env map[string]string) string {
+	if len(env) == 0 {
+		return manifests
+	}
+	return manifestEnvVariable.ReplaceAllStringFunc(manifests, func(placeholder string) string {
+		if value, ok := env[manifestEnvVariable.FindStringSubmatch(placeholder)[1]]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
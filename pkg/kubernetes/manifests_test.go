@@ -0,0 +1,114 @@
+package kubernetes
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestFetchManifests(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/manifests.yaml":
+			_, _ = w.Write([]byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: operator\n"))
+		case "/large.yaml":
+			_, _ = w.Write([]byte(strings.Repeat("#", ManifestMaxSize+1)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	previous := manifestHTTPClient
+	manifestHTTPClient = server.Client()
+	defer func() { manifestHTTPClient = previous }()
+	t.Run("downloads the manifests with their checksum", func(t *testing.T) {
+		manifests, checksum, err := fetchManifests(context.Background(), server.URL+"/manifests.yaml")
+		if err != nil {
+			t.Fatalf("failed to fetch manifests: %v", err)
+		}
+		if !strings.Contains(manifests, "name: operator") || checksum != "2a018a4b60afe9df53ff77f341697a08f4dcaa018383a01d1d657ed25f4af8bb" {
+			t.Errorf("unexpected manifests %s with checksum %s", manifests, checksum)
+		}
+	})
+	t.Run("fails for the manifests exceeding the maximum size", func(t *testing.T) {
+		_, _, err := fetchManifests(context.Background(), server.URL+"/large.yaml")
+		if err == nil || !strings.Contains(err.Error(), "exceed the maximum size") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+	t.Run("fails for the missing manifests", func(t *testing.T) {
+		_, _, err := fetchManifests(context.Background(), server.URL+"/missing.yaml")
+		if err == nil || !strings.Contains(err.Error(), "404 Not Found") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+	t.Run("rejects the non HTTPS URLs", func(t *testing.T) {
+		_, _, err := fetchManifests(context.Background(), "http://example.com/manifests.yaml")
+		if err == nil || err.Error() != "only HTTPS URLs are supported, got http://example.com/manifests.yaml" {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
+
+func TestFetchManifestsFromRestrictedAddresses(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "https://"+server.Listener.Addr().String()+"/manifests.yaml", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: operator\n"))
+	}))
+	defer server.Close()
+	t.Run("refuses the manifests served from a loopback address", func(t *testing.T) {
+		_, _, err := fetchManifests(context.Background(), server.URL+"/manifests.yaml")
+		if err == nil || !strings.Contains(err.Error(), "downloading the manifests from 127.0.0.1 is not allowed, the address is not public") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+	t.Run("refuses the redirects to a loopback address", func(t *testing.T) {
+		previous := manifestHTTPClient
+		defer func() { manifestHTTPClient = previous }()
+		// Only the connections to manifests.example.com are dialed without restriction (to the test server)
+		restricted := &net.Dialer{Control: manifestDialControl}
+		transport := server.Client().Transport.(*http.Transport).Clone()
+		transport.TLSClientConfig.InsecureSkipVerify = true
+		transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+			if address == "manifests.example.com:443" {
+				return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+			}
+			return restricted.DialContext(ctx, network, address)
+		}
+		manifestHTTPClient = &http.Client{Transport: transport, CheckRedirect: previous.CheckRedirect}
+		_, _, err := fetchManifests(context.Background(), "https://manifests.example.com/redirect")
+		if err == nil || !strings.Contains(err.Error(), "downloading the manifests from 127.0.0.1 is not allowed, the address is not public") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+	for _, address := range []string{"10.0.0.1", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1"} {
+		t.Run("refuses "+address, func(t *testing.T) {
+			if !isRestrictedAddr(netip.MustParseAddr(address)) {
+				t.Errorf("expected %s to be restricted", address)
+			}
+		})
+	}
+	for _, address := range []string{"140.82.112.3", "2606:50c0:8000::154"} {
+		t.Run("allows "+address, func(t *testing.T) {
+			if isRestrictedAddr(netip.MustParseAddr(address)) {
+				t.Errorf("expected %s to be allowed", address)
+			}
+		})
+	}
+}
+
+func TestSubstituteManifestEnv(t *testing.T) {
+	manifests := "image: ${REGISTRY}/operator:${VERSION}\nscript: echo $HOME ${UNSET}\n"
+	expected := "image: quay.io/operator:v1.0.0\nscript: echo $HOME ${UNSET}\n"
+	if actual := substituteManifestEnv(manifests, map[string]string{"REGISTRY": "quay.io", "VERSION": "v1.0.0"}); actual != expected {
+		t.Errorf("unexpected manifests %s", actual)
+	}
+}
@@ -0,0 +1,161 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	MetadataFieldLabels      = "labels"
+	MetadataFieldAnnotations = "annotations"
+)
+
+// MetadataUpdate is the result of adding, updating, or removing the labels or annotations of a resource
+type MetadataUpdate struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	// Added are the keys that were not set in the resource
+	Added map[string]string `json:"added,omitempty"`
+	// Updated are the keys that were set with a different value in the resource
+	Updated map[string]string `json:"updated,omitempty"`
+	// Removed are the keys that were set in the resource and have been removed
+	Removed []string `json:"removed,omitempty"`
+	// Result are the labels or annotations of the resource after the update
+	Result map[string]string `json:"result,omitempty"`
+}
+
+// Changed returns true if any key was added, updated, or removed
+func (u *MetadataUpdate) Changed() bool {
+	return len(u.Added) > 0 || len(u.Updated) > 0 || len(u.Removed) > 0
+}
+
+type MetadataOptions struct {
+	// Set are the keys to add or update
+	Set map[string]string
+	// Remove are the keys to remove, keys not set in the resource are ignored
+	Remove []string
+	// Overwrite allows updating keys already set with a different value (same as kubectl label --overwrite)
+	Overwrite bool
+}
+
+// ResourcesLabel adds, updates, or removes labels of the resource (same as kubectl label)
+func (k *Kubernetes) ResourcesLabel(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, options MetadataOptions) (*MetadataUpdate, error) {
+	for key, value := range options.Set {
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label value %q for key %s: %s", value, key, strings.Join(errs, "; "))
+		}
+	}
+	return k.resourcesUpdateMetadata(ctx, gvk, namespace, name, MetadataFieldLabels, options)
+}
+
+// ResourcesAnnotate adds, updates, or removes annotations of the resource (same as kubectl annotate)
+func (k *Kubernetes) ResourcesAnnotate(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, options MetadataOptions) (*MetadataUpdate, error) {
+	return k.resourcesUpdateMetadata(ctx, gvk, namespace, name, MetadataFieldAnnotations, options)
+}
+
+// resourcesUpdateMetadata patches only the keys that differ from the live resource, so the operation is idempotent.
+// Nothing is patched when the resource already has the requested keys.
+func (k *Kubernetes) resourcesUpdateMetadata(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name, field string, options MetadataOptions) (*MetadataUpdate, error) {
+	if len(options.Set) == 0 && len(options.Remove) == 0 {
+		return nil, fmt.Errorf("no %s to set or remove", field)
+	}
+	for _, key := range slices.Concat(slices.Collect(maps.Keys(options.Set)), options.Remove) {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	for _, key := range options.Remove {
+		if _, ok := options.Set[key]; ok {
+			return nil, fmt.Errorf("key %s can't be both set and removed", key)
+		}
+	}
+	obj, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	var current map[string]string
+	if field == MetadataFieldLabels {
+		current = obj.GetLabels()
+	} else {
+		current = obj.GetAnnotations()
+	}
+	update := &MetadataUpdate{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind, Name: obj.GetName(), Namespace: obj.GetNamespace()}
+	operations := metadataPatch(current, field, options, update)
+	if len(update.Updated) > 0 && !options.Overwrite {
+		return nil, fmt.Errorf("%s already set with a different value: %s, enable overwrite to update them",
+			field, strings.Join(slices.Sorted(maps.Keys(update.Updated)), ", "))
+	}
+	if len(operations) == 0 {
+		update.Result = current
+		return update, nil
+	}
+	data, err := json.Marshal(operations)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := k.resourcesPatch(ctx, gvk, obj.GetNamespace(), obj.GetName(), types.JSONPatchType, data)
+	if err != nil {
+		return nil, err
+	}
+	if field == MetadataFieldLabels {
+		update.Result = patched.GetLabels()
+	} else {
+		update.Result = patched.GetAnnotations()
+	}
+	return update, nil
+}
+
+// metadataPatch returns the JSON patch operations to apply the options to the current labels or annotations,
+// and records the keys that are added, updated, or removed in the update
+func metadataPatch(current map[string]string, field string, options MetadataOptions, update *MetadataUpdate) []map[string]any {
+	var operations []map[string]any
+	for _, key := range slices.Sorted(slices.Values(options.Remove)) {
+		if _, ok := current[key]; ok {
+			operations = append(operations, map[string]any{"op": "remove", "path": metadataPath(field, key)})
+			update.Removed = append(update.Removed, key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(options.Set)) {
+		value := options.Set[key]
+		existing, ok := current[key]
+		switch {
+		case !ok:
+			if update.Added == nil {
+				update.Added = map[string]string{}
+			}
+			update.Added[key] = value
+		case existing != value:
+			if update.Updated == nil {
+				update.Updated = map[string]string{}
+			}
+			update.Updated[key] = value
+			operations = append(operations, map[string]any{"op": "replace", "path": metadataPath(field, key), "value": value})
+		}
+	}
+	if len(update.Added) == 0 {
+		return operations
+	}
+	// JSON patch can't add a key to a missing map, the whole map is added when the resource has no labels or annotations
+	if current == nil {
+		return append(operations, map[string]any{"op": "add", "path": "/metadata/" + field, "value": update.Added})
+	}
+	for _, key := range slices.Sorted(maps.Keys(update.Added)) {
+		operations = append(operations, map[string]any{"op": "add", "path": metadataPath(field, key), "value": update.Added[key]})
+	}
+	return operations
+}
+
+// metadataPath returns the JSON pointer (RFC 6901) of the key in the labels or annotations
+func metadataPath(field, key string) string {
+	return "/metadata/" + field + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMetadataPatch(t *testing.T) {
+	t.Run("adds the whole map when the resource has none", func(t *testing.T) {
+		update := &MetadataUpdate{}
+		operations := metadataPatch(nil, MetadataFieldLabels, MetadataOptions{Set: map[string]string{"app": "shop"}, Remove: []string{"missing"}}, update)
+		data, _ := json.Marshal(operations)
+		if string(data) != `[{"op":"add","path":"/metadata/labels","value":{"app":"shop"}}]` {
+			t.Errorf("unexpected patch %s", data)
+		}
+		if update.Added["app"] != "shop" || len(update.Removed) != 0 {
+			t.Errorf("unexpected update %+v", update)
+		}
+	})
+	t.Run("patches only the keys that differ", func(t *testing.T) {
+		update := &MetadataUpdate{}
+		current := map[string]string{"app": "shop", "tier": "backend", "example.com/stale": "true"}
+		operations := metadataPatch(current, MetadataFieldAnnotations, MetadataOptions{
+			Set:    map[string]string{"app": "shop", "tier": "frontend", "new": "value"},
+			Remove: []string{"example.com/stale"},
+		}, update)
+		data, _ := json.Marshal(operations)
+		expected := `[{"op":"remove","path":"/metadata/annotations/example.com~1stale"},` +
+			`{"op":"replace","path":"/metadata/annotations/tier","value":"frontend"},` +
+			`{"op":"add","path":"/metadata/annotations/new","value":"value"}]`
+		if string(data) != expected {
+			t.Errorf("unexpected patch %s", data)
+		}
+		if update.Added["new"] != "value" || update.Updated["tier"] != "frontend" || len(update.Removed) != 1 || !update.Changed() {
+			t.Errorf("unexpected update %+v", update)
+		}
+	})
+	t.Run("returns no operations when nothing changes", func(t *testing.T) {
+		update := &MetadataUpdate{}
+		operations := metadataPatch(map[string]string{"app": "shop"}, MetadataFieldLabels, MetadataOptions{Set: map[string]string{"app": "shop"}, Remove: []string{"missing"}}, update)
+		if len(operations) != 0 || update.Changed() {
+			t.Errorf("expected no changes, got %v and %+v", operations, update)
+		}
+	})
+}
+
+func TestResourcesUpdateMetadataValidation(t *testing.T) {
+	k := &Kubernetes{}
+	for _, tc := range []struct {
+		name     string
+		options  MetadataOptions
+		expected string
+	}{
+		{"no keys", MetadataOptions{}, "no labels to set or remove"},
+		{"invalid key", MetadataOptions{Set: map[string]string{"in valid": "x"}}, `invalid key "in valid"`},
+		{"invalid value", MetadataOptions{Set: map[string]string{"app": "in valid"}}, `invalid label value "in valid" for key app`},
+		{"set and removed", MetadataOptions{Set: map[string]string{"app": "x"}, Remove: []string{"app"}}, "key app can't be both set and removed"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := k.ResourcesLabel(t.Context(), nil, "", "", tc.options)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.expected) {
+				t.Errorf("expected error starting with %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"k8s.io/metrics/pkg/apis/metrics"
+)
+
+// DefaultMetricsHistoryRetention is how long the samples of the Pods metrics are kept if not configured
+const DefaultMetricsHistoryRetention = time.Hour
+
+// ErrMetricsHistoryDisabled is returned when the consumption over a window is requested without the metrics history sampler
+var ErrMetricsHistoryDisabled = errors.New("the metrics history is disabled, set metrics_history_interval in the configuration to sample the Pods metrics")
+
+// metricsHistory is a ring buffer of the samples of the Pods metrics, polled from metrics.k8s.io every interval
+type metricsHistory struct {
+	interval time.Duration
+	mu       sync.RWMutex
+	samples  []metricsSample
+	// next is the index of the ring buffer the next sample is written to
+	next int
+	stop context.CancelFunc
+}
+
+type metricsSample struct {
+	time       time.Time
+	containers map[metricsContainerKey]metricsUsage
+}
+
+type metricsContainerKey struct {
+	namespace, pod, container string
+}
+
+type metricsUsage struct {
+	// cpu is in millicores, memory in bytes
+	cpu, memory int64
+}
+
+// PodsTopHistory is the consumption of the Pods over a window, computed from the samples of the metrics history
+type PodsTopHistory struct {
+	Window   string    `json:"window"`
+	Interval string    `json:"interval"`
+	Samples  int       `json:"samples"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	// Partial is set if the history doesn't cover the whole window yet (e.g. the server started recently)
+	Partial bool             `json:"partial,omitempty"`
+	Pods    []PodUsageWindow `json:"pods"`
+}
+
+type PodUsageWindow struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Samples is the number of samples of the Pod in the window, lower than the total if the Pod was created or deleted meanwhile
+	Samples int         `json:"samples"`
+	CPU     UsageWindow `json:"cpu"`
+	Memory  UsageWindow `json:"memory"`
+	// avgCPU and avgMemory are the values the Pods are sorted by
+	avgCPU, avgMemory float64
+}
+
+type UsageWindow struct {
+	Avg  string `json:"avg"`
+	Max  string `json:"max"`
+	Last string `json:"last"`
+}
+
+// StartMetricsHistory samples the metrics of all the Pods every metrics_history_interval if configured, until StopMetricsHistory or Close
+func (m *Manager) StartMetricsHistory() {
+	if m.staticConfig == nil || m.staticConfig.MetricsHistoryInterval <= 0 {
+		return
+	}
+	interval := m.staticConfig.MetricsHistoryInterval
+	retention := cmp.Or(m.staticConfig.MetricsHistoryRetention, DefaultMetricsHistoryRetention)
+	ctx, cancel := context.WithCancel(context.Background())
+	m.metricsHistory = &metricsHistory{interval: interval, samples: make([]metricsSample, max(int(retention/interval), 1)), stop: cancel}
+	go m.metricsHistory.run(ctx, func(ctx context.Context) (*metrics.PodMetricsList, error) {
+		return m.accessControlClientSet.PodsMetricses(ctx, "", "", metav1.ListOptions{})
+	})
+}
+
+// StopMetricsHistory stops sampling the Pods metrics, the samples are discarded
+func (m *Manager) StopMetricsHistory() {
+	if m.metricsHistory != nil {
+		m.metricsHistory.stop()
+	}
+}
+
+func (h *metricsHistory) run(ctx context.Context, list func(ctx context.Context) (*metrics.PodMetricsList, error)) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		sampleCtx, cancel := context.WithTimeout(ctx, h.interval)
+		podMetrics, err := list(sampleCtx)
+		cancel()
+		if err != nil {
+			klog.V(2).Infof("failed to sample the Pods metrics: %v", err)
+		} else {
+			h.add(time.Now(), podMetrics.Items)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// add records the consumption of the containers of each Pod
+func (h *metricsHistory) add(now time.Time, items []metrics.PodMetrics) {
+	sample := metricsSample{time: now, containers: make(map[metricsContainerKey]metricsUsage, len(items))}
+	for _, item := range items {
+		for _, container := range item.Containers {
+			sample.containers[metricsContainerKey{namespace: item.Namespace, pod: item.Name, container: container.Name}] = metricsUsage{
+				cpu:    container.Usage.Cpu().MilliValue(),
+				memory: container.Usage.Memory().Value(),
+			}
+		}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.samples[h.next] = sample
+	h.next = (h.next + 1) % len(h.samples)
+}
+
+// window returns the samples taken since the start, the oldest first
+func (h *metricsHistory) window(since time.Time) []metricsSample {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var ret []metricsSample
+	for i := range h.samples {
+		if sample := h.samples[(h.next+i)%len(h.samples)]; sample.containers != nil && !sample.time.Before(since) {
+			ret = append(ret, sample)
+		}
+	}
+	return ret
+}
+
+// PodsTopHistory returns the average, maximum, and last consumption of the Pods over the window (e.g. 15m) from the metrics history,
+// sorted by their average consumption of the sortBy resource (cpu or memory) if provided, by namespace and name otherwise.
+// The Pods matching the label selector are the ones currently existing.
+func (k *Kubernetes) PodsTopHistory(ctx context.Context, options PodsTopOptions, window, sortBy string) (*PodsTopHistory, error) {
+	history := k.manager.metricsHistory
+	if history == nil {
+		return nil, ErrMetricsHistoryDisabled
+	}
+	duration, err := parsePromQLDuration(window)
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("invalid window %q, expected a duration (e.g. 15m, 1h)", window)
+	}
+	namespace := options.Namespace
+	if !options.AllNamespaces || namespace != "" {
+		namespace = k.NamespaceOrDefault(namespace)
+	}
+	var selected map[string]bool
+	if options.LabelSelector != "" && options.Name == "" {
+		pods, err := k.ResourcesList(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, namespace,
+			ResourceListOptions{ListOptions: metav1.ListOptions{LabelSelector: options.LabelSelector}})
+		if err != nil {
+			return nil, err
+		}
+		selected = make(map[string]bool)
+		for _, pod := range pods.(*unstructured.UnstructuredList).Items {
+			selected[pod.GetNamespace()+"/"+pod.GetName()] = true
+		}
+	}
+	now := time.Now()
+	samples := history.window(now.Add(-duration))
+	ret := &PodsTopHistory{Window: duration.String(), Interval: history.interval.String(), Samples: len(samples), Pods: []PodUsageWindow{}}
+	if len(samples) == 0 {
+		ret.Partial = true
+		return ret, nil
+	}
+	ret.From, ret.To = samples[0].time.UTC(), samples[len(samples)-1].time.UTC()
+	ret.Partial = samples[0].time.Sub(now.Add(-duration)) > history.interval
+	type aggregate struct {
+		samples             int
+		sumCPU, sumMemory   int64
+		maxCPU, maxMemory   int64
+		lastCPU, lastMemory int64
+	}
+	aggregates := make(map[metricsContainerKey]*aggregate)
+	for _, sample := range samples {
+		for key, usage := range sample.podsUsage() {
+			if (namespace != "" && key.namespace != namespace) || (options.Name != "" && key.pod != options.Name) ||
+				(selected != nil && !selected[key.namespace+"/"+key.pod]) {
+				continue
+			}
+			a, ok := aggregates[key]
+			if !ok {
+				a = &aggregate{}
+				aggregates[key] = a
+			}
+			a.samples++
+			a.sumCPU, a.sumMemory = a.sumCPU+usage.cpu, a.sumMemory+usage.memory
+			a.maxCPU, a.maxMemory = max(a.maxCPU, usage.cpu), max(a.maxMemory, usage.memory)
+			a.lastCPU, a.lastMemory = usage.cpu, usage.memory
+		}
+	}
+	for key, a := range aggregates {
+		avgCPU, avgMemory := float64(a.sumCPU)/float64(a.samples), float64(a.sumMemory)/float64(a.samples)
+		ret.Pods = append(ret.Pods, PodUsageWindow{
+			Namespace: key.namespace,
+			Name:      key.pod,
+			Samples:   a.samples,
+			CPU: UsageWindow{
+				Avg:  resource.NewMilliQuantity(int64(avgCPU+0.5), resource.DecimalSI).String(),
+				Max:  resource.NewMilliQuantity(a.maxCPU, resource.DecimalSI).String(),
+				Last: resource.NewMilliQuantity(a.lastCPU, resource.DecimalSI).String(),
+			},
+			Memory: UsageWindow{
+				Avg:  formatMemoryMi(int64(avgMemory)),
+				Max:  formatMemoryMi(a.maxMemory),
+				Last: formatMemoryMi(a.lastMemory),
+			},
+			avgCPU:    avgCPU,
+			avgMemory: avgMemory,
+		})
+	}
+	slices.SortFunc(ret.Pods, func(a, b PodUsageWindow) int {
+		switch sortBy {
+		case "cpu":
+			return cmp.Compare(b.avgCPU, a.avgCPU)
+		case "memory":
+			return cmp.Compare(b.avgMemory, a.avgMemory)
+		}
+		return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+	})
+	return ret, nil
+}
+
+// podsUsage returns the total consumption of the containers of each Pod of the sample, keyed without container
+func (s metricsSample) podsUsage() map[metricsContainerKey]metricsUsage {
+	ret := make(map[metricsContainerKey]metricsUsage)
+	for key, usage := range s.containers {
+		pod := metricsContainerKey{namespace: key.namespace, pod: key.pod}
+		ret[pod] = metricsUsage{cpu: ret[pod].cpu + usage.cpu, memory: ret[pod].memory + usage.memory}
+	}
+	return ret
+}
+
+// formatMemoryMi formats the bytes in mebibytes, like kubectl top
+func formatMemoryMi(bytes int64) string {
+	return fmt.Sprintf("%dMi", bytes/(1024*1024))
+}
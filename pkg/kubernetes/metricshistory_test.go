@@ -0,0 +1,117 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/metrics"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+func podMetrics(namespace, name, cpu, memory string) metrics.PodMetrics {
+	return metrics.PodMetrics{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Containers: []metrics.ContainerMetrics{{
+			Name:  "app",
+			Usage: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu), v1.ResourceMemory: resource.MustParse(memory)},
+		}},
+	}
+}
+
+func TestMetricsHistoryWindow(t *testing.T) {
+	h := &metricsHistory{interval: time.Minute, samples: make([]metricsSample, 3)}
+	now := time.Now()
+	for i := 5; i > 0; i-- {
+		h.add(now.Add(-time.Duration(i)*time.Minute), []metrics.PodMetrics{podMetrics("ns-1", "a", "100m", "64Mi")})
+	}
+	samples := h.window(now.Add(-time.Hour))
+	if len(samples) != 3 {
+		t.Fatalf("expected the ring buffer to keep the last 3 samples, got %d", len(samples))
+	}
+	if !samples[0].time.Equal(now.Add(-3*time.Minute)) || !samples[2].time.Equal(now.Add(-time.Minute)) {
+		t.Errorf("expected the samples oldest first, got %v and %v", samples[0].time, samples[2].time)
+	}
+	if samples = h.window(now.Add(-150 * time.Second)); len(samples) != 2 {
+		t.Errorf("expected 2 samples in the window, got %d", len(samples))
+	}
+}
+
+func TestPodsTopHistory(t *testing.T) {
+	h := &metricsHistory{interval: time.Minute, samples: make([]metricsSample, 60)}
+	now := time.Now()
+	h.add(now.Add(-3*time.Minute), []metrics.PodMetrics{
+		podMetrics("ns-1", "a", "100m", "64Mi"),
+		podMetrics("ns-1", "b", "500m", "32Mi"),
+		podMetrics("ns-2", "c", "1", "512Mi"),
+	})
+	h.add(now.Add(-2*time.Minute), []metrics.PodMetrics{
+		podMetrics("ns-1", "a", "300m", "128Mi"),
+		podMetrics("ns-2", "c", "1", "512Mi"),
+	})
+	h.add(now.Add(-time.Minute), []metrics.PodMetrics{
+		podMetrics("ns-1", "a", "200m", "96Mi"),
+		podMetrics("ns-1", "b", "100m", "32Mi"),
+		podMetrics("ns-2", "c", "1", "512Mi"),
+	})
+	k := &Kubernetes{manager: &Manager{staticConfig: &config.StaticConfig{}, metricsHistory: h}}
+	t.Run("namespace sorted by cpu", func(t *testing.T) {
+		ret, err := k.PodsTopHistory(context.Background(), PodsTopOptions{Namespace: "ns-1"}, "4m", "cpu")
+		if err != nil {
+			t.Fatalf("failed to get the pods top history: %v", err)
+		}
+		if ret.Samples != 3 || ret.Window != "4m0s" || ret.Interval != "1m0s" || ret.Partial {
+			t.Errorf("unexpected history %+v", ret)
+		}
+		if len(ret.Pods) != 2 || ret.Pods[0].Name != "b" || ret.Pods[1].Name != "a" {
+			t.Fatalf("expected b then a, got %+v", ret.Pods)
+		}
+		a := ret.Pods[1]
+		if a.Samples != 3 || a.CPU.Avg != "200m" || a.CPU.Max != "300m" || a.CPU.Last != "200m" {
+			t.Errorf("unexpected cpu of a %+v", a)
+		}
+		if a.Memory.Avg != "96Mi" || a.Memory.Max != "128Mi" || a.Memory.Last != "96Mi" {
+			t.Errorf("unexpected memory of a %+v", a.Memory)
+		}
+		if ret.Pods[0].Samples != 2 {
+			t.Errorf("expected 2 samples of b, got %d", ret.Pods[0].Samples)
+		}
+	})
+	t.Run("all namespaces sorted by memory", func(t *testing.T) {
+		ret, err := k.PodsTopHistory(context.Background(), PodsTopOptions{AllNamespaces: true}, "90s", "memory")
+		if err != nil {
+			t.Fatalf("failed to get the pods top history: %v", err)
+		}
+		if ret.Samples != 1 || ret.Partial {
+			t.Errorf("unexpected history %+v", ret)
+		}
+		if len(ret.Pods) != 3 || ret.Pods[0].Name != "c" || ret.Pods[0].CPU.Avg != "1" {
+			t.Errorf("expected c first, got %+v", ret.Pods)
+		}
+	})
+	t.Run("window longer than the history", func(t *testing.T) {
+		ret, err := k.PodsTopHistory(context.Background(), PodsTopOptions{Namespace: "ns-2", Name: "c"}, "1h", "")
+		if err != nil {
+			t.Fatalf("failed to get the pods top history: %v", err)
+		}
+		if !ret.Partial || len(ret.Pods) != 1 || ret.Pods[0].Samples != 3 {
+			t.Errorf("expected a partial history of c, got %+v", ret)
+		}
+	})
+	t.Run("invalid window", func(t *testing.T) {
+		if _, err := k.PodsTopHistory(context.Background(), PodsTopOptions{AllNamespaces: true}, "soon", ""); err == nil {
+			t.Error("expected an error for an invalid window")
+		}
+	})
+	t.Run("disabled", func(t *testing.T) {
+		disabled := &Kubernetes{manager: &Manager{staticConfig: &config.StaticConfig{}}}
+		if _, err := disabled.PodsTopHistory(context.Background(), PodsTopOptions{AllNamespaces: true}, "15m", ""); !errors.Is(err, ErrMetricsHistoryDisabled) {
+			t.Errorf("expected ErrMetricsHistoryDisabled, got %v", err)
+		}
+	})
+}
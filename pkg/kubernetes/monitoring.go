@@ -0,0 +1,297 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+const (
+	// monitoringTimeout is the timeout of the requests to the monitoring stack queried directly
+	monitoringTimeout = 60 * time.Second
+	// monitoringMaxResponseSize is the maximum size of the responses of the monitoring stack queried directly
+	monitoringMaxResponseSize = 50 * 1024 * 1024
+	// monitoringMaxErrorSize is the maximum size of the plain text error responses reported as is, the longer ones are likely HTML pages
+	monitoringMaxErrorSize = 1024
+)
+
+// monitoringBackend describes how to find a component of the monitoring stack (Prometheus, Alertmanager...) of the cluster
+type monitoringBackend struct {
+	// name is the name of the component reported in the errors (e.g. Prometheus)
+	name string
+	// url returns the configured URL of the HTTP API of the component, the component isn't discovered if set
+	url func(*config.StaticConfig) string
+	// knownRoutes are the Routes of the component deployed by the distributions, the component behind an authenticating proxy
+	// can't be queried through the API server service proxy, which doesn't forward the credentials
+	knownRoutes []monitoringService
+	// knownServices are the Services of the component deployed by the distributions, the first ones found are queried first
+	knownServices []monitoringService
+	// selectors are the labels of the Services of the component of the operators and of the Helm charts
+	selectors []string
+	// components are the values of the app.kubernetes.io/component label of the Services of the component, the Services of the other
+	// components sharing the labels are ignored
+	components []string
+	// portNames are the usual names of the HTTP port of the Services of the component
+	portNames []string
+	// notFound is returned when the component isn't found in the cluster
+	notFound error
+}
+
+// monitoringService is a Service exposing the HTTP API of a component of the monitoring stack, queried through the API server service proxy,
+// or the URL of the HTTP API (configured or of a Route) queried directly with the bearer token of the Kubernetes credentials
+type monitoringService struct {
+	namespace, name, scheme, port string
+	url                           string
+}
+
+func (s monitoringService) String() string {
+	if s.url != "" {
+		return s.url
+	}
+	return s.namespace + "/" + s.name
+}
+
+// monitoringRequest performs a request with the headers (e.g. the tenant of Loki) to the HTTP API of the component (the configured one,
+// or the first one of the cluster answering), and returns the response along with the component that answered.
+// The requests rejected by the component (e.g. an invalid query) are not retried with the next one.
+func (k *Kubernetes) monitoringRequest(ctx context.Context, backend *monitoringBackend, method, path string, params, headers map[string]string, body []byte) ([]byte, string, error) {
+	candidates := k.monitoringServices(ctx, backend)
+	if len(candidates) == 0 {
+		return nil, "", backend.notFound
+	}
+	var errs []error
+	for _, candidate := range candidates {
+		var response []byte
+		var err error
+		if candidate.url != "" {
+			response, err = k.monitoringURLRequest(ctx, method, candidate.url, path, params, headers, body)
+		} else {
+			request, requestErr := k.manager.accessControlClientSet.ServicesProxy(method, candidate.namespace,
+				utilnet.JoinSchemeNamePort(candidate.scheme, candidate.name, candidate.port))
+			if requestErr != nil {
+				return nil, "", requestErr
+			}
+			request = request.Suffix(path)
+			for key, value := range params {
+				request = request.Param(key, value)
+			}
+			for key, value := range headers {
+				request = request.SetHeader(key, value)
+			}
+			if body != nil {
+				request = request.SetHeader("Content-Type", "application/json").Body(body)
+			}
+			response, err = request.DoRaw(ctx)
+		}
+		if err == nil {
+			return response, candidate.String(), nil
+		}
+		if message, rejected := monitoringRejection(err); rejected {
+			return nil, candidate.String(), fmt.Errorf("%s: %s", candidate, message)
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", candidate, err))
+	}
+	return nil, "", fmt.Errorf("failed to query %s: %w", backend.name, errors.Join(errs...))
+}
+
+// monitoringURLRequest performs a request to the HTTP API at the URL, authenticated with the bearer token of the Kubernetes credentials
+func (k *Kubernetes) monitoringURLRequest(ctx context.Context, method, baseURL, path string, params, headers map[string]string, body []byte) ([]byte, error) {
+	query := url.Values{}
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	target := strings.TrimSuffix(baseURL, "/") + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	insecure := k.manager.staticConfig != nil && k.manager.staticConfig.PrometheusInsecure
+	transport, err := rest.HTTPWrappersForConfig(&rest.Config{
+		BearerToken:     k.manager.cfg.BearerToken,
+		BearerTokenFile: k.manager.cfg.BearerTokenFile,
+		UserAgent:       CustomUserAgent,
+	}, &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure}}) //nolint:gosec // opt-in for self-signed certificates
+	if err != nil {
+		return nil, err
+	}
+	resp, err := (&http.Client{Transport: transport, Timeout: monitoringTimeout}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	response, err := io.ReadAll(io.LimitReader(resp.Body, monitoringMaxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &monitoringStatusError{status: resp.StatusCode, body: response}
+	}
+	return response, nil
+}
+
+// monitoringStatusError is the error response of the HTTP API of a component queried directly
+type monitoringStatusError struct {
+	status int
+	body   []byte
+}
+
+func (e *monitoringStatusError) Error() string {
+	if message := monitoringErrorMessage(e.body); message != "" {
+		return fmt.Sprintf("%s (%d)", message, e.status)
+	}
+	return fmt.Sprintf("unexpected status %d %s", e.status, http.StatusText(e.status))
+}
+
+// monitoringRejection returns the error message of the requests rejected by the component (bad request or unprocessable entity),
+// answered by the component itself and not by a proxy in front of it
+func monitoringRejection(err error) (string, bool) {
+	var statusErr *monitoringStatusError
+	if errors.As(err, &statusErr) && (statusErr.status == http.StatusBadRequest || statusErr.status == http.StatusUnprocessableEntity) {
+		message := monitoringErrorMessage(statusErr.body)
+		return message, message != ""
+	}
+	var apiStatus apierrors.APIStatus
+	if !errors.As(err, &apiStatus) || (!apierrors.IsBadRequest(err) && apiStatus.Status().Code != http.StatusUnprocessableEntity) {
+		return "", false
+	}
+	// The API server service proxy reports the body of the responses that aren't a Kubernetes Status in the causes
+	if details := apiStatus.Status().Details; details != nil {
+		for _, cause := range details.Causes {
+			if message := monitoringErrorMessage([]byte(cause.Message)); message != "" {
+				return message, true
+			}
+		}
+	}
+	return "", false
+}
+
+// monitoringErrorMessage returns the error of an error response of the Prometheus HTTP API, the message of an error response
+// of the Alertmanager HTTP API (a JSON string), or the short plain text error of the Loki HTTP API, empty if the body isn't one
+func monitoringErrorMessage(body []byte) string {
+	var message string
+	if err := json.Unmarshal(body, &message); err == nil {
+		return strings.TrimSpace(message)
+	}
+	if !json.Valid(body) {
+		if text := strings.TrimSpace(string(body)); len(text) <= monitoringMaxErrorSize && utf8.ValidString(text) && !strings.HasPrefix(text, "<") {
+			return text
+		}
+		return ""
+	}
+	response := struct {
+		Status    string `json:"status"`
+		ErrorType string `json:"errorType"`
+		Error     string `json:"error"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil || response.Status != "error" {
+		return ""
+	}
+	if response.ErrorType != "" {
+		return response.ErrorType + ": " + response.Error
+	}
+	return response.Error
+}
+
+// monitoringServices returns the configured URL of the component if any, otherwise its Routes (only usable with a bearer token)
+// and Services found in the cluster, the known ones first
+func (k *Kubernetes) monitoringServices(ctx context.Context, backend *monitoringBackend) []monitoringService {
+	if k.manager.staticConfig != nil {
+		if configured := backend.url(k.manager.staticConfig); configured != "" {
+			return []monitoringService{{url: configured}}
+		}
+	}
+	var ret []monitoringService
+	if k.manager.cfg.BearerToken != "" || k.manager.cfg.BearerTokenFile != "" {
+		for _, known := range backend.knownRoutes {
+			route, err := k.ResourcesGet(ctx, openShiftRouteGVK, known.namespace, known.name)
+			if err != nil {
+				continue
+			}
+			if host, _, _ := unstructured.NestedString(route.Object, "spec", "host"); host != "" {
+				ret = append(ret, monitoringService{namespace: known.namespace, name: known.name, url: "https://" + host})
+			}
+		}
+	}
+	for _, known := range backend.knownServices {
+		services, err := k.manager.accessControlClientSet.Services(known.namespace)
+		if err != nil {
+			continue
+		}
+		if _, err = services.Get(ctx, known.name, metav1.GetOptions{}); err == nil {
+			ret = append(ret, known)
+		}
+	}
+	services, err := k.manager.accessControlClientSet.Services("")
+	if err != nil {
+		return ret
+	}
+	for _, selector := range backend.selectors {
+		list, err := services.List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if apierrors.IsForbidden(err) {
+			break
+		} else if err != nil {
+			continue
+		}
+		for _, service := range list.Items {
+			if candidate, ok := backend.serviceCandidate(&service); ok && !slices.Contains(ret, candidate) {
+				ret = append(ret, candidate)
+			}
+		}
+	}
+	return ret
+}
+
+// serviceCandidate returns the HTTP port of the Service of the component, the Services of the other components
+// sharing the labels (e.g. Alertmanager, node-exporter) are ignored
+func (b *monitoringBackend) serviceCandidate(service *v1.Service) (monitoringService, bool) {
+	if component := service.Labels["app.kubernetes.io/component"]; component != "" && !slices.Contains(b.components, component) {
+		return monitoringService{}, false
+	}
+	if len(service.Spec.Ports) == 0 {
+		return monitoringService{}, false
+	}
+	port := service.Spec.Ports[0]
+	for _, name := range b.portNames {
+		if index := slices.IndexFunc(service.Spec.Ports, func(p v1.ServicePort) bool { return p.Name == name }); index >= 0 {
+			port = service.Spec.Ports[index]
+			break
+		}
+	}
+	ret := monitoringService{namespace: service.Namespace, name: service.Name, scheme: "http", port: port.Name}
+	if ret.port == "" {
+		ret.port = fmt.Sprint(port.Port)
+	}
+	return ret, true
+}
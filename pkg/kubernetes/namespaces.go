@@ -2,14 +2,57 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
 )
 
+var namespaceGVK = &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Namespace"}
+
+// NamespaceStatus is the lifecycle status of a Namespace, with the resources and finalizers blocking its deletion if it is Terminating
+type NamespaceStatus struct {
+	Name              string   `json:"name"`
+	Phase             string   `json:"phase"`
+	DeletionTimestamp string   `json:"deletionTimestamp,omitempty"`
+	Finalizers        []string `json:"finalizers,omitempty"`
+	// Conditions are the deletion conditions reported by the namespace controller that are not False
+	Conditions []NamespaceCondition `json:"conditions,omitempty"`
+	// Remaining are the resources still present in the Terminating Namespace
+	Remaining []NamespaceResource `json:"remaining,omitempty"`
+	// Contents are the resources the deletion of the Namespace would delete, only reported for dry-run deletions
+	Contents []NamespaceResource `json:"contents,omitempty"`
+	// Issues explains what is blocking the deletion of the Terminating Namespace
+	Issues []string `json:"issues,omitempty"`
+}
+
+type NamespaceCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type NamespaceResource struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Name       string   `json:"name"`
+	Finalizers []string `json:"finalizers,omitempty"`
+	// Deleting is true if the deletion of the resource was requested and it's waiting for its finalizers
+	Deleting bool `json:"deleting,omitempty"`
+}
+
 func (k *Kubernetes) NamespacesList(ctx context.Context, options ResourceListOptions) (runtime.Unstructured, error) {
-	return k.ResourcesList(ctx, &schema.GroupVersionKind{
-		Group: "", Version: "v1", Kind: "Namespace",
-	}, "", options)
+	return k.ResourcesList(ctx, namespaceGVK, "", options)
 }
 
 func (k *Kubernetes) ProjectsList(ctx context.Context, options ResourceListOptions) (runtime.Unstructured, error) {
@@ -17,3 +60,163 @@ func (k *Kubernetes) ProjectsList(ctx context.Context, options ResourceListOptio
 		Group: "project.openshift.io", Version: "v1", Kind: "Project",
 	}, "", options)
 }
+
+// NamespacesCreate creates (or updates the labels of) the Namespace through server-side apply
+func (k *Kubernetes) NamespacesCreate(ctx context.Context, name string, labels map[string]string) (*unstructured.Unstructured, error) {
+	namespace := &unstructured.Unstructured{}
+	namespace.SetAPIVersion("v1")
+	namespace.SetKind("Namespace")
+	namespace.SetName(name)
+	namespace.SetLabels(labels)
+	return k.resourcesApply(ctx, namespace, ApplyOptions{})
+}
+
+// NamespacesDelete requests the deletion of the Namespace and returns its status.
+// The deletion is asynchronous, the Namespace stays Terminating until all its resources are deleted.
+func (k *Kubernetes) NamespacesDelete(ctx context.Context, name string) (*NamespaceStatus, error) {
+	if err := k.ResourcesDelete(ctx, namespaceGVK, "", name); err != nil {
+		return nil, err
+	}
+	if IsDryRun(ctx) {
+		return k.namespacesDeletePreview(ctx, name)
+	}
+	ret, err := k.NamespacesStatus(ctx, name)
+	// The Namespace may have been removed right away if it was empty
+	if apierrors.IsNotFound(err) {
+		return &NamespaceStatus{Name: name, Phase: "Deleted"}, nil
+	}
+	return ret, err
+}
+
+// NamespacesStatus returns the lifecycle status of the Namespace. If it is Terminating, the resources remaining in it
+// across all the API groups are listed along with their finalizers to diagnose why the deletion is stuck.
+func (k *Kubernetes) NamespacesStatus(ctx context.Context, name string) (*NamespaceStatus, error) {
+	obj, err := k.ResourcesGet(ctx, namespaceGVK, "", name)
+	if err != nil {
+		return nil, err
+	}
+	ns := &v1.Namespace{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, ns); err != nil {
+		return nil, err
+	}
+	ret := namespaceStatus(ns)
+	if ns.Status.Phase != v1.NamespaceTerminating {
+		return ret, nil
+	}
+	remaining, issues, err := k.namespaceResources(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	ret.Remaining = remaining
+	ret.Issues = append(ret.Issues, issues...)
+	ret.Issues = append(ret.Issues, namespaceRemainingIssues(ret.Remaining)...)
+	if len(ret.Issues) == 0 && len(ret.Remaining) == 0 {
+		ret.Issues = append(ret.Issues, "no resources remain, the namespace controller should complete the deletion shortly, otherwise check that kube-controller-manager is running")
+	}
+	return ret, nil
+}
+
+// namespacesDeletePreview returns the status of the Namespace along with the resources its deletion would delete
+func (k *Kubernetes) namespacesDeletePreview(ctx context.Context, name string) (*NamespaceStatus, error) {
+	ret, err := k.NamespacesStatus(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Phase == string(v1.NamespaceTerminating) {
+		return ret, nil
+	}
+	contents, issues, err := k.namespaceResources(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	ret.Contents = contents
+	ret.Issues = append(ret.Issues, issues...)
+	return ret, nil
+}
+
+// namespaceResources lists the resources present in the Namespace across all the API groups, along with the issues
+// preventing some of them from being listed
+func (k *Kubernetes) namespaceResources(ctx context.Context, name string) ([]NamespaceResource, []string, error) {
+	var ret []NamespaceResource
+	var issues []string
+	apiResourceLists, err := k.manager.discoveryClient.ServerPreferredNamespacedResources()
+	// Unavailable aggregated APIs block the deletion, their resources can't be listed nor deleted
+	if err != nil {
+		var groupErr *discovery.ErrGroupDiscoveryFailed
+		if !errors.As(err, &groupErr) {
+			return nil, nil, err
+		}
+		for gv := range groupErr.Groups {
+			issues = append(issues, fmt.Sprintf("API group %s is unavailable, the namespace controller can't delete its resources until its API service is available or removed", gv.String()))
+		}
+		slices.Sort(issues)
+	}
+	for _, apiResourceList := range apiResourceLists {
+		gv, gvErr := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if gvErr != nil {
+			continue
+		}
+		for _, apiResource := range apiResourceList.APIResources {
+			gvk := &schema.GroupVersionKind{Group: gv.Group, Version: gv.Version, Kind: apiResource.Kind}
+			if strings.Contains(apiResource.Name, "/") || !slices.Contains(apiResource.Verbs, "list") || !isAllowed(k.manager.staticConfig, gvk) {
+				continue
+			}
+			list, listErr := k.manager.dynamicClient.Resource(gv.WithResource(apiResource.Name)).Namespace(name).List(ctx, metav1.ListOptions{})
+			if listErr != nil {
+				issues = append(issues, fmt.Sprintf("failed to list %s in %s: %v", apiResource.Name, gv.String(), listErr))
+				continue
+			}
+			for _, item := range list.Items {
+				ret = append(ret, NamespaceResource{
+					APIVersion: gv.String(),
+					Kind:       apiResource.Kind,
+					Name:       item.GetName(),
+					Finalizers: item.GetFinalizers(),
+					Deleting:   item.GetDeletionTimestamp() != nil,
+				})
+			}
+		}
+	}
+	return ret, issues, nil
+}
+
+// namespaceStatus returns the status of the Namespace with the issues reported by the deletion conditions
+func namespaceStatus(ns *v1.Namespace) *NamespaceStatus {
+	ret := &NamespaceStatus{Name: ns.Name, Phase: string(ns.Status.Phase)}
+	if ns.DeletionTimestamp != nil {
+		ret.DeletionTimestamp = ns.DeletionTimestamp.UTC().Format(time.RFC3339)
+	}
+	for _, finalizer := range ns.Spec.Finalizers {
+		ret.Finalizers = append(ret.Finalizers, string(finalizer))
+	}
+	ret.Finalizers = append(ret.Finalizers, ns.Finalizers...)
+	for _, condition := range ns.Status.Conditions {
+		if condition.Status == v1.ConditionFalse {
+			continue
+		}
+		ret.Conditions = append(ret.Conditions, NamespaceCondition{
+			Type: string(condition.Type), Status: string(condition.Status), Reason: condition.Reason, Message: condition.Message,
+		})
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case v1.NamespaceDeletionDiscoveryFailure, v1.NamespaceDeletionGVParsingFailure, v1.NamespaceDeletionContentFailure:
+			ret.Issues = append(ret.Issues, fmt.Sprintf("the namespace controller reports %s: %s", condition.Type, condition.Message))
+		}
+	}
+	return ret
+}
+
+// namespaceRemainingIssues reports the finalizers of the remaining resources, the namespace controller deletes
+// the rest of the resources and waits for these finalizers to be removed by their controllers
+func namespaceRemainingIssues(remaining []NamespaceResource) []string {
+	var issues []string
+	for _, resource := range remaining {
+		if len(resource.Finalizers) > 0 {
+			issues = append(issues, fmt.Sprintf("%s %s (%s) is waiting for finalizers %s, check that their controller is running or remove them",
+				resource.Kind, resource.Name, resource.APIVersion, strings.Join(resource.Finalizers, ", ")))
+		}
+	}
+	return issues
+}
@@ -0,0 +1,51 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNamespaceStatus(t *testing.T) {
+	t.Run("reports the deletion conditions", func(t *testing.T) {
+		status := namespaceStatus(&v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "stuck", DeletionTimestamp: &metav1.Time{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}},
+			Spec:       v1.NamespaceSpec{Finalizers: []v1.FinalizerName{v1.FinalizerKubernetes}},
+			Status: v1.NamespaceStatus{Phase: v1.NamespaceTerminating, Conditions: []v1.NamespaceCondition{
+				{Type: v1.NamespaceDeletionDiscoveryFailure, Status: v1.ConditionTrue, Message: "metrics.k8s.io/v1beta1: the server is currently unable to handle the request"},
+				{Type: v1.NamespaceDeletionContentFailure, Status: v1.ConditionFalse},
+				{Type: v1.NamespaceFinalizersRemaining, Status: v1.ConditionTrue, Message: "Some content in the namespace has finalizers remaining"},
+			}},
+		})
+		if status.Phase != "Terminating" || status.DeletionTimestamp != "2025-01-02T03:04:05Z" {
+			t.Errorf("unexpected status %v", status)
+		}
+		if len(status.Finalizers) != 1 || status.Finalizers[0] != "kubernetes" {
+			t.Errorf("unexpected finalizers %v", status.Finalizers)
+		}
+		if len(status.Conditions) != 2 {
+			t.Errorf("expected the False conditions to be omitted, got %v", status.Conditions)
+		}
+		if len(status.Issues) != 1 || status.Issues[0] != "the namespace controller reports NamespaceDeletionDiscoveryFailure: metrics.k8s.io/v1beta1: the server is currently unable to handle the request" {
+			t.Errorf("unexpected issues %v", status.Issues)
+		}
+	})
+	t.Run("reports no issues for active namespaces", func(t *testing.T) {
+		status := namespaceStatus(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "active"}, Status: v1.NamespaceStatus{Phase: v1.NamespaceActive}})
+		if status.Phase != "Active" || len(status.Issues) > 0 || status.DeletionTimestamp != "" {
+			t.Errorf("unexpected status %v", status)
+		}
+	})
+}
+
+func TestNamespaceRemainingIssues(t *testing.T) {
+	issues := namespaceRemainingIssues([]NamespaceResource{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "no-finalizers"},
+		{APIVersion: "example.com/v1", Kind: "Widget", Name: "w", Finalizers: []string{"example.com/a", "example.com/b"}, Deleting: true},
+	})
+	if len(issues) != 1 || issues[0] != "Widget w (example.com/v1) is waiting for finalizers example.com/a, example.com/b, check that their controller is running or remove them" {
+		t.Errorf("unexpected issues %v", issues)
+	}
+}
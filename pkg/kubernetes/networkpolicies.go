@@ -0,0 +1,396 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+var networkPolicyGVK = &schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"}
+
+// NetworkPolicy is the summary of a NetworkPolicy with its rules described in plain text
+type NetworkPolicy struct {
+	Namespace   string   `json:"namespace"`
+	Name        string   `json:"name"`
+	PodSelector string   `json:"podSelector"`
+	PolicyTypes []string `json:"policyTypes"`
+	Ingress     []string `json:"ingress,omitempty"`
+	Egress      []string `json:"egress,omitempty"`
+}
+
+// NetworkPolicyEndpoint is the source or destination of the simulated traffic, a Pod or the labels of the Pods
+type NetworkPolicyEndpoint struct {
+	Namespace string
+	// Pod is the name of the Pod, its labels, IP, and named ports are used
+	Pod    string
+	Labels map[string]string
+	// ip and ports are resolved from the Pod
+	ip    string
+	ports []v1.ContainerPort
+}
+
+type NetworkPolicySimulateOptions struct {
+	Source      NetworkPolicyEndpoint
+	Destination NetworkPolicyEndpoint
+	Port        int32
+	// Protocol of the traffic, TCP if not provided
+	Protocol string
+}
+
+// NetworkPolicySimulation is the verdict of the NetworkPolicies for the traffic between two Pods
+type NetworkPolicySimulation struct {
+	Allowed bool `json:"allowed"`
+	// Egress is the verdict of the policies selecting the source Pod
+	Egress NetworkPolicyVerdict `json:"egress"`
+	// Ingress is the verdict of the policies selecting the destination Pod
+	Ingress     NetworkPolicyVerdict `json:"ingress"`
+	Explanation string               `json:"explanation"`
+}
+
+type NetworkPolicyVerdict struct {
+	Allowed bool `json:"allowed"`
+	// Isolated is true if any policy selects the Pod for this direction, otherwise all the traffic is allowed
+	Isolated bool `json:"isolated"`
+	// Policies are the policies selecting the Pod for this direction
+	Policies []string `json:"policies,omitempty"`
+	// AllowedBy are the policies with a rule allowing the traffic
+	AllowedBy []string `json:"allowedBy,omitempty"`
+}
+
+// NetworkPoliciesList lists the NetworkPolicies with their rules described in plain text, in all namespaces if no namespace is provided
+func (k *Kubernetes) NetworkPoliciesList(ctx context.Context, namespace string) ([]NetworkPolicy, error) {
+	policies, err := k.networkPolicies(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]NetworkPolicy, 0, len(policies))
+	for _, policy := range policies {
+		ret = append(ret, networkPolicySummary(&policy))
+	}
+	return ret, nil
+}
+
+// NetworkPoliciesSimulate evaluates the NetworkPolicies of the source and destination namespaces for the traffic
+// from the source to the destination port and explains the verdict.
+// The policies are evaluated as specified by Kubernetes, the enforcement depends on the network plugin of the cluster.
+func (k *Kubernetes) NetworkPoliciesSimulate(ctx context.Context, options NetworkPolicySimulateOptions) (*NetworkPolicySimulation, error) {
+	if options.Port <= 0 {
+		return nil, fmt.Errorf("port is required")
+	}
+	if options.Source.Pod == "" && len(options.Source.Labels) == 0 || options.Destination.Pod == "" && len(options.Destination.Labels) == 0 {
+		return nil, fmt.Errorf("the source and the destination require a pod name or labels")
+	}
+	options.Source.Namespace = k.NamespaceOrDefault(options.Source.Namespace)
+	options.Destination.Namespace = k.NamespaceOrDefault(options.Destination.Namespace)
+	namespaceLabels := make(map[string]map[string]string)
+	for _, endpoint := range []*NetworkPolicyEndpoint{&options.Source, &options.Destination} {
+		if err := k.networkPolicyEndpoint(ctx, endpoint); err != nil {
+			return nil, err
+		}
+		ns, err := k.ResourcesGet(ctx, namespaceGVK, "", endpoint.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		namespaceLabels[endpoint.Namespace] = ns.GetLabels()
+	}
+	egressPolicies, err := k.networkPolicies(ctx, options.Source.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	ingressPolicies := egressPolicies
+	if options.Destination.Namespace != options.Source.Namespace {
+		if ingressPolicies, err = k.networkPolicies(ctx, options.Destination.Namespace); err != nil {
+			return nil, err
+		}
+	}
+	return networkPoliciesSimulate(egressPolicies, ingressPolicies, namespaceLabels, options), nil
+}
+
+func (k *Kubernetes) networkPolicies(ctx context.Context, namespace string) ([]networkingv1.NetworkPolicy, error) {
+	list, err := k.ResourcesList(ctx, networkPolicyGVK, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var ret []networkingv1.NetworkPolicy
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		policy := networkingv1.NetworkPolicy{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &policy); err != nil {
+			return nil, err
+		}
+		ret = append(ret, policy)
+	}
+	return ret, nil
+}
+
+// networkPolicyEndpoint resolves the labels, IP, and ports of the endpoint Pod
+func (k *Kubernetes) networkPolicyEndpoint(ctx context.Context, endpoint *NetworkPolicyEndpoint) error {
+	if endpoint.Pod == "" {
+		return nil
+	}
+	pods, err := k.manager.accessControlClientSet.Pods(endpoint.Namespace)
+	if err != nil {
+		return err
+	}
+	pod, err := pods.Get(ctx, endpoint.Pod, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	endpoint.Labels = pod.Labels
+	endpoint.ip = pod.Status.PodIP
+	for _, container := range pod.Spec.Containers {
+		endpoint.ports = append(endpoint.ports, container.Ports...)
+	}
+	return nil
+}
+
+func networkPoliciesSimulate(egressPolicies, ingressPolicies []networkingv1.NetworkPolicy, namespaceLabels map[string]map[string]string, options NetworkPolicySimulateOptions) *NetworkPolicySimulation {
+	protocol := v1.Protocol(strings.ToUpper(options.Protocol))
+	if protocol == "" {
+		protocol = v1.ProtocolTCP
+	}
+	ret := &NetworkPolicySimulation{
+		Egress:  NetworkPolicyVerdict{Allowed: true},
+		Ingress: NetworkPolicyVerdict{Allowed: true},
+	}
+	for _, policy := range egressPolicies {
+		if !hasPolicyType(&policy, networkingv1.PolicyTypeEgress) || !selectorMatches(&policy.Spec.PodSelector, options.Source.Labels) {
+			continue
+		}
+		ret.Egress.Isolated = true
+		ret.Egress.Policies = append(ret.Egress.Policies, policy.Name)
+		for _, rule := range policy.Spec.Egress {
+			if peersMatch(rule.To, policy.Namespace, &options.Destination, namespaceLabels) &&
+				portsMatch(rule.Ports, options.Port, protocol, options.Destination.ports) {
+				ret.Egress.AllowedBy = append(ret.Egress.AllowedBy, policy.Name)
+				break
+			}
+		}
+	}
+	for _, policy := range ingressPolicies {
+		if !hasPolicyType(&policy, networkingv1.PolicyTypeIngress) || !selectorMatches(&policy.Spec.PodSelector, options.Destination.Labels) {
+			continue
+		}
+		ret.Ingress.Isolated = true
+		ret.Ingress.Policies = append(ret.Ingress.Policies, policy.Name)
+		for _, rule := range policy.Spec.Ingress {
+			if peersMatch(rule.From, policy.Namespace, &options.Source, namespaceLabels) &&
+				portsMatch(rule.Ports, options.Port, protocol, options.Destination.ports) {
+				ret.Ingress.AllowedBy = append(ret.Ingress.AllowedBy, policy.Name)
+				break
+			}
+		}
+	}
+	ret.Egress.Allowed = !ret.Egress.Isolated || len(ret.Egress.AllowedBy) > 0
+	ret.Ingress.Allowed = !ret.Ingress.Isolated || len(ret.Ingress.AllowedBy) > 0
+	ret.Allowed = ret.Egress.Allowed && ret.Ingress.Allowed
+	ret.Explanation = networkPolicyExplanation(ret, options, protocol)
+	return ret
+}
+
+func networkPolicyExplanation(simulation *NetworkPolicySimulation, options NetworkPolicySimulateOptions, protocol v1.Protocol) string {
+	traffic := fmt.Sprintf("%s/%d", protocol, options.Port)
+	var explanation []string
+	switch {
+	case !simulation.Egress.Isolated:
+		explanation = append(explanation, fmt.Sprintf("egress from the source is allowed, no NetworkPolicy in namespace %s selects it for egress", options.Source.Namespace))
+	case simulation.Egress.Allowed:
+		explanation = append(explanation, fmt.Sprintf("egress from the source to %s is allowed by %s", traffic, strings.Join(simulation.Egress.AllowedBy, ", ")))
+	default:
+		explanation = append(explanation, fmt.Sprintf("egress from the source to %s is denied, the source is selected by %s but no egress rule allows the destination",
+			traffic, strings.Join(simulation.Egress.Policies, ", ")))
+	}
+	switch {
+	case !simulation.Ingress.Isolated:
+		explanation = append(explanation, fmt.Sprintf("ingress to the destination is allowed, no NetworkPolicy in namespace %s selects it for ingress", options.Destination.Namespace))
+	case simulation.Ingress.Allowed:
+		explanation = append(explanation, fmt.Sprintf("ingress to the destination on %s is allowed by %s", traffic, strings.Join(simulation.Ingress.AllowedBy, ", ")))
+	default:
+		explanation = append(explanation, fmt.Sprintf("ingress to the destination on %s is denied, the destination is selected by %s but no ingress rule allows the source",
+			traffic, strings.Join(simulation.Ingress.Policies, ", ")))
+	}
+	verdict := "Traffic allowed: "
+	if !simulation.Allowed {
+		verdict = "Traffic denied: "
+	}
+	return verdict + strings.Join(explanation, "; ")
+}
+
+// hasPolicyType returns true if the policy applies to the direction, policies without policyTypes apply to ingress,
+// and to egress if they have egress rules
+func hasPolicyType(policy *networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return policyType == networkingv1.PolicyTypeIngress || len(policy.Spec.Egress) > 0
+	}
+	return slices.Contains(policy.Spec.PolicyTypes, policyType)
+}
+
+func selectorMatches(selector *metav1.LabelSelector, l map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(l))
+}
+
+// peersMatch returns true if any of the peers of a rule of a policy of the namespace matches the endpoint,
+// rules without peers match all the endpoints
+func peersMatch(peers []networkingv1.NetworkPolicyPeer, namespace string, endpoint *NetworkPolicyEndpoint, namespaceLabels map[string]map[string]string) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, peer := range peers {
+		switch {
+		case peer.IPBlock != nil:
+			if ipBlockMatches(peer.IPBlock, endpoint.ip) {
+				return true
+			}
+		case peer.NamespaceSelector != nil:
+			if selectorMatches(peer.NamespaceSelector, namespaceLabels[endpoint.Namespace]) &&
+				(peer.PodSelector == nil || selectorMatches(peer.PodSelector, endpoint.Labels)) {
+				return true
+			}
+		case peer.PodSelector != nil:
+			if endpoint.Namespace == namespace && selectorMatches(peer.PodSelector, endpoint.Labels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ipBlockMatches(ipBlock *networkingv1.IPBlock, ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	if _, cidr, err := net.ParseCIDR(ipBlock.CIDR); err != nil || !cidr.Contains(parsed) {
+		return false
+	}
+	for _, except := range ipBlock.Except {
+		if _, cidr, err := net.ParseCIDR(except); err == nil && cidr.Contains(parsed) {
+			return false
+		}
+	}
+	return true
+}
+
+// portsMatch returns true if any of the ports of a rule matches the port and protocol, rules without ports match all
+// the ports. Named ports are resolved with the container ports of the destination Pod.
+func portsMatch(ports []networkingv1.NetworkPolicyPort, port int32, protocol v1.Protocol, containerPorts []v1.ContainerPort) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, p := range ports {
+		if ptr.Deref(p.Protocol, v1.ProtocolTCP) != protocol {
+			continue
+		}
+		switch {
+		case p.Port == nil:
+			return true
+		case p.Port.Type == intstr.String:
+			for _, containerPort := range containerPorts {
+				if containerPort.Name == p.Port.StrVal && containerPort.ContainerPort == port {
+					return true
+				}
+			}
+		case p.EndPort != nil:
+			if port >= p.Port.IntVal && port <= *p.EndPort {
+				return true
+			}
+		case p.Port.IntVal == port:
+			return true
+		}
+	}
+	return false
+}
+
+func networkPolicySummary(policy *networkingv1.NetworkPolicy) NetworkPolicy {
+	ret := NetworkPolicy{
+		Namespace:   policy.Namespace,
+		Name:        policy.Name,
+		PodSelector: describeSelector(&policy.Spec.PodSelector, "all pods"),
+	}
+	for _, policyType := range []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress} {
+		if hasPolicyType(policy, policyType) {
+			ret.PolicyTypes = append(ret.PolicyTypes, string(policyType))
+		}
+	}
+	if hasPolicyType(policy, networkingv1.PolicyTypeIngress) && len(policy.Spec.Ingress) == 0 {
+		ret.Ingress = append(ret.Ingress, "deny all")
+	}
+	for _, rule := range policy.Spec.Ingress {
+		ret.Ingress = append(ret.Ingress, "allow from "+describePeers(rule.From)+" on "+describePorts(rule.Ports))
+	}
+	if hasPolicyType(policy, networkingv1.PolicyTypeEgress) && len(policy.Spec.Egress) == 0 {
+		ret.Egress = append(ret.Egress, "deny all")
+	}
+	for _, rule := range policy.Spec.Egress {
+		ret.Egress = append(ret.Egress, "allow to "+describePeers(rule.To)+" on "+describePorts(rule.Ports))
+	}
+	return ret
+}
+
+func describeSelector(selector *metav1.LabelSelector, empty string) string {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return err.Error()
+	}
+	if s.Empty() {
+		return empty
+	}
+	return s.String()
+}
+
+func describePeers(peers []networkingv1.NetworkPolicyPeer) string {
+	if len(peers) == 0 {
+		return "anywhere"
+	}
+	var ret []string
+	for _, peer := range peers {
+		switch {
+		case peer.IPBlock != nil && len(peer.IPBlock.Except) > 0:
+			ret = append(ret, fmt.Sprintf("%s except %s", peer.IPBlock.CIDR, strings.Join(peer.IPBlock.Except, ", ")))
+		case peer.IPBlock != nil:
+			ret = append(ret, peer.IPBlock.CIDR)
+		case peer.NamespaceSelector != nil && peer.PodSelector != nil:
+			ret = append(ret, fmt.Sprintf("pods (%s) in namespaces (%s)",
+				describeSelector(peer.PodSelector, "all"), describeSelector(peer.NamespaceSelector, "all")))
+		case peer.NamespaceSelector != nil:
+			ret = append(ret, fmt.Sprintf("namespaces (%s)", describeSelector(peer.NamespaceSelector, "all")))
+		case peer.PodSelector != nil:
+			ret = append(ret, fmt.Sprintf("pods (%s) in the same namespace", describeSelector(peer.PodSelector, "all")))
+		}
+	}
+	return strings.Join(ret, ", ")
+}
+
+func describePorts(ports []networkingv1.NetworkPolicyPort) string {
+	if len(ports) == 0 {
+		return "all ports"
+	}
+	var ret []string
+	for _, p := range ports {
+		protocol := ptr.Deref(p.Protocol, v1.ProtocolTCP)
+		switch {
+		case p.Port == nil:
+			ret = append(ret, string(protocol))
+		case p.EndPort != nil:
+			ret = append(ret, fmt.Sprintf("%s/%s-%d", protocol, p.Port.String(), *p.EndPort))
+		default:
+			ret = append(ret, fmt.Sprintf("%s/%s", protocol, p.Port.String()))
+		}
+	}
+	return strings.Join(ret, ", ")
+}
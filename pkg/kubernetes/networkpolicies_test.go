@@ -0,0 +1,122 @@
+package kubernetes
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestNetworkPoliciesSimulate(t *testing.T) {
+	defaultDeny := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "backend", Name: "default-deny"},
+		Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}},
+	}
+	allowFrontend := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "backend", Name: "allow-frontend"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "frontend"}},
+					PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				}},
+				Ports: []networkingv1.NetworkPolicyPort{{Port: ptr.To(intstr.FromString("http"))}},
+			}},
+		},
+	}
+	namespaceLabels := map[string]map[string]string{"frontend": {"team": "frontend"}, "backend": {"team": "backend"}}
+	options := func(sourceLabels map[string]string, port int32) NetworkPolicySimulateOptions {
+		return NetworkPolicySimulateOptions{
+			Source: NetworkPolicyEndpoint{Namespace: "frontend", Labels: sourceLabels},
+			Destination: NetworkPolicyEndpoint{Namespace: "backend", Labels: map[string]string{"app": "api"},
+				ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+			Port: port,
+		}
+	}
+	t.Run("allows the traffic without policies", func(t *testing.T) {
+		simulation := networkPoliciesSimulate(nil, nil, namespaceLabels, options(map[string]string{"app": "web"}, 8080))
+		if !simulation.Allowed || simulation.Ingress.Isolated || simulation.Egress.Isolated {
+			t.Errorf("unexpected simulation %v", simulation)
+		}
+	})
+	t.Run("allows the traffic matching a rule with a named port", func(t *testing.T) {
+		policies := []networkingv1.NetworkPolicy{defaultDeny, allowFrontend}
+		simulation := networkPoliciesSimulate(nil, policies, namespaceLabels, options(map[string]string{"app": "web"}, 8080))
+		if !simulation.Allowed || !slices.Equal(simulation.Ingress.Policies, []string{"default-deny", "allow-frontend"}) ||
+			!slices.Equal(simulation.Ingress.AllowedBy, []string{"allow-frontend"}) {
+			t.Errorf("unexpected simulation %v", simulation)
+		}
+		if !strings.HasPrefix(simulation.Explanation, "Traffic allowed: ") || !strings.Contains(simulation.Explanation, "on TCP/8080 is allowed by allow-frontend") {
+			t.Errorf("unexpected explanation %s", simulation.Explanation)
+		}
+	})
+	t.Run("denies the traffic from other pods", func(t *testing.T) {
+		policies := []networkingv1.NetworkPolicy{defaultDeny, allowFrontend}
+		simulation := networkPoliciesSimulate(nil, policies, namespaceLabels, options(map[string]string{"app": "batch"}, 8080))
+		if simulation.Allowed || simulation.Ingress.Allowed || len(simulation.Ingress.AllowedBy) > 0 {
+			t.Errorf("unexpected simulation %v", simulation)
+		}
+		if !strings.Contains(simulation.Explanation, "the destination is selected by default-deny, allow-frontend but no ingress rule allows the source") {
+			t.Errorf("unexpected explanation %s", simulation.Explanation)
+		}
+	})
+	t.Run("denies the traffic to other ports", func(t *testing.T) {
+		policies := []networkingv1.NetworkPolicy{defaultDeny, allowFrontend}
+		if simulation := networkPoliciesSimulate(nil, policies, namespaceLabels, options(map[string]string{"app": "web"}, 9090)); simulation.Allowed {
+			t.Errorf("unexpected simulation %v", simulation)
+		}
+	})
+	t.Run("denies the egress of isolated sources", func(t *testing.T) {
+		egressDeny := networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "frontend", Name: "egress-dns-only"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				Egress: []networkingv1.NetworkPolicyEgressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{{Protocol: ptr.To(v1.ProtocolUDP), Port: ptr.To(intstr.FromInt32(53))}},
+				}},
+			},
+		}
+		simulation := networkPoliciesSimulate([]networkingv1.NetworkPolicy{egressDeny}, nil, namespaceLabels, options(map[string]string{"app": "web"}, 8080))
+		if simulation.Allowed || simulation.Egress.Allowed || !simulation.Ingress.Allowed {
+			t.Errorf("unexpected simulation %v", simulation)
+		}
+	})
+}
+
+func TestIPBlockMatches(t *testing.T) {
+	ipBlock := &networkingv1.IPBlock{CIDR: "10.0.0.0/16", Except: []string{"10.0.1.0/24"}}
+	for ip, expected := range map[string]bool{"10.0.0.5": true, "10.0.1.5": false, "192.168.0.1": false, "": false} {
+		if ipBlockMatches(ipBlock, ip) != expected {
+			t.Errorf("expected ipBlockMatches(%q) to be %v", ip, expected)
+		}
+	}
+}
+
+func TestNetworkPolicySummary(t *testing.T) {
+	summary := networkPolicySummary(&networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "backend", Name: "api"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}}},
+				Ports: []networkingv1.NetworkPolicyPort{{Port: ptr.To(intstr.FromInt32(8000)), EndPort: ptr.To(int32(8080))}},
+			}},
+		},
+	})
+	if summary.PodSelector != "app=api" || !slices.Equal(summary.PolicyTypes, []string{"Ingress", "Egress"}) {
+		t.Errorf("unexpected summary %v", summary)
+	}
+	if !slices.Equal(summary.Ingress, []string{"allow from pods (app=web) in the same namespace on TCP/8000-8080"}) {
+		t.Errorf("unexpected ingress %v", summary.Ingress)
+	}
+	if !slices.Equal(summary.Egress, []string{"deny all"}) {
+		t.Errorf("unexpected egress %v", summary.Egress)
+	}
+}
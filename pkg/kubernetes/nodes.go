@@ -0,0 +1,75 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/metrics/pkg/apis/metrics"
+	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+type NodesTopOptions struct {
+	metav1.ListOptions
+	Name string
+}
+
+// NodesTop returns the resource consumption of the nodes along with their allocatable resources (keyed by node name)
+func (k *Kubernetes) NodesTop(ctx context.Context, options NodesTopOptions) (*metrics.NodeMetricsList, map[string]v1.ResourceList, error) {
+	if !k.supportsGroupVersion(metrics.GroupName + "/" + metricsv1beta1api.SchemeGroupVersion.Version) {
+		return nil, nil, errors.New("metrics API is not available")
+	}
+	nodeMetrics, err := k.manager.accessControlClientSet.NodesMetricses(ctx, options.Name, options.ListOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	nodes, err := k.manager.accessControlClientSet.Nodes()
+	if err != nil {
+		return nil, nil, err
+	}
+	allocatable := make(map[string]v1.ResourceList)
+	if options.Name != "" {
+		node, err := nodes.Get(ctx, options.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		allocatable[node.Name] = node.Status.Allocatable
+	} else {
+		nodeList, err := nodes.List(ctx, options.ListOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, node := range nodeList.Items {
+			allocatable[node.Name] = node.Status.Allocatable
+		}
+	}
+	return nodeMetrics, allocatable, nil
+}
+
+// NodesCordon marks the node as unschedulable (cordon) or schedulable (uncordon), returns false if the node already was
+func (k *Kubernetes) NodesCordon(ctx context.Context, name string, unschedulable bool) (bool, error) {
+	nodes, err := k.manager.accessControlClientSet.Nodes()
+	if err != nil {
+		return false, err
+	}
+	node, err := nodes.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if node.Spec.Unschedulable == unschedulable {
+		return false, nil
+	}
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	if _, err = nodes.Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{
+		FieldManager: version.BinaryName,
+		DryRun:       dryRun(ctx),
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
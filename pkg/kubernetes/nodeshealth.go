@@ -0,0 +1,275 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	resourcehelper "k8s.io/kubectl/pkg/util/resource"
+)
+
+const (
+	// nodesHealthRequestsThreshold is the percentage of the allocatable resources requested above which a node is flagged
+	nodesHealthRequestsThreshold = 90
+	// nodesHealthLimitsThreshold is the percentage of the allocatable resources of the limits above which a node is flagged as overcommitted
+	nodesHealthLimitsThreshold = 200
+	// nodesHealthOutlierPoints is the number of points above the average requests of the nodes above which a node is flagged as an outlier
+	nodesHealthOutlierPoints = 30
+)
+
+// nodesHealthResources are the resources whose allocation is reported
+var nodesHealthResources = []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods}
+
+// NodesHealth is the health of the nodes with the versions running in the cluster
+type NodesHealth struct {
+	Total    int `json:"total"`
+	Ready    int `json:"ready"`
+	Flagged  int `json:"flagged"`
+	Pressure int `json:"pressure"`
+	// KubeletVersions and RuntimeVersions are the number of nodes running each version
+	KubeletVersions map[string]int `json:"kubeletVersions,omitempty"`
+	RuntimeVersions map[string]int `json:"runtimeVersions,omitempty"`
+	// Nodes are sorted with the most flagged first
+	Nodes []NodeHealth `json:"nodes"`
+}
+
+// NodeHealth are the conditions, the allocated resources, and the versions of a node, with the problems and outliers flagged
+type NodeHealth struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles,omitempty"`
+	Ready bool     `json:"ready"`
+	// NotReadyFor is the time since the node is not ready
+	NotReadyFor   string `json:"notReadyFor,omitempty"`
+	Unschedulable bool   `json:"unschedulable,omitempty"`
+	// Conditions are the abnormal conditions (Ready not true, pressure and problem conditions true)
+	Conditions       []NodeHealthCondition  `json:"conditions,omitempty"`
+	Allocation       []NodeHealthAllocation `json:"allocation"`
+	KubeletVersion   string                 `json:"kubeletVersion"`
+	RuntimeVersion   string                 `json:"runtimeVersion"`
+	KernelVersion    string                 `json:"kernelVersion,omitempty"`
+	OSImage          string                 `json:"osImage,omitempty"`
+	Flags            []string               `json:"flags,omitempty"`
+	requestsPercents map[v1.ResourceName]int64
+}
+
+type NodeHealthCondition struct {
+	Type    string     `json:"type"`
+	Status  string     `json:"status"`
+	Reason  string     `json:"reason,omitempty"`
+	Message string     `json:"message,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+// NodeHealthAllocation is the allocatable amount of a resource and the sum of the requests and limits of the non-terminated pods of the node
+type NodeHealthAllocation struct {
+	Resource        string `json:"resource"`
+	Allocatable     string `json:"allocatable"`
+	Requests        string `json:"requests"`
+	RequestsPercent int64  `json:"requestsPercent"`
+	Limits          string `json:"limits,omitempty"`
+	LimitsPercent   int64  `json:"limitsPercent,omitempty"`
+}
+
+// NodesHealthCheck reports the abnormal conditions, the allocated resources, and the versions of the nodes (optionally filtered by name or label selector).
+// The nodes not ready or under pressure, with most of their resources requested, overcommitted, or running a version different from most nodes are flagged.
+func NodesHealthCheck(ctx context.Context, list ResourcesLister, name, labelSelector string) (*NodesHealth, error) {
+	now := time.Now()
+	options := metav1.ListOptions{LabelSelector: labelSelector}
+	if name != "" {
+		options = metav1.ListOptions{FieldSelector: "metadata.name=" + name}
+	}
+	nodeList, err := list(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "Node"}, "", ResourceListOptions{ListOptions: options})
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*v1.Node, 0, len(nodeList.(*unstructured.UnstructuredList).Items))
+	for _, item := range nodeList.(*unstructured.UnstructuredList).Items {
+		node := &v1.Node{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, node); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	if name != "" && len(nodes) == 0 {
+		return nil, fmt.Errorf("node %s not found", name)
+	}
+	podOptions := metav1.ListOptions{FieldSelector: "status.phase!=Succeeded,status.phase!=Failed"}
+	if name != "" {
+		podOptions.FieldSelector += ",spec.nodeName=" + name
+	}
+	podList, err := list(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "", ResourceListOptions{ListOptions: podOptions})
+	if err != nil {
+		return nil, err
+	}
+	pods := make(map[string][]*v1.Pod)
+	for _, item := range podList.(*unstructured.UnstructuredList).Items {
+		pod := &v1.Pod{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, pod); err != nil {
+			return nil, err
+		}
+		if pod.Spec.NodeName != "" {
+			pods[pod.Spec.NodeName] = append(pods[pod.Spec.NodeName], pod)
+		}
+	}
+	ret := &NodesHealth{KubeletVersions: make(map[string]int), RuntimeVersions: make(map[string]int)}
+	for _, node := range nodes {
+		health := nodeHealth(node, pods[node.Name], now)
+		ret.Total++
+		if health.Ready {
+			ret.Ready++
+		}
+		if slices.ContainsFunc(health.Conditions, func(c NodeHealthCondition) bool { return strings.HasSuffix(c.Type, "Pressure") }) {
+			ret.Pressure++
+		}
+		ret.KubeletVersions[health.KubeletVersion]++
+		ret.RuntimeVersions[health.RuntimeVersion]++
+		ret.Nodes = append(ret.Nodes, health)
+	}
+	flagNodesOutliers(ret)
+	for _, node := range ret.Nodes {
+		if len(node.Flags) > 0 {
+			ret.Flagged++
+		}
+	}
+	slices.SortStableFunc(ret.Nodes, func(a, b NodeHealth) int {
+		return cmp.Or(cmp.Compare(len(b.Flags), len(a.Flags)), strings.Compare(a.Name, b.Name))
+	})
+	return ret, nil
+}
+
+// nodeHealth returns the abnormal conditions and the allocated resources of the node, flagging the problems of the node itself
+func nodeHealth(node *v1.Node, pods []*v1.Pod, now time.Time) NodeHealth {
+	ret := NodeHealth{
+		Name:             node.Name,
+		Unschedulable:    node.Spec.Unschedulable,
+		KubeletVersion:   node.Status.NodeInfo.KubeletVersion,
+		RuntimeVersion:   node.Status.NodeInfo.ContainerRuntimeVersion,
+		KernelVersion:    node.Status.NodeInfo.KernelVersion,
+		OSImage:          node.Status.NodeInfo.OSImage,
+		requestsPercents: make(map[v1.ResourceName]int64),
+	}
+	for label := range node.Labels {
+		if role, ok := strings.CutPrefix(label, "node-role.kubernetes.io/"); ok && role != "" {
+			ret.Roles = append(ret.Roles, role)
+		}
+	}
+	slices.Sort(ret.Roles)
+	ready := false
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			ready = condition.Status == v1.ConditionTrue
+			if !ready && !condition.LastTransitionTime.IsZero() {
+				ret.NotReadyFor = now.Sub(condition.LastTransitionTime.Time).Truncate(time.Second).String()
+			}
+		}
+		if (condition.Type == v1.NodeReady) == (condition.Status == v1.ConditionTrue) {
+			continue
+		}
+		abnormal := NodeHealthCondition{Type: string(condition.Type), Status: string(condition.Status), Reason: condition.Reason, Message: condition.Message}
+		if !condition.LastTransitionTime.IsZero() {
+			abnormal.Since = &condition.LastTransitionTime.Time
+		}
+		ret.Conditions = append(ret.Conditions, abnormal)
+		if condition.Type != v1.NodeReady {
+			ret.Flags = append(ret.Flags, string(condition.Type))
+		}
+	}
+	ret.Ready = ready
+	if !ready {
+		flag := "NotReady"
+		if ret.NotReadyFor != "" {
+			flag += " for " + ret.NotReadyFor
+		}
+		ret.Flags = append([]string{flag}, ret.Flags...)
+	}
+	if ret.Unschedulable {
+		ret.Flags = append(ret.Flags, "cordoned")
+	}
+	requests, limits := v1.ResourceList{}, v1.ResourceList{}
+	for _, pod := range pods {
+		podRequests, podLimits := resourcehelper.PodRequestsAndLimits(pod)
+		for name, quantity := range podRequests {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+		for name, quantity := range podLimits {
+			total := limits[name]
+			total.Add(quantity)
+			limits[name] = total
+		}
+	}
+	requests[v1.ResourcePods] = *resource.NewQuantity(int64(len(pods)), resource.DecimalSI)
+	for _, name := range nodesHealthResources {
+		allocatable, ok := node.Status.Allocatable[name]
+		if !ok || allocatable.IsZero() {
+			continue
+		}
+		allocation := NodeHealthAllocation{Resource: string(name), Allocatable: allocatable.String()}
+		requested := requests[name]
+		allocation.Requests = requested.String()
+		allocation.RequestsPercent = requested.MilliValue() * 100 / allocatable.MilliValue()
+		ret.requestsPercents[name] = allocation.RequestsPercent
+		if limit, ok := limits[name]; ok && name != v1.ResourcePods {
+			allocation.Limits = limit.String()
+			allocation.LimitsPercent = limit.MilliValue() * 100 / allocatable.MilliValue()
+		}
+		ret.Allocation = append(ret.Allocation, allocation)
+		if allocation.RequestsPercent >= nodesHealthRequestsThreshold {
+			ret.Flags = append(ret.Flags, fmt.Sprintf("%s requests at %d%% of allocatable", name, allocation.RequestsPercent))
+		}
+		if allocation.LimitsPercent >= nodesHealthLimitsThreshold {
+			ret.Flags = append(ret.Flags, fmt.Sprintf("%s limits overcommitted at %d%% of allocatable", name, allocation.LimitsPercent))
+		}
+	}
+	return ret
+}
+
+// flagNodesOutliers flags the nodes requested well above the average of the nodes, and the nodes running a version different from most nodes
+func flagNodesOutliers(health *NodesHealth) {
+	if len(health.Nodes) < 2 {
+		return
+	}
+	kubeletVersion, runtimeVersion := mostCommon(health.KubeletVersions), mostCommon(health.RuntimeVersions)
+	for _, name := range nodesHealthResources {
+		var total int64
+		for _, node := range health.Nodes {
+			total += node.requestsPercents[name]
+		}
+		average := total / int64(len(health.Nodes))
+		for i, node := range health.Nodes {
+			if percent := node.requestsPercents[name]; percent-average >= nodesHealthOutlierPoints && percent < nodesHealthRequestsThreshold {
+				health.Nodes[i].Flags = append(health.Nodes[i].Flags, fmt.Sprintf("%s requests at %d%%, well above the %d%% average of the nodes", name, percent, average))
+			}
+		}
+	}
+	for i, node := range health.Nodes {
+		if len(health.KubeletVersions) > 1 && node.KubeletVersion != kubeletVersion {
+			health.Nodes[i].Flags = append(health.Nodes[i].Flags, fmt.Sprintf("kubelet %s differs from most nodes (%s)", node.KubeletVersion, kubeletVersion))
+		}
+		if len(health.RuntimeVersions) > 1 && node.RuntimeVersion != runtimeVersion {
+			health.Nodes[i].Flags = append(health.Nodes[i].Flags, fmt.Sprintf("runtime %s differs from most nodes (%s)", node.RuntimeVersion, runtimeVersion))
+		}
+	}
+}
+
+// mostCommon returns the most common value, the lowest one in case of a tie
+func mostCommon(counts map[string]int) string {
+	ret := ""
+	for _, value := range slices.Sorted(maps.Keys(counts)) {
+		if ret == "" || counts[value] > counts[ret] {
+			ret = value
+		}
+	}
+	return ret
+}
@@ -0,0 +1,127 @@
+package kubernetes
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestNodesHealthCheck(t *testing.T) {
+	node := func(name, kubelet string, conditions ...v1.NodeCondition) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"node-role.kubernetes.io/worker": ""}},
+			Status: v1.NodeStatus{
+				Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("8Gi"), v1.ResourcePods: resource.MustParse("110")},
+				Conditions:  append([]v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}, conditions...),
+				NodeInfo:    v1.NodeSystemInfo{KubeletVersion: kubelet, ContainerRuntimeVersion: "cri-o://1.31.0"},
+			},
+		}
+	}
+	pod := func(name, nodeName, cpu, cpuLimit string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}, Spec: v1.PodSpec{NodeName: nodeName, Containers: []v1.Container{{
+			Name: "app", Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+				Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpuLimit)},
+			},
+		}}}}
+	}
+	notReady := node("node-not-ready", "v1.31.0")
+	notReady.Status.Conditions[0] = v1.NodeCondition{Type: v1.NodeReady, Status: v1.ConditionUnknown, Reason: "NodeStatusUnknown",
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour))}
+	objects := map[string][]runtime.Object{
+		"Node": {
+			node("node-busy", "v1.31.0"),
+			node("node-idle", "v1.31.0"),
+			node("node-old", "v1.30.4", v1.NodeCondition{Type: v1.NodeDiskPressure, Status: v1.ConditionTrue, Reason: "KubeletHasDiskPressure"}),
+			notReady,
+		},
+		"Pod": {
+			pod("busy-1", "node-busy", "2", "8"),
+			pod("busy-2", "node-busy", "1800m", "1"),
+			pod("idle", "node-idle", "100m", "100m"),
+			pod("pending", "", "1", "1"),
+		},
+	}
+	list := func(_ context.Context, gvk *schema.GroupVersionKind, _ string, _ ResourceListOptions) (runtime.Unstructured, error) {
+		ret := &unstructured.UnstructuredList{}
+		for _, object := range objects[gvk.Kind] {
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+			if err != nil {
+				return nil, err
+			}
+			ret.Items = append(ret.Items, unstructured.Unstructured{Object: u})
+		}
+		return ret, nil
+	}
+	health, err := NodesHealthCheck(context.Background(), list, "", "")
+	if err != nil {
+		t.Fatalf("failed to check nodes health: %v", err)
+	}
+	nodes := make(map[string]NodeHealth)
+	for _, n := range health.Nodes {
+		nodes[n.Name] = n
+	}
+	t.Run("summarizes the nodes", func(t *testing.T) {
+		if health.Total != 4 || health.Ready != 3 || health.Pressure != 1 || health.Flagged != 3 ||
+			health.KubeletVersions["v1.31.0"] != 3 || health.KubeletVersions["v1.30.4"] != 1 {
+			t.Errorf("unexpected health %v", health)
+		}
+	})
+	t.Run("reports the not ready duration", func(t *testing.T) {
+		n := nodes["node-not-ready"]
+		if n.Ready || n.NotReadyFor != "2h0m0s" || len(n.Conditions) != 1 || n.Conditions[0].Reason != "NodeStatusUnknown" ||
+			!slices.Equal(n.Flags, []string{"NotReady for 2h0m0s"}) {
+			t.Errorf("unexpected node %v", n)
+		}
+	})
+	t.Run("reports the allocation of the pods of the node", func(t *testing.T) {
+		n := nodes["node-busy"]
+		expected := []NodeHealthAllocation{
+			{Resource: "cpu", Allocatable: "4", Requests: "3800m", RequestsPercent: 95, Limits: "9", LimitsPercent: 225},
+			{Resource: "memory", Allocatable: "8Gi", Requests: "0", RequestsPercent: 0},
+			{Resource: "pods", Allocatable: "110", Requests: "2", RequestsPercent: 1},
+		}
+		if !slices.Equal(n.Allocation, expected) {
+			t.Errorf("unexpected allocation %v", n.Allocation)
+		}
+		if !slices.Equal(n.Flags, []string{"cpu requests at 95% of allocatable", "cpu limits overcommitted at 225% of allocatable"}) {
+			t.Errorf("unexpected flags %v", n.Flags)
+		}
+	})
+	t.Run("flags the pressure conditions and the version outliers", func(t *testing.T) {
+		n := nodes["node-old"]
+		if !slices.Equal(n.Flags, []string{"DiskPressure", "kubelet v1.30.4 differs from most nodes (v1.31.0)"}) || !slices.Equal(n.Roles, []string{"worker"}) {
+			t.Errorf("unexpected node %v", n)
+		}
+	})
+	t.Run("doesn't flag the healthy nodes, listed last", func(t *testing.T) {
+		if n := health.Nodes[len(health.Nodes)-1]; n.Name != "node-idle" || len(n.Flags) != 0 {
+			t.Errorf("unexpected node %v", n)
+		}
+	})
+}
+
+func TestFlagNodesOutliers(t *testing.T) {
+	health := &NodesHealth{
+		KubeletVersions: map[string]int{"v1.31.0": 3},
+		RuntimeVersions: map[string]int{"containerd://1.7.0": 3},
+		Nodes: []NodeHealth{
+			{Name: "a", requestsPercents: map[v1.ResourceName]int64{v1.ResourceMemory: 80}},
+			{Name: "b", requestsPercents: map[v1.ResourceName]int64{v1.ResourceMemory: 10}},
+			{Name: "c", requestsPercents: map[v1.ResourceName]int64{v1.ResourceMemory: 15}},
+		},
+	}
+	flagNodesOutliers(health)
+	if !slices.Equal(health.Nodes[0].Flags, []string{"memory requests at 80%, well above the 35% average of the nodes"}) ||
+		len(health.Nodes[1].Flags) != 0 || len(health.Nodes[2].Flags) != 0 {
+		t.Errorf("unexpected nodes %v", health.Nodes)
+	}
+}
@@ -0,0 +1,273 @@
+package kubernetes
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	ObjectCountsSourceMetrics = "apiserver metrics"
+	ObjectCountsSourceList    = "list"
+	// objectCountsLargeThreshold is the number of objects above which a resource type is flagged
+	objectCountsLargeThreshold = 10000
+	// objectCountsEventsThreshold is the number of events above which an event storm is suspected (events are kept for an hour by default)
+	objectCountsEventsThreshold = 5000
+	// objectCountsFinishedJobsThreshold is the number of finished Jobs above which they are flagged
+	objectCountsFinishedJobsThreshold = 100
+	// objectCountsOldReplicaSetsThreshold is the number of ReplicaSets scaled down to zero above which they are flagged
+	objectCountsOldReplicaSetsThreshold = 100
+)
+
+var (
+	jobGVK = &schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+	// objectCountsMetrics are the apiserver metrics of the number of stored objects, the newest first
+	objectCountsMetrics = []string{"apiserver_resource_objects", "apiserver_storage_objects", "etcd_object_counts"}
+)
+
+// ObjectCounts are the number of objects stored by the API server for each resource type, with the resource types growing out of control
+type ObjectCounts struct {
+	// Source is apiserver metrics if the counts were read from the metrics of the API server, list if each resource type was listed
+	Source string `json:"source"`
+	Total  int64  `json:"total"`
+	// Resources are the resource types with the most objects, the most first
+	Resources []ObjectCount `json:"resources"`
+	// Findings are the resource types with a runaway growth and how to clean them up
+	Findings []ObjectCountFinding `json:"findings,omitempty"`
+}
+
+type ObjectCount struct {
+	// Resource is the plural name and group of the resource type (e.g. replicasets.apps)
+	Resource string `json:"resource"`
+	Count    int64  `json:"count"`
+	// AtLeast is set when the API server didn't report the remaining items of the list, the count is a lower bound
+	AtLeast bool `json:"atLeast,omitempty"`
+}
+
+type ObjectCountFinding struct {
+	Resource string `json:"resource"`
+	Count    int64  `json:"count"`
+	Message  string `json:"message"`
+}
+
+// ObjectCounts reports the number of objects of each resource type (the top ones only if top is positive), read from the object count metrics
+// of the API server if allowed, or by listing each resource type with limit=1 and remainingItemCount otherwise.
+// The events, the finished Jobs, the old ReplicaSets, and the resource types with more than 10000 objects are reported as findings.
+func (k *Kubernetes) ObjectCounts(ctx context.Context, top int) (*ObjectCounts, error) {
+	apiResources, err := k.APIResources(ctx, APIResourcesOptions{Verbs: []string{"list"}})
+	if err != nil {
+		return nil, err
+	}
+	resources := make(map[string]APIResource, len(apiResources))
+	for _, apiResource := range apiResources {
+		resources[objectCountResource(apiResource)] = apiResource
+	}
+	// The events of the events.k8s.io group are the same objects as the core events, they are counted once
+	delete(resources, "events.events.k8s.io")
+	ret := &ObjectCounts{Source: ObjectCountsSourceMetrics}
+	if metrics, metricsErr := k.manager.discoveryClient.RESTClient().Get().AbsPath("/metrics").DoRaw(ctx); metricsErr == nil {
+		counts := parseObjectCountMetrics(metrics)
+		for resource, count := range counts {
+			if _, ok := resources[resource]; ok {
+				ret.Resources = append(ret.Resources, ObjectCount{Resource: resource, Count: count})
+			}
+		}
+	}
+	if len(ret.Resources) == 0 {
+		ret.Source = ObjectCountsSourceList
+		for _, resource := range slices.Sorted(maps.Keys(resources)) {
+			if count, ok := k.objectCountList(ctx, resources[resource]); ok {
+				count.Resource = resource
+				ret.Resources = append(ret.Resources, count)
+			}
+		}
+	}
+	for _, count := range ret.Resources {
+		ret.Total += count.Count
+	}
+	slices.SortFunc(ret.Resources, func(a, b ObjectCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Resource, b.Resource))
+	})
+	counts := make(map[string]int64, len(ret.Resources))
+	for _, count := range ret.Resources {
+		counts[count.Resource] = count.Count
+	}
+	ret.Findings = objectCountFindings(counts)
+	if jobs, jobsErr := k.ResourcesList(ctx, jobGVK, "", ResourceListOptions{}); jobsErr == nil {
+		if finding := finishedJobsFinding(jobs.(*unstructured.UnstructuredList).Items); finding != nil {
+			ret.Findings = append(ret.Findings, *finding)
+		}
+	}
+	if replicaSets, replicaSetsErr := k.ResourcesList(ctx, &replicaSetGVK, "", ResourceListOptions{}); replicaSetsErr == nil {
+		if finding := oldReplicaSetsFinding(replicaSets.(*unstructured.UnstructuredList).Items); finding != nil {
+			ret.Findings = append(ret.Findings, *finding)
+		}
+	}
+	if top > 0 && len(ret.Resources) > top {
+		ret.Resources = ret.Resources[:top]
+	}
+	return ret, nil
+}
+
+// objectCountList counts the objects of the resource type by listing a single item, the API server reports the remaining items
+func (k *Kubernetes) objectCountList(ctx context.Context, apiResource APIResource) (ObjectCount, bool) {
+	gv, err := schema.ParseGroupVersion(apiResource.APIVersion)
+	if err != nil {
+		return ObjectCount{}, false
+	}
+	list, err := k.ResourcesList(ctx, &schema.GroupVersionKind{Group: gv.Group, Version: gv.Version, Kind: apiResource.Kind}, "",
+		ResourceListOptions{ListOptions: metav1.ListOptions{Limit: 1}})
+	if err != nil {
+		return ObjectCount{}, false
+	}
+	unstructuredList := list.(*unstructured.UnstructuredList)
+	ret := ObjectCount{Count: int64(len(unstructuredList.Items))}
+	if remaining := unstructuredList.GetRemainingItemCount(); remaining != nil {
+		ret.Count += *remaining
+	} else if unstructuredList.GetContinue() != "" {
+		ret.AtLeast = true
+	}
+	return ret, true
+}
+
+// objectCountResource returns the name of the resource type as reported by the API server metrics (e.g. pods, replicasets.apps)
+func objectCountResource(apiResource APIResource) string {
+	if group, _, ok := strings.Cut(apiResource.APIVersion, "/"); ok {
+		return apiResource.Name + "." + group
+	}
+	return apiResource.Name
+}
+
+// parseObjectCountMetrics returns the number of objects by resource type of the first object count metric found in the Prometheus text format,
+// the negative counts (resource types not tracked) are ignored
+func parseObjectCountMetrics(metrics []byte) map[string]int64 {
+	found := make(map[string]map[string]int64)
+	scanner := bufio.NewScanner(bytes.NewReader(metrics))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		name, rest, ok := strings.Cut(line, "{")
+		if !ok || !slices.Contains(objectCountsMetrics, name) {
+			continue
+		}
+		labels, value, ok := strings.Cut(rest, "} ")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		count, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil || count < 0 {
+			continue
+		}
+		resource, group := metricLabel(labels, "resource"), metricLabel(labels, "group")
+		if resource == "" {
+			continue
+		}
+		if group != "" {
+			resource += "." + group
+		}
+		if found[name] == nil {
+			found[name] = make(map[string]int64)
+		}
+		found[name][resource] = int64(count)
+	}
+	for _, name := range objectCountsMetrics {
+		if counts, ok := found[name]; ok {
+			return counts
+		}
+	}
+	return nil
+}
+
+// metricLabel returns the value of the label of a Prometheus text format sample (e.g. resource="pods",group="")
+func metricLabel(labels, name string) string {
+	for _, label := range strings.Split(labels, ",") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(label), name+"="); ok {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// objectCountFindings flags the event storms and the resource types with too many objects
+func objectCountFindings(counts map[string]int64) []ObjectCountFinding {
+	var ret []ObjectCountFinding
+	if events := counts["events"]; events >= objectCountsEventsThreshold {
+		ret = append(ret, ObjectCountFinding{Resource: "events", Count: events,
+			Message: "event storm suspected, the events are kept for the API server --event-ttl (1 hour by default): look for the most frequent Warning events and fix the objects emitting them"})
+	}
+	for _, resource := range slices.Sorted(maps.Keys(counts)) {
+		if count := counts[resource]; count >= objectCountsLargeThreshold && resource != "events" {
+			ret = append(ret, ObjectCountFinding{Resource: resource, Count: count,
+				Message: fmt.Sprintf("more than %d objects, large lists slow down the API server and etcd: check the controller creating them and whether the old ones are cleaned up", objectCountsLargeThreshold)})
+		}
+	}
+	return ret
+}
+
+// finishedJobsFinding flags the finished (complete or failed) Jobs piling up, nil if there are only a few
+func finishedJobsFinding(items []unstructured.Unstructured) *ObjectCountFinding {
+	var finished, withoutTTL int64
+	namespaces := make(map[string]int)
+	for _, item := range items {
+		job := &batchv1.Job{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, job); err != nil {
+			continue
+		}
+		if !slices.ContainsFunc(job.Status.Conditions, func(c batchv1.JobCondition) bool {
+			return (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == "True"
+		}) {
+			continue
+		}
+		finished++
+		namespaces[job.Namespace]++
+		if job.Spec.TTLSecondsAfterFinished == nil {
+			withoutTTL++
+		}
+	}
+	if finished < objectCountsFinishedJobsThreshold {
+		return nil
+	}
+	return &ObjectCountFinding{Resource: "jobs.batch", Count: finished,
+		Message: fmt.Sprintf("%d finished Jobs (%d without ttlSecondsAfterFinished), most in %s: set ttlSecondsAfterFinished on the Jobs, "+
+			"lower the successfulJobsHistoryLimit and failedJobsHistoryLimit of the CronJobs, or delete the finished Jobs", finished, withoutTTL, strings.Join(topReasons(namespaces, 3), ", "))}
+}
+
+// oldReplicaSetsFinding flags the ReplicaSets of Deployments scaled down to zero piling up, nil if there are only a few
+func oldReplicaSetsFinding(items []unstructured.Unstructured) *ObjectCountFinding {
+	var old int64
+	deployments := make(map[string]int)
+	for _, item := range items {
+		replicas, found, _ := unstructured.NestedInt64(item.Object, "spec", "replicas")
+		if !found || replicas != 0 {
+			continue
+		}
+		for _, owner := range item.GetOwnerReferences() {
+			if owner.Kind == "Deployment" {
+				old++
+				deployments[item.GetNamespace()+"/"+owner.Name]++
+			}
+		}
+	}
+	if old < objectCountsOldReplicaSetsThreshold {
+		return nil
+	}
+	return &ObjectCountFinding{Resource: "replicasets.apps", Count: old,
+		Message: fmt.Sprintf("%d old ReplicaSets scaled down to zero kept for the rollout history, most for %s: "+
+			"lower the revisionHistoryLimit of the Deployments (10 by default)", old, strings.Join(topReasons(deployments, 3), ", "))}
+}
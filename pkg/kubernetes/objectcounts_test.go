@@ -0,0 +1,117 @@
+package kubernetes
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseObjectCountMetrics(t *testing.T) {
+	t.Run("prefers the newest metric", func(t *testing.T) {
+		counts := parseObjectCountMetrics([]byte(`# HELP apiserver_storage_objects [STABLE] Number of stored objects at the time of last check split by kind.
+# TYPE apiserver_storage_objects gauge
+apiserver_storage_objects{resource="events"} 12
+apiserver_storage_objects{resource="replicasets.apps"} 4
+apiserver_resource_objects{group="",resource="events"} 15
+apiserver_resource_objects{group="apps",resource="replicasets"} 5
+apiserver_resource_objects{group="apps",resource="deployments"} -1
+apiserver_request_total{code="200",resource="pods"} 100
+`))
+		if !maps.Equal(counts, map[string]int64{"events": 15, "replicasets.apps": 5}) {
+			t.Errorf("unexpected counts %v", counts)
+		}
+	})
+	t.Run("reads the stable metric", func(t *testing.T) {
+		counts := parseObjectCountMetrics([]byte("apiserver_storage_objects{resource=\"pods\"} 3e+03\napiserver_storage_objects{resource=\"leases.coordination.k8s.io\"} 7\n"))
+		if !maps.Equal(counts, map[string]int64{"pods": 3000, "leases.coordination.k8s.io": 7}) {
+			t.Errorf("unexpected counts %v", counts)
+		}
+	})
+	t.Run("no metric", func(t *testing.T) {
+		if counts := parseObjectCountMetrics([]byte("apiserver_request_total{code=\"200\"} 1\n")); counts != nil {
+			t.Errorf("unexpected counts %v", counts)
+		}
+	})
+}
+
+func TestObjectCountResource(t *testing.T) {
+	if r := objectCountResource(APIResource{Name: "pods", APIVersion: "v1"}); r != "pods" {
+		t.Errorf("unexpected resource %s", r)
+	}
+	if r := objectCountResource(APIResource{Name: "ingresses", APIVersion: "networking.k8s.io/v1"}); r != "ingresses.networking.k8s.io" {
+		t.Errorf("unexpected resource %s", r)
+	}
+}
+
+func TestObjectCountFindings(t *testing.T) {
+	findings := objectCountFindings(map[string]int64{"events": 6000, "secrets": 12000, "pods": 200})
+	if len(findings) != 2 || findings[0].Resource != "events" || !strings.HasPrefix(findings[0].Message, "event storm suspected") ||
+		findings[1].Resource != "secrets" || findings[1].Count != 12000 {
+		t.Errorf("unexpected findings %v", findings)
+	}
+}
+
+func TestFinishedJobsFinding(t *testing.T) {
+	var items []unstructured.Unstructured
+	for i := range objectCountsFinishedJobsThreshold + 1 {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "batch", Name: fmt.Sprintf("job-%d", i)},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}}}
+		if i%2 == 0 {
+			job.Spec.TTLSecondsAfterFinished = ptr.To(int32(60))
+		}
+		u, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(job)
+		items = append(items, unstructured.Unstructured{Object: u})
+	}
+	running, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "running"}})
+	items = append(items, unstructured.Unstructured{Object: running})
+	t.Run("flags the finished jobs", func(t *testing.T) {
+		finding := finishedJobsFinding(items)
+		if finding == nil || finding.Count != 101 || !strings.HasPrefix(finding.Message, "101 finished Jobs (50 without ttlSecondsAfterFinished), most in batch (101)") {
+			t.Errorf("unexpected finding %v", finding)
+		}
+	})
+	t.Run("ignores a few finished jobs", func(t *testing.T) {
+		if finding := finishedJobsFinding(items[:10]); finding != nil {
+			t.Errorf("unexpected finding %v", finding)
+		}
+	})
+}
+
+func TestOldReplicaSetsFinding(t *testing.T) {
+	var items []unstructured.Unstructured
+	for i := range objectCountsOldReplicaSetsThreshold {
+		replicas := int64(0)
+		if i == 0 {
+			replicas = 2
+		}
+		items = append(items, unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"namespace": "web", "name": fmt.Sprintf("app-%d", i),
+				"ownerReferences": []any{map[string]any{"apiVersion": "apps/v1", "kind": "Deployment", "name": "app", "uid": "1"}}},
+			"spec": map[string]any{"replicas": replicas},
+		}})
+	}
+	t.Run("ignores fewer old replica sets than the threshold", func(t *testing.T) {
+		if finding := oldReplicaSetsFinding(items); finding != nil {
+			t.Errorf("unexpected finding %v", finding)
+		}
+	})
+	t.Run("flags the old replica sets", func(t *testing.T) {
+		items = append(items, unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"namespace": "web", "name": "other",
+				"ownerReferences": []any{map[string]any{"apiVersion": "apps/v1", "kind": "Deployment", "name": "other", "uid": "2"}}},
+			"spec": map[string]any{"replicas": int64(0)},
+		}})
+		finding := oldReplicaSetsFinding(items)
+		if finding == nil || finding.Count != 100 || !strings.HasPrefix(finding.Message, "100 old ReplicaSets scaled down to zero kept for the rollout history, most for web/app (99), web/other (1)") {
+			t.Errorf("unexpected finding %v", finding)
+		}
+	})
+}
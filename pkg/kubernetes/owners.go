@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+)
+
+const (
+	OwnersDirectionUp   = "up"
+	OwnersDirectionDown = "down"
+	OwnersDirectionBoth = "both"
+)
+
+// OwnerTree is the ownership tree of an object: the owner chain up to its controlling object, and its dependents
+type OwnerTree struct {
+	Object Owner `json:"object"`
+	// Owners is the chain of controller owners, the closest owner first
+	Owners []Owner `json:"owners,omitempty"`
+	// Controller is the top-level owner (the object itself if it has no owners), the object to edit to change the object durably
+	Controller Owner `json:"controller"`
+	// Dependents are the objects owned by the object, recursively
+	Dependents []OwnerDependent `json:"dependents,omitempty"`
+	// Warnings reports the parts of the tree that couldn't be retrieved (e.g. forbidden owners or resources)
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// OwnerDependent is an object owned by another object of the tree
+type OwnerDependent struct {
+	Owner
+	// Controller is whether the owner is the managing controller of the dependent
+	Controller bool             `json:"controller,omitempty"`
+	Dependents []OwnerDependent `json:"dependents,omitempty"`
+}
+
+// ResourcesOwners returns the ownership tree of the object, following its ownerReferences upward (direction up),
+// listing its dependents downward (direction down), or both.
+// The dependents are searched in every listable resource of the namespace of the object (all namespaces for cluster scoped objects),
+// up to MaxOwnerChainDepth levels.
+func (k *Kubernetes) ResourcesOwners(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name, direction string) (*OwnerTree, error) {
+	if direction == "" {
+		direction = OwnersDirectionBoth
+	}
+	if !slices.Contains([]string{OwnersDirectionUp, OwnersDirectionDown, OwnersDirectionBoth}, direction) {
+		return nil, fmt.Errorf("invalid direction %s, supported values are up, down, and both", direction)
+	}
+	obj, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	ret := &OwnerTree{Object: Owner{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Name: obj.GetName(), Namespace: obj.GetNamespace()}}
+	ret.Controller = ret.Object
+	if direction != OwnersDirectionDown {
+		ret.Owners, err = k.ResourcesOwnerChain(ctx, obj)
+		if err != nil {
+			ret.Warnings = append(ret.Warnings, fmt.Sprintf("owner chain is incomplete: %v", err))
+		}
+		if len(ret.Owners) > 0 {
+			ret.Controller = ret.Owners[len(ret.Owners)-1]
+		}
+	}
+	if direction != OwnersDirectionUp {
+		dependents, warnings := k.dependentsByOwner(ctx, obj.GetNamespace())
+		ret.Warnings = append(ret.Warnings, warnings...)
+		ret.Dependents = ownerDependents(obj.GetUID(), dependents, map[types.UID]bool{obj.GetUID(): true}, MaxOwnerChainDepth)
+	}
+	return ret, nil
+}
+
+// dependentsByOwner lists the objects with owner references of every listable namespaced resource, indexed by the UID of their owners
+func (k *Kubernetes) dependentsByOwner(ctx context.Context, namespace string) (map[types.UID][]unstructured.Unstructured, []string) {
+	var warnings []string
+	apiResourceLists, err := k.manager.discoveryClient.ServerPreferredNamespacedResources()
+	if err != nil {
+		var groupErr *discovery.ErrGroupDiscoveryFailed
+		if !errors.As(err, &groupErr) {
+			return nil, []string{fmt.Sprintf("dependents are not available: %v", err)}
+		}
+		for gv := range groupErr.Groups {
+			warnings = append(warnings, fmt.Sprintf("API group %s is unavailable, its dependents are not listed", gv.String()))
+		}
+	}
+	ret := make(map[types.UID][]unstructured.Unstructured)
+	for _, apiResourceList := range apiResourceLists {
+		gv, gvErr := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if gvErr != nil {
+			continue
+		}
+		for _, apiResource := range apiResourceList.APIResources {
+			gvk := &schema.GroupVersionKind{Group: gv.Group, Version: gv.Version, Kind: apiResource.Kind}
+			// Events are never owned, and usually the largest lists of a namespace
+			if apiResource.Kind == "Event" || strings.Contains(apiResource.Name, "/") || !slices.Contains(apiResource.Verbs, "list") || !isAllowed(k.manager.staticConfig, gvk) {
+				continue
+			}
+			list, listErr := k.manager.dynamicClient.Resource(gv.WithResource(apiResource.Name)).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if listErr != nil {
+				warnings = append(warnings, fmt.Sprintf("failed to list %s in %s: %v", apiResource.Name, gv.String(), listErr))
+				continue
+			}
+			for _, item := range list.Items {
+				for _, ownerReference := range item.GetOwnerReferences() {
+					ret[ownerReference.UID] = append(ret[ownerReference.UID], item)
+				}
+			}
+		}
+	}
+	slices.Sort(warnings)
+	return ret, warnings
+}
+
+// ownerDependents returns the tree of the dependents of the owner, the visited UIDs prevent cycles
+func ownerDependents(owner types.UID, dependents map[types.UID][]unstructured.Unstructured, visited map[types.UID]bool, depth int) []OwnerDependent {
+	if depth <= 0 {
+		return nil
+	}
+	var ret []OwnerDependent
+	for _, item := range dependents[owner] {
+		if visited[item.GetUID()] {
+			continue
+		}
+		visited[item.GetUID()] = true
+		dependent := OwnerDependent{Owner: Owner{APIVersion: item.GetAPIVersion(), Kind: item.GetKind(), Name: item.GetName(), Namespace: item.GetNamespace()}}
+		for _, ownerReference := range item.GetOwnerReferences() {
+			if ownerReference.UID == owner && ownerReference.Controller != nil && *ownerReference.Controller {
+				dependent.Controller = true
+			}
+		}
+		dependent.Dependents = ownerDependents(item.GetUID(), dependents, visited, depth-1)
+		ret = append(ret, dependent)
+	}
+	slices.SortFunc(ret, func(a, b OwnerDependent) int {
+		return strings.Compare(a.Kind+"/"+a.Name, b.Kind+"/"+b.Name)
+	})
+	return ret
+}
@@ -0,0 +1,65 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func ownersTestObject(kind, name string, uid types.UID, ownerUIDs ...types.UID) unstructured.Unstructured {
+	obj := unstructured.Unstructured{Object: map[string]any{"apiVersion": "v1", "kind": kind}}
+	obj.SetName(name)
+	obj.SetNamespace("default")
+	obj.SetUID(uid)
+	var ownerReferences []any
+	for i, ownerUID := range ownerUIDs {
+		ownerReferences = append(ownerReferences, map[string]any{
+			"apiVersion": "apps/v1", "kind": "ReplicaSet", "name": "owner", "uid": string(ownerUID), "controller": i == 0,
+		})
+	}
+	if len(ownerReferences) > 0 {
+		obj.Object["metadata"].(map[string]any)["ownerReferences"] = ownerReferences
+	}
+	return obj
+}
+
+func TestOwnerDependents(t *testing.T) {
+	dependents := map[types.UID][]unstructured.Unstructured{
+		"deployment": {ownersTestObject("ReplicaSet", "rs", "rs", "deployment")},
+		"rs": {
+			ownersTestObject("Pod", "pod-b", "pod-b", "rs"),
+			ownersTestObject("Pod", "pod-a", "pod-a", "rs"),
+			ownersTestObject("ConfigMap", "shared", "shared", "other", "rs"),
+		},
+		// cycle back to the ReplicaSet
+		"pod-a": {ownersTestObject("ReplicaSet", "rs", "rs", "pod-a")},
+	}
+	tree := ownerDependents("deployment", dependents, map[types.UID]bool{"deployment": true}, MaxOwnerChainDepth)
+	t.Run("returns direct dependents", func(t *testing.T) {
+		if len(tree) != 1 || tree[0].Kind != "ReplicaSet" || !tree[0].Controller {
+			t.Fatalf("expected ReplicaSet controlled dependent, got %v", tree)
+		}
+	})
+	t.Run("returns nested dependents sorted by kind and name", func(t *testing.T) {
+		nested := tree[0].Dependents
+		if len(nested) != 3 || nested[0].Name != "shared" || nested[1].Name != "pod-a" || nested[2].Name != "pod-b" {
+			t.Fatalf("expected shared, pod-a, pod-b dependents, got %v", nested)
+		}
+	})
+	t.Run("reports non controller owners", func(t *testing.T) {
+		if tree[0].Dependents[0].Controller {
+			t.Errorf("expected shared ConfigMap not to be controlled by the ReplicaSet")
+		}
+	})
+	t.Run("stops at cycles", func(t *testing.T) {
+		if len(tree[0].Dependents[1].Dependents) != 0 {
+			t.Errorf("expected no dependents for pod-a, got %v", tree[0].Dependents[1].Dependents)
+		}
+	})
+	t.Run("stops at max depth", func(t *testing.T) {
+		if shallow := ownerDependents("deployment", dependents, map[types.UID]bool{}, 1); len(shallow[0].Dependents) != 0 {
+			t.Errorf("expected no nested dependents, got %v", shallow[0].Dependents)
+		}
+	})
+}
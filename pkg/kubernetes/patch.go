@@ -0,0 +1,58 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	PatchTypeStrategic = "strategic"
+	PatchTypeMerge     = "merge"
+	PatchTypeJSON      = "json"
+)
+
+// PatchTypes are the patch types supported by ResourcesPatch, same names as kubectl patch --type
+var PatchTypes = []string{PatchTypeStrategic, PatchTypeMerge, PatchTypeJSON}
+
+var patchTypes = map[string]types.PatchType{
+	PatchTypeStrategic: types.StrategicMergePatchType,
+	PatchTypeMerge:     types.MergePatchType,
+	PatchTypeJSON:      types.JSONPatchType,
+}
+
+// ResourcesPatch patches the resource with the provided patch (JSON or YAML) and returns the resource before and after the patch.
+// If no patchType is provided, a strategic merge patch is used for the built-in kinds and a JSON merge patch for the rest
+// (strategic merge patches require the Go struct of the kind, so they are not supported for custom resources).
+func (k *Kubernetes) ResourcesPatch(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name, patchType, patch string) (before, after *unstructured.Unstructured, err error) {
+	strategicSupported := scheme.Scheme.Recognizes(*gvk)
+	if patchType == "" && strategicSupported {
+		patchType = PatchTypeStrategic
+	} else if patchType == "" {
+		patchType = PatchTypeMerge
+	}
+	if !slices.Contains(PatchTypes, patchType) {
+		return nil, nil, fmt.Errorf("unsupported patch type %q, supported types are %v", patchType, PatchTypes)
+	}
+	if patchType == PatchTypeStrategic && !strategicSupported {
+		return nil, nil, fmt.Errorf("strategic merge patch is not supported for %s %s, use a %s or %s patch instead",
+			gvk.GroupVersion().String(), gvk.Kind, PatchTypeMerge, PatchTypeJSON)
+	}
+	data, err := yaml.YAMLToJSON([]byte(patch))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid patch: %w", err)
+	}
+	if before, err = k.ResourcesGet(ctx, gvk, namespace, name); err != nil {
+		return nil, nil, err
+	}
+	if after, err = k.resourcesPatch(ctx, gvk, namespace, name, patchTypes[patchType], data); err != nil {
+		return nil, nil, err
+	}
+	return before, after, nil
+}
@@ -0,0 +1,31 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResourcesPatchValidation(t *testing.T) {
+	k := &Kubernetes{}
+	t.Run("rejects unsupported patch type", func(t *testing.T) {
+		_, _, err := k.ResourcesPatch(context.Background(), &schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, "default", "a-configmap", "apply", "{}")
+		if err == nil || !strings.Contains(err.Error(), `unsupported patch type "apply"`) {
+			t.Errorf("expected unsupported patch type error, got %v", err)
+		}
+	})
+	t.Run("rejects strategic merge patch for custom resources", func(t *testing.T) {
+		_, _, err := k.ResourcesPatch(context.Background(), &schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Custom"}, "default", "a-custom", PatchTypeStrategic, "{}")
+		if err == nil || !strings.Contains(err.Error(), "strategic merge patch is not supported for example.com/v1 Custom") {
+			t.Errorf("expected strategic merge patch error, got %v", err)
+		}
+	})
+	t.Run("rejects invalid patch", func(t *testing.T) {
+		_, _, err := k.ResourcesPatch(context.Background(), &schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Custom"}, "default", "a-custom", "", "{invalid")
+		if err == nil || !strings.Contains(err.Error(), "invalid patch") {
+			t.Errorf("expected invalid patch error, got %v", err)
+		}
+	})
+}
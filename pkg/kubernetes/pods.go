@@ -5,8 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	labelutil "k8s.io/apimachinery/pkg/labels"
@@ -14,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/metrics/pkg/apis/metrics"
 	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
@@ -73,7 +77,7 @@ func (k *Kubernetes) PodsDelete(ctx context.Context, namespace, name string) (st
 			LabelSelector: managedLabelSelector.String(),
 		}); sl != nil {
 			for _, svc := range sl.Items {
-				_ = services.Delete(ctx, svc.Name, metav1.DeleteOptions{})
+				_ = services.Delete(ctx, svc.Name, metav1.DeleteOptions{DryRun: dryRun(ctx)})
 			}
 		}
 	}
@@ -87,7 +91,7 @@ func (k *Kubernetes) PodsDelete(ctx context.Context, namespace, name string) (st
 			LabelSelector: managedLabelSelector.String(),
 		}); rl != nil {
 			for _, route := range rl.Items {
-				_ = routeResources.Delete(ctx, route.GetName(), metav1.DeleteOptions{})
+				_ = routeResources.Delete(ctx, route.GetName(), metav1.DeleteOptions{DryRun: dryRun(ctx)})
 			}
 		}
 
@@ -96,25 +100,75 @@ func (k *Kubernetes) PodsDelete(ctx context.Context, namespace, name string) (st
 		k.ResourcesDelete(ctx, &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}, namespace, name)
 }
 
-func (k *Kubernetes) PodsLog(ctx context.Context, namespace, name, container string, previous bool, tail int64) (string, error) {
+type PodsLogOptions struct {
+	Container string
+	// AllContainers retrieves the logs of every init and regular container of the pod, each line prefixed with its container
+	AllContainers bool
+	Previous      bool
+	Tail          int64
+	SinceSeconds  int64
+	SinceTime     *metav1.Time
+	Timestamps    bool
+}
+
+func (k *Kubernetes) PodsLog(ctx context.Context, namespace, name string, options PodsLogOptions) (string, error) {
 	pods, err := k.manager.accessControlClientSet.Pods(k.NamespaceOrDefault(namespace))
 	if err != nil {
 		return "", err
 	}
 
 	logOptions := &v1.PodLogOptions{
-		Container: container,
-		Previous:  previous,
+		Container:  options.Container,
+		Previous:   options.Previous,
+		SinceTime:  options.SinceTime,
+		Timestamps: options.Timestamps,
 	}
 
 	// Only set tailLines if a value is provided (non-zero)
-	if tail > 0 {
-		logOptions.TailLines = &tail
+	if options.Tail > 0 {
+		logOptions.TailLines = &options.Tail
 	} else {
 		// Default to DefaultTailLines lines when not specified
 		logOptions.TailLines = ptr.To(DefaultTailLines)
 	}
+	if options.SinceSeconds > 0 {
+		logOptions.SinceSeconds = &options.SinceSeconds
+	}
+
+	if !options.AllContainers {
+		return podsLog(ctx, pods, name, logOptions)
+	}
+	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	// https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/polymorphichelpers/logsforobject.go#L92-L117
+	ret := strings.Builder{}
+	for _, container := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		containerLogOptions := logOptions.DeepCopy()
+		containerLogOptions.Container = container.Name
+		prefix := fmt.Sprintf("[pod/%s/%s] ", name, container.Name)
+		logs, err := podsLog(ctx, pods, name, containerLogOptions)
+		if apierrors.IsBadRequest(err) {
+			// The container hasn't started yet, or has no previous instance
+			ret.WriteString(prefix + err.Error() + "\n")
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		for _, line := range strings.SplitAfter(logs, "\n") {
+			if line != "" {
+				ret.WriteString(prefix + line)
+			}
+		}
+		if logs != "" && !strings.HasSuffix(logs, "\n") {
+			ret.WriteString("\n")
+		}
+	}
+	return ret.String(), nil
+}
 
+func podsLog(ctx context.Context, pods corev1.PodInterface, name string, logOptions *v1.PodLogOptions) (string, error) {
 	req := pods.GetLogs(name, logOptions)
 	res := req.Do(ctx)
 	if res.Error() != nil {
@@ -222,18 +276,40 @@ func (k *Kubernetes) PodsTop(ctx context.Context, options PodsTopOptions) (*metr
 }
 
 func (k *Kubernetes) PodsExec(ctx context.Context, namespace, name, container string, command []string) (string, error) {
+	executor, err := k.podsExecutor(ctx, namespace, name, container, command, false)
+	if err != nil {
+		return "", err
+	}
+	stdout := bytes.NewBuffer(make([]byte, 0))
+	stderr := bytes.NewBuffer(make([]byte, 0))
+	if err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: stdout, Stderr: stderr, Tty: false,
+	}); err != nil {
+		return "", err
+	}
+	if stdout.Len() > 0 {
+		return stdout.String(), nil
+	}
+	if stderr.Len() > 0 {
+		return stderr.String(), nil
+	}
+	return "", nil
+}
+
+// podsExecutor returns the executor of the command in the provided (or first) container of the pod, with optional stdin
+func (k *Kubernetes) podsExecutor(ctx context.Context, namespace, name, container string, command []string, stdin bool) (remotecommand.Executor, error) {
 	namespace = k.NamespaceOrDefault(namespace)
 	pods, err := k.manager.accessControlClientSet.Pods(namespace)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/cmd/exec/exec.go#L350-L352
 	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
-		return "", fmt.Errorf("cannot exec into a container in a completed pod; current phase is %s", pod.Status.Phase)
+		return nil, fmt.Errorf("cannot exec into a container in a completed pod; current phase is %s", pod.Status.Phase)
 	}
 	if container == "" {
 		container = pod.Spec.Containers[0].Name
@@ -241,25 +317,9 @@ func (k *Kubernetes) PodsExec(ctx context.Context, namespace, name, container st
 	podExecOptions := &v1.PodExecOptions{
 		Container: container,
 		Command:   command,
+		Stdin:     stdin,
 		Stdout:    true,
 		Stderr:    true,
 	}
-	executor, err := k.manager.accessControlClientSet.PodsExec(namespace, name, podExecOptions)
-	if err != nil {
-		return "", err
-	}
-	stdout := bytes.NewBuffer(make([]byte, 0))
-	stderr := bytes.NewBuffer(make([]byte, 0))
-	if err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdout: stdout, Stderr: stderr, Tty: false,
-	}); err != nil {
-		return "", err
-	}
-	if stdout.Len() > 0 {
-		return stdout.String(), nil
-	}
-	if stderr.Len() > 0 {
-		return stderr.String(), nil
-	}
-	return "", nil
+	return k.manager.accessControlClientSet.PodsExec(namespace, name, podExecOptions)
 }
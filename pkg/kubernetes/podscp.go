@@ -0,0 +1,127 @@
+package kubernetes
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	// DefaultCopyFromMaxBytes is the default maximum size of the files read from the containers
+	DefaultCopyFromMaxBytes = int64(1024 * 1024)
+	// MaxCopyFromBytes is the maximum size of the files that can be read from the containers
+	MaxCopyFromBytes = int64(10 * 1024 * 1024)
+	// MaxCopyToBytes is the maximum size of the files that can be written into the containers
+	MaxCopyToBytes = 1024 * 1024
+)
+
+// PodsCopyFrom reads the file at the provided absolute path of the container, the same way kubectl cp does (tar over exec).
+// The container image must provide the tar binary. Files larger than maxBytes are rejected.
+func (k *Kubernetes) PodsCopyFrom(ctx context.Context, namespace, name, container, filePath string, maxBytes int64) ([]byte, error) {
+	if !path.IsAbs(filePath) {
+		return nil, fmt.Errorf("path %s must be absolute", filePath)
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultCopyFromMaxBytes
+	}
+	// https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/cmd/cp/cp.go#L320-L326
+	executor, err := k.podsExecutor(ctx, namespace, name, container, []string{"tar", "cf", "-", path.Clean(filePath)}, false)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	reader, writer := io.Pipe()
+	stderr := bytes.NewBuffer(make([]byte, 0))
+	streamed := make(chan error, 1)
+	go func() {
+		err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: writer, Stderr: stderr})
+		_ = writer.CloseWithError(err)
+		streamed <- err
+	}()
+	content, err := readTarFile(tar.NewReader(reader), maxBytes)
+	// Stop the stream once the file is read (or rejected), a larger archive is never fully transferred
+	cancel()
+	_ = reader.Close()
+	<-streamed
+	if err != nil {
+		return nil, copyError(filePath, err, stderr)
+	}
+	return content, nil
+}
+
+// readTarFile reads the content of the first entry of the archive, which must be a regular file of at most maxBytes
+func readTarFile(tarReader *tar.Reader, maxBytes int64) ([]byte, error) {
+	header, err := tarReader.Next()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("empty archive")
+	} else if err != nil {
+		return nil, err
+	}
+	if header.Typeflag != tar.TypeReg {
+		return nil, errors.New("not a regular file")
+	}
+	if header.Size > maxBytes {
+		return nil, fmt.Errorf("file size %d exceeds the maximum of %d bytes", header.Size, maxBytes)
+	}
+	content := make([]byte, header.Size)
+	if _, err = io.ReadFull(tarReader, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// PodsCopyTo writes the provided content to the file at the provided absolute path of the container, the same way
+// kubectl cp does (tar over exec). The container image must provide the tar binary and the parent directory must exist.
+func (k *Kubernetes) PodsCopyTo(ctx context.Context, namespace, name, container, filePath string, content []byte) error {
+	if !path.IsAbs(filePath) {
+		return fmt.Errorf("path %s must be absolute", filePath)
+	}
+	if len(content) > MaxCopyToBytes {
+		return fmt.Errorf("content size %d exceeds the maximum of %d bytes", len(content), MaxCopyToBytes)
+	}
+	filePath = path.Clean(filePath)
+	archive := bytes.NewBuffer(make([]byte, 0, len(content)+1024))
+	tarWriter := tar.NewWriter(archive)
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path.Base(filePath),
+		Size:     int64(len(content)),
+		Mode:     0o644,
+		ModTime:  time.Now(),
+	}); err != nil {
+		return err
+	}
+	if _, err := tarWriter.Write(content); err != nil {
+		return err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	// https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/cmd/cp/cp.go#L290-L297
+	executor, err := k.podsExecutor(ctx, namespace, name, container, []string{"tar", "-xmf", "-", "-C", path.Dir(filePath)}, true)
+	if err != nil {
+		return err
+	}
+	stderr := bytes.NewBuffer(make([]byte, 0))
+	if err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdin: archive, Stdout: io.Discard, Stderr: stderr}); err != nil {
+		return copyError(filePath, err, stderr)
+	}
+	return nil
+}
+
+// copyError reports the output of tar in the container, which explains most of the failures (missing file, no tar binary, etc.)
+func copyError(filePath string, err error, stderr *bytes.Buffer) error {
+	if message := strings.TrimSpace(stderr.String()); message != "" {
+		return fmt.Errorf("failed to copy %s: %w: %s", filePath, err, message)
+	}
+	return fmt.Errorf("failed to copy %s: %w", filePath, err)
+}
@@ -0,0 +1,52 @@
+package kubernetes
+
+import (
+	"archive/tar"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func testArchive(t *testing.T, header *tar.Header, content string) *tar.Reader {
+	archive := bytes.NewBuffer(nil)
+	tarWriter := tar.NewWriter(archive)
+	if header != nil {
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return tar.NewReader(archive)
+}
+
+func TestReadTarFile(t *testing.T) {
+	t.Run("regular file", func(t *testing.T) {
+		archive := testArchive(t, &tar.Header{Typeflag: tar.TypeReg, Name: "etc/hostname", Size: 5, Mode: 0o644}, "pod-1")
+		content, err := readTarFile(archive, DefaultCopyFromMaxBytes)
+		if err != nil || string(content) != "pod-1" {
+			t.Errorf("expected pod-1, got %q (%v)", content, err)
+		}
+	})
+	t.Run("file exceeding the maximum size", func(t *testing.T) {
+		archive := testArchive(t, &tar.Header{Typeflag: tar.TypeReg, Name: "var/log/app.log", Size: 11, Mode: 0o644}, "hello world")
+		if _, err := readTarFile(archive, 10); err == nil || !strings.Contains(err.Error(), "exceeds the maximum of 10 bytes") {
+			t.Errorf("expected size error, got %v", err)
+		}
+	})
+	t.Run("directory", func(t *testing.T) {
+		archive := testArchive(t, &tar.Header{Typeflag: tar.TypeDir, Name: "etc/", Mode: 0o755}, "")
+		if _, err := readTarFile(archive, DefaultCopyFromMaxBytes); err == nil || err.Error() != "not a regular file" {
+			t.Errorf("expected not a regular file error, got %v", err)
+		}
+	})
+	t.Run("empty archive", func(t *testing.T) {
+		if _, err := readTarFile(testArchive(t, nil, ""), DefaultCopyFromMaxBytes); err == nil || err.Error() != "empty archive" {
+			t.Errorf("expected empty archive error, got %v", err)
+		}
+	})
+}
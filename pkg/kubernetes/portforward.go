@@ -0,0 +1,254 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labelutil "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// DefaultPortForwardDuration is the default lifetime of a port forward
+	DefaultPortForwardDuration = 5 * time.Minute
+	// MaxPortForwardDuration is the maximum lifetime of a port forward
+	MaxPortForwardDuration = time.Hour
+	// DefaultPortForwardAddress is the default address the port forwards listen on
+	DefaultPortForwardAddress = "localhost"
+)
+
+type PortForwardOptions struct {
+	Namespace string
+	// Pod to forward the port of, mutually exclusive with Service
+	Pod string
+	// Service to forward the port of, the port is forwarded to one of the running pods backing the service
+	Service string
+	// Port of the pod, or of the service
+	Port int32
+	// LocalPort to listen on, a random port is used if 0
+	LocalPort int32
+	// Address to listen on, DefaultPortForwardAddress if empty
+	Address string
+	// Duration after which the forward is closed, DefaultPortForwardDuration if 0
+	Duration time.Duration
+}
+
+// PortForward is an active port forward, closed once it expires
+type PortForward struct {
+	ID         string    `json:"id"`
+	Namespace  string    `json:"namespace"`
+	Target     string    `json:"target"`
+	Pod        string    `json:"pod"`
+	PodPort    int32     `json:"podPort"`
+	Address    string    `json:"address"`
+	Expiration time.Time `json:"expiration"`
+	stop       chan struct{}
+	timer      *time.Timer
+}
+
+// portForwardRegistry keeps the active port forwards of the server, the forwards outlive the tool calls opening them
+type portForwardRegistry struct {
+	mu       sync.Mutex
+	forwards map[string]*PortForward
+}
+
+var portForwards = &portForwardRegistry{forwards: make(map[string]*PortForward)}
+
+func (r *portForwardRegistry) add(forward *PortForward) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.forwards[forward.ID] = forward
+	forward.timer = time.AfterFunc(time.Until(forward.Expiration), func() {
+		klog.V(2).Infof("Port forward %s expired", forward.ID)
+		_, _ = r.close(forward.ID)
+	})
+}
+
+func (r *portForwardRegistry) list() []PortForward {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret := make([]PortForward, 0, len(r.forwards))
+	for _, forward := range r.forwards {
+		ret = append(ret, *forward)
+	}
+	slices.SortFunc(ret, func(a, b PortForward) int { return a.Expiration.Compare(b.Expiration) })
+	return ret
+}
+
+func (r *portForwardRegistry) close(id string) (*PortForward, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	forward, ok := r.forwards[id]
+	if !ok {
+		return nil, fmt.Errorf("port forward %s not found", id)
+	}
+	delete(r.forwards, id)
+	forward.timer.Stop()
+	close(forward.stop)
+	return forward, nil
+}
+
+// PortForwardOpen forwards a local port of the server to the port of a pod (or of a running pod backing a service)
+// until the forward expires or is closed, the returned forward has the bound local address
+func (k *Kubernetes) PortForwardOpen(ctx context.Context, options PortForwardOptions) (*PortForward, error) {
+	namespace := k.NamespaceOrDefault(options.Namespace)
+	duration := options.Duration
+	if duration <= 0 {
+		duration = DefaultPortForwardDuration
+	}
+	if duration > MaxPortForwardDuration {
+		return nil, fmt.Errorf("duration %s exceeds the maximum port forward duration %s", duration, MaxPortForwardDuration)
+	}
+	address := options.Address
+	if address == "" {
+		address = DefaultPortForwardAddress
+	}
+	pod, podPort, target, err := k.portForwardTarget(ctx, namespace, options)
+	if err != nil {
+		return nil, err
+	}
+	dialer, err := k.manager.accessControlClientSet.PodsPortForward(namespace, pod)
+	if err != nil {
+		return nil, err
+	}
+	stop, ready := make(chan struct{}), make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{address},
+		[]string{fmt.Sprintf("%d:%d", options.LocalPort, podPort)}, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	failed := make(chan error, 1)
+	go func() {
+		failed <- forwarder.ForwardPorts()
+	}()
+	select {
+	case <-ready:
+	case err = <-failed:
+		if err == nil {
+			err = errors.New("port forward closed")
+		}
+		return nil, fmt.Errorf("failed to forward port %d of pod %s: %w", podPort, pod, err)
+	case <-ctx.Done():
+		close(stop)
+		return nil, ctx.Err()
+	}
+	ports, err := forwarder.GetPorts()
+	if err != nil || len(ports) == 0 {
+		close(stop)
+		return nil, fmt.Errorf("failed to get the local port of the forward: %v", err)
+	}
+	forward := &PortForward{
+		ID:         rand.String(8),
+		Namespace:  namespace,
+		Target:     target,
+		Pod:        pod,
+		PodPort:    podPort,
+		Address:    net.JoinHostPort(address, strconv.Itoa(int(ports[0].Local))),
+		Expiration: time.Now().Add(duration).Truncate(time.Second),
+		stop:       stop,
+	}
+	portForwards.add(forward)
+	go func() {
+		// The forward stops on its own if the connection to the API server is lost
+		if err := <-failed; err != nil {
+			klog.V(1).Infof("Port forward %s failed: %v", forward.ID, err)
+			_, _ = portForwards.close(forward.ID)
+		}
+	}()
+	klog.V(1).Infof("Port forward %s opened from %s to %s:%d until %s", forward.ID, forward.Address, target, podPort, forward.Expiration)
+	return forward, nil
+}
+
+// PortForwardList returns the active port forwards sorted by expiration
+func (k *Kubernetes) PortForwardList() []PortForward {
+	return portForwards.list()
+}
+
+// PortForwardClose closes the active port forward with the provided ID
+func (k *Kubernetes) PortForwardClose(id string) (*PortForward, error) {
+	return portForwards.close(id)
+}
+
+// portForwardTarget resolves the pod and the pod port of the forward, the services are resolved to one of their
+// running pods the same way kubectl port-forward does
+func (k *Kubernetes) portForwardTarget(ctx context.Context, namespace string, options PortForwardOptions) (string, int32, string, error) {
+	if (options.Pod == "") == (options.Service == "") {
+		return "", 0, "", errors.New("exactly one of pod or service must be provided")
+	}
+	if options.Pod != "" {
+		return options.Pod, options.Port, "pod/" + options.Pod, nil
+	}
+	services, err := k.manager.accessControlClientSet.Services(namespace)
+	if err != nil {
+		return "", 0, "", err
+	}
+	service, err := services.Get(ctx, options.Service, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, "", err
+	}
+	if len(service.Spec.Selector) == 0 {
+		return "", 0, "", fmt.Errorf("service %s has no selector", options.Service)
+	}
+	var targetPort *intstr.IntOrString
+	for _, port := range service.Spec.Ports {
+		if port.Port == options.Port {
+			targetPort = &port.TargetPort
+			if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
+				// The target port defaults to the service port
+				targetPort = ptr.To(intstr.FromInt32(port.Port))
+			}
+			break
+		}
+	}
+	if targetPort == nil {
+		return "", 0, "", fmt.Errorf("service %s doesn't expose port %d", options.Service, options.Port)
+	}
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return "", 0, "", err
+	}
+	podList, err := pods.List(ctx, metav1.ListOptions{LabelSelector: labelutil.SelectorFromSet(service.Spec.Selector).String()})
+	if err != nil {
+		return "", 0, "", err
+	}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != v1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		podPort, err := containerPort(&pod, *targetPort)
+		if err != nil {
+			return "", 0, "", err
+		}
+		return pod.Name, podPort, "service/" + options.Service, nil
+	}
+	return "", 0, "", fmt.Errorf("no running pod found for service %s", options.Service)
+}
+
+// containerPort resolves the (possibly named) target port of a service in the provided pod
+func containerPort(pod *v1.Pod, targetPort intstr.IntOrString) (int32, error) {
+	if targetPort.Type == intstr.Int {
+		return targetPort.IntVal, nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if strings.EqualFold(port.Name, targetPort.StrVal) {
+				return port.ContainerPort, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("pod %s has no container port named %s", pod.Name, targetPort.StrVal)
+}
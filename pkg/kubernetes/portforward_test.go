@@ -0,0 +1,73 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestContainerPort(t *testing.T) {
+	pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{
+		{Name: "sidecar", Ports: []v1.ContainerPort{{Name: "metrics", ContainerPort: 9090}}},
+		{Name: "app", Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+	}}}
+	t.Run("numeric target port", func(t *testing.T) {
+		if port, err := containerPort(pod, intstr.FromInt32(3000)); err != nil || port != 3000 {
+			t.Errorf("expected port 3000, got %d (%v)", port, err)
+		}
+	})
+	t.Run("named target port", func(t *testing.T) {
+		if port, err := containerPort(pod, intstr.FromString("http")); err != nil || port != 8080 {
+			t.Errorf("expected port 8080, got %d (%v)", port, err)
+		}
+	})
+	t.Run("missing named target port", func(t *testing.T) {
+		if _, err := containerPort(pod, intstr.FromString("grpc")); err == nil {
+			t.Error("expected error for missing named port")
+		}
+	})
+}
+
+func TestPortForwardRegistry(t *testing.T) {
+	registry := &portForwardRegistry{forwards: make(map[string]*PortForward)}
+	late := &PortForward{ID: "late", Expiration: time.Now().Add(time.Hour), stop: make(chan struct{})}
+	early := &PortForward{ID: "early", Expiration: time.Now().Add(30 * time.Minute), stop: make(chan struct{})}
+	registry.add(late)
+	registry.add(early)
+	t.Run("list sorts by expiration", func(t *testing.T) {
+		forwards := registry.list()
+		if len(forwards) != 2 || forwards[0].ID != "early" || forwards[1].ID != "late" {
+			t.Errorf("unexpected forwards %v", forwards)
+		}
+	})
+	t.Run("close stops the forward", func(t *testing.T) {
+		if _, err := registry.close("early"); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		select {
+		case <-early.stop:
+		default:
+			t.Error("expected stop channel to be closed")
+		}
+		if forwards := registry.list(); len(forwards) != 1 {
+			t.Errorf("expected 1 forward, got %d", len(forwards))
+		}
+	})
+	t.Run("close unknown forward", func(t *testing.T) {
+		if _, err := registry.close("early"); err == nil {
+			t.Error("expected error closing unknown forward")
+		}
+	})
+	t.Run("expired forwards are closed", func(t *testing.T) {
+		expiring := &PortForward{ID: "expiring", Expiration: time.Now(), stop: make(chan struct{})}
+		registry.add(expiring)
+		select {
+		case <-expiring.stop:
+		case <-time.After(5 * time.Second):
+			t.Error("expected expired forward to be closed")
+		}
+	})
+	_, _ = registry.close("late")
+}
@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+const (
+	// ProbeDefaultImage is the image of the probe pods if no image is configured, it must provide sh, nslookup, nc, and wget
+	ProbeDefaultImage = "busybox:1.36"
+	// probeDefaultTimeout is the time to wait for a probe pod to complete
+	probeDefaultTimeout = 2 * time.Minute
+	// probePollInterval is the time between two checks of the phase of a probe pod
+	probePollInterval = time.Second
+)
+
+// ProbeOptions configure a short-lived probe pod running a shell script
+type ProbeOptions struct {
+	// Namespace of the probe pod, the configured namespace if empty
+	Namespace string
+	// Image of the probe pod, the probe_image configuration (or ProbeDefaultImage) if empty
+	Image string
+	// Timeout is the time to wait for the probe pod to complete, 2 minutes if not provided
+	Timeout time.Duration
+}
+
+// probeImage returns the image of the probe pods: the provided one, the configured one, or the default one
+func (k *Kubernetes) probeImage(image string) string {
+	if image != "" {
+		return image
+	}
+	if k.manager.staticConfig != nil && k.manager.staticConfig.ProbeImage != "" {
+		return k.manager.staticConfig.ProbeImage
+	}
+	return ProbeDefaultImage
+}
+
+// runProbe runs the script in a short-lived pod and returns its logs once it completes, the pod is deleted afterward.
+// The script is expected to report its failures in its output, a failed pod is not an error.
+func (k *Kubernetes) runProbe(ctx context.Context, name, script string, options ProbeOptions) (string, error) {
+	namespace := k.NamespaceOrDefault(options.Namespace)
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return "", err
+	}
+	name = fmt.Sprintf("%s-%s-%s", version.BinaryName, name, rand.String(5))
+	pod, err := pods.Create(ctx, &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: probeLabels(name)},
+		Spec: v1.PodSpec{
+			RestartPolicy:                 v1.RestartPolicyNever,
+			TerminationGracePeriodSeconds: ptr.To(int64(0)),
+			AutomountServiceAccountToken:  ptr.To(false),
+			Containers: []v1.Container{{
+				Name:    "probe",
+				Image:   k.probeImage(options.Image),
+				Command: []string{"sh", "-c", script},
+				SecurityContext: &v1.SecurityContext{
+					AllowPrivilegeEscalation: ptr.To(false),
+					Capabilities:             &v1.Capabilities{Drop: []v1.Capability{"ALL"}},
+				},
+			}},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		// The probe pod is deleted even if the tool call was cancelled
+		if err := pods.Delete(context.WithoutCancel(ctx), pod.Name, metav1.DeleteOptions{GracePeriodSeconds: ptr.To(int64(0))}); err != nil {
+			klog.V(1).Infof("failed to delete probe pod %s/%s: %v", namespace, pod.Name, err)
+		}
+	}()
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = probeDefaultTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	for pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("probe pod %s/%s didn't complete within %s (phase %s)%s", namespace, pod.Name, timeout, pod.Status.Phase, probeWaitingReason(pod))
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(probePollInterval):
+		}
+		if pod, err = pods.Get(ctx, pod.Name, metav1.GetOptions{}); err != nil {
+			return "", err
+		}
+	}
+	return podsLog(ctx, pods, pod.Name, &v1.PodLogOptions{Container: "probe"})
+}
+
+// probeLabels returns the labels of the probe pods, the name label is omitted if name is empty
+func probeLabels(name string) map[string]string {
+	ret := map[string]string{
+		AppKubernetesComponent: "probe",
+		AppKubernetesManagedBy: version.BinaryName,
+		AppKubernetesPartOf:    version.BinaryName + "-probe",
+	}
+	if name != "" {
+		ret[AppKubernetesName] = name
+	}
+	return ret
+}
+
+// probeWaitingReason returns why the container of the probe pod is waiting (e.g. ImagePullBackOff)
+func probeWaitingReason(pod *v1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			return fmt.Sprintf(": %s %s", status.State.Waiting.Reason, status.State.Waiting.Message)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+// ErrPrometheusNotFound is returned when no Prometheus (or Thanos Querier) Service is found in the cluster
+var ErrPrometheusNotFound = errors.New("no Prometheus service found in the cluster")
+
+// prometheusBackend finds the Prometheus of the cluster, the Thanos Querier of OpenShift first
+var prometheusBackend = &monitoringBackend{
+	name: "Prometheus",
+	url:  func(c *config.StaticConfig) string { return c.PrometheusURL },
+	knownRoutes: []monitoringService{
+		{namespace: "openshift-monitoring", name: "thanos-querier"},
+		{namespace: "openshift-monitoring", name: "prometheus-k8s"},
+	},
+	knownServices: []monitoringService{
+		{namespace: "openshift-monitoring", name: "thanos-querier", scheme: "https", port: "web"},
+		{namespace: "openshift-monitoring", name: "prometheus-k8s", scheme: "https", port: "web"},
+	},
+	selectors:  []string{"operated-prometheus=true", "app.kubernetes.io/name=prometheus", "app=prometheus"},
+	components: []string{"prometheus", "server"},
+	portNames:  []string{"web", "http-web", "http"},
+	notFound:   ErrPrometheusNotFound,
+}
+
+// PrometheusAlert is an alert of the Prometheus alerting rules
+type PrometheusAlert struct {
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Severity  string `json:"severity,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// Summary is the summary (or message, or description) annotation of the alert
+	Summary  string     `json:"summary,omitempty"`
+	ActiveAt *time.Time `json:"activeAt,omitempty"`
+}
+
+// PrometheusAlertsGetter returns the Prometheus the alerts were retrieved from and its alerts
+type PrometheusAlertsGetter func(ctx context.Context) (string, []PrometheusAlert, error)
+
+// PrometheusAlerts returns the pending and firing alerts of the Prometheus of the cluster, ErrPrometheusNotFound if none is found
+func (k *Kubernetes) PrometheusAlerts(ctx context.Context) (string, []PrometheusAlert, error) {
+	body, source, err := k.prometheusGet(ctx, "/api/v1/alerts", nil)
+	if err != nil {
+		return "", nil, err
+	}
+	response := struct {
+		Data struct {
+			Alerts []struct {
+				Labels      map[string]string `json:"labels"`
+				Annotations map[string]string `json:"annotations"`
+				State       string            `json:"state"`
+				ActiveAt    *time.Time        `json:"activeAt"`
+			} `json:"alerts"`
+		} `json:"data"`
+	}{}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return "", nil, fmt.Errorf("failed to parse the alerts of %s: %w", source, err)
+	}
+	var ret []PrometheusAlert
+	for _, alert := range response.Data.Alerts {
+		summary := alert.Annotations["summary"]
+		for _, annotation := range []string{"message", "description"} {
+			if summary == "" {
+				summary = alert.Annotations[annotation]
+			}
+		}
+		ret = append(ret, PrometheusAlert{
+			Name:      alert.Labels["alertname"],
+			State:     alert.State,
+			Severity:  alert.Labels["severity"],
+			Namespace: alert.Labels["namespace"],
+			Summary:   summary,
+			ActiveAt:  alert.ActiveAt,
+		})
+	}
+	return source, ret, nil
+}
+
+// prometheusGet performs a GET request to the Prometheus HTTP API (the configured one, or the first one of the cluster answering),
+// and returns the response along with the Prometheus that answered
+func (k *Kubernetes) prometheusGet(ctx context.Context, path string, params map[string]string) ([]byte, string, error) {
+	return k.monitoringRequest(ctx, prometheusBackend, http.MethodGet, path, params, nil, nil)
+}
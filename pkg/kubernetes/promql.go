@@ -0,0 +1,308 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// PromQLDefaultMaxSeries is the default maximum number of series returned by the PromQL queries
+	PromQLDefaultMaxSeries = 20
+	// PromQLDefaultMaxPoints is the default maximum number of points returned for each series of the PromQL range queries
+	PromQLDefaultMaxPoints = 60
+)
+
+// PromQLQueryOptions is an instant query, or a range query if Start is provided
+type PromQLQueryOptions struct {
+	Query string
+	// Time is the evaluation time of the instant queries, now if not provided
+	Time string
+	// Start and End are the range of the range queries (RFC3339, unix timestamp, or relative to now, e.g. -1h), End defaults to now
+	Start, End string
+	// Step is the resolution of the range queries (e.g. 30s, 5m), computed to return at most MaxPoints points if not provided
+	Step string
+	// MaxSeries is the maximum number of series returned, the ones with the highest values are kept
+	MaxSeries int
+	// MaxPoints is the maximum number of points of each series of the range queries, the series are downsampled (averaged) to fit
+	MaxPoints int
+}
+
+// PromQLResult is the result of a PromQL query, truncated and downsampled to fit the context of the LLM
+type PromQLResult struct {
+	// Source is the Prometheus that answered
+	Source     string `json:"source"`
+	Query      string `json:"query"`
+	ResultType string `json:"resultType"`
+	// Step is the resolution of the range queries, Downsampled is set if the points were averaged to fit MaxPoints
+	Step        string `json:"step,omitempty"`
+	Downsampled bool   `json:"downsampled,omitempty"`
+	// TotalSeries is the number of series returned by Prometheus, Truncated is set if only the highest ones are reported
+	TotalSeries int            `json:"totalSeries"`
+	Truncated   bool           `json:"truncated,omitempty"`
+	Series      []PromQLSeries `json:"series,omitempty"`
+	// Scalar is the value of the scalar and string results
+	Scalar   *PromQLSample `json:"scalar,omitempty"`
+	Warnings []string      `json:"warnings,omitempty"`
+}
+
+type PromQLSeries struct {
+	Metric map[string]string `json:"metric"`
+	// Value is the value of the instant queries, Values the points of the range queries
+	Value  *PromQLSample  `json:"value,omitempty"`
+	Values []PromQLSample `json:"values,omitempty"`
+	// Min, Max, and Last summarize the points of the range queries before downsampling
+	Min  string `json:"min,omitempty"`
+	Max  string `json:"max,omitempty"`
+	Last string `json:"last,omitempty"`
+	// sortValue is the value the series are ranked by (the instant value, or the maximum of the points)
+	sortValue float64
+}
+
+type PromQLSample struct {
+	Time  time.Time `json:"time"`
+	Value string    `json:"value"`
+}
+
+// PromQLQuery evaluates the PromQL query (an instant query, or a range query if a Start is provided) with the Prometheus of the cluster
+func (k *Kubernetes) PromQLQuery(ctx context.Context, options PromQLQueryOptions) (*PromQLResult, error) {
+	now := time.Now()
+	params := map[string]string{"query": options.Query}
+	path := "/api/v1/query"
+	maxPoints := cmp.Or(options.MaxPoints, PromQLDefaultMaxPoints)
+	var step time.Duration
+	if options.Start != "" {
+		start, err := parsePromQLTime(options.Start, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start: %w", err)
+		}
+		end := now
+		if options.End != "" {
+			if end, err = parsePromQLTime(options.End, now); err != nil {
+				return nil, fmt.Errorf("invalid end: %w", err)
+			}
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("end %s must be after start %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+		}
+		if step, err = promQLStep(options.Step, end.Sub(start), maxPoints); err != nil {
+			return nil, err
+		}
+		path = "/api/v1/query_range"
+		params["start"] = strconv.FormatInt(start.Unix(), 10)
+		params["end"] = strconv.FormatInt(end.Unix(), 10)
+		params["step"] = strconv.FormatFloat(step.Seconds(), 'f', -1, 64)
+	} else if options.Time != "" {
+		evaluation, err := parsePromQLTime(options.Time, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time: %w", err)
+		}
+		params["time"] = strconv.FormatInt(evaluation.Unix(), 10)
+	}
+	body, source, err := k.prometheusGet(ctx, path, params)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := parsePromQLResponse(body, cmp.Or(options.MaxSeries, PromQLDefaultMaxSeries), maxPoints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the response of %s: %w", source, err)
+	}
+	ret.Source = source
+	ret.Query = options.Query
+	if step > 0 {
+		ret.Step = step.String()
+	}
+	return ret, nil
+}
+
+// promQLStep returns the provided step, or the step returning at most maxPoints points over the duration (rounded up to the second)
+func promQLStep(step string, duration time.Duration, maxPoints int) (time.Duration, error) {
+	if step != "" {
+		ret, err := parsePromQLDuration(step)
+		if err != nil || ret <= 0 {
+			return 0, fmt.Errorf("invalid step %q, expected a duration (e.g. 30s, 5m)", step)
+		}
+		return ret, nil
+	}
+	// Prometheus returns the points at both ends of the range
+	ret := duration / time.Duration(max(maxPoints-1, 1))
+	if truncated := ret.Truncate(time.Second); truncated < ret {
+		ret = truncated + time.Second
+	}
+	return max(time.Second, ret), nil
+}
+
+// parsePromQLTime parses an RFC3339 time, a unix timestamp, now, or a duration relative to now (e.g. -1h, -30m)
+func parsePromQLTime(value string, now time.Time) (time.Time, error) {
+	if value == "now" {
+		return now, nil
+	}
+	if relative, ok := strings.CutPrefix(value, "-"); ok {
+		if duration, err := parsePromQLDuration(relative); err == nil {
+			return now.Add(-duration), nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if unix, err := strconv.ParseFloat(value, 64); err == nil {
+		seconds, fraction := math.Modf(unix)
+		return time.Unix(int64(seconds), int64(fraction*1e9)), nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not an RFC3339 time, a unix timestamp, or a duration relative to now (e.g. -1h)", value)
+}
+
+// parsePromQLDuration parses a Go duration, or a Prometheus duration with days or weeks (e.g. 1d, 2w)
+func parsePromQLDuration(value string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			if n, err := strconv.Atoi(number); err == nil {
+				return time.Duration(n) * unit, nil
+			}
+		}
+	}
+	return time.ParseDuration(value)
+}
+
+// parsePromQLResponse parses the response of the Prometheus query API, keeping the maxSeries highest series and downsampling
+// their points to maxPoints
+func parsePromQLResponse(body []byte, maxSeries, maxPoints int) (*PromQLResult, error) {
+	response := struct {
+		Status string `json:"status"`
+		Data   struct {
+			ResultType string          `json:"resultType"`
+			Result     json.RawMessage `json:"result"`
+		} `json:"data"`
+		Error    string   `json:"error"`
+		Warnings []string `json:"warnings"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("query failed: %s", response.Error)
+	}
+	ret := &PromQLResult{ResultType: response.Data.ResultType, Warnings: response.Warnings}
+	switch response.Data.ResultType {
+	case "scalar", "string":
+		var sample []any
+		if err := json.Unmarshal(response.Data.Result, &sample); err != nil {
+			return nil, err
+		}
+		value := promQLSample(sample)
+		ret.Scalar = &value
+		return ret, nil
+	case "vector", "matrix":
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", response.Data.ResultType)
+	}
+	var result []struct {
+		Metric map[string]string `json:"metric"`
+		Value  []any             `json:"value"`
+		Values [][]any           `json:"values"`
+	}
+	if err := json.Unmarshal(response.Data.Result, &result); err != nil {
+		return nil, err
+	}
+	for _, r := range result {
+		series := PromQLSeries{Metric: r.Metric, sortValue: math.Inf(-1)}
+		if r.Value != nil {
+			value := promQLSample(r.Value)
+			series.Value = &value
+			series.sortValue = promQLFloat(value.Value)
+		}
+		if len(r.Values) > 0 {
+			samples := make([]PromQLSample, 0, len(r.Values))
+			for _, v := range r.Values {
+				samples = append(samples, promQLSample(v))
+			}
+			summarizePromQLSeries(&series, samples)
+			series.Values = samples
+			if len(samples) > maxPoints {
+				series.Values = downsamplePromQL(samples, maxPoints)
+				ret.Downsampled = true
+			}
+		}
+		ret.Series = append(ret.Series, series)
+	}
+	ret.TotalSeries = len(ret.Series)
+	slices.SortStableFunc(ret.Series, func(a, b PromQLSeries) int {
+		return cmp.Compare(b.sortValue, a.sortValue)
+	})
+	if len(ret.Series) > maxSeries {
+		ret.Series = ret.Series[:maxSeries]
+		ret.Truncated = true
+	}
+	return ret, nil
+}
+
+// promQLSample converts a [unix time, "value"] pair of the Prometheus API
+func promQLSample(pair []any) PromQLSample {
+	var ret PromQLSample
+	if len(pair) != 2 {
+		return ret
+	}
+	if unix, ok := pair[0].(float64); ok {
+		seconds, fraction := math.Modf(unix)
+		ret.Time = time.Unix(int64(seconds), int64(math.Round(fraction*1e3))*int64(time.Millisecond)).UTC()
+	}
+	ret.Value, _ = pair[1].(string)
+	return ret
+}
+
+// promQLFloat parses a sample value, NaN are ranked last
+func promQLFloat(value string) float64 {
+	ret, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(ret) {
+		return math.Inf(-1)
+	}
+	return ret
+}
+
+func formatPromQLFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', 6, 64)
+}
+
+// summarizePromQLSeries sets the minimum, maximum, and last value of the points
+func summarizePromQLSeries(series *PromQLSeries, samples []PromQLSample) {
+	minimum, maximum := math.Inf(1), math.Inf(-1)
+	for _, sample := range samples {
+		if value := promQLFloat(sample.Value); !math.IsInf(value, -1) {
+			minimum, maximum = min(minimum, value), max(maximum, value)
+		}
+	}
+	if !math.IsInf(maximum, -1) {
+		series.Min, series.Max = formatPromQLFloat(minimum), formatPromQLFloat(maximum)
+	}
+	series.Last = samples[len(samples)-1].Value
+	series.sortValue = maximum
+}
+
+// downsamplePromQL averages the consecutive points in maxPoints buckets, each bucket has the time of its first point
+func downsamplePromQL(samples []PromQLSample, maxPoints int) []PromQLSample {
+	bucketSize := (len(samples) + maxPoints - 1) / maxPoints
+	ret := make([]PromQLSample, 0, maxPoints)
+	for i := 0; i < len(samples); i += bucketSize {
+		bucket := samples[i:min(i+bucketSize, len(samples))]
+		var sum float64
+		var count int
+		for _, sample := range bucket {
+			if value := promQLFloat(sample.Value); !math.IsInf(value, 0) {
+				sum += value
+				count++
+			}
+		}
+		value := "NaN"
+		if count > 0 {
+			value = formatPromQLFloat(sum / float64(count))
+		}
+		ret = append(ret, PromQLSample{Time: bucket[0].Time, Value: value})
+	}
+	return ret
+}
@@ -0,0 +1,167 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+func TestParsePromQLTime(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	for value, expected := range map[string]time.Time{
+		"now":                  now,
+		"-1h":                  now.Add(-time.Hour),
+		"-2d":                  now.Add(-48 * time.Hour),
+		"2025-06-01T10:00:00Z": time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC),
+		"1748772000":           time.Unix(1748772000, 0),
+	} {
+		parsed, err := parsePromQLTime(value, now)
+		if err != nil || !parsed.Equal(expected) {
+			t.Errorf("%s: expected %s, got %s %v", value, expected, parsed, err)
+		}
+	}
+	if _, err := parsePromQLTime("yesterday", now); err == nil {
+		t.Errorf("expected an error for an invalid time")
+	}
+}
+
+func TestPromQLStep(t *testing.T) {
+	t.Run("computed from the maximum points", func(t *testing.T) {
+		step, err := promQLStep("", time.Hour, 61)
+		if err != nil || step != time.Minute {
+			t.Errorf("expected 1m, got %s %v", step, err)
+		}
+	})
+	t.Run("rounded up to the second", func(t *testing.T) {
+		step, err := promQLStep("", 100*time.Second, 8)
+		if err != nil || step != 15*time.Second {
+			t.Errorf("expected 15s, got %s %v", step, err)
+		}
+	})
+	t.Run("provided", func(t *testing.T) {
+		step, err := promQLStep("1d", time.Hour, 60)
+		if err != nil || step != 24*time.Hour {
+			t.Errorf("expected 24h, got %s %v", step, err)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := promQLStep("-5m", time.Hour, 60); err == nil || !strings.Contains(err.Error(), `invalid step "-5m"`) {
+			t.Errorf("expected an invalid step error, got %v", err)
+		}
+	})
+}
+
+func TestParsePromQLResponse(t *testing.T) {
+	t.Run("vector truncated to the highest series", func(t *testing.T) {
+		ret, err := parsePromQLResponse([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"namespace":"a"},"value":[1748772000.5,"1"]},
+			{"metric":{"namespace":"b"},"value":[1748772000.5,"NaN"]},
+			{"metric":{"namespace":"c"},"value":[1748772000.5,"3"]},
+			{"metric":{"namespace":"d"},"value":[1748772000.5,"2"]}]}}`), 2, 60)
+		if err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+		if ret.ResultType != "vector" || ret.TotalSeries != 4 || !ret.Truncated || len(ret.Series) != 2 ||
+			ret.Series[0].Metric["namespace"] != "c" || ret.Series[1].Metric["namespace"] != "d" {
+			t.Errorf("unexpected result %+v", ret)
+		}
+		if ret.Series[0].Value.Value != "3" || !ret.Series[0].Value.Time.Equal(time.UnixMilli(1748772000500)) {
+			t.Errorf("unexpected value %+v", ret.Series[0].Value)
+		}
+	})
+	t.Run("matrix downsampled", func(t *testing.T) {
+		var values []string
+		for i := range 10 {
+			values = append(values, fmt.Sprintf(`[%d,"%d"]`, 1748772000+i*60, i))
+		}
+		ret, err := parsePromQLResponse([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"pod":"p"},"values":[`+strings.Join(values, ",")+`]}]},"warnings":["partial"]}`), 20, 5)
+		if err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+		series := ret.Series[0]
+		if !ret.Downsampled || ret.Truncated || len(series.Values) != 5 || series.Min != "0" || series.Max != "9" || series.Last != "9" {
+			t.Errorf("unexpected result %+v", ret)
+		}
+		if series.Values[0].Value != "0.5" || series.Values[4].Value != "8.5" || !series.Values[1].Time.Equal(time.Unix(1748772120, 0)) {
+			t.Errorf("unexpected points %+v", series.Values)
+		}
+		if len(ret.Warnings) != 1 || ret.Warnings[0] != "partial" {
+			t.Errorf("unexpected warnings %v", ret.Warnings)
+		}
+	})
+	t.Run("scalar", func(t *testing.T) {
+		ret, err := parsePromQLResponse([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1748772000,"42"]}}`), 20, 60)
+		if err != nil || ret.Scalar == nil || ret.Scalar.Value != "42" || len(ret.Series) != 0 {
+			t.Errorf("unexpected result %+v %v", ret, err)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		if _, err := parsePromQLResponse([]byte(`{"status":"error","error":"boom"}`), 20, 60); err == nil || err.Error() != "query failed: boom" {
+			t.Errorf("expected a query error, got %v", err)
+		}
+	})
+}
+
+func TestPrometheusRejection(t *testing.T) {
+	body := []byte(`{"status":"error","errorType":"bad_data","error":"parse error: unexpected end of input"}`)
+	if message, ok := monitoringRejection(&monitoringStatusError{status: http.StatusBadRequest, body: body}); !ok || message != "bad_data: parse error: unexpected end of input" {
+		t.Errorf("expected a rejection, got %s %v", message, ok)
+	}
+	if _, ok := monitoringRejection(&monitoringStatusError{status: http.StatusForbidden, body: []byte("Forbidden")}); ok {
+		t.Errorf("expected a forbidden response not to be a rejection")
+	}
+	if _, ok := monitoringRejection(errors.New("connection refused")); ok {
+		t.Errorf("expected a connection error not to be a rejection")
+	}
+}
+
+func TestPromQLQueryURL(t *testing.T) {
+	var authorization, path, step string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization, path, step = r.Header.Get("Authorization"), r.URL.Path, r.URL.Query().Get("step")
+		if r.URL.Query().Get("query") == "up{" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"unexpected end of input"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"job":"apiserver"},"values":[[1748772000,"1"],[1748772060,"1"]]}]}}`))
+	}))
+	defer server.Close()
+	k := &Kubernetes{manager: &Manager{
+		cfg:          &rest.Config{BearerToken: "token"},
+		staticConfig: &config.StaticConfig{PrometheusURL: server.URL, PrometheusInsecure: true},
+	}}
+	t.Run("range query with the bearer token", func(t *testing.T) {
+		ret, err := k.PromQLQuery(context.Background(), PromQLQueryOptions{Query: "up", Start: "-1h", MaxPoints: 61})
+		if err != nil {
+			t.Fatalf("failed to query: %v", err)
+		}
+		if authorization != "Bearer token" || path != "/api/v1/query_range" || step != "60" {
+			t.Errorf("unexpected request %s %s %s", authorization, path, step)
+		}
+		if ret.Source != server.URL || ret.Step != "1m0s" || len(ret.Series) != 1 || ret.Series[0].Max != "1" {
+			t.Errorf("unexpected result %+v", ret)
+		}
+	})
+	t.Run("rejected query", func(t *testing.T) {
+		_, err := k.PromQLQuery(context.Background(), PromQLQueryOptions{Query: "up{"})
+		if err == nil || !strings.HasSuffix(err.Error(), "bad_data: unexpected end of input") {
+			t.Errorf("expected the Prometheus error, got %v", err)
+		}
+	})
+	t.Run("invalid start", func(t *testing.T) {
+		if _, err := k.PromQLQuery(context.Background(), PromQLQueryOptions{Query: "up", Start: "yesterday"}); err == nil || !strings.HasPrefix(err.Error(), "invalid start") {
+			t.Errorf("expected an invalid start error, got %v", err)
+		}
+	})
+}
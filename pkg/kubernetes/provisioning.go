@@ -0,0 +1,131 @@
+package kubernetes
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/metrics"
+)
+
+const (
+	// ProvisioningOverThreshold usage below this fraction of the request flags an over-provisioned container
+	ProvisioningOverThreshold = 0.2
+	// ProvisioningNearLimitThreshold usage above this fraction of the limit flags a container close to its limit
+	ProvisioningNearLimitThreshold = 0.9
+)
+
+// ProvisioningStatus classifies the usage of a resource against its requests and limits
+type ProvisioningStatus string
+
+const (
+	ProvisioningStatusOK               ProvisioningStatus = "ok"
+	ProvisioningStatusNoRequest        ProvisioningStatus = "no-request"
+	ProvisioningStatusOverProvisioned  ProvisioningStatus = "over-provisioned"
+	ProvisioningStatusUnderProvisioned ProvisioningStatus = "under-provisioned"
+	ProvisioningStatusNearLimit        ProvisioningStatus = "near-limit"
+)
+
+// ResourceProvisioning is the usage of a container resource compared to its requests and limits
+type ResourceProvisioning struct {
+	Usage   resource.Quantity
+	Request *resource.Quantity
+	Limit   *resource.Quantity
+	Status  ProvisioningStatus
+}
+
+type ContainerProvisioning struct {
+	Namespace string
+	Pod       string
+	Container string
+	CPU       ResourceProvisioning
+	Memory    ResourceProvisioning
+}
+
+// PodsTopProvisioning compares the resource consumption of the containers of the pods with their requests and limits
+func (k *Kubernetes) PodsTopProvisioning(ctx context.Context, options PodsTopOptions) ([]ContainerProvisioning, error) {
+	podMetrics, pods, err := k.podsTopWithSpecs(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]ContainerProvisioning, 0)
+	for _, podMetric := range podMetrics.Items {
+		pod, ok := pods[podMetric.Namespace+"/"+podMetric.Name]
+		if !ok {
+			continue
+		}
+		for _, containerMetric := range podMetric.Containers {
+			for _, container := range pod.Spec.Containers {
+				if container.Name != containerMetric.Name {
+					continue
+				}
+				ret = append(ret, ContainerProvisioning{
+					Namespace: podMetric.Namespace,
+					Pod:       podMetric.Name,
+					Container: container.Name,
+					CPU:       resourceProvisioning(v1.ResourceCPU, containerMetric.Usage, container.Resources),
+					Memory:    resourceProvisioning(v1.ResourceMemory, containerMetric.Usage, container.Resources),
+				})
+			}
+		}
+	}
+	return ret, nil
+}
+
+// podsTopWithSpecs returns the metrics of the pods along with the pods, keyed by namespace/name
+func (k *Kubernetes) podsTopWithSpecs(ctx context.Context, options PodsTopOptions) (*metrics.PodMetricsList, map[string]*v1.Pod, error) {
+	podMetrics, err := k.PodsTop(ctx, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	namespace := ""
+	if !options.AllNamespaces || options.Namespace != "" {
+		namespace = k.NamespaceOrDefault(options.Namespace)
+	}
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	ret := make(map[string]*v1.Pod)
+	if options.Name != "" {
+		pod, err := pods.Get(ctx, options.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		ret[pod.Namespace+"/"+pod.Name] = pod
+	} else {
+		podList, err := pods.List(ctx, options.ListOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range podList.Items {
+			ret[podList.Items[i].Namespace+"/"+podList.Items[i].Name] = &podList.Items[i]
+		}
+	}
+	return podMetrics, ret, nil
+}
+
+func resourceProvisioning(name v1.ResourceName, usage v1.ResourceList, requirements v1.ResourceRequirements) ResourceProvisioning {
+	ret := ResourceProvisioning{Usage: usage[name]}
+	if request, ok := requirements.Requests[name]; ok {
+		ret.Request = &request
+	}
+	if limit, ok := requirements.Limits[name]; ok {
+		ret.Limit = &limit
+	}
+	used := float64(ret.Usage.MilliValue())
+	switch {
+	case ret.Limit != nil && !ret.Limit.IsZero() && used >= ProvisioningNearLimitThreshold*float64(ret.Limit.MilliValue()):
+		ret.Status = ProvisioningStatusNearLimit
+	case ret.Request == nil || ret.Request.IsZero():
+		ret.Status = ProvisioningStatusNoRequest
+	case used > float64(ret.Request.MilliValue()):
+		ret.Status = ProvisioningStatusUnderProvisioned
+	case used < ProvisioningOverThreshold*float64(ret.Request.MilliValue()):
+		ret.Status = ProvisioningStatusOverProvisioned
+	default:
+		ret.Status = ProvisioningStatusOK
+	}
+	return ret
+}
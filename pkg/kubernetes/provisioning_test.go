@@ -0,0 +1,34 @@
+package kubernetes
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestResourceProvisioning(t *testing.T) {
+	cases := []struct {
+		name         string
+		usage        string
+		requirements v1.ResourceRequirements
+		expected     ProvisioningStatus
+	}{
+		{"no request", "100m", v1.ResourceRequirements{}, ProvisioningStatusNoRequest},
+		{"over-provisioned", "10m", v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")}}, ProvisioningStatusOverProvisioned},
+		{"under-provisioned", "150m", v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")}}, ProvisioningStatusUnderProvisioned},
+		{"ok", "50m", v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")}}, ProvisioningStatusOK},
+		{"near limit", "190m", v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+			Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("200m")},
+		}, ProvisioningStatusNearLimit},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			usage := v1.ResourceList{v1.ResourceCPU: resource.MustParse(c.usage)}
+			if status := resourceProvisioning(v1.ResourceCPU, usage, c.requirements).Status; status != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// QuotaNearLimitThreshold usage above this fraction of the hard limit flags a quota close to be exhausted
+const QuotaNearLimitThreshold = 0.9
+
+var (
+	resourceQuotaGVK = &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ResourceQuota"}
+	limitRangeGVK    = &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "LimitRange"}
+)
+
+// containerResourceFields are the requests and limits checked in the workload containers, with the quota resources constraining them
+var containerResourceFields = []struct {
+	field          string
+	quotaResources []v1.ResourceName
+}{
+	{field: "requests.cpu", quotaResources: []v1.ResourceName{v1.ResourceCPU, v1.ResourceRequestsCPU}},
+	{field: "requests.memory", quotaResources: []v1.ResourceName{v1.ResourceMemory, v1.ResourceRequestsMemory}},
+	{field: "limits.cpu", quotaResources: []v1.ResourceName{v1.ResourceLimitsCPU}},
+	{field: "limits.memory", quotaResources: []v1.ResourceName{v1.ResourceLimitsMemory}},
+}
+
+// NamespaceQuotas is the overview of the ResourceQuotas and LimitRanges of a namespace
+type NamespaceQuotas struct {
+	Namespace   string              `json:"namespace"`
+	Quotas      []QuotaUsage        `json:"quotas,omitempty"`
+	LimitRanges []LimitRangeSummary `json:"limitRanges,omitempty"`
+	// MissingResources are the workload containers without requests or limits
+	MissingResources []WorkloadMissingResources `json:"missingResources,omitempty"`
+	// Issues reports the quotas close to be exhausted and the workloads that will be rejected by the quotas
+	Issues []string `json:"issues,omitempty"`
+}
+
+type QuotaUsage struct {
+	Name      string          `json:"name"`
+	Resources []QuotaResource `json:"resources"`
+}
+
+type QuotaResource struct {
+	Resource string `json:"resource"`
+	Used     string `json:"used"`
+	Hard     string `json:"hard"`
+	// Percentage is the usage of the hard limit, omitted for zero limits
+	Percentage *int64 `json:"percentage,omitempty"`
+}
+
+type LimitRangeSummary struct {
+	Name   string              `json:"name"`
+	Limits []v1.LimitRangeItem `json:"limits"`
+}
+
+type WorkloadMissingResources struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Container string `json:"container"`
+	// Missing are the requests and limits not set (e.g. requests.cpu, limits.memory)
+	Missing []string `json:"missing"`
+	// Defaulted are the missing requests and limits set by the defaults of the LimitRanges
+	Defaulted []string `json:"defaulted,omitempty"`
+}
+
+// workloadTemplate is the pod template of a workload
+type workloadTemplate struct {
+	namespace string
+	kind      string
+	name      string
+	spec      *v1.PodSpec
+}
+
+// ResourcesLister lists resources, e.g. Kubernetes.ResourcesList or its ACM proxy counterpart
+type ResourcesLister func(ctx context.Context, gvk *schema.GroupVersionKind, namespace string, options ResourceListOptions) (runtime.Unstructured, error)
+
+// QuotasOverview reports the quota usage compared to the hard limits, the LimitRange defaults, and the workload containers
+// without requests or limits of the namespace. If no namespace is provided, all the namespaces with a ResourceQuota or
+// a LimitRange are reported.
+func (k *Kubernetes) QuotasOverview(ctx context.Context, namespace string) ([]NamespaceQuotas, error) {
+	quotaList, err := k.ResourcesList(ctx, resourceQuotaGVK, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	limitRangeList, err := k.ResourcesList(ctx, limitRangeGVK, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	overviews := make(map[string]*NamespaceQuotas)
+	overview := func(namespace string) *NamespaceQuotas {
+		if _, ok := overviews[namespace]; !ok {
+			overviews[namespace] = &NamespaceQuotas{Namespace: namespace}
+		}
+		return overviews[namespace]
+	}
+	if namespace != "" {
+		overview(namespace)
+	}
+	for _, item := range quotaList.(*unstructured.UnstructuredList).Items {
+		quota := &v1.ResourceQuota{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, quota); err != nil {
+			return nil, err
+		}
+		o := overview(quota.Namespace)
+		o.Quotas = append(o.Quotas, quotaUsage(quota))
+	}
+	for _, item := range limitRangeList.(*unstructured.UnstructuredList).Items {
+		limitRange := &v1.LimitRange{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, limitRange); err != nil {
+			return nil, err
+		}
+		o := overview(limitRange.Namespace)
+		o.LimitRanges = append(o.LimitRanges, LimitRangeSummary{Name: limitRange.Name, Limits: limitRange.Spec.Limits})
+	}
+	ret := make([]NamespaceQuotas, 0, len(overviews))
+	for _, o := range overviews {
+		templates, err := workloadTemplates(ctx, k.ResourcesList, o.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		o.MissingResources = workloadMissingResources(templates, o.LimitRanges)
+		o.Issues = quotaIssues(o)
+		ret = append(ret, *o)
+	}
+	slices.SortFunc(ret, func(a, b NamespaceQuotas) int { return strings.Compare(a.Namespace, b.Namespace) })
+	return ret, nil
+}
+
+// workloadTemplates returns the pod templates of the Deployments, StatefulSets, DaemonSets, CronJobs, and standalone
+// Jobs and Pods of the namespace (all namespaces if empty)
+func workloadTemplates(ctx context.Context, list ResourcesLister, namespace string) ([]workloadTemplate, error) {
+	var ret []workloadTemplate
+	for _, gvk := range []*schema.GroupVersionKind{
+		{Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+		{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+		{Group: "batch", Version: "v1", Kind: "CronJob"},
+		{Group: "batch", Version: "v1", Kind: "Job"},
+		{Group: "", Version: "v1", Kind: "Pod"},
+	} {
+		workloads, err := list(ctx, gvk, namespace, ResourceListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range workloads.(*unstructured.UnstructuredList).Items {
+			// Jobs and Pods created by other workloads share their template
+			if (gvk.Kind == "Job" || gvk.Kind == "Pod") && len(item.GetOwnerReferences()) > 0 {
+				continue
+			}
+			fields := []string{"spec", "template", "spec"}
+			switch gvk.Kind {
+			case "CronJob":
+				fields = []string{"spec", "jobTemplate", "spec", "template", "spec"}
+			case "Pod":
+				fields = []string{"spec"}
+			}
+			podSpec, _, err := unstructured.NestedMap(item.Object, fields...)
+			if err != nil {
+				return nil, err
+			}
+			spec := &v1.PodSpec{}
+			if err = runtime.DefaultUnstructuredConverter.FromUnstructured(podSpec, spec); err != nil {
+				return nil, err
+			}
+			ret = append(ret, workloadTemplate{namespace: item.GetNamespace(), kind: gvk.Kind, name: item.GetName(), spec: spec})
+		}
+	}
+	return ret, nil
+}
+
+func quotaUsage(quota *v1.ResourceQuota) QuotaUsage {
+	ret := QuotaUsage{Name: quota.Name}
+	for name, hard := range quota.Status.Hard {
+		used := quota.Status.Used[name]
+		r := QuotaResource{Resource: string(name), Used: used.String(), Hard: hard.String()}
+		if !hard.IsZero() {
+			percentage := int64(used.AsApproximateFloat64() / hard.AsApproximateFloat64() * 100)
+			r.Percentage = &percentage
+		}
+		ret.Resources = append(ret.Resources, r)
+	}
+	slices.SortFunc(ret.Resources, func(a, b QuotaResource) int { return strings.Compare(a.Resource, b.Resource) })
+	return ret
+}
+
+// workloadMissingResources returns the containers of the workloads without requests or limits,
+// with the ones that will be set by the Container defaults of the LimitRanges
+func workloadMissingResources(templates []workloadTemplate, limitRanges []LimitRangeSummary) []WorkloadMissingResources {
+	defaults := map[string]bool{}
+	for _, limitRange := range limitRanges {
+		for _, limit := range limitRange.Limits {
+			if limit.Type != v1.LimitTypeContainer {
+				continue
+			}
+			// The default limit is also the default request if no default request is set
+			for name := range limit.Default {
+				defaults["limits."+string(name)] = true
+				defaults["requests."+string(name)] = true
+			}
+			for name := range limit.DefaultRequest {
+				defaults["requests."+string(name)] = true
+			}
+		}
+	}
+	var ret []WorkloadMissingResources
+	for _, template := range templates {
+		for _, container := range template.spec.Containers {
+			missing := WorkloadMissingResources{Kind: template.kind, Name: template.name, Container: container.Name}
+			for _, f := range containerResourceFields {
+				resources := container.Resources.Requests
+				if strings.HasPrefix(f.field, "limits.") {
+					resources = container.Resources.Limits
+				}
+				if _, ok := resources[v1.ResourceName(strings.SplitN(f.field, ".", 2)[1])]; ok {
+					continue
+				}
+				missing.Missing = append(missing.Missing, f.field)
+				if defaults[f.field] {
+					missing.Defaulted = append(missing.Defaulted, f.field)
+				}
+			}
+			if len(missing.Missing) > 0 {
+				ret = append(ret, missing)
+			}
+		}
+	}
+	return ret
+}
+
+// quotaIssues reports the quota resources close to be exhausted and the workloads whose pods will be rejected because
+// they don't set the requests or limits constrained by the quotas and the LimitRanges don't default them
+func quotaIssues(overview *NamespaceQuotas) []string {
+	var issues []string
+	constrained := map[string]string{}
+	for _, quota := range overview.Quotas {
+		for _, r := range quota.Resources {
+			if r.Percentage != nil && *r.Percentage >= 100 {
+				issues = append(issues, fmt.Sprintf("quota %s is exhausted for %s (%s/%s), new pods requesting it will be rejected", quota.Name, r.Resource, r.Used, r.Hard))
+			} else if r.Percentage != nil && float64(*r.Percentage) >= QuotaNearLimitThreshold*100 {
+				issues = append(issues, fmt.Sprintf("quota %s is at %d%% for %s (%s/%s)", quota.Name, *r.Percentage, r.Resource, r.Used, r.Hard))
+			}
+			for _, f := range containerResourceFields {
+				if slices.Contains(f.quotaResources, v1.ResourceName(r.Resource)) {
+					constrained[f.field] = quota.Name
+				}
+			}
+		}
+	}
+	for _, missing := range overview.MissingResources {
+		var rejected []string
+		for _, field := range missing.Missing {
+			if _, ok := constrained[field]; ok && !slices.Contains(missing.Defaulted, field) {
+				rejected = append(rejected, field)
+			}
+		}
+		if len(rejected) > 0 {
+			issues = append(issues, fmt.Sprintf("%s %s container %s doesn't set %s required by quota %s, its pods will be rejected",
+				missing.Kind, missing.Name, missing.Container, strings.Join(rejected, ", "), constrained[rejected[0]]))
+		}
+	}
+	return issues
+}
@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"slices"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestQuotaUsage(t *testing.T) {
+	usage := quotaUsage(&v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute"},
+		Status: v1.ResourceQuotaStatus{
+			Hard: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("2"), v1.ResourcePods: resource.MustParse("0")},
+			Used: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("1500m")},
+		},
+	})
+	if len(usage.Resources) != 2 || usage.Resources[0].Resource != "pods" || usage.Resources[1].Resource != "requests.cpu" {
+		t.Fatalf("unexpected resources %v", usage.Resources)
+	}
+	if usage.Resources[0].Percentage != nil {
+		t.Errorf("expected no percentage for zero limits, got %d", *usage.Resources[0].Percentage)
+	}
+	if r := usage.Resources[1]; r.Used != "1500m" || r.Hard != "2" || r.Percentage == nil || *r.Percentage != 75 {
+		t.Errorf("unexpected usage %v", r)
+	}
+}
+
+func TestQuotaIssues(t *testing.T) {
+	templates := []workloadTemplate{
+		{kind: "Deployment", name: "web", spec: &v1.PodSpec{Containers: []v1.Container{{
+			Name:      "nginx",
+			Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")}},
+		}}}},
+		{kind: "Pod", name: "debug", spec: &v1.PodSpec{Containers: []v1.Container{{
+			Name: "shell",
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m"), v1.ResourceMemory: resource.MustParse("64Mi")},
+				Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m"), v1.ResourceMemory: resource.MustParse("64Mi")},
+			},
+		}}}},
+	}
+	limitRanges := []LimitRangeSummary{{Name: "defaults", Limits: []v1.LimitRangeItem{{
+		Type:    v1.LimitTypeContainer,
+		Default: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+	}}}}
+	overview := &NamespaceQuotas{
+		Namespace:        "team-a",
+		LimitRanges:      limitRanges,
+		MissingResources: workloadMissingResources(templates, limitRanges),
+		Quotas: []QuotaUsage{{Name: "compute", Resources: []QuotaResource{
+			{Resource: "limits.cpu", Used: "1", Hard: "10", Percentage: ptr.To(int64(10))},
+			{Resource: "requests.memory", Used: "950Mi", Hard: "1Gi", Percentage: ptr.To(int64(92))},
+			{Resource: "pods", Used: "10", Hard: "10", Percentage: ptr.To(int64(100))},
+		}}},
+	}
+	t.Run("reports the containers without requests or limits", func(t *testing.T) {
+		if len(overview.MissingResources) != 1 {
+			t.Fatalf("expected only the web container, got %v", overview.MissingResources)
+		}
+		missing := overview.MissingResources[0]
+		if missing.Name != "web" || !slices.Equal(missing.Missing, []string{"requests.memory", "limits.cpu", "limits.memory"}) {
+			t.Errorf("unexpected missing resources %v", missing)
+		}
+		if !slices.Equal(missing.Defaulted, []string{"limits.cpu"}) {
+			t.Errorf("expected limits.cpu to be defaulted by the LimitRange, got %v", missing.Defaulted)
+		}
+	})
+	t.Run("reports the exhausted quotas and the rejected workloads", func(t *testing.T) {
+		expected := []string{
+			"quota compute is at 92% for requests.memory (950Mi/1Gi)",
+			"quota compute is exhausted for pods (10/10), new pods requesting it will be rejected",
+			"Deployment web container nginx doesn't set requests.memory required by quota compute, its pods will be rejected",
+		}
+		if issues := quotaIssues(overview); !slices.Equal(issues, expected) {
+			t.Errorf("unexpected issues %v", issues)
+		}
+	})
+}
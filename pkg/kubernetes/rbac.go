@@ -0,0 +1,132 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	roleGVK               = &schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"}
+	clusterRoleGVK        = &schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}
+	roleBindingGVK        = &schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"}
+	clusterRoleBindingGVK = &schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding"}
+)
+
+// WhoCanSubject is a subject (User, Group, or ServiceAccount) allowed to perform an access, with the bindings granting it
+type WhoCanSubject struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Via are the bindings and roles granting the access (e.g. ClusterRoleBinding/admins -> ClusterRole/admin)
+	Via []string `json:"via"`
+}
+
+// AuthWhoCan returns the subjects bound to a Role or ClusterRole with a rule allowing the access, same as kubectl who-can.
+// The ClusterRoleBindings grant the access in every namespace, the RoleBindings only in the namespace of the access (none if empty).
+// Only the RBAC authorizer is considered (not webhooks, nor the cluster-admin rights of system:masters outside RBAC).
+func (k *Kubernetes) AuthWhoCan(ctx context.Context, attributes AuthorizationAttributes) ([]WhoCanSubject, error) {
+	clusterRoles, err := k.rbacList(ctx, clusterRoleGVK, "")
+	if err != nil {
+		return nil, err
+	}
+	clusterRoleBindings, err := k.rbacList(ctx, clusterRoleBindingGVK, "")
+	if err != nil {
+		return nil, err
+	}
+	var roles, roleBindings []unstructured.Unstructured
+	if attributes.Namespace != "" {
+		if roles, err = k.rbacList(ctx, roleGVK, attributes.Namespace); err != nil {
+			return nil, err
+		}
+		if roleBindings, err = k.rbacList(ctx, roleBindingGVK, attributes.Namespace); err != nil {
+			return nil, err
+		}
+	}
+	allowed := make(map[string]bool)
+	for kind, items := range map[string][]unstructured.Unstructured{"ClusterRole": clusterRoles, "Role": roles} {
+		for _, item := range items {
+			role := &rbacv1.ClusterRole{}
+			if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, role); err != nil {
+				return nil, err
+			}
+			if slices.ContainsFunc(role.Rules, func(rule rbacv1.PolicyRule) bool { return policyRuleAllows(rule, attributes) }) {
+				allowed[kind+"/"+role.Name] = true
+			}
+		}
+	}
+	subjects := make(map[string]*WhoCanSubject)
+	for kind, items := range map[string][]unstructured.Unstructured{"ClusterRoleBinding": clusterRoleBindings, "RoleBinding": roleBindings} {
+		for _, item := range items {
+			binding := &rbacv1.RoleBinding{}
+			if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, binding); err != nil {
+				return nil, err
+			}
+			role := binding.RoleRef.Kind + "/" + binding.RoleRef.Name
+			if !allowed[role] {
+				continue
+			}
+			for _, subject := range binding.Subjects {
+				key := subject.Kind + "/" + subject.Namespace + "/" + subject.Name
+				if subjects[key] == nil {
+					subjects[key] = &WhoCanSubject{Kind: subject.Kind, Name: subject.Name, Namespace: subject.Namespace}
+				}
+				subjects[key].Via = append(subjects[key].Via, fmt.Sprintf("%s/%s -> %s", kind, binding.Name, role))
+			}
+		}
+	}
+	ret := make([]WhoCanSubject, 0, len(subjects))
+	for _, subject := range subjects {
+		slices.Sort(subject.Via)
+		ret = append(ret, *subject)
+	}
+	slices.SortFunc(ret, func(a, b WhoCanSubject) int {
+		return strings.Compare(a.Kind+"/"+a.Namespace+"/"+a.Name, b.Kind+"/"+b.Namespace+"/"+b.Name)
+	})
+	return ret, nil
+}
+
+// AuthorizationAttributes is the access checked by AuthCanI and AuthWhoCan, the resource is the plural resource name (e.g. deployments)
+type AuthorizationAttributes struct {
+	Verb        string
+	Group       string
+	Resource    string
+	Subresource string
+	Namespace   string
+	Name        string
+}
+
+func (k *Kubernetes) rbacList(ctx context.Context, gvk *schema.GroupVersionKind, namespace string) ([]unstructured.Unstructured, error) {
+	list, err := k.ResourcesList(ctx, gvk, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.(*unstructured.UnstructuredList).Items, nil
+}
+
+// policyRuleAllows returns whether the rule allows the access (see the RBAC authorizer rule matching)
+func policyRuleAllows(rule rbacv1.PolicyRule, attributes AuthorizationAttributes) bool {
+	if !slices.Contains(rule.Verbs, rbacv1.VerbAll) && !slices.Contains(rule.Verbs, attributes.Verb) {
+		return false
+	}
+	if !slices.Contains(rule.APIGroups, rbacv1.APIGroupAll) && !slices.Contains(rule.APIGroups, attributes.Group) {
+		return false
+	}
+	resource := attributes.Resource
+	if attributes.Subresource != "" {
+		resource += "/" + attributes.Subresource
+	}
+	if !slices.ContainsFunc(rule.Resources, func(ruleResource string) bool {
+		return ruleResource == rbacv1.ResourceAll || ruleResource == resource ||
+			(attributes.Subresource != "" && ruleResource == "*/"+attributes.Subresource)
+	}) {
+		return false
+	}
+	return len(rule.ResourceNames) == 0 || (attributes.Name != "" && slices.Contains(rule.ResourceNames, attributes.Name))
+}
@@ -0,0 +1,41 @@
+package kubernetes
+
+import (
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestPolicyRuleAllows(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		rule       rbacv1.PolicyRule
+		attributes AuthorizationAttributes
+		expected   bool
+	}{
+		{"matches verb, group, and resource", rbacv1.PolicyRule{Verbs: []string{"get", "list"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
+			AuthorizationAttributes{Verb: "list", Group: "apps", Resource: "deployments"}, true},
+		{"rejects other verbs", rbacv1.PolicyRule{Verbs: []string{"get"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
+			AuthorizationAttributes{Verb: "delete", Group: "apps", Resource: "deployments"}, false},
+		{"rejects other groups", rbacv1.PolicyRule{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"deployments"}},
+			AuthorizationAttributes{Verb: "get", Group: "apps", Resource: "deployments"}, false},
+		{"matches wildcards", rbacv1.PolicyRule{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}},
+			AuthorizationAttributes{Verb: "delete", Group: "apps", Resource: "deployments", Subresource: "scale"}, true},
+		{"requires subresource rule", rbacv1.PolicyRule{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+			AuthorizationAttributes{Verb: "get", Resource: "pods", Subresource: "log"}, false},
+		{"matches subresource rule", rbacv1.PolicyRule{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"pods/log"}},
+			AuthorizationAttributes{Verb: "get", Resource: "pods", Subresource: "log"}, true},
+		{"matches wildcard resource subresource rule", rbacv1.PolicyRule{Verbs: []string{"update"}, APIGroups: []string{"*"}, Resources: []string{"*/scale"}},
+			AuthorizationAttributes{Verb: "update", Group: "apps", Resource: "deployments", Subresource: "scale"}, true},
+		{"matches resource names", rbacv1.PolicyRule{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"a-secret"}},
+			AuthorizationAttributes{Verb: "get", Resource: "secrets", Name: "a-secret"}, true},
+		{"rejects unnamed access to resource names", rbacv1.PolicyRule{Verbs: []string{"list"}, APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"a-secret"}},
+			AuthorizationAttributes{Verb: "list", Resource: "secrets"}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if allowed := policyRuleAllows(tc.rule, tc.attributes); allowed != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, allowed)
+			}
+		})
+	}
+}
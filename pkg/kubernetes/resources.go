@@ -2,18 +2,33 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"k8s.io/apimachinery/pkg/runtime"
 	"regexp"
 	"strings"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/version"
 	authv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	ApplyStatusCreated   = "created"
+	ApplyStatusUpdated   = "updated"
+	ApplyStatusUnchanged = "unchanged"
+	ApplyStatusError     = "error"
 )
 
 const (
@@ -26,9 +41,14 @@ const (
 type ResourceListOptions struct {
 	metav1.ListOptions
 	AsTable bool
+	// SortBy sorts the items of the returned page by name, age, or status (see SortList), the server order is kept if empty
+	SortBy string
 }
 
 func (k *Kubernetes) ResourcesList(ctx context.Context, gvk *schema.GroupVersionKind, namespace string, options ResourceListOptions) (runtime.Unstructured, error) {
+	if err := validateSortBy(options.SortBy); err != nil {
+		return nil, err
+	}
 	gvr, err := k.resourceFor(gvk)
 	if err != nil {
 		return nil, err
@@ -39,10 +59,16 @@ func (k *Kubernetes) ResourcesList(ctx context.Context, gvk *schema.GroupVersion
 	if isNamespaced && !k.canIUse(ctx, gvr, namespace, "list") && namespace == "" {
 		namespace = k.manager.configuredNamespace()
 	}
+	var ret runtime.Unstructured
 	if options.AsTable {
-		return k.resourcesListAsTable(ctx, gvk, gvr, namespace, options)
+		ret, err = k.resourcesListAsTable(ctx, gvk, gvr, namespace, options)
+	} else {
+		ret, err = k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).List(ctx, options.ListOptions)
 	}
-	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).List(ctx, options.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+	return ret, SortList(ret, options.SortBy)
 }
 
 func (k *Kubernetes) ResourcesGet(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
@@ -58,18 +84,151 @@ func (k *Kubernetes) ResourcesGet(ctx context.Context, gvk *schema.GroupVersionK
 	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
-func (k *Kubernetes) ResourcesCreateOrUpdate(ctx context.Context, resource string) ([]*unstructured.Unstructured, error) {
-	separator := regexp.MustCompile(`\r?\n---\r?\n`)
-	resources := separator.Split(resource, -1)
+// ApplyOptions are the server-side apply options used by ResourcesCreateOrUpdate
+type ApplyOptions struct {
+	// FieldManager is the manager owning the applied fields, defaults to the server binary name
+	FieldManager string
+	// Force takes the ownership of the fields in conflict with other field managers
+	Force bool
+}
+
+// ApplyResult is the server-side apply outcome of each of the objects provided to ResourcesCreateOrUpdate
+type ApplyResult struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	// Status is the outcome of the apply (created, updated, unchanged, or error)
+	Status string `json:"status"`
+	// Object is the applied object, nil if the apply failed
+	Object *unstructured.Unstructured `json:"-"`
+	// Err is the apply error, nil if the apply succeeded
+	Err       error           `json:"-"`
+	Error     string          `json:"error,omitempty"`
+	Conflicts []ApplyConflict `json:"conflicts,omitempty"`
+}
+
+// ApplyConflict is a field managed by another field manager with a different value
+type ApplyConflict struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ResourcesCreateOrUpdate server-side applies each of the objects in the provided YAML or JSON (multiple YAML documents and JSON arrays are supported).
+// The objects are applied independently, the failure of one of them (e.g. a conflict with another field manager, or a document that can't be parsed)
+// is reported in its result.
+func (k *Kubernetes) ResourcesCreateOrUpdate(ctx context.Context, resource string, options ApplyOptions) ([]ApplyResult, error) {
+	documents := ParseDocuments(resource)
+	if len(documents) == 0 {
+		return nil, errors.New("no resources found in the provided YAML or JSON")
+	}
+	results := make([]ApplyResult, 0, len(documents))
+	var err error
+	for _, document := range documents {
+		if document.Err != nil {
+			results = append(results, ApplyResult{Status: ApplyStatusError, Err: document.Err, Error: document.Err.Error()})
+			continue
+		}
+		obj := document.Object
+		result := ApplyResult{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Name: obj.GetName(), Namespace: obj.GetNamespace()}
+		gvk := obj.GroupVersionKind()
+		// The live object is only needed to report the status of the apply, the apply reports its own errors
+		live, _ := k.ResourcesGet(ctx, &gvk, obj.GetNamespace(), obj.GetName())
+		if result.Object, err = k.resourcesApply(ctx, obj, options); err != nil {
+			result.Status = ApplyStatusError
+			result.Err, result.Error = err, err.Error()
+			result.Conflicts = applyConflicts(err)
+		} else {
+			result.Namespace = result.Object.GetNamespace()
+			result.Status = ApplyStatus(live, result.Object)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// ApplyStatus returns whether the apply created, updated, or left unchanged the live object (nil if it didn't exist)
+func ApplyStatus(live, applied *unstructured.Unstructured) string {
+	if live == nil {
+		return ApplyStatusCreated
+	}
+	live, applied = live.DeepCopy(), applied.DeepCopy()
+	live.SetManagedFields(nil)
+	applied.SetManagedFields(nil)
+	if len(fieldChanges("", live.Object, applied.Object)) == 0 {
+		return ApplyStatusUnchanged
+	}
+	return ApplyStatusUpdated
+}
+
+// ParsedDocument is an object parsed from the provided YAML or JSON, or the error of the document that couldn't be parsed
+type ParsedDocument struct {
+	Object *unstructured.Unstructured
+	Err    error
+}
+
+// ParseResources parses the objects in the provided YAML or JSON, multiple YAML documents and JSON arrays are supported
+func ParseResources(resource string) ([]*unstructured.Unstructured, error) {
 	var parsedResources []*unstructured.Unstructured
-	for _, r := range resources {
-		var obj unstructured.Unstructured
-		if err := yaml.NewYAMLToJSONDecoder(strings.NewReader(r)).Decode(&obj); err != nil {
-			return nil, err
+	for _, document := range ParseDocuments(resource) {
+		if document.Err != nil {
+			return nil, document.Err
+		}
+		parsedResources = append(parsedResources, document.Object)
+	}
+	return parsedResources, nil
+}
+
+// ParseDocuments parses each of the objects in the provided YAML or JSON independently (multiple YAML documents and JSON arrays
+// are supported), the documents that can't be parsed are returned with their error. Empty documents are ignored.
+func ParseDocuments(resource string) []ParsedDocument {
+	separator := regexp.MustCompile(`\r?\n---\r?\n`)
+	var documents []ParsedDocument
+	for i, r := range separator.Split(resource, -1) {
+		var raw json.RawMessage
+		if err := yaml.NewYAMLToJSONDecoder(strings.NewReader(r)).Decode(&raw); err != nil {
+			if !errors.Is(err, io.EOF) {
+				documents = append(documents, ParsedDocument{Err: fmt.Errorf("document %d: %w", i+1, err)})
+			}
+			continue
+		}
+		items := []json.RawMessage{raw}
+		if trimmed := strings.TrimSpace(string(raw)); trimmed == "" || trimmed == "null" {
+			continue
+		} else if strings.HasPrefix(trimmed, "[") {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				documents = append(documents, ParsedDocument{Err: fmt.Errorf("document %d: %w", i+1, err)})
+				continue
+			}
+		}
+		for j, item := range items {
+			obj := &unstructured.Unstructured{}
+			if err := obj.UnmarshalJSON(item); err != nil {
+				if len(items) > 1 {
+					err = fmt.Errorf("item %d: %w", j+1, err)
+				}
+				documents = append(documents, ParsedDocument{Err: fmt.Errorf("document %d: %w", i+1, err)})
+				continue
+			}
+			documents = append(documents, ParsedDocument{Object: obj})
+		}
+	}
+	return documents
+}
+
+// applyConflicts extracts the field manager conflicts from a server-side apply error
+func applyConflicts(err error) []ApplyConflict {
+	var statusError *apierrors.StatusError
+	if !apierrors.IsConflict(err) || !errors.As(err, &statusError) || statusError.ErrStatus.Details == nil {
+		return nil
+	}
+	var conflicts []ApplyConflict
+	for _, cause := range statusError.ErrStatus.Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			conflicts = append(conflicts, ApplyConflict{Field: cause.Field, Message: cause.Message})
 		}
-		parsedResources = append(parsedResources, &obj)
 	}
-	return k.resourcesCreateOrUpdate(ctx, parsedResources)
+	return conflicts
 }
 
 func (k *Kubernetes) ResourcesDelete(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) error {
@@ -82,7 +241,7 @@ func (k *Kubernetes) ResourcesDelete(ctx context.Context, gvk *schema.GroupVersi
 	if namespaced, nsErr := k.isNamespaced(gvk); nsErr == nil && namespaced {
 		namespace = k.NamespaceOrDefault(namespace)
 	}
-	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{DryRun: dryRun(ctx)})
 }
 
 // resourcesListAsTable retrieves a list of resources in a table format.
@@ -134,29 +293,93 @@ func (k *Kubernetes) resourcesListAsTable(ctx context.Context, gvk *schema.Group
 
 func (k *Kubernetes) resourcesCreateOrUpdate(ctx context.Context, resources []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
 	for i, obj := range resources {
-		gvk := obj.GroupVersionKind()
-		gvr, rErr := k.resourceFor(&gvk)
-		if rErr != nil {
-			return nil, rErr
+		var err error
+		if resources[i], err = k.resourcesApply(ctx, obj, ApplyOptions{}); err != nil {
+			return nil, err
 		}
+	}
+	return resources, nil
+}
 
-		namespace := obj.GetNamespace()
-		// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
-		if namespaced, nsErr := k.isNamespaced(&gvk); nsErr == nil && namespaced {
-			namespace = k.NamespaceOrDefault(namespace)
-		}
-		resources[i], rErr = k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
-			FieldManager: version.BinaryName,
-		})
-		if rErr != nil {
-			return nil, rErr
-		}
-		// Clear the cache to ensure the next operation is performed on the latest exposed APIs (will change after the CRD creation)
-		if gvk.Kind == "CustomResourceDefinition" {
-			k.manager.accessControlRESTMapper.Reset()
+func (k *Kubernetes) resourcesApply(ctx context.Context, obj *unstructured.Unstructured, options ApplyOptions) (*unstructured.Unstructured, error) {
+	gvk := obj.GroupVersionKind()
+	gvr, err := k.resourceFor(&gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := obj.GetNamespace()
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := k.isNamespaced(&gvk); nsErr == nil && namespaced {
+		namespace = k.NamespaceOrDefault(namespace)
+	}
+	fieldManager := options.FieldManager
+	if fieldManager == "" {
+		fieldManager = version.BinaryName
+	}
+	applied, err := k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+		FieldManager: fieldManager,
+		Force:        options.Force,
+		DryRun:       dryRun(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Clear the cache to ensure the next operation is performed on the latest exposed APIs (will change after the CRD creation)
+	if gvk.Kind == "CustomResourceDefinition" && !IsDryRun(ctx) {
+		k.manager.accessControlRESTMapper.Reset()
+	}
+	return applied, nil
+}
+
+func (k *Kubernetes) resourcesPatch(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, patchType types.PatchType, data []byte) (*unstructured.Unstructured, error) {
+	gvr, err := k.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := k.isNamespaced(gvk); nsErr == nil && namespaced {
+		namespace = k.NamespaceOrDefault(namespace)
+	}
+	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Patch(ctx, name, patchType, data, metav1.PatchOptions{
+		FieldManager: version.BinaryName,
+		DryRun:       dryRun(ctx),
+	})
+}
+
+// ResourceInformer returns an informer watching the resource with the provided kind, namespace, and name,
+// the informer must be run by the caller
+func (k *Kubernetes) ResourceInformer(gvk *schema.GroupVersionKind, namespace, name string) (cache.SharedIndexInformer, error) {
+	gvr, err := k.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := k.isNamespaced(gvk); nsErr == nil && namespaced {
+		namespace = k.NamespaceOrDefault(namespace)
+	}
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	return dynamicinformer.NewFilteredDynamicInformer(k.manager.dynamicClient, *gvr, namespace, 0, cache.Indexers{}, func(options *metav1.ListOptions) {
+		options.FieldSelector = fieldSelector
+	}).Informer(), nil
+}
+
+// KindFor resolves the kind of the provided resource type, in any of the forms accepted by kubectl
+// (e.g. Pod, pod, pods, deployments.apps, deployments.v1.apps)
+func (k *Kubernetes) KindFor(resource string) (*schema.GroupVersionKind, error) {
+	fullySpecifiedGVR, groupResource := schema.ParseResourceArg(strings.ToLower(resource))
+	if fullySpecifiedGVR != nil {
+		if gvk, err := k.manager.accessControlRESTMapper.KindFor(*fullySpecifiedGVR); err == nil {
+			return &gvk, nil
 		}
 	}
-	return resources, nil
+	gvk, err := k.manager.accessControlRESTMapper.KindFor(groupResource.WithVersion(""))
+	if err != nil {
+		return nil, err
+	}
+	return &gvk, nil
 }
 
 func (k *Kubernetes) resourceFor(gvk *schema.GroupVersionKind) (*schema.GroupVersionResource, error) {
@@ -188,22 +411,13 @@ func (k *Kubernetes) supportsGroupVersion(groupVersion string) bool {
 }
 
 func (k *Kubernetes) canIUse(ctx context.Context, gvr *schema.GroupVersionResource, namespace, verb string) bool {
-	accessReviews, err := k.manager.accessControlClientSet.SelfSubjectAccessReviews()
-	if err != nil {
-		return false
-	}
-	response, err := accessReviews.Create(ctx, &authv1.SelfSubjectAccessReview{
-		Spec: authv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &authv1.ResourceAttributes{
-			Namespace: namespace,
-			Verb:      verb,
-			Group:     gvr.Group,
-			Version:   gvr.Version,
-			Resource:  gvr.Resource,
-		}},
-	}, metav1.CreateOptions{})
-	if err != nil {
-		// TODO: maybe return the error too
-		return false
-	}
-	return response.Status.Allowed
+	allowed, err := k.CanI(ctx, &authv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      verb,
+		Group:     gvr.Group,
+		Version:   gvr.Version,
+		Resource:  gvr.Resource,
+	})
+	// TODO: maybe return the error too
+	return err == nil && allowed
 }
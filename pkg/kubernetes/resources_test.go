@@ -0,0 +1,93 @@
+package kubernetes
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseResources(t *testing.T) {
+	t.Run("parses multiple YAML documents", func(t *testing.T) {
+		resources, err := ParseResources("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: b\n")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resources) != 2 || resources[0].GetKind() != "ConfigMap" || resources[1].GetName() != "b" {
+			t.Errorf("unexpected resources: %v", resources)
+		}
+	})
+	t.Run("parses JSON", func(t *testing.T) {
+		resources, err := ParseResources(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}}`)
+		if err != nil || len(resources) != 1 || resources[0].GetName() != "a" {
+			t.Errorf("unexpected resources: %v (%v)", resources, err)
+		}
+	})
+}
+
+func TestParseDocuments(t *testing.T) {
+	t.Run("parses JSON arrays", func(t *testing.T) {
+		documents := ParseDocuments(`[{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}}, {"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "b"}}]`)
+		if len(documents) != 2 || documents[0].Object.GetName() != "a" || documents[1].Object.GetKind() != "Secret" {
+			t.Errorf("unexpected documents: %v", documents)
+		}
+	})
+	t.Run("reports the documents that can't be parsed", func(t *testing.T) {
+		documents := ParseDocuments("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\nkind: [\n---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: b\n")
+		if len(documents) != 3 || documents[0].Err != nil || documents[1].Err == nil || documents[2].Object.GetName() != "b" {
+			t.Fatalf("unexpected documents: %v", documents)
+		}
+		if !strings.HasPrefix(documents[1].Err.Error(), "document 2: ") {
+			t.Errorf("unexpected error: %v", documents[1].Err)
+		}
+		if _, err := ParseResources("kind: [\n"); err == nil {
+			t.Error("expected ParseResources to fail")
+		}
+	})
+	t.Run("ignores empty documents", func(t *testing.T) {
+		documents := ParseDocuments("---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\n# comment\n---\n")
+		if len(documents) != 1 || documents[0].Object.GetName() != "a" {
+			t.Errorf("unexpected documents: %v", documents)
+		}
+	})
+}
+
+func TestApplyStatus(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1", "kind": "ConfigMap",
+		"metadata": map[string]interface{}{"name": "a", "resourceVersion": "1"},
+		"data":     map[string]interface{}{"key": "value"},
+	}}
+	if status := ApplyStatus(nil, live); status != ApplyStatusCreated {
+		t.Errorf("expected created, got %s", status)
+	}
+	if status := ApplyStatus(live, live.DeepCopy()); status != ApplyStatusUnchanged {
+		t.Errorf("expected unchanged, got %s", status)
+	}
+	updated := live.DeepCopy()
+	updated.SetResourceVersion("2")
+	if status := ApplyStatus(live, updated); status != ApplyStatusUpdated {
+		t.Errorf("expected updated, got %s", status)
+	}
+}
+
+func TestApplyConflicts(t *testing.T) {
+	t.Run("extracts field manager conflicts", func(t *testing.T) {
+		err := apierrors.NewApplyConflict([]metav1.StatusCause{
+			{Type: metav1.CauseTypeFieldManagerConflict, Field: ".data.key", Message: `conflict with "other-manager": .data.key`},
+			{Type: metav1.CauseTypeFieldValueInvalid, Field: ".data.other", Message: "invalid"},
+		}, "Apply failed with 1 conflict")
+		conflicts := applyConflicts(err)
+		if len(conflicts) != 1 || conflicts[0].Field != ".data.key" || conflicts[0].Message != `conflict with "other-manager": .data.key` {
+			t.Errorf("unexpected conflicts: %v", conflicts)
+		}
+	})
+	t.Run("ignores other errors", func(t *testing.T) {
+		if conflicts := applyConflicts(errors.New("other")); conflicts != nil {
+			t.Errorf("expected no conflicts, got %v", conflicts)
+		}
+	})
+}
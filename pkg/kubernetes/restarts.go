@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ContainerRestarts are the restarts and OOM kills of a container across the pods of a workload
+type ContainerRestarts struct {
+	Cluster   string `json:"cluster,omitempty"`
+	Namespace string `json:"namespace"`
+	// Kind and Workload are the controller of the pods (e.g. Deployment, StatefulSet, DaemonSet, Job), or Pod for standalone pods
+	Kind      string `json:"kind"`
+	Workload  string `json:"workload"`
+	Container string `json:"container"`
+	Init      bool   `json:"init,omitempty"`
+	Pods      int    `json:"pods"`
+	Restarts  int32  `json:"restarts"`
+	// OOMKilledPods is the number of pods whose container was last terminated by an OOM kill
+	OOMKilledPods int `json:"oomKilledPods,omitempty"`
+	// MemoryRequest and MemoryLimit are the ones of the container spec, empty if not set
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	MemoryLimit   string `json:"memoryLimit,omitempty"`
+	// LastTermination is the most recent termination of the container across the pods
+	LastTermination *ContainerTermination `json:"lastTermination,omitempty"`
+	// Waiting are the distinct reasons the container is currently waiting (e.g. CrashLoopBackOff)
+	Waiting []string `json:"waiting,omitempty"`
+}
+
+// ContainerTermination is the termination of the container of a pod
+type ContainerTermination struct {
+	Pod        string    `json:"pod"`
+	Reason     string    `json:"reason,omitempty"`
+	ExitCode   int32     `json:"exitCode"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+// WorkloadsRestarts returns the containers of the pods of the namespace (all namespaces if empty) with at least minRestarts restarts
+// or last terminated by an OOM kill, aggregated per workload, the hotspots first
+func WorkloadsRestarts(ctx context.Context, list ResourcesLister, namespace string, minRestarts int32) ([]ContainerRestarts, error) {
+	pods, err := list(ctx, &schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	byContainer := make(map[string]*ContainerRestarts)
+	for _, item := range pods.(*unstructured.UnstructuredList).Items {
+		pod := &v1.Pod{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, pod); err != nil {
+			return nil, err
+		}
+		addPodRestarts(byContainer, pod)
+	}
+	var ret []ContainerRestarts
+	for _, restarts := range byContainer {
+		if restarts.Restarts >= minRestarts || restarts.OOMKilledPods > 0 {
+			slices.Sort(restarts.Waiting)
+			ret = append(ret, *restarts)
+		}
+	}
+	SortRestarts(ret)
+	return ret, nil
+}
+
+// SortRestarts sorts the containers by OOM killed pods and restarts, the hotspots first
+func SortRestarts(restarts []ContainerRestarts) {
+	slices.SortFunc(restarts, func(a, b ContainerRestarts) int {
+		return cmp.Or(
+			cmp.Compare(b.OOMKilledPods, a.OOMKilledPods),
+			cmp.Compare(b.Restarts, a.Restarts),
+			strings.Compare(a.Cluster, b.Cluster),
+			strings.Compare(a.Namespace, b.Namespace),
+			strings.Compare(a.Workload, b.Workload),
+			strings.Compare(a.Container, b.Container),
+		)
+	})
+}
+
+func addPodRestarts(byContainer map[string]*ContainerRestarts, pod *v1.Pod) {
+	kind, workload := podWorkload(pod)
+	add := func(containers []v1.Container, statuses []v1.ContainerStatus, init bool) {
+		for _, status := range statuses {
+			index := slices.IndexFunc(containers, func(c v1.Container) bool { return c.Name == status.Name })
+			if index < 0 {
+				continue
+			}
+			key := strings.Join([]string{pod.Namespace, kind, workload, status.Name}, "/")
+			restarts := byContainer[key]
+			if restarts == nil {
+				restarts = &ContainerRestarts{Namespace: pod.Namespace, Kind: kind, Workload: workload, Container: status.Name, Init: init}
+				if memory, ok := containers[index].Resources.Requests[v1.ResourceMemory]; ok {
+					restarts.MemoryRequest = memory.String()
+				}
+				if memory, ok := containers[index].Resources.Limits[v1.ResourceMemory]; ok {
+					restarts.MemoryLimit = memory.String()
+				}
+				byContainer[key] = restarts
+			}
+			restarts.Pods++
+			restarts.Restarts += status.RestartCount
+			if status.State.Waiting != nil && status.State.Waiting.Reason != "" && !slices.Contains(restarts.Waiting, status.State.Waiting.Reason) {
+				restarts.Waiting = append(restarts.Waiting, status.State.Waiting.Reason)
+			}
+			// The current state of a terminated container (e.g. Jobs and crash looping containers) is its latest termination
+			terminated := status.State.Terminated
+			if terminated == nil {
+				terminated = status.LastTerminationState.Terminated
+			}
+			if terminated == nil {
+				continue
+			}
+			if terminated.Reason == "OOMKilled" {
+				restarts.OOMKilledPods++
+			}
+			if restarts.LastTermination == nil || terminated.FinishedAt.After(restarts.LastTermination.FinishedAt) {
+				restarts.LastTermination = &ContainerTermination{
+					Pod:        pod.Name,
+					Reason:     terminated.Reason,
+					ExitCode:   terminated.ExitCode,
+					StartedAt:  terminated.StartedAt.Time,
+					FinishedAt: terminated.FinishedAt.Time,
+				}
+			}
+		}
+	}
+	add(pod.Spec.InitContainers, pod.Status.InitContainerStatuses, true)
+	add(pod.Spec.Containers, pod.Status.ContainerStatuses, false)
+}
@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+func TestWorkloadsRestarts(t *testing.T) {
+	finished := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	webPod := func(name string, restarts int32, reason string, finishedAt time.Time) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "ns-1",
+				Name:            name,
+				Labels:          map[string]string{"pod-template-hash": "7d4b9c"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-7d4b9c", Controller: ptr.To(true)}},
+			},
+			Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web", Resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("128Mi")},
+			}}}},
+			Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+				Name:                 "web",
+				RestartCount:         restarts,
+				State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: reason, ExitCode: 137, FinishedAt: metav1.NewTime(finishedAt)}},
+			}}},
+		}
+	}
+	pods := []*v1.Pod{
+		webPod("web-7d4b9c-a", 3, "OOMKilled", finished),
+		webPod("web-7d4b9c-b", 4, "Error", finished.Add(time.Hour)),
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-2", Name: "flaky"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app"}}},
+			Status:     v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{Name: "app", RestartCount: 10}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-2", Name: "stable"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app"}}},
+			Status:     v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{Name: "app", RestartCount: 1}}},
+		},
+	}
+	list := func(_ context.Context, _ *schema.GroupVersionKind, _ string, _ ResourceListOptions) (runtime.Unstructured, error) {
+		ret := &unstructured.UnstructuredList{}
+		for _, pod := range pods {
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+			if err != nil {
+				return nil, err
+			}
+			ret.Items = append(ret.Items, unstructured.Unstructured{Object: obj})
+		}
+		return ret, nil
+	}
+	restarts, err := WorkloadsRestarts(t.Context(), list, "", 5)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	t.Run("reports the OOM killed and the restarting containers, the OOM killed first", func(t *testing.T) {
+		if len(restarts) != 2 || restarts[0].Workload != "web" || restarts[1].Workload != "flaky" {
+			t.Fatalf("unexpected restarts %v", restarts)
+		}
+	})
+	t.Run("aggregates the pods of the workload", func(t *testing.T) {
+		web := restarts[0]
+		if web.Kind != "Deployment" || web.Pods != 2 || web.Restarts != 7 || web.OOMKilledPods != 1 || web.MemoryLimit != "128Mi" {
+			t.Errorf("unexpected aggregation %v", web)
+		}
+		if len(web.Waiting) != 1 || web.Waiting[0] != "CrashLoopBackOff" {
+			t.Errorf("unexpected waiting reasons %v", web.Waiting)
+		}
+	})
+	t.Run("reports the most recent termination", func(t *testing.T) {
+		last := restarts[0].LastTermination
+		if last == nil || last.Pod != "web-7d4b9c-b" || last.Reason != "Error" || last.ExitCode != 137 || !last.FinishedAt.Equal(finished.Add(time.Hour)) {
+			t.Errorf("unexpected last termination %v", last)
+		}
+	})
+}
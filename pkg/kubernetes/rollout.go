@@ -0,0 +1,377 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/util/deployment/deployment.go#L37-L48
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+	changeCauseAnnotation        = "kubernetes.io/change-cause"
+	restartedAtAnnotation        = "kubectl.kubernetes.io/restartedAt"
+	deploymentTimedOutReason     = "ProgressDeadlineExceeded"
+)
+
+var replicaSetGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}
+var controllerRevisionGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ControllerRevision"}
+
+// RolloutKinds are the kinds of the workloads supporting rollouts
+var RolloutKinds = []string{"Deployment", "StatefulSet", "DaemonSet"}
+
+type RolloutStatus struct {
+	Kind       string             `json:"kind"`
+	Name       string             `json:"name"`
+	Namespace  string             `json:"namespace"`
+	Done       bool               `json:"done"`
+	Message    string             `json:"message"`
+	Conditions []RolloutCondition `json:"conditions,omitempty"`
+	// Issues lists the problems blocking (or likely to block) the rollout
+	Issues []string `json:"issues,omitempty"`
+}
+
+type RolloutCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type RolloutRevision struct {
+	Revision    int64     `json:"revision"`
+	Name        string    `json:"name"`
+	ChangeCause string    `json:"changeCause,omitempty"`
+	Images      []string  `json:"images,omitempty"`
+	Created     time.Time `json:"created"`
+	// template of the pods of the revision, used to roll back
+	template *v1.PodTemplateSpec
+	// data of the controller revision (StatefulSet, DaemonSet), used to roll back
+	data []byte
+}
+
+func rolloutGVK(kind string) (*schema.GroupVersionKind, error) {
+	if !slices.Contains(RolloutKinds, kind) {
+		return nil, fmt.Errorf("kind %s doesn't support rollouts, supported kinds are %v", kind, RolloutKinds)
+	}
+	return &schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: kind}, nil
+}
+
+// RolloutStatus returns the status of the latest rollout of the workload along with the analysis of its conditions,
+// the status message is the same as the one of kubectl rollout status
+func (k *Kubernetes) RolloutStatus(ctx context.Context, kind, namespace, name string) (*RolloutStatus, error) {
+	gvk, err := rolloutGVK(kind)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	ret := &RolloutStatus{Kind: kind, Name: name, Namespace: obj.GetNamespace()}
+	switch kind {
+	case "Deployment":
+		err = deploymentRolloutStatus(obj, ret)
+	case "StatefulSet":
+		err = statefulSetRolloutStatus(obj, ret)
+	case "DaemonSet":
+		err = daemonSetRolloutStatus(obj, ret)
+	}
+	return ret, err
+}
+
+// https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/polymorphichelpers/rollout_status.go#L59-L92
+func deploymentRolloutStatus(obj *unstructured.Unstructured, ret *RolloutStatus) error {
+	deployment := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment); err != nil {
+		return err
+	}
+	for _, condition := range deployment.Status.Conditions {
+		ret.Conditions = append(ret.Conditions, RolloutCondition{
+			Type: string(condition.Type), Status: string(condition.Status), Reason: condition.Reason, Message: condition.Message,
+		})
+		switch {
+		case condition.Type == appsv1.DeploymentProgressing && condition.Reason == deploymentTimedOutReason:
+			ret.Issues = append(ret.Issues, "the rollout exceeded its progress deadline: "+condition.Message)
+		case condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == v1.ConditionTrue:
+			ret.Issues = append(ret.Issues, "replicas can't be created: "+condition.Message)
+		case condition.Type == appsv1.DeploymentAvailable && condition.Status == v1.ConditionFalse:
+			ret.Issues = append(ret.Issues, "the deployment doesn't have the minimum number of available replicas: "+condition.Message)
+		}
+	}
+	if deployment.Spec.Paused {
+		ret.Issues = append(ret.Issues, "the deployment is paused, the rollout won't progress until it is resumed")
+	}
+	replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+	switch {
+	case deployment.Generation > deployment.Status.ObservedGeneration:
+		ret.Message = "Waiting for deployment spec update to be observed..."
+	case slices.ContainsFunc(deployment.Status.Conditions, func(c appsv1.DeploymentCondition) bool {
+		return c.Type == appsv1.DeploymentProgressing && c.Reason == deploymentTimedOutReason
+	}):
+		ret.Message = fmt.Sprintf("deployment %q exceeded its progress deadline", deployment.Name)
+	case deployment.Status.UpdatedReplicas < replicas:
+		ret.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...", deployment.Name, deployment.Status.UpdatedReplicas, replicas)
+	case deployment.Status.Replicas > deployment.Status.UpdatedReplicas:
+		ret.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...", deployment.Name, deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	case deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas:
+		ret.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...", deployment.Name, deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	default:
+		ret.Message = fmt.Sprintf("deployment %q successfully rolled out", deployment.Name)
+		ret.Done = true
+	}
+	return nil
+}
+
+// https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/polymorphichelpers/rollout_status.go#L120-L150
+func statefulSetRolloutStatus(obj *unstructured.Unstructured, ret *RolloutStatus) error {
+	sts := &appsv1.StatefulSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, sts); err != nil {
+		return err
+	}
+	for _, condition := range sts.Status.Conditions {
+		ret.Conditions = append(ret.Conditions, RolloutCondition{
+			Type: string(condition.Type), Status: string(condition.Status), Reason: condition.Reason, Message: condition.Message,
+		})
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		ret.Issues = append(ret.Issues, "the update strategy is OnDelete, the pods are only updated once they are deleted")
+	}
+	replicas := ptr.Deref(sts.Spec.Replicas, 1)
+	switch {
+	case sts.Status.ObservedGeneration == 0 || sts.Generation > sts.Status.ObservedGeneration:
+		ret.Message = "Waiting for statefulset spec update to be observed..."
+	case sts.Status.ReadyReplicas < replicas:
+		ret.Message = fmt.Sprintf("Waiting for %d pods to be ready...", replicas-sts.Status.ReadyReplicas)
+	case sts.Spec.UpdateStrategy.RollingUpdate != nil && sts.Spec.UpdateStrategy.RollingUpdate.Partition != nil &&
+		sts.Status.UpdatedReplicas < replicas-*sts.Spec.UpdateStrategy.RollingUpdate.Partition:
+		ret.Message = fmt.Sprintf("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated...",
+			sts.Status.UpdatedReplicas, replicas-*sts.Spec.UpdateStrategy.RollingUpdate.Partition)
+	case sts.Status.UpdateRevision != sts.Status.CurrentRevision:
+		ret.Message = fmt.Sprintf("waiting for statefulset rolling update to complete %d pods at revision %s...", sts.Status.UpdatedReplicas, sts.Status.UpdateRevision)
+	default:
+		ret.Message = fmt.Sprintf("statefulset rolling update complete %d pods at revision %s...", sts.Status.CurrentReplicas, sts.Status.CurrentRevision)
+		ret.Done = true
+	}
+	return nil
+}
+
+// https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/polymorphichelpers/rollout_status.go#L95-L117
+func daemonSetRolloutStatus(obj *unstructured.Unstructured, ret *RolloutStatus) error {
+	daemon := &appsv1.DaemonSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, daemon); err != nil {
+		return err
+	}
+	for _, condition := range daemon.Status.Conditions {
+		ret.Conditions = append(ret.Conditions, RolloutCondition{
+			Type: string(condition.Type), Status: string(condition.Status), Reason: condition.Reason, Message: condition.Message,
+		})
+	}
+	if daemon.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		ret.Issues = append(ret.Issues, "the update strategy is OnDelete, the pods are only updated once they are deleted")
+	}
+	if daemon.Status.NumberMisscheduled > 0 {
+		ret.Issues = append(ret.Issues, fmt.Sprintf("%d pods are running on nodes they are not supposed to run on", daemon.Status.NumberMisscheduled))
+	}
+	switch {
+	case daemon.Generation > daemon.Status.ObservedGeneration:
+		ret.Message = "Waiting for daemon set spec update to be observed..."
+	case daemon.Status.UpdatedNumberScheduled < daemon.Status.DesiredNumberScheduled:
+		ret.Message = fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d out of %d new pods have been updated...", daemon.Name, daemon.Status.UpdatedNumberScheduled, daemon.Status.DesiredNumberScheduled)
+	case daemon.Status.NumberAvailable < daemon.Status.DesiredNumberScheduled:
+		ret.Message = fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d of %d updated pods are available...", daemon.Name, daemon.Status.NumberAvailable, daemon.Status.DesiredNumberScheduled)
+	default:
+		ret.Message = fmt.Sprintf("daemon set %q successfully rolled out", daemon.Name)
+		ret.Done = true
+	}
+	return nil
+}
+
+// RolloutHistory returns the revisions of the workload sorted by revision number, the latest revision last
+func (k *Kubernetes) RolloutHistory(ctx context.Context, kind, namespace, name string) ([]RolloutRevision, error) {
+	gvk, err := rolloutGVK(kind)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	selector, found, err := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if err != nil || !found {
+		return nil, fmt.Errorf("%s %s has no selector", kind, name)
+	}
+	labelSelector := &metav1.LabelSelector{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(selector, labelSelector); err != nil {
+		return nil, err
+	}
+	parsedSelector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	historyGVK := &controllerRevisionGVK
+	if kind == "Deployment" {
+		historyGVK = &replicaSetGVK
+	}
+	listOptions := ResourceListOptions{}
+	listOptions.LabelSelector = parsedSelector.String()
+	list, err := k.ResourcesList(ctx, historyGVK, obj.GetNamespace(), listOptions)
+	if err != nil {
+		return nil, err
+	}
+	var ret []RolloutRevision
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		if !slices.ContainsFunc(item.GetOwnerReferences(), func(o metav1.OwnerReference) bool { return o.UID == obj.GetUID() }) {
+			continue
+		}
+		revision, err := rolloutRevision(kind, &item)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *revision)
+	}
+	slices.SortFunc(ret, func(a, b RolloutRevision) int { return int(a.Revision - b.Revision) })
+	return ret, nil
+}
+
+func rolloutRevision(kind string, item *unstructured.Unstructured) (*RolloutRevision, error) {
+	ret := &RolloutRevision{Name: item.GetName(), Created: item.GetCreationTimestamp().Time, ChangeCause: item.GetAnnotations()[changeCauseAnnotation]}
+	if kind == "Deployment" {
+		replicaSet := &appsv1.ReplicaSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, replicaSet); err != nil {
+			return nil, err
+		}
+		revision, err := strconv.ParseInt(replicaSet.Annotations[deploymentRevisionAnnotation], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid revision of replica set %s: %w", replicaSet.Name, err)
+		}
+		ret.Revision = revision
+		ret.template = replicaSet.Spec.Template.DeepCopy()
+		// https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/polymorphichelpers/rollback.go#L185-L187
+		delete(ret.template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	} else {
+		controllerRevision := &appsv1.ControllerRevision{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, controllerRevision); err != nil {
+			return nil, err
+		}
+		ret.Revision = controllerRevision.Revision
+		ret.data = controllerRevision.Data.Raw
+		// The controller revisions store the pod template as a strategic merge patch of the workload
+		patch := struct {
+			Spec struct {
+				Template v1.PodTemplateSpec `json:"template"`
+			} `json:"spec"`
+		}{}
+		if err := json.Unmarshal(controllerRevision.Data.Raw, &patch); err == nil {
+			ret.template = &patch.Spec.Template
+		}
+	}
+	if ret.template != nil {
+		for _, container := range ret.template.Spec.Containers {
+			ret.Images = append(ret.Images, container.Image)
+		}
+	}
+	return ret, nil
+}
+
+// RolloutRestart restarts the pods of the workload the same way kubectl rollout restart does, by annotating its pod template
+func (k *Kubernetes) RolloutRestart(ctx context.Context, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	gvk, err := rolloutGVK(kind)
+	if err != nil {
+		return nil, err
+	}
+	if kind == "Deployment" {
+		if err = k.rolloutNotPaused(ctx, gvk, namespace, name, "restart"); err != nil {
+			return nil, err
+		}
+	}
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"template": map[string]any{"metadata": map[string]any{
+		"annotations": map[string]string{restartedAtAnnotation: time.Now().Format(time.RFC3339)},
+	}}}})
+	if err != nil {
+		return nil, err
+	}
+	return k.resourcesPatch(ctx, gvk, namespace, name, types.StrategicMergePatchType, patch)
+}
+
+// RolloutUndo rolls the workload back to the provided revision, or to the previous revision if 0
+func (k *Kubernetes) RolloutUndo(ctx context.Context, kind, namespace, name string, toRevision int64) (*unstructured.Unstructured, error) {
+	gvk, err := rolloutGVK(kind)
+	if err != nil {
+		return nil, err
+	}
+	if kind == "Deployment" {
+		if err = k.rolloutNotPaused(ctx, gvk, namespace, name, "roll back"); err != nil {
+			return nil, err
+		}
+	}
+	history, err := k.RolloutHistory(ctx, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := undoRevision(history, toRevision)
+	if err != nil {
+		return nil, err
+	}
+	if kind != "Deployment" {
+		return k.resourcesPatch(ctx, gvk, namespace, name, types.StrategicMergePatchType, revision.data)
+	}
+	obj, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	deployment := &appsv1.Deployment{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment); err != nil {
+		return nil, err
+	}
+	if apiequality.Semantic.DeepEqual(&deployment.Spec.Template, revision.template) {
+		return nil, fmt.Errorf("skipped rollback, the current template already matches revision %d", revision.Revision)
+	}
+	// https://github.com/kubernetes/kubectl/blob/v0.34.1/pkg/polymorphichelpers/rollback.go#L150-L166
+	patch, err := json.Marshal([]map[string]any{{"op": "replace", "path": "/spec/template", "value": revision.template}})
+	if err != nil {
+		return nil, err
+	}
+	return k.resourcesPatch(ctx, gvk, namespace, name, types.JSONPatchType, patch)
+}
+
+// undoRevision returns the revision to roll back to, the previous revision (the one before the latest) if toRevision is 0
+func undoRevision(history []RolloutRevision, toRevision int64) (*RolloutRevision, error) {
+	if toRevision == 0 {
+		if len(history) < 2 {
+			return nil, errors.New("no rollout history found to roll back to")
+		}
+		return &history[len(history)-2], nil
+	}
+	for i := range history {
+		if history[i].Revision == toRevision {
+			return &history[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find the specified revision %d in history", toRevision)
+}
+
+func (k *Kubernetes) rolloutNotPaused(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name, action string) error {
+	obj, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return err
+	}
+	if paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused"); paused {
+		return fmt.Errorf("can't %s paused deployment (resume it first)", action)
+	}
+	return nil
+}
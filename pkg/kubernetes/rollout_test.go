@@ -0,0 +1,102 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func toUnstructured(t *testing.T, obj any) *unstructured.Unstructured {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatalf("failed to convert to unstructured: %v", err)
+	}
+	return &unstructured.Unstructured{Object: u}
+}
+
+func TestDeploymentRolloutStatus(t *testing.T) {
+	deployment := func(mutate func(d *appsv1.Deployment)) *unstructured.Unstructured {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-deployment", Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2,
+			},
+		}
+		mutate(d)
+		return toUnstructured(t, d)
+	}
+	t.Run("successfully rolled out", func(t *testing.T) {
+		ret := &RolloutStatus{}
+		if err := deploymentRolloutStatus(deployment(func(*appsv1.Deployment) {}), ret); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !ret.Done || ret.Message != `deployment "a-deployment" successfully rolled out` || len(ret.Issues) != 0 {
+			t.Errorf("unexpected status: %v", ret)
+		}
+	})
+	t.Run("waiting for updated replicas", func(t *testing.T) {
+		ret := &RolloutStatus{}
+		_ = deploymentRolloutStatus(deployment(func(d *appsv1.Deployment) { d.Status.UpdatedReplicas = 1 }), ret)
+		if ret.Done || !strings.Contains(ret.Message, "1 out of 2 new replicas have been updated") {
+			t.Errorf("unexpected status: %v", ret)
+		}
+	})
+	t.Run("progress deadline exceeded", func(t *testing.T) {
+		ret := &RolloutStatus{}
+		_ = deploymentRolloutStatus(deployment(func(d *appsv1.Deployment) {
+			d.Status.UpdatedReplicas = 1
+			d.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: deploymentTimedOutReason, Message: "timed out",
+			}}
+		}), ret)
+		if ret.Done || !strings.Contains(ret.Message, "exceeded its progress deadline") {
+			t.Errorf("unexpected message: %s", ret.Message)
+		}
+		if len(ret.Issues) != 1 || !strings.Contains(ret.Issues[0], "timed out") {
+			t.Errorf("expected progress deadline issue, got %v", ret.Issues)
+		}
+		if len(ret.Conditions) != 1 || ret.Conditions[0].Reason != deploymentTimedOutReason {
+			t.Errorf("expected condition to be reported, got %v", ret.Conditions)
+		}
+	})
+	t.Run("paused", func(t *testing.T) {
+		ret := &RolloutStatus{}
+		_ = deploymentRolloutStatus(deployment(func(d *appsv1.Deployment) { d.Spec.Paused = true }), ret)
+		if len(ret.Issues) != 1 || !strings.Contains(ret.Issues[0], "paused") {
+			t.Errorf("expected paused issue, got %v", ret.Issues)
+		}
+	})
+}
+
+func TestUndoRevision(t *testing.T) {
+	history := []RolloutRevision{{Revision: 1, Name: "rev-1"}, {Revision: 3, Name: "rev-3"}, {Revision: 4, Name: "rev-4"}}
+	t.Run("defaults to previous revision", func(t *testing.T) {
+		revision, err := undoRevision(history, 0)
+		if err != nil || revision.Name != "rev-3" {
+			t.Errorf("expected rev-3, got %v (%v)", revision, err)
+		}
+	})
+	t.Run("specified revision", func(t *testing.T) {
+		revision, err := undoRevision(history, 1)
+		if err != nil || revision.Name != "rev-1" {
+			t.Errorf("expected rev-1, got %v (%v)", revision, err)
+		}
+	})
+	t.Run("missing revision", func(t *testing.T) {
+		if _, err := undoRevision(history, 2); err == nil || !strings.Contains(err.Error(), "revision 2") {
+			t.Errorf("expected missing revision error, got %v", err)
+		}
+	})
+	t.Run("no previous revision", func(t *testing.T) {
+		if _, err := undoRevision(history[:1], 0); err == nil {
+			t.Error("expected error for single revision history")
+		}
+	})
+}
@@ -0,0 +1,246 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
+)
+
+// RouteResolution is an Ingress or HTTPRoute matching a host and path, with the backend Services and Pods serving the request
+type RouteResolution struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Match is the rule of the route matching the host and path
+	Match string `json:"match"`
+	// Parents are the Gateways the HTTPRoute is attached to and whether they accepted it
+	Parents  []HTTPRouteParent `json:"parents,omitempty"`
+	Backends []RouteBackend    `json:"backends,omitempty"`
+	// Issues reports why the route might not serve the request (e.g. not accepted by its Gateway, no load balancer address)
+	Issues []string `json:"issues,omitempty"`
+}
+
+type RouteBackend struct {
+	// Service is the namespace/name of the backend Service
+	Service      string   `json:"service"`
+	Port         string   `json:"port,omitempty"`
+	Weight       *int32   `json:"weight,omitempty"`
+	ReadyPods    []string `json:"readyPods,omitempty"`
+	NotReadyPods []string `json:"notReadyPods,omitempty"`
+	// Issues reports why the Service has no ready endpoints
+	Issues []string `json:"issues,omitempty"`
+}
+
+type routeMatch struct {
+	resolution RouteResolution
+	// exact and length define the precedence of the match, exact path matches first and then the longest paths
+	exact    bool
+	length   int
+	backends []routeBackendRef
+}
+
+type routeBackendRef struct {
+	kind      string
+	namespace string
+	name      string
+	port      string
+	weight    *int32
+}
+
+// RoutesResolve returns the Ingresses and HTTPRoutes matching the host and path, in order of precedence, with the
+// Services and Pods backing them and the attachment conditions of the HTTPRoutes to their Gateways.
+// HTTPRoutes are ignored if the Gateway API is not installed in the cluster.
+func (k *Kubernetes) RoutesResolve(ctx context.Context, namespace, host, path string) ([]RouteResolution, error) {
+	if path == "" {
+		path = "/"
+	}
+	var matches []routeMatch
+	ingresses, err := k.ingresses(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, ingress := range ingresses {
+		if match := ingressRouteMatch(&ingress, host, path); match != nil {
+			matches = append(matches, *match)
+		}
+	}
+	routes, err := k.httpRoutes(ctx, namespace)
+	if err != nil && !errors.Is(err, ErrGatewayAPINotInstalled) {
+		return nil, err
+	}
+	for _, route := range routes {
+		if match := httpRouteMatch(&route, host, path); match != nil {
+			matches = append(matches, *match)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].exact != matches[j].exact {
+			return matches[i].exact
+		}
+		return matches[i].length > matches[j].length
+	})
+	ret := make([]RouteResolution, 0, len(matches))
+	for _, match := range matches {
+		for _, ref := range match.backends {
+			match.resolution.Backends = append(match.resolution.Backends, k.routeBackend(ctx, ref))
+		}
+		ret = append(ret, match.resolution)
+	}
+	return ret, nil
+}
+
+func (k *Kubernetes) routeBackend(ctx context.Context, ref routeBackendRef) RouteBackend {
+	ret := RouteBackend{Service: ref.namespace + "/" + ref.name, Port: ref.port, Weight: ref.weight}
+	if ref.kind != "Service" {
+		ret.Service = ref.kind + " " + ret.Service
+		ret.Issues = append(ret.Issues, "the backend is not a Service, its endpoints can't be resolved")
+		return ret
+	}
+	service, err := k.ServicesDescribe(ctx, ref.namespace, ref.name)
+	if err != nil {
+		ret.Issues = append(ret.Issues, fmt.Sprintf("failed to describe the service: %v", err))
+		return ret
+	}
+	for _, endpoint := range service.Endpoints {
+		pod := endpoint.Pod
+		if pod == "" && len(endpoint.Addresses) > 0 {
+			pod = endpoint.Addresses[0]
+		}
+		if endpoint.Ready {
+			ret.ReadyPods = append(ret.ReadyPods, pod)
+		} else {
+			ret.NotReadyPods = append(ret.NotReadyPods, pod)
+		}
+	}
+	ret.Issues = append(ret.Issues, service.Issues...)
+	return ret
+}
+
+// ingressRouteMatch returns the most specific rule of the Ingress matching the host and path, or its default backend
+func ingressRouteMatch(ingress *networkingv1.Ingress, host, path string) *routeMatch {
+	var ret *routeMatch
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil || !hostMatches(rule.Host, host) {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			pathType := ptr.Deref(p.PathType, networkingv1.PathTypeImplementationSpecific)
+			exact := pathType == networkingv1.PathTypeExact
+			if (exact && p.Path != path) || (!exact && !pathPrefixMatches(p.Path, path)) {
+				continue
+			}
+			if ret != nil && (ret.exact || (!exact && ret.length >= len(p.Path))) {
+				continue
+			}
+			ruleHost := rule.Host
+			if ruleHost == "" {
+				ruleHost = "*"
+			}
+			ret = &routeMatch{
+				resolution: RouteResolution{Match: fmt.Sprintf("%s%s (%s)", ruleHost, p.Path, pathType)},
+				exact:      exact,
+				length:     len(p.Path),
+				backends:   ingressBackendRefs(ingress.Namespace, &p.Backend),
+			}
+		}
+	}
+	if ret == nil && ingress.Spec.DefaultBackend != nil {
+		ret = &routeMatch{
+			resolution: RouteResolution{Match: "default backend"},
+			length:     -1,
+			backends:   ingressBackendRefs(ingress.Namespace, ingress.Spec.DefaultBackend),
+		}
+	}
+	if ret == nil {
+		return nil
+	}
+	ret.resolution.Kind = "Ingress"
+	ret.resolution.Namespace = ingress.Namespace
+	ret.resolution.Name = ingress.Name
+	if len(ingress.Status.LoadBalancer.Ingress) == 0 {
+		ret.resolution.Issues = append(ret.resolution.Issues, "the ingress has no load balancer address, check that the ingress controller of its class is running")
+	}
+	return ret
+}
+
+func ingressBackendRefs(namespace string, backend *networkingv1.IngressBackend) []routeBackendRef {
+	switch {
+	case backend.Service != nil && backend.Service.Port.Name != "":
+		return []routeBackendRef{{kind: "Service", namespace: namespace, name: backend.Service.Name, port: backend.Service.Port.Name}}
+	case backend.Service != nil:
+		return []routeBackendRef{{kind: "Service", namespace: namespace, name: backend.Service.Name, port: strconv.Itoa(int(backend.Service.Port.Number))}}
+	case backend.Resource != nil:
+		return []routeBackendRef{{kind: backend.Resource.Kind, namespace: namespace, name: backend.Resource.Name}}
+	}
+	return nil
+}
+
+// httpRouteMatch returns the most specific rule of the HTTPRoute matching the host and path
+func httpRouteMatch(route *httpRouteObject, host, path string) *routeMatch {
+	if len(route.Spec.Hostnames) > 0 {
+		matched := false
+		for _, hostname := range route.Spec.Hostnames {
+			matched = matched || hostMatches(hostname, host)
+		}
+		if !matched {
+			return nil
+		}
+	}
+	var ret *routeMatch
+	for _, rule := range route.Spec.Rules {
+		for _, p := range httpRouteRulePaths(rule.Matches) {
+			pathType, value := p[0], p[1]
+			exact := pathType == "Exact"
+			switch pathType {
+			case "Exact":
+				if value != path {
+					continue
+				}
+			case "RegularExpression":
+				if matched, err := regexp.MatchString("^(?:"+value+")$", path); err != nil || !matched {
+					continue
+				}
+			default:
+				if !pathPrefixMatches(value, path) {
+					continue
+				}
+			}
+			if ret != nil && (ret.exact || (!exact && ret.length >= len(value))) {
+				continue
+			}
+			ret = &routeMatch{
+				resolution: RouteResolution{Match: fmt.Sprintf("%s (%s)", value, pathType)},
+				exact:      exact,
+				length:     len(value),
+			}
+			for _, backendRef := range rule.BackendRefs {
+				ref := routeBackendRef{
+					kind:      ptr.Deref(backendRef.Kind, "Service"),
+					namespace: ptr.Deref(backendRef.Namespace, route.Namespace),
+					name:      backendRef.Name,
+					weight:    backendRef.Weight,
+				}
+				if backendRef.Port != nil {
+					ref.port = strconv.Itoa(int(*backendRef.Port))
+				}
+				ret.backends = append(ret.backends, ref)
+			}
+		}
+	}
+	if ret == nil {
+		return nil
+	}
+	summary := httpRouteSummary(route)
+	ret.resolution.Kind = "HTTPRoute"
+	ret.resolution.Namespace = route.Namespace
+	ret.resolution.Name = route.Name
+	ret.resolution.Parents = summary.Parents
+	ret.resolution.Issues = summary.Issues
+	return ret
+}
@@ -0,0 +1,172 @@
+package kubernetes
+
+import (
+	"slices"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestHostMatches(t *testing.T) {
+	cases := []struct {
+		hostname, host string
+		expected       bool
+	}{
+		{"", "app.example.com", true},
+		{"app.example.com", "APP.example.com", true},
+		{"app.example.com", "api.example.com", false},
+		{"*.example.com", "app.example.com", true},
+		{"*.example.com", "a.b.example.com", false},
+		{"*.example.com", "example.com", false},
+	}
+	for _, c := range cases {
+		if actual := hostMatches(c.hostname, c.host); actual != c.expected {
+			t.Errorf("hostMatches(%s, %s) = %v, expected %v", c.hostname, c.host, actual, c.expected)
+		}
+	}
+}
+
+func TestPathPrefixMatches(t *testing.T) {
+	cases := []struct {
+		prefix, path string
+		expected     bool
+	}{
+		{"/", "/anything", true},
+		{"/foo", "/foo", true},
+		{"/foo/", "/foo/bar", true},
+		{"/foo", "/foobar", false},
+		{"/foo/bar", "/foo", false},
+	}
+	for _, c := range cases {
+		if actual := pathPrefixMatches(c.prefix, c.path); actual != c.expected {
+			t.Errorf("pathPrefixMatches(%s, %s) = %v, expected %v", c.prefix, c.path, actual, c.expected)
+		}
+	}
+}
+
+func TestIngressRouteMatch(t *testing.T) {
+	backend := func(name string) networkingv1.IngressBackend {
+		return networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: name, Port: networkingv1.ServiceBackendPort{Number: 80}}}
+	}
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec: networkingv1.IngressSpec{
+			DefaultBackend: ptr.To(backend("fallback")),
+			Rules: []networkingv1.IngressRule{{
+				Host: "app.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+					{Path: "/", PathType: ptr.To(networkingv1.PathTypePrefix), Backend: backend("frontend")},
+					{Path: "/api", PathType: ptr.To(networkingv1.PathTypePrefix), Backend: backend("api")},
+					{Path: "/api/health", PathType: ptr.To(networkingv1.PathTypeExact), Backend: backend("health")},
+				}}},
+			}},
+		},
+	}
+	t.Run("returns the longest matching prefix", func(t *testing.T) {
+		match := ingressRouteMatch(ingress, "app.example.com", "/api/users")
+		if match == nil || match.resolution.Match != "app.example.com/api (Prefix)" || match.backends[0].name != "api" || match.backends[0].port != "80" {
+			t.Fatalf("unexpected match %v", match)
+		}
+		if !slices.Equal(match.resolution.Issues, []string{"the ingress has no load balancer address, check that the ingress controller of its class is running"}) {
+			t.Errorf("unexpected issues %v", match.resolution.Issues)
+		}
+	})
+	t.Run("returns the exact match first", func(t *testing.T) {
+		match := ingressRouteMatch(ingress, "app.example.com", "/api/health")
+		if match == nil || !match.exact || match.backends[0].name != "health" {
+			t.Errorf("unexpected match %v", match)
+		}
+	})
+	t.Run("returns the default backend for other hosts", func(t *testing.T) {
+		match := ingressRouteMatch(ingress, "other.example.com", "/api")
+		if match == nil || match.resolution.Match != "default backend" || match.backends[0].name != "fallback" {
+			t.Errorf("unexpected match %v", match)
+		}
+	})
+}
+
+func TestHTTPRoute(t *testing.T) {
+	route := &httpRouteObject{}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(map[string]interface{}{
+		"metadata": map[string]interface{}{"namespace": "default", "name": "web"},
+		"spec": map[string]interface{}{
+			"hostnames":  []interface{}{"*.example.com"},
+			"parentRefs": []interface{}{map[string]interface{}{"name": "gateway", "namespace": "infra"}},
+			"rules": []interface{}{
+				map[string]interface{}{"backendRefs": []interface{}{map[string]interface{}{"name": "frontend", "port": int64(80)}}},
+				map[string]interface{}{
+					"matches":     []interface{}{map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/api"}}},
+					"backendRefs": []interface{}{map[string]interface{}{"name": "api", "namespace": "backend", "port": int64(8080), "weight": int64(90)}},
+				},
+			},
+		},
+		"status": map[string]interface{}{"parents": []interface{}{map[string]interface{}{
+			"parentRef": map[string]interface{}{"name": "gateway", "namespace": "infra"},
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Accepted", "status": "True", "reason": "Accepted"},
+				map[string]interface{}{"type": "ResolvedRefs", "status": "False", "reason": "BackendNotFound", "message": "service backend/api not found"},
+			},
+		}}},
+	}, route)
+	if err != nil {
+		t.Fatalf("failed to convert route: %v", err)
+	}
+	t.Run("summary describes the rules and the parent conditions", func(t *testing.T) {
+		summary := httpRouteSummary(route)
+		if !slices.Equal(summary.Rules, []string{"/ (PathPrefix) -> frontend:80", "/api (PathPrefix) -> backend/api:8080"}) {
+			t.Errorf("unexpected rules %v", summary.Rules)
+		}
+		if len(summary.Parents) != 1 || summary.Parents[0].Gateway != "infra/gateway" || !summary.Parents[0].Accepted {
+			t.Errorf("unexpected parents %v", summary.Parents)
+		}
+		if !slices.Equal(summary.Issues, []string{"parent infra/gateway condition ResolvedRefs is False: service backend/api not found"}) {
+			t.Errorf("unexpected issues %v", summary.Issues)
+		}
+	})
+	t.Run("match returns the most specific rule", func(t *testing.T) {
+		match := httpRouteMatch(route, "app.example.com", "/api/users")
+		if match == nil || match.resolution.Match != "/api (PathPrefix)" {
+			t.Fatalf("unexpected match %v", match)
+		}
+		if match.backends[0].namespace != "backend" || match.backends[0].port != "8080" || ptr.Deref(match.backends[0].weight, 0) != 90 {
+			t.Errorf("unexpected backends %v", match.backends)
+		}
+	})
+	t.Run("match ignores other hosts", func(t *testing.T) {
+		if match := httpRouteMatch(route, "example.org", "/"); match != nil {
+			t.Errorf("unexpected match %v", match)
+		}
+	})
+}
+
+func TestGatewaySummary(t *testing.T) {
+	gateway := &gatewayObject{}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(map[string]interface{}{
+		"metadata": map[string]interface{}{"namespace": "infra", "name": "gateway"},
+		"spec": map[string]interface{}{
+			"gatewayClassName": "istio",
+			"listeners":        []interface{}{map[string]interface{}{"name": "http", "protocol": "HTTP", "port": int64(80)}},
+		},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{"type": "Programmed", "status": "False", "message": "no addresses assigned"}},
+			"listeners": []interface{}{map[string]interface{}{
+				"name":           "http",
+				"attachedRoutes": int64(2),
+				"conditions":     []interface{}{map[string]interface{}{"type": "Conflicted", "status": "False"}},
+			}},
+		},
+	}, gateway)
+	if err != nil {
+		t.Fatalf("failed to convert gateway: %v", err)
+	}
+	summary := gatewaySummary(gateway)
+	if summary.Class != "istio" || len(summary.Listeners) != 1 || summary.Listeners[0].AttachedRoutes != 2 {
+		t.Errorf("unexpected summary %v", summary)
+	}
+	if !slices.Equal(summary.Issues, []string{"gateway condition Programmed is False: no addresses assigned"}) {
+		t.Errorf("unexpected issues %v", summary.Issues)
+	}
+}
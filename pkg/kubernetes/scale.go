@@ -0,0 +1,91 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// Scale is the readback of the scale subresource after it has been updated
+type Scale struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	// PreviousReplicas is the desired number of replicas before scaling
+	PreviousReplicas int32 `json:"previousReplicas"`
+	// Replicas is the desired number of replicas after scaling
+	Replicas int32 `json:"replicas"`
+	// CurrentReplicas is the number of replicas observed by the controller at the time of the readback
+	CurrentReplicas int32  `json:"currentReplicas"`
+	Selector        string `json:"selector,omitempty"`
+}
+
+// ResourcesScale sets the desired number of replicas of any resource exposing the scale subresource
+// (e.g. Deployment, ReplicaSet, StatefulSet, or a custom resource with the scale subresource enabled)
+func (k *Kubernetes) ResourcesScale(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, replicas int32) (*Scale, error) {
+	gvr, err := k.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if !k.hasSubresource(gvk, gvr.Resource+"/scale") {
+		return nil, fmt.Errorf("%s %s is not scalable (scale subresource not found)", gvk.GroupVersion().String(), gvk.Kind)
+	}
+
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := k.isNamespaced(gvk); nsErr == nil && namespaced {
+		namespace = k.NamespaceOrDefault(namespace)
+	}
+	client := k.manager.dynamicClient.Resource(*gvr).Namespace(namespace)
+	previous, err := client.Get(ctx, name, metav1.GetOptions{}, "scale")
+	if err != nil {
+		return nil, err
+	}
+	previousScale := &autoscalingv1.Scale{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(previous.Object, previousScale); err != nil {
+		return nil, err
+	}
+	patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+	updated, err := client.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{
+		FieldManager: version.BinaryName,
+		DryRun:       dryRun(ctx),
+	}, "scale")
+	if err != nil {
+		return nil, err
+	}
+	scale := &autoscalingv1.Scale{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(updated.Object, scale); err != nil {
+		return nil, err
+	}
+	return &Scale{
+		APIVersion:       gvk.GroupVersion().String(),
+		Kind:             gvk.Kind,
+		Name:             name,
+		Namespace:        scale.Namespace,
+		PreviousReplicas: previousScale.Spec.Replicas,
+		Replicas:         scale.Spec.Replicas,
+		CurrentReplicas:  scale.Status.Replicas,
+		Selector:         scale.Status.Selector,
+	}, nil
+}
+
+// hasSubresource checks if the API server exposes the provided subresource (e.g. deployments/scale) for the kind
+func (k *Kubernetes) hasSubresource(gvk *schema.GroupVersionKind, subresource string) bool {
+	apiResourceList, err := k.manager.discoveryClient.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		return false
+	}
+	for _, apiResource := range apiResourceList.APIResources {
+		if apiResource.Name == subresource {
+			return true
+		}
+	}
+	return false
+}
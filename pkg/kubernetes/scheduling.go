@@ -0,0 +1,552 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	resourcehelper "k8s.io/kubectl/pkg/util/resource"
+	"k8s.io/utils/ptr"
+)
+
+const defaultSchedulerName = "default-scheduler"
+
+// PodScheduling explains why a pod is not scheduled, by evaluating the scheduling constraints of the pod against each node
+type PodScheduling struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase"`
+	// NodeName is the node the pod is scheduled on, the constraints are not evaluated if set
+	NodeName string `json:"nodeName,omitempty"`
+	// SchedulerMessage is the message of the PodScheduled condition (e.g. 0/3 nodes are available: ...)
+	SchedulerMessage string `json:"schedulerMessage,omitempty"`
+	// Events are the messages of the FailedScheduling and cluster autoscaler events of the pod, the latest last
+	Events []string `json:"events,omitempty"`
+	// Issues are the problems preventing the scheduling regardless of the nodes (scheduling gates, volumes, scheduler)
+	Issues []string `json:"issues,omitempty"`
+	// Summary is the number of nodes rejected by each constraint, the most common first
+	Summary []string `json:"summary,omitempty"`
+	// Nodes are the nodes with the constraints rejecting the pod, empty reasons if the pod fits
+	Nodes []NodeFit `json:"nodes,omitempty"`
+	// Suggestions are the constraints to relax to get the pod scheduled
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// NodeFit is the evaluation of the scheduling constraints of a pod against a node
+type NodeFit struct {
+	Node    string   `json:"node"`
+	Fits    bool     `json:"fits"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// schedulingReason is a constraint rejecting a pod on a node, the nodes are grouped by constraint in the summary
+type schedulingReason struct {
+	// constraint is the rejecting constraint (e.g. Insufficient cpu), the same for all the nodes rejected by it
+	constraint string
+	// detail is the node specific detail of the rejection (e.g. the available cpu)
+	detail     string
+	suggestion string
+}
+
+func (r schedulingReason) String() string {
+	if r.detail == "" {
+		return r.constraint
+	}
+	return r.constraint + " (" + r.detail + ")"
+}
+
+// schedulingContext is the cluster state the scheduling constraints of a pod are evaluated against
+type schedulingContext struct {
+	pod   *v1.Pod
+	nodes []v1.Node
+	// pods are the scheduled and not terminated pods, by node
+	pods map[string][]v1.Pod
+	// volumeNodeSelectors are the node affinities of the PersistentVolumes bound to the claims of the pod, by PersistentVolume
+	volumeNodeSelectors map[string]*v1.NodeSelector
+}
+
+// PodsWhyPending explains why the pod is not scheduled: the scheduler events and condition, the issues of its volumes and scheduling gates,
+// and the node constraints (cordons, node selector and affinity, taints, resources, host ports, pod affinity and anti-affinity,
+// topology spread, and volume node affinity) rejecting it on each node, along with the constraints to relax
+func (k *Kubernetes) PodsWhyPending(ctx context.Context, namespace, name string) (*PodScheduling, error) {
+	namespace = k.NamespaceOrDefault(namespace)
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return nil, err
+	}
+	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := &PodScheduling{Namespace: pod.Namespace, Name: pod.Name, Phase: string(pod.Status.Phase), NodeName: pod.Spec.NodeName}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status != v1.ConditionTrue {
+			ret.SchedulerMessage = condition.Message
+		}
+	}
+	if ret.NodeName != "" {
+		return ret, nil
+	}
+	events, err := k.EventsList(ctx, namespace, EventsListOptions{InvolvedObjectKind: "Pod", InvolvedObjectName: name})
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if slices.Contains([]any{"FailedScheduling", "NotTriggerScaleUp", "TriggeredScaleUp"}, event["Reason"]) {
+			ret.Events = append(ret.Events, fmt.Sprintf("%s: %s", event["Reason"], event["Message"]))
+		}
+	}
+	if len(pod.Spec.SchedulingGates) > 0 {
+		gates := make([]string, 0, len(pod.Spec.SchedulingGates))
+		for _, gate := range pod.Spec.SchedulingGates {
+			gates = append(gates, gate.Name)
+		}
+		ret.Issues = append(ret.Issues, fmt.Sprintf("the pod has the scheduling gates %s, it's not scheduled until they are removed by their controllers", strings.Join(gates, ", ")))
+	}
+	if pod.Spec.SchedulerName != "" && pod.Spec.SchedulerName != defaultSchedulerName && len(ret.Events) == 0 {
+		ret.Issues = append(ret.Issues, fmt.Sprintf("the pod is scheduled by %s and has no scheduling events, check that the scheduler is running", pod.Spec.SchedulerName))
+	}
+	sc := &schedulingContext{pod: pod, pods: make(map[string][]v1.Pod), volumeNodeSelectors: make(map[string]*v1.NodeSelector)}
+	if ret.Issues, err = k.schedulingVolumes(ctx, sc, ret.Issues); err != nil {
+		return nil, err
+	}
+	if err = k.schedulingCluster(ctx, sc); err != nil {
+		return nil, err
+	}
+	podSchedulingNodes(sc, ret)
+	return ret, nil
+}
+
+// schedulingCluster records the nodes and the scheduled and not terminated pods of the cluster in the scheduling context
+func (k *Kubernetes) schedulingCluster(ctx context.Context, sc *schedulingContext) error {
+	nodes, err := k.manager.accessControlClientSet.Nodes()
+	if err != nil {
+		return err
+	}
+	nodeList, err := nodes.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	sc.nodes = nodeList.Items
+	allPods, err := k.manager.accessControlClientSet.Pods("")
+	if err != nil {
+		return err
+	}
+	podList, err := allPods.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, p := range podList.Items {
+		if p.Spec.NodeName != "" && p.Status.Phase != v1.PodSucceeded && p.Status.Phase != v1.PodFailed {
+			sc.pods[p.Spec.NodeName] = append(sc.pods[p.Spec.NodeName], p)
+		}
+	}
+	return nil
+}
+
+// schedulingVolumes appends the issues of the PersistentVolumeClaims of the pod and records the node affinity of the bound PersistentVolumes
+func (k *Kubernetes) schedulingVolumes(ctx context.Context, sc *schedulingContext, issues []string) ([]string, error) {
+	for _, volume := range sc.pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		claimName := volume.PersistentVolumeClaim.ClaimName
+		obj, err := k.ResourcesGet(ctx, persistentVolumeClaimGVK, sc.pod.Namespace, claimName)
+		if apierrors.IsNotFound(err) {
+			issues = append(issues, fmt.Sprintf("PersistentVolumeClaim %s not found, create it", claimName))
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		pvc := &v1.PersistentVolumeClaim{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pvc); err != nil {
+			return nil, err
+		}
+		if pvc.Status.Phase == v1.ClaimBound {
+			if obj, err = k.ResourcesGet(ctx, persistentVolumeGVK, "", pvc.Spec.VolumeName); err != nil {
+				return nil, err
+			}
+			pv := &v1.PersistentVolume{}
+			if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pv); err != nil {
+				return nil, err
+			}
+			if pv.Spec.NodeAffinity != nil && pv.Spec.NodeAffinity.Required != nil {
+				sc.volumeNodeSelectors[pv.Name] = pv.Spec.NodeAffinity.Required
+			}
+			continue
+		}
+		storageClassName := ptr.Deref(pvc.Spec.StorageClassName, "")
+		if storageClassName == "" {
+			issues = append(issues, fmt.Sprintf("PersistentVolumeClaim %s is %s without a storage class, it's only bound to a matching pre-provisioned PersistentVolume",
+				claimName, pvc.Status.Phase))
+			continue
+		}
+		obj, err = k.ResourcesGet(ctx, storageClassGVK, "", storageClassName)
+		if apierrors.IsNotFound(err) {
+			issues = append(issues, fmt.Sprintf("PersistentVolumeClaim %s is %s, its StorageClass %s doesn't exist", claimName, pvc.Status.Phase, storageClassName))
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		storageClass := &storagev1.StorageClass{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, storageClass); err != nil {
+			return nil, err
+		}
+		if storageClass.VolumeBindingMode != nil && *storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+			// The volume is provisioned once the pod is scheduled, the topology of the StorageClass restricts the nodes
+			if len(storageClass.AllowedTopologies) > 0 {
+				sc.volumeNodeSelectors["StorageClass "+storageClass.Name] = allowedTopologiesNodeSelector(storageClass.AllowedTopologies)
+			}
+			continue
+		}
+		issues = append(issues, fmt.Sprintf("PersistentVolumeClaim %s is %s, the %s provisioner of StorageClass %s didn't provision a volume (check the events of the claim)",
+			claimName, pvc.Status.Phase, storageClass.Provisioner, storageClassName))
+	}
+	return issues, nil
+}
+
+// podSchedulingNodes evaluates the constraints of the pod against each node, and summarizes the rejections with the constraints to relax
+func podSchedulingNodes(sc *schedulingContext, ret *PodScheduling) {
+	if len(sc.nodes) == 0 {
+		ret.Issues = append(ret.Issues, "the cluster has no nodes")
+		return
+	}
+	rejected := make(map[string]int)
+	suggestions := make(map[string]string)
+	for _, node := range sc.nodes {
+		fit := NodeFit{Node: node.Name, Fits: true}
+		for _, reason := range nodeSchedulingReasons(sc, &node) {
+			fit.Fits = false
+			fit.Reasons = append(fit.Reasons, reason.String())
+			rejected[reason.constraint]++
+			suggestions[reason.constraint] = reason.suggestion
+		}
+		ret.Nodes = append(ret.Nodes, fit)
+	}
+	constraints := slices.Collect(maps.Keys(rejected))
+	slices.SortFunc(constraints, func(a, b string) int {
+		if rejected[a] != rejected[b] {
+			return rejected[b] - rejected[a]
+		}
+		return strings.Compare(a, b)
+	})
+	for _, constraint := range constraints {
+		ret.Summary = append(ret.Summary, fmt.Sprintf("%d/%d node(s) %s", rejected[constraint], len(sc.nodes), constraint))
+		if !slices.Contains(ret.Suggestions, suggestions[constraint]) {
+			ret.Suggestions = append(ret.Suggestions, suggestions[constraint])
+		}
+	}
+	if slices.ContainsFunc(ret.Nodes, func(fit NodeFit) bool { return fit.Fits }) && len(ret.Issues) == 0 {
+		ret.Suggestions = append(ret.Suggestions, "some nodes fit the pod, it should be scheduled at the next attempt unless the cluster changed since the last scheduling failure")
+	}
+}
+
+// nodeSchedulingReasons returns the constraints rejecting the pod on the node, in the order of the scheduler filters
+func nodeSchedulingReasons(sc *schedulingContext, node *v1.Node) []schedulingReason {
+	var ret []schedulingReason
+	pod := sc.pod
+	unschedulableTaint := v1.Taint{Key: v1.TaintNodeUnschedulable, Effect: v1.TaintEffectNoSchedule}
+	if node.Spec.Unschedulable && !tolerationsTolerate(pod.Spec.Tolerations, &unschedulableTaint) {
+		ret = append(ret, schedulingReason{constraint: "were unschedulable (cordoned)",
+			suggestion: "uncordon the cordoned nodes (nodes_uncordon) once their maintenance is complete"})
+	}
+	if len(pod.Spec.NodeSelector) > 0 && !labels.SelectorFromSet(pod.Spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		selector := labels.SelectorFromSet(pod.Spec.NodeSelector).String()
+		ret = append(ret, schedulingReason{constraint: "didn't match the Pod's node selector " + selector,
+			suggestion: fmt.Sprintf("relax the nodeSelector %s of the pod, or label the nodes that should run it", selector)})
+	}
+	if affinity := pod.Spec.Affinity; affinity != nil && affinity.NodeAffinity != nil && affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil &&
+		!nodeSelectorMatches(affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution, node) {
+		ret = append(ret, schedulingReason{constraint: "didn't match the Pod's node affinity",
+			suggestion: "relax the requiredDuringSchedulingIgnoredDuringExecution node affinity of the pod (or make it preferredDuringSchedulingIgnoredDuringExecution), or label the nodes that should run it"})
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == v1.TaintEffectPreferNoSchedule || (taint.Key == v1.TaintNodeUnschedulable && node.Spec.Unschedulable) || tolerationsTolerate(pod.Spec.Tolerations, &taint) {
+			continue
+		}
+		ret = append(ret, schedulingReason{constraint: fmt.Sprintf("had untolerated taint {%s}", taint.ToString()),
+			suggestion: fmt.Sprintf("add a toleration for the taint %s to the pod if it's meant to run on these nodes, or remove the taint (nodes_taint)", taint.ToString())})
+		break
+	}
+	ret = append(ret, nodeResourcesReasons(sc, node)...)
+	ret = append(ret, nodePortsReasons(sc, node)...)
+	ret = append(ret, nodePodAffinityReasons(sc, node)...)
+	ret = append(ret, nodeTopologySpreadReasons(sc, node)...)
+	for _, source := range slices.Sorted(maps.Keys(sc.volumeNodeSelectors)) {
+		if !nodeSelectorMatches(sc.volumeNodeSelectors[source], node) {
+			if !strings.HasPrefix(source, "StorageClass ") {
+				source = "PersistentVolume " + source
+			}
+			ret = append(ret, schedulingReason{constraint: "had volume node affinity conflict", detail: source,
+				suggestion: "the volumes of the pod are only available in some nodes or zones, make sure nodes with capacity for the pod exist there"})
+		}
+	}
+	return ret
+}
+
+// nodeResourcesReasons returns the resources requested by the pod that are not available in the node
+func nodeResourcesReasons(sc *schedulingContext, node *v1.Node) []schedulingReason {
+	var ret []schedulingReason
+	if allocatable, ok := node.Status.Allocatable[v1.ResourcePods]; ok && int64(len(sc.pods[node.Name])) >= allocatable.Value() {
+		ret = append(ret, schedulingReason{constraint: "Too many pods", detail: fmt.Sprintf("%d pods allowed", allocatable.Value()),
+			suggestion: "add nodes, or raise the maximum number of pods per node"})
+	}
+	requests, _ := resourcehelper.PodRequestsAndLimits(sc.pod)
+	used := v1.ResourceList{}
+	for _, p := range sc.pods[node.Name] {
+		podRequests, _ := resourcehelper.PodRequestsAndLimits(&p)
+		for name, quantity := range podRequests {
+			total := used[name]
+			total.Add(quantity)
+			used[name] = total
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(requests)) {
+		requested := requests[name]
+		if requested.IsZero() {
+			continue
+		}
+		allocatable := node.Status.Allocatable[name]
+		available := allocatable.DeepCopy()
+		available.Sub(used[name])
+		if requested.Cmp(available) <= 0 {
+			continue
+		}
+		if available.Sign() < 0 {
+			available = resource.Quantity{}
+		}
+		ret = append(ret, schedulingReason{constraint: "Insufficient " + string(name),
+			detail:     fmt.Sprintf("requested %s, %s available of %s allocatable", requested.String(), available.String(), allocatable.String()),
+			suggestion: fmt.Sprintf("lower the %s requests of the pod, free capacity by scaling down other workloads, or add nodes with more %s", name, name)})
+	}
+	return ret
+}
+
+// nodePortsReasons returns the host ports requested by the pod already used by the pods of the node
+func nodePortsReasons(sc *schedulingContext, node *v1.Node) []schedulingReason {
+	used := make(map[string]bool)
+	for _, p := range sc.pods[node.Name] {
+		for _, port := range podHostPorts(&p) {
+			used[port] = true
+		}
+	}
+	for _, port := range podHostPorts(sc.pod) {
+		if used[port] {
+			return []schedulingReason{{constraint: "didn't have free ports for the requested pod ports", detail: port,
+				suggestion: "remove the hostPort of the pod containers (use a Service instead), or make sure a single replica runs per node (e.g. DaemonSet)"}}
+		}
+	}
+	return nil
+}
+
+func podHostPorts(pod *v1.Pod) []string {
+	var ret []string
+	for _, container := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		for _, port := range container.Ports {
+			if port.HostPort > 0 {
+				protocol := port.Protocol
+				if protocol == "" {
+					protocol = v1.ProtocolTCP
+				}
+				ret = append(ret, fmt.Sprintf("%d/%s", port.HostPort, protocol))
+			}
+		}
+	}
+	return ret
+}
+
+// nodePodAffinityReasons returns the required pod affinity and anti-affinity terms of the pod not satisfied in the topology domain of the node.
+// The namespaceSelector of the terms is approximated as all the namespaces.
+func nodePodAffinityReasons(sc *schedulingContext, node *v1.Node) []schedulingReason {
+	affinity := sc.pod.Spec.Affinity
+	if affinity == nil {
+		return nil
+	}
+	var ret []schedulingReason
+	if affinity.PodAffinity != nil {
+		for _, term := range affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			matching := sc.podsInDomain(node, term)
+			// A pod matching its own affinity term can be scheduled if no pod matches the term anywhere (e.g. the first replica)
+			if len(matching) == 0 && !(podAffinityTermMatches(sc.pod, sc.pod, term) && len(sc.podsMatching(term)) == 0) {
+				ret = append(ret, schedulingReason{constraint: "didn't match pod affinity rules", detail: "no pod matching " + describeSelector(term.LabelSelector, "all pods") + " in topology " + term.TopologyKey,
+					suggestion: "relax the required pod affinity of the pod (or make it preferred), or schedule the pods it must run with first"})
+				break
+			}
+		}
+	}
+	if affinity.PodAntiAffinity != nil {
+		for _, term := range affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if matching := sc.podsInDomain(node, term); len(matching) > 0 {
+				ret = append(ret, schedulingReason{constraint: "didn't match pod anti-affinity rules", detail: fmt.Sprintf("pod %s/%s in topology %s", matching[0].Namespace, matching[0].Name, term.TopologyKey),
+					suggestion: "relax the required pod anti-affinity of the pod (or make it preferred), or add nodes in other topology domains (e.g. more nodes or zones than replicas)"})
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// nodeTopologySpreadReasons returns the DoNotSchedule topology spread constraints of the pod that scheduling it on the node would violate
+func nodeTopologySpreadReasons(sc *schedulingContext, node *v1.Node) []schedulingReason {
+	var ret []schedulingReason
+	for _, constraint := range sc.pod.Spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable != v1.DoNotSchedule {
+			continue
+		}
+		suggestion := "increase maxSkew or set whenUnsatisfiable: ScheduleAnyway in the topology spread constraints of the pod, or add nodes in the topology domains with fewer pods"
+		domain, ok := node.Labels[constraint.TopologyKey]
+		if !ok {
+			ret = append(ret, schedulingReason{constraint: "didn't match pod topology spread constraints (missing required label)", detail: constraint.TopologyKey, suggestion: suggestion})
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+		if err != nil {
+			continue
+		}
+		// The pods matching the constraint in each domain of the nodes eligible for the pod
+		counts := make(map[string]int)
+		for _, n := range sc.nodes {
+			value, ok := n.Labels[constraint.TopologyKey]
+			if !ok || !sc.nodeEligible(&n) {
+				continue
+			}
+			counts[value] += 0
+			for _, p := range sc.pods[n.Name] {
+				if p.Namespace == sc.pod.Namespace && selector.Matches(labels.Set(p.Labels)) {
+					counts[value]++
+				}
+			}
+		}
+		minimum := -1
+		for _, count := range counts {
+			if minimum < 0 || count < minimum {
+				minimum = count
+			}
+		}
+		count := counts[domain]
+		if selector.Matches(labels.Set(sc.pod.Labels)) {
+			count++
+		}
+		if skew := count - max(minimum, 0); skew > int(constraint.MaxSkew) {
+			ret = append(ret, schedulingReason{constraint: "didn't match pod topology spread constraints",
+				detail:     fmt.Sprintf("%s=%s would have a skew of %d, maxSkew is %d", constraint.TopologyKey, domain, skew, constraint.MaxSkew),
+				suggestion: suggestion})
+		}
+	}
+	return ret
+}
+
+// nodeEligible returns whether the node matches the node selector and the required node affinity of the pod (topology spread nodeAffinityPolicy Honor)
+func (sc *schedulingContext) nodeEligible(node *v1.Node) bool {
+	if len(sc.pod.Spec.NodeSelector) > 0 && !labels.SelectorFromSet(sc.pod.Spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	affinity := sc.pod.Spec.Affinity
+	return affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil ||
+		nodeSelectorMatches(affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution, node)
+}
+
+// podsInDomain returns the pods matching the term in the topology domain of the node
+func (sc *schedulingContext) podsInDomain(node *v1.Node, term v1.PodAffinityTerm) []v1.Pod {
+	domain, ok := node.Labels[term.TopologyKey]
+	if !ok {
+		return nil
+	}
+	var ret []v1.Pod
+	for _, n := range sc.nodes {
+		if n.Labels[term.TopologyKey] != domain {
+			continue
+		}
+		for _, p := range sc.pods[n.Name] {
+			if podAffinityTermMatches(sc.pod, &p, term) {
+				ret = append(ret, p)
+			}
+		}
+	}
+	return ret
+}
+
+// podsMatching returns the scheduled pods matching the term in any node
+func (sc *schedulingContext) podsMatching(term v1.PodAffinityTerm) []v1.Pod {
+	var ret []v1.Pod
+	for _, pods := range sc.pods {
+		for _, p := range pods {
+			if podAffinityTermMatches(sc.pod, &p, term) {
+				ret = append(ret, p)
+			}
+		}
+	}
+	return ret
+}
+
+// podAffinityTermMatches returns whether the other pod matches the pod affinity term of the pod
+func podAffinityTermMatches(pod, other *v1.Pod, term v1.PodAffinityTerm) bool {
+	if term.NamespaceSelector == nil {
+		namespaces := term.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{pod.Namespace}
+		}
+		if !slices.Contains(namespaces, other.Namespace) {
+			return false
+		}
+	}
+	return term.LabelSelector != nil && selectorMatches(term.LabelSelector, other.Labels)
+}
+
+// nodeSelectorMatches returns whether the node matches any of the terms of the node selector
+func nodeSelectorMatches(nodeSelector *v1.NodeSelector, node *v1.Node) bool {
+	return slices.ContainsFunc(nodeSelector.NodeSelectorTerms, func(term v1.NodeSelectorTerm) bool {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			return false
+		}
+		return nodeSelectorRequirementsMatch(term.MatchExpressions, labels.Set(node.Labels)) &&
+			nodeSelectorRequirementsMatch(term.MatchFields, labels.Set{"metadata.name": node.Name})
+	})
+}
+
+func nodeSelectorRequirementsMatch(requirements []v1.NodeSelectorRequirement, set labels.Set) bool {
+	operators := map[v1.NodeSelectorOperator]selection.Operator{
+		v1.NodeSelectorOpIn:           selection.In,
+		v1.NodeSelectorOpNotIn:        selection.NotIn,
+		v1.NodeSelectorOpExists:       selection.Exists,
+		v1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+		v1.NodeSelectorOpGt:           selection.GreaterThan,
+		v1.NodeSelectorOpLt:           selection.LessThan,
+	}
+	for _, requirement := range requirements {
+		r, err := labels.NewRequirement(requirement.Key, operators[requirement.Operator], requirement.Values)
+		if err != nil || !r.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowedTopologiesNodeSelector converts the allowed topologies of a StorageClass to a node selector
+func allowedTopologiesNodeSelector(topologies []v1.TopologySelectorTerm) *v1.NodeSelector {
+	ret := &v1.NodeSelector{}
+	for _, topology := range topologies {
+		term := v1.NodeSelectorTerm{}
+		for _, expression := range topology.MatchLabelExpressions {
+			term.MatchExpressions = append(term.MatchExpressions, v1.NodeSelectorRequirement{
+				Key: expression.Key, Operator: v1.NodeSelectorOpIn, Values: expression.Values,
+			})
+		}
+		ret.NodeSelectorTerms = append(ret.NodeSelectorTerms, term)
+	}
+	return ret
+}
+
+func tolerationsTolerate(tolerations []v1.Toleration, taint *v1.Taint) bool {
+	return slices.ContainsFunc(tolerations, func(toleration v1.Toleration) bool { return toleration.ToleratesTaint(taint) })
+}
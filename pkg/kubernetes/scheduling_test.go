@@ -0,0 +1,141 @@
+package kubernetes
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func schedulingTestNode(name string, labels map[string]string, cpu string) v1.Node {
+	return v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: v1.NodeStatus{Allocatable: v1.ResourceList{
+			v1.ResourceCPU: resource.MustParse(cpu), v1.ResourceMemory: resource.MustParse("4Gi"), v1.ResourcePods: resource.MustParse("110"),
+		}},
+	}
+}
+
+func schedulingTestPod(namespace, name string, labels map[string]string, cpu string) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "c", Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+		}}}},
+	}
+}
+
+func TestNodeSchedulingReasons(t *testing.T) {
+	constraints := func(reasons []schedulingReason) []string {
+		ret := make([]string, 0, len(reasons))
+		for _, reason := range reasons {
+			ret = append(ret, reason.constraint)
+		}
+		return ret
+	}
+	t.Run("cordoned node", func(t *testing.T) {
+		pod := schedulingTestPod("ns", "pending", nil, "100m")
+		node := schedulingTestNode("node-1", nil, "2")
+		node.Spec.Unschedulable = true
+		node.Spec.Taints = []v1.Taint{{Key: v1.TaintNodeUnschedulable, Effect: v1.TaintEffectNoSchedule}}
+		reasons := constraints(nodeSchedulingReasons(&schedulingContext{pod: &pod}, &node))
+		if !slices.Equal(reasons, []string{"were unschedulable (cordoned)"}) {
+			t.Errorf("expected the node to be rejected as cordoned only, got %v", reasons)
+		}
+	})
+	t.Run("node selector and affinity", func(t *testing.T) {
+		pod := schedulingTestPod("ns", "pending", nil, "100m")
+		pod.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+		pod.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{
+				{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"a"}},
+			}}},
+		}}}
+		node := schedulingTestNode("node-1", map[string]string{"disk": "hdd", "zone": "b"}, "2")
+		reasons := constraints(nodeSchedulingReasons(&schedulingContext{pod: &pod}, &node))
+		if !slices.Equal(reasons, []string{"didn't match the Pod's node selector disk=ssd", "didn't match the Pod's node affinity"}) {
+			t.Errorf("unexpected reasons %v", reasons)
+		}
+		node = schedulingTestNode("node-2", map[string]string{"disk": "ssd", "zone": "a"}, "2")
+		if reasons = constraints(nodeSchedulingReasons(&schedulingContext{pod: &pod}, &node)); len(reasons) > 0 {
+			t.Errorf("expected the pod to fit, got %v", reasons)
+		}
+	})
+	t.Run("untolerated taint", func(t *testing.T) {
+		pod := schedulingTestPod("ns", "pending", nil, "100m")
+		node := schedulingTestNode("node-1", nil, "2")
+		node.Spec.Taints = []v1.Taint{
+			{Key: "soft", Effect: v1.TaintEffectPreferNoSchedule},
+			{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoSchedule},
+		}
+		reasons := constraints(nodeSchedulingReasons(&schedulingContext{pod: &pod}, &node))
+		if !slices.Equal(reasons, []string{"had untolerated taint {gpu=true:NoSchedule}"}) {
+			t.Errorf("unexpected reasons %v", reasons)
+		}
+		pod.Spec.Tolerations = []v1.Toleration{{Key: "gpu", Operator: v1.TolerationOpExists}}
+		if reasons = constraints(nodeSchedulingReasons(&schedulingContext{pod: &pod}, &node)); len(reasons) > 0 {
+			t.Errorf("expected the taint to be tolerated, got %v", reasons)
+		}
+	})
+	t.Run("insufficient resources", func(t *testing.T) {
+		pod := schedulingTestPod("ns", "pending", nil, "1500m")
+		node := schedulingTestNode("node-1", nil, "2")
+		sc := &schedulingContext{pod: &pod, pods: map[string][]v1.Pod{"node-1": {schedulingTestPod("ns", "running", nil, "1")}}}
+		reasons := nodeSchedulingReasons(sc, &node)
+		if len(reasons) != 1 || reasons[0].String() != "Insufficient cpu (requested 1500m, 1 available of 2 allocatable)" {
+			t.Errorf("unexpected reasons %v", reasons)
+		}
+	})
+	t.Run("pod anti-affinity", func(t *testing.T) {
+		labels := map[string]string{"app": "web"}
+		pod := schedulingTestPod("ns", "web-2", labels, "100m")
+		pod.Spec.Affinity = &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
+			{LabelSelector: &metav1.LabelSelector{MatchLabels: labels}, TopologyKey: "kubernetes.io/hostname"},
+		}}}
+		node := schedulingTestNode("node-1", map[string]string{"kubernetes.io/hostname": "node-1"}, "2")
+		sc := &schedulingContext{pod: &pod, nodes: []v1.Node{node}, pods: map[string][]v1.Pod{"node-1": {schedulingTestPod("ns", "web-1", labels, "100m")}}}
+		reasons := nodeSchedulingReasons(sc, &node)
+		if len(reasons) != 1 || reasons[0].String() != "didn't match pod anti-affinity rules (pod ns/web-1 in topology kubernetes.io/hostname)" {
+			t.Errorf("unexpected reasons %v", reasons)
+		}
+	})
+	t.Run("topology spread", func(t *testing.T) {
+		labels := map[string]string{"app": "web"}
+		pod := schedulingTestPod("ns", "web-3", labels, "100m")
+		pod.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{{
+			MaxSkew: 1, TopologyKey: "zone", WhenUnsatisfiable: v1.DoNotSchedule, LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+		}}
+		zoneA := schedulingTestNode("node-a", map[string]string{"zone": "a"}, "2")
+		zoneB := schedulingTestNode("node-b", map[string]string{"zone": "b"}, "2")
+		sc := &schedulingContext{pod: &pod, nodes: []v1.Node{zoneA, zoneB}, pods: map[string][]v1.Pod{"node-a": {schedulingTestPod("ns", "web-1", labels, "100m")}}}
+		reasons := nodeSchedulingReasons(sc, &zoneA)
+		if len(reasons) != 1 || reasons[0].String() != "didn't match pod topology spread constraints (zone=a would have a skew of 2, maxSkew is 1)" {
+			t.Errorf("unexpected reasons %v", reasons)
+		}
+		if reasons = nodeSchedulingReasons(sc, &zoneB); len(reasons) > 0 {
+			t.Errorf("expected the pod to fit in zone b, got %v", reasons)
+		}
+	})
+}
+
+func TestPodSchedulingNodes(t *testing.T) {
+	pod := schedulingTestPod("ns", "pending", nil, "3")
+	small := schedulingTestNode("small-1", nil, "2")
+	tainted := schedulingTestNode("tainted-1", nil, "8")
+	tainted.Spec.Taints = []v1.Taint{{Key: "dedicated", Value: "db", Effect: v1.TaintEffectNoSchedule}}
+	ret := &PodScheduling{}
+	podSchedulingNodes(&schedulingContext{pod: &pod, nodes: []v1.Node{small, schedulingTestNode("small-2", nil, "2"), tainted}}, ret)
+	expected := []string{"2/3 node(s) Insufficient cpu", "1/3 node(s) had untolerated taint {dedicated=db:NoSchedule}"}
+	if !slices.Equal(ret.Summary, expected) {
+		t.Errorf("expected summary %v, got %v", expected, ret.Summary)
+	}
+	if len(ret.Suggestions) != 2 || !strings.HasPrefix(ret.Suggestions[0], "lower the cpu requests of the pod") {
+		t.Errorf("unexpected suggestions %v", ret.Suggestions)
+	}
+	if slices.ContainsFunc(ret.Nodes, func(fit NodeFit) bool { return fit.Fits }) {
+		t.Errorf("expected no node to fit, got %v", ret.Nodes)
+	}
+}
@@ -0,0 +1,84 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var secretGVK = &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"}
+
+// ErrSecretValuesNotAllowed is returned when the values of a Secret are requested but the server configuration doesn't allow revealing them
+var ErrSecretValuesNotAllowed = errors.New("revealing the values of Secrets is not allowed by the server configuration (reveal_secret_values)")
+
+// Secret is a Secret with its metadata and key names, the values are only set when revealed
+type Secret struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	Type              string            `json:"type"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	Annotations       map[string]string `json:"annotations,omitempty"`
+	CreationTimestamp metav1.Time       `json:"creationTimestamp"`
+	Immutable         bool              `json:"immutable,omitempty"`
+	Keys              []SecretKey       `json:"keys"`
+}
+
+type SecretKey struct {
+	Name string `json:"name"`
+	// Size is the size of the decoded value in bytes
+	Size int `json:"size"`
+	// Value is the decoded value, only set when revealed
+	Value *string `json:"value,omitempty"`
+}
+
+// SecretsGet returns the metadata and the key names of the Secret. The values are only returned when revealValues
+// is requested and the server configuration allows revealing them.
+func (k *Kubernetes) SecretsGet(ctx context.Context, namespace, name string, revealValues bool) (*Secret, error) {
+	if revealValues && !k.manager.staticConfig.RevealSecretValues {
+		return nil, ErrSecretValuesNotAllowed
+	}
+	obj, err := k.ResourcesGet(ctx, secretGVK, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	secret := &v1.Secret{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, secret); err != nil {
+		return nil, err
+	}
+	return secretSummary(secret, revealValues), nil
+}
+
+func secretSummary(secret *v1.Secret, revealValues bool) *Secret {
+	ret := &Secret{
+		Name:              secret.Name,
+		Namespace:         secret.Namespace,
+		Type:              string(secret.Type),
+		Labels:            secret.Labels,
+		Annotations:       secret.Annotations,
+		CreationTimestamp: secret.CreationTimestamp,
+		Immutable:         secret.Immutable != nil && *secret.Immutable,
+		Keys:              make([]SecretKey, 0, len(secret.Data)),
+	}
+	// The last applied configuration contains the values of the Secret
+	if _, ok := ret.Annotations[v1.LastAppliedConfigAnnotation]; ok && !revealValues {
+		ret.Annotations = maps.Clone(secret.Annotations)
+		delete(ret.Annotations, v1.LastAppliedConfigAnnotation)
+	}
+	for key, value := range secret.Data {
+		secretKey := SecretKey{Name: key, Size: len(value)}
+		if revealValues {
+			decoded := string(value)
+			secretKey.Value = &decoded
+		}
+		ret.Keys = append(ret.Keys, secretKey)
+	}
+	slices.SortFunc(ret.Keys, func(a, b SecretKey) int { return strings.Compare(a.Name, b.Name) })
+	return ret
+}
@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretSummary(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "db",
+			Namespace:   "default",
+			Annotations: map[string]string{v1.LastAppliedConfigAnnotation: `{"data":{"password":"aHVudGVyMg=="}}`, "owner": "team-a"},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: map[string][]byte{"user": []byte("admin"), "password": []byte("hunter2")},
+	}
+	t.Run("returns the key names without values", func(t *testing.T) {
+		summary := secretSummary(secret, false)
+		if len(summary.Keys) != 2 || summary.Keys[0].Name != "password" || summary.Keys[0].Size != 7 || summary.Keys[1].Name != "user" {
+			t.Errorf("unexpected keys %v", summary.Keys)
+		}
+		for _, key := range summary.Keys {
+			if key.Value != nil {
+				t.Errorf("expected no value for key %s", key.Name)
+			}
+		}
+		if _, ok := summary.Annotations[v1.LastAppliedConfigAnnotation]; ok || summary.Annotations["owner"] != "team-a" {
+			t.Errorf("expected the last applied configuration to be removed, got %v", summary.Annotations)
+		}
+		if _, ok := secret.Annotations[v1.LastAppliedConfigAnnotation]; !ok {
+			t.Errorf("the original Secret should not be modified")
+		}
+	})
+	t.Run("returns the decoded values when revealed", func(t *testing.T) {
+		summary := secretSummary(secret, true)
+		if summary.Keys[0].Value == nil || *summary.Keys[0].Value != "hunter2" {
+			t.Errorf("expected revealed value, got %v", summary.Keys[0].Value)
+		}
+	})
+}
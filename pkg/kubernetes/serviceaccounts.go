@@ -0,0 +1,79 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// DefaultServiceAccountTokenExpiration is the default lifetime of a requested ServiceAccount token
+	DefaultServiceAccountTokenExpiration = time.Hour
+	// MinServiceAccountTokenExpiration is the minimum lifetime of a ServiceAccount token accepted by the API server
+	MinServiceAccountTokenExpiration = 10 * time.Minute
+	// MaxServiceAccountTokenExpiration is the maximum lifetime of a requested ServiceAccount token
+	MaxServiceAccountTokenExpiration = 24 * time.Hour
+)
+
+// ErrServiceAccountTokensNotAllowed is returned when a ServiceAccount token is requested but the server configuration doesn't allow it
+var ErrServiceAccountTokensNotAllowed = errors.New("requesting ServiceAccount tokens is not allowed by the server configuration (allow_service_account_tokens)")
+
+// ServiceAccountTokenOptions are the options of a TokenRequest
+type ServiceAccountTokenOptions struct {
+	Namespace string
+	Name      string
+	// Audiences are the intended audiences of the token, the API server audiences if empty
+	Audiences []string
+	// Expiration is the requested lifetime of the token, DefaultServiceAccountTokenExpiration if 0
+	Expiration time.Duration
+}
+
+// ServiceAccountToken is a token minted for a ServiceAccount
+type ServiceAccountToken struct {
+	ServiceAccount      string      `json:"serviceAccount"`
+	Namespace           string      `json:"namespace"`
+	Audiences           []string    `json:"audiences"`
+	ExpirationTimestamp metav1.Time `json:"expirationTimestamp"`
+	Token               string      `json:"token"`
+}
+
+// ServiceAccountsToken requests a bounded token for the ServiceAccount (same as kubectl create token).
+// The API server may issue a token with a shorter lifetime than requested.
+func (k *Kubernetes) ServiceAccountsToken(ctx context.Context, options ServiceAccountTokenOptions) (*ServiceAccountToken, error) {
+	if !k.manager.staticConfig.AllowServiceAccountTokens {
+		return nil, ErrServiceAccountTokensNotAllowed
+	}
+	expiration := options.Expiration
+	if expiration == 0 {
+		expiration = DefaultServiceAccountTokenExpiration
+	}
+	if expiration < MinServiceAccountTokenExpiration || expiration > MaxServiceAccountTokenExpiration {
+		return nil, fmt.Errorf("expiration %s must be between %s and %s", expiration, MinServiceAccountTokenExpiration, MaxServiceAccountTokenExpiration)
+	}
+	namespace := k.NamespaceOrDefault(options.Namespace)
+	serviceAccounts, err := k.manager.accessControlClientSet.ServiceAccounts(namespace)
+	if err != nil {
+		return nil, err
+	}
+	tokenRequest, err := serviceAccounts.CreateToken(ctx, options.Name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         options.Audiences,
+			ExpirationSeconds: ptr.To(int64(expiration.Seconds())),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &ServiceAccountToken{
+		ServiceAccount:      options.Name,
+		Namespace:           namespace,
+		Audiences:           tokenRequest.Spec.Audiences,
+		ExpirationTimestamp: tokenRequest.Status.ExpirationTimestamp,
+		Token:               tokenRequest.Status.Token,
+	}, nil
+}
@@ -0,0 +1,188 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+var endpointSliceGVK = &schema.GroupVersionKind{Group: "discovery.k8s.io", Version: "v1", Kind: "EndpointSlice"}
+
+// ServiceDescription is a Service joined with its EndpointSlices and the Pods matching its selector
+type ServiceDescription struct {
+	Namespace   string            `json:"namespace"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	ClusterIP   string            `json:"clusterIP,omitempty"`
+	ExternalIPs []string          `json:"externalIPs,omitempty"`
+	Selector    map[string]string `json:"selector,omitempty"`
+	Ports       []ServicePort     `json:"ports,omitempty"`
+	// MatchingPods is the number of Pods matching the selector of the Service
+	MatchingPods int               `json:"matchingPods"`
+	Endpoints    []ServiceEndpoint `json:"endpoints,omitempty"`
+	// Issues reports why the Service has no ready endpoints (e.g. selector matching zero pods, pods not ready, wrong target port)
+	Issues []string `json:"issues,omitempty"`
+}
+
+type ServicePort struct {
+	Name       string `json:"name,omitempty"`
+	Protocol   string `json:"protocol"`
+	Port       int32  `json:"port"`
+	TargetPort string `json:"targetPort,omitempty"`
+	NodePort   int32  `json:"nodePort,omitempty"`
+}
+
+type ServiceEndpoint struct {
+	Addresses   []string `json:"addresses"`
+	Ready       bool     `json:"ready"`
+	Terminating bool     `json:"terminating,omitempty"`
+	Pod         string   `json:"pod,omitempty"`
+	Node        string   `json:"node,omitempty"`
+	// Ports are the ports of the EndpointSlice (name:port/protocol)
+	Ports []string `json:"ports,omitempty"`
+}
+
+// ServicesDescribe returns the Service with its EndpointSlices, the ready and not ready endpoints with their target Pods,
+// and the issues explaining why the Service has no ready endpoints
+func (k *Kubernetes) ServicesDescribe(ctx context.Context, namespace, name string) (*ServiceDescription, error) {
+	namespace = k.NamespaceOrDefault(namespace)
+	services, err := k.manager.accessControlClientSet.Services(namespace)
+	if err != nil {
+		return nil, err
+	}
+	service, err := services.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var pods []v1.Pod
+	if len(service.Spec.Selector) > 0 {
+		podsClient, err := k.manager.accessControlClientSet.Pods(namespace)
+		if err != nil {
+			return nil, err
+		}
+		podList, err := podsClient.List(ctx, metav1.ListOptions{LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String()})
+		if err != nil {
+			return nil, err
+		}
+		pods = podList.Items
+	}
+	list, err := k.ResourcesList(ctx, endpointSliceGVK, namespace, ResourceListOptions{ListOptions: metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + name,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	var endpointSlices []discoveryv1.EndpointSlice
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		endpointSlice := discoveryv1.EndpointSlice{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &endpointSlice); err != nil {
+			return nil, err
+		}
+		endpointSlices = append(endpointSlices, endpointSlice)
+	}
+	return serviceDescription(service, endpointSlices, pods), nil
+}
+
+func serviceDescription(service *v1.Service, endpointSlices []discoveryv1.EndpointSlice, pods []v1.Pod) *ServiceDescription {
+	ret := &ServiceDescription{
+		Namespace:    service.Namespace,
+		Name:         service.Name,
+		Type:         string(service.Spec.Type),
+		ClusterIP:    service.Spec.ClusterIP,
+		ExternalIPs:  service.Spec.ExternalIPs,
+		Selector:     service.Spec.Selector,
+		MatchingPods: len(pods),
+	}
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		ret.ExternalIPs = append(ret.ExternalIPs, ingress.IP+ingress.Hostname)
+	}
+	for _, port := range service.Spec.Ports {
+		ret.Ports = append(ret.Ports, ServicePort{
+			Name:       port.Name,
+			Protocol:   string(port.Protocol),
+			Port:       port.Port,
+			TargetPort: port.TargetPort.String(),
+			NodePort:   port.NodePort,
+		})
+	}
+	ready := 0
+	for _, endpointSlice := range endpointSlices {
+		var ports []string
+		for _, port := range endpointSlice.Ports {
+			ports = append(ports, fmt.Sprintf("%s:%d/%s", ptr.Deref(port.Name, ""), ptr.Deref(port.Port, 0), ptr.Deref(port.Protocol, v1.ProtocolTCP)))
+		}
+		for _, endpoint := range endpointSlice.Endpoints {
+			e := ServiceEndpoint{
+				Addresses:   endpoint.Addresses,
+				Ready:       ptr.Deref(endpoint.Conditions.Ready, true),
+				Terminating: ptr.Deref(endpoint.Conditions.Terminating, false),
+				Node:        ptr.Deref(endpoint.NodeName, ""),
+				Ports:       ports,
+			}
+			if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+				e.Pod = endpoint.TargetRef.Name
+			}
+			if e.Ready {
+				ready++
+			}
+			ret.Endpoints = append(ret.Endpoints, e)
+		}
+	}
+	ret.Issues = serviceIssues(service, pods, ready)
+	return ret
+}
+
+// serviceIssues explains why the Service has no ready endpoints, or why some target ports can't be resolved
+func serviceIssues(service *v1.Service, pods []v1.Pod, ready int) []string {
+	var issues []string
+	if service.Spec.Type == v1.ServiceTypeExternalName {
+		return nil
+	}
+	selector := labels.SelectorFromSet(service.Spec.Selector).String()
+	readyPods := slices.IndexFunc(pods, podReady) >= 0
+	switch {
+	case len(service.Spec.Selector) == 0 && ready == 0:
+		issues = append(issues, "the service has no selector and no ready endpoints, its endpoints must be managed manually (EndpointSlices labeled "+
+			discoveryv1.LabelServiceName+"="+service.Name+")")
+	case len(service.Spec.Selector) > 0 && len(pods) == 0:
+		issues = append(issues, fmt.Sprintf("the selector %s matches no pods in namespace %s, check the labels of the pod template of the workload", selector, service.Namespace))
+	case len(service.Spec.Selector) > 0 && !readyPods:
+		issues = append(issues, fmt.Sprintf("%d pods match the selector %s but none is ready, check their readiness probes and status", len(pods), selector))
+	case len(service.Spec.Selector) > 0 && ready == 0:
+		issues = append(issues, "the selector matches ready pods but the service has no ready endpoints, check the target ports and the EndpointSlice controller")
+	}
+	for _, port := range service.Spec.Ports {
+		if port.TargetPort.Type != intstr.String || len(pods) == 0 {
+			continue
+		}
+		if !slices.ContainsFunc(pods, func(pod v1.Pod) bool { return podHasPort(&pod, port.TargetPort.StrVal) }) {
+			issues = append(issues, fmt.Sprintf("target port %s of port %d is not a named container port of the matching pods", port.TargetPort.StrVal, port.Port))
+		}
+	}
+	return issues
+}
+
+func podReady(pod v1.Pod) bool {
+	return slices.ContainsFunc(pod.Status.Conditions, func(condition v1.PodCondition) bool {
+		return condition.Type == v1.PodReady && condition.Status == v1.ConditionTrue
+	})
+}
+
+func podHasPort(pod *v1.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		if slices.ContainsFunc(container.Ports, func(port v1.ContainerPort) bool { return port.Name == name }) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package kubernetes
+
+import (
+	"slices"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestServiceDescription(t *testing.T) {
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec: v1.ServiceSpec{
+			Type:      v1.ServiceTypeClusterIP,
+			ClusterIP: "10.96.0.10",
+			Selector:  map[string]string{"app": "web"},
+			Ports:     []v1.ServicePort{{Name: "http", Protocol: v1.ProtocolTCP, Port: 80, TargetPort: intstr.FromString("http")}},
+		},
+	}
+	readyPod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "nginx", Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}}}}},
+		Status:     v1.PodStatus{Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}},
+	}
+	t.Run("returns the endpoints with their target pods", func(t *testing.T) {
+		description := serviceDescription(service, []discoveryv1.EndpointSlice{{
+			Ports: []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To(int32(8080)), Protocol: ptr.To(v1.ProtocolTCP)}},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}, TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-1"}},
+				{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}, TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-2"}},
+			},
+		}}, []v1.Pod{readyPod})
+		if len(description.Endpoints) != 2 || !description.Endpoints[0].Ready || description.Endpoints[1].Ready || description.Endpoints[0].Pod != "web-1" {
+			t.Errorf("unexpected endpoints %v", description.Endpoints)
+		}
+		if !slices.Equal(description.Endpoints[0].Ports, []string{"http:8080/TCP"}) {
+			t.Errorf("unexpected ports %v", description.Endpoints[0].Ports)
+		}
+		if description.Ports[0].TargetPort != "http" || description.MatchingPods != 1 || len(description.Issues) > 0 {
+			t.Errorf("unexpected description %v", description)
+		}
+	})
+	t.Run("reports a selector matching no pods", func(t *testing.T) {
+		description := serviceDescription(service, nil, nil)
+		if !slices.Equal(description.Issues, []string{"the selector app=web matches no pods in namespace default, check the labels of the pod template of the workload"}) {
+			t.Errorf("unexpected issues %v", description.Issues)
+		}
+	})
+	t.Run("reports pods not ready and missing named ports", func(t *testing.T) {
+		notReadyPod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}, Spec: v1.PodSpec{Containers: []v1.Container{{Name: "nginx"}}}}
+		description := serviceDescription(service, nil, []v1.Pod{notReadyPod})
+		expected := []string{
+			"1 pods match the selector app=web but none is ready, check their readiness probes and status",
+			"target port http of port 80 is not a named container port of the matching pods",
+		}
+		if !slices.Equal(description.Issues, expected) {
+			t.Errorf("unexpected issues %v", description.Issues)
+		}
+	})
+}
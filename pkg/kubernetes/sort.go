@@ -0,0 +1,125 @@
+package kubernetes
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	SortByName   = "name"
+	SortByAge    = "age"
+	SortByStatus = "status"
+)
+
+// SortByValues are the supported ResourceListOptions.SortBy values
+var SortByValues = []string{SortByName, SortByAge, SortByStatus}
+
+// listSortKey is the sort key of an item (or table row) of a list, namespace and name break the ties of the other keys
+type listSortKey struct {
+	namespace         string
+	name              string
+	creationTimestamp string
+	status            string
+}
+
+// SortList sorts in place the items of a list (or the rows of a table) returned by ResourcesList:
+//   - name: by namespace and name
+//   - age: newest first
+//   - status: by status.phase (or the Status column of a table)
+//
+// The sort applies to the returned page only, the pages are returned by the server in its own order.
+func SortList(obj runtime.Unstructured, sortBy string) error {
+	if err := validateSortBy(sortBy); err != nil || sortBy == "" || obj == nil {
+		return err
+	}
+	if list, ok := obj.(*unstructured.UnstructuredList); ok {
+		keys := make([]listSortKey, len(list.Items))
+		for i := range list.Items {
+			keys[i] = objectSortKey(list.Items[i].Object)
+			keys[i].status, _, _ = unstructured.NestedString(list.Items[i].Object, "status", "phase")
+		}
+		list.Items = sortByKeys(list.Items, keys, sortBy)
+		return nil
+	}
+	content := obj.UnstructuredContent()
+	rows, ok := content["rows"].([]interface{})
+	if !ok {
+		return nil
+	}
+	statusColumn := -1
+	columns, _ := content["columnDefinitions"].([]interface{})
+	for i, column := range columns {
+		if definition, ok := column.(map[string]interface{}); ok && strings.EqualFold(fmt.Sprint(definition["name"]), "status") {
+			statusColumn = i
+			break
+		}
+	}
+	keys := make([]listSortKey, len(rows))
+	for i, row := range rows {
+		r, _ := row.(map[string]interface{})
+		if object, ok := r["object"].(map[string]interface{}); ok {
+			keys[i] = objectSortKey(object)
+		}
+		if cells, ok := r["cells"].([]interface{}); ok && statusColumn >= 0 && statusColumn < len(cells) {
+			keys[i].status = fmt.Sprint(cells[statusColumn])
+		}
+	}
+	content["rows"] = sortByKeys(rows, keys, sortBy)
+	return nil
+}
+
+func validateSortBy(sortBy string) error {
+	if sortBy != "" && !slices.Contains(SortByValues, sortBy) {
+		return fmt.Errorf("invalid sortBy %s, supported values are %s", sortBy, strings.Join(SortByValues, ", "))
+	}
+	return nil
+}
+
+func objectSortKey(object map[string]interface{}) listSortKey {
+	metadata := &unstructured.Unstructured{Object: object}
+	key := listSortKey{namespace: metadata.GetNamespace(), name: metadata.GetName()}
+	if creationTimestamp := metadata.GetCreationTimestamp(); !creationTimestamp.IsZero() {
+		key.creationTimestamp = creationTimestamp.UTC().Format(time.RFC3339)
+	}
+	return key
+}
+
+// sortByKeys returns the values sorted by their keys (keys[i] is the key of values[i])
+func sortByKeys[T any](values []T, keys []listSortKey, sortBy string) []T {
+	indexes := make([]int, len(values))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return listSortLess(sortBy, keys[indexes[i]], keys[indexes[j]])
+	})
+	sorted := make([]T, len(values))
+	for i, index := range indexes {
+		sorted[i] = values[index]
+	}
+	return sorted
+}
+
+func listSortLess(sortBy string, a, b listSortKey) bool {
+	switch sortBy {
+	case SortByAge:
+		// RFC 3339 UTC timestamps sort lexicographically, newest first
+		if a.creationTimestamp != b.creationTimestamp {
+			return a.creationTimestamp > b.creationTimestamp
+		}
+	case SortByStatus:
+		if a.status != b.status {
+			return a.status < b.status
+		}
+	}
+	if a.namespace != b.namespace {
+		return a.namespace < b.namespace
+	}
+	return a.name < b.name
+}
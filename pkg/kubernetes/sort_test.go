@@ -0,0 +1,108 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func sortTestPod(namespace, name, creationTimestamp, phase string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"namespace": namespace, "name": name, "creationTimestamp": creationTimestamp},
+		"status":     map[string]interface{}{"phase": phase},
+	}
+}
+
+func sortTestList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	for _, pod := range []map[string]interface{}{
+		sortTestPod("ns-b", "pod-a", "2024-01-02T00:00:00Z", "Running"),
+		sortTestPod("ns-a", "pod-c", "2024-01-01T00:00:00Z", "Pending"),
+		sortTestPod("ns-a", "pod-b", "2024-01-03T00:00:00Z", "Running"),
+	} {
+		list.Items = append(list.Items, unstructured.Unstructured{Object: pod})
+	}
+	return list
+}
+
+func sortedNames(list *unstructured.UnstructuredList) string {
+	names := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		names = append(names, item.GetNamespace()+"/"+item.GetName())
+	}
+	return strings.Join(names, ",")
+}
+
+func TestSortList(t *testing.T) {
+	for sortBy, expected := range map[string]string{
+		"":           "ns-b/pod-a,ns-a/pod-c,ns-a/pod-b",
+		SortByName:   "ns-a/pod-b,ns-a/pod-c,ns-b/pod-a",
+		SortByAge:    "ns-a/pod-b,ns-b/pod-a,ns-a/pod-c",
+		SortByStatus: "ns-a/pod-c,ns-a/pod-b,ns-b/pod-a",
+	} {
+		t.Run("sorts list by "+sortBy, func(t *testing.T) {
+			list := sortTestList()
+			if err := SortList(list, sortBy); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if names := sortedNames(list); names != expected {
+				t.Errorf("expected %s, got %s", expected, names)
+			}
+		})
+	}
+	t.Run("sorts table rows by status column", func(t *testing.T) {
+		table := &metav1.Table{ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name"}, {Name: "Status"}}}
+		for _, row := range [][]string{{"pod-a", "Running"}, {"pod-b", "CrashLoopBackOff"}, {"pod-c", "Completed"}} {
+			table.Rows = append(table.Rows, metav1.TableRow{
+				Cells:  []interface{}{row[0], row[1]},
+				Object: runtime.RawExtension{Raw: []byte(`{"kind":"PartialObjectMetadata","apiVersion":"meta.k8s.io/v1","metadata":{"name":"` + row[0] + `"}}`)},
+			})
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(table)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		obj := &unstructured.Unstructured{Object: content}
+		if err = SortList(obj, SortByStatus); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sorted := &metav1.Table{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, sorted); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var names []string
+		for _, row := range sorted.Rows {
+			names = append(names, row.Cells[0].(string))
+		}
+		if strings.Join(names, ",") != "pod-c,pod-b,pod-a" {
+			t.Errorf("expected pod-c,pod-b,pod-a, got %v", names)
+		}
+	})
+	t.Run("sorts table rows by age", func(t *testing.T) {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind": "Table",
+			"rows": []interface{}{
+				map[string]interface{}{"cells": []interface{}{"old"}, "object": sortTestPod("", "old", "2024-01-01T00:00:00Z", "")},
+				map[string]interface{}{"cells": []interface{}{"new"}, "object": sortTestPod("", "new", "2024-02-01T00:00:00Z", "")},
+			},
+		}}
+		if err := SortList(obj, SortByAge); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rows := obj.Object["rows"].([]interface{})
+		if first := rows[0].(map[string]interface{})["cells"].([]interface{})[0]; first != "new" {
+			t.Errorf("expected newest row first, got %v", first)
+		}
+	})
+	t.Run("rejects unsupported sortBy", func(t *testing.T) {
+		err := SortList(sortTestList(), "size")
+		if err == nil || !strings.Contains(err.Error(), "invalid sortBy size") {
+			t.Errorf("expected invalid sortBy error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,197 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// defaultStorageClassAnnotation marks the StorageClass used by the PersistentVolumeClaims without a storage class
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+	// betaDefaultStorageClassAnnotation is the deprecated beta version of defaultStorageClassAnnotation
+	betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+var (
+	persistentVolumeClaimGVK = &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "PersistentVolumeClaim"}
+	persistentVolumeGVK      = &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "PersistentVolume"}
+	storageClassGVK          = &schema.GroupVersionKind{Group: "storage.k8s.io", Version: "v1", Kind: "StorageClass"}
+	eventGVK                 = &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Event"}
+)
+
+type PersistentVolumeClaim struct {
+	Namespace    string   `json:"namespace"`
+	Name         string   `json:"name"`
+	Phase        string   `json:"phase"`
+	Volume       string   `json:"volume,omitempty"`
+	Requested    string   `json:"requested,omitempty"`
+	Capacity     string   `json:"capacity,omitempty"`
+	AccessModes  []string `json:"accessModes,omitempty"`
+	StorageClass string   `json:"storageClass,omitempty"`
+	// Events are the provisioning events of the Pending claims explaining why they are not bound
+	Events []string `json:"events,omitempty"`
+}
+
+type PersistentVolume struct {
+	Name          string   `json:"name"`
+	Phase         string   `json:"phase"`
+	Capacity      string   `json:"capacity,omitempty"`
+	AccessModes   []string `json:"accessModes,omitempty"`
+	ReclaimPolicy string   `json:"reclaimPolicy,omitempty"`
+	StorageClass  string   `json:"storageClass,omitempty"`
+	// Claim is the namespace/name of the PersistentVolumeClaim bound to the volume
+	Claim  string `json:"claim,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type StorageClass struct {
+	Name                 string `json:"name"`
+	Provisioner          string `json:"provisioner"`
+	Default              bool   `json:"default"`
+	ReclaimPolicy        string `json:"reclaimPolicy,omitempty"`
+	VolumeBindingMode    string `json:"volumeBindingMode,omitempty"`
+	AllowVolumeExpansion bool   `json:"allowVolumeExpansion"`
+}
+
+// PersistentVolumeClaimsList lists the PersistentVolumeClaims with their bound volume and capacity, in all namespaces if
+// no namespace is provided. The Pending claims include the events explaining why they are not provisioned nor bound.
+func (k *Kubernetes) PersistentVolumeClaimsList(ctx context.Context, namespace string) ([]PersistentVolumeClaim, error) {
+	list, err := k.ResourcesList(ctx, persistentVolumeClaimGVK, namespace, ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]PersistentVolumeClaim, 0)
+	var pending []int
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		pvc := &v1.PersistentVolumeClaim{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, pvc); err != nil {
+			return nil, err
+		}
+		if pvc.Status.Phase == v1.ClaimPending {
+			pending = append(pending, len(ret))
+		}
+		ret = append(ret, persistentVolumeClaim(pvc))
+	}
+	if len(pending) == 0 {
+		return ret, nil
+	}
+	events, err := k.ResourcesList(ctx, eventGVK, namespace, ResourceListOptions{ListOptions: metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.kind", persistentVolumeClaimGVK.Kind).String(),
+	}})
+	if err != nil {
+		return nil, err
+	}
+	claimEvents := make(map[string][]string)
+	for _, item := range events.(*unstructured.UnstructuredList).Items {
+		event := &v1.Event{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, event); err != nil {
+			return nil, err
+		}
+		key := event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name
+		message := fmt.Sprintf("%s %s: %s", event.Type, event.Reason, strings.TrimSpace(event.Message))
+		if !slices.Contains(claimEvents[key], message) {
+			claimEvents[key] = append(claimEvents[key], message)
+		}
+	}
+	for _, i := range pending {
+		ret[i].Events = claimEvents[ret[i].Namespace+"/"+ret[i].Name]
+	}
+	return ret, nil
+}
+
+// PersistentVolumesList lists the PersistentVolumes with their reclaim policy and bound claim
+func (k *Kubernetes) PersistentVolumesList(ctx context.Context) ([]PersistentVolume, error) {
+	list, err := k.ResourcesList(ctx, persistentVolumeGVK, "", ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]PersistentVolume, 0)
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		pv := &v1.PersistentVolume{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, pv); err != nil {
+			return nil, err
+		}
+		volume := PersistentVolume{
+			Name:          pv.Name,
+			Phase:         string(pv.Status.Phase),
+			AccessModes:   accessModes(pv.Spec.AccessModes),
+			ReclaimPolicy: string(pv.Spec.PersistentVolumeReclaimPolicy),
+			StorageClass:  pv.Spec.StorageClassName,
+			Reason:        pv.Status.Reason,
+		}
+		if capacity, ok := pv.Spec.Capacity[v1.ResourceStorage]; ok {
+			volume.Capacity = capacity.String()
+		}
+		if pv.Spec.ClaimRef != nil {
+			volume.Claim = pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+		}
+		ret = append(ret, volume)
+	}
+	return ret, nil
+}
+
+// StorageClassesList lists the StorageClasses with their provisioner, whether they are the default, and whether they support volume expansion
+func (k *Kubernetes) StorageClassesList(ctx context.Context) ([]StorageClass, error) {
+	list, err := k.ResourcesList(ctx, storageClassGVK, "", ResourceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]StorageClass, 0)
+	for _, item := range list.(*unstructured.UnstructuredList).Items {
+		sc := &storagev1.StorageClass{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, sc); err != nil {
+			return nil, err
+		}
+		storageClass := StorageClass{
+			Name:                 sc.Name,
+			Provisioner:          sc.Provisioner,
+			Default:              sc.Annotations[defaultStorageClassAnnotation] == "true" || sc.Annotations[betaDefaultStorageClassAnnotation] == "true",
+			AllowVolumeExpansion: ptr.Deref(sc.AllowVolumeExpansion, false),
+		}
+		if sc.ReclaimPolicy != nil {
+			storageClass.ReclaimPolicy = string(*sc.ReclaimPolicy)
+		}
+		if sc.VolumeBindingMode != nil {
+			storageClass.VolumeBindingMode = string(*sc.VolumeBindingMode)
+		}
+		ret = append(ret, storageClass)
+	}
+	return ret, nil
+}
+
+func persistentVolumeClaim(pvc *v1.PersistentVolumeClaim) PersistentVolumeClaim {
+	ret := PersistentVolumeClaim{
+		Namespace:    pvc.Namespace,
+		Name:         pvc.Name,
+		Phase:        string(pvc.Status.Phase),
+		Volume:       pvc.Spec.VolumeName,
+		AccessModes:  accessModes(pvc.Spec.AccessModes),
+		StorageClass: ptr.Deref(pvc.Spec.StorageClassName, ""),
+	}
+	if requested, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+		ret.Requested = requested.String()
+	}
+	if capacity, ok := pvc.Status.Capacity[v1.ResourceStorage]; ok {
+		ret.Capacity = capacity.String()
+	}
+	return ret
+}
+
+func accessModes(modes []v1.PersistentVolumeAccessMode) []string {
+	ret := make([]string, 0, len(modes))
+	for _, mode := range modes {
+		ret = append(ret, string(mode))
+	}
+	return ret
+}
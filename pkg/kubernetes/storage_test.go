@@ -0,0 +1,44 @@
+package kubernetes
+
+import (
+	"slices"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPersistentVolumeClaim(t *testing.T) {
+	t.Run("returns the requested and bound capacity", func(t *testing.T) {
+		pvc := persistentVolumeClaim(&v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "data"},
+			Spec: v1.PersistentVolumeClaimSpec{
+				AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+				StorageClassName: ptr.To("standard"),
+				VolumeName:       "pv-1",
+				Resources:        v1.VolumeResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")}},
+			},
+			Status: v1.PersistentVolumeClaimStatus{
+				Phase:    v1.ClaimBound,
+				Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse("2Gi")},
+			},
+		})
+		if pvc.Phase != "Bound" || pvc.Volume != "pv-1" || pvc.Requested != "1Gi" || pvc.Capacity != "2Gi" || pvc.StorageClass != "standard" {
+			t.Errorf("unexpected claim %v", pvc)
+		}
+		if !slices.Equal(pvc.AccessModes, []string{"ReadWriteOnce"}) {
+			t.Errorf("unexpected access modes %v", pvc.AccessModes)
+		}
+	})
+	t.Run("omits the capacity of unbound claims", func(t *testing.T) {
+		pvc := persistentVolumeClaim(&v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pending"},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+		})
+		if pvc.Phase != "Pending" || pvc.Volume != "" || pvc.Capacity != "" || pvc.StorageClass != "" {
+			t.Errorf("unexpected claim %v", pvc)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// TaintEffects are the valid effects of a Node taint
+var TaintEffects = []string{string(v1.TaintEffectNoSchedule), string(v1.TaintEffectPreferNoSchedule), string(v1.TaintEffectNoExecute)}
+
+type NodesTaintOptions struct {
+	// Add are the taints to add, or to update if a taint with the same key and effect exists
+	Add []v1.Taint
+	// Remove are the taints to remove by key, and by effect if provided; taints not set in the Node are ignored
+	Remove []v1.Taint
+	// Overwrite allows updating the value of existing taints (same as kubectl taint --overwrite)
+	Overwrite bool
+}
+
+// NodeTaints is the result of updating the taints of a Node
+type NodeTaints struct {
+	Node    string     `json:"node"`
+	Added   []v1.Taint `json:"added,omitempty"`
+	Updated []v1.Taint `json:"updated,omitempty"`
+	Removed []v1.Taint `json:"removed,omitempty"`
+	// Taints are the taints of the Node after the update
+	Taints []v1.Taint `json:"taints,omitempty"`
+	// Affected are the running Pods of the Node that don't tolerate the added NoExecute taints and will be evicted
+	Affected []TaintAffectedPod `json:"affected,omitempty"`
+}
+
+// Changed returns true if any taint was added, updated, or removed
+func (t *NodeTaints) Changed() bool {
+	return len(t.Added) > 0 || len(t.Updated) > 0 || len(t.Removed) > 0
+}
+
+type TaintAffectedPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Taint     string `json:"taint"`
+	// Eviction is when the Pod is evicted, immediately or after the tolerationSeconds of a matching toleration
+	Eviction string `json:"eviction"`
+}
+
+// NodesTaint adds, updates, or removes the taints of the Node (same as kubectl taint nodes).
+// The running Pods that will be evicted by the added NoExecute taints are reported, use dry-run to preview them.
+func (k *Kubernetes) NodesTaint(ctx context.Context, name string, options NodesTaintOptions) (*NodeTaints, error) {
+	if err := validateTaints(options); err != nil {
+		return nil, err
+	}
+	nodes, err := k.manager.accessControlClientSet.Nodes()
+	if err != nil {
+		return nil, err
+	}
+	node, err := nodes.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := &NodeTaints{Node: name}
+	ret.Taints = updateTaints(node.Spec.Taints, options, ret)
+	if len(ret.Updated) > 0 && !options.Overwrite {
+		keys := make([]string, 0, len(ret.Updated))
+		for _, taint := range ret.Updated {
+			keys = append(keys, taint.Key+":"+string(taint.Effect))
+		}
+		return nil, fmt.Errorf("taints already set with a different value: %s, enable overwrite to update them", strings.Join(keys, ", "))
+	}
+	if !ret.Changed() {
+		return ret, nil
+	}
+	noExecute := slices.DeleteFunc(slices.Concat(ret.Added, ret.Updated), func(taint v1.Taint) bool { return taint.Effect != v1.TaintEffectNoExecute })
+	if len(noExecute) > 0 {
+		pods, err := k.manager.accessControlClientSet.Pods("")
+		if err != nil {
+			return nil, err
+		}
+		podList, err := pods.List(ctx, metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String()})
+		if err != nil {
+			return nil, err
+		}
+		ret.Affected = taintAffectedPods(podList.Items, noExecute)
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"resourceVersion": node.ResourceVersion},
+		"spec":     map[string]any{"taints": ret.Taints},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = nodes.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{
+		FieldManager: version.BinaryName,
+		DryRun:       dryRun(ctx),
+	}); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func validateTaints(options NodesTaintOptions) error {
+	if len(options.Add) == 0 && len(options.Remove) == 0 {
+		return fmt.Errorf("no taints to add or remove")
+	}
+	for _, taint := range slices.Concat(options.Add, options.Remove) {
+		if errs := validation.IsQualifiedName(taint.Key); len(errs) > 0 {
+			return fmt.Errorf("invalid taint key %q: %s", taint.Key, strings.Join(errs, "; "))
+		}
+		if taint.Effect != "" && !slices.Contains(TaintEffects, string(taint.Effect)) {
+			return fmt.Errorf("invalid taint effect %q for key %s, valid effects are %v", taint.Effect, taint.Key, TaintEffects)
+		}
+	}
+	for _, taint := range options.Add {
+		if taint.Effect == "" {
+			return fmt.Errorf("missing taint effect for key %s, valid effects are %v", taint.Key, TaintEffects)
+		}
+		if errs := validation.IsValidLabelValue(taint.Value); len(errs) > 0 {
+			return fmt.Errorf("invalid taint value %q for key %s: %s", taint.Value, taint.Key, strings.Join(errs, "; "))
+		}
+		if slices.ContainsFunc(options.Remove, func(remove v1.Taint) bool { return taintMatches(&remove, &taint) }) {
+			return fmt.Errorf("taint %s:%s can't be both added and removed", taint.Key, taint.Effect)
+		}
+	}
+	return nil
+}
+
+// updateTaints returns the taints resulting from applying the options to the current taints,
+// and records the taints that are added, updated, or removed in the result
+func updateTaints(current []v1.Taint, options NodesTaintOptions, result *NodeTaints) []v1.Taint {
+	var taints []v1.Taint
+	for _, taint := range current {
+		if slices.ContainsFunc(options.Remove, func(remove v1.Taint) bool { return taintMatches(&remove, &taint) }) {
+			result.Removed = append(result.Removed, taint)
+			continue
+		}
+		taints = append(taints, taint)
+	}
+	for _, taint := range options.Add {
+		i := slices.IndexFunc(taints, func(existing v1.Taint) bool { return existing.MatchTaint(&taint) })
+		switch {
+		case i < 0:
+			taints = append(taints, taint)
+			result.Added = append(result.Added, taint)
+		case taints[i].Value != taint.Value:
+			taints[i].Value = taint.Value
+			result.Updated = append(result.Updated, taint)
+		}
+	}
+	return taints
+}
+
+// taintMatches returns true if the taint has the key of the removal, and its effect if provided
+func taintMatches(remove, taint *v1.Taint) bool {
+	return remove.Key == taint.Key && (remove.Effect == "" || remove.Effect == taint.Effect)
+}
+
+// taintAffectedPods returns the running Pods that will be evicted by the NoExecute taints
+func taintAffectedPods(pods []v1.Pod, noExecute []v1.Taint) []TaintAffectedPod {
+	var affected []TaintAffectedPod
+	for _, pod := range pods {
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		for _, taint := range noExecute {
+			i := slices.IndexFunc(pod.Spec.Tolerations, func(toleration v1.Toleration) bool { return toleration.ToleratesTaint(&taint) })
+			eviction := "immediately"
+			if i >= 0 && pod.Spec.Tolerations[i].TolerationSeconds == nil {
+				continue
+			} else if i >= 0 {
+				eviction = fmt.Sprintf("after %ds", *pod.Spec.Tolerations[i].TolerationSeconds)
+			}
+			affected = append(affected, TaintAffectedPod{Namespace: pod.Namespace, Name: pod.Name, Taint: taint.ToString(), Eviction: eviction})
+		}
+	}
+	return affected
+}
@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateTaints(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		options  NodesTaintOptions
+		expected string
+	}{
+		{"no taints", NodesTaintOptions{}, "no taints to add or remove"},
+		{"invalid key", NodesTaintOptions{Add: []v1.Taint{{Key: "in valid", Effect: v1.TaintEffectNoSchedule}}}, `invalid taint key "in valid"`},
+		{"invalid effect", NodesTaintOptions{Remove: []v1.Taint{{Key: "dedicated", Effect: "Never"}}}, `invalid taint effect "Never" for key dedicated`},
+		{"missing effect", NodesTaintOptions{Add: []v1.Taint{{Key: "dedicated"}}}, "missing taint effect for key dedicated"},
+		{"added and removed", NodesTaintOptions{
+			Add:    []v1.Taint{{Key: "dedicated", Effect: v1.TaintEffectNoSchedule}},
+			Remove: []v1.Taint{{Key: "dedicated"}},
+		}, "taint dedicated:NoSchedule can't be both added and removed"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := validateTaints(tc.options); err == nil || !strings.HasPrefix(err.Error(), tc.expected) {
+				t.Errorf("expected error starting with %q, got %v", tc.expected, err)
+			}
+		})
+	}
+	t.Run("valid taints", func(t *testing.T) {
+		if err := validateTaints(NodesTaintOptions{Add: []v1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoExecute}}}); err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
+
+func TestUpdateTaints(t *testing.T) {
+	current := []v1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+		{Key: "maintenance", Effect: v1.TaintEffectNoSchedule},
+		{Key: "maintenance", Effect: v1.TaintEffectNoExecute},
+	}
+	t.Run("adds, updates, and removes the taints", func(t *testing.T) {
+		result := &NodeTaints{}
+		taints := updateTaints(current, NodesTaintOptions{
+			Add:    []v1.Taint{{Key: "dedicated", Value: "cpu", Effect: v1.TaintEffectNoSchedule}, {Key: "spot", Effect: v1.TaintEffectPreferNoSchedule}},
+			Remove: []v1.Taint{{Key: "maintenance"}},
+		}, result)
+		if len(taints) != 2 || taints[0].Value != "cpu" || taints[1].Key != "spot" {
+			t.Errorf("unexpected taints %v", taints)
+		}
+		if len(result.Added) != 1 || len(result.Updated) != 1 || len(result.Removed) != 2 {
+			t.Errorf("unexpected result %+v", result)
+		}
+		if current[0].Value != "gpu" {
+			t.Errorf("current taints should not be modified")
+		}
+	})
+	t.Run("makes no changes when the node has the taints", func(t *testing.T) {
+		result := &NodeTaints{}
+		updateTaints(current, NodesTaintOptions{
+			Add:    []v1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}},
+			Remove: []v1.Taint{{Key: "maintenance", Effect: v1.TaintEffectPreferNoSchedule}},
+		}, result)
+		if result.Changed() {
+			t.Errorf("expected no changes, got %+v", result)
+		}
+	})
+}
+
+func TestTaintAffectedPods(t *testing.T) {
+	taint := v1.Taint{Key: "maintenance", Effect: v1.TaintEffectNoExecute}
+	pod := func(name string, phase v1.PodPhase, tolerations ...v1.Toleration) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       v1.PodSpec{Tolerations: tolerations},
+			Status:     v1.PodStatus{Phase: phase},
+		}
+	}
+	affected := taintAffectedPods([]v1.Pod{
+		pod("intolerant", v1.PodRunning),
+		pod("tolerant", v1.PodRunning, v1.Toleration{Key: "maintenance", Operator: v1.TolerationOpExists}),
+		pod("temporarily-tolerant", v1.PodRunning, v1.Toleration{Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: ptr.To(int64(60))}),
+		pod("finished", v1.PodSucceeded),
+	}, []v1.Taint{taint})
+	if len(affected) != 2 {
+		t.Fatalf("expected 2 affected pods, got %v", affected)
+	}
+	if affected[0].Name != "intolerant" || affected[0].Eviction != "immediately" || affected[0].Taint != "maintenance:NoExecute" {
+		t.Errorf("unexpected affected pod %+v", affected[0])
+	}
+	if affected[1].Name != "temporarily-tolerant" || affected[1].Eviction != "after 60s" {
+		t.Errorf("unexpected affected pod %+v", affected[1])
+	}
+}
@@ -0,0 +1,479 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+const (
+	// TracesDefaultStart is the default start of the range of the traces searched
+	TracesDefaultStart = "-1h"
+	// TracesDefaultLimit is the default maximum number of traces returned
+	TracesDefaultLimit = 10
+	// tracesSpanThreshold is the share of the duration of the trace below which the spans are condensed, unless they failed
+	tracesSpanThreshold = 0.05
+)
+
+var (
+	// ErrTracingNotFound is returned when neither Tempo nor Jaeger is found in the cluster
+	ErrTracingNotFound = errors.New("no Tempo or Jaeger service found in the cluster, configure its URL with tempo_url or jaeger_url")
+	errTempoNotFound   = errors.New("no Tempo service found in the cluster")
+	errJaegerNotFound  = errors.New("no Jaeger service found in the cluster")
+)
+
+// tempoBackend finds the Tempo of the cluster, the query frontend of the distributed deployments and of the Tempo Operator
+var tempoBackend = &monitoringBackend{
+	name:       "Tempo",
+	url:        func(c *config.StaticConfig) string { return c.TempoURL },
+	selectors:  []string{"app.kubernetes.io/name=tempo", "app=tempo"},
+	components: []string{"query-frontend"},
+	portNames:  []string{"http", "http-metrics", "tempo-prom-metrics"},
+	notFound:   errTempoNotFound,
+}
+
+// jaegerBackend finds the query service of the Jaeger of the cluster, deployed by the Jaeger Operator or the Helm chart
+var jaegerBackend = &monitoringBackend{
+	name:       "Jaeger",
+	url:        func(c *config.StaticConfig) string { return c.JaegerURL },
+	selectors:  []string{"app.kubernetes.io/name=jaeger", "app=jaeger"},
+	components: []string{"query", "service-query", "all-in-one"},
+	portNames:  []string{"http-query", "query-http", "query"},
+	notFound:   errJaegerNotFound,
+}
+
+// TracesSearchOptions searches the traces of a service, its root span optionally matching the operation and the duration range
+type TracesSearchOptions struct {
+	Service   string
+	Operation string
+	// MinDuration and MaxDuration are the range of the duration of the traces (e.g. 500ms, 2s)
+	MinDuration, MaxDuration string
+	// Start and End are the range of the search (RFC3339, unix timestamp, or relative to now, e.g. -1h), they default to the last hour
+	Start, End string
+	Limit      int
+}
+
+type TracesSearchResult struct {
+	// Source is the Tempo or Jaeger that answered
+	Source string `json:"source"`
+	// Traces are the traces found, the slowest first
+	Traces []TraceSummary `json:"traces"`
+}
+
+// TraceSummary is a trace with its condensed span tree, the spans shorter than 5% of the trace are condensed unless they failed
+type TraceSummary struct {
+	TraceID       string    `json:"traceID"`
+	RootService   string    `json:"rootService"`
+	RootOperation string    `json:"rootOperation"`
+	Start         time.Time `json:"start"`
+	Duration      string    `json:"duration"`
+	Spans         int       `json:"spans"`
+	Errors        int       `json:"errors,omitempty"`
+	// Pods are the Pods (namespace/name) of the spans of the trace, according to their k8s.pod.name (or hostname) attribute
+	Pods []string   `json:"pods,omitempty"`
+	Tree *TraceSpan `json:"tree,omitempty"`
+	// duration is the duration the traces are ranked by
+	duration time.Duration
+}
+
+type TraceSpan struct {
+	Service   string `json:"service"`
+	Operation string `json:"operation"`
+	Duration  string `json:"duration"`
+	Pod       string `json:"pod,omitempty"`
+	Error     string `json:"error,omitempty"`
+	// Condensed is the number of spans below this one omitted because they are short and succeeded
+	Condensed int         `json:"condensed,omitempty"`
+	Children  []TraceSpan `json:"children,omitempty"`
+}
+
+// traceSpan is a span of Tempo or Jaeger
+type traceSpan struct {
+	id, parent         string
+	service, operation string
+	start              time.Time
+	duration           time.Duration
+	pod                string
+	failed             bool
+	errorMessage       string
+}
+
+// TracesSearch searches the traces with the Tempo of the cluster, or with its Jaeger if no Tempo is configured or found
+func (k *Kubernetes) TracesSearch(ctx context.Context, options TracesSearchOptions) (*TracesSearchResult, error) {
+	now := time.Now()
+	start, err := parsePromQLTime(cmp.Or(options.Start, TracesDefaultStart), now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start: %w", err)
+	}
+	end := now
+	if options.End != "" {
+		if end, err = parsePromQLTime(options.End, now); err != nil {
+			return nil, fmt.Errorf("invalid end: %w", err)
+		}
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("end %s must be after start %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+	for _, d := range []string{options.MinDuration, options.MaxDuration} {
+		if _, err = time.ParseDuration(cmp.Or(d, "0s")); err != nil {
+			return nil, fmt.Errorf("invalid duration %q, expected a duration (e.g. 500ms, 2s)", d)
+		}
+	}
+	options.Limit = cmp.Or(options.Limit, TracesDefaultLimit)
+	var ret *TracesSearchResult
+	if k.manager.staticConfig == nil || k.manager.staticConfig.JaegerURL == "" || k.manager.staticConfig.TempoURL != "" {
+		if ret, err = k.tempoTracesSearch(ctx, options, start, end); !errors.Is(err, errTempoNotFound) {
+			return ret, err
+		}
+	}
+	if ret, err = k.jaegerTracesSearch(ctx, options, start, end); errors.Is(err, errJaegerNotFound) {
+		return nil, ErrTracingNotFound
+	}
+	return ret, err
+}
+
+// tempoTracesSearch searches the trace IDs with the Tempo search API, then retrieves each trace
+func (k *Kubernetes) tempoTracesSearch(ctx context.Context, options TracesSearchOptions, start, end time.Time) (*TracesSearchResult, error) {
+	var tags []string
+	if options.Service != "" {
+		tags = append(tags, "service.name="+strconv.Quote(options.Service))
+	}
+	if options.Operation != "" {
+		tags = append(tags, "name="+strconv.Quote(options.Operation))
+	}
+	params := map[string]string{
+		"start": strconv.FormatInt(start.Unix(), 10),
+		"end":   strconv.FormatInt(end.Unix(), 10),
+		"limit": strconv.Itoa(options.Limit),
+	}
+	if len(tags) > 0 {
+		params["tags"] = strings.Join(tags, " ")
+	}
+	if options.MinDuration != "" {
+		params["minDuration"] = options.MinDuration
+	}
+	if options.MaxDuration != "" {
+		params["maxDuration"] = options.MaxDuration
+	}
+	body, source, err := k.monitoringRequest(ctx, tempoBackend, http.MethodGet, "/api/search", params, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	search := struct {
+		Traces []struct {
+			TraceID string `json:"traceID"`
+		} `json:"traces"`
+	}{}
+	if err = json.Unmarshal(body, &search); err != nil {
+		return nil, fmt.Errorf("failed to parse the traces of %s: %w", source, err)
+	}
+	ret := &TracesSearchResult{Source: source, Traces: []TraceSummary{}}
+	for _, trace := range search.Traces {
+		body, _, err = k.monitoringRequest(ctx, tempoBackend, http.MethodGet, "/api/traces/"+url.PathEscape(trace.TraceID), nil,
+			map[string]string{"Accept": "application/json"}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the trace %s: %w", trace.TraceID, err)
+		}
+		spans, err := parseTempoTrace(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the trace %s of %s: %w", trace.TraceID, source, err)
+		}
+		ret.Traces = append(ret.Traces, condenseTrace(trace.TraceID, spans))
+	}
+	sortTraces(ret.Traces)
+	return ret, nil
+}
+
+// jaegerTracesSearch searches the traces with the Jaeger query API, which returns their spans
+func (k *Kubernetes) jaegerTracesSearch(ctx context.Context, options TracesSearchOptions, start, end time.Time) (*TracesSearchResult, error) {
+	if options.Service == "" {
+		// The service is checked after the discovery so that ErrTracingNotFound is reported first
+		if len(k.monitoringServices(ctx, jaegerBackend)) == 0 {
+			return nil, errJaegerNotFound
+		}
+		return nil, errors.New("the service is required to search the traces with Jaeger")
+	}
+	params := map[string]string{
+		"service": options.Service,
+		"start":   strconv.FormatInt(start.UnixMicro(), 10),
+		"end":     strconv.FormatInt(end.UnixMicro(), 10),
+		"limit":   strconv.Itoa(options.Limit),
+	}
+	if options.Operation != "" {
+		params["operation"] = options.Operation
+	}
+	if options.MinDuration != "" {
+		params["minDuration"] = options.MinDuration
+	}
+	if options.MaxDuration != "" {
+		params["maxDuration"] = options.MaxDuration
+	}
+	body, source, err := k.monitoringRequest(ctx, jaegerBackend, http.MethodGet, "/api/traces", params, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	traces, err := parseJaegerTraces(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the traces of %s: %w", source, err)
+	}
+	ret := &TracesSearchResult{Source: source, Traces: []TraceSummary{}}
+	for _, traceID := range slices.Sorted(maps.Keys(traces)) {
+		ret.Traces = append(ret.Traces, condenseTrace(traceID, traces[traceID]))
+	}
+	sortTraces(ret.Traces)
+	return ret, nil
+}
+
+// parseTempoTrace parses the spans of a trace of the Tempo API (OTLP JSON)
+func parseTempoTrace(body []byte) ([]traceSpan, error) {
+	type attribute struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue string `json:"stringValue"`
+		} `json:"value"`
+	}
+	type spans struct {
+		Spans []struct {
+			SpanID            string          `json:"spanId"`
+			ParentSpanID      string          `json:"parentSpanId"`
+			Name              string          `json:"name"`
+			StartTimeUnixNano string          `json:"startTimeUnixNano"`
+			EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+			Attributes        []attribute     `json:"attributes"`
+			Status            json.RawMessage `json:"status"`
+		} `json:"spans"`
+	}
+	type batch struct {
+		Resource struct {
+			Attributes []attribute `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans                  []spans `json:"scopeSpans"`
+		InstrumentationLibrarySpans []spans `json:"instrumentationLibrarySpans"`
+	}
+	trace := struct {
+		Batches       []batch `json:"batches"`
+		ResourceSpans []batch `json:"resourceSpans"`
+		Trace         struct {
+			ResourceSpans []batch `json:"resourceSpans"`
+		} `json:"trace"`
+	}{}
+	if err := json.Unmarshal(body, &trace); err != nil {
+		return nil, err
+	}
+	attributes := func(attributes []attribute) map[string]string {
+		ret := make(map[string]string, len(attributes))
+		for _, a := range attributes {
+			ret[a.Key] = a.Value.StringValue
+		}
+		return ret
+	}
+	var ret []traceSpan
+	for _, b := range slices.Concat(trace.Batches, trace.ResourceSpans, trace.Trace.ResourceSpans) {
+		resource := attributes(b.Resource.Attributes)
+		for _, s := range slices.Concat(b.ScopeSpans, b.InstrumentationLibrarySpans) {
+			for _, span := range s.Spans {
+				startNano, _ := strconv.ParseInt(span.StartTimeUnixNano, 10, 64)
+				endNano, _ := strconv.ParseInt(span.EndTimeUnixNano, 10, 64)
+				status := struct {
+					Code    any    `json:"code"`
+					Message string `json:"message"`
+				}{}
+				_ = json.Unmarshal(span.Status, &status)
+				failed := status.Code == "STATUS_CODE_ERROR" || status.Code == float64(2)
+				ret = append(ret, traceSpan{
+					id:           span.SpanID,
+					parent:       span.ParentSpanID,
+					service:      resource["service.name"],
+					operation:    span.Name,
+					start:        time.Unix(0, startNano).UTC(),
+					duration:     time.Duration(endNano - startNano),
+					pod:          tracePod(resource["k8s.namespace.name"], cmp.Or(resource["k8s.pod.name"], resource["host.name"])),
+					failed:       failed,
+					errorMessage: cmp.Or(status.Message, attributes(span.Attributes)["exception.message"]),
+				})
+			}
+		}
+	}
+	return ret, nil
+}
+
+// parseJaegerTraces parses the spans of the traces of the Jaeger query API by trace ID
+func parseJaegerTraces(body []byte) (map[string][]traceSpan, error) {
+	type tag struct {
+		Key   string `json:"key"`
+		Value any    `json:"value"`
+	}
+	response := struct {
+		Data []struct {
+			TraceID string `json:"traceID"`
+			Spans   []struct {
+				SpanID        string `json:"spanID"`
+				OperationName string `json:"operationName"`
+				References    []struct {
+					RefType string `json:"refType"`
+					SpanID  string `json:"spanID"`
+				} `json:"references"`
+				StartTime int64  `json:"startTime"`
+				Duration  int64  `json:"duration"`
+				Tags      []tag  `json:"tags"`
+				ProcessID string `json:"processID"`
+			} `json:"spans"`
+			Processes map[string]struct {
+				ServiceName string `json:"serviceName"`
+				Tags        []tag  `json:"tags"`
+			} `json:"processes"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+	tags := func(tags []tag) map[string]string {
+		ret := make(map[string]string, len(tags))
+		for _, t := range tags {
+			ret[t.Key] = fmt.Sprint(t.Value)
+		}
+		return ret
+	}
+	ret := make(map[string][]traceSpan, len(response.Data))
+	for _, trace := range response.Data {
+		for _, span := range trace.Spans {
+			process := trace.Processes[span.ProcessID]
+			processTags, spanTags := tags(process.Tags), tags(span.Tags)
+			s := traceSpan{
+				id:           span.SpanID,
+				service:      process.ServiceName,
+				operation:    span.OperationName,
+				start:        time.UnixMicro(span.StartTime).UTC(),
+				duration:     time.Duration(span.Duration) * time.Microsecond,
+				pod:          tracePod(processTags["k8s.namespace.name"], cmp.Or(processTags["k8s.pod.name"], processTags["hostname"])),
+				failed:       spanTags["error"] == "true" || spanTags["otel.status_code"] == "ERROR",
+				errorMessage: cmp.Or(spanTags["otel.status_description"], spanTags["exception.message"]),
+			}
+			for _, reference := range span.References {
+				if reference.RefType == "CHILD_OF" || s.parent == "" {
+					s.parent = reference.SpanID
+				}
+			}
+			ret[trace.TraceID] = append(ret[trace.TraceID], s)
+		}
+	}
+	return ret, nil
+}
+
+func tracePod(namespace, pod string) string {
+	if pod == "" || namespace == "" {
+		return pod
+	}
+	return namespace + "/" + pod
+}
+
+// condenseTrace summarizes the spans of the trace, its span tree keeps the spans longer than 5% of the trace, the failed spans,
+// and their ancestors, the other ones are counted in the Condensed of their closest kept ancestor
+func condenseTrace(traceID string, spans []traceSpan) TraceSummary {
+	ret := TraceSummary{TraceID: traceID, Spans: len(spans)}
+	if len(spans) == 0 {
+		return ret
+	}
+	byID := make(map[string]*traceSpan, len(spans))
+	children := make(map[string][]*traceSpan, len(spans))
+	for i := range spans {
+		byID[spans[i].id] = &spans[i]
+	}
+	var roots []*traceSpan
+	pods := make(map[string]bool)
+	for i := range spans {
+		span := &spans[i]
+		if _, ok := byID[span.parent]; ok && span.parent != span.id {
+			children[span.parent] = append(children[span.parent], span)
+		} else {
+			roots = append(roots, span)
+		}
+		if span.failed {
+			ret.Errors++
+		}
+		if span.pod != "" {
+			pods[span.pod] = true
+		}
+	}
+	ret.Pods = slices.Sorted(maps.Keys(pods))
+	for _, c := range children {
+		slices.SortFunc(c, func(a, b *traceSpan) int { return a.start.Compare(b.start) })
+	}
+	// The root is the earliest span without parent (the other ones are orphans whose parent wasn't reported)
+	slices.SortFunc(roots, func(a, b *traceSpan) int { return a.start.Compare(b.start) })
+	root := roots[0]
+	ret.RootService, ret.RootOperation, ret.Start = root.service, root.operation, root.start
+	traceEnd := root.start.Add(root.duration)
+	for _, span := range spans {
+		if end := span.start.Add(span.duration); end.After(traceEnd) {
+			traceEnd = end
+		}
+	}
+	ret.duration = traceEnd.Sub(root.start)
+	ret.Duration = formatSpanDuration(ret.duration)
+	threshold := time.Duration(float64(ret.duration) * tracesSpanThreshold)
+	keep := make(map[*traceSpan]bool, len(spans))
+	var mark func(span *traceSpan) bool
+	mark = func(span *traceSpan) bool {
+		kept := span.failed || span.duration >= threshold
+		for _, child := range children[span.id] {
+			if mark(child) {
+				kept = true
+			}
+		}
+		keep[span] = kept
+		return kept
+	}
+	mark(root)
+	var size func(span *traceSpan) int
+	size = func(span *traceSpan) int {
+		ret := 1
+		for _, child := range children[span.id] {
+			ret += size(child)
+		}
+		return ret
+	}
+	var build func(span *traceSpan) TraceSpan
+	build = func(span *traceSpan) TraceSpan {
+		node := TraceSpan{Service: span.service, Operation: span.operation, Duration: formatSpanDuration(span.duration), Pod: span.pod}
+		if span.failed {
+			node.Error = cmp.Or(span.errorMessage, "error")
+		}
+		for _, child := range children[span.id] {
+			if keep[child] {
+				node.Children = append(node.Children, build(child))
+			} else {
+				node.Condensed += size(child)
+			}
+		}
+		return node
+	}
+	tree := build(root)
+	ret.Tree = &tree
+	return ret
+}
+
+// formatSpanDuration rounds the duration to the millisecond, or to the microsecond below a millisecond
+func formatSpanDuration(d time.Duration) string {
+	if d >= time.Millisecond {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Microsecond).String()
+}
+
+func sortTraces(traces []TraceSummary) {
+	slices.SortStableFunc(traces, func(a, b TraceSummary) int {
+		return cmp.Or(cmp.Compare(b.duration, a.duration), strings.Compare(a.TraceID, b.TraceID))
+	})
+}
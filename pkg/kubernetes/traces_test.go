@@ -0,0 +1,123 @@
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+const tempoTraceResponse = `{"batches":[
+	{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"frontend"}},{"key":"k8s.namespace.name","value":{"stringValue":"shop"}},{"key":"k8s.pod.name","value":{"stringValue":"frontend-1"}}]},
+	 "scopeSpans":[{"spans":[
+		{"spanId":"AA==","name":"GET /cart","startTimeUnixNano":"1748772000000000000","endTimeUnixNano":"1748772002000000000"},
+		{"spanId":"AB==","parentSpanId":"AA==","name":"render","startTimeUnixNano":"1748772001900000000","endTimeUnixNano":"1748772001950000000"}]}]},
+	{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"cart"}},{"key":"k8s.namespace.name","value":{"stringValue":"shop"}},{"key":"k8s.pod.name","value":{"stringValue":"cart-1"}}]},
+	 "scopeSpans":[{"spans":[
+		{"spanId":"AC==","parentSpanId":"AA==","name":"GetCart","startTimeUnixNano":"1748772000100000000","endTimeUnixNano":"1748772001800000000"},
+		{"spanId":"AD==","parentSpanId":"AC==","name":"redis GET","startTimeUnixNano":"1748772000100000000","endTimeUnixNano":"1748772000101000000","status":{"code":"STATUS_CODE_ERROR","message":"connection reset"}},
+		{"spanId":"AE==","parentSpanId":"AC==","name":"SELECT","startTimeUnixNano":"1748772000200000000","endTimeUnixNano":"1748772001700000000"},
+		{"spanId":"AF==","parentSpanId":"AC==","name":"cache hit","startTimeUnixNano":"1748772001700000000","endTimeUnixNano":"1748772001701000000"}]}]}
+]}`
+
+func TestCondenseTempoTrace(t *testing.T) {
+	spans, err := parseTempoTrace([]byte(tempoTraceResponse))
+	if err != nil || len(spans) != 6 {
+		t.Fatalf("failed to parse: %d spans %v", len(spans), err)
+	}
+	trace := condenseTrace("t1", spans)
+	if trace.RootService != "frontend" || trace.RootOperation != "GET /cart" || trace.Duration != "2s" || trace.Spans != 6 || trace.Errors != 1 {
+		t.Errorf("unexpected trace %+v", trace)
+	}
+	if strings.Join(trace.Pods, ",") != "shop/cart-1,shop/frontend-1" {
+		t.Errorf("unexpected pods %v", trace.Pods)
+	}
+	t.Run("condenses the short spans", func(t *testing.T) {
+		if trace.Tree.Condensed != 1 || len(trace.Tree.Children) != 1 {
+			t.Fatalf("unexpected root %+v", trace.Tree)
+		}
+		getCart := trace.Tree.Children[0]
+		if getCart.Operation != "GetCart" || getCart.Pod != "shop/cart-1" || getCart.Duration != "1.7s" || getCart.Condensed != 1 || len(getCart.Children) != 2 {
+			t.Errorf("unexpected span %+v", getCart)
+		}
+	})
+	t.Run("keeps the failed spans", func(t *testing.T) {
+		redis := trace.Tree.Children[0].Children[0]
+		if redis.Operation != "redis GET" || redis.Error != "connection reset" || redis.Duration != "1ms" {
+			t.Errorf("unexpected span %+v", redis)
+		}
+	})
+}
+
+func TestParseJaegerTraces(t *testing.T) {
+	traces, err := parseJaegerTraces([]byte(`{"data":[{"traceID":"j1","spans":[
+		{"spanID":"a","operationName":"HTTP GET","startTime":1748772000000000,"duration":300000,"processID":"p1"},
+		{"spanID":"b","operationName":"query","references":[{"refType":"CHILD_OF","spanID":"a"}],"startTime":1748772000010000,"duration":250000,"processID":"p2","tags":[{"key":"error","type":"bool","value":true}]}],
+		"processes":{"p1":{"serviceName":"api","tags":[{"key":"hostname","value":"api-7d9f"}]},"p2":{"serviceName":"db","tags":[]}}}]}`))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	trace := condenseTrace("j1", traces["j1"])
+	if trace.RootService != "api" || trace.Duration != "300ms" || trace.Errors != 1 || strings.Join(trace.Pods, ",") != "api-7d9f" {
+		t.Errorf("unexpected trace %+v", trace)
+	}
+	if len(trace.Tree.Children) != 1 || trace.Tree.Children[0].Service != "db" || trace.Tree.Children[0].Error != "error" {
+		t.Errorf("unexpected tree %+v", trace.Tree)
+	}
+}
+
+func TestTracesSearch(t *testing.T) {
+	var tags, minDuration string
+	tempo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/search":
+			tags, minDuration = r.URL.Query().Get("tags"), r.URL.Query().Get("minDuration")
+			_, _ = w.Write([]byte(`{"traces":[{"traceID":"t1"}]}`))
+		case "/api/traces/t1":
+			_, _ = w.Write([]byte(tempoTraceResponse))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer tempo.Close()
+	var service string
+	jaeger := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		service = r.URL.Query().Get("service")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer jaeger.Close()
+	t.Run("tempo", func(t *testing.T) {
+		k := &Kubernetes{manager: &Manager{cfg: &rest.Config{}, staticConfig: &config.StaticConfig{TempoURL: tempo.URL, JaegerURL: jaeger.URL}}}
+		ret, err := k.TracesSearch(context.Background(), TracesSearchOptions{Service: "frontend", Operation: "GET /cart", MinDuration: "1s"})
+		if err != nil {
+			t.Fatalf("failed to search: %v", err)
+		}
+		if tags != `service.name="frontend" name="GET /cart"` || minDuration != "1s" || ret.Source != tempo.URL || len(ret.Traces) != 1 || ret.Traces[0].TraceID != "t1" {
+			t.Errorf("unexpected request %s %s or result %+v", tags, minDuration, ret)
+		}
+	})
+	t.Run("jaeger", func(t *testing.T) {
+		k := &Kubernetes{manager: &Manager{cfg: &rest.Config{}, staticConfig: &config.StaticConfig{JaegerURL: jaeger.URL}}}
+		ret, err := k.TracesSearch(context.Background(), TracesSearchOptions{Service: "api"})
+		if err != nil || service != "api" || ret.Source != jaeger.URL || len(ret.Traces) != 0 {
+			t.Errorf("unexpected request %s or result %+v %v", service, ret, err)
+		}
+	})
+	t.Run("jaeger without service", func(t *testing.T) {
+		k := &Kubernetes{manager: &Manager{cfg: &rest.Config{}, staticConfig: &config.StaticConfig{JaegerURL: jaeger.URL}}}
+		if _, err := k.TracesSearch(context.Background(), TracesSearchOptions{}); err == nil || !strings.Contains(err.Error(), "service is required") {
+			t.Errorf("expected a service required error, got %v", err)
+		}
+	})
+	t.Run("invalid duration", func(t *testing.T) {
+		k := &Kubernetes{manager: &Manager{cfg: &rest.Config{}, staticConfig: &config.StaticConfig{TempoURL: tempo.URL}}}
+		if _, err := k.TracesSearch(context.Background(), TracesSearchOptions{MinDuration: "slow"}); err == nil || !strings.Contains(err.Error(), `invalid duration "slow"`) {
+			t.Errorf("expected an invalid duration error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,316 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var verticalPodAutoscalerGVK = &schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+
+const (
+	// RecommendationSourceVerticalPodAutoscaler the recommendations are the ones of the VerticalPodAutoscalers
+	RecommendationSourceVerticalPodAutoscaler = "VerticalPodAutoscaler"
+	// RecommendationSourceMetricsHistory the recommendations are computed from the samples of the metrics history
+	RecommendationSourceMetricsHistory = "metrics-history"
+	// recommendationMargin is the safety margin added to the usage for the recommendations computed from the metrics history
+	recommendationMargin = 0.15
+	// recommendationMinSamples is the number of samples below which the recommendations computed from the metrics history are flagged as unreliable
+	recommendationMinSamples = 10
+)
+
+// RecommendationVerdict compares the current request of a container with the recommended bounds
+type RecommendationVerdict string
+
+const (
+	RecommendationVerdictOK RecommendationVerdict = "ok"
+	// RecommendationVerdictUnderRequested the request is below the lower bound
+	RecommendationVerdictUnderRequested RecommendationVerdict = "under-requested"
+	// RecommendationVerdictOverRequested the request is above the upper bound
+	RecommendationVerdictOverRequested RecommendationVerdict = "over-requested"
+	RecommendationVerdictNoRequest     RecommendationVerdict = "no-request"
+)
+
+// VerticalPodAutoscalerRecommendations are the recommended requests of the containers of the workloads
+type VerticalPodAutoscalerRecommendations struct {
+	// Source is VerticalPodAutoscaler, or metrics-history if the VerticalPodAutoscaler isn't installed
+	Source string `json:"source"`
+	// Window and Samples are the span and the number of samples of the metrics history the recommendations are computed from
+	Window          string                    `json:"window,omitempty"`
+	Samples         int                       `json:"samples,omitempty"`
+	Recommendations []ContainerRecommendation `json:"recommendations"`
+}
+
+// ContainerRecommendation is the recommendation of a container of a workload compared to its current requests
+type ContainerRecommendation struct {
+	Namespace string `json:"namespace"`
+	// VerticalPodAutoscaler and UpdateMode are the name and the update mode of the VerticalPodAutoscaler, empty if computed from the metrics history
+	VerticalPodAutoscaler string `json:"verticalPodAutoscaler,omitempty"`
+	UpdateMode            string `json:"updateMode,omitempty"`
+	// Kind and Workload are the target of the recommendation (e.g. Deployment, StatefulSet)
+	Kind      string                  `json:"kind"`
+	Workload  string                  `json:"workload"`
+	Container string                  `json:"container,omitempty"`
+	CPU       *ResourceRecommendation `json:"cpu,omitempty"`
+	Memory    *ResourceRecommendation `json:"memory,omitempty"`
+	// Issues explains why there is no (reliable) recommendation
+	Issues []string `json:"issues,omitempty"`
+}
+
+type ResourceRecommendation struct {
+	// Request is the current request of the container, empty if not set
+	Request    string                `json:"request,omitempty"`
+	LowerBound string                `json:"lowerBound,omitempty"`
+	Target     string                `json:"target"`
+	UpperBound string                `json:"upperBound,omitempty"`
+	Verdict    RecommendationVerdict `json:"verdict,omitempty"`
+}
+
+// VerticalPodAutoscalerRecommendationsList returns the target, lower, and upper bound recommendations of the VerticalPodAutoscalers compared
+// to the current requests of the containers, in all namespaces if no namespace is provided.
+// If the VerticalPodAutoscaler isn't installed, naive recommendations are computed from the metrics history instead.
+func (k *Kubernetes) VerticalPodAutoscalerRecommendationsList(ctx context.Context, namespace string) (*VerticalPodAutoscalerRecommendations, error) {
+	list, err := k.ResourcesList(ctx, verticalPodAutoscalerGVK, namespace, ResourceListOptions{})
+	if err != nil && !meta.IsNoMatchError(err) {
+		return nil, err
+	}
+	vpaInstalled := err == nil
+	if !vpaInstalled && k.manager.metricsHistory == nil {
+		return nil, fmt.Errorf("the VerticalPodAutoscaler is not installed and %w", ErrMetricsHistoryDisabled)
+	}
+	pods, err := k.workloadsPods(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !vpaInstalled {
+		return metricsHistoryRecommendations(k.manager.metricsHistory, namespace, pods), nil
+	}
+	ret := &VerticalPodAutoscalerRecommendations{Source: RecommendationSourceVerticalPodAutoscaler, Recommendations: []ContainerRecommendation{}}
+	for _, vpa := range list.(*unstructured.UnstructuredList).Items {
+		ret.Recommendations = append(ret.Recommendations, verticalPodAutoscalerRecommendations(&vpa, pods)...)
+	}
+	return ret, nil
+}
+
+// workloadsPods returns a Pod of each workload (namespace/kind/name) to read the current requests of its containers
+func (k *Kubernetes) workloadsPods(ctx context.Context, namespace string) (map[string]*v1.Pod, error) {
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := pods.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]*v1.Pod)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		kind, workload := podWorkload(pod)
+		// Pods are keyed by name too, to find the workload of the samples of the metrics history
+		ret[pod.Namespace+"/Pod/"+pod.Name] = pod
+		if key := pod.Namespace + "/" + kind + "/" + workload; ret[key] == nil || pod.Status.Phase == v1.PodRunning {
+			ret[key] = pod
+		}
+	}
+	return ret, nil
+}
+
+func verticalPodAutoscalerRecommendations(vpa *unstructured.Unstructured, pods map[string]*v1.Pod) []ContainerRecommendation {
+	kind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+	workload, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+	updateMode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+	base := ContainerRecommendation{
+		Namespace:             vpa.GetNamespace(),
+		VerticalPodAutoscaler: vpa.GetName(),
+		UpdateMode:            updateMode,
+		Kind:                  kind,
+		Workload:              workload,
+	}
+	if base.UpdateMode == "" {
+		base.UpdateMode = "Auto"
+	}
+	pod := pods[base.Namespace+"/"+kind+"/"+workload]
+	var issues []string
+	conditions, _, _ := unstructured.NestedSlice(vpa.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, _ := c.(map[string]interface{})
+		conditionType, _ := condition["type"].(string)
+		if status, _ := condition["status"].(string); status != "True" || !slices.Contains([]string{"ConfigUnsupported", "NoPodsMatched", "LowConfidence"}, conditionType) {
+			continue
+		}
+		message, _ := condition["message"].(string)
+		issues = append(issues, strings.TrimSuffix(conditionType+": "+message, ": "))
+	}
+	containers, _, _ := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+	if len(containers) == 0 {
+		base.Issues = append(issues, "the VerticalPodAutoscaler has no recommendation yet")
+		return []ContainerRecommendation{base}
+	}
+	var ret []ContainerRecommendation
+	for _, c := range containers {
+		container, _ := c.(map[string]interface{})
+		recommendation := base
+		recommendation.Container, _ = container["containerName"].(string)
+		recommendation.Issues = issues
+		requests := containerRequests(pod, recommendation.Container)
+		if pod == nil {
+			recommendation.Issues = append(slices.Clone(issues), "no Pod of the target found to compare the recommendation with the current requests")
+		}
+		for _, r := range []struct {
+			name           v1.ResourceName
+			recommendation **ResourceRecommendation
+		}{{v1.ResourceCPU, &recommendation.CPU}, {v1.ResourceMemory, &recommendation.Memory}} {
+			bound := func(field string) *resource.Quantity {
+				value, _ := container[field].(map[string]interface{})[string(r.name)].(string)
+				if quantity, err := resource.ParseQuantity(value); err == nil {
+					return &quantity
+				}
+				return nil
+			}
+			target := bound("target")
+			if target == nil {
+				continue
+			}
+			var request *resource.Quantity
+			if quantity, ok := requests[r.name]; ok {
+				request = &quantity
+			}
+			*r.recommendation = resourceRecommendation(r.name, request, bound("lowerBound"), target, bound("upperBound"), pod != nil)
+		}
+		ret = append(ret, recommendation)
+	}
+	return ret
+}
+
+// metricsHistoryRecommendations computes naive recommendations for the containers of the workloads from all the samples of the metrics history:
+// the lower bound is the median usage, the target the 90th percentile of the CPU usage and the peak memory usage,
+// the upper bound the peak usage, the target and the upper bound with a 15% margin.
+// Only the samples of the Pods currently existing are considered.
+func metricsHistoryRecommendations(history *metricsHistory, namespace string, pods map[string]*v1.Pod) *VerticalPodAutoscalerRecommendations {
+	samples := history.window(time.Time{})
+	ret := &VerticalPodAutoscalerRecommendations{Source: RecommendationSourceMetricsHistory, Samples: len(samples), Recommendations: []ContainerRecommendation{}}
+	if len(samples) > 0 {
+		ret.Window = samples[len(samples)-1].time.Sub(samples[0].time).Round(time.Second).String()
+	}
+	type usages struct {
+		recommendation ContainerRecommendation
+		pod            *v1.Pod
+		cpu, memory    []int64
+	}
+	byContainer := make(map[string]*usages)
+	var keys []string
+	for _, sample := range samples {
+		for key, usage := range sample.containers {
+			pod := pods[key.namespace+"/Pod/"+key.pod]
+			if pod == nil || (namespace != "" && key.namespace != namespace) {
+				continue
+			}
+			kind, workload := podWorkload(pod)
+			id := strings.Join([]string{key.namespace, kind, workload, key.container}, "/")
+			u := byContainer[id]
+			if u == nil {
+				u = &usages{
+					recommendation: ContainerRecommendation{Namespace: key.namespace, Kind: kind, Workload: workload, Container: key.container},
+					pod:            pods[key.namespace+"/"+kind+"/"+workload],
+				}
+				byContainer[id] = u
+				keys = append(keys, id)
+			}
+			u.cpu = append(u.cpu, usage.cpu)
+			u.memory = append(u.memory, usage.memory)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		u := byContainer[key]
+		requests := containerRequests(u.pod, u.recommendation.Container)
+		slices.Sort(u.cpu)
+		slices.Sort(u.memory)
+		for _, r := range []struct {
+			name           v1.ResourceName
+			values         []int64
+			targetQuantile float64
+			quantity       func(int64) resource.Quantity
+			recommendation **ResourceRecommendation
+		}{
+			{v1.ResourceCPU, u.cpu, 0.9, func(v int64) resource.Quantity { return *resource.NewMilliQuantity(v, resource.DecimalSI) }, &u.recommendation.CPU},
+			{v1.ResourceMemory, u.memory, 1, func(v int64) resource.Quantity { return *resource.NewQuantity(v, resource.BinarySI) }, &u.recommendation.Memory},
+		} {
+			lower := r.quantity(quantile(r.values, 0.5))
+			target := r.quantity(withMargin(quantile(r.values, r.targetQuantile)))
+			upper := r.quantity(withMargin(quantile(r.values, 1)))
+			var request *resource.Quantity
+			if quantity, ok := requests[r.name]; ok {
+				request = &quantity
+			}
+			*r.recommendation = resourceRecommendation(r.name, request, &lower, &target, &upper, true)
+		}
+		if len(u.cpu) < recommendationMinSamples {
+			u.recommendation.Issues = append(u.recommendation.Issues,
+				fmt.Sprintf("only %d samples of the container in the metrics history, the recommendation is not reliable yet", len(u.cpu)))
+		}
+		ret.Recommendations = append(ret.Recommendations, u.recommendation)
+	}
+	return ret
+}
+
+func containerRequests(pod *v1.Pod, container string) v1.ResourceList {
+	if pod == nil {
+		return nil
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container {
+			return c.Resources.Requests
+		}
+	}
+	return nil
+}
+
+// resourceRecommendation formats the recommendation and compares the current request with its bounds if compare is set
+func resourceRecommendation(name v1.ResourceName, request, lower, target, upper *resource.Quantity, compare bool) *ResourceRecommendation {
+	format := func(quantity *resource.Quantity) string {
+		switch {
+		case quantity == nil:
+			return ""
+		case name == v1.ResourceMemory:
+			return formatMemoryMi(quantity.Value())
+		default:
+			return resource.NewMilliQuantity(quantity.MilliValue(), resource.DecimalSI).String()
+		}
+	}
+	ret := &ResourceRecommendation{Request: format(request), LowerBound: format(lower), Target: format(target), UpperBound: format(upper)}
+	switch {
+	case !compare:
+	case request == nil:
+		ret.Verdict = RecommendationVerdictNoRequest
+	case lower != nil && request.Cmp(*lower) < 0:
+		ret.Verdict = RecommendationVerdictUnderRequested
+	case upper != nil && request.Cmp(*upper) > 0:
+		ret.Verdict = RecommendationVerdictOverRequested
+	default:
+		ret.Verdict = RecommendationVerdictOK
+	}
+	return ret
+}
+
+// quantile returns the nearest-rank quantile of the sorted values
+func quantile(sorted []int64, q float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[max(int(math.Ceil(q*float64(len(sorted))))-1, 0)]
+}
+
+func withMargin(value int64) int64 {
+	return int64(math.Ceil(float64(value) * (1 + recommendationMargin)))
+}
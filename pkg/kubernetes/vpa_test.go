@@ -0,0 +1,128 @@
+package kubernetes
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/metrics/pkg/apis/metrics"
+	"k8s.io/utils/ptr"
+)
+
+func vpaTestPod(name string, cpu, memory string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "ns-1",
+			Name:            name,
+			Labels:          map[string]string{"pod-template-hash": "5d4f"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-5d4f", Controller: ptr.To(true)}},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu), v1.ResourceMemory: resource.MustParse(memory)},
+		}}}},
+	}
+}
+
+func TestVerticalPodAutoscalerRecommendations(t *testing.T) {
+	pod := vpaTestPod("web-5d4f-a", "1", "128Mi")
+	pods := map[string]*v1.Pod{"ns-1/Deployment/web": pod, "ns-1/Pod/web-5d4f-a": pod}
+	vpa := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"namespace": "ns-1", "name": "web-vpa"},
+		"spec": map[string]interface{}{
+			"targetRef":    map[string]interface{}{"kind": "Deployment", "name": "web"},
+			"updatePolicy": map[string]interface{}{"updateMode": "Off"},
+		},
+		"status": map[string]interface{}{"recommendation": map[string]interface{}{"containerRecommendations": []interface{}{
+			map[string]interface{}{
+				"containerName": "app",
+				"lowerBound":    map[string]interface{}{"cpu": "100m", "memory": "262144k"},
+				"target":        map[string]interface{}{"cpu": "250m", "memory": "300Mi"},
+				"upperBound":    map[string]interface{}{"cpu": "500m", "memory": "1Gi"},
+			},
+		}}},
+	}}
+	t.Run("compares the recommendation with the current requests", func(t *testing.T) {
+		ret := verticalPodAutoscalerRecommendations(vpa, pods)
+		if len(ret) != 1 || ret[0].VerticalPodAutoscaler != "web-vpa" || ret[0].UpdateMode != "Off" || ret[0].Container != "app" || len(ret[0].Issues) != 0 {
+			t.Fatalf("unexpected recommendations %+v", ret)
+		}
+		if cpu := *ret[0].CPU; cpu != (ResourceRecommendation{Request: "1", LowerBound: "100m", Target: "250m", UpperBound: "500m", Verdict: RecommendationVerdictOverRequested}) {
+			t.Errorf("unexpected cpu recommendation %+v", cpu)
+		}
+		if memory := *ret[0].Memory; memory != (ResourceRecommendation{Request: "128Mi", LowerBound: "250Mi", Target: "300Mi", UpperBound: "1024Mi", Verdict: RecommendationVerdictUnderRequested}) {
+			t.Errorf("unexpected memory recommendation %+v", memory)
+		}
+	})
+	t.Run("reports the missing target Pods", func(t *testing.T) {
+		ret := verticalPodAutoscalerRecommendations(vpa, map[string]*v1.Pod{})
+		if len(ret) != 1 || ret[0].CPU.Request != "" || ret[0].CPU.Verdict != "" || len(ret[0].Issues) != 1 {
+			t.Errorf("unexpected recommendations %+v", ret)
+		}
+	})
+	t.Run("reports the missing recommendation", func(t *testing.T) {
+		pending := &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"namespace": "ns-1", "name": "pending"},
+			"spec":     map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Deployment", "name": "web"}},
+			"status": map[string]interface{}{"conditions": []interface{}{
+				map[string]interface{}{"type": "NoPodsMatched", "status": "True", "message": "No pods match this VPA object"},
+				map[string]interface{}{"type": "RecommendationProvided", "status": "False"},
+			}},
+		}}
+		ret := verticalPodAutoscalerRecommendations(pending, pods)
+		expected := []string{"NoPodsMatched: No pods match this VPA object", "the VerticalPodAutoscaler has no recommendation yet"}
+		if len(ret) != 1 || ret[0].UpdateMode != "Auto" || ret[0].CPU != nil || !slices.Equal(ret[0].Issues, expected) {
+			t.Errorf("unexpected recommendations %+v", ret)
+		}
+	})
+}
+
+func TestMetricsHistoryRecommendations(t *testing.T) {
+	pods := map[string]*v1.Pod{}
+	for _, pod := range []*v1.Pod{vpaTestPod("web-5d4f-a", "1", "64Mi"), vpaTestPod("web-5d4f-b", "1", "64Mi")} {
+		pods["ns-1/Pod/"+pod.Name] = pod
+		pods["ns-1/Deployment/web"] = pod
+	}
+	h := &metricsHistory{interval: time.Minute, samples: make([]metricsSample, 60)}
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		h.add(now.Add(time.Duration(i-10)*time.Minute), []metrics.PodMetrics{
+			podMetrics("ns-1", "web-5d4f-a", "100m", "100Mi"),
+			podMetrics("ns-1", "web-5d4f-b", "200m", "200Mi"),
+			podMetrics("ns-1", "deleted", "4", "4Gi"),
+			podMetrics("ns-2", "other", "1", "1Gi"),
+		})
+	}
+	ret := metricsHistoryRecommendations(h, "ns-1", pods)
+	if ret.Source != RecommendationSourceMetricsHistory || ret.Samples != 10 || ret.Window != "9m0s" {
+		t.Errorf("unexpected recommendations %+v", ret)
+	}
+	if len(ret.Recommendations) != 1 {
+		t.Fatalf("expected a recommendation for the container of the Deployment only, got %+v", ret.Recommendations)
+	}
+	recommendation := ret.Recommendations[0]
+	if recommendation.Kind != "Deployment" || recommendation.Workload != "web" || recommendation.Container != "app" || len(recommendation.Issues) != 0 {
+		t.Errorf("unexpected recommendation %+v", recommendation)
+	}
+	if cpu := *recommendation.CPU; cpu != (ResourceRecommendation{Request: "1", LowerBound: "100m", Target: "230m", UpperBound: "230m", Verdict: RecommendationVerdictOverRequested}) {
+		t.Errorf("unexpected cpu recommendation %+v", cpu)
+	}
+	if memory := *recommendation.Memory; memory != (ResourceRecommendation{Request: "64Mi", LowerBound: "100Mi", Target: "230Mi", UpperBound: "230Mi", Verdict: RecommendationVerdictUnderRequested}) {
+		t.Errorf("unexpected memory recommendation %+v", memory)
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	values := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for q, expected := range map[float64]int64{0: 1, 0.5: 5, 0.9: 9, 1: 10} {
+		if actual := quantile(values, q); actual != expected {
+			t.Errorf("expected quantile %v to be %d, got %d", q, expected, actual)
+		}
+	}
+	if quantile(nil, 0.5) != 0 {
+		t.Error("expected the quantile of no values to be 0")
+	}
+}
@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// WorkloadState is the rollup of the readiness and the rollout of a workload
+type WorkloadState string
+
+const (
+	WorkloadStateReady WorkloadState = "ready"
+	// WorkloadStateProgressing a rollout (or a spec update) is in progress
+	WorkloadStateProgressing WorkloadState = "progressing"
+	// WorkloadStateDegraded all the replicas are up-to-date but some aren't available
+	WorkloadStateDegraded WorkloadState = "degraded"
+	// WorkloadStateStalled the rollout exceeded its progress deadline or the replicas can't be created
+	WorkloadStateStalled WorkloadState = "stalled"
+	// WorkloadStateScaledDown no replica is desired
+	WorkloadStateScaledDown WorkloadState = "scaled-down"
+)
+
+// WorkloadStatus is the readiness of a Deployment, StatefulSet, or DaemonSet, like the kube_*_status_replicas metrics of kube-state-metrics
+type WorkloadStatus struct {
+	Namespace string
+	Kind      string
+	Name      string
+	// Desired is the number of replicas of the spec, the number of nodes the pods should run on for a DaemonSet
+	Desired   int64
+	Ready     int64
+	Available int64
+	UpToDate  int64
+	State     WorkloadState
+	// Message is the first issue or the rollout status of the workloads that aren't ready
+	Message string
+}
+
+// NamespaceWorkloadsStatus is the number of workloads of a namespace per state
+type NamespaceWorkloadsStatus struct {
+	Namespace string
+	Workloads int
+	States    map[WorkloadState]int
+}
+
+// workloadStatusFields are the paths of the desired, ready, available, and up-to-date replicas of each kind
+var workloadStatusFields = map[string][4][]string{
+	"Deployment":  {{"spec", "replicas"}, {"status", "readyReplicas"}, {"status", "availableReplicas"}, {"status", "updatedReplicas"}},
+	"StatefulSet": {{"spec", "replicas"}, {"status", "readyReplicas"}, {"status", "availableReplicas"}, {"status", "updatedReplicas"}},
+	"DaemonSet":   {{"status", "desiredNumberScheduled"}, {"status", "numberReady"}, {"status", "numberAvailable"}, {"status", "updatedNumberScheduled"}},
+}
+
+// WorkloadsStatus returns the readiness and the rollout state of the Deployments, StatefulSets, and DaemonSets,
+// sorted by namespace, kind, and name, in all namespaces if no namespace is provided
+func (k *Kubernetes) WorkloadsStatus(ctx context.Context, namespace string) ([]WorkloadStatus, error) {
+	var ret []WorkloadStatus
+	for _, kind := range RolloutKinds {
+		list, err := k.ResourcesList(ctx, &schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: kind}, namespace, ResourceListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.(*unstructured.UnstructuredList).Items {
+			status, err := workloadStatus(kind, &item)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, *status)
+		}
+	}
+	slices.SortFunc(ret, func(a, b WorkloadStatus) int {
+		return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Kind, b.Kind), strings.Compare(a.Name, b.Name))
+	})
+	return ret, nil
+}
+
+// WorkloadsStatusByNamespace counts the workloads per state and namespace
+func WorkloadsStatusByNamespace(workloads []WorkloadStatus) []NamespaceWorkloadsStatus {
+	var ret []NamespaceWorkloadsStatus
+	for _, workload := range workloads {
+		if len(ret) == 0 || ret[len(ret)-1].Namespace != workload.Namespace {
+			ret = append(ret, NamespaceWorkloadsStatus{Namespace: workload.Namespace, States: make(map[WorkloadState]int)})
+		}
+		namespace := &ret[len(ret)-1]
+		namespace.Workloads++
+		namespace.States[workload.State]++
+	}
+	return ret
+}
+
+func workloadStatus(kind string, obj *unstructured.Unstructured) (*WorkloadStatus, error) {
+	rollout := &RolloutStatus{Kind: kind, Name: obj.GetName(), Namespace: obj.GetNamespace()}
+	var err error
+	switch kind {
+	case "Deployment":
+		err = deploymentRolloutStatus(obj, rollout)
+	case "StatefulSet":
+		err = statefulSetRolloutStatus(obj, rollout)
+	case "DaemonSet":
+		err = daemonSetRolloutStatus(obj, rollout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ret := &WorkloadStatus{Namespace: obj.GetNamespace(), Kind: kind, Name: obj.GetName()}
+	fields := workloadStatusFields[kind]
+	for i, count := range []*int64{&ret.Desired, &ret.Ready, &ret.Available, &ret.UpToDate} {
+		value, found, _ := unstructured.NestedInt64(obj.Object, fields[i]...)
+		if !found && i == 0 && kind != "DaemonSet" {
+			// The replicas of the spec default to 1
+			value = 1
+		}
+		*count = value
+	}
+	stalled := slices.ContainsFunc(rollout.Conditions, func(c RolloutCondition) bool {
+		return (c.Type == string(appsv1.DeploymentProgressing) && c.Reason == deploymentTimedOutReason) ||
+			(c.Type == string(appsv1.DeploymentReplicaFailure) && c.Status == string(v1.ConditionTrue))
+	})
+	switch {
+	case ret.Desired == 0 && rollout.Done:
+		ret.State = WorkloadStateScaledDown
+	case rollout.Done:
+		ret.State = WorkloadStateReady
+	case stalled:
+		ret.State = WorkloadStateStalled
+	case ret.UpToDate >= ret.Desired && ret.Available < ret.Desired:
+		ret.State = WorkloadStateDegraded
+	default:
+		ret.State = WorkloadStateProgressing
+	}
+	if ret.State != WorkloadStateReady && ret.State != WorkloadStateScaledDown {
+		ret.Message = rollout.Message
+		if len(rollout.Issues) > 0 {
+			ret.Message = rollout.Issues[0]
+		}
+	}
+	return ret, nil
+}
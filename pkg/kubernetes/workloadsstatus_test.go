@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestWorkloadStatus(t *testing.T) {
+	deployment := func(mutate func(d *appsv1.Deployment)) *appsv1.Deployment {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "web", Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3,
+			},
+		}
+		mutate(d)
+		return d
+	}
+	for _, c := range []struct {
+		name            string
+		kind            string
+		obj             any
+		state           WorkloadState
+		desired, ready  int64
+		expectedMessage string
+	}{
+		{"ready deployment", "Deployment", deployment(func(*appsv1.Deployment) {}), WorkloadStateReady, 3, 3, ""},
+		{"scaled down deployment", "Deployment", deployment(func(d *appsv1.Deployment) {
+			d.Spec.Replicas = ptr.To(int32(0))
+			d.Status = appsv1.DeploymentStatus{ObservedGeneration: 2}
+		}), WorkloadStateScaledDown, 0, 0, ""},
+		{"rolling out deployment", "Deployment", deployment(func(d *appsv1.Deployment) { d.Status.UpdatedReplicas = 1 }),
+			WorkloadStateProgressing, 3, 3, `Waiting for deployment "web" rollout to finish: 1 out of 3 new replicas have been updated...`},
+		{"degraded deployment", "Deployment", deployment(func(d *appsv1.Deployment) { d.Status.ReadyReplicas, d.Status.AvailableReplicas = 1, 1 }),
+			WorkloadStateDegraded, 3, 1, `Waiting for deployment "web" rollout to finish: 1 of 3 updated replicas are available...`},
+		{"stalled deployment", "Deployment", deployment(func(d *appsv1.Deployment) {
+			d.Status.UpdatedReplicas = 1
+			d.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: deploymentTimedOutReason, Message: "timed out",
+			}}
+		}), WorkloadStateStalled, 3, 3, "the rollout exceeded its progress deadline: timed out"},
+		{"deployment without replicas", "Deployment", &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "new", Generation: 1}},
+			WorkloadStateProgressing, 1, 0, "Waiting for deployment spec update to be observed..."},
+		{"degraded daemon set", "DaemonSet", &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "agent", Generation: 1},
+			Status: appsv1.DaemonSetStatus{
+				ObservedGeneration: 1, DesiredNumberScheduled: 4, UpdatedNumberScheduled: 4, NumberReady: 3, NumberAvailable: 3,
+			},
+		}, WorkloadStateDegraded, 4, 3, `Waiting for daemon set "agent" rollout to finish: 3 of 4 updated pods are available...`},
+		{"ready stateful set", "StatefulSet", &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "db", Generation: 1},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(2))},
+			Status: appsv1.StatefulSetStatus{
+				ObservedGeneration: 1, Replicas: 2, ReadyReplicas: 2, AvailableReplicas: 2, UpdatedReplicas: 2, CurrentReplicas: 2,
+				CurrentRevision: "db-1", UpdateRevision: "db-1",
+			},
+		}, WorkloadStateReady, 2, 2, ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			ret, err := workloadStatus(c.kind, toUnstructured(t, c.obj))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ret.State != c.state || ret.Desired != c.desired || ret.Ready != c.ready || ret.Message != c.expectedMessage {
+				t.Errorf("unexpected status %+v", ret)
+			}
+		})
+	}
+}
+
+func TestWorkloadsStatusByNamespace(t *testing.T) {
+	ret := WorkloadsStatusByNamespace([]WorkloadStatus{
+		{Namespace: "ns-1", State: WorkloadStateReady},
+		{Namespace: "ns-1", State: WorkloadStateStalled},
+		{Namespace: "ns-1", State: WorkloadStateReady},
+		{Namespace: "ns-2", State: WorkloadStateDegraded},
+	})
+	if len(ret) != 2 || ret[0].Workloads != 3 || ret[0].States[WorkloadStateReady] != 2 || ret[0].States[WorkloadStateStalled] != 1 ||
+		ret[1].Workloads != 1 || ret[1].States[WorkloadStateDegraded] != 1 {
+		t.Errorf("unexpected summary %+v", ret)
+	}
+}
@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/yaml"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type AlertsSuite struct {
+	BaseMcpSuite
+	alertmanager *httptest.Server
+}
+
+func (s *AlertsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = []string{"metrics"}
+	s.alertmanager = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/alerts":
+			_, _ = w.Write([]byte(`[
+				{"labels":{"alertname":"KubePodCrashLooping","severity":"warning","namespace":"ns-1"},"annotations":{"runbook_url":"https://runbooks/crashloop"},
+				 "startsAt":"2025-06-01T10:00:00Z","fingerprint":"a1","receivers":[{"name":"default"}],"status":{"state":"active"}},
+				{"labels":{"alertname":"Watchdog","severity":"none"},"startsAt":"2025-06-01T09:00:00Z","fingerprint":"c3","status":{"state":"suppressed","silencedBy":["s1"]}}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/silences":
+			_, _ = w.Write([]byte(`[{"id":"s1","matchers":[{"name":"alertname","value":"Watchdog","isRegex":false}],"status":{"state":"active"}}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+			_, _ = w.Write([]byte(`{"silenceID":"s2"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v2/silence/s1":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	s.Cfg.AlertmanagerURL = s.alertmanager.URL
+}
+
+func (s *AlertsSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	s.alertmanager.Close()
+}
+
+func (s *AlertsSuite) TestAlertsList() {
+	s.InitMcpClient()
+	s.Run("alerts_list()", func() {
+		toolResult, err := s.CallTool("alerts_list", map[string]interface{}{})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		var ret internalk8s.AlertmanagerAlerts
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &ret))
+		s.Run("excludes the suppressed alerts", func() {
+			s.Equal(2, ret.Total)
+			s.Require().Len(ret.Alerts, 1)
+			s.Equal("KubePodCrashLooping", ret.Alerts[0].Name)
+			s.Equal("https://runbooks/crashloop", ret.Alerts[0].RunbookURL)
+		})
+	})
+	s.Run("alerts_list(suppressed=true)", func() {
+		toolResult, _ := s.CallTool("alerts_list", map[string]interface{}{"suppressed": true})
+		var ret internalk8s.AlertmanagerAlerts
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &ret))
+		s.Len(ret.Alerts, 2)
+	})
+}
+
+func (s *AlertsSuite) TestAlertsGet() {
+	s.InitMcpClient()
+	s.Run("alerts_get(fingerprint=a1)", func() {
+		toolResult, err := s.CallTool("alerts_get", map[string]interface{}{"fingerprint": "a1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		var ret []internalk8s.AlertmanagerAlert
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &ret))
+		s.Require().Len(ret, 1)
+		s.Equal("ns-1", ret[0].Labels["namespace"])
+		s.Equal([]string{"default"}, ret[0].Receivers)
+	})
+	s.Run("alerts_get() without fingerprint or name", func() {
+		toolResult, _ := s.CallTool("alerts_get", map[string]interface{}{})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get alerts, exactly one of fingerprint or name must be provided", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("alerts_get(name=NotFiring)", func() {
+		toolResult, _ := s.CallTool("alerts_get", map[string]interface{}{"name": "NotFiring"})
+		s.True(toolResult.IsError, "call tool should fail")
+	})
+}
+
+func (s *AlertsSuite) TestSilences() {
+	s.InitMcpClient()
+	s.Run("silences_list()", func() {
+		toolResult, err := s.CallTool("silences_list", map[string]interface{}{})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		var ret []internalk8s.AlertmanagerSilence
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &ret))
+		s.Require().Len(ret, 1)
+		s.Equal([]string{`alertname="Watchdog"`}, ret[0].Matchers)
+	})
+	s.Run("silences_create(matchers, comment)", func() {
+		toolResult, err := s.CallTool("silences_create", map[string]interface{}{
+			"matchers": map[string]interface{}{"alertname": "KubePodCrashLooping", "namespace": "ns-1"},
+			"comment":  "known issue",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		var ret internalk8s.AlertmanagerSilence
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &ret))
+		s.Equal("s2", ret.ID)
+		s.Equal("2h0m0s", ret.EndsAt.Sub(ret.StartsAt).String())
+	})
+	s.Run("silences_expire(id=s1)", func() {
+		toolResult, err := s.CallTool("silences_expire", map[string]interface{}{"id": "s1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal("Silence s1 expired successfully", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestAlerts(t *testing.T) {
+	suite.Run(t, new(AlertsSuite))
+}
@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/audit"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+// newAuditor creates the auditor for the configured sinks, nil if auditing is disabled
+func newAuditor(staticConfig *config.StaticConfig) (*audit.Auditor, error) {
+	return audit.New(audit.Options{
+		File:         staticConfig.AuditLogFile,
+		MaxSizeMB:    staticConfig.AuditLogMaxSize,
+		MaxBackups:   staticConfig.AuditLogMaxBackups,
+		WebhookURL:   staticConfig.AuditWebhookURL,
+		MutatingOnly: staticConfig.AuditMutatingOnly,
+	})
+}
+
+// auditMiddleware records every tool call, including the ones rejected by the inner middlewares
+func auditMiddleware(auditor *audit.Auditor) api.ToolMiddleware {
+	return func(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+		return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			readOnly := ptr.Deref(params.Tool.Tool.Annotations.ReadOnlyHint, false)
+			if !auditor.Enabled(readOnly) {
+				return next(params)
+			}
+			start := time.Now()
+			result, err := next(params)
+			event := &audit.Event{
+				Time:       start.UTC(),
+				Tool:       params.Tool.Tool.Name,
+				Arguments:  audit.Redact(params.GetArguments()),
+				Cluster:    auditCluster(params.GetArguments()),
+				ReadOnly:   readOnly,
+				Status:     audit.StatusSuccess,
+				DurationMs: time.Since(start).Milliseconds(),
+			}
+			if params.Kubernetes != nil {
+				event.User = auditor.Identity(params.GetBearerToken(), func() (string, error) {
+					return params.WhoAmI(params)
+				})
+			}
+			switch {
+			case err != nil:
+				event.Status, event.Error = audit.StatusError, err.Error()
+			case result != nil && result.Error != nil:
+				event.Status, event.Error, event.ErrorCode = audit.StatusError, result.Error.Error(), string(result.ErrorCode)
+			}
+			auditor.Record(event)
+			return result, err
+		}
+	}
+}
+
+// auditCluster returns the cluster targeted by the tool call, empty for the current cluster
+func auditCluster(arguments map[string]any) string {
+	for _, argument := range []string{"cluster", "managedCluster"} {
+		if cluster, ok := arguments[argument].(string); ok && cluster != "" {
+			return cluster
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/audit"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+func readAuditEvents(t *testing.T, path string) []audit.Event {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log %v", err)
+	}
+	var events []audit.Event
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		event := audit.Event{}
+		if err = json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("invalid audit log line %s: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestAudit(t *testing.T) {
+	auditLogFile := filepath.Join(t.TempDir(), "audit.log")
+	auditServer := func(c *mcpContext) {
+		c.withEnvTest()
+		c.staticConfig = &config.StaticConfig{AuditLogFile: auditLogFile}
+	}
+	testCaseWithContext(t, &mcpContext{before: auditServer}, func(c *mcpContext) {
+		_, _ = c.callTool("namespaces_list", map[string]interface{}{})
+		_, _ = c.callTool("resources_create_or_update", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: audited-secret\n  namespace: default\nstringData:\n  password: hunter2\n",
+		})
+		_, _ = c.callTool("pods_get", map[string]interface{}{"name": "not-found"})
+		events := readAuditEvents(t, auditLogFile)
+		t.Run("records every tool call", func(t *testing.T) {
+			if len(events) != 3 {
+				t.Fatalf("expected 3 audit events, got %d", len(events))
+			}
+		})
+		t.Run("records tool name, status and read-only hint", func(t *testing.T) {
+			if events[0].Tool != "namespaces_list" || events[0].Status != audit.StatusSuccess || !events[0].ReadOnly {
+				t.Fatalf("unexpected audit event %v", events[0])
+			}
+			if events[1].Tool != "resources_create_or_update" || events[1].ReadOnly {
+				t.Fatalf("unexpected audit event %v", events[1])
+			}
+		})
+		t.Run("records caller identity", func(t *testing.T) {
+			if events[0].User == "" {
+				t.Fatalf("expected caller identity, got empty")
+			}
+		})
+		t.Run("redacts Secret manifests", func(t *testing.T) {
+			if events[1].Arguments["resource"] != audit.Redacted {
+				t.Fatalf("expected redacted resource, got %v", events[1].Arguments["resource"])
+			}
+		})
+		t.Run("records errors", func(t *testing.T) {
+			if events[2].Status != audit.StatusError || !strings.Contains(events[2].Error, "not-found") {
+				t.Fatalf("unexpected audit event %v", events[2])
+			}
+		})
+	})
+}
+
+func TestAuditMutatingOnly(t *testing.T) {
+	auditLogFile := filepath.Join(t.TempDir(), "audit.log")
+	auditServer := func(c *mcpContext) {
+		c.withEnvTest()
+		c.staticConfig = &config.StaticConfig{AuditLogFile: auditLogFile, AuditMutatingOnly: true}
+	}
+	testCaseWithContext(t, &mcpContext{before: auditServer}, func(c *mcpContext) {
+		_, _ = c.callTool("namespaces_list", map[string]interface{}{})
+		_, _ = c.callTool("pods_delete", map[string]interface{}{"name": "not-found"})
+		events := readAuditEvents(t, auditLogFile)
+		t.Run("records only mutating tool calls", func(t *testing.T) {
+			if len(events) != 1 || events[0].Tool != "pods_delete" {
+				t.Fatalf("expected only pods_delete audit event, got %v", events)
+			}
+		})
+	})
+}
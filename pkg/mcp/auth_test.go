@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type AuthSuite struct {
+	BaseMcpSuite
+}
+
+func (s *AuthSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.RbacV1().Roles("ns-1").Create(s.T().Context(), &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployment-scaler"},
+		Rules:      []rbacv1.PolicyRule{{Verbs: []string{"update", "patch"}, APIGroups: []string{"apps"}, Resources: []string{"deployments/scale"}}},
+	}, metav1.CreateOptions{})
+	_, _ = kc.RbacV1().RoleBindings("ns-1").Create(s.T().Context(), &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "scalers"},
+		RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "deployment-scaler"},
+		Subjects:   []rbacv1.Subject{{Kind: "User", Name: "jane"}},
+	}, metav1.CreateOptions{})
+}
+
+func (s *AuthSuite) TestAuthCanI() {
+	s.InitMcpClient()
+	s.Run("auth_can_i(verb=list, resource=pods)", func() {
+		toolResult, err := s.CallTool("auth_can_i", map[string]interface{}{"verb": "list", "resource": "pods"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "allowed: true")
+	})
+	s.Run("auth_can_i with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("auth_can_i", map[string]interface{}{"verb": "list", "resource": "pods", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to check access, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("auth_can_i(user=jane) allowed by RoleBinding", func() {
+		toolResult, err := s.CallTool("auth_can_i", map[string]interface{}{
+			"verb": "patch", "resource": "deployments.apps/scale", "namespace": "ns-1", "user": "jane",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "allowed: true")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "user: jane")
+	})
+	s.Run("auth_can_i(user=jane) denied in other namespace", func() {
+		toolResult, err := s.CallTool("auth_can_i", map[string]interface{}{
+			"verb": "patch", "resource": "deployments.apps/scale", "namespace": "ns-2", "user": "jane",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "allowed: false")
+	})
+	s.Run("auth_can_i(missing resource)", func() {
+		toolResult, _ := s.CallTool("auth_can_i", map[string]interface{}{"verb": "list"})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check access, either resource or nonResourceURL must be provided", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *AuthSuite) TestAuthWhoCan() {
+	s.InitMcpClient()
+	s.Run("auth_who_can(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("auth_who_can", map[string]interface{}{
+			"verb": "patch", "resource": "deployments.apps/scale", "namespace": "ns-1",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: jane")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "RoleBinding/scalers -> Role/deployment-scaler")
+	})
+	s.Run("auth_who_can(namespace=ns-2) excludes RoleBindings of other namespaces", func() {
+		toolResult, err := s.CallTool("auth_who_can", map[string]interface{}{
+			"verb": "patch", "resource": "deployments.apps/scale", "namespace": "ns-2",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.NotContains(toolResult.Content[0].(mcp.TextContent).Text, "name: jane")
+	})
+}
+
+func TestAuth(t *testing.T) {
+	suite.Run(t, new(AuthSuite))
+}
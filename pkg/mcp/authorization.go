@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// preflightAuthorizationMiddleware rejects the tool calls the current identity lacks the permissions for.
+// The pre-flight checks don't apply to the calls routed to a managed cluster through the ACM proxy.
+func preflightAuthorizationMiddleware(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		if cluster, ok := params.GetArguments()["cluster"].(string); ok && cluster != "" {
+			return next(params)
+		}
+		if err := preflightAuthorization(params, params.Kubernetes, *params.Tool, params.GetArguments()); err != nil {
+			return api.NewToolCallResult("", err), nil
+		}
+		return next(params)
+	}
+}
+
+// preflightAuthorization checks with SelfSubjectAccessReviews that the current identity is allowed to perform the
+// required accesses declared by the tool, so that a precise error is returned instead of a raw 403 from the API server.
+// Checks that can't be performed are skipped, the API server remains the source of truth.
+func preflightAuthorization(ctx context.Context, k *internalk8s.Kubernetes, tool api.ServerTool, arguments map[string]any) error {
+	namespace, _ := arguments["namespace"].(string)
+	for _, access := range tool.Access {
+		if access.Optional {
+			continue
+		}
+		attributes := resourceAttributes(access, namespace, k.NamespaceOrDefault(""))
+		allowed, err := k.CanI(ctx, attributes)
+		// Lists across all namespaces fall back to the current namespace when the cluster-wide list is denied
+		if err == nil && !allowed && access.AllNamespaces && attributes.Namespace == "" {
+			attributes = resourceAttributes(access, k.NamespaceOrDefault(""), "")
+			allowed, err = k.CanI(ctx, attributes)
+		}
+		if err != nil {
+			klog.V(3).Infof("pre-flight authorization check for tool %s skipped: %v", tool.Tool.Name, err)
+			continue
+		}
+		if !allowed {
+			return api.NewToolError(api.ErrorCodeForbidden, false, fmt.Errorf("you lack permission to %s", describeAccess(attributes)))
+		}
+	}
+	return nil
+}
+
+// usable returns false if the current identity can never perform any of the required accesses declared by the tool.
+// Namespaced accesses are considered denied if they are denied both cluster-wide and in the current namespace.
+func usable(ctx context.Context, k *internalk8s.Kubernetes, tool api.ServerTool) bool {
+	for _, access := range tool.Access {
+		if access.Optional {
+			continue
+		}
+		namespaces := []string{""}
+		if !access.ClusterScoped {
+			namespaces = append(namespaces, k.NamespaceOrDefault(""))
+		}
+		allowed := false
+		for _, namespace := range namespaces {
+			attributes := resourceAttributes(access, namespace, namespace)
+			if ok, err := k.CanI(ctx, attributes); err != nil || ok {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceAttributes resolves the namespace of the access from the namespace argument (if any)
+func resourceAttributes(access api.ResourceAccess, namespace, defaultNamespace string) *authv1.ResourceAttributes {
+	switch {
+	case access.ClusterScoped:
+		namespace = ""
+	case namespace == "" && !access.AllNamespaces:
+		namespace = defaultNamespace
+	}
+	return &authv1.ResourceAttributes{
+		Namespace:   namespace,
+		Verb:        access.Verb,
+		Group:       access.Group,
+		Resource:    access.Resource,
+		Subresource: access.Subresource,
+	}
+}
+
+// describeAccess returns a human-readable description of the access (e.g. "list deployments.apps in namespace default")
+func describeAccess(attributes *authv1.ResourceAttributes) string {
+	resource := attributes.Resource
+	if attributes.Group != "" {
+		resource += "." + attributes.Group
+	}
+	if attributes.Subresource != "" {
+		resource += "/" + attributes.Subresource
+	}
+	if attributes.Namespace == "" {
+		return fmt.Sprintf("%s %s at the cluster scope", attributes.Verb, resource)
+	}
+	return fmt.Sprintf("%s %s in namespace %s", attributes.Verb, resource, attributes.Namespace)
+}
@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+func TestPreflightAuthorization(t *testing.T) {
+	preflightServer := func(c *mcpContext) { c.staticConfig = &config.StaticConfig{PreflightAuthorization: true} }
+	testCaseWithContext(t, &mcpContext{before: preflightServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		defer restoreAuth(c.ctx)
+		client := c.newKubernetesClient()
+		// Authorize user only to get and list pods in the default/configured namespace
+		r, _ := client.RbacV1().Roles("default").Create(c.ctx, &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "allow-pods-read"},
+			Rules: []rbacv1.PolicyRule{{
+				Verbs:     []string{"get", "list"},
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+			}},
+		}, metav1.CreateOptions{})
+		_, _ = client.RbacV1().RoleBindings("default").Create(c.ctx, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "allow-pods-read"},
+			Subjects:   []rbacv1.Subject{{Kind: "User", Name: envTestUser.Name}},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: r.Name},
+		}, metav1.CreateOptions{})
+		// Deny cluster by removing cluster rule
+		_ = client.RbacV1().ClusterRoles().Delete(c.ctx, "allow-all", metav1.DeleteOptions{})
+		t.Run("pods_list falls back to the current namespace", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_list", map[string]interface{}{})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult)
+			}
+		})
+		t.Run("pods_list_in_namespace in a forbidden namespace returns precise error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_list_in_namespace", map[string]interface{}{"namespace": "ns-1"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "you lack permission to list pods in namespace ns-1" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_delete without delete permission returns precise error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_delete", map[string]interface{}{"name": "a-pod-in-default"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "you lack permission to delete pods in namespace default" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log without log permission returns precise error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{"name": "a-pod-in-default"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "you lack permission to get pods/log in namespace default" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
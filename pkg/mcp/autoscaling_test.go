@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+type AutoscalingSuite struct {
+	BaseMcpSuite
+}
+
+func (s *AutoscalingSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.AutoscalingV2().HorizontalPodAutoscalers("ns-1").Create(s.T().Context(), &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "an-hpa"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "a-deployment"},
+			MinReplicas:    ptr.To(int32(2)),
+			MaxReplicas:    10,
+			Metrics: []autoscalingv2.MetricSpec{{Type: autoscalingv2.ResourceMetricSourceType, Resource: &autoscalingv2.ResourceMetricSource{
+				Name:   "cpu",
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: ptr.To(int32(80))},
+			}}},
+		},
+	}, metav1.CreateOptions{})
+}
+
+func (s *AutoscalingSuite) TestHpasList() {
+	s.InitMcpClient()
+	s.Run("hpas_list(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("hpas_list", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "target: Deployment/a-deployment")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "resource cpu: <unknown> / 80%")
+	})
+	s.Run("hpas_list with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("hpas_list", map[string]interface{}{"cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to list horizontal pod autoscalers, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("hpas_list(namespace=ns-2)", func() {
+		toolResult, err := s.CallTool("hpas_list", map[string]interface{}{"namespace": "ns-2"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("No horizontal pod autoscalers found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *AutoscalingSuite) TestHpasStatus() {
+	s.InitMcpClient()
+	s.Run("hpas_status reports the autoscaler without status", func() {
+		toolResult, err := s.CallTool("hpas_status", map[string]interface{}{"namespace": "ns-1", "name": "an-hpa"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "minReplicas: 2")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "the autoscaler has no status conditions")
+	})
+	s.Run("hpas_status(name=missing)", func() {
+		toolResult, _ := s.CallTool("hpas_status", map[string]interface{}{"namespace": "ns-1", "name": "missing"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get horizontal pod autoscaler status: horizontalpodautoscalers.autoscaling \"missing\" not found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *AutoscalingSuite) TestVpaRecommendations() {
+	s.InitMcpClient()
+	s.Run("vpa_recommendations without VerticalPodAutoscaler nor metrics history", func() {
+		toolResult, err := s.CallTool("vpa_recommendations", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get vertical pod autoscaler recommendations: the VerticalPodAutoscaler is not installed and the metrics history is disabled, "+
+			"set metrics_history_interval in the configuration to sample the Pods metrics", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("vpa_recommendations with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("vpa_recommendations", map[string]interface{}{"namespace": "ns-1", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to get vertical pod autoscaler recommendations, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestAutoscaling(t *testing.T) {
+	suite.Run(t, new(AutoscalingSuite))
+}
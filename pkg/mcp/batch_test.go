@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/yaml"
+)
+
+type BatchSuite struct {
+	BaseMcpSuite
+}
+
+func (s *BatchSuite) TestBatchGet() {
+	s.InitMcpClient()
+	s.Run("batch_get with missing requests returns error", func() {
+		toolResult, _ := s.CallTool("batch_get", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get resources, missing argument requests", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("batch_get with duplicate ids returns error", func() {
+		toolResult, _ := s.CallTool("batch_get", map[string]interface{}{"requests": []interface{}{
+			map[string]interface{}{"id": "ns", "apiVersion": "v1", "kind": "Namespace"},
+			map[string]interface{}{"id": "ns", "apiVersion": "v1", "kind": "Namespace", "name": "default"},
+		}})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get resources, invalid argument requests: duplicate request id ns", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("batch_get(get, list, invalid)", func() {
+		toolResult, err := s.CallTool("batch_get", map[string]interface{}{"requests": []interface{}{
+			map[string]interface{}{"id": "default", "apiVersion": "v1", "kind": "Namespace", "name": "default"},
+			map[string]interface{}{"id": "namespaces", "apiVersion": "v1", "kind": "Namespace"},
+			map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "namespace": "default", "name": "non-existent"},
+		}})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		var decoded map[string]map[string]interface{}
+		err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+		s.Run("has yaml content", func() {
+			s.Nilf(err, "unmarshal failed %v", err)
+		})
+		s.Run("returns results keyed by request id", func() {
+			s.Len(decoded, 3)
+			s.Contains(decoded, "default")
+			s.Contains(decoded, "namespaces")
+			s.Contains(decoded, "2", "expected the index as key of the request without id")
+		})
+		s.Run("gets the named resource", func() {
+			s.Equal("default", decoded["default"]["result"].(map[string]interface{})["metadata"].(map[string]interface{})["name"])
+		})
+		s.Run("lists the resources", func() {
+			s.GreaterOrEqual(len(decoded["namespaces"]["result"].([]interface{})), 1)
+		})
+		s.Run("reports the error of the failed request", func() {
+			s.Equal(`configmaps "non-existent" not found`, decoded["2"]["error"])
+			s.Equal("NotFound", decoded["2"]["errorCode"])
+		})
+	})
+}
+
+func TestBatch(t *testing.T) {
+	suite.Run(t, new(BatchSuite))
+}
@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// DefaultCapabilityDetectionInterval is the default interval between two probes of the target cluster capabilities
+const DefaultCapabilityDetectionInterval = 5 * time.Minute
+
+func capabilityDetectionInterval(staticConfig *config.StaticConfig) time.Duration {
+	if staticConfig.CapabilityDetectionInterval > 0 {
+		return staticConfig.CapabilityDetectionInterval
+	}
+	return DefaultCapabilityDetectionInterval
+}
+
+func (s *Server) setCapabilities(capabilities internalk8s.Capabilities) {
+	s.capabilitiesMu.Lock()
+	defer s.capabilitiesMu.Unlock()
+	klog.V(1).Infof("Detected cluster capabilities: [%s]", capabilities)
+	s.capabilities = capabilities
+}
+
+// isToolsetAvailable returns whether the prerequisites of the toolset are available in the target cluster.
+// Toolsets are always available if capability detection is disabled.
+func (s *Server) isToolsetAvailable(toolset api.Toolset) bool {
+	withPrerequisites, ok := toolset.(api.ToolsetWithPrerequisites)
+	if !s.configuration.CapabilityDetection || !ok {
+		return true
+	}
+	s.capabilitiesMu.RLock()
+	defer s.capabilitiesMu.RUnlock()
+	for _, prerequisite := range withPrerequisites.GetPrerequisites() {
+		if !s.capabilities.Has(prerequisite) {
+			klog.V(1).Infof("toolset %s is hidden, the cluster lacks the %s capability", toolset.GetName(), prerequisite)
+			return false
+		}
+	}
+	return true
+}
+
+// watchCapabilities periodically probes the target cluster and reloads the tools if the capabilities change,
+// the clients are notified with a tools/list_changed notification.
+func (s *Server) watchCapabilities(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.refreshCapabilities(ctx) {
+				continue
+			}
+			if err := s.reloadTools(); err != nil {
+				klog.Errorf("failed to reload tools after cluster capabilities changed: %v", err)
+			}
+		}
+	}
+}
+
+// refreshCapabilities probes the target cluster and returns whether its capabilities changed
+func (s *Server) refreshCapabilities(ctx context.Context) bool {
+	capabilities := s.k.Capabilities(ctx)
+	s.capabilitiesMu.RLock()
+	changed := !slices.Equal(capabilities, s.capabilities)
+	s.capabilitiesMu.RUnlock()
+	if changed {
+		s.setCapabilities(capabilities)
+	}
+	return changed
+}
@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+func TestCapabilityDetection(t *testing.T) {
+	capabilityDetectionServer := func(c *mcpContext) {
+		c.staticConfig = &config.StaticConfig{
+			Toolsets:            []string{"core", "acm"},
+			CapabilityDetection: true,
+		}
+	}
+	testCaseWithContext(t, &mcpContext{before: capabilityDetectionServer}, func(c *mcpContext) {
+		hasTool := func(name string) bool {
+			tools, err := c.mcpClient.ListTools(c.ctx, mcp.ListToolsRequest{})
+			if err != nil {
+				t.Fatalf("call ListTools failed %v", err)
+			}
+			for _, tool := range tools.Tools {
+				if tool.Name == name {
+					return true
+				}
+			}
+			return false
+		}
+		t.Run("Toolsets without prerequisites are exposed", func(t *testing.T) {
+			if !hasTool("pods_list") {
+				t.Fatalf("Tool pods_list should be exposed")
+			}
+		})
+		t.Run("Toolsets with missing prerequisites are hidden", func(t *testing.T) {
+			if hasTool("addons_list") {
+				t.Fatalf("Tool addons_list should be hidden without ACM")
+			}
+		})
+		t.Run("Toolsets are exposed once their prerequisites are installed", func(t *testing.T) {
+			if err := c.crdApply(`{
+				"apiVersion": "apiextensions.k8s.io/v1",
+				"kind": "CustomResourceDefinition",
+				"metadata": {"name": "managedclusters.cluster.open-cluster-management.io"},
+				"spec": {
+					"group": "cluster.open-cluster-management.io",
+					"versions": [{"name": "v1", "served": true, "storage": true, "schema": {"openAPIV3Schema": {"type": "object", "x-kubernetes-preserve-unknown-fields": true}}}],
+					"scope": "Cluster",
+					"names": {"plural": "managedclusters", "singular": "managedcluster", "kind": "ManagedCluster"}
+				}
+			}`); err != nil {
+				t.Fatalf("failed to create ManagedCluster CRD %v", err)
+			}
+			defer func() { _ = c.crdDelete("managedclusters.cluster.open-cluster-management.io") }()
+			if !c.mcpServer.refreshCapabilities(c.ctx) {
+				t.Fatalf("capabilities should have changed")
+			}
+			if err := c.mcpServer.reloadTools(); err != nil {
+				t.Fatalf("failed to reload tools %v", err)
+			}
+			if !hasTool("addons_list") {
+				t.Fatalf("Tool addons_list should be exposed with ACM")
+			}
+		})
+	})
+}
@@ -0,0 +1,44 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type CapacitySuite struct {
+	BaseMcpSuite
+}
+
+func (s *CapacitySuite) TestCapacityReport() {
+	s.InitMcpClient()
+	s.Run("capacity_report(manifest) simulates the pod", func() {
+		toolResult, err := s.CallTool("capacity_report", map[string]interface{}{
+			"manifest": `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"a-capacity-pod"},"spec":{"containers":[{"name":"c","resources":{"requests":{"cpu":"500m"}}}]}}`,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "pod: Pod default/a-capacity-pod")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "cpu: 500m")
+	})
+	s.Run("capacity_report with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("capacity_report", map[string]interface{}{"kind": "Deployment", "namespace": "ns-1", "name": "missing", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to get capacity report, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("capacity_report(kind) without name", func() {
+		toolResult, _ := s.CallTool("capacity_report", map[string]interface{}{"kind": "Deployment"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get capacity report, kind and name must be provided together", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("capacity_report(kind, name=missing)", func() {
+		toolResult, _ := s.CallTool("capacity_report", map[string]interface{}{"kind": "Deployment", "namespace": "ns-1", "name": "missing"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get capacity report: deployments.apps \"missing\" not found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestCapacity(t *testing.T) {
+	suite.Run(t, new(CapacitySuite))
+}
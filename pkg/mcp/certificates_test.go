@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CertificatesSuite struct {
+	BaseMcpSuite
+}
+
+func (s *CertificatesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "expiring.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(5*24*time.Hour + time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	s.Require().NoError(err)
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.CoreV1().Secrets("ns-1").Create(s.T().Context(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "certs-expiring"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			corev1.TLSPrivateKeyKey: []byte("redacted"),
+		},
+	}, metav1.CreateOptions{})
+	_, _ = kc.NetworkingV1().Ingresses("ns-1").Create(s.T().Context(), &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "certs-expiring"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"expiring.example.com"}, SecretName: "certs-expiring"}},
+			DefaultBackend: &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+				Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80},
+			}},
+		},
+	}, metav1.CreateOptions{})
+}
+
+func (s *CertificatesSuite) TestCertsExpiry() {
+	s.InitMcpClient()
+	s.Run("certs_expiry(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("certs_expiry", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		text := toolResult.Content[0].(mcp.TextContent).Text
+		s.Contains(text, "name: certs-expiring")
+		s.Contains(text, "subject: CN=expiring.example.com")
+		s.Contains(text, "daysLeft: 5")
+		s.Contains(text, "- Ingress ns-1/certs-expiring")
+		s.NotContains(text, "redacted")
+	})
+	s.Run("certs_expiry(namespace=ns-1, days=1)", func() {
+		toolResult, err := s.CallTool("certs_expiry", map[string]interface{}{"namespace": "ns-1", "days": 1})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("No certificate expiring within 1 days found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("certs_expiry(cluster) outside ACM mode labels the certificates as the current cluster", func() {
+		toolResult, err := s.CallTool("certs_expiry", map[string]interface{}{"namespace": "ns-1", "cluster": "cluster-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.NotContains(toolResult.Content[0].(mcp.TextContent).Text, "cluster-1")
+	})
+	s.Run("certs_expiry(clusters) outside ACM mode", func() {
+		toolResult, _ := s.CallTool("certs_expiry", map[string]interface{}{"clusters": []string{"cluster-1"}})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to scan certificates expiry, clusters is only supported in ACM mode", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *CertificatesSuite) TestCertsExpiryWithPreflightAuthorization() {
+	s.Cfg.PreflightAuthorization = true
+	s.InitMcpClient()
+	defer restoreAuth(s.T().Context())
+	// Deny the optional APIs (cert-manager Certificates, Gateways, and OpenShift Routes)
+	_, _ = kubernetes.NewForConfigOrDie(envTest.Config).RbacV1().ClusterRoles().Update(s.T().Context(), &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-all"},
+		Rules: []rbacv1.PolicyRule{{
+			Verbs:     []string{"list"},
+			APIGroups: []string{"", "networking.k8s.io", "admissionregistration.k8s.io"},
+			Resources: []string{"secrets", "ingresses", "validatingwebhookconfigurations", "mutatingwebhookconfigurations"},
+		}},
+	}, metav1.UpdateOptions{})
+	s.Run("certs_expiry without access to the optional APIs", func() {
+		toolResult, err := s.CallTool("certs_expiry", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: certs-expiring")
+	})
+}
+
+func TestCertificates(t *testing.T) {
+	suite.Run(t, new(CertificatesSuite))
+}
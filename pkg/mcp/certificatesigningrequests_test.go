@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CertificateSigningRequestsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *CertificateSigningRequestsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	request, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "a-custom-client"}}, key)
+	s.Require().NoError(err)
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	for _, name := range []string{"a-csr-to-approve", "a-csr-to-deny"} {
+		_ = kc.CertificatesV1().CertificateSigningRequests().Delete(s.T().Context(), name, metav1.DeleteOptions{})
+		_, err = kc.CertificatesV1().CertificateSigningRequests().Create(s.T().Context(), &certificatesv1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: certificatesv1.CertificateSigningRequestSpec{
+				Request:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: request}),
+				SignerName: "example.com/custom",
+				Usages:     []certificatesv1.KeyUsage{certificatesv1.UsageClientAuth},
+			},
+		}, metav1.CreateOptions{})
+		s.Require().NoError(err)
+	}
+}
+
+func (s *CertificateSigningRequestsSuite) TestCertificateSigningRequestsList() {
+	s.InitMcpClient()
+	s.Run("certificatesigningrequests_list returns the pending requests", func() {
+		toolResult, err := s.CallTool("certificatesigningrequests_list", map[string]interface{}{})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: a-csr-to-approve")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "commonName: a-custom-client")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "signerName: example.com/custom")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "- client auth")
+	})
+	s.Run("certificatesigningrequests_list with cluster returns error instead of listing the requests of the current cluster", func() {
+		toolResult, _ := s.CallTool("certificatesigningrequests_list", map[string]interface{}{"cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to list certificate signing requests, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *CertificateSigningRequestsSuite) TestCertificateSigningRequestsApproveAndDeny() {
+	s.InitMcpClient()
+	s.Run("certificatesigningrequests_approve with cluster returns error instead of approving the request of the current cluster", func() {
+		toolResult, _ := s.CallTool("certificatesigningrequests_approve", map[string]interface{}{"name": "a-csr-to-approve", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to approve certificate signing request, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+		csr, err := kubernetes.NewForConfigOrDie(envTestRestConfig).CertificatesV1().CertificateSigningRequests().Get(s.T().Context(), "a-csr-to-approve", metav1.GetOptions{})
+		s.Require().NoError(err)
+		s.Empty(csr.Status.Conditions)
+	})
+	s.Run("certificatesigningrequests_deny with cluster returns error instead of denying the request of the current cluster", func() {
+		toolResult, _ := s.CallTool("certificatesigningrequests_deny", map[string]interface{}{"name": "a-csr-to-deny", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to deny certificate signing request, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("certificatesigningrequests_approve approves the request", func() {
+		toolResult, err := s.CallTool("certificatesigningrequests_approve", map[string]interface{}{"name": "a-csr-to-approve", "reason": "ClientVerified"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal("CertificateSigningRequest a-csr-to-approve approved successfully", toolResult.Content[0].(mcp.TextContent).Text)
+		csr, err := kubernetes.NewForConfigOrDie(envTestRestConfig).CertificatesV1().CertificateSigningRequests().Get(s.T().Context(), "a-csr-to-approve", metav1.GetOptions{})
+		s.Require().NoError(err)
+		s.Require().Len(csr.Status.Conditions, 1)
+		s.Equal(certificatesv1.CertificateApproved, csr.Status.Conditions[0].Type)
+		s.Equal("ClientVerified", csr.Status.Conditions[0].Reason)
+	})
+	s.Run("certificatesigningrequests_approve of an approved request", func() {
+		toolResult, err := s.CallTool("certificatesigningrequests_approve", map[string]interface{}{"name": "a-csr-to-approve"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("CertificateSigningRequest a-csr-to-approve is already approved", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("certificatesigningrequests_deny of an approved request fails", func() {
+		toolResult, _ := s.CallTool("certificatesigningrequests_deny", map[string]interface{}{"name": "a-csr-to-approve"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to deny certificate signing request: CertificateSigningRequest a-csr-to-approve is already approved", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("certificatesigningrequests_deny denies the request", func() {
+		toolResult, err := s.CallTool("certificatesigningrequests_deny", map[string]interface{}{"name": "a-csr-to-deny", "message": "unknown client"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal("CertificateSigningRequest a-csr-to-deny denied successfully", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("certificatesigningrequests_list(all=true) includes the approved and denied requests", func() {
+		toolResult, err := s.CallTool("certificatesigningrequests_list", map[string]interface{}{"all": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "status: Approved")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "status: Denied")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "message: unknown client")
+	})
+}
+
+func TestCertificateSigningRequests(t *testing.T) {
+	suite.Run(t, new(CertificateSigningRequestsSuite))
+}
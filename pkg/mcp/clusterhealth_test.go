@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type ClusterHealthSuite struct {
+	BaseMcpSuite
+}
+
+func (s *ClusterHealthSuite) TestClusterHealth() {
+	s.InitMcpClient()
+	s.Run("cluster_health()", func() {
+		toolResult, err := s.CallTool("cluster_health", map[string]interface{}{})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		var health internalk8s.ClusterHealth
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &health))
+		s.Run("scores the health", func() {
+			s.GreaterOrEqual(health.Score, 0)
+			s.LessOrEqual(health.Score, 100)
+			s.Contains([]string{internalk8s.ClusterHealthy, internalk8s.ClusterDegraded, internalk8s.ClusterCritical}, health.Status)
+			s.Contains(health.Summary, health.Status)
+		})
+		s.Run("reports the pods", func() {
+			s.Positive(health.Pods.Total)
+		})
+		s.Run("doesn't report the alerts without Prometheus", func() {
+			s.Nil(health.Alerts)
+		})
+	})
+	s.Run("cluster_health(cluster) outside ACM mode reports the health of the current cluster", func() {
+		toolResult, err := s.CallTool("cluster_health", map[string]interface{}{"cluster": "cluster-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.NotContains(toolResult.Content[0].(mcp.TextContent).Text, "cluster-1")
+	})
+	s.Run("cluster_health(clusters) outside ACM mode", func() {
+		toolResult, _ := s.CallTool("cluster_health", map[string]interface{}{"clusters": []string{"cluster-1"}})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check cluster health, clusters is only supported in ACM mode", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *ClusterHealthSuite) TestClusterHealthWithPreflightAuthorization() {
+	s.Cfg.PreflightAuthorization = true
+	s.InitMcpClient()
+	defer restoreAuth(s.T().Context())
+	// Deny the optional accesses (OpenShift ClusterOperators and the Prometheus service proxy)
+	_, _ = kubernetes.NewForConfigOrDie(envTest.Config).RbacV1().ClusterRoles().Update(s.T().Context(), &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-all"},
+		Rules: []rbacv1.PolicyRule{{
+			Verbs:     []string{"list"},
+			APIGroups: []string{""},
+			Resources: []string{"nodes", "pods", "events", "services"},
+		}},
+	}, metav1.UpdateOptions{})
+	s.Run("cluster_health without access to the optional APIs", func() {
+		toolResult, err := s.CallTool("cluster_health", map[string]interface{}{})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "score:")
+	})
+}
+
+func TestClusterHealth(t *testing.T) {
+	suite.Run(t, new(ClusterHealthSuite))
+}
@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type ConfigMapsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *ConfigMapsSuite) TestConfigMapsCreateOrUpdate() {
+	s.InitMcpClient()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	s.Run("configmaps_create_or_update(missing name)", func() {
+		toolResult, _ := s.CallTool("configmaps_create_or_update", map[string]interface{}{"literals": map[string]interface{}{"a": "b"}})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to create or update configmap, missing argument name", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("configmaps_create_or_update with cluster returns error without writing to the current cluster", func() {
+		toolResult, _ := s.CallTool("configmaps_create_or_update", map[string]interface{}{
+			"namespace": "default",
+			"name":      "a-configmap-for-another-cluster",
+			"literals":  map[string]interface{}{"a": "b"},
+			"cluster":   "managed-1",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to create or update configmap, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+		_, err := kc.CoreV1().ConfigMaps("default").Get(s.T().Context(), "a-configmap-for-another-cluster", metav1.GetOptions{})
+		s.Truef(errors.IsNotFound(err), "expected the ConfigMap not to be created, got %v", err)
+	})
+	s.Run("configmaps_create_or_update with literals and files", func() {
+		toolResult, err := s.CallTool("configmaps_create_or_update", map[string]interface{}{
+			"namespace": "default",
+			"name":      "a-configmap-from-files",
+			"literals":  map[string]interface{}{"LOG_LEVEL": "debug"},
+			"files": []interface{}{
+				map[string]interface{}{"key": "app.properties", "content": "port=8080"},
+				map[string]interface{}{"key": "blob.bin", "content": "/w==", "base64": true},
+			},
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "# The following resource (YAML) has been created or updated successfully")
+		configMap, err := kc.CoreV1().ConfigMaps("default").Get(s.T().Context(), "a-configmap-from-files", metav1.GetOptions{})
+		s.Require().NoError(err)
+		s.Equal("debug", configMap.Data["LOG_LEVEL"])
+		s.Equal("port=8080", configMap.Data["app.properties"])
+		s.Equal([]byte{0xff}, configMap.BinaryData["blob.bin"])
+	})
+	s.Run("configmaps_create_or_update with appendHash", func() {
+		toolResult, err := s.CallTool("configmaps_create_or_update", map[string]interface{}{
+			"namespace":  "default",
+			"name":       "a-hashed-configmap",
+			"literals":   map[string]interface{}{"key": "value"},
+			"immutable":  true,
+			"appendHash": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		name := regexp.MustCompile(`(?m)^  name: (a-hashed-configmap-\w+)$`).FindStringSubmatch(toolResult.Content[0].(mcp.TextContent).Text)
+		s.Require().Len(name, 2, "expected the name to have the hash suffix")
+		configMap, err := kc.CoreV1().ConfigMaps("default").Get(s.T().Context(), name[1], metav1.GetOptions{})
+		s.Require().NoError(err)
+		s.True(*configMap.Immutable)
+	})
+}
+
+func TestConfigMaps(t *testing.T) {
+	suite.Run(t, new(ConfigMapsSuite))
+}
@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/confirmation"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// confirmationMiddleware implements the two-step confirmation of destructive tools.
+// The first call returns a one-time token along with a summary of the impact of the operation,
+// the operation only executes when the tool is called again with the same arguments and the token
+// in the same session and with the same credentials.
+func confirmationMiddleware(store *confirmation.Store) api.ToolMiddleware {
+	return func(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+		return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			tool := params.Tool.Tool
+			// Dry-run calls don't persist any change, there is nothing to confirm
+			if !ptr.Deref(tool.Annotations.DestructiveHint, false) || internalk8s.IsDryRun(params) {
+				return next(params)
+			}
+			arguments := params.GetArguments()
+			caller := confirmationCaller(params)
+			if token, ok := arguments[confirmation.TokenArgument].(string); ok && token != "" {
+				if err := store.Consume(token, caller, tool.Name, arguments); err != nil {
+					return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+						fmt.Errorf("failed to confirm %s: %v, call the tool without %s to get a new token", tool.Name, err, confirmation.TokenArgument))), nil
+				}
+				return next(params)
+			}
+			token, err := store.Issue(caller, tool.Name, arguments)
+			if err != nil {
+				return api.NewToolCallResult("", fmt.Errorf("failed to issue confirmation token for %s: %v", tool.Name, err)), nil
+			}
+			return api.NewToolCallResult(impactSummary(tool, arguments, impactPreview(next, params), token, store), nil), nil
+		}
+	}
+}
+
+// confirmationCaller returns the key binding the confirmation tokens to the MCP session and the credentials of the call
+func confirmationCaller(params api.ToolHandlerParams) string {
+	sessionID := ""
+	if session := server.ClientSessionFromContext(params.Context); session != nil {
+		sessionID = session.SessionID()
+	}
+	authorization, _ := params.Context.Value(internalk8s.OAuthAuthorizationHeader).(string)
+	return sessionID + "\n" + authorization
+}
+
+// impactPreview performs the operation with server-side dry-run to report what it would actually change
+// (e.g. the pods a drain would evict, the resources the deletion of a namespace would delete).
+// Tools not supporting dry-run have no preview.
+func impactPreview(next api.ToolHandlerFunc, params api.ToolHandlerParams) string {
+	if !params.Tool.DryRun {
+		return ""
+	}
+	params.Context = internalk8s.WithDryRun(params.Context)
+	result, err := next(params)
+	switch {
+	case err != nil:
+		return fmt.Sprintf("The impact could not be previewed with a server-side dry run: %v\n", err)
+	case result == nil:
+		return ""
+	case result.Error != nil:
+		return fmt.Sprintf("The impact could not be previewed with a server-side dry run: %v\n", result.Error)
+	}
+	return "Preview of the impact (server-side dry run, no changes have been persisted):\n" + strings.TrimSuffix(result.Content, "\n") + "\n"
+}
+
+// impactSummary describes the destructive operation awaiting confirmation in a human-readable way
+func impactSummary(tool api.Tool, arguments map[string]any, preview string, token string, store *confirmation.Store) string {
+	title := tool.Annotations.Title
+	if title == "" {
+		title = tool.Name
+	}
+	summary := strings.Builder{}
+	summary.WriteString(fmt.Sprintf("Confirmation required: %s is a destructive operation and was NOT executed.\n", title))
+	summary.WriteString(fmt.Sprintf("Impact: %s\n", tool.Description))
+	if len(arguments) > 0 {
+		if args, err := output.MarshalYaml(arguments); err == nil {
+			summary.WriteString("Arguments:\n")
+			summary.WriteString(args)
+		}
+	}
+	summary.WriteString(preview)
+	summary.WriteString(fmt.Sprintf("Review the impact with the user. To proceed, call %s again with the same arguments and %s=%q.\n",
+		tool.Name, confirmation.TokenArgument, token))
+	summary.WriteString(fmt.Sprintf("The token can be used only once and expires in %s.", store.TTL()))
+	return summary.String()
+}
+
+// withConfirmationToken returns a copy of the input schema that accepts the confirmation token argument
+func withConfirmationToken(schema *jsonschema.Schema) *jsonschema.Schema {
+	ret := &jsonschema.Schema{Type: "object"}
+	if schema != nil {
+		copied := *schema
+		ret = &copied
+	}
+	ret.Properties = maps.Clone(ret.Properties)
+	if ret.Properties == nil {
+		ret.Properties = make(map[string]*jsonschema.Schema)
+	}
+	ret.Properties[confirmation.TokenArgument] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Confirmation token returned by a previous call of this tool with the same arguments. The operation is only executed when a valid token is provided",
+	}
+	return ret
+}
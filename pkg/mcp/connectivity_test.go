@@ -0,0 +1,49 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConnectivitySuite struct {
+	BaseMcpSuite
+}
+
+func (s *ConnectivitySuite) TestConnectivityCheck() {
+	s.InitMcpClient()
+	s.Run("connectivity_check(target=missing)", func() {
+		toolResult, err := s.CallTool("connectivity_check", map[string]interface{}{"port": 80})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check connectivity: exactly one of the target service, pod, or host is required", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("connectivity_check with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("connectivity_check", map[string]interface{}{"targetHost": "example.com", "port": 80, "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to check connectivity, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("connectivity_check(targetHost, port=missing)", func() {
+		toolResult, err := s.CallTool("connectivity_check", map[string]interface{}{"targetHost": "example.com"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check connectivity: port is required", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("connectivity_check(targetService=nonexistent)", func() {
+		toolResult, err := s.CallTool("connectivity_check", map[string]interface{}{"targetService": "nonexistent"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check connectivity: services \"nonexistent\" not found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("connectivity_check(protocol=udp)", func() {
+		toolResult, err := s.CallTool("connectivity_check", map[string]interface{}{"targetHost": "example.com", "port": 53, "protocol": "udp"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check connectivity: unsupported protocol \"udp\", expected tcp or http", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestConnectivity(t *testing.T) {
+	suite.Run(t, new(ConnectivitySuite))
+}
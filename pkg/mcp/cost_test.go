@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/yaml"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type CostSuite struct {
+	BaseMcpSuite
+}
+
+func (s *CostSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = []string{"cost"}
+}
+
+func (s *CostSuite) TestCostAllocationOpenCost() {
+	openCost := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/allocation" || r.URL.Query().Get("aggregate") != "namespace" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":200,"data":[{
+			"ns-1":{"name":"ns-1","properties":{"namespace":"ns-1"},"cpuCost":2,"ramCost":1,"totalCost":3,"totalEfficiency":0.5},
+			"ns-2":{"name":"ns-2","properties":{"namespace":"ns-2"},"cpuCost":6,"ramCost":2,"totalCost":8}}]}`))
+	}))
+	defer openCost.Close()
+	s.Cfg.OpenCostURL = openCost.URL
+	s.InitMcpClient()
+	s.Run("cost_allocation()", func() {
+		toolResult, err := s.CallTool("cost_allocation", map[string]interface{}{})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		var ret internalk8s.CostReport
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &ret))
+		s.Run("returns the namespaces, the most expensive first", func() {
+			s.False(ret.Estimated)
+			s.Equal(11.0, ret.Total)
+			s.Require().Len(ret.Allocations, 2)
+			s.Equal("ns-2", ret.Allocations[0].Namespace)
+			s.Equal("50%", ret.Allocations[1].Efficiency)
+		})
+	})
+	s.Run("cost_allocation(window=invalid)", func() {
+		toolResult, _ := s.CallTool("cost_allocation", map[string]interface{}{"window": "lastweek"})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "failed to get cost allocation: invalid window \"lastweek\"")
+	})
+}
+
+func (s *CostSuite) TestCostAllocationEstimate() {
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("cost_allocation", map[string]interface{}{"aggregate": "workload"})
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+	var ret internalk8s.CostReport
+	s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &ret))
+	s.Run("estimates the costs from the requests without OpenCost", func() {
+		s.True(ret.Estimated)
+		s.Equal(internalk8s.CostAggregateWorkload, ret.Aggregate)
+		s.Equal(internalk8s.CostDefaultWindow, ret.Window)
+	})
+}
+
+func TestCost(t *testing.T) {
+	suite.Run(t, new(CostSuite))
+}
@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type CrdsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *CrdsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = []string{"crd"}
+}
+
+func (s *CrdsSuite) TestCrdsRejectCluster() {
+	s.InitMcpClient()
+	cases := []struct {
+		tool   string
+		args   map[string]interface{}
+		action string
+	}{
+		{"crds_list", map[string]interface{}{}, "list CRDs"},
+		{"crds_schema", map[string]interface{}{"name": "routes.route.openshift.io"}, "get CRD schema"},
+		{"crds_instances_list", map[string]interface{}{"name": "routes.route.openshift.io"}, "list CRD instances"},
+		{"crds_issues", map[string]interface{}{}, "detect CRD issues"},
+	}
+	for _, c := range cases {
+		s.Run(c.tool+" with cluster returns error instead of using the current cluster", func() {
+			c.args["cluster"] = "managed-1"
+			toolResult, _ := s.CallTool(c.tool, c.args)
+			s.Truef(toolResult.IsError, "call tool should fail")
+			s.Equal(`failed to `+c.action+`, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+		})
+	}
+}
+
+func TestCrds(t *testing.T) {
+	suite.Run(t, new(CrdsSuite))
+}
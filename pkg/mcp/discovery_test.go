@@ -0,0 +1,174 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type DiscoverySuite struct {
+	BaseMcpSuite
+}
+
+func (s *DiscoverySuite) TestAPIResources() {
+	s.InitMcpClient()
+	s.Run("api_resources", func() {
+		toolResult, err := s.CallTool("api_resources", map[string]interface{}{})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns table with headers", func() {
+			s.Regexp("NAME\\s+SHORTNAMES\\s+APIVERSION\\s+NAMESPACED\\s+KIND\\s+VERBS", toolResult.Content[0].(mcp.TextContent).Text)
+		})
+		s.Run("returns core and apps resources", func() {
+			s.Regexp("(?m)^pods\\s+po\\s+v1\\s+true\\s+Pod\\s+", toolResult.Content[0].(mcp.TextContent).Text)
+			s.Regexp("(?m)^deployments\\s+deploy\\s+apps/v1\\s+true\\s+Deployment\\s+", toolResult.Content[0].(mcp.TextContent).Text)
+		})
+		s.Run("excludes subresources", func() {
+			s.NotContains(toolResult.Content[0].(mcp.TextContent).Text, "pods/log")
+		})
+	})
+	s.Run("api_resources with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("api_resources", map[string]interface{}{"cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to list api resources, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("api_resources(apiGroup=apps, namespaced=true)", func() {
+		toolResult, err := s.CallTool("api_resources", map[string]interface{}{"apiGroup": "apps", "namespaced": true})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns only apps resources", func() {
+			s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "deployments")
+			s.NotRegexp("(?m)^pods\\s+", toolResult.Content[0].(mcp.TextContent).Text)
+		})
+	})
+	s.Run("api_resources(apiGroup=core, namespaced=false)", func() {
+		toolResult, err := s.CallTool("api_resources", map[string]interface{}{"apiGroup": "core", "namespaced": false})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns only cluster scoped core resources", func() {
+			s.Regexp("(?m)^nodes\\s+", toolResult.Content[0].(mcp.TextContent).Text)
+			s.NotRegexp("(?m)^pods\\s+", toolResult.Content[0].(mcp.TextContent).Text)
+		})
+	})
+}
+
+func (s *DiscoverySuite) TestAPIResourcesDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Secret" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("api_resources (denied)", func() {
+		toolResult, err := s.CallTool("api_resources", map[string]interface{}{"apiGroup": "core"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("excludes denied resources", func() {
+			s.NotRegexp("(?m)^secrets\\s+", toolResult.Content[0].(mcp.TextContent).Text)
+			s.Regexp("(?m)^configmaps\\s+", toolResult.Content[0].(mcp.TextContent).Text)
+		})
+	})
+	s.Run("resources_explain (denied)", func() {
+		toolResult, err := s.CallTool("resources_explain", map[string]interface{}{"apiVersion": "v1", "kind": "Secret"})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Equal("failed to explain resource: resource not allowed: /v1, Kind=Secret", toolResult.Content[0].(mcp.TextContent).Text)
+		})
+	})
+}
+
+func (s *DiscoverySuite) TestResourcesExplain() {
+	s.InitMcpClient()
+	s.Run("resources_explain(missing kind)", func() {
+		toolResult, _ := s.CallTool("resources_explain", map[string]interface{}{"apiVersion": "v1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to explain resource, missing argument kind", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("resources_explain(apiVersion=apps/v1, kind=Deployment, field=spec.template.spec.containers)", func() {
+		toolResult, err := s.CallTool("resources_explain", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "field": "spec.template.spec.containers"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		var explanation kubernetes.Explanation
+		s.Run("has yaml content", func() {
+			s.NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &explanation))
+		})
+		s.Run("returns the field type", func() {
+			s.Equal("[]Container", explanation.Type)
+		})
+		s.Run("returns the container fields", func() {
+			var name *kubernetes.ExplanationField
+			for i := range explanation.Fields {
+				if explanation.Fields[i].Name == "name" {
+					name = &explanation.Fields[i]
+				}
+			}
+			s.Require().NotNil(name, "expected name field")
+			s.Equal("string", name.Type)
+			s.True(name.Required)
+		})
+	})
+	s.Run("resources_explain(field=spec.missing)", func() {
+		toolResult, _ := s.CallTool("resources_explain", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "field": "spec.missing"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to explain resource: field \"spec.missing\" does not exist in Deployment", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *DiscoverySuite) TestAPIObjectCounts() {
+	s.InitMcpClient()
+	s.Run("api_object_counts(top=0)", func() {
+		toolResult, err := s.CallTool("api_object_counts", map[string]interface{}{"top": 0})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		var counts kubernetes.ObjectCounts
+		s.Run("has yaml content", func() {
+			s.NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &counts))
+		})
+		s.Run("returns the counts of each resource type", func() {
+			s.Contains([]string{kubernetes.ObjectCountsSourceMetrics, kubernetes.ObjectCountsSourceList}, counts.Source)
+			var namespaces *kubernetes.ObjectCount
+			for i := range counts.Resources {
+				if counts.Resources[i].Resource == "namespaces" {
+					namespaces = &counts.Resources[i]
+				}
+			}
+			s.Require().NotNil(namespaces, "expected namespaces count")
+			s.GreaterOrEqual(namespaces.Count, int64(3))
+			s.Positive(counts.Total)
+		})
+	})
+	s.Run("api_object_counts with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("api_object_counts", map[string]interface{}{"cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to count api objects, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("api_object_counts(top=1)", func() {
+		toolResult, err := s.CallTool("api_object_counts", map[string]interface{}{"top": 1})
+		s.Nilf(err, "call tool failed %v", err)
+		var counts kubernetes.ObjectCounts
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &counts))
+		s.Len(counts.Resources, 1)
+	})
+}
+
+func TestDiscovery(t *testing.T) {
+	suite.Run(t, new(DiscoverySuite))
+}
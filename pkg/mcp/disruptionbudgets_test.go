@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+type DisruptionBudgetsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *DisruptionBudgetsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.PolicyV1().PodDisruptionBudgets("ns-1").Create(s.T().Context(), &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-pdb"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+			Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a-pdb"}},
+		},
+	}, metav1.CreateOptions{})
+}
+
+func (s *DisruptionBudgetsSuite) TestPdbList() {
+	s.InitMcpClient()
+	s.Run("pdb_list(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("pdb_list", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: a-pdb")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "selector: app=a-pdb")
+	})
+	s.Run("pdb_list with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("pdb_list", map[string]interface{}{"cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to list pod disruption budgets, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("pdb_list(namespace=ns-2)", func() {
+		toolResult, err := s.CallTool("pdb_list", map[string]interface{}{"namespace": "ns-2"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("No pod disruption budgets found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *DisruptionBudgetsSuite) TestPdbCheck() {
+	s.InitMcpClient()
+	s.Run("pdb_check(node=missing) ignores the budgets without pods on the node", func() {
+		toolResult, err := s.CallTool("pdb_check", map[string]interface{}{"namespace": "ns-1", "node": "missing"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal("No pod disruption budgets blocking node drains found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *DisruptionBudgetsSuite) TestPodsEvict() {
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	for name, labels := range map[string]map[string]string{"a-pod-protected-by-pdb": {"app": "a-pdb"}, "a-pod-to-evict": {"app": "unprotected"}} {
+		_, _ = kc.CoreV1().Pods("ns-1").Create(s.T().Context(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+		}, metav1.CreateOptions{})
+	}
+	s.InitMcpClient()
+	s.Run("pods_evict(name=a-pod-protected-by-pdb) is blocked by the PodDisruptionBudget", func() {
+		toolResult, _ := s.CallTool("pods_evict", map[string]interface{}{"namespace": "ns-1", "name": "a-pod-protected-by-pdb"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text,
+			"failed to evict pod a-pod-protected-by-pdb in namespace ns-1: eviction of pod ns-1/a-pod-protected-by-pdb is blocked by PodDisruptionBudget ns-1/a-pdb")
+		_, err := kc.CoreV1().Pods("ns-1").Get(s.T().Context(), "a-pod-protected-by-pdb", metav1.GetOptions{})
+		s.NoError(err, "Pod should not be evicted")
+	})
+	s.Run("pods_evict with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("pods_evict", map[string]interface{}{"namespace": "ns-1", "name": "a-pod-protected-by-pdb", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to evict pod, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("pods_evict(name=a-pod-to-evict) evicts the pod", func() {
+		toolResult, err := s.CallTool("pods_evict", map[string]interface{}{"namespace": "ns-1", "name": "a-pod-to-evict", "gracePeriodSeconds": 0})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal("Pod a-pod-to-evict evicted successfully", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestDisruptionBudgets(t *testing.T) {
+	suite.Run(t, new(DisruptionBudgetsSuite))
+}
@@ -0,0 +1,37 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type DNSSuite struct {
+	BaseMcpSuite
+}
+
+func (s *DNSSuite) TestDNSCheck() {
+	s.InitMcpClient()
+	s.Run("dns_check(names=[invalid])", func() {
+		toolResult, err := s.CallTool("dns_check", map[string]interface{}{"names": []string{"kubernetes.io; id"}})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check DNS: invalid DNS name \"kubernetes.io; id\"", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("dns_check with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("dns_check", map[string]interface{}{"cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to check DNS, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("dns_check(server=invalid)", func() {
+		toolResult, err := s.CallTool("dns_check", map[string]interface{}{"server": "$(id)"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check DNS: invalid DNS name \"$(id)\"", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestDNS(t *testing.T) {
+	suite.Run(t, new(DNSSuite))
+}
@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+const driftDesired = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: drift-edited\n  namespace: ns-1\ndata:\n  key: desired\n" +
+	"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: drift-missing\n  namespace: ns-1\ndata:\n  key: desired\n"
+
+type DriftSuite struct {
+	BaseMcpSuite
+}
+
+func (s *DriftSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.CoreV1().ConfigMaps("ns-1").Create(s.T().Context(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "drift-edited"},
+		Data:       map[string]string{"key": "edited"},
+	}, metav1.CreateOptions{FieldManager: "kubectl-edit"})
+	_, _ = kc.CoreV1().ConfigMaps("ns-1").Create(s.T().Context(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "drift-desired"},
+		Data:       map[string]string{"manifests.yaml": driftDesired, "README.md": "not a manifest"},
+	}, metav1.CreateOptions{})
+}
+
+func (s *DriftSuite) TestDriftCheck() {
+	s.InitMcpClient()
+	for name, args := range map[string]map[string]interface{}{
+		"drift_check(resource)":  {"resource": driftDesired},
+		"drift_check(configMap)": {"configMap": "drift-desired", "configMapNamespace": "ns-1"},
+	} {
+		s.Run(name, func() {
+			toolResult, err := s.CallTool("drift_check", args)
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+			var drifts []internalk8s.ResourceDrift
+			s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &drifts))
+			s.Require().Len(drifts, 2)
+			s.Run("reports the drifted fields", func() {
+				s.Equal(internalk8s.DriftDrifted, drifts[0].Status)
+				s.Require().Len(drifts[0].Differences, 1)
+				s.Equal("data.key", drifts[0].Differences[0].Path)
+				s.Equal("edited", drifts[0].Differences[0].Live)
+			})
+			s.Run("reports the manual changes", func() {
+				s.Require().Len(drifts[0].ManualChanges, 1)
+				s.Equal("kubectl-edit", drifts[0].ManualChanges[0].Manager)
+				s.Contains(drifts[0].ManualChanges[0].Fields, "data.key")
+			})
+			s.Run("reports the missing resources", func() {
+				s.Equal(internalk8s.DriftMissing, drifts[1].Status)
+			})
+		})
+	}
+	s.Run("drift_check() without source", func() {
+		toolResult, _ := s.CallTool("drift_check", map[string]interface{}{})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check drift: exactly one of the manifests, the ConfigMap, or the URL must be provided", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("drift_check with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("drift_check", map[string]interface{}{"resource": driftDesired, "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to check drift, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("drift_check(url) with plain HTTP", func() {
+		toolResult, _ := s.CallTool("drift_check", map[string]interface{}{"url": "http://example.com/manifests.yaml"})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check drift: only HTTPS URLs are supported, got http://example.com/manifests.yaml", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestDrift(t *testing.T) {
+	suite.Run(t, new(DriftSuite))
+}
@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"maps"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// dryRunMiddleware submits the mutating operations of the tool call with server-side dry-run when the dryRun argument is true.
+// The result of the tool is the would-be result of the operation so that it can be reviewed before committing the change.
+func dryRunMiddleware(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		if !params.Tool.DryRun {
+			return next(params)
+		}
+		if dryRun, _ := params.GetArguments()[api.DryRunArgument].(bool); !dryRun {
+			return next(params)
+		}
+		params.Context = internalk8s.WithDryRun(params.Context)
+		result, err := next(params)
+		if err != nil || result == nil || result.Error != nil {
+			return result, err
+		}
+		result.Content = "# Dry run: the following is a preview of the result, no changes have been persisted\n" + result.Content
+		return result, nil
+	}
+}
+
+// withDryRun returns a copy of the input schema that accepts the dry-run argument
+func withDryRun(schema *jsonschema.Schema) *jsonschema.Schema {
+	ret := &jsonschema.Schema{Type: "object"}
+	if schema != nil {
+		copied := *schema
+		ret = &copied
+	}
+	ret.Properties = maps.Clone(ret.Properties)
+	if ret.Properties == nil {
+		ret.Properties = make(map[string]*jsonschema.Schema)
+	}
+	ret.Properties[api.DryRunArgument] = &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "If true, the operation is validated by the server and its would-be result is returned, but no changes are persisted (Optional, defaults to false)",
+	}
+	return ret
+}
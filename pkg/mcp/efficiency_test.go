@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+func TestWorkloadsEfficiency(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			// Request Performed by DiscoveryClient to Kube API (Get API Groups legacy -core-)
+			if req.URL.Path == "/api" {
+				_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["metrics.k8s.io/v1beta1"],"serverAddressByClientCIDRs":[{"clientCIDR":"0.0.0.0/0"}]}`))
+				return
+			}
+			// Request Performed by DiscoveryClient to Kube API (Get API Groups)
+			if req.URL.Path == "/apis" {
+				_, _ = w.Write([]byte(`{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`))
+				return
+			}
+			// Request Performed by DiscoveryClient to Kube API (Get API Resources)
+			if req.URL.Path == "/apis/metrics.k8s.io/v1beta1" {
+				_, _ = w.Write([]byte(`{"kind":"APIResourceList","apiVersion":"v1","groupVersion":"metrics.k8s.io/v1beta1","resources":[{"name":"pods","singularName":"","namespaced":true,"kind":"PodMetrics","verbs":["get","list"]}]}`))
+				return
+			}
+			// Pod Metrics from all namespaces
+			if req.URL.Path == "/apis/metrics.k8s.io/v1beta1/pods" {
+				_, _ = w.Write([]byte(`{"kind":"PodMetricsList","apiVersion":"metrics.k8s.io/v1beta1","items":[` +
+					`{"metadata":{"name":"web-7d4b9c-a","namespace":"default"},"containers":[{"name":"web","usage":{"cpu":"10m","memory":"20Mi"}}]},` +
+					`{"metadata":{"name":"web-7d4b9c-b","namespace":"default"},"containers":[{"name":"web","usage":{"cpu":"20m","memory":"30Mi"}}]},` +
+					`{"metadata":{"name":"worker","namespace":"ns-1"},"containers":[{"name":"worker","usage":{"cpu":"950m","memory":"60Mi"}}]}` +
+					`]}`))
+				return
+			}
+			// Pods from all namespaces
+			if req.URL.Path == "/api/v1/pods" {
+				resources := `"resources":{"requests":{"cpu":"500m","memory":"64Mi"},"limits":{"cpu":"1","memory":"128Mi"}}`
+				_, _ = w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[` +
+					`{"metadata":{"name":"web-7d4b9c-a","namespace":"default","labels":{"pod-template-hash":"7d4b9c"},"ownerReferences":[{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"web-7d4b9c","uid":"1","controller":true}]},` +
+					`"spec":{"containers":[{"name":"web",` + resources + `}]}},` +
+					`{"metadata":{"name":"web-7d4b9c-b","namespace":"default","labels":{"pod-template-hash":"7d4b9c"},"ownerReferences":[{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"web-7d4b9c","uid":"1","controller":true}]},` +
+					`"spec":{"containers":[{"name":"web",` + resources + `}]}},` +
+					`{"metadata":{"name":"worker","namespace":"ns-1"},"spec":{"containers":[{"name":"worker","resources":{"requests":{"cpu":"500m"},"limits":{"cpu":"1"}}}]},` +
+					`"status":{"containerStatuses":[{"name":"worker","restartCount":3,"lastState":{"terminated":{"reason":"OOMKilled","exitCode":137}}}]}}` +
+					`]}`))
+				return
+			}
+		}))
+		t.Run("workloads_efficiency with cluster returns error instead of using the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("workloads_efficiency", map[string]interface{}{"cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to get workloads efficiency, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, err := c.callTool("workloads_efficiency", map[string]interface{}{})
+		t.Run("workloads_efficiency aggregates the containers per workload", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			textContent := toolResult.Content[0].(mcp.TextContent).Text
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %s", textContent)
+			}
+			expectedRows := regexp.MustCompile(`(?s)` +
+				`default\s+Deployment/web\s+web\s+2\s+0\s+30m\s+1\s+2\s+3%\s+50Mi\s+128Mi\s+256Mi\s+39%\s+cpu-over-requested\s*\n.*` +
+				`ns-1\s+Pod/worker\s+worker\s+1\s+3\s+950m\s+500m\s+1\s+190%\s+60Mi\s+<none>\s+<none>\s+<none>\s+cpu-under-requested,no-memory-request,no-memory-limit,cpu-throttled,oom-killed`)
+			if !expectedRows.MatchString(textContent) {
+				t.Errorf("Expected rows '%s' not found in output:\n%s", expectedRows.String(), textContent)
+			}
+		})
+		t.Run("workloads_efficiency summarizes the namespaces", func(t *testing.T) {
+			textContent := toolResult.Content[0].(mcp.TextContent).Text
+			expectedRows := regexp.MustCompile(`(?s)` +
+				`default\s+1\s+1\s+30m\s+1\s+3%\s+50Mi\s+128Mi\s+39%.*` +
+				`ns-1\s+1\s+1\s+950m\s+500m\s+190%\s+60Mi\s+0\s+<none>`)
+			if !expectedRows.MatchString(textContent) {
+				t.Errorf("Expected rows '%s' not found in output:\n%s", expectedRows.String(), textContent)
+			}
+		})
+	})
+}
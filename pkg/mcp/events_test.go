@@ -1,8 +1,10 @@
 package mcp
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -11,6 +13,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
 )
 
 type EventsSuite struct {
@@ -117,6 +121,79 @@ func (s *EventsSuite) TestEventsList() {
 			})
 		})
 	})
+	s.Run("events_list (with repeated events)", func() {
+		client := kubernetes.NewForConfigOrDie(envTestRestConfig)
+		for i, message := range []string{"Back-off restarting failed container", "Back-off restarting failed container", "Container image pulled"} {
+			eventType := "Warning"
+			if i == 2 {
+				eventType = "Normal"
+			}
+			_, _ = client.CoreV1().Events("ns-2").Create(s.T().Context(), &v1.Event{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("a-repeated-event-%d", i),
+				},
+				InvolvedObject: v1.ObjectReference{
+					APIVersion: "v1",
+					Kind:       "Pod",
+					Name:       "a-crashing-pod",
+					Namespace:  "ns-2",
+				},
+				Type:           eventType,
+				Reason:         "BackOff",
+				Message:        message,
+				Count:          int32(i + 2),
+				FirstTimestamp: metav1.NewTime(time.Date(2025, 1, 2, 15, 0, i, 0, time.UTC)),
+				LastTimestamp:  metav1.NewTime(time.Date(2025, 1, 2, 15, 4, i, 0, time.UTC)),
+			}, metav1.CreateOptions{})
+		}
+		s.Run("events_list(involvedObjectName=a-crashing-pod, type=Warning)", func() {
+			toolResult, err := s.CallTool("events_list", map[string]interface{}{
+				"namespace":          "ns-2",
+				"involvedObjectKind": "Pod",
+				"involvedObjectName": "a-crashing-pod",
+				"type":               "Warning",
+			})
+			s.Run("no error", func() {
+				s.Nilf(err, "call tool failed %v", err)
+				s.Falsef(toolResult.IsError, "call tool failed")
+			})
+			s.Run("returns deduplicated warning events", func() {
+				s.YAMLEqf(""+
+					"- Count: 5\n"+
+					"  InvolvedObject:\n"+
+					"    Kind: Pod\n"+
+					"    Name: a-crashing-pod\n"+
+					"    apiVersion: v1\n"+
+					"  Message: Back-off restarting failed container\n"+
+					"  Namespace: ns-2\n"+
+					"  Reason: BackOff\n"+
+					"  Timestamp: 2025-01-02 15:04:01 +0000 UTC\n"+
+					"  Type: Warning\n",
+					toolResult.Content[0].(mcp.TextContent).Text,
+					"unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			})
+		})
+		s.Run("events_list(since=1h) omits older events", func() {
+			toolResult, err := s.CallTool("events_list", map[string]interface{}{
+				"namespace": "ns-2",
+				"since":     "1h",
+			})
+			s.Run("no error", func() {
+				s.Nilf(err, "call tool failed %v", err)
+				s.Falsef(toolResult.IsError, "call tool failed")
+			})
+			s.Run("returns no events message", func() {
+				s.Equal("# No events found", toolResult.Content[0].(mcp.TextContent).Text)
+			})
+		})
+		s.Run("events_list(since=invalid) returns error", func() {
+			toolResult, _ := s.CallTool("events_list", map[string]interface{}{
+				"since": "yesterday",
+			})
+			s.Truef(toolResult.IsError, "call tool should fail")
+			s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "failed to list events, invalid since \"yesterday\"")
+		})
+	})
 }
 
 func (s *EventsSuite) TestEventsListDenied() {
@@ -138,6 +215,92 @@ func (s *EventsSuite) TestEventsListDenied() {
 	})
 }
 
+func (s *EventsSuite) TestEventsWatch() {
+	s.InitMcpClient()
+	client := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	now := metav1.Now()
+	_, _ = client.CoreV1().Events("ns-1").Create(s.T().Context(), &v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "a-crashing-pod-event"},
+		InvolvedObject: v1.ObjectReference{APIVersion: "v1", Kind: "Pod", Name: "a-crashing-pod", Namespace: "ns-1"},
+		Type:           "Warning",
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		Count:          7,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+	}, metav1.CreateOptions{})
+	_, _ = client.CoreV1().Events("ns-1").Create(s.T().Context(), &v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "a-normal-event"},
+		InvolvedObject: v1.ObjectReference{APIVersion: "v1", Kind: "Pod", Name: "a-pod", Namespace: "ns-1"},
+		Type:           "Normal",
+		Reason:         "Pulled",
+		Message:        "Image pulled",
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+	}, metav1.CreateOptions{})
+	s.Run("events_watch(namespace=ns-1, duration=1s)", func() {
+		toolResult, err := s.CallTool("events_watch", map[string]interface{}{
+			"namespace": "ns-1",
+			"duration":  "1s",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		var decoded struct {
+			Groups []struct {
+				Object   string `json:"object"`
+				Severity string `json:"severity"`
+				Score    int    `json:"score"`
+				Count    int    `json:"count"`
+			} `json:"groups"`
+		}
+		err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded)
+		s.Run("has yaml content", func() {
+			s.Nilf(err, "unmarshal failed %v", err)
+		})
+		s.Run("returns the warning events grouped by involved object", func() {
+			s.Require().Len(decoded.Groups, 1)
+			s.Equal("Pod/a-crashing-pod", decoded.Groups[0].Object)
+			s.Equal("high", decoded.Groups[0].Severity)
+			s.Equal(57, decoded.Groups[0].Score)
+			s.Equal(7, decoded.Groups[0].Count)
+		})
+	})
+	s.Run("events_watch(namespace=ns-1, includeNormal=true)", func() {
+		toolResult, err := s.CallTool("events_watch", map[string]interface{}{
+			"namespace":     "ns-1",
+			"duration":      "1s",
+			"includeNormal": true,
+		})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "object: Pod/a-pod")
+	})
+	s.Run("events_watch with a duration longer than the read-only tools timeout completes", func() {
+		toolResult, err := s.CallTool("events_watch", map[string]interface{}{
+			"namespace": "ns-1",
+			"duration":  (api.DefaultListTimeout + time.Second).String(),
+		})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "object: Pod/a-crashing-pod")
+	})
+	s.Run("events_watch with cluster returns error instead of watching the current cluster", func() {
+		toolResult, _ := s.CallTool("events_watch", map[string]interface{}{"namespace": "ns-1", "duration": "1s", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to watch events, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("events_watch(duration=1h)", func() {
+		toolResult, err := s.CallTool("events_watch", map[string]interface{}{
+			"duration": "1h",
+		})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "failed to watch events, invalid duration \"1h\": must be positive and at most 5m0s")
+	})
+}
+
 func TestEvents(t *testing.T) {
 	suite.Run(t, new(EventsSuite))
 }
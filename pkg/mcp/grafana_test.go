@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/yaml"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type GrafanaSuite struct {
+	BaseMcpSuite
+}
+
+func (s *GrafanaSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = []string{"metrics"}
+}
+
+func (s *GrafanaSuite) TestGrafanaLinks() {
+	s.Cfg.GrafanaURL = "https://grafana.example.com"
+	s.Cfg.GrafanaDashboards = map[string]string{"cluster": ""}
+	s.InitMcpClient()
+	s.Run("grafana_links(pod=web-1)", func() {
+		toolResult, err := s.CallTool("grafana_links", map[string]interface{}{"pod": "web-1"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		var links []internalk8s.GrafanaLink
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &links))
+		s.Run("links the pod and its namespace in the configured namespace", func() {
+			s.Require().Len(links, 2)
+			s.Equal("https://grafana.example.com/d/6581e46e4e5c7ba40a07646395ef7b23?from=now-1h&to=now&var-namespace=default&var-pod=web-1", links[0].URL)
+			s.Equal("namespace", links[1].Scope)
+		})
+	})
+	s.Run("grafana_links(kind=Deployment)", func() {
+		toolResult, err := s.CallTool("grafana_links", map[string]interface{}{"kind": "Deployment"})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to generate grafana links: the kind and the name of the workload must be provided together", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *GrafanaSuite) TestGrafanaLinksNotConfigured() {
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("grafana_links", map[string]interface{}{})
+	s.Nilf(err, "call tool should not return error object")
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Equal("failed to generate grafana links: no Grafana is configured, configure its URL with grafana_url", toolResult.Content[0].(mcp.TextContent).Text)
+}
+
+func TestGrafana(t *testing.T) {
+	suite.Run(t, new(GrafanaSuite))
+}
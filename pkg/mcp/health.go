@@ -0,0 +1,35 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/acm"
+)
+
+// ReadinessCheck is the outcome of one of the readiness checks of the server
+type ReadinessCheck struct {
+	Name string
+	Err  error
+}
+
+// Ready runs the readiness checks of the server with its own identity: the connectivity to the API server, the
+// freshness of the discovery cache, and, in ACM mode, the reachability of the cluster-proxy route.
+// The server is never ready once it's shutting down, so that no new client is routed to it.
+func (s *Server) Ready(ctx context.Context) []ReadinessCheck {
+	if s.drain != nil && s.drain.isDraining() {
+		return []ReadinessCheck{{Name: "shutdown", Err: errors.New("the server is shutting down")}}
+	}
+	if s.k == nil {
+		return []ReadinessCheck{{Name: "api-server", Err: errors.New("kubernetes manager is not initialized")}}
+	}
+	checks := []ReadinessCheck{
+		{Name: "api-server", Err: s.k.CheckAPIServer(ctx)},
+		{Name: "discovery", Err: s.k.CheckDiscovery(ctx)},
+	}
+	if s.configuration.ACMMode {
+		proxyClient := acm.NewProxyClient(s.k.GetAPIServerHost(), s.k.GetBearerToken(), s.acmTransports).WithRouteCache(s.acmRoutes)
+		checks = append(checks, ReadinessCheck{Name: "acm-proxy", Err: proxyClient.CheckProxyRoute(ctx)})
+	}
+	return checks
+}
@@ -70,6 +70,78 @@ func (s *HelmSuite) TestHelmInstall() {
 	})
 }
 
+func (s *HelmSuite) TestHelmInstallResourcesAndNotes() {
+	s.InitMcpClient()
+	s.Run("helm_install(chart=helm-chart-secret, name=secret-release, wait=false)", func() {
+		_, file, _, _ := runtime.Caller(0)
+		chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-secret")
+		toolResult, err := s.CallTool("helm_install", map[string]interface{}{
+			"chart": chartPath,
+			"name":  "secret-release",
+			"wait":  false,
+		})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		var decoded []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded))
+		s.Require().Len(decoded, 1)
+		s.Run("has the resources of the manifest", func() {
+			s.Equal([]interface{}{"Secret/secret-release-secret"}, decoded[0]["resources"])
+		})
+		s.Run("has the notes", func() {
+			s.Equal("The secret-release secret is installed in default", strings.TrimSpace(decoded[0]["notes"].(string)))
+		})
+	})
+	s.Run("helm_install(timeout=invalid)", func() {
+		toolResult, _ := s.CallTool("helm_install", map[string]interface{}{"chart": "no-op", "timeout": "soon"})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "failed to install helm chart, invalid timeout \"soon\"")
+	})
+}
+
+func (s *HelmSuite) TestHelmUpgrade() {
+	s.InitMcpClient()
+	_, file, _, _ := runtime.Caller(0)
+	chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-no-op")
+	upgrade := func(install bool) []map[string]interface{} {
+		toolResult, err := s.CallTool("helm_upgrade", map[string]interface{}{
+			"chart":   chartPath,
+			"name":    "release-to-upgrade",
+			"install": install,
+			"values":  map[string]interface{}{"replicas": 2},
+		})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		var decoded []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded))
+		s.Require().Len(decoded, 1)
+		return decoded
+	}
+	s.Run("helm_upgrade(name=release-to-upgrade, install=true) installs the missing release", func() {
+		decoded := upgrade(true)
+		s.Equal("release-to-upgrade", decoded[0]["name"])
+		s.Equal(float64(1), decoded[0]["revision"])
+		s.Equal("deployed", decoded[0]["status"])
+	})
+	s.Run("helm_upgrade(name=release-to-upgrade) upgrades the release", func() {
+		decoded := upgrade(false)
+		s.Equal(float64(2), decoded[0]["revision"])
+		s.Equal("deployed", decoded[0]["status"])
+	})
+	s.Run("helm_upgrade(name=missing-release) fails", func() {
+		toolResult, _ := s.CallTool("helm_upgrade", map[string]interface{}{"chart": chartPath, "name": "missing-release"})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "failed to upgrade helm release 'missing-release'")
+	})
+	s.Run("helm_upgrade(reuseValues=true, resetValues=true) fails", func() {
+		toolResult, _ := s.CallTool("helm_upgrade", map[string]interface{}{
+			"chart": chartPath, "name": "release-to-upgrade", "reuseValues": true, "resetValues": true,
+		})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to upgrade helm release, reuseValues and resetValues are mutually exclusive", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
 func (s *HelmSuite) TestHelmInstallDenied() {
 	s.Require().NoError(toml.Unmarshal([]byte(`
 		denied_resources = [ { version = "v1", kind = "Secret" } ]
@@ -181,6 +253,122 @@ func (s *HelmSuite) TestHelmList() {
 	})
 }
 
+func (s *HelmSuite) TestHelmListStatus() {
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	for _, status := range []string{"deployed", "failed"} {
+		_, err := kc.CoreV1().Secrets("default").Create(s.T().Context(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "sh.helm.release.v1.release-" + status,
+				Labels: map[string]string{"owner": "helm", "name": "release-" + status},
+			},
+			Data: map[string][]byte{
+				"release": []byte(base64.StdEncoding.EncodeToString([]byte("{" +
+					"\"name\":\"release-" + status + "\"," +
+					"\"info\":{\"status\":\"" + status + "\"}" +
+					"}"))),
+			},
+		}, metav1.CreateOptions{})
+		s.Require().NoError(err)
+	}
+	s.InitMcpClient()
+	s.Run("helm_list(status=failed) returns the failed releases", func() {
+		toolResult, err := s.CallTool("helm_list", map[string]interface{}{"status": "failed"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		var decoded []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded))
+		s.Require().Len(decoded, 1)
+		s.Equal("release-failed", decoded[0]["name"])
+	})
+	s.Run("helm_list(status=all) returns all the releases", func() {
+		toolResult, err := s.CallTool("helm_list", map[string]interface{}{"status": "all"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		var decoded []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded))
+		s.Len(decoded, 2)
+	})
+	s.Run("helm_list(status=superseded) with no superseded releases", func() {
+		toolResult, err := s.CallTool("helm_list", map[string]interface{}{"status": "superseded"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Equal("No Helm releases found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("helm_list(status=invalid) fails", func() {
+		toolResult, _ := s.CallTool("helm_list", map[string]interface{}{"status": "invalid"})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "unknown status \"invalid\"")
+	})
+}
+
+func (s *HelmSuite) TestHelmInspect() {
+	s.InitMcpClient()
+	_, file, _, _ := runtime.Caller(0)
+	chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-secret")
+	for _, values := range []map[string]interface{}{{"replicas": 1}, {"replicas": 2}} {
+		toolResult, err := s.CallTool("helm_upgrade", map[string]interface{}{
+			"chart": chartPath, "name": "release-to-inspect", "install": true, "wait": false, "values": values,
+		})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+	}
+	s.Run("helm_status(name=release-to-inspect)", func() {
+		toolResult, err := s.CallTool("helm_status", map[string]interface{}{"name": "release-to-inspect"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		var decoded []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded))
+		s.Require().Len(decoded, 1)
+		s.Equal(float64(2), decoded[0]["revision"])
+		s.Equal("deployed", decoded[0]["status"])
+		s.Equal([]interface{}{"Secret/release-to-inspect-secret"}, decoded[0]["resources"])
+		s.Contains(decoded[0]["notes"], "The release-to-inspect secret is installed")
+	})
+	s.Run("helm_status(name=missing-release) fails", func() {
+		toolResult, _ := s.CallTool("helm_status", map[string]interface{}{"name": "missing-release"})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "failed to get helm release status 'missing-release'")
+	})
+	s.Run("helm_history(name=release-to-inspect)", func() {
+		toolResult, err := s.CallTool("helm_history", map[string]interface{}{"name": "release-to-inspect"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		var decoded []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded))
+		s.Require().Len(decoded, 2)
+		s.Equal(float64(1), decoded[0]["revision"])
+		s.Equal("superseded", decoded[0]["status"])
+		s.Equal("Install complete", decoded[0]["description"])
+		s.Equal(float64(2), decoded[1]["revision"])
+		s.Equal("Upgrade complete", decoded[1]["description"])
+	})
+	s.Run("helm_history(name=release-to-inspect, max=1) returns the latest revision", func() {
+		toolResult, err := s.CallTool("helm_history", map[string]interface{}{"name": "release-to-inspect", "max": 1})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		var decoded []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded))
+		s.Require().Len(decoded, 1)
+		s.Equal(float64(2), decoded[0]["revision"])
+	})
+	s.Run("helm_get_values(name=release-to-inspect) returns the user-supplied values", func() {
+		toolResult, err := s.CallTool("helm_get_values", map[string]interface{}{"name": "release-to-inspect"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		s.Equal("replicas: 2\n", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("helm_get_values(name=release-to-inspect, revision=1) returns the values of the revision", func() {
+		toolResult, err := s.CallTool("helm_get_values", map[string]interface{}{"name": "release-to-inspect", "revision": 1})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Equal("replicas: 1\n", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("helm_get_values(name=release-to-inspect, all=true) returns the computed values", func() {
+		toolResult, err := s.CallTool("helm_get_values", map[string]interface{}{"name": "release-to-inspect", "all": true})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		var decoded map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded))
+		s.Equal(float64(2), decoded["replicas"])
+	})
+}
+
 func (s *HelmSuite) TestHelmUninstallNoReleases() {
 	s.InitMcpClient()
 	s.Run("helm_uninstall(name=release-to-uninstall) with no releases", func() {
@@ -230,6 +418,83 @@ func (s *HelmSuite) TestHelmUninstall() {
 	})
 }
 
+func (s *HelmSuite) TestHelmUninstallKeepHistory() {
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, err := kc.CoreV1().Secrets("default").Create(s.T().Context(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "sh.helm.release.v1.existent-release-to-keep.v1",
+			Labels: map[string]string{"owner": "helm", "name": "existent-release-to-keep", "version": "1"},
+		},
+		Data: map[string][]byte{
+			"release": []byte(base64.StdEncoding.EncodeToString([]byte("{" +
+				"\"name\":\"existent-release-to-keep\"," +
+				"\"version\":1," +
+				"\"info\":{\"status\":\"deployed\"}" +
+				"}"))),
+		},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.InitMcpClient()
+	s.Run("helm_uninstall(name=existent-release-to-keep, keepHistory=true) with deployed release", func() {
+		toolResult, err := s.CallTool("helm_uninstall", map[string]interface{}{
+			"name":        "existent-release-to-keep",
+			"keepHistory": true,
+		})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		s.Run("returns uninstalled", func() {
+			s.Truef(strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "Uninstalled release existent-release-to-keep"), "unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+		})
+		s.Run("keeps the release history", func() {
+			secret, err := kc.CoreV1().Secrets("default").Get(s.T().Context(), "sh.helm.release.v1.existent-release-to-keep.v1", metav1.GetOptions{})
+			s.Require().NoError(err, "expected release history to be kept")
+			s.Equal("uninstalled", secret.Labels["status"])
+		})
+	})
+}
+
+func (s *HelmSuite) TestHelmRollback() {
+	s.InitMcpClient()
+	_, file, _, _ := runtime.Caller(0)
+	chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-no-op")
+	s.Run("helm_rollback(name=release-to-roll-back) with a single revision fails", func() {
+		toolResult, err := s.CallTool("helm_upgrade", map[string]interface{}{"chart": chartPath, "name": "release-to-roll-back", "install": true})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		toolResult, _ = s.CallTool("helm_rollback", map[string]interface{}{"name": "release-to-roll-back"})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to roll back helm release 'release-to-roll-back': release release-to-roll-back has no previous revision to roll back to",
+			toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("helm_rollback(name=release-to-roll-back) rolls back to the previous revision", func() {
+		toolResult, err := s.CallTool("helm_upgrade", map[string]interface{}{"chart": chartPath, "name": "release-to-roll-back"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		toolResult, err = s.CallTool("helm_rollback", map[string]interface{}{"name": "release-to-roll-back"})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		var decoded []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded))
+		s.Require().Len(decoded, 1)
+		s.Equal(float64(3), decoded[0]["revision"])
+		s.Equal("deployed", decoded[0]["status"])
+	})
+	s.Run("helm_rollback(name=release-to-roll-back, revision=1, dryRun=true) returns the revision to roll back to", func() {
+		toolResult, err := s.CallTool("helm_rollback", map[string]interface{}{"name": "release-to-roll-back", "revision": 1, "dryRun": true})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed %v", toolResult.Content)
+		var decoded []map[string]interface{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded))
+		s.Require().Len(decoded, 1)
+		s.Equal(float64(1), decoded[0]["revision"])
+	})
+	s.Run("helm_rollback(name=release-to-roll-back, revision=42) fails", func() {
+		toolResult, _ := s.CallTool("helm_rollback", map[string]interface{}{"name": "release-to-roll-back", "revision": 42})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "revision 42 of release release-to-roll-back not found")
+	})
+}
+
 func (s *HelmSuite) TestHelmUninstallDenied() {
 	s.Require().NoError(toml.Unmarshal([]byte(`
 		denied_resources = [ { version = "v1", kind = "Secret" } ]
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type ImagesSuite struct {
+	BaseMcpSuite
+}
+
+func (s *ImagesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	labels := map[string]string{"app": "images-web"}
+	_, _ = kc.AppsV1().Deployments("ns-2").Create(s.T().Context(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "images-web"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers:       []corev1.Container{{Name: "web", Image: "registry.example.com/images-web:1.0", ImagePullPolicy: corev1.PullAlways}},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+}
+
+func (s *ImagesSuite) TestImagesList() {
+	s.InitMcpClient()
+	s.Run("images_list(namespace=ns-2)", func() {
+		toolResult, err := s.CallTool("images_list", map[string]interface{}{"namespace": "ns-2"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		text := toolResult.Content[0].(mcp.TextContent).Text
+		s.Contains(text, "- image: registry.example.com/images-web:1.0")
+		s.Contains(text, "kind: Deployment")
+		s.Contains(text, "name: images-web")
+		s.Contains(text, "pullPolicy: Always")
+		s.Contains(text, "imagePullSecrets: true")
+	})
+	s.Run("images_list(namespace=empty)", func() {
+		toolResult, err := s.CallTool("images_list", map[string]interface{}{"namespace": "images-empty"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("No images found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("images_list(cluster) outside ACM mode labels the images as the current cluster", func() {
+		toolResult, err := s.CallTool("images_list", map[string]interface{}{"namespace": "ns-2", "cluster": "cluster-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.NotContains(toolResult.Content[0].(mcp.TextContent).Text, "cluster-1")
+	})
+	s.Run("images_list(clusters) outside ACM mode", func() {
+		toolResult, _ := s.CallTool("images_list", map[string]interface{}{"clusters": []string{"cluster-1"}})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to list images, clusters is only supported in ACM mode", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestImages(t *testing.T) {
+	suite.Run(t, new(ImagesSuite))
+}
@@ -0,0 +1,53 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type KustomizeSuite struct {
+	BaseMcpSuite
+}
+
+func (s *KustomizeSuite) TestKustomizeBuild() {
+	files := map[string]interface{}{
+		"kustomization.yaml": "resources:\n- configmap.yaml\nnamespace: ns-1\nnameSuffix: -kustomized\n",
+		"configmap.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-configmap\ndata:\n  key: value\n",
+	}
+	s.InitMcpClient()
+	s.Run("kustomize_build renders the kustomization", func() {
+		toolResult, err := s.CallTool("kustomize_build", map[string]interface{}{"files": files})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "# The kustomization has been rendered to the following resources (YAML)")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: a-configmap-kustomized")
+	})
+	s.Run("kustomize_build with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("kustomize_build", map[string]interface{}{"files": files, "apply": true, "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to build kustomization, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("kustomize_build(apply=true) applies the rendered resources", func() {
+		toolResult, err := s.CallTool("kustomize_build", map[string]interface{}{"files": files, "apply": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: a-configmap-kustomized")
+		kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+		configMap, err := kc.CoreV1().ConfigMaps("ns-1").Get(s.T().Context(), "a-configmap-kustomized", metav1.GetOptions{})
+		s.Nilf(err, "configmap not applied %v", err)
+		s.Equal("value", configMap.Data["key"])
+	})
+	s.Run("kustomize_build without sources", func() {
+		toolResult, _ := s.CallTool("kustomize_build", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to build kustomization: either the kustomization files or the ConfigMap storing them must be provided", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestKustomize(t *testing.T) {
+	suite.Run(t, new(KustomizeSuite))
+}
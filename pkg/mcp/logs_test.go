@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/yaml"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type LogsSuite struct {
+	BaseMcpSuite
+	loki *httptest.Server
+}
+
+func (s *LogsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = []string{"metrics"}
+	s.loki = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/loki/api/v1/query_range" || r.Header.Get("X-Scope-OrgID") != "application" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[` +
+			`{"stream":{"namespace":"ns-1","pod":"a"},"values":[["1748772060000000000","error: timeout"],["1748772000000000000","error: refused"]]}]}}`))
+	}))
+	s.Cfg.LokiURL = s.loki.URL
+	s.Cfg.LokiTenant = "application"
+}
+
+func (s *LogsSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	s.loki.Close()
+}
+
+func (s *LogsSuite) TestLogsQuery() {
+	s.InitMcpClient()
+	s.Run("logs_query(query, limit=2)", func() {
+		toolResult, err := s.CallTool("logs_query", map[string]interface{}{"query": `{namespace="ns-1"} |= "error"`, "limit": 2})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		var ret internalk8s.LokiQueryResult
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &ret))
+		s.Run("returns the log lines by stream", func() {
+			s.Equal("streams", ret.ResultType)
+			s.Require().Len(ret.Streams, 1)
+			s.Equal("a", ret.Streams[0].Labels["pod"])
+			s.Len(ret.Streams[0].Entries, 2)
+			s.True(ret.Limited)
+		})
+	})
+	s.Run("logs_query(tenant=unknown)", func() {
+		toolResult, _ := s.CallTool("logs_query", map[string]interface{}{"query": `{namespace="ns-1"}`, "tenant": "unknown"})
+		s.True(toolResult.IsError, "call tool should fail")
+	})
+}
+
+func TestLogs(t *testing.T) {
+	suite.Run(t, new(LogsSuite))
+}
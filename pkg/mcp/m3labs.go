@@ -7,27 +7,43 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/client-go/transport"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/acm"
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 )
 
 func ServerToolToM3LabsServerTool(s *Server, tools []api.ServerTool) ([]server.ServerTool, error) {
 	m3labTools := make([]server.ServerTool, 0)
+	middlewares := s.toolMiddlewares()
 	for _, tool := range tools {
+		annotations := tool.Tool.Annotations
+		if tool.AdvertisedAnnotations != nil {
+			annotations = *tool.AdvertisedAnnotations
+		}
 		m3labTool := mcp.Tool{
 			Name:        tool.Tool.Name,
 			Description: tool.Tool.Description,
 			Annotations: mcp.ToolAnnotation{
-				Title:           tool.Tool.Annotations.Title,
-				ReadOnlyHint:    tool.Tool.Annotations.ReadOnlyHint,
-				DestructiveHint: tool.Tool.Annotations.DestructiveHint,
-				IdempotentHint:  tool.Tool.Annotations.IdempotentHint,
-				OpenWorldHint:   tool.Tool.Annotations.OpenWorldHint,
+				Title:           annotations.Title,
+				ReadOnlyHint:    annotations.ReadOnlyHint,
+				DestructiveHint: annotations.DestructiveHint,
+				IdempotentHint:  annotations.IdempotentHint,
+				OpenWorldHint:   annotations.OpenWorldHint,
 			},
 		}
-		if tool.Tool.InputSchema != nil {
-			schema, err := json.Marshal(tool.Tool.InputSchema)
+		inputSchema := tool.Tool.InputSchema
+		if tool.DryRun {
+			inputSchema = withDryRun(inputSchema)
+		}
+		if s.configuration.RequireConfirmation && ptr.Deref(tool.Tool.Annotations.DestructiveHint, false) {
+			inputSchema = withConfirmationToken(inputSchema)
+		}
+		if inputSchema != nil {
+			schema, err := json.Marshal(inputSchema)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal tool input schema for tool %s: %v", tool.Tool.Name, err)
 			}
@@ -39,39 +55,80 @@ func ServerToolToM3LabsServerTool(s *Server, tools []api.ServerTool) ([]server.S
 			}
 			m3labTool.RawInputSchema = schema
 		}
+		if tool.Tool.OutputSchema != nil {
+			schema, err := json.Marshal(tool.Tool.OutputSchema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal tool output schema for tool %s: %v", tool.Tool.Name, err)
+			}
+			m3labTool.RawOutputSchema = schema
+		}
+		handler := api.ChainToolMiddleware(tool.Handler, middlewares...)
 		m3labHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			k, err := s.k.Derived(ctx)
+			ctx, done := s.inFlight.track(ctx, request)
+			defer done()
+			k, err := s.sessions.derived(ctx, s.k)
 			if err != nil {
 				return nil, err
 			}
 
-			// Initialize ACM proxy client if in ACM mode
-			var acmProxyClient interface{}
-			if s.configuration.ACMMode {
-				// Get the Kubernetes server URL and bearer token from the derived client
-				serverHost := k.GetAPIServerHost()
-				bearerToken := k.GetBearerToken()
-
-				// Create ACM proxy client with Kubernetes server URL and token
-				acmProxyClient = acm.NewProxyClient(serverHost, bearerToken)
-				fmt.Printf("DEBUG: ACM proxy client initialized with server=%s, token_length=%d\n", serverHost, len(bearerToken))
-			}
-
-			result, err := tool.Handler(api.ToolHandlerParams{
+			ctx, acmProxyClient := s.acmProxyClient(ctx, k, "tool", request.Params.Name)
+			result, err := handler(api.ToolHandlerParams{
 				Context:         ctx,
 				Kubernetes:      k,
 				ToolCallRequest: request,
+				Tool:            &tool,
 				ListOutput:      s.configuration.ListOutput(),
 				// Multi-cluster support
 				IsACMMode:      s.configuration.ACMMode,
 				ACMProxyClient: acmProxyClient,
+				Session:        s.states.get(ctx),
+				Sampler:        s.sampler(ctx),
+				Progress:       s.progress(request),
 			})
 			if err != nil {
 				return nil, err
 			}
-			return NewTextResult(result.Content, result.Error), nil
+			return withErrorMeta(NewStructuredResult(result.Content, result.StructuredContent, result.Error), result), nil
 		}
 		m3labTools = append(m3labTools, server.ServerTool{Tool: m3labTool, Handler: m3labHandler})
 	}
 	return m3labTools, nil
 }
+
+// acmProxyClient returns the ACM proxy client performing the multi-cluster requests on behalf of the provided client,
+// nil if the ACM mode is disabled. The returned context traces all the proxy requests of the operation with the same ID.
+func (s *Server) acmProxyClient(ctx context.Context, k *internalk8s.Kubernetes, operation, name string) (context.Context, interface{}) {
+	if !s.configuration.ACMMode {
+		return ctx, nil
+	}
+	// Get the Kubernetes server URL, bearer token, and impersonated identity (OIDC impersonation) from the derived client
+	serverHost := k.GetAPIServerHost()
+	bearerToken := k.GetBearerToken()
+	impersonate := transport.ImpersonationConfig(k.GetImpersonation())
+
+	// Create ACM proxy client with Kubernetes server URL and token, impersonating the same identity as the derived client.
+	// The client is cheap to create: the cluster-proxy route is only discovered by the first proxied request of the
+	// server host and then cached, as the managed cluster names.
+	acmProxyClient := acm.NewProxyClient(serverHost, bearerToken, s.acmTransports).
+		WithClusterCache(s.acmClusters).WithRouteCache(s.acmRoutes).WithImpersonation(impersonate)
+	requestID := acm.NewRequestID()
+	klog.V(4).InfoS("ACM proxy client initialized", "requestID", requestID, operation, name, "server", serverHost)
+	return acm.WithRequestID(ctx, requestID), acmProxyClient
+}
+
+// withErrorMeta adds the machine-readable classification of the failed tool call to the result metadata,
+// agent frameworks can rely on it to implement retry and escalation policies
+func withErrorMeta(callToolResult *mcp.CallToolResult, result *api.ToolCallResult) *mcp.CallToolResult {
+	if result.Error == nil {
+		return callToolResult
+	}
+	errorCode, retryable := result.ErrorCode, result.Retryable
+	if errorCode == "" {
+		errorCode, retryable = api.ClassifyError(result.Error)
+	}
+	callToolResult.Meta = mcp.NewMetaFromMap(map[string]any{
+		"errorCode": errorCode,
+		"retryable": retryable,
+	})
+	return callToolResult
+}
@@ -5,7 +5,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
 	"slices"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -13,11 +18,16 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 
+	"github.com/containers/kubernetes-mcp-server/pkg/acm"
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/audit"
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/confirmation"
 	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/ratelimit"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+	"github.com/containers/kubernetes-mcp-server/pkg/tracing"
 	"github.com/containers/kubernetes-mcp-server/pkg/version"
 )
 
@@ -27,15 +37,15 @@ const TokenScopesContextKey = ContextKey("TokenScopesContextKey")
 
 type Configuration struct {
 	*config.StaticConfig
-	listOutput output.Output
-	toolsets   []api.Toolset
+	// ToolMiddlewares are additional middlewares applied to every tool call, after the built-in ones
+	ToolMiddlewares []api.ToolMiddleware
+	listOutput      output.Output
+	toolsets        []api.Toolset
 }
 
 func (c *Configuration) Toolsets() []api.Toolset {
 	if c.toolsets == nil {
-		for _, toolset := range c.StaticConfig.Toolsets {
-			c.toolsets = append(c.toolsets, toolsets.ToolsetFromString(toolset))
-		}
+		c.toolsets = toolsets.Enabled(c.StaticConfig.Toolsets, c.StaticConfig.DisabledToolsets)
 	}
 	return c.toolsets
 }
@@ -68,21 +78,66 @@ type Server struct {
 	server        *server.MCPServer
 	enabledTools  []string
 	k             *internalk8s.Manager
+	// acmTransports are shared by the per-call ACM proxy clients to reuse connections to the managed clusters
+	acmTransports *acm.Transports
+	// acmClusters caches the managed cluster names used to validate the cluster argument of the tool calls
+	acmClusters *acm.ClusterCache
+	// acmRoutes caches the cluster-proxy route discovered by the per-call ACM proxy clients
+	acmRoutes *acm.RouteCache
+	// confirmations tracks the tokens issued for destructive tool calls awaiting confirmation
+	confirmations *confirmation.Store
+	// auditor records the tool calls, nil if auditing is disabled
+	auditor *audit.Auditor
+	// rateLimiter throttles the tool calls of each session, nil if rate limiting is disabled
+	rateLimiter *ratelimit.Limiter
+	// sessions keeps the Kubernetes clients derived from the bearer token of each MCP session
+	sessions *sessionClients
+	// states keeps the state of each MCP session (e.g. the defaults inherited by the tool calls)
+	states *sessionStates
+	// inFlight tracks the in-flight tool calls to cancel them when the client sends a cancellation notification
+	inFlight *inFlightCalls
+	// drain tracks the in-flight tool calls to wait for them on shutdown
+	drain *drain
+	// subscriptions keeps the resource subscriptions of each session
+	subscriptions *resourceSubscriptions
+	// toolsMu serializes the tool reloads (kubeconfig changes and capability changes)
+	toolsMu sync.Mutex
+	// capabilities of the target cluster, only probed if capability detection is enabled
+	capabilities   internalk8s.Capabilities
+	capabilitiesMu sync.RWMutex
+	// stopCapabilityDetection stops the periodic capability detection
+	stopCapabilityDetection context.CancelFunc
 }
 
 func NewServer(configuration Configuration) (*Server, error) {
+	return newServer(configuration, &server.Hooks{})
+}
+
+func newServer(configuration Configuration, hooks *server.Hooks) (*Server, error) {
+	inFlight := newInFlightCalls()
+	inFlight.addHooks(hooks)
+	subscriptions := newResourceSubscriptions(resourceSubscriptionLimit(configuration.StaticConfig))
+	subscriptions.addHooks(hooks)
+	states := newSessionStates()
+	states.addHooks(hooks)
+	addMetricsHooks(hooks)
 	var serverOptions []server.ServerOption
 	serverOptions = append(serverOptions,
 		server.WithResourceCapabilities(true, true),
 		server.WithPromptCapabilities(true),
 		server.WithToolCapabilities(true),
 		server.WithLogging(),
+		server.WithHooks(hooks),
 		server.WithToolHandlerMiddleware(toolCallLoggingMiddleware),
 	)
 	if configuration.RequireOAuth && false { // TODO: Disabled scope auth validation for now
 		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(toolScopedAuthorizationMiddleware))
 	}
 
+	auditor, err := newAuditor(configuration.StaticConfig)
+	if err != nil {
+		return nil, err
+	}
 	s := &Server{
 		configuration: &configuration,
 		server: server.NewMCPServer(
@@ -90,11 +145,34 @@ func NewServer(configuration Configuration) (*Server, error) {
 			version.Version,
 			serverOptions...,
 		),
+		acmTransports: acm.NewTransports(acmTransportOptions(configuration.StaticConfig)),
+		acmClusters:   acm.NewClusterCache(acm.DefaultClusterCacheTTL),
+		acmRoutes:     acm.NewRouteCache(),
+		confirmations: confirmation.NewStore(confirmation.DefaultTTL),
+		auditor:       auditor,
+		rateLimiter:   newRateLimiter(configuration.StaticConfig),
+		sessions:      newSessionClients(),
+		states:        states,
+		inFlight:      inFlight,
+		drain:         newDrain(),
+		subscriptions: subscriptions,
 	}
+	s.subscriptions.notify = s.notifyResourceUpdated
+	s.server.AddNotificationHandler("notifications/cancelled", s.inFlight.cancel)
+	s.server.EnableSampling()
+	s.server.AddResourceTemplates(s.resourceTemplates()...)
 	if err := s.reloadKubernetesClient(); err != nil {
+		if auditor != nil {
+			_ = auditor.Close()
+		}
 		return nil, err
 	}
 	s.k.WatchKubeConfig(s.reloadKubernetesClient)
+	if configuration.CapabilityDetection {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.stopCapabilityDetection = cancel
+		go s.watchCapabilities(ctx, capabilityDetectionInterval(configuration.StaticConfig))
+	}
 
 	return s, nil
 }
@@ -104,27 +182,77 @@ func (s *Server) reloadKubernetesClient() error {
 	if err != nil {
 		return err
 	}
+	// The previous Manager isn't closed since its kubeconfig watcher triggers the reloads, only its metrics sampling is stopped
+	if s.k != nil {
+		s.k.StopMetricsHistory()
+	}
 	s.k = k
+	s.k.StartMetricsHistory()
+	if s.configuration.CapabilityDetection {
+		s.setCapabilities(s.k.Capabilities(context.Background()))
+	}
+	return s.reloadTools()
+}
+
+// reloadTools (re)computes the tools advertised to the clients, the clients are notified if the server is already running
+func (s *Server) reloadTools() error {
+	s.toolsMu.Lock()
+	defer s.toolsMu.Unlock()
+	// The identity is only known upfront when OAuth is not required (each request carries its own token otherwise)
+	var preflight *internalk8s.Kubernetes
+	if s.configuration.PreflightAuthorization && !s.configuration.RequireOAuth {
+		var err error
+		if preflight, err = s.k.Derived(context.Background()); err != nil {
+			return err
+		}
+	}
 	applicableTools := make([]api.ServerTool, 0)
+	enabledTools := make([]string, 0)
+	toolNames := make(map[string]string)
 	for _, toolset := range s.configuration.Toolsets() {
+		if !s.isToolsetAvailable(toolset) {
+			continue
+		}
 		for _, tool := range toolset.GetTools(s.k) {
 			if !s.configuration.isToolApplicable(tool) {
 				continue
 			}
+			if preflight != nil && !usable(context.Background(), preflight, tool) {
+				klog.V(1).Infof("tool %s is hidden, the current identity lacks the permissions to use it", tool.Tool.Name)
+				continue
+			}
+			originalName := tool.Tool.Name
+			tool = s.configuration.overrideTool(tool)
+			if slices.ContainsFunc(applicableTools, func(t api.ServerTool) bool { return t.Tool.Name == tool.Tool.Name }) {
+				return fmt.Errorf("tool %s is exposed more than once, check the tool_overrides configuration", tool.Tool.Name)
+			}
 			applicableTools = append(applicableTools, tool)
-			s.enabledTools = append(s.enabledTools, tool.Tool.Name)
+			enabledTools = append(enabledTools, tool.Tool.Name)
+			toolNames[originalName] = tool.Tool.Name
 		}
 	}
+	s.enabledTools = enabledTools
 	m3labsServerTools, err := ServerToolToM3LabsServerTool(s, applicableTools)
 	if err != nil {
 		return fmt.Errorf("failed to convert tools: %v", err)
 	}
 	s.server.SetTools(m3labsServerTools...)
+	s.server.SetPrompts(prompts(toolNames)...)
 	return nil
 }
 
-func (s *Server) ServeStdio() error {
-	return server.ServeStdio(s.server)
+func (s *Server) ServeStdio(ctx context.Context) error {
+	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+	// The resource subscription requests are intercepted once the stdio session is registered
+	sessionCtx := make(chan context.Context, 1)
+	stdioServer := server.NewStdioServer(s.server)
+	stdioServer.SetContextFunc(func(ctx context.Context) context.Context {
+		sessionCtx <- ctx
+		return ctx
+	})
+	stdout := &syncWriter{w: os.Stdout}
+	return stdioServer.Listen(ctx, s.stdioSubscriptions(sessionCtx, os.Stdin, stdout), stdout)
 }
 
 func (s *Server) ServeSse(baseUrl string, httpServer *http.Server) *server.SSEServer {
@@ -136,13 +264,16 @@ func (s *Server) ServeSse(baseUrl string, httpServer *http.Server) *server.SSESe
 	return server.NewSSEServer(s.server, options...)
 }
 
-func (s *Server) ServeHTTP(httpServer *http.Server) *server.StreamableHTTPServer {
+func (s *Server) ServeHTTP(httpServer *http.Server) http.Handler {
 	options := []server.StreamableHTTPOption{
 		server.WithHTTPContextFunc(contextFunc),
 		server.WithStreamableHTTPServer(httpServer),
-		server.WithStateLess(true),
 	}
-	return server.NewStreamableHTTPServer(s.server, options...)
+	if !s.configuration.StatefulHTTP {
+		return server.NewStreamableHTTPServer(s.server, append(options, server.WithStateLess(true))...)
+	}
+	sessions := newHttpSessions(s.configuration.MaxHTTPSessions)
+	return sessions.handler(server.NewStreamableHTTPServer(s.server, append(options, server.WithSessionIdManager(sessions))...))
 }
 
 // KubernetesApiVerifyToken verifies the given token with the audience by
@@ -167,9 +298,44 @@ func (s *Server) GetEnabledTools() []string {
 }
 
 func (s *Server) Close() {
+	if s.stopCapabilityDetection != nil {
+		s.stopCapabilityDetection()
+	}
+	s.subscriptions.close()
 	if s.k != nil {
 		s.k.Close()
 	}
+	if s.acmTransports != nil {
+		s.acmTransports.CloseIdleConnections()
+	}
+	if s.auditor != nil {
+		if err := s.auditor.Close(); err != nil {
+			klog.Errorf("failed to close audit log: %v", err)
+		}
+	}
+}
+
+// acmTransportOptions overrides the default ACM proxy transport options with the configured ones
+func acmTransportOptions(staticConfig *config.StaticConfig) acm.TransportOptions {
+	options := acm.DefaultTransportOptions()
+	if staticConfig.ACMProxyMaxIdleConnsPerHost > 0 {
+		options.MaxIdleConnsPerHost = staticConfig.ACMProxyMaxIdleConnsPerHost
+	}
+	if staticConfig.ACMProxyIdleConnTimeout > 0 {
+		options.IdleConnTimeout = staticConfig.ACMProxyIdleConnTimeout
+	}
+	options.DisableHTTP2 = staticConfig.ACMProxyDisableHTTP2
+	for operation, timeout := range map[acm.Operation]time.Duration{
+		acm.OperationDefault: staticConfig.ACMProxyTimeout,
+		acm.OperationList:    staticConfig.ACMProxyListTimeout,
+		acm.OperationLogs:    staticConfig.ACMProxyLogsTimeout,
+		acm.OperationWatch:   staticConfig.ACMProxyWatchTimeout,
+	} {
+		if timeout > 0 {
+			options.Timeouts[operation] = timeout
+		}
+	}
+	return options
 }
 
 func NewTextResult(content string, err error) *mcp.CallToolResult {
@@ -194,7 +360,17 @@ func NewTextResult(content string, err error) *mcp.CallToolResult {
 	}
 }
 
+// NewStructuredResult returns a text result that also carries the structured content, unless the tool call failed
+func NewStructuredResult(content string, structuredContent any, err error) *mcp.CallToolResult {
+	result := NewTextResult(content, err)
+	if err == nil {
+		result.StructuredContent = structuredContent
+	}
+	return result
+}
+
 func contextFunc(ctx context.Context, r *http.Request) context.Context {
+	ctx = tracing.Extract(ctx, r.Header)
 	// Get the standard Authorization header (OAuth compliant)
 	authHeader := r.Header.Get(string(internalk8s.OAuthAuthorizationHeader))
 	if authHeader != "" {
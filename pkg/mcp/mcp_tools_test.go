@@ -7,9 +7,12 @@ import (
 
 	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 )
 
@@ -52,6 +55,29 @@ func TestReadOnly(t *testing.T) {
 				}
 			}
 		})
+		t.Run("Mutating tools are rejected at dispatch time", func(t *testing.T) {
+			handlerCalled := false
+			tools, err := ServerToolToM3LabsServerTool(c.mcpServer, []api.ServerTool{{
+				Tool: api.Tool{Name: "mutating_tool", Annotations: api.ToolAnnotations{ReadOnlyHint: ptr.To(false)}},
+				Handler: func(_ api.ToolHandlerParams) (*api.ToolCallResult, error) {
+					handlerCalled = true
+					return api.NewToolCallResult("mutated", nil), nil
+				},
+			}})
+			if err != nil {
+				t.Fatalf("failed to convert tool: %v", err)
+			}
+			result, err := tools[0].Handler(c.ctx, mcp.CallToolRequest{})
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if !result.IsError || handlerCalled {
+				t.Fatalf("mutating tool should be rejected in read-only mode")
+			}
+			if result.Content[0].(mcp.TextContent).Text != "tool mutating_tool is not allowed, the server is running in read-only mode" {
+				t.Fatalf("unexpected error message %v", result.Content[0].(mcp.TextContent).Text)
+			}
+		})
 	})
 }
 
@@ -74,6 +100,75 @@ func TestDisableDestructive(t *testing.T) {
 	})
 }
 
+func TestRequireConfirmation(t *testing.T) {
+	requireConfirmationServer := func(c *mcpContext) {
+		c.withEnvTest()
+		c.staticConfig = &config.StaticConfig{RequireConfirmation: true}
+	}
+	testCaseWithContext(t, &mcpContext{before: requireConfirmationServer}, func(c *mcpContext) {
+		tools, err := c.mcpClient.ListTools(c.ctx, mcp.ListToolsRequest{})
+		t.Run("ListTools returns tools", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call ListTools failed %v", err)
+			}
+		})
+		t.Run("Destructive tools accept a confirmation token", func(t *testing.T) {
+			for _, tool := range tools.Tools {
+				_, hasToken := tool.InputSchema.Properties["confirmation_token"]
+				if ptr.Deref(tool.Annotations.DestructiveHint, false) != hasToken {
+					t.Errorf("Tool %s confirmation_token argument mismatch, destructive: %v", tool.Name, ptr.Deref(tool.Annotations.DestructiveHint, false))
+				}
+			}
+		})
+		client := c.newKubernetesClient()
+		_, _ = client.CoreV1().ConfigMaps("default").Create(c.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-configmap-to-confirm"},
+		}, metav1.CreateOptions{})
+		args := map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-confirm"}
+		first, err := c.callTool("resources_delete", args)
+		t.Run("First call returns impact summary and token", func(t *testing.T) {
+			if err != nil || first.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if !strings.HasPrefix(first.Content[0].(mcp.TextContent).Text, "Confirmation required: Resources: Delete is a destructive operation and was NOT executed.") {
+				t.Fatalf("unexpected tool result content got: %v", first.Content[0].(mcp.TextContent).Text)
+			}
+			if _, err := client.CoreV1().ConfigMaps("default").Get(c.ctx, "a-configmap-to-confirm", metav1.GetOptions{}); err != nil {
+				t.Fatalf("ConfigMap deleted without confirmation: %v", err)
+			}
+		})
+		t.Run("First call previews the impact with a server-side dry run", func(t *testing.T) {
+			if !strings.Contains(first.Content[0].(mcp.TextContent).Text, "Preview of the impact (server-side dry run, no changes have been persisted):\nResource deleted successfully\n") {
+				t.Fatalf("impact preview not found in: %v", first.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		token := regexp.MustCompile(`confirmation_token="([^"]+)"`).FindStringSubmatch(first.Content[0].(mcp.TextContent).Text)
+		t.Run("Call with different arguments is rejected", func(t *testing.T) {
+			if len(token) != 2 {
+				t.Fatalf("confirmation token not found")
+			}
+			toolResult, _ := c.callTool("resources_delete", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "another-configmap", "confirmation_token": token[1]})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		second, err := c.callTool("resources_delete", args)
+		token = regexp.MustCompile(`confirmation_token="([^"]+)"`).FindStringSubmatch(second.Content[0].(mcp.TextContent).Text)
+		t.Run("Call with token executes the operation", func(t *testing.T) {
+			if err != nil || len(token) != 2 {
+				t.Fatalf("confirmation token not found %v", err)
+			}
+			toolResult, err := c.callTool("resources_delete", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-confirm", "confirmation_token": token[1]})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Resource deleted successfully" {
+				t.Fatalf("invalid tool result content got: %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
 func TestEnabledTools(t *testing.T) {
 	enabledToolsServer := test.Must(config.ReadToml([]byte(`
 		enabled_tools = [ "namespaces_list", "events_list" ]
@@ -120,6 +215,244 @@ func TestDisabledTools(t *testing.T) {
 	})
 }
 
+func TestToolOverrides(t *testing.T) {
+	toolOverridesServer := test.Must(config.ReadToml([]byte(`
+		enabled_tools = [ "namespaces_list", "events_list" ]
+		[tool_overrides.namespaces_list]
+		name = "org_namespaces"
+		append_description = "Only namespaces prefixed with team- belong to the current team."
+		title = "Org: Namespaces"
+		[tool_overrides.events_list]
+		description = "List the cluster events"
+		open_world_hint = false
+	`)))
+	testCaseWithContext(t, &mcpContext{staticConfig: toolOverridesServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		tools, err := c.mcpClient.ListTools(c.ctx, mcp.ListToolsRequest{})
+		t.Run("ListTools returns tools", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call ListTools failed %v", err)
+			}
+		})
+		toolsByName := make(map[string]mcp.Tool)
+		for _, tool := range tools.Tools {
+			toolsByName[tool.Name] = tool
+		}
+		t.Run("ListTools returns renamed tool", func(t *testing.T) {
+			if _, ok := toolsByName["namespaces_list"]; ok {
+				t.Fatalf("Tool namespaces_list should be renamed")
+			}
+			tool, ok := toolsByName["org_namespaces"]
+			if !ok {
+				t.Fatalf("Tool org_namespaces not found")
+			}
+			if !strings.HasSuffix(tool.Description, "\nOnly namespaces prefixed with team- belong to the current team.") {
+				t.Errorf("Tool org_namespaces description not appended, got %s", tool.Description)
+			}
+			if tool.Annotations.Title != "Org: Namespaces" {
+				t.Errorf("Tool org_namespaces title not overridden, got %s", tool.Annotations.Title)
+			}
+		})
+		t.Run("ListTools returns overridden description and annotations", func(t *testing.T) {
+			tool := toolsByName["events_list"]
+			if tool.Description != "List the cluster events" {
+				t.Errorf("Tool events_list description not overridden, got %s", tool.Description)
+			}
+			if ptr.Deref(tool.Annotations.OpenWorldHint, true) {
+				t.Errorf("Tool events_list openWorldHint not overridden")
+			}
+		})
+		t.Run("Renamed tool can be called", func(t *testing.T) {
+			toolResult, err := c.callTool("org_namespaces", map[string]interface{}{})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+		})
+	})
+}
+
+func TestToolOverridesKeepSafetyChecks(t *testing.T) {
+	toolOverridesServer := test.Must(config.ReadToml([]byte(`
+		require_confirmation = true
+		[tool_overrides.resources_delete]
+		read_only_hint = true
+		destructive_hint = false
+	`)))
+	testCaseWithContext(t, &mcpContext{staticConfig: toolOverridesServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		tools, err := c.mcpClient.ListTools(c.ctx, mcp.ListToolsRequest{})
+		t.Run("ListTools returns overridden hints", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call ListTools failed %v", err)
+			}
+			for _, tool := range tools.Tools {
+				if tool.Name != "resources_delete" {
+					continue
+				}
+				if !ptr.Deref(tool.Annotations.ReadOnlyHint, false) || ptr.Deref(tool.Annotations.DestructiveHint, true) {
+					t.Errorf("Tool resources_delete hints not overridden")
+				}
+				if _, hasToken := tool.InputSchema.Properties["confirmation_token"]; !hasToken {
+					t.Errorf("Tool resources_delete should accept a confirmation token")
+				}
+			}
+		})
+		client := c.newKubernetesClient()
+		_, _ = client.CoreV1().ConfigMaps("default").Create(c.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-configmap-to-confirm"},
+		}, metav1.CreateOptions{})
+		toolResult, err := c.callTool("resources_delete", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-confirm"})
+		t.Run("Overridden destructive tool still requires confirmation", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "Confirmation required: Resources: Delete is a destructive operation and was NOT executed.") {
+				t.Fatalf("unexpected tool result content got: %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if _, err := client.CoreV1().ConfigMaps("default").Get(c.ctx, "a-configmap-to-confirm", metav1.GetOptions{}); err != nil {
+				t.Fatalf("ConfigMap deleted without confirmation: %v", err)
+			}
+		})
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	rateLimitServer := test.Must(config.ReadToml([]byte(`
+		rate_limit = { requests_per_second = 0.001, burst = 2 }
+		tool_rate_limits = { events_list = { requests_per_second = 0 } }
+	`)))
+	testCaseWithContext(t, &mcpContext{staticConfig: rateLimitServer}, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("Calls within burst are allowed", func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				toolResult, err := c.callTool("namespaces_list", map[string]interface{}{})
+				if err != nil || toolResult.IsError {
+					t.Fatalf("call tool failed %v", err)
+				}
+			}
+		})
+		t.Run("Calls exceeding burst are rejected", func(t *testing.T) {
+			toolResult, _ := c.callTool("namespaces_list", map[string]interface{}{})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "rate limit exceeded for tool namespaces_list, retry in ") {
+				t.Fatalf("unexpected error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("Other tools keep their own bucket", func(t *testing.T) {
+			toolResult, err := c.callTool("configuration_view", map[string]interface{}{})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+		})
+		t.Run("Tool limits override the default limit", func(t *testing.T) {
+			for i := 0; i < 5; i++ {
+				toolResult, err := c.callTool("events_list", map[string]interface{}{})
+				if err != nil || toolResult.IsError {
+					t.Fatalf("call tool failed %v", err)
+				}
+			}
+		})
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	testCaseWithContext(t, &mcpContext{staticConfig: &config.StaticConfig{RequireConfirmation: true}}, func(c *mcpContext) {
+		c.withEnvTest()
+		tools, err := c.mcpClient.ListTools(c.ctx, mcp.ListToolsRequest{})
+		t.Run("ListTools returns tools", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call ListTools failed %v", err)
+			}
+		})
+		t.Run("Mutating tools accept a dryRun argument", func(t *testing.T) {
+			for _, tool := range tools.Tools {
+				if tool.Name != "resources_create_or_update" && tool.Name != "resources_delete" && tool.Name != "pods_delete" {
+					continue
+				}
+				if _, ok := tool.InputSchema.Properties["dryRun"]; !ok {
+					t.Errorf("Tool %s does not accept a dryRun argument", tool.Name)
+				}
+			}
+		})
+		client := c.newKubernetesClient()
+		configMapYaml := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm-dry-run\n  namespace: default\n"
+		created, err := c.callTool("resources_create_or_update", map[string]interface{}{"resource": configMapYaml, "dryRun": true})
+		t.Run("resources_create_or_update with dryRun returns would-be result", func(t *testing.T) {
+			if err != nil || created.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if !strings.HasPrefix(created.Content[0].(mcp.TextContent).Text, "# Dry run: the following is a preview of the result, no changes have been persisted\n") {
+				t.Fatalf("unexpected tool result content got: %v", created.Content[0].(mcp.TextContent).Text)
+			}
+			if !strings.Contains(created.Content[0].(mcp.TextContent).Text, "name: a-cm-dry-run") {
+				t.Fatalf("expected would-be resource, got: %v", created.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_create_or_update with dryRun does not persist the resource", func(t *testing.T) {
+			if _, err := client.CoreV1().ConfigMaps("default").Get(c.ctx, "a-cm-dry-run", metav1.GetOptions{}); err == nil {
+				t.Fatalf("ConfigMap persisted in dry-run mode")
+			}
+		})
+		_, _ = client.CoreV1().ConfigMaps("default").Create(c.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-cm-dry-run-delete"},
+		}, metav1.CreateOptions{})
+		deleted, err := c.callTool("resources_delete", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-cm-dry-run-delete", "dryRun": true})
+		t.Run("resources_delete with dryRun does not require confirmation", func(t *testing.T) {
+			if err != nil || deleted.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if strings.HasPrefix(deleted.Content[0].(mcp.TextContent).Text, "Confirmation required") {
+				t.Fatalf("unexpected confirmation request: %v", deleted.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_delete with dryRun does not delete the resource", func(t *testing.T) {
+			if _, err := client.CoreV1().ConfigMaps("default").Get(c.ctx, "a-cm-dry-run-delete", metav1.GetOptions{}); err != nil {
+				t.Fatalf("ConfigMap deleted in dry-run mode: %v", err)
+			}
+		})
+	})
+}
+
+func TestToolCallErrorClassification(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		toolResult, err := c.callTool("resources_get", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "nonexistent-configmap"})
+		t.Run("Failed tool call returns error", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("Failed tool call returns error code in metadata", func(t *testing.T) {
+			if toolResult.Meta == nil {
+				t.Fatalf("expected metadata in tool result")
+			}
+			if toolResult.Meta.AdditionalFields["errorCode"] != "NotFound" {
+				t.Errorf("expected NotFound error code, got %v", toolResult.Meta.AdditionalFields["errorCode"])
+			}
+			if toolResult.Meta.AdditionalFields["retryable"] != false {
+				t.Errorf("expected non-retryable error, got %v", toolResult.Meta.AdditionalFields["retryable"])
+			}
+		})
+		invalidArguments, _ := c.callTool("resources_get", map[string]interface{}{})
+		t.Run("Invalid arguments return ValidationFailed error code", func(t *testing.T) {
+			if invalidArguments.Meta == nil || invalidArguments.Meta.AdditionalFields["errorCode"] != "ValidationFailed" {
+				t.Errorf("expected ValidationFailed error code, got %v", invalidArguments.Meta)
+			}
+		})
+		successful, _ := c.callTool("namespaces_list", map[string]interface{}{})
+		t.Run("Successful tool call has no error code", func(t *testing.T) {
+			if successful.Meta != nil && successful.Meta.AdditionalFields["errorCode"] != nil {
+				t.Errorf("unexpected error code %v", successful.Meta.AdditionalFields["errorCode"])
+			}
+		})
+	})
+}
+
 func TestToolCallLogging(t *testing.T) {
 	testCaseWithContext(t, &mcpContext{logLevel: 5}, func(c *mcpContext) {
 		_, _ = c.callTool("configuration_view", map[string]interface{}{
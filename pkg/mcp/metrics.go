@@ -0,0 +1,44 @@
+package mcp
+
+import (
+	"context"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/metrics"
+)
+
+// addMetricsHooks adds the server hooks tracking the number of active sessions
+func addMetricsHooks(hooks *server.Hooks) {
+	hooks.AddOnRegisterSession(func(_ context.Context, _ server.ClientSession) {
+		metrics.ActiveSessions.Inc()
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, _ server.ClientSession) {
+		metrics.ActiveSessions.Dec()
+	})
+}
+
+// metricsMiddleware records the count, duration, and outcome of every tool call, including the ones rejected by the
+// inner middlewares
+func metricsMiddleware(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		start := time.Now()
+		result, err := next(params)
+		metrics.ToolCallDuration.WithLabelValues(params.Tool.Tool.Name).Observe(time.Since(start).Seconds())
+		status, errorCode := metrics.StatusSuccess, ""
+		switch {
+		case err != nil:
+			status, errorCode = metrics.StatusError, string(api.ErrorCodeUnknown)
+		case result != nil && result.Error != nil:
+			status, errorCode = metrics.StatusError, string(result.ErrorCode)
+			if errorCode == "" {
+				code, _ := api.ClassifyError(result.Error)
+				errorCode = string(code)
+			}
+		}
+		metrics.ToolCalls.WithLabelValues(params.Tool.Tool.Name, status, errorCode).Inc()
+		return result, err
+	}
+}
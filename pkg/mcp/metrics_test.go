@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/metrics"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	call := func(name string, result *api.ToolCallResult, err error) {
+		handler := metricsMiddleware(func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			return result, err
+		})
+		_, _ = handler(api.ToolHandlerParams{Context: context.Background(), Tool: &api.ServerTool{Tool: api.Tool{Name: name}}})
+	}
+	t.Run("Successful calls are counted", func(t *testing.T) {
+		call("metrics_success", api.NewToolCallResult("ok", nil), nil)
+		if count := testutil.ToFloat64(metrics.ToolCalls.WithLabelValues("metrics_success", metrics.StatusSuccess, "")); count != 1 {
+			t.Errorf("expected 1 successful call, got %v", count)
+		}
+	})
+	t.Run("Failed calls are counted by error code", func(t *testing.T) {
+		call("metrics_error", api.NewToolCallResult("", api.NewToolError(api.ErrorCodeForbidden, false, errors.New("forbidden"))), nil)
+		call("metrics_error", api.NewToolCallResult("", errors.New("something went wrong")), nil)
+		call("metrics_error", nil, errors.New("handler failed"))
+		if count := testutil.ToFloat64(metrics.ToolCalls.WithLabelValues("metrics_error", metrics.StatusError, "Forbidden")); count != 1 {
+			t.Errorf("expected 1 Forbidden call, got %v", count)
+		}
+		if count := testutil.ToFloat64(metrics.ToolCalls.WithLabelValues("metrics_error", metrics.StatusError, "Unknown")); count != 2 {
+			t.Errorf("expected 2 Unknown calls, got %v", count)
+		}
+	})
+	t.Run("Duration is observed", func(t *testing.T) {
+		if count := testutil.CollectAndCount(metrics.ToolCallDuration, "kubernetes_mcp_server_tool_call_duration_seconds"); count < 2 {
+			t.Errorf("expected the duration of each tool to be observed, got %d series", count)
+		}
+	})
+}
+
+func TestMetricsHooks(t *testing.T) {
+	hooks := &server.Hooks{}
+	addMetricsHooks(hooks)
+	initial := testutil.ToFloat64(metrics.ActiveSessions)
+	session := server.NewInProcessSession("metrics-session", nil)
+	for _, hook := range hooks.OnRegisterSession {
+		hook(context.Background(), session)
+	}
+	t.Run("Registered sessions are active", func(t *testing.T) {
+		if active := testutil.ToFloat64(metrics.ActiveSessions); active != initial+1 {
+			t.Errorf("expected %v active sessions, got %v", initial+1, active)
+		}
+	})
+	for _, hook := range hooks.OnUnregisterSession {
+		hook(context.Background(), session)
+	}
+	t.Run("Unregistered sessions are no longer active", func(t *testing.T) {
+		if active := testutil.ToFloat64(metrics.ActiveSessions); active != initial {
+			t.Errorf("expected %v active sessions, got %v", initial, active)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"fmt"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/acm"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// toolMiddlewares returns the middlewares applied to every tool call, the first middleware is the outermost one
+func (s *Server) toolMiddlewares() []api.ToolMiddleware {
+	middlewares := []api.ToolMiddleware{sessionDefaultsMiddleware, tracingMiddleware, metricsMiddleware, drainMiddleware(s.drain)}
+	if s.auditor != nil {
+		middlewares = append(middlewares, auditMiddleware(s.auditor))
+	}
+	if s.rateLimiter != nil {
+		middlewares = append(middlewares, rateLimitMiddleware(s.rateLimiter))
+	}
+	middlewares = append(middlewares, timeoutMiddleware(s.configuration))
+	if s.configuration.ReadOnly {
+		middlewares = append(middlewares, readOnlyMiddleware)
+	}
+	if s.configuration.ACMMode {
+		middlewares = append(middlewares, acmClusterValidationMiddleware)
+	}
+	if s.configuration.PreflightAuthorization {
+		middlewares = append(middlewares, preflightAuthorizationMiddleware)
+	}
+	middlewares = append(middlewares, dryRunMiddleware)
+	if s.configuration.RequireConfirmation {
+		middlewares = append(middlewares, confirmationMiddleware(s.confirmations))
+	}
+	return append(middlewares, s.configuration.ToolMiddlewares...)
+}
+
+// readOnlyMiddleware rejects the calls to mutating tools.
+// Mutating tools are not advertised in read-only mode, but they must never be dispatched either.
+func readOnlyMiddleware(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		if !ptr.Deref(params.Tool.Tool.Annotations.ReadOnlyHint, false) {
+			return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeForbidden, false,
+				fmt.Errorf("tool %s is not allowed, the server is running in read-only mode", params.Tool.Tool.Name))), nil
+		}
+		return next(params)
+	}
+}
+
+// acmClusterValidationMiddleware rejects unknown clusters upfront with a helpful error instead of an opaque error from the proxy route
+func acmClusterValidationMiddleware(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		proxyClient, ok := params.ACMProxyClient.(*acm.ProxyClient)
+		if !ok {
+			return next(params)
+		}
+		for _, cluster := range requestedClusters(params.GetArguments()) {
+			if err := proxyClient.ValidateClusterName(params, cluster); err != nil {
+				return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeNotFound, false, err)), nil
+			}
+		}
+		return next(params)
+	}
+}
+
+// requestedClusters returns the cluster names of the arguments: the cluster argument, the clusters argument of the
+// fan-out tools, and the cluster of each of the requests of the batch tools
+func requestedClusters(arguments map[string]any) []string {
+	var clusters []string
+	if cluster, ok := arguments["cluster"].(string); ok && cluster != "" {
+		clusters = append(clusters, cluster)
+	}
+	if names, ok := arguments["clusters"].([]any); ok {
+		for _, name := range names {
+			if cluster, ok := name.(string); ok && cluster != "" {
+				clusters = append(clusters, cluster)
+			}
+		}
+	}
+	if requests, ok := arguments["requests"].([]any); ok {
+		for _, request := range requests {
+			if request, ok := request.(map[string]any); ok {
+				if cluster, ok := request["cluster"].(string); ok && cluster != "" {
+					clusters = append(clusters, cluster)
+				}
+			}
+		}
+	}
+	return clusters
+}
@@ -0,0 +1,31 @@
+package mcp
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRequestedClusters(t *testing.T) {
+	cases := []struct {
+		name      string
+		arguments map[string]any
+		expected  []string
+	}{
+		{"no cluster", map[string]any{"namespace": "default"}, nil},
+		{"empty cluster", map[string]any{"cluster": ""}, nil},
+		{"cluster", map[string]any{"cluster": "managed-1"}, []string{"managed-1"}},
+		{"clusters", map[string]any{"clusters": []any{"managed-1", "", "managed-2"}}, []string{"managed-1", "managed-2"}},
+		{"requests", map[string]any{"requests": []any{
+			map[string]any{"id": "a", "cluster": "managed-1"},
+			map[string]any{"id": "b"},
+			map[string]any{"id": "c", "cluster": "managed-2"},
+		}}, []string{"managed-1", "managed-2"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := requestedClusters(c.arguments); !slices.Equal(actual, c.expected) {
+				t.Errorf("expected clusters %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
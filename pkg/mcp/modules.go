@@ -1,5 +1,11 @@
 package mcp
 
+import _ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/acm"
 import _ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/config"
 import _ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/core"
+import _ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/cost"
+import _ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/crd"
 import _ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/helm"
+import _ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/metrics"
+import _ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/network"
+import _ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/storage"
@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/yaml"
 
 	"github.com/containers/kubernetes-mcp-server/internal/test"
@@ -108,6 +109,60 @@ func (s *NamespacesSuite) TestNamespacesListAsTable() {
 	})
 }
 
+func (s *NamespacesSuite) TestNamespacesLifecycle() {
+	s.InitMcpClient()
+	s.Run("namespaces_create", func() {
+		toolResult, err := s.CallTool("namespaces_create", map[string]interface{}{"name": "ns-lifecycle", "labels": map[string]interface{}{"team": "a"}})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "team: a")
+	})
+	s.Run("namespaces_status of an active namespace", func() {
+		toolResult, err := s.CallTool("namespaces_status", map[string]interface{}{"name": "ns-lifecycle"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "phase: Active")
+		s.NotContains(toolResult.Content[0].(mcp.TextContent).Text, "issues:")
+	})
+	dynamicClient := dynamic.NewForConfigOrDie(envTestRestConfig)
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetName("a-configmap-with-finalizer")
+	configMap.SetFinalizers([]string{"example.com/cleanup"})
+	_, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("ns-lifecycle").
+		Create(s.T().Context(), configMap, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	s.Run("namespaces_delete with cluster returns error instead of deleting the namespace of the current cluster", func() {
+		toolResult, _ := s.CallTool("namespaces_delete", map[string]interface{}{"name": "ns-lifecycle", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to delete namespace, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+		namespace, err := kubernetes.NewForConfigOrDie(envTestRestConfig).CoreV1().Namespaces().Get(s.T().Context(), "ns-lifecycle", metav1.GetOptions{})
+		s.Require().NoError(err)
+		s.Nil(namespace.DeletionTimestamp)
+	})
+	s.Run("namespaces_delete(dryRun=true) lists the resources the deletion would delete", func() {
+		toolResult, err := s.CallTool("namespaces_delete", map[string]interface{}{"name": "ns-lifecycle", "dryRun": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "contents:")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: a-configmap-with-finalizer")
+		namespace, err := kubernetes.NewForConfigOrDie(envTestRestConfig).CoreV1().Namespaces().Get(s.T().Context(), "ns-lifecycle", metav1.GetOptions{})
+		s.Require().NoError(err)
+		s.Nil(namespace.DeletionTimestamp)
+	})
+	s.Run("namespaces_delete reports the finalizers blocking the deletion", func() {
+		toolResult, err := s.CallTool("namespaces_delete", map[string]interface{}{"name": "ns-lifecycle"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		var status map[string]any
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &status))
+		s.Equal("Terminating", status["phase"])
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: a-configmap-with-finalizer")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "ConfigMap a-configmap-with-finalizer (v1) is waiting for finalizers example.com/cleanup")
+	})
+}
+
 func TestNamespaces(t *testing.T) {
 	suite.Run(t, new(NamespacesSuite))
 }
@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type NetworkSuite struct {
+	BaseMcpSuite
+}
+
+func (s *NetworkSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = []string{"network"}
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.NetworkingV1().NetworkPolicies("ns-1").Create(s.T().Context(), &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-policy-allowing-web"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}}},
+			}},
+		},
+	}, metav1.CreateOptions{})
+}
+
+func (s *NetworkSuite) TestNetworkPoliciesList() {
+	s.InitMcpClient()
+	s.Run("networkpolicies_list(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("networkpolicies_list", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: a-policy-allowing-web")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "allow from pods (app=web) in the same namespace on all ports")
+	})
+	s.Run("networkpolicies_list with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("networkpolicies_list", map[string]interface{}{"cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to list network policies, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *NetworkSuite) TestNetworkPoliciesSimulate() {
+	s.InitMcpClient()
+	simulate := func(sourceLabels map[string]interface{}) *internalk8s.NetworkPolicySimulation {
+		toolResult, err := s.CallTool("networkpolicies_simulate", map[string]interface{}{
+			"source":      map[string]interface{}{"namespace": "ns-1", "labels": sourceLabels},
+			"destination": map[string]interface{}{"namespace": "ns-1", "labels": map[string]interface{}{"app": "api"}},
+			"port":        8080,
+		})
+		s.Require().Nilf(err, "call tool failed %v", err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		simulation := &internalk8s.NetworkPolicySimulation{}
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), simulation))
+		return simulation
+	}
+	s.Run("networkpolicies_simulate allows the traffic from web", func() {
+		simulation := simulate(map[string]interface{}{"app": "web"})
+		s.True(simulation.Allowed)
+		s.Equal([]string{"a-policy-allowing-web"}, simulation.Ingress.AllowedBy)
+	})
+	s.Run("networkpolicies_simulate denies the traffic from other pods", func() {
+		simulation := simulate(map[string]interface{}{"app": "batch"})
+		s.False(simulation.Allowed)
+		s.Contains(simulation.Explanation, "Traffic denied")
+	})
+}
+
+func (s *NetworkSuite) TestServicesDescribe() {
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.CoreV1().Services("ns-1").Create(s.T().Context(), &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-service-without-pods"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "missing"},
+			Ports:    []corev1.ServicePort{{Port: 80}},
+		},
+	}, metav1.CreateOptions{})
+	s.InitMcpClient()
+	s.Run("services_describe reports the selector matching no pods", func() {
+		toolResult, err := s.CallTool("services_describe", map[string]interface{}{"namespace": "ns-1", "name": "a-service-without-pods"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "matchingPods: 0")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "the selector app=missing matches no pods in namespace ns-1")
+	})
+	s.Run("services_describe with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("services_describe", map[string]interface{}{"namespace": "ns-1", "name": "a-service-without-pods", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to describe service, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("services_describe(name=missing)", func() {
+		toolResult, _ := s.CallTool("services_describe", map[string]interface{}{"namespace": "ns-1", "name": "missing"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to describe service: services \"missing\" not found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *NetworkSuite) TestIngresses() {
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.NetworkingV1().Ingresses("ns-1").Create(s.T().Context(), &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "an-ingress"},
+		Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+			Host: "app.example.com",
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{
+				Path:     "/api",
+				PathType: ptr.To(networkingv1.PathTypePrefix),
+				Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+					Name: "a-service-without-pods", Port: networkingv1.ServiceBackendPort{Number: 80},
+				}},
+			}}}},
+		}}},
+	}, metav1.CreateOptions{})
+	s.InitMcpClient()
+	s.Run("ingresses_list(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("ingresses_list", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "app.example.com/api (Prefix) -> a-service-without-pods:80")
+	})
+	s.Run("routes_resolve returns the matching ingress and its backend", func() {
+		toolResult, err := s.CallTool("routes_resolve", map[string]interface{}{"host": "app.example.com", "path": "/api/users"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: an-ingress")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "service: ns-1/a-service-without-pods")
+	})
+	s.Run("routes_resolve with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("routes_resolve", map[string]interface{}{"host": "shop.example.com", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to resolve routes, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("routes_resolve(host=other.example.com)", func() {
+		toolResult, err := s.CallTool("routes_resolve", map[string]interface{}{"host": "other.example.com"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal("No ingresses or http routes match the host other.example.com and path /", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestNetwork(t *testing.T) {
+	suite.Run(t, new(NetworkSuite))
+}
@@ -0,0 +1,275 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func TestNodesCordon(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().Nodes().Create(t.Context(), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "a-node-to-cordon"}}, metav1.CreateOptions{})
+		t.Run("nodes_cordon marks the node as unschedulable", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_cordon", map[string]interface{}{"name": "a-node-to-cordon"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+				return
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Node a-node-to-cordon cordoned successfully" {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			node, _ := kc.CoreV1().Nodes().Get(t.Context(), "a-node-to-cordon", metav1.GetOptions{})
+			if !node.Spec.Unschedulable {
+				t.Fatalf("node was not cordoned")
+				return
+			}
+		})
+		t.Run("nodes_cordon on a cordoned node makes no changes", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_cordon", map[string]interface{}{"name": "a-node-to-cordon"})
+			if toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "Node a-node-to-cordon is already cordoned" {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		t.Run("nodes_uncordon marks the node as schedulable", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_uncordon", map[string]interface{}{"name": "a-node-to-cordon"})
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			node, _ := kc.CoreV1().Nodes().Get(t.Context(), "a-node-to-cordon", metav1.GetOptions{})
+			if node.Spec.Unschedulable {
+				t.Fatalf("node was not uncordoned")
+				return
+			}
+		})
+		t.Run("nodes_cordon with cluster returns error without cordoning the node of the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_cordon", map[string]interface{}{"name": "a-node-to-cordon", "cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to cordon node, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			node, _ := kc.CoreV1().Nodes().Get(t.Context(), "a-node-to-cordon", metav1.GetOptions{})
+			if node.Spec.Unschedulable {
+				t.Fatalf("node of the current cluster should not be cordoned")
+				return
+			}
+		})
+	})
+}
+
+func TestNodesHealth(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		node, _ := kc.CoreV1().Nodes().Create(t.Context(), &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "a-node-under-pressure",
+			Labels: map[string]string{"node-role.kubernetes.io/worker": ""},
+		}}, metav1.CreateOptions{})
+		node.Status = corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi"), corev1.ResourcePods: resource.MustParse("110")},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue, Reason: "KubeletReady"},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, Reason: "KubeletHasInsufficientMemory"},
+			},
+			NodeInfo: corev1.NodeSystemInfo{KubeletVersion: "v1.31.0", ContainerRuntimeVersion: "containerd://1.7.0"},
+		}
+		_, _ = kc.CoreV1().Nodes().UpdateStatus(t.Context(), node, metav1.UpdateOptions{})
+		toolResult, err := c.callTool("nodes_health", map[string]interface{}{"name": "a-node-under-pressure"})
+		t.Run("nodes_health returns the node health", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+		})
+		var health kubernetes.NodesHealth
+		err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &health)
+		t.Run("nodes_health flags the pressure conditions", func(t *testing.T) {
+			if err != nil || health.Total != 1 || health.Pressure != 1 || health.Flagged != 1 {
+				t.Fatalf("unexpected health %v %v", health, err)
+			}
+			if health.Nodes[0].Flags[0] != "MemoryPressure" || health.Nodes[0].Roles[0] != "worker" || health.Nodes[0].KubeletVersion != "v1.31.0" {
+				t.Fatalf("unexpected node health %v", health.Nodes[0])
+			}
+		})
+		t.Run("nodes_health reports the allocation", func(t *testing.T) {
+			if len(health.Nodes) == 0 || len(health.Nodes[0].Allocation) != 3 || health.Nodes[0].Allocation[0].Allocatable != "2" {
+				t.Fatalf("unexpected allocation %v", health.Nodes)
+			}
+		})
+		t.Run("nodes_health with a missing node returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_health", map[string]interface{}{"name": "a-missing-node"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to get nodes health: node a-missing-node not found" {
+				t.Fatalf("unexpected result %v", toolResult.Content)
+			}
+		})
+	})
+}
+
+func TestNodesDrain(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().Nodes().Create(t.Context(), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "a-node-to-drain"}}, metav1.CreateOptions{})
+		pod := func(name string, owner *metav1.OwnerReference) *corev1.Pod {
+			p := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       corev1.PodSpec{NodeName: "a-node-to-drain", Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+			}
+			if owner != nil {
+				p.OwnerReferences = []metav1.OwnerReference{*owner}
+			}
+			return p
+		}
+		rs, _ := kc.AppsV1().ReplicaSets("default").Create(t.Context(), &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-replicaset-to-drain"},
+			Spec: appsv1.ReplicaSetSpec{
+				Replicas: ptr.To(int32(0)),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "drain"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "drain"}},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+				},
+			},
+		}, metav1.CreateOptions{})
+		_, _ = kc.CoreV1().Pods("default").Create(t.Context(), pod("a-managed-pod-to-drain", metav1.NewControllerRef(rs, appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))), metav1.CreateOptions{})
+		_, _ = kc.CoreV1().Pods("default").Create(t.Context(), pod("an-unmanaged-pod-to-drain", nil), metav1.CreateOptions{})
+		t.Run("nodes_drain with cluster returns error without draining the node of the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_drain", map[string]interface{}{"name": "a-node-to-drain", "force": true, "cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to drain node, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			node, _ := kc.CoreV1().Nodes().Get(t.Context(), "a-node-to-drain", metav1.GetOptions{})
+			if node.Spec.Unschedulable {
+				t.Fatalf("node of the current cluster should not be cordoned")
+				return
+			}
+		})
+		t.Run("nodes_drain with unmanaged pods returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_drain", map[string]interface{}{"name": "a-node-to-drain"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "default/an-unmanaged-pod-to-drain (not managed by a controller") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			node, _ := kc.CoreV1().Nodes().Get(t.Context(), "a-node-to-drain", metav1.GetOptions{})
+			if node.Spec.Unschedulable {
+				t.Fatalf("node should not be cordoned")
+				return
+			}
+		})
+		drained, err := c.callTool("nodes_drain", map[string]interface{}{"name": "a-node-to-drain", "force": true})
+		t.Run("nodes_drain cordons the node and evicts the pods", func(t *testing.T) {
+			if err != nil || drained.IsError {
+				t.Fatalf("call tool failed %v %v", err, drained.Content)
+				return
+			}
+			var decoded kubernetes.NodeDrain
+			if err = yaml.Unmarshal([]byte(drained.Content[0].(mcp.TextContent).Text), &decoded); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+				return
+			}
+			if !decoded.Cordoned || len(decoded.Evicted) != 2 || len(decoded.Failed) != 0 {
+				t.Fatalf("unexpected drain result %v", decoded)
+				return
+			}
+			node, _ := kc.CoreV1().Nodes().Get(t.Context(), "a-node-to-drain", metav1.GetOptions{})
+			if !node.Spec.Unschedulable {
+				t.Fatalf("node was not cordoned")
+				return
+			}
+		})
+	})
+}
+
+func TestNodesTaint(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().Nodes().Create(t.Context(), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "a-node-to-taint"}}, metav1.CreateOptions{})
+		_, _ = kc.CoreV1().Pods("default").Create(t.Context(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-pod-in-a-tainted-node"},
+			Spec:       corev1.PodSpec{NodeName: "a-node-to-taint", Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+		}, metav1.CreateOptions{})
+		noExecute := map[string]interface{}{"key": "maintenance", "effect": "NoExecute"}
+		t.Run("nodes_taint with cluster returns error without tainting the node of the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_taint", map[string]interface{}{"name": "a-node-to-taint", "add": []interface{}{noExecute}, "cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to taint node, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			node, _ := kc.CoreV1().Nodes().Get(t.Context(), "a-node-to-taint", metav1.GetOptions{})
+			if len(node.Spec.Taints) > 0 {
+				t.Fatalf("node of the current cluster should not be tainted, got %v", node.Spec.Taints)
+				return
+			}
+		})
+		t.Run("nodes_taint with invalid effect returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_taint", map[string]interface{}{
+				"name": "a-node-to-taint", "add": []interface{}{map[string]interface{}{"key": "maintenance", "effect": "Never"}},
+			})
+			if !toolResult.IsError || !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, `invalid taint effect "Never"`) {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		t.Run("nodes_taint with dryRun reports the affected pods without tainting the node", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_taint", map[string]interface{}{"name": "a-node-to-taint", "add": []interface{}{noExecute}, "dryRun": true})
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			var decoded kubernetes.NodeTaints
+			if err := yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+				return
+			}
+			if len(decoded.Affected) != 1 || decoded.Affected[0].Name != "a-pod-in-a-tainted-node" || decoded.Affected[0].Eviction != "immediately" {
+				t.Fatalf("unexpected affected pods %v", decoded.Affected)
+				return
+			}
+			node, _ := kc.CoreV1().Nodes().Get(t.Context(), "a-node-to-taint", metav1.GetOptions{})
+			if len(node.Spec.Taints) != 0 {
+				t.Fatalf("node should not be tainted, got %v", node.Spec.Taints)
+				return
+			}
+		})
+		t.Run("nodes_taint adds and removes the taints", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_taint", map[string]interface{}{"name": "a-node-to-taint", "add": []interface{}{noExecute}})
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			node, _ := kc.CoreV1().Nodes().Get(t.Context(), "a-node-to-taint", metav1.GetOptions{})
+			if len(node.Spec.Taints) != 1 || node.Spec.Taints[0].Key != "maintenance" {
+				t.Fatalf("node was not tainted, got %v", node.Spec.Taints)
+				return
+			}
+			toolResult, _ = c.callTool("nodes_taint", map[string]interface{}{"name": "a-node-to-taint", "remove": []interface{}{map[string]interface{}{"key": "maintenance"}}})
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			node, _ = kc.CoreV1().Nodes().Get(t.Context(), "a-node-to-taint", metav1.GetOptions{})
+			if len(node.Spec.Taints) != 0 {
+				t.Fatalf("node taint was not removed, got %v", node.Spec.Taints)
+				return
+			}
+		})
+	})
+}
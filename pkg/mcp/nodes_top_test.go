@@ -0,0 +1,76 @@
+package mcp
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestNodesTopMetricsAvailable(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			// Request Performed by DiscoveryClient to Kube API (Get API Groups legacy -core-)
+			if req.URL.Path == "/api" {
+				_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["metrics.k8s.io/v1beta1"],"serverAddressByClientCIDRs":[{"clientCIDR":"0.0.0.0/0"}]}`))
+				return
+			}
+			// Request Performed by DiscoveryClient to Kube API (Get API Groups)
+			if req.URL.Path == "/apis" {
+				_, _ = w.Write([]byte(`{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`))
+				return
+			}
+			// Request Performed by DiscoveryClient to Kube API (Get API Resources)
+			if req.URL.Path == "/apis/metrics.k8s.io/v1beta1" {
+				_, _ = w.Write([]byte(`{"kind":"APIResourceList","apiVersion":"v1","groupVersion":"metrics.k8s.io/v1beta1","resources":[{"name":"nodes","singularName":"","namespaced":false,"kind":"NodeMetrics","verbs":["get","list"]}]}`))
+				return
+			}
+			// Node Metrics
+			if req.URL.Path == "/apis/metrics.k8s.io/v1beta1/nodes" {
+				_, _ = w.Write([]byte(`{"kind":"NodeMetricsList","apiVersion":"metrics.k8s.io/v1beta1","items":[` +
+					`{"metadata":{"name":"node-1"},"usage":{"cpu":"500m","memory":"1Gi"}},` +
+					`{"metadata":{"name":"node-2"},"usage":{"cpu":"1","memory":"3Gi"}}` +
+					`]}`))
+				return
+			}
+			// Nodes
+			if req.URL.Path == "/api/v1/nodes" {
+				_, _ = w.Write([]byte(`{"kind":"NodeList","apiVersion":"v1","items":[` +
+					`{"metadata":{"name":"node-1"},"status":{"allocatable":{"cpu":"2","memory":"4Gi"}}},` +
+					`{"metadata":{"name":"node-2"},"status":{"allocatable":{"cpu":"2","memory":"4Gi"}}}` +
+					`]}`))
+				return
+			}
+		}))
+		nodesTop, err := c.callTool("nodes_top", map[string]interface{}{"sort_by": "cpu"})
+		t.Run("nodes_top returns node metrics with allocatable percentages", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			textContent := nodesTop.Content[0].(mcp.TextContent).Text
+			if nodesTop.IsError {
+				t.Fatalf("call tool failed %s", textContent)
+			}
+			expectedHeaders := regexp.MustCompile(`(?m)^\s*NAME\s+CPU\(cores\)\s+CPU\(%\)\s+MEMORY\(bytes\)\s+MEMORY\(%\)`)
+			if !expectedHeaders.MatchString(textContent) {
+				t.Errorf("Expected headers '%s' not found in output:\n%s", expectedHeaders.String(), textContent)
+			}
+			expectedRows := regexp.MustCompile(`(?s)node-2\s+1000m\s+50%\s+3072Mi\s+75%.*node-1\s+500m\s+25%\s+1024Mi\s+25%`)
+			if !expectedRows.MatchString(textContent) {
+				t.Errorf("Expected rows sorted by cpu '%s' not found in output:\n%s", expectedRows.String(), textContent)
+			}
+		})
+		t.Run("nodes_top with cluster returns error instead of reporting the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_top", map[string]interface{}{"cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to get nodes top, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
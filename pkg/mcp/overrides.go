@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"strings"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// overrideTool applies the configured override (if any) to the tool.
+// Overrides are applied once the tool is deemed applicable, so that they can't expose a mutating tool in read-only mode
+// or a destructive tool with destructive tools disabled, and the hint overrides don't bypass the read-only and confirmation checks.
+func (c *Configuration) overrideTool(tool api.ServerTool) api.ServerTool {
+	override, ok := c.ToolOverrides[tool.Tool.Name]
+	if !ok {
+		return tool
+	}
+	if override.Name != "" {
+		tool.Tool.Name = override.Name
+	}
+	if override.Description != "" {
+		tool.Tool.Description = override.Description
+	}
+	if override.AppendDescription != "" {
+		tool.Tool.Description = strings.TrimSpace(tool.Tool.Description + "\n" + override.AppendDescription)
+	}
+	if override.Title != "" {
+		tool.Tool.Annotations.Title = override.Title
+	}
+	// The hint overrides are only advertised, the safety checks keep relying on the built-in hints
+	advertised := tool.Tool.Annotations
+	if override.ReadOnlyHint != nil {
+		advertised.ReadOnlyHint = override.ReadOnlyHint
+	}
+	if override.DestructiveHint != nil {
+		advertised.DestructiveHint = override.DestructiveHint
+	}
+	if override.IdempotentHint != nil {
+		advertised.IdempotentHint = override.IdempotentHint
+	}
+	if override.OpenWorldHint != nil {
+		advertised.OpenWorldHint = override.OpenWorldHint
+	}
+	tool.AdvertisedAnnotations = &advertised
+	return tool
+}
@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func TestResourcesOwners(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		root, _ := kc.CoreV1().ConfigMaps("default").Create(c.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "owners-root"},
+		}, metav1.CreateOptions{})
+		child, _ := kc.CoreV1().ConfigMaps("default").Create(c.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "owners-child", OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: root.Name, UID: root.UID, Controller: ptr.To(true)},
+			}},
+		}, metav1.CreateOptions{})
+		_, _ = kc.CoreV1().ConfigMaps("default").Create(c.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "owners-grandchild", OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: child.Name, UID: child.UID, Controller: ptr.To(true)},
+			}},
+		}, metav1.CreateOptions{})
+		t.Run("resources_owners with cluster returns error instead of using the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_owners", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap", "cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to get resource owners, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_owners with missing name returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_owners", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to get resource owners, missing argument name" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		toolResult, err := c.callTool("resources_owners", map[string]interface{}{
+			"apiVersion": "v1", "kind": "ConfigMap", "namespace": "default", "name": "owners-child",
+		})
+		t.Run("resources_owners returns the ownership tree", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+		})
+		var tree kubernetes.OwnerTree
+		if err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &tree); err != nil {
+			t.Fatalf("invalid tool result content %v", err)
+		}
+		t.Run("resources_owners returns the owner chain and controller", func(t *testing.T) {
+			if len(tree.Owners) != 1 || tree.Owners[0].Name != "owners-root" || tree.Controller.Name != "owners-root" {
+				t.Fatalf("invalid owners, got %v (controller %v)", tree.Owners, tree.Controller)
+			}
+		})
+		t.Run("resources_owners returns the dependents", func(t *testing.T) {
+			if len(tree.Dependents) != 1 || tree.Dependents[0].Name != "owners-grandchild" || !tree.Dependents[0].Controller {
+				t.Fatalf("invalid dependents, got %v", tree.Dependents)
+			}
+		})
+		t.Run("resources_owners with direction up skips the dependents", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_owners", map[string]interface{}{
+				"apiVersion": "v1", "kind": "ConfigMap", "namespace": "default", "name": "owners-child", "direction": "up",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			var up kubernetes.OwnerTree
+			if err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &up); err != nil || len(up.Dependents) != 0 || len(up.Owners) != 1 {
+				t.Fatalf("invalid tree, got %v (%v)", up, err)
+			}
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/plugin"
+)
+
+// ServePlugin serves the provided toolset over stdio as an external toolset plugin.
+// It is meant to be called from the main function of third-party plugin executables,
+// which are then configured in the plugins section of the kubernetes-mcp-server configuration.
+func ServePlugin(toolset api.Toolset, staticConfig *config.StaticConfig) error {
+	if os.Getenv(plugin.MagicCookieKey) != plugin.MagicCookieValue {
+		return fmt.Errorf("%s is a kubernetes-mcp-server toolset plugin and is not meant to be executed directly", toolset.GetName())
+	}
+	if staticConfig == nil {
+		staticConfig = config.Default()
+	}
+	s, err := newServer(
+		Configuration{StaticConfig: staticConfig, toolsets: []api.Toolset{toolset}},
+		plugin.NewHandshakeHooks(toolset),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize plugin %s: %w", toolset.GetName(), err)
+	}
+	defer s.Close()
+	return s.ServeStdio(context.Background())
+}
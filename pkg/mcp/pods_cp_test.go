@@ -0,0 +1,46 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type PodsCopySuite struct {
+	BaseMcpSuite
+}
+
+func (s *PodsCopySuite) TestPodsCopyFrom() {
+	s.InitMcpClient()
+	s.Run("pods_cp_from with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("pods_cp_from", map[string]interface{}{"name": "a-pod", "path": "/etc/hostname", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to copy from pod, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *PodsCopySuite) TestPodsCopyFromReadOnly() {
+	s.Cfg.ReadOnly = true
+	s.InitMcpClient()
+	s.Run("pods_cp_from is not available in read-only mode (it executes commands in the container)", func() {
+		tools, err := s.ListTools(s.T().Context(), mcp.ListToolsRequest{})
+		s.Require().NoError(err, "call ListTools failed")
+		for _, tool := range tools.Tools {
+			s.NotEqual("pods_cp_from", tool.Name)
+		}
+	})
+}
+
+func (s *PodsCopySuite) TestPodsCopyTo() {
+	s.InitMcpClient()
+	s.Run("pods_cp_to with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("pods_cp_to", map[string]interface{}{"name": "a-pod", "path": "/tmp/a-file", "content": "a-content", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to copy to pod, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestPodsCopy(t *testing.T) {
+	suite.Run(t, new(PodsCopySuite))
+}
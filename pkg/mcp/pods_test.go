@@ -785,12 +785,76 @@ func TestPodsLog(t *testing.T) {
 				t.Fatalf("call tool should fail")
 				return
 			}
-			expectedErrorMsg := "failed to parse tail parameter: expected integer"
+			expectedErrorMsg := "failed to get pod log, invalid argument tail: expected integer, got string"
 			if errMsg := podsInvalidTailLines.Content[0].(mcp.TextContent).Text; !strings.Contains(errMsg, expectedErrorMsg) {
 				t.Fatalf("unexpected error message, expected to contain '%s', got '%s'", expectedErrorMsg, errMsg)
 				return
 			}
 		})
+		podsAllContainersLog, err := c.callTool("pods_log", map[string]interface{}{
+			"namespace":     "ns-1",
+			"name":          "a-pod-in-ns-1",
+			"allContainers": true,
+		})
+		t.Run("pods_log with allContainers=true returns every container log", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			if podsAllContainersLog.IsError {
+				t.Fatalf("call tool failed %v", podsAllContainersLog.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		podsSinceLog, err := c.callTool("pods_log", map[string]interface{}{
+			"namespace":    "ns-1",
+			"name":         "a-pod-in-ns-1",
+			"sinceSeconds": 3600,
+			"timestamps":   true,
+		})
+		t.Run("pods_log with sinceSeconds and timestamps returns pod log", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			if podsSinceLog.IsError {
+				t.Fatalf("call tool failed %v", podsSinceLog.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		podsInvalidSinceTime, _ := c.callTool("pods_log", map[string]interface{}{
+			"namespace": "ns-1",
+			"name":      "a-pod-in-ns-1",
+			"sinceTime": "yesterday",
+		})
+		t.Run("pods_log with invalid sinceTime returns error", func(t *testing.T) {
+			if !podsInvalidSinceTime.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			expectedErrorMsg := "failed to get pod log, invalid sinceTime \"yesterday\""
+			if errMsg := podsInvalidSinceTime.Content[0].(mcp.TextContent).Text; !strings.Contains(errMsg, expectedErrorMsg) {
+				t.Fatalf("unexpected error message, expected to contain '%s', got '%s'", expectedErrorMsg, errMsg)
+				return
+			}
+		})
+		podsSinceConflict, _ := c.callTool("pods_log", map[string]interface{}{
+			"namespace":    "ns-1",
+			"name":         "a-pod-in-ns-1",
+			"sinceSeconds": 60,
+			"sinceTime":    "2025-01-02T15:04:05Z",
+		})
+		t.Run("pods_log with sinceSeconds and sinceTime returns error", func(t *testing.T) {
+			if !podsSinceConflict.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			expectedErrorMsg := "failed to get pod log, sinceSeconds and sinceTime are mutually exclusive"
+			if errMsg := podsSinceConflict.Content[0].(mcp.TextContent).Text; !strings.Contains(errMsg, expectedErrorMsg) {
+				t.Fatalf("unexpected error message, expected to contain '%s', got '%s'", expectedErrorMsg, errMsg)
+				return
+			}
+		})
 	})
 }
 
@@ -1126,3 +1190,135 @@ func TestPodsListWithLabelSelector(t *testing.T) {
 		})
 	})
 }
+
+func TestPodsListWithFieldSelectorLimitAndSort(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		for _, name := range []string{"paged-pod-b", "paged-pod-a", "paged-pod-c"} {
+			_, _ = kc.CoreV1().Pods("ns-1").Create(c.ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"paged": "true"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+			}, metav1.CreateOptions{})
+		}
+		t.Run("pods_list_in_namespace with field selector returns filtered pods", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_list_in_namespace", map[string]interface{}{
+				"namespace":     "ns-1",
+				"fieldSelector": "metadata.name=paged-pod-a",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			var decoded []unstructured.Unstructured
+			if err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if len(decoded) != 1 || decoded[0].GetName() != "paged-pod-a" {
+				t.Fatalf("invalid pods, expected paged-pod-a, got %v", decoded)
+			}
+		})
+		t.Run("pods_list_in_namespace with limit returns the continue token of the next page", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_list_in_namespace", map[string]interface{}{
+				"namespace":     "ns-1",
+				"labelSelector": "paged=true",
+				"limit":         2,
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			var decoded []unstructured.Unstructured
+			if err = yaml.Unmarshal([]byte(text), &decoded); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if len(decoded) != 2 {
+				t.Fatalf("invalid pods count, expected 2, got %v", len(decoded))
+			}
+			matches := regexp.MustCompile(`continue: (\S+)`).FindStringSubmatch(text)
+			if len(matches) != 2 {
+				t.Fatalf("expected continue token in output, got %s", text)
+			}
+			toolResult, err = c.callTool("pods_list_in_namespace", map[string]interface{}{
+				"namespace":     "ns-1",
+				"labelSelector": "paged=true",
+				"limit":         2,
+				"continue":      matches[1],
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			text = toolResult.Content[0].(mcp.TextContent).Text
+			if err = yaml.Unmarshal([]byte(text), &decoded); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if len(decoded) != 1 || strings.Contains(text, "truncated") {
+				t.Fatalf("expected last page with 1 pod, got %s", text)
+			}
+		})
+		t.Run("pods_list_in_namespace with sortBy name returns sorted pods", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_list_in_namespace", map[string]interface{}{
+				"namespace":     "ns-1",
+				"labelSelector": "paged=true",
+				"sortBy":        "name",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			var decoded []unstructured.Unstructured
+			if err = yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &decoded); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+			if len(decoded) != 3 || decoded[0].GetName() != "paged-pod-a" || decoded[2].GetName() != "paged-pod-c" {
+				t.Fatalf("invalid pods order, got %v", decoded)
+			}
+		})
+		t.Run("pods_list_in_namespace with unsupported sortBy returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_list_in_namespace", map[string]interface{}{
+				"namespace": "ns-1",
+				"sortBy":    "size",
+			})
+			if !toolResult.IsError || !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "sortBy") {
+				t.Fatalf("expected sortBy error, got %v", toolResult.Content)
+			}
+		})
+	})
+}
+
+func TestPodsWhyPending(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().Pods("ns-1").Create(c.ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-pending-pod"},
+			Spec: corev1.PodSpec{
+				NodeSelector: map[string]string{"disk": "ssd"},
+				Containers:   []corev1.Container{{Name: "nginx", Image: "nginx"}},
+			},
+		}, metav1.CreateOptions{})
+		t.Run("pods_why_pending with cluster returns error instead of using the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_why_pending", map[string]interface{}{"namespace": "ns-1", "name": "not-found", "cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to analyze pod scheduling, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_why_pending with not found name returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_why_pending", map[string]interface{}{"namespace": "ns-1", "name": "not-found"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to analyze the scheduling of pod not-found in namespace ns-1: pods \"not-found\" not found" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_why_pending explains the pending pod", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_why_pending", map[string]interface{}{"namespace": "ns-1", "name": "a-pending-pod"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "name: a-pending-pod") || !strings.Contains(text, "the cluster has no nodes") {
+				t.Fatalf("unexpected scheduling analysis %s", text)
+			}
+		})
+	})
+}
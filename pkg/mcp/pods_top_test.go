@@ -9,6 +9,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 )
 
 func TestPodsTopMetricsUnavailable(t *testing.T) {
@@ -209,6 +210,68 @@ func TestPodsTopMetricsAvailable(t *testing.T) {
 	})
 }
 
+func TestPodsTopCompareRequests(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			// Request Performed by DiscoveryClient to Kube API (Get API Groups legacy -core-)
+			if req.URL.Path == "/api" {
+				_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["metrics.k8s.io/v1beta1"],"serverAddressByClientCIDRs":[{"clientCIDR":"0.0.0.0/0"}]}`))
+				return
+			}
+			// Request Performed by DiscoveryClient to Kube API (Get API Groups)
+			if req.URL.Path == "/apis" {
+				_, _ = w.Write([]byte(`{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`))
+				return
+			}
+			// Request Performed by DiscoveryClient to Kube API (Get API Resources)
+			if req.URL.Path == "/apis/metrics.k8s.io/v1beta1" {
+				_, _ = w.Write([]byte(`{"kind":"APIResourceList","apiVersion":"v1","groupVersion":"metrics.k8s.io/v1beta1","resources":[{"name":"pods","singularName":"","namespaced":true,"kind":"PodMetrics","verbs":["get","list"]}]}`))
+				return
+			}
+			// Pod Metrics from configured namespace
+			if req.URL.Path == "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods" {
+				_, _ = w.Write([]byte(`{"kind":"PodMetricsList","apiVersion":"metrics.k8s.io/v1beta1","items":[` +
+					`{"metadata":{"name":"pod-1","namespace":"default"},"containers":[{"name":"container-1","usage":{"cpu":"10m","memory":"20Mi"}},{"name":"container-2","usage":{"cpu":"300m","memory":"40Mi"}}]}` +
+					`]}`))
+				return
+			}
+			// Pods from configured namespace
+			if req.URL.Path == "/api/v1/namespaces/default/pods" {
+				_, _ = w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[` +
+					`{"metadata":{"name":"pod-1","namespace":"default"},"spec":{"containers":[` +
+					`{"name":"container-1","resources":{"requests":{"cpu":"100m","memory":"64Mi"}}},` +
+					`{"name":"container-2","resources":{"requests":{"cpu":"200m"},"limits":{"memory":"42Mi"}}}` +
+					`]}}]}`))
+				return
+			}
+		}))
+		podsTop, err := c.callTool("pods_top", map[string]interface{}{
+			"all_namespaces":   false,
+			"compare_requests": true,
+			"sort_by":          "cpu",
+		})
+		t.Run("pods_top[compare_requests=true] flags over and under provisioned containers", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			textContent := podsTop.Content[0].(mcp.TextContent).Text
+			if podsTop.IsError {
+				t.Fatalf("call tool failed %s", textContent)
+			}
+			expectedRows := regexp.MustCompile(`(?s)` +
+				`default\s+pod-1\s+container-2\s+300m\s+200m\s+<none>\s+under-provisioned\s+40Mi\s+<none>\s+42Mi\s+near-limit.*` +
+				`default\s+pod-1\s+container-1\s+10m\s+100m\s+<none>\s+over-provisioned\s+20Mi\s+64Mi\s+<none>\s+ok`)
+			if !expectedRows.MatchString(textContent) {
+				t.Errorf("Expected rows '%s' not found in output:\n%s", expectedRows.String(), textContent)
+			}
+		})
+	})
+}
+
 func TestPodsTopDenied(t *testing.T) {
 	deniedResourcesServer := test.Must(config.ReadToml([]byte(`
 		denied_resources = [ { group = "metrics.k8s.io", version = "v1beta1" } ]
@@ -249,3 +312,23 @@ func TestPodsTopDenied(t *testing.T) {
 		})
 	})
 }
+
+func TestPodsTopWindowHistoryDisabled(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		podsTop, err := c.callTool("pods_top", map[string]interface{}{"window": "15m"})
+		t.Run("pods_top with window has error", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if !podsTop.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("pods_top with window describes the disabled history", func(t *testing.T) {
+			expectedMessage := "failed to get pods top: " + kubernetes.ErrMetricsHistoryDisabled.Error()
+			if podsTop.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, podsTop.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
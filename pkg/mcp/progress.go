@@ -0,0 +1,40 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// progress sends the progress notifications of a tool call to the client that requested them with a progress token
+type progress struct {
+	server *server.MCPServer
+	token  mcp.ProgressToken
+}
+
+var _ api.Progress = (*progress)(nil)
+
+// progress returns the progress of the tool call, nil if the client didn't provide a progress token
+func (s *Server) progress(request mcp.CallToolRequest) api.Progress {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	return &progress{server: s.server, token: request.Params.Meta.ProgressToken}
+}
+
+func (p *progress) Notify(ctx context.Context, progress, total float64, message string) {
+	params := map[string]any{"progressToken": p.token, "progress": progress}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	if err := p.server.SendNotificationToClient(ctx, "notifications/progress", params); err != nil {
+		klog.V(3).Infof("failed to send progress notification: %v", err)
+	}
+}
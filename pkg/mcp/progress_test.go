@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type testProgressSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (t *testProgressSession) Initialize()       {}
+func (t *testProgressSession) Initialized() bool { return true }
+func (t *testProgressSession) SessionID() string { return "a-session" }
+func (t *testProgressSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return t.notifications
+}
+
+func TestProgress(t *testing.T) {
+	s := &Server{server: server.NewMCPServer("test", "0.0.0")}
+	t.Run("Not available without progress token", func(t *testing.T) {
+		if s.progress(mcp.CallToolRequest{}) != nil {
+			t.Errorf("expected no progress")
+		}
+	})
+	request := mcp.CallToolRequest{}
+	request.Params.Meta = &mcp.Meta{ProgressToken: "a-token"}
+	progress := s.progress(request)
+	if progress == nil {
+		t.Fatalf("expected a progress for a request with a progress token")
+	}
+	t.Run("Sends the progress notification to the client", func(t *testing.T) {
+		session := &testProgressSession{notifications: make(chan mcp.JSONRPCNotification, 1)}
+		progress.Notify(s.server.WithContext(context.Background(), session), 1, 3, "pod default/a-pod evicted")
+		notification := <-session.notifications
+		if notification.Method != "notifications/progress" {
+			t.Fatalf("unexpected notification method %s", notification.Method)
+		}
+		fields := notification.Params.AdditionalFields
+		if fields["progressToken"] != "a-token" || fields["progress"] != float64(1) || fields["total"] != float64(3) || fields["message"] != "pod default/a-pod evicted" {
+			t.Errorf("unexpected notification params %v", fields)
+		}
+	})
+}
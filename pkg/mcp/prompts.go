@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// workflowPrompt is a built-in prompt pre-wiring the sequence of tool calls of a common SRE workflow
+type workflowPrompt struct {
+	prompt mcp.Prompt
+	// tools the workflow relies on (original names), the prompt is only exposed if all of them are
+	tools []string
+	// instructions renders the workflow, tool returns the name the provided tool is exposed with
+	instructions func(arguments map[string]string, tool func(string) string) string
+}
+
+func workflowPrompts() []workflowPrompt {
+	return []workflowPrompt{
+		{
+			prompt: mcp.NewPrompt("diagnose-pod",
+				mcp.WithPromptDescription("Diagnose why a Pod is failing, crash looping, or not ready, and suggest a remediation"),
+				mcp.WithArgument("namespace", mcp.ArgumentDescription("Namespace of the Pod"), mcp.RequiredArgument()),
+				mcp.WithArgument("name", mcp.ArgumentDescription("Name of the Pod"), mcp.RequiredArgument()),
+			),
+			tools: []string{"pods_get", "pods_log", "events_list"},
+			instructions: func(arguments map[string]string, tool func(string) string) string {
+				return fmt.Sprintf(`Diagnose the Pod %[2]s in namespace %[1]s.
+
+Follow these steps:
+1. Call %[3]s with namespace %[1]s and name %[2]s. Check the phase, the conditions, and the state, restart count, and last termination reason of each container.
+2. Call %[5]s with namespace %[1]s and look for the events involving the Pod (e.g. FailedScheduling, FailedMount, BackOff, Unhealthy, OOMKilled).
+3. Call %[4]s with namespace %[1]s and name %[2]s for each failing container. If a container restarted, call it again with previous set to true to get the logs of the crashed instance.
+
+Then answer with:
+- Status: a one-line summary of the Pod health.
+- Root cause: the most likely cause, quoting the evidence (condition, event, or log line) supporting it.
+- Remediation: the concrete change to apply (manifest field, resource, or command), without applying it.`,
+					arguments["namespace"], arguments["name"], tool("pods_get"), tool("pods_log"), tool("events_list"))
+			},
+		},
+		{
+			prompt: mcp.NewPrompt("why-is-my-deployment-not-rolling-out",
+				mcp.WithPromptDescription("Find out why a Deployment rollout is stuck or not progressing"),
+				mcp.WithArgument("namespace", mcp.ArgumentDescription("Namespace of the Deployment"), mcp.RequiredArgument()),
+				mcp.WithArgument("name", mcp.ArgumentDescription("Name of the Deployment"), mcp.RequiredArgument()),
+			),
+			tools: []string{"resources_get", "resources_list", "pods_list_in_namespace", "events_list"},
+			instructions: func(arguments map[string]string, tool func(string) string) string {
+				return fmt.Sprintf(`Find out why the rollout of the Deployment %[2]s in namespace %[1]s is not progressing.
+
+Follow these steps:
+1. Call %[3]s with apiVersion apps/v1, kind Deployment, namespace %[1]s, and name %[2]s. Compare spec.replicas with status.replicas, updatedReplicas, readyReplicas, and availableReplicas, and check the Progressing and Available conditions (e.g. ProgressDeadlineExceeded). Note spec.paused, the rollout strategy, and the selector.
+2. Call %[4]s with apiVersion apps/v1, kind ReplicaSet, namespace %[1]s, and the Deployment selector as labelSelector to identify the new and the old ReplicaSets.
+3. Call %[5]s with namespace %[1]s and the Deployment selector as labelSelector to find the Pods of the new ReplicaSet that are not ready.
+4. Call %[6]s with namespace %[1]s and look for the events of the Deployment, its ReplicaSets, and its Pods (e.g. FailedCreate because of quotas, FailedScheduling, image pull errors, failing probes).
+
+Then answer with:
+- Rollout status: the revision being rolled out and how many replicas are updated and available.
+- Blocker: the reason the rollout is not progressing, quoting the evidence supporting it.
+- Next steps: the fix to apply, or whether the rollout should be rolled back, without applying it.`,
+					arguments["namespace"], arguments["name"], tool("resources_get"), tool("resources_list"), tool("pods_list_in_namespace"), tool("events_list"))
+			},
+		},
+		{
+			prompt: mcp.NewPrompt("capacity-review",
+				mcp.WithPromptDescription("Review the capacity of the cluster nodes against the resource requests and the actual usage of the workloads"),
+				mcp.WithArgument("namespace", mcp.ArgumentDescription("Optional namespace to focus the review on. If not provided, the whole cluster is reviewed")),
+			),
+			tools: []string{"resources_list", "pods_top"},
+			instructions: func(arguments map[string]string, tool func(string) string) string {
+				scope, top := "the whole cluster", "all_namespaces set to true"
+				if namespace := arguments["namespace"]; namespace != "" {
+					scope, top = "namespace "+namespace, "namespace "+namespace
+				}
+				return fmt.Sprintf(`Review the capacity of %[1]s.
+
+Follow these steps:
+1. Call %[3]s with apiVersion v1 and kind Node. For each node, note the allocatable CPU and memory, the conditions (MemoryPressure, DiskPressure, PIDPressure), and whether it is schedulable.
+2. Call %[3]s with apiVersion v1 and kind Pod (restricted to %[1]s) and sum the CPU and memory requests and limits of the running Pods.
+3. Call %[4]s with %[2]s to get the actual CPU and memory usage of the Pods.
+
+Then answer with:
+- Capacity: a table with the allocatable, requested, and used CPU and memory (absolute and percentage).
+- Hotspots: the nodes under pressure and the Pods whose usage is far above (risk of eviction or throttling) or far below (over-provisioned) their requests.
+- Recommendations: the request and limit adjustments, or the additional capacity needed, prioritized by impact.`,
+					scope, top, tool("resources_list"), tool("pods_top"))
+			},
+		},
+	}
+}
+
+// prompts returns the workflow prompts whose tools are all exposed, toolNames maps the original name of the exposed
+// tools to the name they're exposed with
+func prompts(toolNames map[string]string) []server.ServerPrompt {
+	serverPrompts := make([]server.ServerPrompt, 0)
+	for _, workflow := range workflowPrompts() {
+		applicable := true
+		for _, tool := range workflow.tools {
+			if _, ok := toolNames[tool]; !ok {
+				applicable = false
+				break
+			}
+		}
+		if !applicable {
+			continue
+		}
+		serverPrompts = append(serverPrompts, server.ServerPrompt{Prompt: workflow.prompt, Handler: workflowPromptHandler(workflow, toolNames)})
+	}
+	return serverPrompts
+}
+
+func workflowPromptHandler(workflow workflowPrompt, toolNames map[string]string) server.PromptHandlerFunc {
+	return func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		arguments := make(map[string]string, len(request.Params.Arguments))
+		for name, value := range request.Params.Arguments {
+			arguments[name] = strings.TrimSpace(value)
+		}
+		for _, argument := range workflow.prompt.Arguments {
+			if argument.Required && arguments[argument.Name] == "" {
+				return nil, fmt.Errorf("missing argument %s", argument.Name)
+			}
+		}
+		instructions := workflow.instructions(arguments, func(tool string) string { return toolNames[tool] })
+		return mcp.NewGetPromptResult(workflow.prompt.Description, []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(instructions)),
+		}), nil
+	}
+}
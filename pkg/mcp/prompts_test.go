@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type PromptsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *PromptsSuite) TestListPrompts() {
+	s.InitMcpClient()
+	prompts, err := s.ListPrompts(s.T().Context(), mcp.ListPromptsRequest{})
+	s.Run("ListPrompts returns prompts", func() {
+		s.Nilf(err, "call ListPrompts failed %v", err)
+		s.Len(prompts.Prompts, 3)
+	})
+	s.Run("ListPrompts returns the SRE workflow prompts", func() {
+		names := make([]string, 0, len(prompts.Prompts))
+		for _, prompt := range prompts.Prompts {
+			names = append(names, prompt.Name)
+		}
+		s.ElementsMatch([]string{"diagnose-pod", "why-is-my-deployment-not-rolling-out", "capacity-review"}, names)
+	})
+}
+
+func (s *PromptsSuite) TestListPromptsWithDisabledTools() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		disabled_tools = [ "pods_top" ]
+	`), s.Cfg), "Expected to parse toml config")
+	s.InitMcpClient()
+	prompts, err := s.ListPrompts(s.T().Context(), mcp.ListPromptsRequest{})
+	s.Run("ListPrompts omits prompts relying on disabled tools", func() {
+		s.Nilf(err, "call ListPrompts failed %v", err)
+		for _, prompt := range prompts.Prompts {
+			s.NotEqual("capacity-review", prompt.Name)
+		}
+	})
+}
+
+func (s *PromptsSuite) TestGetPrompt() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		[tool_overrides.pods_log]
+		name = "read_pod_logs"
+	`), s.Cfg), "Expected to parse toml config")
+	s.InitMcpClient()
+	s.Run("GetPrompt(diagnose-pod) with missing name returns error", func() {
+		_, err := s.GetPrompt("diagnose-pod", map[string]string{"namespace": "default"})
+		s.ErrorContains(err, "missing argument name")
+	})
+	s.Run("GetPrompt(diagnose-pod)", func() {
+		result, err := s.GetPrompt("diagnose-pod", map[string]string{"namespace": "default", "name": "a-pod"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call GetPrompt failed %v", err)
+			s.Len(result.Messages, 1)
+		})
+		text := result.Messages[0].Content.(mcp.TextContent).Text
+		s.Run("renders the arguments", func() {
+			s.Contains(text, "Diagnose the Pod a-pod in namespace default.")
+		})
+		s.Run("refers to the tools by their exposed name", func() {
+			s.Contains(text, "Call pods_get with namespace default and name a-pod.")
+			s.Contains(text, "Call read_pod_logs with namespace default and name a-pod")
+		})
+	})
+	s.Run("GetPrompt(capacity-review) without namespace reviews the whole cluster", func() {
+		result, err := s.GetPrompt("capacity-review", map[string]string{})
+		s.Nilf(err, "call GetPrompt failed %v", err)
+		s.Contains(result.Messages[0].Content.(mcp.TextContent).Text, "Call pods_top with all_namespaces set to true")
+	})
+}
+
+func TestPrompts(t *testing.T) {
+	suite.Run(t, new(PromptsSuite))
+}
@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/yaml"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type PromQLSuite struct {
+	BaseMcpSuite
+	prometheus *httptest.Server
+}
+
+func (s *PromQLSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = []string{"metrics"}
+	s.prometheus = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/query":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"namespace":"ns-1"},"value":[1748772000,"0.5"]},{"metric":{"namespace":"ns-2"},"value":[1748772000,"2"]}]}}`))
+		case "/api/v1/query_range":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"metric":{"namespace":"ns-1"},"values":[[1748772000,"1"],[1748772060,"2"],[1748772120,"3"],[1748772180,"4"]]}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	s.Cfg.PrometheusURL = s.prometheus.URL
+}
+
+func (s *PromQLSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	s.prometheus.Close()
+}
+
+func (s *PromQLSuite) TestPromQLQuery() {
+	s.InitMcpClient()
+	s.Run("promql_query(query, maxSeries=1)", func() {
+		toolResult, err := s.CallTool("promql_query", map[string]interface{}{"query": "sum by (namespace) (up)", "maxSeries": 1})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		var ret internalk8s.PromQLResult
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &ret))
+		s.Run("returns the highest series", func() {
+			s.Equal(2, ret.TotalSeries)
+			s.True(ret.Truncated)
+			s.Require().Len(ret.Series, 1)
+			s.Equal("ns-2", ret.Series[0].Metric["namespace"])
+			s.Equal("2", ret.Series[0].Value.Value)
+		})
+	})
+	s.Run("promql_query() without query", func() {
+		toolResult, _ := s.CallTool("promql_query", map[string]interface{}{})
+		s.True(toolResult.IsError, "call tool should fail")
+	})
+}
+
+func (s *PromQLSuite) TestPromQLRange() {
+	s.InitMcpClient()
+	s.Run("promql_range(query, start, maxPoints=2)", func() {
+		toolResult, err := s.CallTool("promql_range", map[string]interface{}{"query": "up", "start": "-4m", "step": "1m", "maxPoints": 2})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		var ret internalk8s.PromQLResult
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &ret))
+		s.Run("downsamples the points", func() {
+			s.Equal("1m0s", ret.Step)
+			s.True(ret.Downsampled)
+			s.Require().Len(ret.Series, 1)
+			s.Len(ret.Series[0].Values, 2)
+			s.Equal("4", ret.Series[0].Max)
+		})
+	})
+	s.Run("promql_range(start=invalid)", func() {
+		toolResult, _ := s.CallTool("promql_range", map[string]interface{}{"query": "up", "start": "yesterday"})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "failed to query prometheus range: invalid start")
+	})
+}
+
+func TestPromQL(t *testing.T) {
+	suite.Run(t, new(PromQLSuite))
+}
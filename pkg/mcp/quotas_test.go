@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type QuotasSuite struct {
+	BaseMcpSuite
+}
+
+func (s *QuotasSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.CoreV1().ResourceQuotas("ns-1").Create(s.T().Context(), &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-quota"},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("1Gi")}},
+	}, metav1.CreateOptions{})
+	_, _ = kc.CoreV1().LimitRanges("ns-1").Create(s.T().Context(), &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-limit-range"},
+		Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+			Type:    corev1.LimitTypeContainer,
+			Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+		}}},
+	}, metav1.CreateOptions{})
+	_, _ = kc.CoreV1().Pods("ns-1").Create(s.T().Context(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-pod-without-requests"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+	}, metav1.CreateOptions{})
+}
+
+func (s *QuotasSuite) TestQuotasOverview() {
+	s.InitMcpClient()
+	s.Run("quotas_overview(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("quotas_overview", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		text := toolResult.Content[0].(mcp.TextContent).Text
+		s.Contains(text, "name: a-quota")
+		s.Contains(text, "name: a-limit-range")
+		s.Contains(text, "name: a-pod-without-requests")
+	})
+	s.Run("quotas_overview with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("quotas_overview", map[string]interface{}{"namespace": "ns-1", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to get quotas overview, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("quotas_overview(namespace=ns-2) without quotas", func() {
+		toolResult, err := s.CallTool("quotas_overview", map[string]interface{}{"namespace": "ns-2"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "namespace: ns-2")
+		s.NotContains(toolResult.Content[0].(mcp.TextContent).Text, "a-quota")
+	})
+}
+
+func TestQuotas(t *testing.T) {
+	suite.Run(t, new(QuotasSuite))
+}
@@ -0,0 +1,53 @@
+package mcp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/ratelimit"
+)
+
+// newRateLimiter creates the rate limiter for the configured limits, nil if rate limiting is disabled
+func newRateLimiter(staticConfig *config.StaticConfig) *ratelimit.Limiter {
+	toolLimits := make(map[string]ratelimit.Limit, len(staticConfig.ToolRateLimits))
+	for tool, limit := range staticConfig.ToolRateLimits {
+		toolLimits[tool] = ratelimit.Limit{RequestsPerSecond: limit.RequestsPerSecond, Burst: limit.Burst}
+	}
+	limiter := ratelimit.New(ratelimit.Limit{
+		RequestsPerSecond: staticConfig.RateLimit.RequestsPerSecond,
+		Burst:             staticConfig.RateLimit.Burst,
+	}, toolLimits)
+	if !limiter.Enabled() {
+		return nil
+	}
+	return limiter
+}
+
+// rateLimitMiddleware rejects the tool calls exceeding the rate limit of the session
+func rateLimitMiddleware(limiter *ratelimit.Limiter) api.ToolMiddleware {
+	return func(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+		return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			if err := limiter.Allow(rateLimitSession(params), params.Tool.Tool.Name); err != nil {
+				return api.NewToolCallResult("", err), nil
+			}
+			return next(params)
+		}
+	}
+}
+
+// rateLimitSession returns the key identifying the caller, the MCP session or the bearer token for stateless transports
+func rateLimitSession(params api.ToolHandlerParams) string {
+	if session := server.ClientSessionFromContext(params.Context); session != nil && session.SessionID() != "" {
+		return session.SessionID()
+	}
+	if authorization, ok := params.Context.Value(internalk8s.OAuthAuthorizationHeader).(string); ok && authorization != "" {
+		hash := sha256.Sum256([]byte(authorization))
+		return hex.EncodeToString(hash[:])
+	}
+	return ""
+}
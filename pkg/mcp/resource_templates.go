@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	// resourceURIScheme is the scheme of the URIs of the cluster objects exposed as MCP resources
+	resourceURIScheme = "k8s://"
+	// resourceLocalCluster is the cluster segment of the URIs of the objects of the current cluster
+	resourceLocalCluster = "local"
+	// resourceClusterScope is the namespace segment of the URIs of the cluster scoped objects
+	resourceClusterScope = "_"
+)
+
+// resourceTemplates returns the MCP resource templates exposing the cluster objects as browsable resources,
+// clients can attach live manifests as context without a tool call
+func (s *Server) resourceTemplates() []server.ServerResourceTemplate {
+	return []server.ServerResourceTemplate{{
+		Template: mcp.NewResourceTemplate(
+			resourceURIScheme+"{cluster}/{namespace}/{kind}/{name}",
+			"Kubernetes object",
+			mcp.WithTemplateDescription(fmt.Sprintf("YAML manifest of a Kubernetes object. "+
+				"cluster is %q for the current cluster or the name of an ACM managed cluster, "+
+				"namespace is %q for cluster scoped objects, "+
+				"kind is the resource type in any of the forms accepted by kubectl (e.g. Pod, deployments.apps, deployments.v1.apps)",
+				resourceLocalCluster, resourceClusterScope)),
+			mcp.WithTemplateMIMEType("application/yaml"),
+		),
+		Handler: s.readResource,
+	}}
+}
+
+// resourceURI is the parsed URI of a cluster object exposed as an MCP resource
+type resourceURI struct {
+	cluster   string
+	namespace string
+	kind      string
+	name      string
+}
+
+// GetArguments exposes the cluster of the object so that it's read through the ACM proxy as in a regular tool call
+func (r resourceURI) GetArguments() map[string]any {
+	if r.cluster == resourceLocalCluster {
+		return map[string]any{}
+	}
+	return map[string]any{"cluster": r.cluster}
+}
+
+func parseResourceURI(uri string) (*resourceURI, error) {
+	path, ok := strings.CutPrefix(uri, resourceURIScheme)
+	if !ok {
+		return nil, fmt.Errorf("invalid resource URI %s, expected scheme %s", uri, resourceURIScheme)
+	}
+	segments := strings.Split(path, "/")
+	if len(segments) != 4 {
+		return nil, fmt.Errorf("invalid resource URI %s, expected %s{cluster}/{namespace}/{kind}/{name}", uri, resourceURIScheme)
+	}
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil || unescaped == "" {
+			return nil, fmt.Errorf("invalid resource URI %s, empty or malformed segment %q", uri, segment)
+		}
+		segments[i] = unescaped
+	}
+	parsed := &resourceURI{cluster: segments[0], namespace: segments[1], kind: segments[2], name: segments[3]}
+	if parsed.namespace == resourceClusterScope {
+		parsed.namespace = ""
+	}
+	return parsed, nil
+}
+
+// readResource handles the resources/read requests of the cluster object URIs by returning their YAML manifest
+func (s *Server) readResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri, err := parseResourceURI(request.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+	k, err := s.sessions.derived(ctx, s.k)
+	if err != nil {
+		return nil, err
+	}
+	if uri.cluster != resourceLocalCluster && !s.configuration.ACMMode {
+		return nil, fmt.Errorf("failed to read resource %s: cluster %s is not available, only %s is supported when ACM mode is disabled",
+			request.Params.URI, uri.cluster, resourceLocalCluster)
+	}
+	gvk, err := k.KindFor(uri.kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s: %w", request.Params.URI, err)
+	}
+	ctx, acmProxyClient := s.acmProxyClient(ctx, k, "resource", request.Params.URI)
+	params := api.ToolHandlerParams{
+		Context:         ctx,
+		Kubernetes:      k,
+		ToolCallRequest: uri,
+		ListOutput:      s.configuration.ListOutput(),
+		IsACMMode:       s.configuration.ACMMode,
+		ACMProxyClient:  acmProxyClient,
+	}
+	ret, err := params.ResourcesGet(params, gvk, uri.namespace, uri.name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s: %w", request.Params.URI, err)
+	}
+	manifest, err := output.MarshalYaml(ret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s: %w", request.Params.URI, err)
+	}
+	return []mcp.ResourceContents{mcp.TextResourceContents{
+		URI:      request.Params.URI,
+		MIMEType: "application/yaml",
+		Text:     manifest,
+	}}, nil
+}
@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type ResourceTemplatesSuite struct {
+	BaseMcpSuite
+}
+
+func (s *ResourceTemplatesSuite) TestParseResourceURI() {
+	s.Run("parses namespaced object URI", func() {
+		uri, err := parseResourceURI("k8s://local/default/deployments.apps/a-deployment")
+		s.Require().NoError(err)
+		s.Equal(resourceURI{cluster: "local", namespace: "default", kind: "deployments.apps", name: "a-deployment"}, *uri)
+		s.Empty(uri.GetArguments(), "expected local cluster not to be routed through the ACM proxy")
+	})
+	s.Run("parses cluster scoped object URI", func() {
+		uri, err := parseResourceURI("k8s://managed-1/_/Namespace/default")
+		s.Require().NoError(err)
+		s.Equal("", uri.namespace)
+		s.Equal(map[string]any{"cluster": "managed-1"}, uri.GetArguments())
+	})
+	s.Run("rejects invalid URIs", func() {
+		for _, uri := range []string{"https://local/default/Pod/a-pod", "k8s://local/default/Pod", "k8s://local//Pod/a-pod"} {
+			_, err := parseResourceURI(uri)
+			s.Errorf(err, "expected %s to be rejected", uri)
+		}
+	})
+}
+
+func (s *ResourceTemplatesSuite) TestListResourceTemplates() {
+	s.InitMcpClient()
+	templates, err := s.ListResourceTemplates(s.T().Context(), mcp.ListResourceTemplatesRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(templates.ResourceTemplates, 1)
+	s.Equal("k8s://{cluster}/{namespace}/{kind}/{name}", templates.ResourceTemplates[0].URITemplate.Raw())
+}
+
+func (s *ResourceTemplatesSuite) TestReadResource() {
+	client := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = client.CoreV1().ConfigMaps("default").Create(s.T().Context(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-configmap-to-read"},
+		Data:       map[string]string{"key": "value"},
+	}, metav1.CreateOptions{})
+	s.InitMcpClient()
+	s.Run("reads namespaced object", func() {
+		result, err := s.ReadResource("k8s://local/default/ConfigMap/a-configmap-to-read")
+		s.Require().NoError(err)
+		s.Require().Len(result.Contents, 1)
+		contents := result.Contents[0].(mcp.TextResourceContents)
+		s.Equal("application/yaml", contents.MIMEType)
+		s.Contains(contents.Text, "name: a-configmap-to-read")
+		s.Contains(contents.Text, "key: value")
+	})
+	s.Run("reads cluster scoped object", func() {
+		result, err := s.ReadResource("k8s://local/_/namespaces/default")
+		s.Require().NoError(err)
+		s.Contains(result.Contents[0].(mcp.TextResourceContents).Text, "name: default")
+	})
+	s.Run("fails for unknown object", func() {
+		_, err := s.ReadResource("k8s://local/default/ConfigMap/non-existent")
+		s.ErrorContains(err, `configmaps "non-existent" not found`)
+	})
+	s.Run("fails for managed clusters when ACM mode is disabled", func() {
+		_, err := s.ReadResource("k8s://managed-1/default/ConfigMap/a-configmap-to-read")
+		s.ErrorContains(err, "cluster managed-1 is not available")
+	})
+}
+
+func TestResourceTemplates(t *testing.T) {
+	suite.Run(t, new(ResourceTemplatesSuite))
+}
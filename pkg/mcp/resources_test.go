@@ -6,16 +6,19 @@ import (
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 
 	"github.com/containers/kubernetes-mcp-server/internal/test"
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/output"
 )
 
@@ -357,6 +360,96 @@ func TestResourcesGet(t *testing.T) {
 	})
 }
 
+func TestResourcesProjection(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("resources_get with jsonPath returns the selected fields", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_get", map[string]interface{}{
+				"apiVersion": "v1", "kind": "Namespace", "name": "default", "jsonPath": ".metadata.name",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if text := toolResult.Content[0].(mcp.TextContent).Text; text != "default" {
+				t.Fatalf("invalid projection, expected default, got %v", text)
+			}
+		})
+		t.Run("resources_list with goTemplate returns the selected fields", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_list", map[string]interface{}{
+				"apiVersion": "v1", "kind": "Namespace", "sortBy": "name", "goTemplate": "{{range .items}}{{.metadata.name}};{{end}}",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if text := toolResult.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "default;") || strings.Contains(text, "apiVersion") {
+				t.Fatalf("invalid projection, got %v", text)
+			}
+		})
+		t.Run("resources_list with invalid jsonPath returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_list", map[string]interface{}{
+				"apiVersion": "v1", "kind": "Namespace", "jsonPath": "{.items[",
+			})
+			if !toolResult.IsError || !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "invalid jsonPath") {
+				t.Fatalf("expected invalid jsonPath error, got %v", toolResult.Content)
+			}
+		})
+	})
+}
+
+func TestResourcesDescribe(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("resources_describe with cluster returns error instead of using the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_describe", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "ns-1", "name": "a-pod-in-ns-1", "cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to describe resource, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_describe with missing name returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_describe", map[string]interface{}{"apiVersion": "v1", "kind": "Pod"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to describe resource, missing argument name" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		pod, err := c.callTool("resources_describe", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "ns-1", "name": "a-pod-in-ns-1"})
+		t.Run("resources_describe returns pod description", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			if pod.IsError {
+				t.Fatalf("call tool failed %v", pod.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		var decodedPod kubernetes.Description
+		err = yaml.Unmarshal([]byte(pod.Content[0].(mcp.TextContent).Text), &decodedPod)
+		t.Run("resources_describe has yaml content", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+				return
+			}
+		})
+		t.Run("resources_describe returns the pod", func(t *testing.T) {
+			if name, _, _ := unstructured.NestedString(decodedPod.Object, "metadata", "name"); name != "a-pod-in-ns-1" {
+				t.Fatalf("invalid pod name, expected a-pod-in-ns-1, got %v", name)
+				return
+			}
+		})
+		t.Run("resources_describe returns the pod containers", func(t *testing.T) {
+			if len(decodedPod.Containers) != 1 || decodedPod.Containers[0].Name != "nginx" || decodedPod.Containers[0].Image != "nginx" {
+				t.Fatalf("invalid containers, got %v", decodedPod.Containers)
+				return
+			}
+		})
+	})
+}
+
 func TestResourcesGetDenied(t *testing.T) {
 	deniedResourcesServer := test.Must(config.ReadToml([]byte(`
 		denied_resources = [
@@ -583,6 +676,166 @@ func TestResourcesCreateOrUpdate(t *testing.T) {
 	})
 }
 
+func TestResourcesCreateOrUpdateConflicts(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		configMapYaml := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm-with-conflicts\n  namespace: default\ndata:\n  key: "
+		created, err := c.callTool("resources_create_or_update", map[string]interface{}{"resource": configMapYaml + "a", "fieldManager": "manager-a"})
+		t.Run("resources_create_or_update with fieldManager creates the resource", func(t *testing.T) {
+			if err != nil || created.IsError {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			configMap, _ := c.newKubernetesClient().CoreV1().ConfigMaps("default").Get(t.Context(), "a-cm-with-conflicts", metav1.GetOptions{})
+			if configMap == nil || len(configMap.ManagedFields) == 0 || configMap.ManagedFields[0].Manager != "manager-a" {
+				t.Fatalf("expected fields managed by manager-a, got %v", configMap)
+				return
+			}
+		})
+		conflicted, err := c.callTool("resources_create_or_update", map[string]interface{}{"resource": configMapYaml + "b", "fieldManager": "manager-b"})
+		t.Run("resources_create_or_update with conflicting fieldManager returns conflict", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			if !conflicted.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			if !strings.Contains(conflicted.Content[0].(mcp.TextContent).Text, "conflict") {
+				t.Fatalf("expected conflict error, got %v", conflicted.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		forced, err := c.callTool("resources_create_or_update", map[string]interface{}{"resource": configMapYaml + "b", "fieldManager": "manager-b", "forceConflicts": true})
+		t.Run("resources_create_or_update with forceConflicts takes ownership of the fields", func(t *testing.T) {
+			if err != nil || forced.IsError {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			configMap, _ := c.newKubernetesClient().CoreV1().ConfigMaps("default").Get(t.Context(), "a-cm-with-conflicts", metav1.GetOptions{})
+			if configMap == nil || configMap.Data["key"] != "b" {
+				t.Fatalf("expected forced value b, got %v", configMap)
+				return
+			}
+		})
+		partial, err := c.callTool("resources_create_or_update", map[string]interface{}{
+			"resource": configMapYaml + "c\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: another-cm-with-conflicts\n  namespace: default\n",
+		})
+		t.Run("resources_create_or_update reports per-object results", func(t *testing.T) {
+			if err != nil || partial.IsError {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			text := partial.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "name: another-cm-with-conflicts") {
+				t.Fatalf("expected applied resource, got %v", text)
+				return
+			}
+			if !strings.Contains(text, "# The following resources (YAML) could not be created or updated") || !strings.Contains(text, "field: .data.key") {
+				t.Fatalf("expected conflicts, got %v", text)
+				return
+			}
+		})
+		multiDocument, err := c.callTool("resources_create_or_update", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm-created-or-updated\n  namespace: default\n" +
+				"---\nkind: [\n" +
+				"---\n[{\"apiVersion\": \"v1\", \"kind\": \"ConfigMap\", \"metadata\": {\"name\": \"a-cm-from-json-array\", \"namespace\": \"default\"}}]\n",
+		})
+		t.Run("resources_create_or_update reports the status of each document", func(t *testing.T) {
+			if err != nil || multiDocument.IsError {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			text := multiDocument.Content[0].(mcp.TextContent).Text
+			for _, expected := range []string{
+				"# - unchanged: ConfigMap default/a-cm-created-or-updated\n",
+				"# - error: document 2: ",
+				"# - created: ConfigMap default/a-cm-from-json-array\n",
+			} {
+				if !strings.Contains(text, expected) {
+					t.Fatalf("expected %s, got %v", expected, text)
+					return
+				}
+			}
+		})
+	})
+}
+
+func TestResourcesDiff(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().ConfigMaps("default").Create(t.Context(), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-configmap-to-diff"},
+			Data:       map[string]string{"key": "value"},
+		}, metav1.CreateOptions{})
+		t.Run("resources_diff with cluster returns error instead of using the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_diff", map[string]interface{}{"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-configmap-to-diff\n  namespace: default\n", "cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to diff resources, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_diff with missing resource returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_diff", map[string]interface{}{})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to diff resources, missing argument resource" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		diff, err := c.callTool("resources_diff", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-configmap-to-diff\n  namespace: default\ndata:\n  key: changed\n" +
+				"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-configmap-to-diff-created\n  namespace: default\ndata:\n  key: value\n",
+		})
+		t.Run("resources_diff returns the diff", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			if diff.IsError {
+				t.Fatalf("call tool failed %v", diff.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		text := diff.Content[0].(mcp.TextContent).Text
+		t.Run("resources_diff returns the operations and changed fields", func(t *testing.T) {
+			for _, expected := range []string{"operation: update", "path: data.key", "operation: create"} {
+				if !strings.Contains(text, expected) {
+					t.Fatalf("expected %q in diff, got %v", expected, text)
+					return
+				}
+			}
+		})
+		t.Run("resources_diff returns the unified diff", func(t *testing.T) {
+			for _, expected := range []string{"--- live/ConfigMap/default/a-configmap-to-diff", "-  key: value", "+  key: changed"} {
+				if !strings.Contains(text, expected) {
+					t.Fatalf("expected %q in diff, got %v", expected, text)
+					return
+				}
+			}
+			if strings.Contains(text, "managedFields") {
+				t.Fatalf("expected managedFields to be stripped, got %v", text)
+				return
+			}
+		})
+		t.Run("resources_diff doesn't persist changes", func(t *testing.T) {
+			configMap, _ := kc.CoreV1().ConfigMaps("default").Get(t.Context(), "a-configmap-to-diff", metav1.GetOptions{})
+			if configMap == nil || configMap.Data["key"] != "value" {
+				t.Fatalf("configmap was modified, got %v", configMap)
+				return
+			}
+			if _, err := kc.CoreV1().ConfigMaps("default").Get(t.Context(), "a-configmap-to-diff-created", metav1.GetOptions{}); err == nil {
+				t.Fatalf("configmap was created")
+				return
+			}
+		})
+	})
+}
+
 func TestResourcesCreateOrUpdateDenied(t *testing.T) {
 	deniedResourcesServer := test.Must(config.ReadToml([]byte(`
 		denied_resources = [
@@ -745,6 +998,236 @@ func TestResourcesDelete(t *testing.T) {
 	})
 }
 
+func TestResourcesPatch(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().ConfigMaps("default").Create(t.Context(), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-configmap-to-patch"},
+			Data:       map[string]string{"key": "value"},
+		}, metav1.CreateOptions{})
+		t.Run("resources_patch with cluster returns error instead of using the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-patch", "patch": "{\"data\":{\"key\":\"patched\"}}", "cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to patch resource, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_patch with missing patch returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-patch"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to patch resource, missing argument patch" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		t.Run("resources_patch with invalid patchType returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-patch", "patchType": "apply", "patch": "{}"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+		})
+		patched, err := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-patch", "patch": "data:\n  key: patched"})
+		t.Run("resources_patch patches the resource with strategic merge patch", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			if patched.IsError {
+				t.Fatalf("call tool failed %v", patched.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			configMap, _ := kc.CoreV1().ConfigMaps("default").Get(t.Context(), "a-configmap-to-patch", metav1.GetOptions{})
+			if configMap == nil || configMap.Data["key"] != "patched" {
+				t.Fatalf("configmap was not patched, got %v", configMap)
+				return
+			}
+		})
+		t.Run("resources_patch returns the diff", func(t *testing.T) {
+			text := patched.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "-  key: value") || !strings.Contains(text, "+  key: patched") {
+				t.Fatalf("diff not found in tool result, got %v", text)
+				return
+			}
+		})
+		jsonPatched, err := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-patch", "patchType": "json",
+			"patch": `[{"op":"add","path":"/data/other","value":"added"}]`})
+		t.Run("resources_patch patches the resource with JSON patch", func(t *testing.T) {
+			if err != nil || jsonPatched.IsError {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			configMap, _ := kc.CoreV1().ConfigMaps("default").Get(t.Context(), "a-configmap-to-patch", metav1.GetOptions{})
+			if configMap == nil || configMap.Data["other"] != "added" {
+				t.Fatalf("configmap was not patched, got %v", configMap)
+				return
+			}
+		})
+	})
+}
+
+func TestResourcesScale(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		_, _ = kc.AppsV1().Deployments("default").Create(t.Context(), &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-deployment-to-scale"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(int32(1)),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a-deployment-to-scale"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "a-deployment-to-scale"}},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+				},
+			},
+		}, metav1.CreateOptions{})
+		t.Run("resources_scale with cluster returns error instead of using the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_scale", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "a-deployment-to-scale", "replicas": 0, "cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to scale resource, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_scale with missing replicas returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_scale", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "a-deployment-to-scale"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to scale resource, missing argument replicas" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		t.Run("resources_scale with non-scalable resource returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_scale", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap", "replicas": 2})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "is not scalable") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		scaled, err := c.callTool("resources_scale", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "a-deployment-to-scale", "replicas": 3})
+		t.Run("resources_scale scales the deployment", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			if scaled.IsError {
+				t.Fatalf("call tool failed %v", scaled.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		t.Run("resources_scale returns the replicas readback", func(t *testing.T) {
+			var decoded kubernetes.Scale
+			if err = yaml.Unmarshal([]byte(scaled.Content[0].(mcp.TextContent).Text), &decoded); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+				return
+			}
+			if decoded.PreviousReplicas != 1 || decoded.Replicas != 3 {
+				t.Fatalf("invalid replicas, expected 1 -> 3, got %d -> %d", decoded.PreviousReplicas, decoded.Replicas)
+				return
+			}
+		})
+		t.Run("resources_scale updates the deployment replicas", func(t *testing.T) {
+			deployment, _ := kc.AppsV1().Deployments("default").Get(t.Context(), "a-deployment-to-scale", metav1.GetOptions{})
+			if deployment == nil || ptr.Deref(deployment.Spec.Replicas, 0) != 3 {
+				t.Fatalf("deployment was not scaled, got %v", deployment)
+				return
+			}
+		})
+	})
+}
+
+func TestResourcesLabel(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		kc := c.newKubernetesClient()
+		_, _ = kc.CoreV1().ConfigMaps("default").Create(t.Context(), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-configmap-to-label", Labels: map[string]string{"tier": "backend", "stale": "true"}},
+		}, metav1.CreateOptions{})
+		t.Run("resources_label with cluster returns error instead of using the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_label", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-label", "overwrite": true, "cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to label resource, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_label with existing label and different value returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_label", map[string]interface{}{
+				"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-label", "labels": map[string]interface{}{"tier": "frontend"},
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "labels already set with a different value: tier") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		labeled, err := c.callTool("resources_label", map[string]interface{}{
+			"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-label",
+			"labels": map[string]interface{}{"app.kubernetes.io/part-of": "shop", "tier": "frontend"}, "remove": []interface{}{"stale", "missing"}, "overwrite": true,
+		})
+		t.Run("resources_label updates the labels", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+				return
+			}
+			if labeled.IsError {
+				t.Fatalf("call tool failed %v", labeled.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			var decoded kubernetes.MetadataUpdate
+			if err = yaml.Unmarshal([]byte(labeled.Content[0].(mcp.TextContent).Text), &decoded); err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+				return
+			}
+			if decoded.Added["app.kubernetes.io/part-of"] != "shop" || decoded.Updated["tier"] != "frontend" || len(decoded.Removed) != 1 || decoded.Removed[0] != "stale" {
+				t.Fatalf("invalid update, got %v", decoded)
+				return
+			}
+			cm, _ := kc.CoreV1().ConfigMaps("default").Get(t.Context(), "a-configmap-to-label", metav1.GetOptions{})
+			if len(cm.Labels) != 2 || cm.Labels["app.kubernetes.io/part-of"] != "shop" || cm.Labels["tier"] != "frontend" {
+				t.Fatalf("configmap was not labeled, got %v", cm.Labels)
+				return
+			}
+		})
+		t.Run("resources_label with the same labels makes no changes", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_label", map[string]interface{}{
+				"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-label", "labels": map[string]interface{}{"tier": "frontend"},
+			})
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# The resource already has the requested labels, no changes were made") {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		t.Run("resources_annotate adds annotations to a resource without annotations", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_annotate", map[string]interface{}{
+				"apiVersion": "v1", "kind": "ConfigMap", "name": "a-configmap-to-label", "annotations": map[string]interface{}{"example.com/owner": "team-a"},
+			})
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+			cm, _ := kc.CoreV1().ConfigMaps("default").Get(t.Context(), "a-configmap-to-label", metav1.GetOptions{})
+			if cm.Annotations["example.com/owner"] != "team-a" {
+				t.Fatalf("configmap was not annotated, got %v", cm.Annotations)
+				return
+			}
+		})
+	})
+}
+
 func TestResourcesDeleteDenied(t *testing.T) {
 	deniedResourcesServer := test.Must(config.ReadToml([]byte(`
 		denied_resources = [
@@ -790,3 +1273,37 @@ func TestResourcesDeleteDenied(t *testing.T) {
 		})
 	})
 }
+
+func TestResourcesApplyURL(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		c.withEnvTest()
+		t.Run("resources_apply_url with cluster returns error instead of using the current cluster", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_apply_url", map[string]interface{}{"url": "https://example.com/manifest.yaml", "cluster": "managed-1"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != `failed to apply resources from url, cluster "managed-1" is not supported, the operation can only be performed on the current cluster` {
+				t.Fatalf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_apply_url with missing url returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_apply_url", map[string]interface{}{})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to apply resources from url, missing argument url" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+		t.Run("resources_apply_url with non HTTPS url returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_apply_url", map[string]interface{}{"url": "http://example.com/manifests.yaml"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+				return
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to apply resources from url: only HTTPS URLs are supported, got http://example.com/manifests.yaml" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+				return
+			}
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type RestartsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *RestartsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	pod, err := kc.CoreV1().Pods("ns-2").Create(s.T().Context(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "restarts-oom-killed"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx", Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+		}}}},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:                 "app",
+		Image:                "nginx",
+		RestartCount:         2,
+		State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+	}}
+	_, _ = kc.CoreV1().Pods("ns-2").UpdateStatus(s.T().Context(), pod, metav1.UpdateOptions{})
+}
+
+func (s *RestartsSuite) TestWorkloadsRestarts() {
+	s.InitMcpClient()
+	s.Run("workloads_restarts(namespace=ns-2)", func() {
+		toolResult, err := s.CallTool("workloads_restarts", map[string]interface{}{"namespace": "ns-2"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		text := toolResult.Content[0].(mcp.TextContent).Text
+		s.Contains(text, "workload: restarts-oom-killed")
+		s.Contains(text, "oomKilledPods: 1")
+		s.Contains(text, "memoryLimit: 64Mi")
+		s.Contains(text, "reason: OOMKilled")
+		s.Contains(text, "- CrashLoopBackOff")
+	})
+	s.Run("workloads_restarts(namespace=empty)", func() {
+		toolResult, err := s.CallTool("workloads_restarts", map[string]interface{}{"namespace": "restarts-empty"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("No restart or OOMKill hotspots found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("workloads_restarts(cluster) outside ACM mode labels the restarts as the current cluster", func() {
+		toolResult, err := s.CallTool("workloads_restarts", map[string]interface{}{"namespace": "ns-2", "cluster": "cluster-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.NotContains(toolResult.Content[0].(mcp.TextContent).Text, "cluster-1")
+	})
+	s.Run("workloads_restarts(clusters) outside ACM mode", func() {
+		toolResult, _ := s.CallTool("workloads_restarts", map[string]interface{}{"clusters": []string{"cluster-1"}})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to report workloads restarts, clusters is only supported in ACM mode", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestRestarts(t *testing.T) {
+	suite.Run(t, new(RestartsSuite))
+}
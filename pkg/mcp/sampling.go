@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// sampler requests the completions from the LLM of the client of an MCP session (MCP sampling)
+type sampler struct {
+	server *server.MCPServer
+}
+
+var _ api.Sampler = (*sampler)(nil)
+
+// sampler returns the sampler of the MCP session of the request, nil if the transport or the client don't support sampling
+func (s *Server) sampler(ctx context.Context) api.Sampler {
+	clientSession := server.ClientSessionFromContext(ctx)
+	if _, ok := clientSession.(server.SessionWithSampling); !ok {
+		return nil
+	}
+	if clientInfo, ok := clientSession.(server.SessionWithClientInfo); ok && clientInfo.GetClientCapabilities().Sampling == nil {
+		return nil
+	}
+	return &sampler{server: s.server}
+}
+
+func (sm *sampler) Sample(ctx context.Context, systemPrompt, prompt string, maxTokens int) (string, error) {
+	result, err := sm.server.RequestSampling(ctx, mcp.CreateMessageRequest{
+		CreateMessageParams: mcp.CreateMessageParams{
+			Messages: []mcp.SamplingMessage{
+				{Role: mcp.RoleUser, Content: mcp.NewTextContent(prompt)},
+			},
+			SystemPrompt:   systemPrompt,
+			IncludeContext: "none",
+			MaxTokens:      maxTokens,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	// The content is decoded as a generic map by the transports
+	content := result.Content
+	if contentMap, ok := content.(map[string]any); ok {
+		if content, err = mcp.ParseContent(contentMap); err != nil {
+			return "", err
+		}
+	}
+	if text, ok := mcp.AsTextContent(content); ok {
+		return text.Text, nil
+	}
+	return "", errors.New("the client returned a non-text sampling result")
+}
@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type testSamplingHandler struct {
+	request mcp.CreateMessageRequest
+	content any
+}
+
+func (h *testSamplingHandler) CreateMessage(_ context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	h.request = request
+	return &mcp.CreateMessageResult{SamplingMessage: mcp.SamplingMessage{Role: mcp.RoleAssistant, Content: h.content}, Model: "test"}, nil
+}
+
+func TestSampler(t *testing.T) {
+	s := &Server{server: server.NewMCPServer("test", "0.0.0")}
+	s.server.EnableSampling()
+	withSession := func(session server.ClientSession) context.Context {
+		return s.server.WithContext(context.Background(), session)
+	}
+	t.Run("Not available without session", func(t *testing.T) {
+		if s.sampler(context.Background()) != nil {
+			t.Errorf("expected no sampler")
+		}
+	})
+	t.Run("Not available if the client doesn't declare the sampling capability", func(t *testing.T) {
+		if s.sampler(withSession(server.NewInProcessSession("a-session", &testSamplingHandler{}))) != nil {
+			t.Errorf("expected no sampler")
+		}
+	})
+	handler := &testSamplingHandler{content: mcp.NewTextContent("the summary")}
+	session := server.NewInProcessSession("a-session", handler)
+	session.SetClientCapabilities(mcp.ClientCapabilities{Sampling: &struct{}{}})
+	sampler := s.sampler(withSession(session))
+	if sampler == nil {
+		t.Fatalf("expected a sampler for a client declaring the sampling capability")
+	}
+	t.Run("Requests the completion to the client", func(t *testing.T) {
+		summary, err := sampler.Sample(withSession(session), "the system prompt", "the logs", 100)
+		if err != nil || summary != "the summary" {
+			t.Errorf("expected the summary, got %q %v", summary, err)
+		}
+		if handler.request.SystemPrompt != "the system prompt" || handler.request.MaxTokens != 100 {
+			t.Errorf("unexpected request %v", handler.request.CreateMessageParams)
+		}
+	})
+	t.Run("Decodes the content of the transports", func(t *testing.T) {
+		handler.content = map[string]any{"type": "text", "text": "the decoded summary"}
+		summary, err := sampler.Sample(withSession(session), "the system prompt", "the logs", 100)
+		if err != nil || summary != "the decoded summary" {
+			t.Errorf("expected the decoded summary, got %q %v", summary, err)
+		}
+	})
+	t.Run("Fails for non-text content", func(t *testing.T) {
+		handler.content = mcp.NewImageContent("data", "image/png")
+		if _, err := sampler.Sample(withSession(session), "the system prompt", "the logs", 100); err == nil {
+			t.Errorf("expected an error for non-text content")
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type SecretsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *SecretsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.CoreV1().Secrets("default").Create(s.T().Context(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-secret-to-get"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}, metav1.CreateOptions{})
+}
+
+func (s *SecretsSuite) TestSecretsGet() {
+	s.InitMcpClient()
+	s.Run("secrets_get returns the key names without values", func() {
+		toolResult, err := s.CallTool("secrets_get", map[string]interface{}{"namespace": "default", "name": "a-secret-to-get"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: password")
+		s.NotContains(toolResult.Content[0].(mcp.TextContent).Text, "hunter2")
+	})
+	s.Run("secrets_get with revealValues is not allowed by default", func() {
+		toolResult, _ := s.CallTool("secrets_get", map[string]interface{}{"namespace": "default", "name": "a-secret-to-get", "revealValues": true})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "revealing the values of Secrets is not allowed by the server configuration")
+	})
+	s.Run("secrets_get with cluster returns error instead of the Secret of the current cluster", func() {
+		toolResult, _ := s.CallTool("secrets_get", map[string]interface{}{"namespace": "default", "name": "a-secret-to-get", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to get secret, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("resources_get redacts the Secret data", func() {
+		toolResult, err := s.CallTool("resources_get", map[string]interface{}{"apiVersion": "v1", "kind": "Secret", "namespace": "default", "name": "a-secret-to-get"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "password: '[REDACTED]'")
+		s.NotContains(toolResult.Content[0].(mcp.TextContent).Text, "aHVudGVyMg==")
+	})
+}
+
+func (s *SecretsSuite) TestSecretsGetRevealValues() {
+	s.Require().NoError(toml.Unmarshal([]byte(`reveal_secret_values = true`), s.Cfg), "Expected to parse reveal config")
+	s.InitMcpClient()
+	s.Run("secrets_get with revealValues returns the values", func() {
+		toolResult, err := s.CallTool("secrets_get", map[string]interface{}{"namespace": "default", "name": "a-secret-to-get", "revealValues": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "value: hunter2")
+	})
+}
+
+func (s *SecretsSuite) TestSecretsCreateOrUpdate() {
+	s.InitMcpClient()
+	s.Run("secrets_create_or_update creates the Secret and redacts the values", func() {
+		toolResult, err := s.CallTool("secrets_create_or_update", map[string]interface{}{
+			"namespace": "default",
+			"name":      "a-secret-from-literals",
+			"literals":  map[string]interface{}{"token": "s3cr3t"},
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "token: '[REDACTED]'")
+		s.NotContains(toolResult.Content[0].(mcp.TextContent).Text, "czNjcjN0")
+		secret, err := kubernetes.NewForConfigOrDie(envTestRestConfig).CoreV1().Secrets("default").Get(s.T().Context(), "a-secret-from-literals", metav1.GetOptions{})
+		s.Require().NoError(err)
+		s.Equal("s3cr3t", string(secret.Data["token"]))
+		s.Equal(corev1.SecretTypeOpaque, secret.Type)
+	})
+}
+
+func TestSecrets(t *testing.T) {
+	suite.Run(t, new(SecretsSuite))
+}
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type ServiceAccountsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *ServiceAccountsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.CoreV1().ServiceAccounts("default").Create(s.T().Context(), &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-service-account"},
+	}, metav1.CreateOptions{})
+}
+
+func (s *ServiceAccountsSuite) TestServiceAccountsTokenNotAllowed() {
+	s.InitMcpClient()
+	s.Run("serviceaccounts_token is not allowed by default", func() {
+		toolResult, _ := s.CallTool("serviceaccounts_token", map[string]interface{}{"namespace": "default", "name": "a-service-account"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "requesting ServiceAccount tokens is not allowed by the server configuration")
+	})
+}
+
+func (s *ServiceAccountsSuite) TestServiceAccountsToken() {
+	s.Require().NoError(toml.Unmarshal([]byte(`allow_service_account_tokens = true`), s.Cfg), "Expected to parse token config")
+	s.InitMcpClient()
+	s.Run("serviceaccounts_token returns a bound token", func() {
+		toolResult, err := s.CallTool("serviceaccounts_token", map[string]interface{}{
+			"namespace":  "default",
+			"name":       "a-service-account",
+			"audiences":  []interface{}{"vault"},
+			"expiration": "30m",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "serviceAccount: a-service-account")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "- vault")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "token: ey")
+	})
+	s.Run("serviceaccounts_token with expiration below the minimum", func() {
+		toolResult, _ := s.CallTool("serviceaccounts_token", map[string]interface{}{"namespace": "default", "name": "a-service-account", "expiration": "1m"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to request ServiceAccount token: expiration 1m0s must be between 10m0s and 24h0m0s", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("serviceaccounts_token with invalid expiration", func() {
+		toolResult, _ := s.CallTool("serviceaccounts_token", map[string]interface{}{"namespace": "default", "name": "a-service-account", "expiration": "soon"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "failed to request ServiceAccount token, invalid expiration \"soon\"")
+	})
+	s.Run("serviceaccounts_token with cluster returns error instead of a token of the current cluster", func() {
+		toolResult, _ := s.CallTool("serviceaccounts_token", map[string]interface{}{"namespace": "default", "name": "a-service-account", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to request ServiceAccount token, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestServiceAccounts(t *testing.T) {
+	suite.Run(t, new(ServiceAccountsSuite))
+}
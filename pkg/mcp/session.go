@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog/v2"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/metrics"
+)
+
+// sessionClientsCacheName is the cache label of the session clients metrics
+const sessionClientsCacheName = "session_clients"
+
+// sessionIdleTTL is the time after which the client of an inactive session is discarded
+const sessionIdleTTL = 30 * time.Minute
+
+// sessionClients caches the Kubernetes client derived from the bearer token of each MCP session.
+// In HTTP transport mode, the tool calls of the session are performed with the identity of the token so that the user
+// RBAC applies end to end.
+// The session IDs are supplied by the clients (SSE sessionId query parameter, Mcp-Session-Id header), so a request
+// can't inherit the token of its session: every request must carry the token and the cached client is only reused
+// when the token matches the one it was derived from.
+type sessionClients struct {
+	mu sync.Mutex
+	// sessions are keyed by the session instance (not the ID), the ephemeral sessions of the stateless streamable
+	// HTTP transport share their ID
+	sessions  map[server.ClientSession]*sessionClient
+	lastPrune time.Time
+}
+
+type sessionClient struct {
+	// tokenHash is the SHA-256 hash of the Authorization header the client was derived from
+	tokenHash [sha256.Size]byte
+	// manager the client was derived from, the client is derived again if the kubeconfig is reloaded
+	manager  *internalk8s.Manager
+	k        *internalk8s.Kubernetes
+	lastSeen time.Time
+}
+
+func newSessionClients() *sessionClients {
+	return &sessionClients{sessions: make(map[server.ClientSession]*sessionClient)}
+}
+
+// derived returns the Kubernetes client for the MCP session of the request.
+// The client derived from the bearer token of the request is cached for the session and reused by the subsequent
+// requests of the session with the same token, requests with a different token replace the cached client.
+func (sc *sessionClients) derived(ctx context.Context, m *internalk8s.Manager) (*internalk8s.Kubernetes, error) {
+	clientSession := server.ClientSessionFromContext(ctx)
+	authorization, _ := ctx.Value(internalk8s.OAuthAuthorizationHeader).(string)
+	if clientSession == nil || clientSession.SessionID() == "" || !strings.HasPrefix(authorization, "Bearer ") {
+		return m.Derived(ctx)
+	}
+
+	tokenHash := sha256.Sum256([]byte(authorization))
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	now := time.Now()
+	sc.prune(now)
+	session, ok := sc.sessions[clientSession]
+	if ok && subtle.ConstantTimeCompare(session.tokenHash[:], tokenHash[:]) == 1 && session.manager == m {
+		metrics.CacheRequests.WithLabelValues(sessionClientsCacheName, metrics.CacheHit).Inc()
+		session.lastSeen = now
+		return session.k, nil
+	}
+	metrics.CacheRequests.WithLabelValues(sessionClientsCacheName, metrics.CacheMiss).Inc()
+	k, err := m.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(5).Infof("Kubernetes client derived from the bearer token of session %s", clientSession.SessionID())
+	sc.sessions[clientSession] = &sessionClient{tokenHash: tokenHash, manager: m, k: k, lastSeen: now}
+	return k, nil
+}
+
+// prune discards the inactive sessions, the caller must hold the lock
+func (sc *sessionClients) prune(now time.Time) {
+	if now.Sub(sc.lastPrune) < sessionIdleTTL {
+		return
+	}
+	sc.lastPrune = now
+	for clientSession, session := range sc.sessions {
+		if now.Sub(session.lastSeen) > sessionIdleTTL {
+			delete(sc.sessions, clientSession)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// sessionStates keeps the state of each MCP session (e.g. the defaults set with session_set_defaults)
+type sessionStates struct {
+	mu sync.Mutex
+	// sessions are keyed by the session instance (not the ID), see sessionClients
+	sessions map[server.ClientSession]*sessionState
+}
+
+type sessionState struct {
+	mu       sync.RWMutex
+	defaults api.SessionDefaults
+}
+
+var _ api.Session = (*sessionState)(nil)
+
+func newSessionStates() *sessionStates {
+	return &sessionStates{sessions: make(map[server.ClientSession]*sessionState)}
+}
+
+// addHooks adds the server hooks discarding the state of a session when it's closed
+func (ss *sessionStates) addHooks(hooks *server.Hooks) {
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		ss.mu.Lock()
+		defer ss.mu.Unlock()
+		delete(ss.sessions, session)
+	})
+}
+
+// get returns the state of the MCP session of the request, nil for the transports without sessions
+func (ss *sessionStates) get(ctx context.Context) api.Session {
+	clientSession := server.ClientSessionFromContext(ctx)
+	if clientSession == nil || clientSession.SessionID() == "" {
+		return nil
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	state, ok := ss.sessions[clientSession]
+	if !ok {
+		state = &sessionState{}
+		ss.sessions[clientSession] = state
+	}
+	return state
+}
+
+func (s *sessionState) Defaults() api.SessionDefaults {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.defaults
+}
+
+func (s *sessionState) SetDefaults(defaults api.SessionDefaults) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaults = defaults
+}
+
+// toolCallArguments are the effective arguments of a tool call once the session defaults are applied
+type toolCallArguments map[string]any
+
+func (a toolCallArguments) GetArguments() map[string]any {
+	return a
+}
+
+// sessionDefaultsMiddleware applies the defaults of the session to the arguments the tool call doesn't provide,
+// only if the tool accepts them (e.g. the namespace isn't applied to the tools listing all namespaces).
+// The tool calls that can't be routed to the default cluster are rejected instead of being performed on the hub.
+func sessionDefaultsMiddleware(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		if params.Session == nil {
+			return next(params)
+		}
+		defaults := params.Session.Defaults()
+		if defaults.Output != "" {
+			if listOutput := output.FromString(defaults.Output); listOutput != nil {
+				params.ListOutput = listOutput
+			}
+		}
+		arguments := toolCallArguments(maps.Clone(params.GetArguments()))
+		if arguments == nil {
+			arguments = make(toolCallArguments)
+		}
+		if defaults.Cluster != "" && !acceptsArgument(params.Tool, "cluster") {
+			defaultClusterParams := params
+			defaultClusterParams.ToolCallRequest = toolCallArguments{"cluster": defaults.Cluster}
+			if err := api.RejectClusterParameter(defaultClusterParams); err != nil {
+				return api.NewToolCallResult("", fmt.Errorf("failed to apply the session default cluster, %w (clear it with session_set_defaults)", err)), nil
+			}
+		}
+		for name, value := range map[string]string{"namespace": defaults.Namespace, "cluster": defaults.Cluster} {
+			if value == "" || !acceptsArgument(params.Tool, name) {
+				continue
+			}
+			if provided, _ := arguments[name].(string); provided == "" {
+				arguments[name] = value
+			}
+		}
+		params.ToolCallRequest = arguments
+		return next(params)
+	}
+}
+
+func acceptsArgument(tool *api.ServerTool, name string) bool {
+	if tool == nil || tool.Tool.InputSchema == nil {
+		return false
+	}
+	_, ok := tool.Tool.InputSchema.Properties[name]
+	return ok
+}
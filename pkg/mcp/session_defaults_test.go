@@ -0,0 +1,138 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/config"
+)
+
+func TestSessionDefaults(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "0.0.0")
+	states := newSessionStates()
+	session := server.NewInProcessSession("a-session", nil)
+	ctx := mcpServer.WithContext(context.Background(), session)
+	var setDefaults api.ServerTool
+	for _, tool := range (&config.Toolset{}).GetTools(nil) {
+		if tool.Tool.Name == "session_set_defaults" {
+			setDefaults = tool
+		}
+	}
+	call := func(ctx context.Context, tool *api.ServerTool, arguments map[string]any) (api.ToolHandlerParams, *api.ToolCallResult) {
+		var called api.ToolHandlerParams
+		handler := func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			called = params
+			return tool.Handler(params)
+		}
+		result, _ := sessionDefaultsMiddleware(handler)(api.ToolHandlerParams{
+			Context:         ctx,
+			ToolCallRequest: mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}},
+			Tool:            tool,
+			ListOutput:      output.Yaml,
+			Session:         states.get(ctx),
+		})
+		return called, result
+	}
+	namespaced := &api.ServerTool{
+		Tool: api.Tool{Name: "namespaced", InputSchema: &jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{
+			"namespace": {Type: "string"},
+			"cluster":   {Type: "string"},
+		}}},
+		Handler: func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			return api.NewToolCallResult("ok", nil), nil
+		},
+	}
+	clusterScoped := &api.ServerTool{
+		Tool:    api.Tool{Name: "cluster_scoped", InputSchema: &jsonschema.Schema{Type: "object"}},
+		Handler: namespaced.Handler,
+	}
+	t.Run("Tool calls without defaults keep their arguments", func(t *testing.T) {
+		params, _ := call(ctx, namespaced, map[string]any{})
+		if _, ok := params.GetArguments()["namespace"]; ok {
+			t.Errorf("expected no namespace, got %v", params.GetArguments())
+		}
+	})
+	_, result := call(ctx, &setDefaults, map[string]any{"namespace": "payments", "cluster": "prod-east", "output": "table"})
+	t.Run("session_set_defaults returns the defaults", func(t *testing.T) {
+		if result.Error != nil {
+			t.Fatalf("expected no error, got %v", result.Error)
+		}
+		if !strings.Contains(result.Content, "namespace: payments") || !strings.Contains(result.Content, "cluster: prod-east") {
+			t.Errorf("expected the defaults, got %s", result.Content)
+		}
+	})
+	t.Run("Tool calls inherit the defaults", func(t *testing.T) {
+		params, _ := call(ctx, namespaced, map[string]any{})
+		if params.GetArguments()["namespace"] != "payments" || params.GetArguments()["cluster"] != "prod-east" {
+			t.Errorf("expected the default namespace and cluster, got %v", params.GetArguments())
+		}
+		if params.ListOutput != output.Table {
+			t.Errorf("expected the default output, got %s", params.ListOutput.GetName())
+		}
+	})
+	t.Run("Tool calls override the defaults", func(t *testing.T) {
+		params, _ := call(ctx, namespaced, map[string]any{"namespace": "checkout"})
+		if params.GetArguments()["namespace"] != "checkout" {
+			t.Errorf("expected the provided namespace, got %v", params.GetArguments())
+		}
+	})
+	t.Run("Tool calls that can't be routed to the default cluster are rejected", func(t *testing.T) {
+		drain := &api.ServerTool{
+			Tool: api.Tool{Name: "nodes_drain", InputSchema: &jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{
+				"name": {Type: "string"},
+			}}, Annotations: api.ToolAnnotations{ReadOnlyHint: ptr.To(false), DestructiveHint: ptr.To(true)}},
+			Handler: namespaced.Handler,
+		}
+		params, result := call(ctx, drain, map[string]any{"name": "worker-1"})
+		if params.Tool != nil {
+			t.Errorf("expected the tool not to be called")
+		}
+		if result.Error == nil || !strings.Contains(result.Error.Error(), `cluster "prod-east" is not supported`) {
+			t.Errorf("expected the default cluster to be rejected, got %v", result.Error)
+		}
+		var toolError *api.ToolError
+		if !errors.As(result.Error, &toolError) || toolError.Code != api.ErrorCodeValidationFailed {
+			t.Errorf("expected a validation error, got %v", result.Error)
+		}
+	})
+	t.Run("session_set_defaults keeps the omitted defaults", func(t *testing.T) {
+		call(ctx, &setDefaults, map[string]any{"namespace": "checkout"})
+		if defaults := states.get(ctx).Defaults(); defaults != (api.SessionDefaults{Namespace: "checkout", Cluster: "prod-east", Output: "table"}) {
+			t.Errorf("expected the cluster and output to be kept, got %v", defaults)
+		}
+	})
+	t.Run("session_set_defaults clears the defaults", func(t *testing.T) {
+		call(ctx, &setDefaults, map[string]any{"clear": []any{"cluster", "output"}})
+		if defaults := states.get(ctx).Defaults(); defaults != (api.SessionDefaults{Namespace: "checkout"}) {
+			t.Errorf("expected the cluster and output to be cleared, got %v", defaults)
+		}
+	})
+	t.Run("Tool calls without the argument don't inherit it", func(t *testing.T) {
+		params, _ := call(ctx, clusterScoped, map[string]any{})
+		if len(params.GetArguments()) != 0 {
+			t.Errorf("expected no arguments, got %v", params.GetArguments())
+		}
+	})
+	t.Run("Defaults are scoped to the session", func(t *testing.T) {
+		other := mcpServer.WithContext(context.Background(), server.NewInProcessSession("another-session", nil))
+		params, _ := call(other, namespaced, map[string]any{})
+		if _, ok := params.GetArguments()["namespace"]; ok {
+			t.Errorf("expected no namespace, got %v", params.GetArguments())
+		}
+	})
+	t.Run("session_set_defaults fails without session", func(t *testing.T) {
+		_, result := call(context.Background(), &setDefaults, map[string]any{"namespace": "payments"})
+		if result.Error == nil || !strings.Contains(result.Error.Error(), "the transport is stateless") {
+			t.Errorf("expected stateless error, got %v", result.Error)
+		}
+	})
+}
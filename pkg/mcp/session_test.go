@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func TestSessionClients(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		sessions := newSessionClients()
+		withSession := func(session server.ClientSession, authorization string) context.Context {
+			ctx := c.mcpServer.server.WithContext(context.Background(), session)
+			if authorization != "" {
+				ctx = context.WithValue(ctx, internalk8s.OAuthAuthorizationHeader, authorization)
+			}
+			return ctx
+		}
+		session := server.NewInProcessSession("a-session", nil)
+		first, err := sessions.derived(withSession(session, "Bearer a-token"), c.mcpServer.k)
+		t.Run("Derives client from the session bearer token", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("failed to derive client: %v", err)
+			}
+			if first.GetBearerToken() != "a-token" {
+				t.Errorf("expected client derived from the session token, got %s", first.GetBearerToken())
+			}
+		})
+		t.Run("Reuses the session client", func(t *testing.T) {
+			k, err := sessions.derived(withSession(session, "Bearer a-token"), c.mcpServer.k)
+			if err != nil || k != first {
+				t.Errorf("expected the session client to be reused, err: %v", err)
+			}
+		})
+		t.Run("Requests without token don't reuse the session client", func(t *testing.T) {
+			k, err := sessions.derived(withSession(session, ""), c.mcpServer.k)
+			if err != nil {
+				t.Fatalf("failed to derive client: %v", err)
+			}
+			if k == first || k.GetBearerToken() == "a-token" {
+				t.Errorf("expected the session token not to be inherited by a request without token")
+			}
+		})
+		t.Run("Requests with a new token replace the session client", func(t *testing.T) {
+			k, err := sessions.derived(withSession(session, "Bearer another-token"), c.mcpServer.k)
+			if err != nil || k.GetBearerToken() != "another-token" {
+				t.Errorf("expected client derived from the new token, err: %v", err)
+			}
+			k, _ = sessions.derived(withSession(session, "Bearer a-token"), c.mcpServer.k)
+			if k == first || k.GetBearerToken() != "a-token" {
+				t.Errorf("expected client derived again from the previous token, got %s", k.GetBearerToken())
+			}
+		})
+		t.Run("Sessions with the same ID don't share the client", func(t *testing.T) {
+			spoofed := server.NewInProcessSession("a-session", nil)
+			k, err := sessions.derived(withSession(spoofed, ""), c.mcpServer.k)
+			if err != nil {
+				t.Fatalf("failed to derive client: %v", err)
+			}
+			if k.GetBearerToken() == "a-token" {
+				t.Errorf("expected the token not to be inherited by a different session instance")
+			}
+		})
+	})
+}
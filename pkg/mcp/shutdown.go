@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+// DefaultShutdownGracePeriod is the default time the in-flight tool calls are given to complete on shutdown
+const DefaultShutdownGracePeriod = 10 * time.Second
+
+func shutdownGracePeriod(staticConfig *config.StaticConfig) time.Duration {
+	if staticConfig.ShutdownGracePeriod > 0 {
+		return staticConfig.ShutdownGracePeriod
+	}
+	return DefaultShutdownGracePeriod
+}
+
+// drain tracks the in-flight tool calls so that, on shutdown, the server stops accepting new calls and waits for the
+// in-flight ones to complete before closing the transports
+type drain struct {
+	mu       sync.Mutex
+	draining bool
+	calls    sync.WaitGroup
+	// abort cancels the calls still in flight once the grace period expires
+	ctx   context.Context
+	abort context.CancelFunc
+}
+
+func newDrain() *drain {
+	ctx, abort := context.WithCancel(context.Background())
+	return &drain{ctx: ctx, abort: abort}
+}
+
+// acquire registers a new tool call, it returns false if the server is shutting down
+func (d *drain) acquire() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return false
+	}
+	d.calls.Add(1)
+	return true
+}
+
+func (d *drain) isDraining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// wait stops accepting new tool calls and waits for the in-flight ones to complete,
+// the remaining calls are canceled if the context expires first
+func (d *drain) wait(ctx context.Context) error {
+	d.mu.Lock()
+	d.draining = true
+	d.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		d.calls.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		d.abort()
+		return fmt.Errorf("in-flight tool calls didn't complete within the grace period, canceling them: %w", ctx.Err())
+	}
+}
+
+// drainMiddleware rejects the tool calls once the server is shutting down (so that the client retries them against
+// another replica) and tracks the in-flight ones
+func drainMiddleware(d *drain) api.ToolMiddleware {
+	return func(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+		return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			if !d.acquire() {
+				return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeUnavailable, true,
+					fmt.Errorf("tool %s was not called, the server is shutting down", params.Tool.Tool.Name))), nil
+			}
+			defer d.calls.Done()
+			ctx, cancel := context.WithCancel(params.Context)
+			defer cancel()
+			defer context.AfterFunc(d.ctx, cancel)()
+			params.Context = ctx
+			return next(params)
+		}
+	}
+}
+
+// Shutdown stops accepting new tool calls and gives the in-flight ones the configured grace period to complete,
+// the calls still in flight afterward are canceled. The resource subscriptions (watch streams) are closed once drained.
+// The transports must be shut down afterward, Close flushes the audit sinks.
+func (s *Server) Shutdown(ctx context.Context) error {
+	gracePeriod := shutdownGracePeriod(s.configuration.StaticConfig)
+	klog.V(0).Infof("Draining in-flight tool calls (grace period %s)...", gracePeriod)
+	ctx, cancel := context.WithTimeout(ctx, gracePeriod)
+	defer cancel()
+	err := s.drain.wait(ctx)
+	s.subscriptions.close()
+	return err
+}
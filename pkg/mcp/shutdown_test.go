@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+func TestDrain(t *testing.T) {
+	call := func(d *drain, handler api.ToolHandlerFunc) (*api.ToolCallResult, error) {
+		return drainMiddleware(d)(handler)(api.ToolHandlerParams{Context: context.Background(), Tool: &api.ServerTool{Tool: api.Tool{Name: "drain_test"}}})
+	}
+	t.Run("Waits for the in-flight calls", func(t *testing.T) {
+		d := newDrain()
+		started, release := make(chan struct{}), make(chan struct{})
+		go func() {
+			_, _ = call(d, func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+				close(started)
+				<-release
+				return api.NewToolCallResult("ok", nil), nil
+			})
+		}()
+		<-started
+		drained := make(chan error, 1)
+		go func() { drained <- d.wait(context.Background()) }()
+		select {
+		case <-drained:
+			t.Fatalf("expected the drain to wait for the in-flight call")
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(release)
+		if err := <-drained; err != nil {
+			t.Errorf("expected the drain to complete, got %v", err)
+		}
+	})
+	t.Run("Rejects new calls once draining", func(t *testing.T) {
+		d := newDrain()
+		_ = d.wait(context.Background())
+		result, _ := call(d, func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			t.Fatalf("expected the call to be rejected")
+			return nil, nil
+		})
+		var toolError *api.ToolError
+		if result == nil || !errors.As(result.Error, &toolError) || toolError.Code != api.ErrorCodeUnavailable || !toolError.Retryable {
+			t.Errorf("expected a retryable Unavailable error, got %v", result)
+		}
+	})
+	t.Run("Cancels the calls still in flight after the grace period", func(t *testing.T) {
+		d := newDrain()
+		started, canceled := make(chan struct{}), make(chan error, 1)
+		go func() {
+			_, _ = call(d, func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+				close(started)
+				<-params.Done()
+				canceled <- params.Err()
+				return nil, params.Err()
+			})
+		}()
+		<-started
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := d.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the grace period to expire, got %v", err)
+		}
+		select {
+		case err := <-canceled:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected the call to be canceled, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("expected the in-flight call to be canceled")
+		}
+	})
+}
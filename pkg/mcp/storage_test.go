@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+type StorageSuite struct {
+	BaseMcpSuite
+}
+
+func (s *StorageSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = []string{"storage"}
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, _ = kc.StorageV1().StorageClasses().Create(s.T().Context(), &storagev1.StorageClass{
+		ObjectMeta:           metav1.ObjectMeta{Name: "a-storage-class", Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"}},
+		Provisioner:          "example.com/provisioner",
+		AllowVolumeExpansion: ptr.To(true),
+	}, metav1.CreateOptions{})
+	_, _ = kc.CoreV1().PersistentVolumeClaims("default").Create(s.T().Context(), &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-pending-claim"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: ptr.To("a-storage-class"),
+			Resources:        corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}},
+		},
+	}, metav1.CreateOptions{})
+	_, _ = kc.CoreV1().Events("default").Create(s.T().Context(), &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "a-pending-claim-event"},
+		InvolvedObject: corev1.ObjectReference{APIVersion: "v1", Kind: "PersistentVolumeClaim", Namespace: "default", Name: "a-pending-claim"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "ProvisioningFailed",
+		Message:        "storageclass.storage.k8s.io \"a-storage-class\" provisioner is not available",
+	}, metav1.CreateOptions{})
+}
+
+func (s *StorageSuite) TestPvcsList() {
+	s.InitMcpClient()
+	s.Run("pvcs_list(pending=true) includes the provisioning events", func() {
+		toolResult, err := s.CallTool("pvcs_list", map[string]interface{}{"namespace": "default", "pending": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		text := toolResult.Content[0].(mcp.TextContent).Text
+		s.Contains(text, "name: a-pending-claim")
+		s.Contains(text, "phase: Pending")
+		s.Contains(text, "Warning ProvisioningFailed: storageclass.storage.k8s.io \"a-storage-class\" provisioner is not available")
+	})
+	s.Run("pvcs_list with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("pvcs_list", map[string]interface{}{"cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to list persistent volume claims, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func (s *StorageSuite) TestStorageClassesList() {
+	s.InitMcpClient()
+	s.Run("storageclasses_list", func() {
+		toolResult, err := s.CallTool("storageclasses_list", map[string]interface{}{})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "name: a-storage-class\n  provisioner: example.com/provisioner\n  default: true")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "allowVolumeExpansion: true")
+	})
+}
+
+func TestStorage(t *testing.T) {
+	suite.Run(t, new(StorageSuite))
+}
@@ -0,0 +1,227 @@
+package mcp
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// httpSessionIdleTimeout is the time after which a session of the stateful streamable HTTP transport without
+	// requests or open event stream is discarded
+	httpSessionIdleTimeout = 30 * time.Minute
+	// httpSessionReplayEvents is the number of events of each session kept to resume its event stream
+	httpSessionReplayEvents = 100
+	httpSessionIDPrefix     = "mcp-session-"
+	headerLastEventID       = "Last-Event-ID"
+)
+
+// httpSessions is the SessionIdManager of the stateful streamable HTTP transport.
+// It tracks the sessions issued to the clients, limits the concurrent ones, and keeps the last events of their
+// event streams so that a client reconnecting with the Last-Event-ID header receives the events it missed.
+type httpSessions struct {
+	mu sync.Mutex
+	// limit is the maximum number of concurrent sessions, zero is unlimited
+	limit       int
+	idleTimeout time.Duration
+	now         func() time.Time
+	sessions    map[string]*httpSession
+}
+
+type httpSession struct {
+	lastSeen time.Time
+	// streams is the number of open event streams, a session with an open stream is never idle
+	streams     int
+	lastEventID uint64
+	// events are the last events sent through the event stream, oldest first
+	events []httpSessionEvent
+}
+
+type httpSessionEvent struct {
+	id   uint64
+	data []byte
+}
+
+var _ server.SessionIdManager = &httpSessions{}
+
+func newHttpSessions(limit int) *httpSessions {
+	return &httpSessions{
+		limit:       limit,
+		idleTimeout: httpSessionIdleTimeout,
+		now:         time.Now,
+		sessions:    make(map[string]*httpSession),
+	}
+}
+
+func (hs *httpSessions) Generate() string {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	sessionID := httpSessionIDPrefix + uuid.NewString()
+	hs.sessions[sessionID] = &httpSession{lastSeen: hs.now()}
+	return sessionID
+}
+
+// Validate reports the unknown sessions (terminated, expired, or issued before a restart) as terminated so that
+// clients initialize a new one
+func (hs *httpSessions) Validate(sessionID string) (isTerminated bool, err error) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	session, ok := hs.sessions[sessionID]
+	if !ok {
+		return true, nil
+	}
+	session.lastSeen = hs.now()
+	return false, nil
+}
+
+func (hs *httpSessions) Terminate(sessionID string) (isNotAllowed bool, err error) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	delete(hs.sessions, sessionID)
+	return false, nil
+}
+
+// available discards the idle sessions and returns true if a new session can be initialized
+func (hs *httpSessions) available() bool {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	for sessionID, session := range hs.sessions {
+		if session.streams == 0 && hs.now().Sub(session.lastSeen) > hs.idleTimeout {
+			delete(hs.sessions, sessionID)
+		}
+	}
+	return hs.limit <= 0 || len(hs.sessions) < hs.limit
+}
+
+// openStream marks the event stream of the session as open and returns the events after lastEventID to replay,
+// returns false if the session is unknown
+func (hs *httpSessions) openStream(sessionID string, lastEventID uint64) ([]httpSessionEvent, bool) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	session, ok := hs.sessions[sessionID]
+	if !ok {
+		return nil, false
+	}
+	session.streams++
+	replay := make([]httpSessionEvent, 0)
+	for _, event := range session.events {
+		if event.id > lastEventID {
+			replay = append(replay, event)
+		}
+	}
+	return replay, true
+}
+
+func (hs *httpSessions) closeStream(sessionID string) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if session, ok := hs.sessions[sessionID]; ok {
+		session.streams--
+		session.lastSeen = hs.now()
+	}
+}
+
+// record assigns the next event ID of the session to the event and keeps it for replay
+func (hs *httpSessions) record(sessionID string, data []byte) uint64 {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	session, ok := hs.sessions[sessionID]
+	if !ok {
+		return 0
+	}
+	session.lastEventID++
+	session.events = append(session.events, httpSessionEvent{id: session.lastEventID, data: data})
+	if len(session.events) > httpSessionReplayEvents {
+		session.events = session.events[len(session.events)-httpSessionReplayEvents:]
+	}
+	return session.lastEventID
+}
+
+// handler wraps the streamable HTTP transport to enforce the session limit and to make the event streams resumable
+func (hs *httpSessions) handler(streamableHTTPServer *server.StreamableHTTPServer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.Header.Get(server.HeaderKeySessionID)
+		switch {
+		case r.Method == http.MethodPost && sessionID == "" && !hs.available():
+			klog.V(1).Infof("rejecting new streamable HTTP session, the limit of %d concurrent sessions is reached", hs.limit)
+			http.Error(w, fmt.Sprintf("Too many sessions, the server is limited to %d concurrent sessions", hs.limit), http.StatusServiceUnavailable)
+		case r.Method == http.MethodGet:
+			if sessionID == "" {
+				http.Error(w, "Missing session ID", http.StatusBadRequest)
+				return
+			}
+			lastEventID, _ := strconv.ParseUint(r.Header.Get(headerLastEventID), 10, 64)
+			replay, ok := hs.openStream(sessionID, lastEventID)
+			if !ok {
+				http.Error(w, "Session terminated", http.StatusNotFound)
+				return
+			}
+			defer hs.closeStream(sessionID)
+			streamableHTTPServer.ServeHTTP(&resumableStream{ResponseWriter: w, sessions: hs, sessionID: sessionID, replay: replay}, r)
+		default:
+			streamableHTTPServer.ServeHTTP(w, r)
+		}
+	})
+}
+
+// resumableStream assigns an ID to each event written to the event stream of a session and records it for replay,
+// the events missed by the client are replayed once the stream headers are written
+type resumableStream struct {
+	http.ResponseWriter
+	sessions  *httpSessions
+	sessionID string
+	replay    []httpSessionEvent
+	// streaming is true once the event stream is established, other responses (e.g. errors) are written as is
+	streaming bool
+	// pending is the incomplete event being written
+	pending []byte
+}
+
+func (rs *resumableStream) WriteHeader(statusCode int) {
+	rs.ResponseWriter.WriteHeader(statusCode)
+	if statusCode != http.StatusOK {
+		return
+	}
+	rs.streaming = true
+	for _, event := range rs.replay {
+		if _, err := rs.writeEvent(event.id, event.data); err != nil {
+			return
+		}
+	}
+	rs.replay = nil
+}
+
+func (rs *resumableStream) Write(p []byte) (int, error) {
+	if !rs.streaming {
+		return rs.ResponseWriter.Write(p)
+	}
+	rs.pending = append(rs.pending, p...)
+	for {
+		end := bytes.Index(rs.pending, []byte("\n\n"))
+		if end < 0 {
+			return len(p), nil
+		}
+		event := bytes.Clone(rs.pending[:end+2])
+		rs.pending = rs.pending[end+2:]
+		if _, err := rs.writeEvent(rs.sessions.record(rs.sessionID, event), event); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (rs *resumableStream) writeEvent(id uint64, event []byte) (int, error) {
+	return fmt.Fprintf(rs.ResponseWriter, "id: %d\n%s", id, event)
+}
+
+func (rs *resumableStream) Flush() {
+	if flusher, ok := rs.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestHttpSessions(t *testing.T) {
+	now := time.Now()
+	sessions := newHttpSessions(2)
+	sessions.now = func() time.Time { return now }
+	first, second := sessions.Generate(), sessions.Generate()
+	t.Run("Generates unique session IDs", func(t *testing.T) {
+		if !strings.HasPrefix(first, httpSessionIDPrefix) || first == second {
+			t.Errorf("unexpected session IDs %s and %s", first, second)
+		}
+	})
+	t.Run("Unknown sessions are terminated", func(t *testing.T) {
+		if isTerminated, err := sessions.Validate("mcp-session-unknown"); !isTerminated || err != nil {
+			t.Errorf("expected unknown session to be terminated, got %t %v", isTerminated, err)
+		}
+	})
+	t.Run("Limits the concurrent sessions", func(t *testing.T) {
+		if sessions.available() {
+			t.Errorf("expected no session to be available")
+		}
+	})
+	t.Run("Terminated sessions release their slot", func(t *testing.T) {
+		_, _ = sessions.Terminate(second)
+		if isTerminated, _ := sessions.Validate(second); !isTerminated {
+			t.Errorf("expected session to be terminated")
+		}
+		if !sessions.available() {
+			t.Errorf("expected a session to be available")
+		}
+	})
+	t.Run("Idle sessions are discarded", func(t *testing.T) {
+		second = sessions.Generate()
+		_, _ = sessions.openStream(second, 0)
+		now = now.Add(httpSessionIdleTimeout + time.Second)
+		if !sessions.available() {
+			t.Errorf("expected the idle session to be discarded")
+		}
+		if isTerminated, _ := sessions.Validate(first); !isTerminated {
+			t.Errorf("expected the idle session to be terminated")
+		}
+		if isTerminated, _ := sessions.Validate(second); isTerminated {
+			t.Errorf("expected the session with an open stream to be kept")
+		}
+	})
+	t.Run("Keeps the last events for replay", func(t *testing.T) {
+		for i := 0; i < httpSessionReplayEvents+5; i++ {
+			sessions.record(second, []byte("event: message\ndata: {}\n\n"))
+		}
+		replay, _ := sessions.openStream(second, httpSessionReplayEvents+3)
+		if len(replay) != 2 || replay[0].id != httpSessionReplayEvents+4 {
+			t.Errorf("expected the events after the last event ID, got %v", replay)
+		}
+		replay, _ = sessions.openStream(second, 0)
+		if len(replay) != httpSessionReplayEvents || replay[0].id != 6 {
+			t.Errorf("expected the last %d events, got %d", httpSessionReplayEvents, len(replay))
+		}
+	})
+}
+
+func TestStatefulHttp(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "0.0.1")
+	sessions := newHttpSessions(1)
+	testServer := httptest.NewServer(sessions.handler(server.NewStreamableHTTPServer(mcpServer, server.WithSessionIdManager(sessions))))
+	t.Cleanup(testServer.Close)
+	post := func(sessionID, body string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, testServer.URL+"/mcp", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if sessionID != "" {
+			req.Header.Set(server.HeaderKeySessionID, sessionID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to post: %v", err)
+		}
+		_ = resp.Body.Close()
+		return resp
+	}
+	// listen opens the event stream of the session, and returns the first event once the notification is sent
+	listen := func(sessionID, lastEventID string, notify bool) (int, string) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, testServer.URL+"/mcp", nil)
+		req.Header.Set(server.HeaderKeySessionID, sessionID)
+		if lastEventID != "" {
+			req.Header.Set(headerLastEventID, lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to listen: %v", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, ""
+		}
+		if notify {
+			if err = mcpServer.SendNotificationToSpecificClient(sessionID, "notifications/message", map[string]any{"data": "hello"}); err != nil {
+				t.Fatalf("failed to notify: %v", err)
+			}
+		}
+		event := ""
+		for scanner := bufio.NewScanner(resp.Body); scanner.Scan() && scanner.Text() != ""; {
+			event += scanner.Text() + "\n"
+		}
+		return resp.StatusCode, event
+	}
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"test","version":"0.0.1"}}}`
+	resp := post("", initialize)
+	sessionID := resp.Header.Get(server.HeaderKeySessionID)
+	t.Run("Initialize returns a session ID", func(t *testing.T) {
+		if resp.StatusCode != http.StatusOK || sessionID == "" {
+			t.Fatalf("expected a session ID, got %d %q", resp.StatusCode, sessionID)
+		}
+	})
+	t.Run("Initialize beyond the session limit is rejected", func(t *testing.T) {
+		if resp := post("", initialize); resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("expected status 503, got %d", resp.StatusCode)
+		}
+	})
+	t.Run("Requests of unknown sessions are rejected", func(t *testing.T) {
+		if resp := post("mcp-session-unknown", `{"jsonrpc":"2.0","id":2,"method":"ping"}`); resp.StatusCode != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", resp.StatusCode)
+		}
+	})
+	t.Run("Events are sent with an ID", func(t *testing.T) {
+		if _, event := listen(sessionID, "", true); !strings.HasPrefix(event, "id: 1\nevent: message\ndata: ") {
+			t.Errorf("unexpected event %q", event)
+		}
+	})
+	t.Run("Events after the Last-Event-ID are replayed", func(t *testing.T) {
+		// The previous stream is unregistered once the server notices the client disconnection
+		status, event := listen(sessionID, "0", false)
+		for i := 0; status == http.StatusBadRequest && i < 50; i++ {
+			time.Sleep(10 * time.Millisecond)
+			status, event = listen(sessionID, "0", false)
+		}
+		if !strings.HasPrefix(event, "id: 1\nevent: message\ndata: ") || !strings.Contains(event, "hello") {
+			t.Errorf("unexpected replayed event %d %q", status, event)
+		}
+	})
+}
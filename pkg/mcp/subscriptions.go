@@ -0,0 +1,328 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+const (
+	// DefaultResourceSubscriptionLimit is the default maximum number of resource subscriptions of each session
+	DefaultResourceSubscriptionLimit = 10
+	// resourceUpdateCoalesceWindow is the time the updates of a subscribed object are coalesced into a single notification
+	resourceUpdateCoalesceWindow = time.Second
+
+	methodResourcesSubscribe   mcp.MCPMethod = "resources/subscribe"
+	methodResourcesUnsubscribe mcp.MCPMethod = "resources/unsubscribe"
+)
+
+func resourceSubscriptionLimit(staticConfig *config.StaticConfig) int {
+	if staticConfig.ResourceSubscriptionLimit > 0 {
+		return staticConfig.ResourceSubscriptionLimit
+	}
+	return DefaultResourceSubscriptionLimit
+}
+
+// resourceSubscriptions keeps the resource subscriptions of each session.
+// The subscribed objects are watched by informers shared by the sessions subscribed to the same object with the same
+// identity, the rapid updates of an object are coalesced into a single notifications/resources/updated notification.
+type resourceSubscriptions struct {
+	mu             sync.Mutex
+	limit          int
+	coalesceWindow time.Duration
+	// notify sends the notifications/resources/updated notification of the URI to the session
+	notify func(sessionID, uri string)
+	// active sessions, subscriptions can only be registered on behalf of an active session
+	active map[string]bool
+	// sessions subscriptions keyed by session ID and resource URI
+	sessions map[string]map[string]*resourceWatch
+	watches  map[resourceWatchKey]*resourceWatch
+}
+
+type resourceWatchKey struct {
+	// identity the object is watched with, the informers are only shared by the sessions with the same identity
+	identity string
+	uri      string
+}
+
+type resourceWatch struct {
+	key         resourceWatchKey
+	stop        chan struct{}
+	subscribers map[string]bool
+	// pending is true while a coalesced notification is scheduled
+	pending bool
+}
+
+func newResourceSubscriptions(limit int) *resourceSubscriptions {
+	return &resourceSubscriptions{
+		limit:          limit,
+		coalesceWindow: resourceUpdateCoalesceWindow,
+		notify:         func(string, string) {},
+		active:         make(map[string]bool),
+		sessions:       make(map[string]map[string]*resourceWatch),
+		watches:        make(map[resourceWatchKey]*resourceWatch),
+	}
+}
+
+// addHooks adds the server hooks tracking the active sessions, the subscriptions of a session are discarded when it's closed
+func (rs *resourceSubscriptions) addHooks(hooks *server.Hooks) {
+	hooks.AddOnRegisterSession(func(_ context.Context, session server.ClientSession) {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+		rs.active[session.SessionID()] = true
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+		delete(rs.active, session.SessionID())
+		for uri := range rs.sessions[session.SessionID()] {
+			rs.remove(session.SessionID(), uri)
+		}
+	})
+}
+
+// subscribe subscribes the session to the updates of the object, the informer is only created if no other session
+// is already watching the object with the same identity
+func (rs *resourceSubscriptions) subscribe(sessionID string, key resourceWatchKey, newInformer func() (cache.SharedIndexInformer, error)) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if !rs.active[sessionID] {
+		return fmt.Errorf("session %s not found", sessionID)
+	}
+	subscriptions := rs.sessions[sessionID]
+	if _, ok := subscriptions[key.uri]; ok {
+		return nil
+	}
+	if len(subscriptions) >= rs.limit {
+		return fmt.Errorf("subscription limit reached, a session can't subscribe to more than %d resources", rs.limit)
+	}
+	watch, ok := rs.watches[key]
+	if !ok {
+		informer, err := newInformer()
+		if err != nil {
+			return err
+		}
+		watch = &resourceWatch{key: key, stop: make(chan struct{}), subscribers: make(map[string]bool)}
+		if _, err = informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+			AddFunc: func(_ interface{}, isInInitialList bool) {
+				if !isInInitialList {
+					rs.updated(watch)
+				}
+			},
+			UpdateFunc: func(_, _ interface{}) { rs.updated(watch) },
+			DeleteFunc: func(_ interface{}) { rs.updated(watch) },
+		}); err != nil {
+			return err
+		}
+		go informer.Run(watch.stop)
+		rs.watches[key] = watch
+	}
+	watch.subscribers[sessionID] = true
+	if subscriptions == nil {
+		subscriptions = make(map[string]*resourceWatch)
+		rs.sessions[sessionID] = subscriptions
+	}
+	subscriptions[key.uri] = watch
+	return nil
+}
+
+// unsubscribe unsubscribes the session from the updates of the URI, the informer is stopped when no session is left
+func (rs *resourceSubscriptions) unsubscribe(sessionID, uri string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.remove(sessionID, uri)
+}
+
+// close stops all the informers
+func (rs *resourceSubscriptions) close() {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	for sessionID := range rs.sessions {
+		for uri := range rs.sessions[sessionID] {
+			rs.remove(sessionID, uri)
+		}
+	}
+}
+
+// remove discards a subscription, the caller must hold the lock
+func (rs *resourceSubscriptions) remove(sessionID, uri string) {
+	watch, ok := rs.sessions[sessionID][uri]
+	if !ok {
+		return
+	}
+	delete(rs.sessions[sessionID], uri)
+	if len(rs.sessions[sessionID]) == 0 {
+		delete(rs.sessions, sessionID)
+	}
+	delete(watch.subscribers, sessionID)
+	if len(watch.subscribers) == 0 {
+		close(watch.stop)
+		delete(rs.watches, watch.key)
+	}
+}
+
+// updated schedules the notification of the subscribers of the watched object, unless one is already scheduled
+func (rs *resourceSubscriptions) updated(watch *resourceWatch) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if watch.pending {
+		return
+	}
+	watch.pending = true
+	time.AfterFunc(rs.coalesceWindow, func() {
+		rs.mu.Lock()
+		watch.pending = false
+		subscribers := make([]string, 0, len(watch.subscribers))
+		for sessionID := range watch.subscribers {
+			subscribers = append(subscribers, sessionID)
+		}
+		rs.mu.Unlock()
+		for _, sessionID := range subscribers {
+			rs.notify(sessionID, watch.key.uri)
+		}
+	})
+}
+
+// notifyResourceUpdated sends the notifications/resources/updated notification to the subscribed session
+func (s *Server) notifyResourceUpdated(sessionID, uri string) {
+	if err := s.server.SendNotificationToSpecificClient(sessionID, "notifications/resources/updated", map[string]any{"uri": uri}); err != nil {
+		klog.V(2).Infof("failed to notify session %s of the update of %s: %v", sessionID, uri, err)
+	}
+}
+
+// subscribeResource subscribes the session to the updates of the object of the URI, the object must be readable with
+// the identity of the session
+func (s *Server) subscribeResource(ctx context.Context, sessionID, rawURI string) error {
+	uri, err := parseResourceURI(rawURI)
+	if err != nil {
+		return err
+	}
+	if uri.cluster != resourceLocalCluster {
+		return fmt.Errorf("failed to subscribe to resource %s: only the objects of the %s cluster support subscriptions", rawURI, resourceLocalCluster)
+	}
+	k, err := s.sessions.derived(ctx, s.k)
+	if err != nil {
+		return err
+	}
+	gvk, err := k.KindFor(uri.kind)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to resource %s: %w", rawURI, err)
+	}
+	if _, err = k.ResourcesGet(ctx, gvk, uri.namespace, uri.name); err != nil {
+		return fmt.Errorf("failed to subscribe to resource %s: %w", rawURI, err)
+	}
+	key := resourceWatchKey{identity: k.GetAPIServerHost() + "|" + k.GetBearerToken(), uri: rawURI}
+	return s.subscriptions.subscribe(sessionID, key, func() (cache.SharedIndexInformer, error) {
+		return k.ResourceInformer(gvk, uri.namespace, uri.name)
+	})
+}
+
+// subscriptionRequest is a resources/subscribe or resources/unsubscribe JSON-RPC request
+type subscriptionRequest struct {
+	ID     mcp.RequestId `json:"id"`
+	Method mcp.MCPMethod `json:"method"`
+	Params struct {
+		URI string `json:"uri"`
+	} `json:"params"`
+}
+
+// handleSubscriptionRequest handles the resources/subscribe and resources/unsubscribe requests, which are not dispatched
+// by the MCP server implementation, returns false if the message is not one of them
+func (s *Server) handleSubscriptionRequest(ctx context.Context, sessionID string, message []byte) (mcp.JSONRPCMessage, bool) {
+	request := subscriptionRequest{}
+	if err := json.Unmarshal(message, &request); err != nil || request.ID.IsNil() {
+		return nil, false
+	}
+	switch request.Method {
+	case methodResourcesSubscribe:
+		if err := s.subscribeResource(ctx, sessionID, request.Params.URI); err != nil {
+			return mcp.NewJSONRPCError(request.ID, mcp.INVALID_PARAMS, err.Error(), nil), true
+		}
+	case methodResourcesUnsubscribe:
+		s.subscriptions.unsubscribe(sessionID, request.Params.URI)
+	default:
+		return nil, false
+	}
+	return mcp.NewJSONRPCResultResponse(request.ID, mcp.EmptyResult{}), true
+}
+
+// stdioSubscriptions returns the stdin of the stdio transport without the resource subscription requests, these are
+// handled once the stdio session is provided and answered through stdout
+func (s *Server) stdioSubscriptions(sessionCtx <-chan context.Context, stdin io.Reader, stdout io.Writer) io.Reader {
+	reader, writer := io.Pipe()
+	go func() {
+		ctx := <-sessionCtx
+		sessionID := server.ClientSessionFromContext(ctx).SessionID()
+		lines := bufio.NewReader(stdin)
+		for {
+			line, err := lines.ReadBytes('\n')
+			if len(line) > 0 {
+				if response, ok := s.handleSubscriptionRequest(ctx, sessionID, line); ok {
+					if data, marshalErr := json.Marshal(response); marshalErr == nil {
+						_, _ = stdout.Write(append(data, '\n'))
+					}
+				} else if _, writeErr := writer.Write(line); writeErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				_ = writer.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return reader
+}
+
+// syncWriter serializes the writes of the stdio transport and of the resource subscription responses
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// SseSubscriptions wraps the SSE transport to handle the resource subscription requests,
+// their responses are sent through the event stream of the session like the rest of the responses
+func (s *Server) SseSubscriptions(sseServer *server.SSEServer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.URL.Query().Get("sessionId")
+		if r.Method != http.MethodPost || sessionID == "" {
+			sseServer.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		response, ok := s.handleSubscriptionRequest(contextFunc(r.Context(), r), sessionID, body)
+		if !ok {
+			sseServer.ServeHTTP(w, r)
+			return
+		}
+		if err = sseServer.SendEventToSession(sessionID, response); err != nil {
+			http.Error(w, "Invalid session ID", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	fcache "k8s.io/client-go/tools/cache/testing"
+)
+
+func TestResourceSubscriptions(t *testing.T) {
+	source := fcache.NewFakeControllerSource()
+	source.Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a-configmap", Namespace: "default"}})
+	informers := 0
+	newInformer := func() (cache.SharedIndexInformer, error) {
+		informers++
+		return cache.NewSharedIndexInformer(source, &corev1.ConfigMap{}, 0, cache.Indexers{}), nil
+	}
+	newNamespaceInformer := func() (cache.SharedIndexInformer, error) {
+		informers++
+		return cache.NewSharedIndexInformer(fcache.NewFakeControllerSource(), &corev1.Namespace{}, 0, cache.Indexers{}), nil
+	}
+	notifications := make(chan string, 10)
+	subscriptions := newResourceSubscriptions(2)
+	subscriptions.coalesceWindow = 50 * time.Millisecond
+	subscriptions.notify = func(sessionID, uri string) { notifications <- sessionID + " " + uri }
+	hooks := &server.Hooks{}
+	subscriptions.addHooks(hooks)
+	for _, session := range []server.ClientSession{server.NewInProcessSession("session-1", nil), server.NewInProcessSession("session-2", nil)} {
+		for _, hook := range hooks.OnRegisterSession {
+			hook(context.Background(), session)
+		}
+	}
+	key := resourceWatchKey{identity: "an-identity", uri: "k8s://local/default/ConfigMap/a-configmap"}
+	t.Run("Inactive sessions can't subscribe", func(t *testing.T) {
+		if err := subscriptions.subscribe("unknown-session", key, newInformer); err == nil || err.Error() != "session unknown-session not found" {
+			t.Fatalf("expected session not found error, got %v", err)
+		}
+	})
+	t.Run("Sessions with the same identity share the informer", func(t *testing.T) {
+		for _, sessionID := range []string{"session-1", "session-2", "session-2"} {
+			if err := subscriptions.subscribe(sessionID, key, newInformer); err != nil {
+				t.Fatalf("failed to subscribe: %v", err)
+			}
+		}
+		if informers != 1 {
+			t.Errorf("expected a single informer, got %d", informers)
+		}
+		if err := subscriptions.subscribe("session-1", resourceWatchKey{identity: "another-identity", uri: "k8s://local/_/Namespace/default"}, newNamespaceInformer); err != nil {
+			t.Fatalf("failed to subscribe: %v", err)
+		}
+		if informers != 2 {
+			t.Errorf("expected an informer per identity, got %d", informers)
+		}
+	})
+	t.Run("Subscriptions are limited per session", func(t *testing.T) {
+		err := subscriptions.subscribe("session-1", resourceWatchKey{identity: "an-identity", uri: "k8s://local/_/Namespace/kube-system"}, newNamespaceInformer)
+		if err == nil || err.Error() != "subscription limit reached, a session can't subscribe to more than 2 resources" {
+			t.Fatalf("expected subscription limit error, got %v", err)
+		}
+	})
+	t.Run("Rapid updates are coalesced into a single notification per session", func(t *testing.T) {
+		// Wait for the informers to list the initial objects (which are not notified)
+		time.Sleep(200 * time.Millisecond)
+		for i := 0; i < 5; i++ {
+			source.Modify(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a-configmap", Namespace: "default"}})
+		}
+		received := map[string]int{}
+		timeout := time.After(500 * time.Millisecond)
+	loop:
+		for {
+			select {
+			case notification := <-notifications:
+				received[notification]++
+			case <-timeout:
+				break loop
+			}
+		}
+		if len(received) != 2 || received["session-1 "+key.uri] != 1 || received["session-2 "+key.uri] != 1 {
+			t.Errorf("expected a single notification per session, got %v", received)
+		}
+	})
+	t.Run("Informer is stopped when the last session unsubscribes", func(t *testing.T) {
+		subscriptions.unsubscribe("session-1", key.uri)
+		if _, ok := subscriptions.watches[key]; !ok {
+			t.Fatalf("expected the informer to keep running for session-2")
+		}
+		for _, hook := range hooks.OnUnregisterSession {
+			hook(context.Background(), server.NewInProcessSession("session-2", nil))
+		}
+		if _, ok := subscriptions.watches[key]; ok {
+			t.Errorf("expected the informer to be stopped")
+		}
+		if _, ok := subscriptions.sessions["session-2"]; ok {
+			t.Errorf("expected the subscriptions of the closed session to be discarded")
+		}
+	})
+	subscriptions.close()
+}
+
+func TestStdioSubscriptions(t *testing.T) {
+	s := &Server{subscriptions: newResourceSubscriptions(DefaultResourceSubscriptionLimit)}
+	sessionCtx := make(chan context.Context, 1)
+	sessionCtx <- server.NewMCPServer("test", "0.0.1").WithContext(context.Background(), server.NewInProcessSession("stdio", nil))
+	stdout := &bytes.Buffer{}
+	stdin := strings.NewReader("" +
+		`{"jsonrpc":"2.0","id":1,"method":"tools/list"}` + "\n" +
+		`{"jsonrpc":"2.0","id":2,"method":"resources/unsubscribe","params":{"uri":"k8s://local/_/Namespace/default"}}` + "\n" +
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	forwarded, err := io.ReadAll(s.stdioSubscriptions(sessionCtx, stdin, &syncWriter{w: stdout}))
+	if err != nil {
+		t.Fatalf("failed to read stdin: %v", err)
+	}
+	t.Run("Forwards the rest of the messages to the MCP server", func(t *testing.T) {
+		expected := `{"jsonrpc":"2.0","id":1,"method":"tools/list"}` + "\n" + `{"jsonrpc":"2.0","method":"notifications/initialized"}`
+		if string(forwarded) != expected {
+			t.Errorf("unexpected forwarded messages %s", forwarded)
+		}
+	})
+	t.Run("Answers the subscription requests through stdout", func(t *testing.T) {
+		if stdout.String() != `{"jsonrpc":"2.0","id":2,"result":{}}`+"\n" {
+			t.Errorf("unexpected response %s", stdout.String())
+		}
+	})
+}
+
+type SubscriptionsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *SubscriptionsSuite) TestSubscribeResource() {
+	client := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	configMap, _ := client.CoreV1().ConfigMaps("default").Create(s.T().Context(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-configmap-to-watch"},
+	}, metav1.CreateOptions{})
+	s.InitMcpClient()
+	notifications := make(chan string, 10)
+	s.mcpServer.subscriptions.notify = func(_, uri string) { notifications <- uri }
+	s.mcpServer.subscriptions.active["a-session"] = true
+	uri := "k8s://local/default/configmaps/a-configmap-to-watch"
+	s.Run("subscribe to non-existent object returns error", func() {
+		response, ok := s.mcpServer.handleSubscriptionRequest(s.T().Context(), "a-session",
+			[]byte(`{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"k8s://local/default/configmaps/non-existent"}}`))
+		s.True(ok)
+		s.IsType(mcp.JSONRPCError{}, response)
+	})
+	s.Run("subscribe to object", func() {
+		_, ok := s.mcpServer.handleSubscriptionRequest(s.T().Context(), "a-session",
+			[]byte(`{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"`+uri+`"}}`))
+		s.True(ok)
+		s.Contains(s.mcpServer.subscriptions.sessions["a-session"], uri)
+	})
+	s.Run("object updates are notified", func() {
+		s.Eventually(func() bool {
+			configMap.Data = map[string]string{"updated": time.Now().String()}
+			configMap, _ = client.CoreV1().ConfigMaps("default").Update(s.T().Context(), configMap, metav1.UpdateOptions{})
+			select {
+			case notification := <-notifications:
+				return notification == uri
+			case <-time.After(2 * resourceUpdateCoalesceWindow):
+				return false
+			}
+		}, 10*time.Second, 100*time.Millisecond)
+	})
+}
+
+func TestSubscriptions(t *testing.T) {
+	suite.Run(t, new(SubscriptionsSuite))
+}
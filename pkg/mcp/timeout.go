@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// toolTimeout returns the timeout of the calls to the provided tool, zero if the calls don't time out
+func (c *Configuration) toolTimeout(tool *api.ServerTool) time.Duration {
+	if timeout, ok := c.ToolTimeouts[tool.Tool.Name]; ok {
+		return timeout
+	}
+	if tool.Timeout > 0 {
+		return tool.Timeout
+	}
+	if ptr.Deref(tool.Tool.Annotations.ReadOnlyHint, false) {
+		return api.DefaultListTimeout
+	}
+	return 0
+}
+
+// timeoutMiddleware enforces the timeout of the tool calls through the ToolHandlerParams context,
+// the in-flight Kubernetes and ACM proxy requests are canceled when the timeout expires
+func timeoutMiddleware(configuration *Configuration) api.ToolMiddleware {
+	return func(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+		return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			timeout := configuration.toolTimeout(params.Tool)
+			if timeout <= 0 {
+				return next(params)
+			}
+			ctx, cancel := context.WithTimeout(params.Context, timeout)
+			defer cancel()
+			params.Context = ctx
+			result, err := next(params)
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeTimeout, true,
+					fmt.Errorf("tool %s timed out after %s", params.Tool.Tool.Name, timeout))), nil
+			}
+			return result, err
+		}
+	}
+}
+
+// inFlightCalls tracks the in-flight tool calls of each session to honor the MCP cancellation notifications
+type inFlightCalls struct {
+	mu sync.Mutex
+	// pending request IDs, keyed by the request metadata (shared by the hook and handler copies of the request)
+	pending map[*mcp.Meta]string
+	calls   map[inFlightCall]context.CancelFunc
+}
+
+type inFlightCall struct {
+	sessionID string
+	requestID string
+}
+
+func newInFlightCalls() *inFlightCalls {
+	return &inFlightCalls{
+		pending: make(map[*mcp.Meta]string),
+		calls:   make(map[inFlightCall]context.CancelFunc),
+	}
+}
+
+// addHooks adds the server hooks tracking the request IDs of the tool calls
+func (f *inFlightCalls) addHooks(hooks *server.Hooks) {
+	hooks.AddBeforeCallTool(func(_ context.Context, id any, request *mcp.CallToolRequest) {
+		if request.Params.Meta == nil {
+			request.Params.Meta = &mcp.Meta{}
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.pending[request.Params.Meta] = requestID(id)
+	})
+	hooks.AddAfterCallTool(func(_ context.Context, _ any, request *mcp.CallToolRequest, _ *mcp.CallToolResult) {
+		f.release(request)
+	})
+	hooks.AddOnError(func(_ context.Context, _ any, _ mcp.MCPMethod, message any, _ error) {
+		if request, ok := message.(*mcp.CallToolRequest); ok {
+			f.release(request)
+		}
+	})
+}
+
+// release forgets the request ID of a completed tool call
+func (f *inFlightCalls) release(request *mcp.CallToolRequest) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.pending, request.Params.Meta)
+}
+
+// track makes the provided tool call cancellable, the returned function must be called when the call completes.
+// Only the calls of the stateful sessions are tracked, the calls of the stateless transports are canceled when the
+// client closes the HTTP request.
+func (f *inFlightCalls) track(ctx context.Context, request mcp.CallToolRequest) (context.Context, func()) {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil || session.SessionID() == "" {
+		return ctx, func() {}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id, ok := f.pending[request.Params.Meta]
+	if !ok {
+		return ctx, func() {}
+	}
+	call := inFlightCall{sessionID: session.SessionID(), requestID: id}
+	ctx, cancel := context.WithCancel(ctx)
+	f.calls[call] = cancel
+	return ctx, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.calls, call)
+		cancel()
+	}
+}
+
+// cancel handles the notifications/cancelled notification by canceling the referenced in-flight tool call
+func (f *inFlightCalls) cancel(ctx context.Context, notification mcp.JSONRPCNotification) {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil || session.SessionID() == "" {
+		return
+	}
+	id, ok := notification.Params.AdditionalFields["requestId"]
+	if !ok {
+		return
+	}
+	call := inFlightCall{sessionID: session.SessionID(), requestID: requestID(id)}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if cancel, ok := f.calls[call]; ok {
+		klog.V(3).Infof("tool call %s canceled by the client: %v", call.requestID, notification.Params.AdditionalFields["reason"])
+		cancel()
+	}
+}
+
+// requestID normalizes the JSON-RPC request ID (numeric IDs are decoded as float64 in the notifications)
+func requestID(id any) string {
+	if requestId, ok := id.(mcp.RequestId); ok {
+		return requestId.String()
+	}
+	return mcp.NewRequestId(id).String()
+}
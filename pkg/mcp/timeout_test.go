@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+func TestToolTimeout(t *testing.T) {
+	configuration := &Configuration{StaticConfig: &config.StaticConfig{
+		ToolTimeouts: map[string]time.Duration{"pods_list": time.Second, "events_list": 0},
+	}}
+	readOnly := func(name string, timeout time.Duration) *api.ServerTool {
+		return &api.ServerTool{Tool: api.Tool{Name: name, Annotations: api.ToolAnnotations{ReadOnlyHint: ptr.To(true)}}, Timeout: timeout}
+	}
+	t.Run("Read-only tools default to the list timeout", func(t *testing.T) {
+		if timeout := configuration.toolTimeout(readOnly("namespaces_list", 0)); timeout != api.DefaultListTimeout {
+			t.Errorf("expected %s, got %s", api.DefaultListTimeout, timeout)
+		}
+	})
+	t.Run("Tool timeout overrides the category default", func(t *testing.T) {
+		if timeout := configuration.toolTimeout(readOnly("pods_log", api.DefaultLogsTimeout)); timeout != api.DefaultLogsTimeout {
+			t.Errorf("expected %s, got %s", api.DefaultLogsTimeout, timeout)
+		}
+	})
+	t.Run("Mutating tools don't time out by default", func(t *testing.T) {
+		if timeout := configuration.toolTimeout(&api.ServerTool{Tool: api.Tool{Name: "resources_delete"}}); timeout != 0 {
+			t.Errorf("expected no timeout, got %s", timeout)
+		}
+	})
+	t.Run("Configured timeouts override the tool timeout", func(t *testing.T) {
+		if timeout := configuration.toolTimeout(readOnly("pods_list", 0)); timeout != time.Second {
+			t.Errorf("expected 1s, got %s", timeout)
+		}
+		if timeout := configuration.toolTimeout(readOnly("events_list", 0)); timeout != 0 {
+			t.Errorf("expected timeout to be disabled, got %s", timeout)
+		}
+	})
+	t.Run("Expired timeout cancels the call and returns a Timeout error", func(t *testing.T) {
+		configuration := &Configuration{StaticConfig: &config.StaticConfig{
+			ToolTimeouts: map[string]time.Duration{"slow_tool": 10 * time.Millisecond},
+		}}
+		handler := timeoutMiddleware(configuration)(func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			<-params.Done()
+			return api.NewToolCallResult("", params.Err()), nil
+		})
+		result, err := handler(api.ToolHandlerParams{Context: context.Background(), Tool: &api.ServerTool{Tool: api.Tool{Name: "slow_tool"}}})
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if result.Error == nil || result.Error.Error() != "tool slow_tool timed out after 10ms" {
+			t.Fatalf("unexpected result error %v", result.Error)
+		}
+		if result.ErrorCode != api.ErrorCodeTimeout || !result.Retryable {
+			t.Errorf("expected retryable Timeout error, got %s", result.ErrorCode)
+		}
+	})
+}
+
+func TestInFlightCalls(t *testing.T) {
+	inFlight := newInFlightCalls()
+	hooks := &server.Hooks{}
+	inFlight.addHooks(hooks)
+	mcpServer := server.NewMCPServer("test", "0.0.1")
+	session := server.NewInProcessSession("a-session", nil)
+	ctx := mcpServer.WithContext(context.Background(), session)
+	request := mcp.CallToolRequest{}
+	for _, hook := range hooks.OnBeforeCallTool {
+		hook(ctx, mcp.NewRequestId(int64(7)), &request)
+	}
+	callCtx, done := inFlight.track(ctx, request)
+	defer done()
+	t.Run("Cancellation of another request is ignored", func(t *testing.T) {
+		inFlight.cancel(ctx, mcp.JSONRPCNotification{Notification: mcp.Notification{
+			Method: "notifications/cancelled",
+			Params: mcp.NotificationParams{AdditionalFields: map[string]any{"requestId": float64(8)}},
+		}})
+		if callCtx.Err() != nil {
+			t.Fatalf("unexpected cancellation")
+		}
+	})
+	t.Run("Cancellation from another session is ignored", func(t *testing.T) {
+		otherCtx := mcpServer.WithContext(context.Background(), server.NewInProcessSession("another-session", nil))
+		inFlight.cancel(otherCtx, mcp.JSONRPCNotification{Notification: mcp.Notification{
+			Method: "notifications/cancelled",
+			Params: mcp.NotificationParams{AdditionalFields: map[string]any{"requestId": float64(7)}},
+		}})
+		if callCtx.Err() != nil {
+			t.Fatalf("unexpected cancellation")
+		}
+	})
+	t.Run("Cancellation notification cancels the in-flight call", func(t *testing.T) {
+		inFlight.cancel(ctx, mcp.JSONRPCNotification{Notification: mcp.Notification{
+			Method: "notifications/cancelled",
+			Params: mcp.NotificationParams{AdditionalFields: map[string]any{"requestId": float64(7)}},
+		}})
+		if callCtx.Err() == nil {
+			t.Fatalf("expected the call to be canceled")
+		}
+	})
+}
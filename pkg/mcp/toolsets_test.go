@@ -11,9 +11,15 @@ import (
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	configuration "github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/acm"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/core"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/cost"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/crd"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/helm"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/metrics"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/network"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/storage"
 )
 
 type ToolsetsSuite struct {
@@ -100,9 +106,15 @@ func (s *ToolsetsSuite) TestDefaultToolsetsToolsInOpenShift() {
 
 func (s *ToolsetsSuite) TestGranularToolsetsTools() {
 	testCases := []api.Toolset{
+		&acm.Toolset{},
 		&core.Toolset{},
+		&cost.Toolset{},
+		&crd.Toolset{},
 		&config.Toolset{},
 		&helm.Toolset{},
+		&metrics.Toolset{},
+		&network.Toolset{},
+		&storage.Toolset{},
 	}
 	for _, testCase := range testCases {
 		s.Run("Toolset "+testCase.GetName(), func() {
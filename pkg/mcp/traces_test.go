@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/yaml"
+
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type TracesSuite struct {
+	BaseMcpSuite
+	jaeger *httptest.Server
+}
+
+func (s *TracesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.Toolsets = []string{"metrics"}
+	s.jaeger = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/traces" || r.URL.Query().Get("service") != "api" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"traceID":"j1","spans":[
+			{"spanID":"a","operationName":"HTTP GET","startTime":1748772000000000,"duration":300000,"processID":"p1"},
+			{"spanID":"b","operationName":"query","references":[{"refType":"CHILD_OF","spanID":"a"}],"startTime":1748772000010000,"duration":250000,"processID":"p2"}],
+			"processes":{"p1":{"serviceName":"api","tags":[{"key":"k8s.pod.name","value":"api-1"},{"key":"k8s.namespace.name","value":"ns-1"}]},"p2":{"serviceName":"db"}}}]}`))
+	}))
+	s.Cfg.JaegerURL = s.jaeger.URL
+}
+
+func (s *TracesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	s.jaeger.Close()
+}
+
+func (s *TracesSuite) TestTracesSearch() {
+	s.InitMcpClient()
+	s.Run("traces_search(service=api)", func() {
+		toolResult, err := s.CallTool("traces_search", map[string]interface{}{"service": "api", "minDuration": "100ms"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		var ret internalk8s.TracesSearchResult
+		s.Require().NoError(yaml.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), &ret))
+		s.Require().Len(ret.Traces, 1)
+		s.Run("returns the condensed span tree", func() {
+			s.Equal("300ms", ret.Traces[0].Duration)
+			s.Equal([]string{"ns-1/api-1"}, ret.Traces[0].Pods)
+			s.Require().Len(ret.Traces[0].Tree.Children, 1)
+			s.Equal("db", ret.Traces[0].Tree.Children[0].Service)
+		})
+	})
+	s.Run("traces_search(minDuration=invalid)", func() {
+		toolResult, _ := s.CallTool("traces_search", map[string]interface{}{"service": "api", "minDuration": "slow"})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(mcp.TextContent).Text, "failed to search traces: invalid duration")
+	})
+}
+
+func TestTraces(t *testing.T) {
+	suite.Run(t, new(TracesSuite))
+}
@@ -0,0 +1,44 @@
+package mcp
+
+import (
+	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/tracing"
+)
+
+// tracingMiddleware records a span per tool call, the Kubernetes and ACM proxy requests of the call are recorded as
+// child spans through the ToolHandlerParams context
+func tracingMiddleware(next api.ToolHandlerFunc) api.ToolHandlerFunc {
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		attributes := []attribute.KeyValue{attribute.String("mcp.tool.name", params.Tool.Tool.Name)}
+		if session := server.ClientSessionFromContext(params.Context); session != nil {
+			attributes = append(attributes, attribute.String("mcp.session.id", session.SessionID()))
+		}
+		if cluster, ok := params.GetArguments()["cluster"].(string); ok && cluster != "" {
+			attributes = append(attributes, attribute.String("acm.cluster", cluster))
+		}
+		ctx, span := tracing.Tracer().Start(params.Context, "tools/call "+params.Tool.Tool.Name,
+			trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
+		defer span.End()
+		params.Context = ctx
+		result, err := next(params)
+		switch {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		case result != nil && result.Error != nil:
+			errorCode := result.ErrorCode
+			if errorCode == "" {
+				errorCode, _ = api.ClassifyError(result.Error)
+			}
+			span.SetAttributes(attribute.String("mcp.error_code", string(errorCode)))
+			span.RecordError(result.Error)
+			span.SetStatus(codes.Error, result.Error.Error())
+		}
+		return result, err
+	}
+}
@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/tracing"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	var traceparent string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	t.Cleanup(apiServer.Close)
+	call := func(name string, handler api.ToolHandlerFunc) {
+		_, _ = tracingMiddleware(handler)(api.ToolHandlerParams{Context: context.Background(), ToolCallRequest: mcp.CallToolRequest{}, Tool: &api.ServerTool{Tool: api.Tool{Name: name}}})
+	}
+	call("tracing_success", func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		req, _ := http.NewRequestWithContext(params, http.MethodGet, apiServer.URL, nil)
+		resp, err := (&http.Client{Transport: tracing.Transport("kubernetes", http.DefaultTransport)}).Do(req)
+		if err != nil {
+			t.Fatalf("failed to request the API server: %v", err)
+		}
+		_ = resp.Body.Close()
+		return api.NewToolCallResult("ok", nil), nil
+	})
+	spans := recorder.Ended()
+	t.Run("Records a span per tool call", func(t *testing.T) {
+		if len(spans) != 2 || spans[1].Name() != "tools/call tracing_success" {
+			t.Fatalf("expected the request and the tool call spans, got %d spans", len(spans))
+		}
+	})
+	t.Run("Records the requests as child spans", func(t *testing.T) {
+		if spans[0].Name() != "kubernetes GET" || spans[0].Parent().SpanID() != spans[1].SpanContext().SpanID() {
+			t.Errorf("expected a child span of the tool call, got %s", spans[0].Name())
+		}
+	})
+	t.Run("Propagates the trace context to the server", func(t *testing.T) {
+		if traceparent == "" || spans[0].SpanContext().TraceID() != spans[1].SpanContext().TraceID() {
+			t.Errorf("expected the traceparent header of the tool call trace, got %q", traceparent)
+		}
+	})
+	t.Run("Records the failed calls with their error code", func(t *testing.T) {
+		call("tracing_error", func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+			return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeForbidden, false, errors.New("forbidden"))), nil
+		})
+		span := recorder.Ended()[2]
+		if span.Status().Code != codes.Error {
+			t.Errorf("expected an error status, got %v", span.Status())
+		}
+		found := false
+		for _, attribute := range span.Attributes() {
+			found = found || (attribute.Key == "mcp.error_code" && attribute.Value.AsString() == "Forbidden")
+		}
+		if !found {
+			t.Errorf("expected the Forbidden error code attribute, got %v", span.Attributes())
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/suite"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+type WorkloadsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *WorkloadsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	labels := map[string]string{"app": "a-workloads-status-deployment"}
+	_, _ = kc.AppsV1().Deployments("ns-1").Create(s.T().Context(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-workloads-status-deployment"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(2)),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "nginx", Image: "nginx"}}},
+			},
+		},
+	}, metav1.CreateOptions{})
+}
+
+func (s *WorkloadsSuite) TestWorkloadsStatus() {
+	s.InitMcpClient()
+	s.Run("workloads_status(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("workloads_status", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Regexp(`ns-1\s+Deployment/a-workloads-status-deployment\s+2\s+0\s+0\s+0\s+progressing\s+Waiting for deployment spec update to be observed\.\.\.`,
+			toolResult.Content[0].(mcp.TextContent).Text)
+		s.Regexp(`NAMESPACE\s+WORKLOADS\s+READY\s+PROGRESSING\s+DEGRADED\s+STALLED\s+SCALED-DOWN\n`+`ns-1\s+\d+`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("workloads_status with cluster returns error instead of using the current cluster", func() {
+		toolResult, _ := s.CallTool("workloads_status", map[string]interface{}{"namespace": "ns-1", "cluster": "managed-1"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal(`failed to get workloads status, cluster "managed-1" is not supported, the operation can only be performed on the current cluster`, toolResult.Content[0].(mcp.TextContent).Text)
+	})
+	s.Run("workloads_status(namespace=ns-2)", func() {
+		toolResult, err := s.CallTool("workloads_status", map[string]interface{}{"namespace": "ns-2"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("No workloads found", toolResult.Content[0].(mcp.TextContent).Text)
+	})
+}
+
+func TestWorkloads(t *testing.T) {
+	suite.Run(t, new(WorkloadsSuite))
+}
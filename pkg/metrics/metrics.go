@@ -0,0 +1,122 @@
+// Package metrics provides the Prometheus metrics of the MCP server itself, exposed by the HTTP transport at /metrics.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	clientmetrics "k8s.io/client-go/tools/metrics"
+)
+
+const namespace = "kubernetes_mcp_server"
+
+const (
+	// StatusSuccess is the status label of the successful tool calls
+	StatusSuccess = "success"
+	// StatusError is the status label of the failed tool calls
+	StatusError = "error"
+
+	// CacheHit is the result label of the lookups served from a cache
+	CacheHit = "hit"
+	// CacheMiss is the result label of the lookups that had to load the value
+	CacheMiss = "miss"
+)
+
+var (
+	// ToolCalls counts the tool calls by tool, status, and error code (empty for the successful calls)
+	ToolCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_calls_total",
+		Help:      "Number of tool calls by tool, status, and error code.",
+	}, []string{"tool", "status", "error_code"})
+	// ToolCallDuration observes the duration of the tool calls by tool
+	ToolCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tool_call_duration_seconds",
+		Help:      "Duration of the tool calls by tool.",
+		Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"tool"})
+	// ActiveSessions is the number of MCP sessions currently registered
+	ActiveSessions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_sessions",
+		Help:      "Number of active MCP sessions.",
+	})
+	// CacheRequests counts the lookups of the internal caches by cache and result (hit or miss)
+	CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Number of lookups of the internal caches by cache and result (hit or miss).",
+	}, []string{"cache", "result"})
+	// APIServerRequests counts the requests to the Kubernetes API server by status code, method, and host
+	APIServerRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "apiserver_requests_total",
+		Help:      "Number of requests to the Kubernetes API server by status code, method, and host.",
+	}, []string{"code", "method", "host"})
+	// APIServerRequestDuration observes the latency of the requests to the Kubernetes API server by verb and host
+	APIServerRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "apiserver_request_duration_seconds",
+		Help:      "Latency of the requests to the Kubernetes API server by verb and host.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"verb", "host"})
+	// ACMProxyRequests counts the requests to the managed clusters through the ACM cluster-proxy by operation and status code
+	ACMProxyRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "acm_proxy_requests_total",
+		Help:      "Number of requests to the managed clusters through the ACM cluster-proxy by operation and status code.",
+	}, []string{"operation", "code"})
+	// ACMProxyRequestDuration observes the latency of the requests through the ACM cluster-proxy by operation
+	ACMProxyRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "acm_proxy_request_duration_seconds",
+		Help:      "Latency of the requests to the managed clusters through the ACM cluster-proxy by operation.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"operation"})
+)
+
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		ToolCalls,
+		ToolCallDuration,
+		ActiveSessions,
+		CacheRequests,
+		APIServerRequests,
+		APIServerRequestDuration,
+		ACMProxyRequests,
+		ACMProxyRequestDuration,
+	)
+	clientmetrics.Register(clientmetrics.RegisterOpts{
+		RequestLatency: requestLatency{},
+		RequestResult:  requestResult{},
+	})
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// requestLatency records the latency of the client-go requests to the Kubernetes API server
+type requestLatency struct{}
+
+func (requestLatency) Observe(_ context.Context, verb string, u url.URL, latency time.Duration) {
+	APIServerRequestDuration.WithLabelValues(verb, u.Host).Observe(latency.Seconds())
+}
+
+// requestResult records the status code of the client-go requests to the Kubernetes API server
+type requestResult struct{}
+
+func (requestResult) Increment(_ context.Context, code string, method string, host string) {
+	APIServerRequests.WithLabelValues(code, method, host).Inc()
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	clientmetrics "k8s.io/client-go/tools/metrics"
+)
+
+func TestAPIServerRequests(t *testing.T) {
+	clientmetrics.RequestResult.Increment(context.Background(), "200", "GET", "api.example.com:6443")
+	clientmetrics.RequestLatency.Observe(context.Background(), "GET", url.URL{Host: "api.example.com:6443"}, 50*time.Millisecond)
+	t.Run("Requests of client-go are counted", func(t *testing.T) {
+		if count := testutil.ToFloat64(APIServerRequests.WithLabelValues("200", "GET", "api.example.com:6443")); count != 1 {
+			t.Errorf("expected 1 request, got %v", count)
+		}
+	})
+	t.Run("Latency of client-go requests is observed", func(t *testing.T) {
+		if count := testutil.CollectAndCount(APIServerRequestDuration); count != 1 {
+			t.Errorf("expected 1 series, got %d", count)
+		}
+	})
+}
+
+func TestHandler(t *testing.T) {
+	CacheRequests.WithLabelValues("test_cache", CacheHit).Inc()
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(recorder.Body)
+	t.Run("Serves the metrics in the Prometheus exposition format", func(t *testing.T) {
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected HTTP 200 OK, got %d", recorder.Code)
+		}
+		if !strings.Contains(string(body), `kubernetes_mcp_server_cache_requests_total{cache="test_cache",result="hit"} 1`) {
+			t.Errorf("expected cache requests metric, got %s", body)
+		}
+	})
+	t.Run("Serves the process and Go runtime metrics", func(t *testing.T) {
+		if !strings.Contains(string(body), "go_goroutines") {
+			t.Errorf("expected Go runtime metrics, got %s", body)
+		}
+	})
+}
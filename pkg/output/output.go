@@ -2,7 +2,9 @@ package output
 
 import (
 	"bytes"
+	"fmt"
 
+	"github.com/pmezard/go-difflib/difflib"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -48,7 +50,9 @@ func (p *yaml) AsTable() bool {
 	return false
 }
 func (p *yaml) PrintObj(obj runtime.Unstructured) (string, error) {
-	return MarshalYaml(obj)
+	hint := continueHint(obj)
+	ret, err := MarshalYaml(obj)
+	return ret + hint, err
 }
 
 type table struct{}
@@ -92,9 +96,26 @@ func (p *table) PrintObj(obj runtime.Unstructured) (string, error) {
 		ShowLabels:    true,
 	})
 	err := printer.PrintObj(objectToPrint, buf)
-	return buf.String(), err
+	return buf.String() + continueHint(obj), err
 }
 
+// continueHint returns the instructions to retrieve the next page of a truncated list, empty if the list is complete
+func continueHint(obj runtime.Unstructured) string {
+	if obj == nil {
+		return ""
+	}
+	continueToken, _, _ := unstructured.NestedString(obj.UnstructuredContent(), "metadata", "continue")
+	if continueToken == "" {
+		return ""
+	}
+	hint := "# The list is truncated"
+	if remaining, found, _ := unstructured.NestedInt64(obj.UnstructuredContent(), "metadata", "remainingItemCount"); found && remaining > 0 {
+		hint += fmt.Sprintf(" (%d remaining items)", remaining)
+	}
+	return hint + ", to retrieve the next page call the tool again with the same arguments and continue: " + continueToken + "\n"
+}
+
+// MarshalYaml returns the YAML representation of the value, the data of the Secrets it contains is redacted
 func MarshalYaml(v any) (string, error) {
 	switch t := v.(type) {
 	//case unstructured.UnstructuredList:
@@ -112,13 +133,37 @@ func MarshalYaml(v any) (string, error) {
 	case *unstructured.Unstructured:
 		t.SetManagedFields(nil)
 	}
-	ret, err := yml.Marshal(v)
+	ret, err := yml.Marshal(RedactSecrets(v))
 	if err != nil {
 		return "", err
 	}
 	return string(ret), nil
 }
 
+// DiffYaml returns a unified diff of the YAML representations of the provided objects, empty if they are equal.
+// A nil object is represented as an empty document (e.g. an object that doesn't exist yet)
+func DiffYaml(fromName string, from any, toName string, to any) (string, error) {
+	var fromYaml, toYaml string
+	var err error
+	if from != nil {
+		if fromYaml, err = MarshalYaml(from); err != nil {
+			return "", err
+		}
+	}
+	if to != nil {
+		if toYaml, err = MarshalYaml(to); err != nil {
+			return "", err
+		}
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(fromYaml),
+		B:        difflib.SplitLines(toYaml),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+}
+
 func init() {
 	Names = make([]string, 0)
 	for _, output := range Outputs {
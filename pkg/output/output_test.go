@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -30,3 +31,60 @@ func TestPlainTextUnstructuredList(t *testing.T) {
 		}
 	})
 }
+
+func TestDiffYaml(t *testing.T) {
+	before := map[string]any{"spec": map[string]any{"replicas": 1, "paused": false}}
+	after := map[string]any{"spec": map[string]any{"replicas": 2, "paused": false}}
+	t.Run("returns unified diff of the changes", func(t *testing.T) {
+		diff, err := DiffYaml("before", before, "after", after)
+		if err != nil {
+			t.Fatalf("Error diffing objects: %v", err)
+		}
+		for _, expected := range []string{"--- before", "+++ after", "-  replicas: 1", "+  replicas: 2", "   paused: false"} {
+			if !strings.Contains(diff, expected) {
+				t.Errorf("Expected '%s' not found in diff: %s", expected, diff)
+			}
+		}
+	})
+	t.Run("returns nil objects as empty documents", func(t *testing.T) {
+		diff, err := DiffYaml("before", nil, "after", after)
+		if err != nil {
+			t.Fatalf("Error diffing objects: %v", err)
+		}
+		if strings.Contains(diff, "null") || !strings.Contains(diff, "+  replicas: 2") {
+			t.Errorf("Expected only additions in diff: %s", diff)
+		}
+	})
+	t.Run("returns empty diff for equal objects", func(t *testing.T) {
+		if diff, err := DiffYaml("before", before, "after", before); err != nil || diff != "" {
+			t.Errorf("Expected empty diff, got '%s' (%v)", diff, err)
+		}
+	})
+}
+
+func TestPrintObjTruncatedList(t *testing.T) {
+	var podList unstructured.UnstructuredList
+	_ = json.Unmarshal([]byte(`
+			{ "apiVersion": "v1", "kind": "PodList", "metadata": { "continue": "next-page-token", "remainingItemCount": 3 }, "items": [{
+			  "apiVersion": "v1", "kind": "Pod",
+			  "metadata": { "name": "pod-1", "namespace": "default", "creationTimestamp": "2023-10-01T00:00:00Z" } }
+			]}`), &podList)
+	for _, o := range Outputs {
+		t.Run(o.GetName()+" prints the continue token", func(t *testing.T) {
+			out, err := o.PrintObj(&podList)
+			if err != nil {
+				t.Fatalf("Error printing pod list: %v", err)
+			}
+			if !strings.Contains(out, "# The list is truncated (3 remaining items)") || !strings.Contains(out, "continue: next-page-token") {
+				t.Errorf("Expected continue token in output: %s", out)
+			}
+		})
+	}
+	t.Run("complete list has no continue token", func(t *testing.T) {
+		podList.SetContinue("")
+		out, _ := Yaml.PrintObj(&podList)
+		if strings.Contains(out, "truncated") {
+			t.Errorf("Unexpected continue token in output: %s", out)
+		}
+	})
+}
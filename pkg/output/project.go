@@ -0,0 +1,67 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Project returns only the fields of the object (or list) selected by a JSONPath expression or a Go template,
+// same as kubectl get -o jsonpath=... or -o go-template=....
+// The JSONPath expression can omit the enclosing braces and the leading dot (e.g. .items[*].metadata.name).
+// Missing fields are printed as empty values, the data of the Secrets is redacted before the projection.
+// The continue token of a truncated list is appended to the output.
+func Project(obj runtime.Unstructured, jsonPath, goTemplate string) (string, error) {
+	if jsonPath != "" && goTemplate != "" {
+		return "", errors.New("only one of jsonPath or goTemplate can be provided")
+	}
+	content, _ := RedactSecrets(obj.UnstructuredContent()).(map[string]any)
+	buf := new(bytes.Buffer)
+	if goTemplate != "" {
+		printer, err := printers.NewGoTemplatePrinter([]byte(goTemplate))
+		if err != nil {
+			return "", fmt.Errorf("invalid goTemplate: %w", err)
+		}
+		printer.AllowMissingKeys(true)
+		// The printer writes the whole object to the output for debugging if the execution fails, only the error is returned
+		if err = printer.PrintObj(&unstructured.Unstructured{Object: content}, buf); err != nil {
+			return "", err
+		}
+		return projection(buf, obj), nil
+	}
+	j := jsonpath.New("jsonPath").AllowMissingKeys(true)
+	if err := j.Parse(relaxedJSONPath(jsonPath)); err != nil {
+		return "", fmt.Errorf("invalid jsonPath: %w", err)
+	}
+	if err := j.Execute(buf, content); err != nil {
+		return "", fmt.Errorf("failed to execute jsonPath %s: %w", jsonPath, err)
+	}
+	return projection(buf, obj), nil
+}
+
+// projection returns the projected output followed by the continue token if the list is truncated
+func projection(buf *bytes.Buffer, obj runtime.Unstructured) string {
+	hint := continueHint(obj)
+	if hint != "" && buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteString("\n")
+	}
+	return buf.String() + hint
+}
+
+// relaxedJSONPath adds the enclosing braces and the leading dot to a JSONPath expression if missing (see kubectl RelaxedJSONPathExpression)
+func relaxedJSONPath(expression string) string {
+	expression = strings.TrimSpace(expression)
+	if strings.Contains(expression, "{") {
+		return expression
+	}
+	if !strings.HasPrefix(expression, ".") {
+		expression = "." + expression
+	}
+	return "{" + expression + "}"
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestProject(t *testing.T) {
+	list := &unstructured.UnstructuredList{Object: map[string]any{"apiVersion": "v1", "kind": "List"}}
+	for _, item := range []map[string]any{
+		{"apiVersion": "v1", "kind": "Pod", "metadata": map[string]any{"name": "pod-1"}, "status": map[string]any{"phase": "Running"}},
+		{"apiVersion": "v1", "kind": "Pod", "metadata": map[string]any{"name": "pod-2"}},
+		{"apiVersion": "v1", "kind": "Secret", "metadata": map[string]any{"name": "secret-1"}, "data": map[string]any{"password": "c2VjcmV0"}},
+	} {
+		list.Items = append(list.Items, unstructured.Unstructured{Object: item})
+	}
+	t.Run("projects JSONPath expression", func(t *testing.T) {
+		out, err := Project(list, "{.items[*].metadata.name}", "")
+		if err != nil || out != "pod-1 pod-2 secret-1" {
+			t.Errorf("expected pod-1 pod-2 secret-1, got %q (%v)", out, err)
+		}
+	})
+	t.Run("projects relaxed JSONPath expression", func(t *testing.T) {
+		for _, expression := range []string{".items[0].status.phase", "items[0].status.phase"} {
+			out, err := Project(list, expression, "")
+			if err != nil || out != "Running" {
+				t.Errorf("expected Running for %s, got %q (%v)", expression, out, err)
+			}
+		}
+	})
+	t.Run("projects Go template with missing keys", func(t *testing.T) {
+		out, err := Project(list, "", "{{range .items}}{{.metadata.name}}={{.status.phase}};{{end}}")
+		if err != nil || !strings.HasPrefix(out, "pod-1=Running;pod-2=") {
+			t.Errorf("unexpected output %q (%v)", out, err)
+		}
+	})
+	t.Run("redacts Secrets", func(t *testing.T) {
+		for _, out := range []string{
+			projectedOrError(Project(list, "{.items[2].data.password}", "")),
+			projectedOrError(Project(list, "", `{{(index .items 2).data.password | base64decode}}`)),
+		} {
+			if strings.Contains(out, "secret") || strings.Contains(out, "c2VjcmV0") {
+				t.Errorf("expected redacted Secret data, got %q", out)
+			}
+		}
+	})
+	t.Run("appends the continue token of truncated lists", func(t *testing.T) {
+		truncated := list.DeepCopy()
+		truncated.SetContinue("next-page-token")
+		out, err := Project(truncated, "{.items[0].metadata.name}", "")
+		if err != nil || !strings.HasPrefix(out, "pod-1\n# The list is truncated") || !strings.Contains(out, "continue: next-page-token") {
+			t.Errorf("unexpected output %q (%v)", out, err)
+		}
+	})
+	t.Run("rejects invalid expressions", func(t *testing.T) {
+		if _, err := Project(list, "{.items[", ""); err == nil || !strings.Contains(err.Error(), "invalid jsonPath") {
+			t.Errorf("expected invalid jsonPath error, got %v", err)
+		}
+		if _, err := Project(list, "", "{{.items"); err == nil || !strings.Contains(err.Error(), "invalid goTemplate") {
+			t.Errorf("expected invalid goTemplate error, got %v", err)
+		}
+		if _, err := Project(list, ".items", "{{.items}}"); err == nil {
+			t.Error("expected error when both jsonPath and goTemplate are provided")
+		}
+	})
+}
+
+func projectedOrError(out string, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return out
+}
@@ -0,0 +1,181 @@
+package output
+
+import (
+	"maps"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	yml "sigs.k8s.io/yaml"
+)
+
+// Redacted replaces the values of the Secrets in the outputs
+const Redacted = "[REDACTED]"
+
+// lastAppliedConfigAnnotation stores the last manifest applied with kubectl apply, including the Secret data
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// secretManifest matches serialized Kubernetes Secret manifests (YAML or JSON) embedded in strings
+var secretManifest = regexp.MustCompile(`(?m)(^\s*kind:\s*["']?Secret["']?\s*$|"kind"\s*:\s*"Secret")`)
+
+// documentSeparator splits the documents of a multi-document YAML string
+var documentSeparator = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
+// RedactSecrets returns the value with the data of the Secrets it contains replaced, including the Secret manifests
+// embedded in strings (e.g. event messages or Helm manifests).
+// Unstructured objects, maps, slices, and strings are inspected. The provided value is never modified, the parts
+// containing Secrets are copied.
+func RedactSecrets(v any) any {
+	ret, _ := redactSecrets(v)
+	return ret
+}
+
+func redactSecrets(v any) (any, bool) {
+	switch t := v.(type) {
+	case *unstructured.Unstructured:
+		if t == nil {
+			return v, false
+		}
+		if obj, changed := redactSecrets(t.Object); changed {
+			return &unstructured.Unstructured{Object: obj.(map[string]any)}, true
+		}
+	case unstructured.Unstructured:
+		if obj, changed := redactSecrets(t.Object); changed {
+			return unstructured.Unstructured{Object: obj.(map[string]any)}, true
+		}
+	case []unstructured.Unstructured:
+		var ret []unstructured.Unstructured
+		for i := range t {
+			if obj, changed := redactSecrets(t[i].Object); changed {
+				if ret == nil {
+					ret = append([]unstructured.Unstructured{}, t...)
+				}
+				ret[i] = unstructured.Unstructured{Object: obj.(map[string]any)}
+			}
+		}
+		if ret != nil {
+			return ret, true
+		}
+	case []*unstructured.Unstructured:
+		var ret []*unstructured.Unstructured
+		for i := range t {
+			if obj, changed := redactSecrets(t[i]); changed {
+				if ret == nil {
+					ret = append([]*unstructured.Unstructured{}, t...)
+				}
+				ret[i] = obj.(*unstructured.Unstructured)
+			}
+		}
+		if ret != nil {
+			return ret, true
+		}
+	case map[string]any:
+		return redactSecretsMap(t)
+	case []map[string]any:
+		var ret []map[string]any
+		for i := range t {
+			if m, changed := redactSecretsMap(t[i]); changed {
+				if ret == nil {
+					ret = append([]map[string]any{}, t...)
+				}
+				ret[i] = m
+			}
+		}
+		if ret != nil {
+			return ret, true
+		}
+	case []any:
+		var ret []any
+		for i := range t {
+			if value, changed := redactSecrets(t[i]); changed {
+				if ret == nil {
+					ret = append([]any{}, t...)
+				}
+				ret[i] = value
+			}
+		}
+		if ret != nil {
+			return ret, true
+		}
+	case string:
+		return redactSecretManifests(t)
+	}
+	return v, false
+}
+
+func redactSecretsMap(m map[string]any) (map[string]any, bool) {
+	var ret map[string]any
+	set := func(key string, value any) {
+		if ret == nil {
+			ret = maps.Clone(m)
+		}
+		ret[key] = value
+	}
+	if m["kind"] == "Secret" {
+		for _, field := range []string{"data", "stringData"} {
+			if data, ok := m[field].(map[string]any); ok && len(data) > 0 {
+				redacted := make(map[string]any, len(data))
+				for key := range data {
+					redacted[key] = Redacted
+				}
+				set(field, redacted)
+			}
+		}
+		if metadata, ok := m["metadata"].(map[string]any); ok {
+			if annotations, ok := metadata["annotations"].(map[string]any); ok && annotations[lastAppliedConfigAnnotation] != nil {
+				redactedAnnotations := maps.Clone(annotations)
+				redactedAnnotations[lastAppliedConfigAnnotation] = Redacted
+				redactedMetadata := maps.Clone(metadata)
+				redactedMetadata["annotations"] = redactedAnnotations
+				set("metadata", redactedMetadata)
+			}
+		}
+	}
+	for key, value := range m {
+		if m["kind"] == "Secret" && (key == "data" || key == "stringData" || key == "metadata") {
+			continue
+		}
+		if redacted, changed := redactSecrets(value); changed {
+			set(key, redacted)
+		}
+	}
+	if ret != nil {
+		return ret, true
+	}
+	return m, false
+}
+
+// redactSecretManifests redacts the data of the Secret manifests in the (multi-document) YAML or JSON string,
+// documents that can't be parsed are redacted as a whole
+func redactSecretManifests(s string) (string, bool) {
+	if !strings.Contains(s, "Secret") || !secretManifest.MatchString(s) {
+		return s, false
+	}
+	documents := documentSeparator.Split(s, -1)
+	changed := false
+	for i, document := range documents {
+		if !secretManifest.MatchString(document) {
+			continue
+		}
+		redacted := Redacted + "\n"
+		var manifest map[string]any
+		if err := yml.Unmarshal([]byte(document), &manifest); err == nil {
+			redactedManifest, manifestChanged := redactSecretsMap(manifest)
+			if !manifestChanged {
+				continue
+			}
+			if data, err := yml.Marshal(redactedManifest); err == nil {
+				redacted = string(data)
+			}
+		}
+		if i > 0 {
+			redacted = "\n" + redacted
+		}
+		documents[i] = redacted
+		changed = true
+	}
+	if !changed {
+		return s, false
+	}
+	return strings.Join(documents, "---"), true
+}
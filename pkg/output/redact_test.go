@@ -0,0 +1,81 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func secretFixture() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]any{
+			"name": "db",
+			"annotations": map[string]any{
+				lastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"Secret","data":{"password":"aHVudGVyMg=="}}`,
+				"owner":                     "team-a",
+			},
+		},
+		"data":       map[string]any{"password": "aHVudGVyMg=="},
+		"stringData": map[string]any{"user": "admin"},
+	}}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	t.Run("redacts the data of Secrets", func(t *testing.T) {
+		secret := secretFixture()
+		redacted := RedactSecrets(secret).(*unstructured.Unstructured)
+		data, _, _ := unstructured.NestedStringMap(redacted.Object, "data")
+		stringData, _, _ := unstructured.NestedStringMap(redacted.Object, "stringData")
+		if data["password"] != Redacted || stringData["user"] != Redacted {
+			t.Errorf("expected redacted data, got %v %v", data, stringData)
+		}
+		if redacted.GetAnnotations()[lastAppliedConfigAnnotation] != Redacted || redacted.GetAnnotations()["owner"] != "team-a" {
+			t.Errorf("expected redacted last applied configuration, got %v", redacted.GetAnnotations())
+		}
+		if original, _, _ := unstructured.NestedString(secret.Object, "data", "password"); original != "aHVudGVyMg==" {
+			t.Errorf("the original Secret should not be modified, got %s", original)
+		}
+	})
+	t.Run("redacts the Secrets of lists", func(t *testing.T) {
+		configMap := unstructured.Unstructured{Object: map[string]any{"kind": "ConfigMap", "data": map[string]any{"key": "value"}}}
+		redacted := RedactSecrets([]unstructured.Unstructured{configMap, *secretFixture()}).([]unstructured.Unstructured)
+		if value, _, _ := unstructured.NestedString(redacted[0].Object, "data", "key"); value != "value" {
+			t.Errorf("ConfigMap data should not be redacted, got %s", value)
+		}
+		if value, _, _ := unstructured.NestedString(redacted[1].Object, "data", "password"); value != Redacted {
+			t.Errorf("Secret data should be redacted, got %s", value)
+		}
+	})
+	t.Run("redacts the Secret manifests embedded in strings", func(t *testing.T) {
+		manifest := "apiVersion: v1\nkind: ConfigMap\ndata:\n  key: value\n---\napiVersion: v1\nkind: Secret\ndata:\n  password: aHVudGVyMg==\n"
+		redacted := RedactSecrets([]map[string]any{{"manifest": manifest}}).([]map[string]any)
+		text := redacted[0]["manifest"].(string)
+		if strings.Contains(text, "aHVudGVyMg==") || !strings.Contains(text, "password: '[REDACTED]'") {
+			t.Errorf("expected redacted Secret manifest, got %s", text)
+		}
+		if !strings.HasPrefix(text, "apiVersion: v1\nkind: ConfigMap\ndata:\n  key: value\n---\n") {
+			t.Errorf("expected unchanged ConfigMap manifest, got %s", text)
+		}
+	})
+	t.Run("redacts unparseable Secret manifests as a whole", func(t *testing.T) {
+		redacted := RedactSecrets(map[string]any{"message": "kind: Secret\ndata: [unterminated"}).(map[string]any)
+		if redacted["message"] != Redacted+"\n" {
+			t.Errorf("expected redacted message, got %v", redacted["message"])
+		}
+	})
+	t.Run("leaves values without Secrets untouched", func(t *testing.T) {
+		value := map[string]any{"kind": "ConfigMap", "metadata": map[string]any{"name": "SecretStore"}}
+		if redacted := RedactSecrets(value).(map[string]any); redacted["metadata"].(map[string]any)["name"] != "SecretStore" {
+			t.Errorf("unexpected redaction %v", redacted)
+		}
+	})
+	t.Run("MarshalYaml redacts the Secrets", func(t *testing.T) {
+		yaml, err := MarshalYaml(secretFixture())
+		if err != nil || strings.Contains(yaml, "aHVudGVyMg==") || strings.Contains(yaml, "admin") {
+			t.Errorf("expected redacted YAML, got %s %v", yaml, err)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// idleTTL is the time after which the bucket of an inactive session is discarded
+const idleTTL = 10 * time.Minute
+
+// Limit is the token bucket configuration of a tool, a zero rate disables the limit
+type Limit struct {
+	// RequestsPerSecond is the rate at which the bucket is refilled
+	RequestsPerSecond float64
+	// Burst is the size of the bucket, defaults to the rate rounded up (and at least 1)
+	Burst int
+}
+
+func (l Limit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return max(1, int(l.RequestsPerSecond+0.999))
+}
+
+// ExceededError is returned when the rate limit of a tool is exceeded
+type ExceededError struct {
+	Tool       string
+	RetryAfter time.Duration
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for tool %s, retry in %s", e.Tool, e.RetryAfter.Round(time.Millisecond))
+}
+
+// Limiter keeps a token bucket for each session and tool
+type Limiter struct {
+	defaultLimit Limit
+	toolLimits   map[string]Limit
+	mu           sync.Mutex
+	buckets      map[bucketKey]*bucket
+	lastPrune    time.Time
+}
+
+type bucketKey struct {
+	session string
+	tool    string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// New creates a Limiter with the default limit applied to every tool and the per-tool overrides
+func New(defaultLimit Limit, toolLimits map[string]Limit) *Limiter {
+	return &Limiter{
+		defaultLimit: defaultLimit,
+		toolLimits:   toolLimits,
+		buckets:      make(map[bucketKey]*bucket),
+		lastPrune:    time.Now(),
+	}
+}
+
+// Enabled returns whether any limit is configured
+func (l *Limiter) Enabled() bool {
+	if l.defaultLimit.RequestsPerSecond > 0 {
+		return true
+	}
+	for _, limit := range l.toolLimits {
+		if limit.RequestsPerSecond > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Allow consumes a token from the bucket of the session and tool, it returns an ExceededError if the bucket is empty
+func (l *Limiter) Allow(session, tool string) error {
+	limit, ok := l.toolLimits[tool]
+	if !ok {
+		limit = l.defaultLimit
+	}
+	if limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+	key := bucketKey{session: session, tool: tool}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.burst())}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	reservation := b.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return &ExceededError{Tool: tool, RetryAfter: delay}
+	}
+	return nil
+}
+
+// prune discards the buckets of inactive sessions, the caller must hold the lock
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < idleTTL {
+		return
+	}
+	l.lastPrune = now
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
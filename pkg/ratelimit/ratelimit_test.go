@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitSuite struct {
+	suite.Suite
+}
+
+func (s *RateLimitSuite) TestEnabled() {
+	s.Run("disabled without limits", func() {
+		s.False(New(Limit{}, nil).Enabled())
+	})
+	s.Run("enabled with default limit", func() {
+		s.True(New(Limit{RequestsPerSecond: 1}, nil).Enabled())
+	})
+	s.Run("enabled with tool limit", func() {
+		s.True(New(Limit{}, map[string]Limit{"pods_list": {RequestsPerSecond: 1}}).Enabled())
+	})
+}
+
+func (s *RateLimitSuite) TestAllow() {
+	limiter := New(Limit{RequestsPerSecond: 0.001, Burst: 2}, map[string]Limit{
+		"pods_log":       {RequestsPerSecond: 0.001},
+		"namespace_list": {},
+	})
+	s.Run("allows burst", func() {
+		s.NoError(limiter.Allow("session-1", "pods_list"))
+		s.NoError(limiter.Allow("session-1", "pods_list"))
+	})
+	s.Run("rejects calls exceeding burst", func() {
+		err := limiter.Allow("session-1", "pods_list")
+		s.Require().Error(err)
+		var exceeded *ExceededError
+		s.Require().True(errors.As(err, &exceeded))
+		s.Equal("pods_list", exceeded.Tool)
+		s.Greater(exceeded.RetryAfter, time.Duration(0))
+		s.Contains(err.Error(), "rate limit exceeded for tool pods_list, retry in ")
+	})
+	s.Run("keeps buckets per session", func() {
+		s.NoError(limiter.Allow("session-2", "pods_list"))
+	})
+	s.Run("keeps buckets per tool", func() {
+		s.NoError(limiter.Allow("session-1", "pods_get"))
+	})
+	s.Run("applies tool limits", func() {
+		s.NoError(limiter.Allow("session-1", "pods_log"))
+		s.Error(limiter.Allow("session-1", "pods_log"))
+	})
+	s.Run("zero tool limit disables the default limit", func() {
+		for i := 0; i < 10; i++ {
+			s.NoError(limiter.Allow("session-1", "namespace_list"))
+		}
+	})
+}
+
+func (s *RateLimitSuite) TestRefill() {
+	limiter := New(Limit{RequestsPerSecond: 100, Burst: 1}, nil)
+	s.NoError(limiter.Allow("session-1", "pods_list"))
+	s.Error(limiter.Allow("session-1", "pods_list"))
+	time.Sleep(20 * time.Millisecond)
+	s.NoError(limiter.Allow("session-1", "pods_list"))
+}
+
+func (s *RateLimitSuite) TestPrune() {
+	limiter := New(Limit{RequestsPerSecond: 1}, nil)
+	s.NoError(limiter.Allow("session-1", "pods_list"))
+	limiter.buckets[bucketKey{session: "session-1", tool: "pods_list"}].lastSeen = time.Now().Add(-2 * idleTTL)
+	limiter.lastPrune = time.Now().Add(-2 * idleTTL)
+	s.NoError(limiter.Allow("session-2", "pods_list"))
+	s.Len(limiter.buckets, 1)
+}
+
+func TestRateLimit(t *testing.T) {
+	suite.Run(t, new(RateLimitSuite))
+}
@@ -0,0 +1,170 @@
+package acm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initAddons() []api.ServerTool {
+	configurableAddons := strings.Join(internalk8s.ConfigurableAddons(), ", ")
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "addons_list",
+			Description: "List the ManagedClusterAddOns of an ACM managed cluster including their availability, degraded and progressing conditions",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"managedCluster": {
+						Type:        "string",
+						Description: "Name of the managed cluster",
+					},
+				},
+				Required: []string{"managedCluster"},
+			},
+			OutputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"managedCluster": {Type: "string"},
+					"addons": {
+						Type: "array",
+						Items: &jsonschema.Schema{
+							Type: "object",
+							Properties: map[string]*jsonschema.Schema{
+								"name":             {Type: "string"},
+								"installNamespace": {Type: "string"},
+								"available":        {Type: "string", Description: "Status of the Available condition (True, False, or Unknown)"},
+								"degraded":         {Type: "boolean"},
+								"progressing":      {Type: "boolean"},
+								"conditions": {
+									Type: "array",
+									Items: &jsonschema.Schema{
+										Type: "object",
+										Properties: map[string]*jsonschema.Schema{
+											"type":    {Type: "string"},
+											"status":  {Type: "string"},
+											"reason":  {Type: "string"},
+											"message": {Type: "string"},
+										},
+									},
+								},
+							},
+							Required: []string{"name", "available"},
+						},
+					},
+				},
+				Required: []string{"managedCluster", "addons"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Addons: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: addonsList},
+		{Tool: api.Tool{
+			Name:        "addons_enable",
+			Description: "Enable an addon for an ACM managed cluster (supported addons: " + configurableAddons + ")",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"managedCluster": {
+						Type:        "string",
+						Description: "Name of the managed cluster",
+					},
+					"addon": {
+						Type:        "string",
+						Description: "Name of the addon to enable",
+					},
+				},
+				Required: []string{"managedCluster", "addon"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Addons: Enable",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: addonsEnable, DryRun: true},
+		{Tool: api.Tool{
+			Name:        "addons_disable",
+			Description: "Disable an addon for an ACM managed cluster, the addon agent is removed from the managed cluster (supported addons: " + configurableAddons + ")",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"managedCluster": {
+						Type:        "string",
+						Description: "Name of the managed cluster",
+					},
+					"addon": {
+						Type:        "string",
+						Description: "Name of the addon to disable",
+					},
+				},
+				Required: []string{"managedCluster", "addon"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Addons: Disable",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: addonsDisable, DryRun: true},
+	}
+}
+
+type addonArgs struct {
+	ManagedCluster string `json:"managedCluster"`
+	Addon          string `json:"addon"`
+}
+
+func addonsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := addonArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list addons, %w", err)), nil
+	}
+	cluster := args.ManagedCluster
+	ret, err := params.AddonsList(params, cluster)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list addons for cluster %s: %w", cluster, err)), nil
+	}
+	structured := map[string]any{"managedCluster": cluster, "addons": ret}
+	if len(ret) == 0 {
+		return api.NewStructuredToolCallResult(fmt.Sprintf("No addons found for cluster %s", cluster), structured, nil), nil
+	}
+	yaml, err := output.MarshalYaml(ret)
+	return api.NewStructuredToolCallResult(yaml, structured, err), nil
+}
+
+func addonsEnable(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	return addonsSetEnabled(params, true)
+}
+
+func addonsDisable(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	return addonsSetEnabled(params, false)
+}
+
+func addonsSetEnabled(params api.ToolHandlerParams, enabled bool) (*api.ToolCallResult, error) {
+	action := "enable"
+	if !enabled {
+		action = "disable"
+	}
+	args := addonArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to %s addon, %w", action, err)), nil
+	}
+	cluster, addon := args.ManagedCluster, args.Addon
+	if _, err := params.AddonSetEnabled(params, cluster, addon, enabled); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to %s addon %s for cluster %s: %w", action, addon, cluster, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Addon %s %sd for cluster %s, use addons_list to follow its availability", addon, action, cluster), nil), nil
+}
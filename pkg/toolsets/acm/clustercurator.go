@@ -0,0 +1,190 @@
+package acm
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initClusterCurator() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "clusters_upgrade_versions",
+			Description: "Get the current OpenShift version, channel and the available upgrade versions of an ACM managed cluster",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"managedCluster": {
+						Type:        "string",
+						Description: "Name of the managed cluster",
+					},
+				},
+				Required: []string{"managedCluster"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Clusters: Upgrade Versions",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clustersUpgradeVersions},
+		{Tool: api.Tool{
+			Name: "clusters_upgrade_plan",
+			Description: "Preview the upgrade of an ACM managed cluster to the provided version without performing any change. " +
+				"Validates the version against the available updates, reports any in-progress curation and returns the ClusterCurator that clusters_upgrade would apply",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"managedCluster": {
+						Type:        "string",
+						Description: "Name of the managed cluster to upgrade",
+					},
+					"version": {
+						Type:        "string",
+						Description: "OpenShift version to upgrade to (must be one of the available updates reported by clusters_upgrade_versions)",
+					},
+					"channel": {
+						Type:        "string",
+						Description: "Update channel to switch to before upgrading (e.g. stable-4.16) (Optional, keeps the current channel if not provided)",
+					},
+				},
+				Required: []string{"managedCluster", "version"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Clusters: Upgrade Plan",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clustersUpgradePlan},
+		{Tool: api.Tool{
+			Name: "clusters_upgrade",
+			Description: "Upgrade an ACM managed cluster to the provided version by creating or updating its ClusterCurator. " +
+				"Use clusters_upgrade_plan first to preview the change and clustercurators_status to monitor the upgrade",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"managedCluster": {
+						Type:        "string",
+						Description: "Name of the managed cluster to upgrade",
+					},
+					"version": {
+						Type:        "string",
+						Description: "OpenShift version to upgrade to (must be one of the available updates reported by clusters_upgrade_versions)",
+					},
+					"channel": {
+						Type:        "string",
+						Description: "Update channel to switch to before upgrading (e.g. stable-4.16) (Optional, keeps the current channel if not provided)",
+					},
+				},
+				Required: []string{"managedCluster", "version"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Clusters: Upgrade",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clustersUpgrade, DryRun: true},
+		{Tool: api.Tool{
+			Name:        "clustercurators_status",
+			Description: "Get the status of the ClusterCurator of an ACM managed cluster to monitor an upgrade or any other curation in progress",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"managedCluster": {
+						Type:        "string",
+						Description: "Name of the managed cluster",
+					},
+				},
+				Required: []string{"managedCluster"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "ClusterCurators: Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterCuratorsStatus},
+	}
+}
+
+type clusterUpgradeArgs struct {
+	ManagedCluster string `json:"managedCluster"`
+	Version        string `json:"version"`
+	Channel        string `json:"channel"`
+}
+
+func clustersUpgradeVersions(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := clusterUpgradeArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get upgrade versions, %w", err)), nil
+	}
+	cluster := args.ManagedCluster
+	ret, err := params.ClusterUpgradeVersions(params, cluster)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get upgrade versions for cluster %s: %w", cluster, err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func clustersUpgradePlan(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	cluster, version, channel, err := upgradeArguments(params, "failed to plan cluster upgrade")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	ret, err := params.ClusterUpgradePlan(params, cluster, version, channel)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to plan upgrade of cluster %s: %w", cluster, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to plan cluster upgrade: %w", err)
+	}
+	return api.NewToolCallResult("# Upgrade plan (no changes have been applied)\n"+marshalledYaml, err), nil
+}
+
+func clustersUpgrade(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	cluster, version, channel, err := upgradeArguments(params, "failed to upgrade cluster")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	resources, err := params.ClusterUpgrade(params, cluster, version, channel)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to upgrade cluster %s: %w", cluster, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(resources)
+	if err != nil {
+		err = fmt.Errorf("failed to upgrade cluster: %w", err)
+	}
+	return api.NewToolCallResult("# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
+}
+
+func clusterCuratorsStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := clusterUpgradeArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get cluster curator status, %w", err)), nil
+	}
+	cluster := args.ManagedCluster
+	ret, err := params.ClusterCuratorStatus(params, cluster)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get cluster curator status for cluster %s: %w", cluster, err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func upgradeArguments(params api.ToolHandlerParams, errorPrefix string) (cluster, version, channel string, err error) {
+	args := clusterUpgradeArgs{}
+	if err = api.BindArguments(params, &args); err != nil {
+		return "", "", "", fmt.Errorf("%s, %w", errorPrefix, err)
+	}
+	return args.ManagedCluster, args.Version, args.Channel, nil
+}
@@ -0,0 +1,305 @@
+package acm
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initHive() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "clusterdeployments_list",
+			Description: "List the Hive ClusterDeployments in the hub cluster from all namespaces or the provided namespace",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: api.WithListProperties(map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to retrieve the ClusterDeployments from. If not provided, will list ClusterDeployments from all namespaces",
+					},
+				}),
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "ClusterDeployments: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterDeploymentsList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "hive.openshift.io", Resource: "clusterdeployments", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name:        "clusterdeployments_status",
+			Description: "Get the provisioning status of a Hive ClusterDeployment in the hub cluster, including failure conditions and the current provision stage",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the ClusterDeployment (usually the same as the cluster name)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the ClusterDeployment",
+					},
+				},
+				Required: []string{"namespace", "name"},
+			},
+			OutputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name":         {Type: "string"},
+					"namespace":    {Type: "string"},
+					"clusterName":  {Type: "string"},
+					"baseDomain":   {Type: "string"},
+					"platform":     {Type: "string"},
+					"installed":    {Type: "boolean"},
+					"powerState":   {Type: "string"},
+					"apiURL":       {Type: "string"},
+					"clusterPool":  {Type: "string"},
+					"clusterClaim": {Type: "string"},
+					"failureConditions": {
+						Type: "array",
+						Items: &jsonschema.Schema{
+							Type: "object",
+							Properties: map[string]*jsonschema.Schema{
+								"type":    {Type: "string"},
+								"reason":  {Type: "string"},
+								"message": {Type: "string"},
+							},
+						},
+					},
+					"provision": {
+						Type: "object",
+						Properties: map[string]*jsonschema.Schema{
+							"name":    {Type: "string"},
+							"stage":   {Type: "string"},
+							"attempt": {Type: "integer"},
+						},
+					},
+				},
+				Required: []string{"name", "namespace", "installed"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "ClusterDeployments: Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterDeploymentsStatus, Access: []api.ResourceAccess{
+			{Verb: "get", Group: "hive.openshift.io", Resource: "clusterdeployments"},
+		}},
+		{Tool: api.Tool{
+			Name:        "clusterpools_list",
+			Description: "List the Hive ClusterPools in the hub cluster from all namespaces or the provided namespace",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: api.WithListProperties(map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to retrieve the ClusterPools from. If not provided, will list ClusterPools from all namespaces",
+					},
+				}),
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "ClusterPools: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterPoolsList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "hive.openshift.io", Resource: "clusterpools", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name:        "clusterclaims_list",
+			Description: "List the Hive ClusterClaims in the hub cluster from all namespaces or the provided namespace",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: api.WithListProperties(map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to retrieve the ClusterClaims from. If not provided, will list ClusterClaims from all namespaces",
+					},
+				}),
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "ClusterClaims: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterClaimsList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "hive.openshift.io", Resource: "clusterclaims", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name:        "clusterclaims_create",
+			Description: "Claim a cluster from a Hive ClusterPool by creating a ClusterClaim in the namespace of the pool",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the ClusterPool to claim the cluster from",
+					},
+					"pool": {
+						Type:        "string",
+						Description: "Name of the ClusterPool to claim the cluster from",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the ClusterClaim (Optional, random name based on the pool name if not provided)",
+					},
+					"lifetime": {
+						Type:        "string",
+						Description: "Maximum lifetime of the claimed cluster as a duration (e.g. 8h, 2h30m). After the lifetime elapses the cluster is deleted (Optional)",
+					},
+				},
+				Required: []string{"namespace", "pool"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "ClusterClaims: Create",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterClaimsCreate, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "create", Group: "hive.openshift.io", Resource: "clusterclaims"},
+		}},
+		{Tool: api.Tool{
+			Name:        "clusterclaims_delete",
+			Description: "Release a claimed cluster by deleting its Hive ClusterClaim (the claimed cluster is deprovisioned by Hive)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the ClusterClaim",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the ClusterClaim to delete",
+					},
+				},
+				Required: []string{"namespace", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "ClusterClaims: Delete",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterClaimsDelete, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "delete", Group: "hive.openshift.io", Resource: "clusterclaims"},
+		}},
+	}
+}
+
+type hiveArgs struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Pool      string `json:"pool"`
+	Lifetime  string `json:"lifetime"`
+	api.ListArgs
+}
+
+func clusterDeploymentsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := hiveArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list cluster deployments, %w", err)), nil
+	}
+	listOptions, err := args.ResourceListOptions("", params.ListOutput.AsTable())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list cluster deployments, %w", err)), nil
+	}
+	ret, err := params.ClusterDeploymentsList(params, args.Namespace, listOptions)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list cluster deployments: %w", err)), nil
+	}
+	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
+}
+
+func clusterDeploymentsStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := hiveArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get cluster deployment status, %w", err)), nil
+	}
+	namespace, name := args.Namespace, args.Name
+	ret, err := params.ClusterDeploymentStatus(params, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get cluster deployment %s status in namespace %s: %w", name, namespace, err)), nil
+	}
+	yaml, err := output.MarshalYaml(ret)
+	return api.NewStructuredToolCallResult(yaml, ret, err), nil
+}
+
+func clusterPoolsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := hiveArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list cluster pools, %w", err)), nil
+	}
+	listOptions, err := args.ResourceListOptions("", params.ListOutput.AsTable())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list cluster pools, %w", err)), nil
+	}
+	ret, err := params.ClusterPoolsList(params, args.Namespace, listOptions)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list cluster pools: %w", err)), nil
+	}
+	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
+}
+
+func clusterClaimsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := hiveArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list cluster claims, %w", err)), nil
+	}
+	listOptions, err := args.ResourceListOptions("", params.ListOutput.AsTable())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list cluster claims, %w", err)), nil
+	}
+	ret, err := params.ClusterClaimsList(params, args.Namespace, listOptions)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list cluster claims: %w", err)), nil
+	}
+	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
+}
+
+func clusterClaimsCreate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := hiveArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create cluster claim, %w", err)), nil
+	}
+	namespace, pool := args.Namespace, args.Pool
+	resources, err := params.ClusterClaimCreate(params, namespace, pool, args.Name, args.Lifetime)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create cluster claim from pool %s in namespace %s: %w", pool, namespace, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(resources)
+	if err != nil {
+		err = fmt.Errorf("failed to create cluster claim: %w", err)
+	}
+	return api.NewToolCallResult("# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
+}
+
+func clusterClaimsDelete(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := hiveArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to delete cluster claim, %w", err)), nil
+	}
+	namespace, name := args.Namespace, args.Name
+	if err := params.ClusterClaimDelete(params, namespace, name); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to delete cluster claim %s in namespace %s: %w", name, namespace, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("ClusterClaim %s deleted successfully, the claimed cluster will be deprovisioned", name), nil), nil
+}
@@ -0,0 +1,37 @@
+package acm
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+type Toolset struct{}
+
+var _ api.ToolsetWithPrerequisites = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "acm"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Tools for managing Advanced Cluster Management (ACM) fleets from the hub cluster (Hive provisioning, upgrades, addons, etc.)"
+}
+
+func (t *Toolset) GetPrerequisites() []internalk8s.Capability {
+	return []internalk8s.Capability{internalk8s.CapabilityACM}
+}
+
+func (t *Toolset) GetTools(_ internalk8s.Openshift) []api.ServerTool {
+	return slices.Concat(
+		initHive(),
+		initClusterCurator(),
+		initAddons(),
+	)
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
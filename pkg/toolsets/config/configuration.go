@@ -43,19 +43,22 @@ func initConfiguration() []api.ServerTool {
 	return tools
 }
 
+type configurationViewArgs struct {
+	Minified bool `json:"minified"`
+}
+
 func configurationView(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	minify := true
-	minified := params.GetArguments()["minified"]
-	if _, ok := minified.(bool); ok {
-		minify = minified.(bool)
+	args := configurationViewArgs{Minified: true}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get configuration, %w", err)), nil
 	}
-	ret, err := params.ConfigurationView(minify)
+	ret, err := params.ConfigurationView(args.Minified)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to get configuration: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to get configuration: %w", err)), nil
 	}
 	configurationYaml, err := output.MarshalYaml(ret)
 	if err != nil {
-		err = fmt.Errorf("failed to get configuration: %v", err)
+		err = fmt.Errorf("failed to get configuration: %w", err)
 	}
 	return api.NewToolCallResult(configurationYaml, err), nil
 }
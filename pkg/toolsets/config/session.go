@@ -0,0 +1,105 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initSession() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "session_set_defaults",
+			Description: "Set the defaults of the current MCP session: the namespace, the managed cluster, and the list output format " +
+				"inherited by the subsequent tool calls that don't provide them (e.g. work in namespace payments on cluster prod-east). " +
+				"The omitted defaults are kept, returns the resulting defaults of the session",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Default namespace of the tools accepting a namespace (Optional)",
+					},
+					"cluster": {
+						Type:        "string",
+						Description: "Default managed cluster name for multi-cluster operations via ACM proxy (Optional)",
+					},
+					"output": {
+						Type:        "string",
+						Description: "Default output format of the list tools (Optional)",
+						Enum:        toAny(output.Names),
+					},
+					"clear": {
+						Type:        "array",
+						Description: "Defaults to clear, the tool calls use the server defaults again (Optional)",
+						Items:       &jsonschema.Schema{Type: "string", Enum: []any{"namespace", "cluster", "output"}},
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Session: Set Defaults",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: sessionSetDefaults},
+	}
+}
+
+type sessionSetDefaultsArgs struct {
+	Namespace string   `json:"namespace"`
+	Cluster   string   `json:"cluster"`
+	Output    string   `json:"output"`
+	Clear     []string `json:"clear"`
+}
+
+func sessionSetDefaults(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	if params.Session == nil {
+		return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+			errors.New("failed to set session defaults, the transport is stateless (use stdio, SSE, or stateful streamable HTTP)"))), nil
+	}
+	var args sessionSetDefaultsArgs
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set session defaults, %w", err)), nil
+	}
+	defaults := params.Session.Defaults()
+	if args.Namespace != "" {
+		defaults.Namespace = args.Namespace
+	}
+	if args.Cluster != "" {
+		defaults.Cluster = args.Cluster
+	}
+	if args.Output != "" {
+		defaults.Output = args.Output
+	}
+	if slices.Contains(args.Clear, "namespace") {
+		defaults.Namespace = ""
+	}
+	if slices.Contains(args.Clear, "cluster") {
+		defaults.Cluster = ""
+	}
+	if slices.Contains(args.Clear, "output") {
+		defaults.Output = ""
+	}
+	params.Session.SetDefaults(defaults)
+	ret, err := output.MarshalYaml(defaults)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set session defaults: %w", err)), nil
+	}
+	return api.NewToolCallResult("# Defaults of the session\n"+ret, nil), nil
+}
+
+func toAny(values []string) []any {
+	ret := make([]any, len(values))
+	for i, value := range values {
+		ret[i] = value
+	}
+	return ret
+}
@@ -17,12 +17,13 @@ func (t *Toolset) GetName() string {
 }
 
 func (t *Toolset) GetDescription() string {
-	return "View and manage the current local Kubernetes configuration (kubeconfig)"
+	return "View and manage the current local Kubernetes configuration (kubeconfig) and the defaults of the MCP session"
 }
 
 func (t *Toolset) GetTools(_ internalk8s.Openshift) []api.ServerTool {
 	return slices.Concat(
 		initConfiguration(),
+		initSession(),
 	)
 }
 
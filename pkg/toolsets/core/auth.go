@@ -0,0 +1,177 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initAuth() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "auth_can_i",
+			Description: "Check whether an action is allowed (same as kubectl auth can-i), for the current identity or impersonating a user, a ServiceAccount (user system:serviceaccount:<namespace>:<name>), or groups. " +
+				"Uses a SelfSubjectAccessReview for the current identity and a SubjectAccessReview for the impersonated identities, so every authorizer of the cluster is considered",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"verb": {
+						Type:        "string",
+						Description: "Verb of the action (e.g. get, list, watch, create, update, patch, delete, deletecollection, impersonate, or * for all)",
+					},
+					"resource": {
+						Type: "string",
+						Description: "Resource of the action as its plural name, optionally with the API group and subresource " +
+							"(e.g. pods, deployments.apps, pods/log, deployments.apps/scale). Not required for a nonResourceURL",
+					},
+					"apiGroup": {
+						Type:        "string",
+						Description: "Optional API group of the resource (e.g. apps), overrides the group of the resource",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the action, all namespaces if not provided",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Optional name of the resource",
+					},
+					"nonResourceURL": {
+						Type:        "string",
+						Description: "Optional non-resource URL of the action (e.g. /healthz or /metrics), the resource is ignored if provided",
+					},
+					"user": {
+						Type:        "string",
+						Description: "Optional user to impersonate (e.g. jane or system:serviceaccount:ns-1:default), the current identity if neither user nor groups are provided",
+					},
+					"groups": {
+						Type:        "array",
+						Description: "Optional groups to impersonate (e.g. system:authenticated)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+				},
+				Required: []string{"verb"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Auth: Can I",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: authCanI},
+		{Tool: api.Tool{
+			Name: "auth_who_can",
+			Description: "List the subjects (Users, Groups, and ServiceAccounts) allowed to perform an action by looking up the Roles and ClusterRoles with a rule allowing it " +
+				"and the RoleBindings and ClusterRoleBindings referencing them, each subject with the bindings granting the access. " +
+				"Only RBAC is considered, other authorizers (e.g. webhooks) can allow more subjects",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"verb": {
+						Type:        "string",
+						Description: "Verb of the action (e.g. get, list, watch, create, update, patch, delete)",
+					},
+					"resource": {
+						Type:        "string",
+						Description: "Resource of the action as its plural name, optionally with the API group and subresource (e.g. pods, deployments.apps, pods/exec)",
+					},
+					"apiGroup": {
+						Type:        "string",
+						Description: "Optional API group of the resource (e.g. apps), overrides the group of the resource",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the action, the RoleBindings of the namespace are included. If not provided, only the ClusterRoleBindings (access in all namespaces) are considered",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Optional name of the resource, the rules restricted to other resource names are excluded",
+					},
+				},
+				Required: []string{"verb", "resource"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Auth: Who Can",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: authWhoCan, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "rbac.authorization.k8s.io", Resource: "clusterroles", ClusterScoped: true},
+			{Verb: "list", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings", ClusterScoped: true},
+		}},
+	}
+}
+
+type authArgs struct {
+	Verb           string   `json:"verb"`
+	Resource       string   `json:"resource"`
+	APIGroup       string   `json:"apiGroup"`
+	Namespace      string   `json:"namespace"`
+	Name           string   `json:"name"`
+	NonResourceURL string   `json:"nonResourceURL"`
+	User           string   `json:"user"`
+	Groups         []string `json:"groups"`
+}
+
+// attributes returns the authorization attributes of the arguments, the resource is parsed as <resource>[.<group>][/<subresource>]
+func (a authArgs) attributes() internalk8s.AuthorizationAttributes {
+	attributes := internalk8s.AuthorizationAttributes{Verb: a.Verb, Namespace: a.Namespace, Name: a.Name, Group: a.APIGroup}
+	resource, subresource, _ := strings.Cut(a.Resource, "/")
+	resource, group, _ := strings.Cut(resource, ".")
+	attributes.Resource, attributes.Subresource = resource, subresource
+	if attributes.Group == "" {
+		attributes.Group = group
+	}
+	return attributes
+}
+
+func authCanI(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := authArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check access, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check access, %w", err)), nil
+	}
+	if args.Resource == "" && args.NonResourceURL == "" {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check access, %w", errors.New("either resource or nonResourceURL must be provided"))), nil
+	}
+	ret, err := params.AuthCanI(params, internalk8s.AccessReview{
+		AuthorizationAttributes: args.attributes(),
+		NonResourcePath:         args.NonResourceURL,
+		User:                    args.User,
+		Groups:                  args.Groups,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check access: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func authWhoCan(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := authArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list subjects, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list subjects, %w", err)), nil
+	}
+	ret, err := params.AuthWhoCan(params, args.attributes())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list subjects: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No subjects are allowed by RBAC to perform the action", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
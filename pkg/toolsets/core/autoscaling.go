@@ -0,0 +1,160 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initAutoscaling() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "hpas_list",
+			Description: "List the Kubernetes HorizontalPodAutoscalers with their target, min/max/current/desired replicas, current and target metrics, " +
+				"conditions, and scaling events. Reports the issues preventing each autoscaler from scaling (e.g. FailedGetMetrics, missing resource requests, ScalingLimited)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to list the HorizontalPodAutoscalers from, all namespaces if not provided",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "HorizontalPodAutoscalers: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: hpasList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "autoscaling", Resource: "horizontalpodautoscalers", AllNamespaces: true},
+			{Verb: "list", Resource: "events", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name: "hpas_status",
+			Description: "Get the status of a Kubernetes HorizontalPodAutoscaler and explain why it isn't scaling its target: " +
+				"current and target metrics, conditions (AbleToScale, ScalingActive, ScalingLimited), scaling events, and the issues found " +
+				"(metrics not available, missing resource requests, replicas limited by min/max, scale down stabilization)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the HorizontalPodAutoscaler, the configured namespace if not provided",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the HorizontalPodAutoscaler",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "HorizontalPodAutoscalers: Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: hpasStatus, Access: []api.ResourceAccess{
+			{Verb: "get", Group: "autoscaling", Resource: "horizontalpodautoscalers"},
+			{Verb: "list", Resource: "events"},
+		}},
+		{Tool: api.Tool{
+			Name: "vpa_recommendations",
+			Description: "List the Kubernetes VerticalPodAutoscalers with the target, lower bound, and upper bound CPU and memory recommendations of each container " +
+				"compared to its current requests (under-requested if below the lower bound, over-requested if above the upper bound). " +
+				"If the VerticalPodAutoscaler isn't installed, naive recommendations are computed from the metrics history of the server " +
+				"(metrics_history_interval configuration): median usage as lower bound, 90th percentile CPU and peak memory usage as target, peak usage as upper bound, with a 15% margin",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to list the recommendations from, all namespaces if not provided",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "VerticalPodAutoscalers: Recommendations",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: vpaRecommendations, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "autoscaling.k8s.io", Resource: "verticalpodautoscalers", AllNamespaces: true},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+		}},
+	}
+}
+
+type hpasListArgs struct {
+	Namespace string `json:"namespace"`
+}
+
+func hpasList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := hpasListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list horizontal pod autoscalers, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list horizontal pod autoscalers, %w", err)), nil
+	}
+	ret, err := params.HorizontalPodAutoscalersList(params, args.Namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list horizontal pod autoscalers: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No horizontal pod autoscalers found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+type hpasStatusArgs struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func hpasStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := hpasStatusArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get horizontal pod autoscaler status, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get horizontal pod autoscaler status, %w", err)), nil
+	}
+	ret, err := params.HorizontalPodAutoscalersGet(params, args.Namespace, args.Name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get horizontal pod autoscaler status: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+type vpaRecommendationsArgs struct {
+	Namespace string `json:"namespace"`
+}
+
+func vpaRecommendations(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := vpaRecommendationsArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get vertical pod autoscaler recommendations, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get vertical pod autoscaler recommendations, %w", err)), nil
+	}
+	ret, err := params.VerticalPodAutoscalerRecommendationsList(params, args.Namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get vertical pod autoscaler recommendations: %w", err)), nil
+	}
+	if len(ret.Recommendations) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No recommendations found (source: %s)", ret.Source), nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
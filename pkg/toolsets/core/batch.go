@@ -0,0 +1,204 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+const (
+	// batchGetMaxRequests is the maximum number of read requests accepted by a single batch_get call
+	batchGetMaxRequests = 50
+	// batchGetConcurrency is the maximum number of read requests of a batch_get call executed concurrently
+	batchGetConcurrency = 10
+)
+
+func initBatch() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "batch_get",
+			Description: "Get and list multiple Kubernetes resources in a single call by providing an array of read requests, the requests are executed concurrently and their results are returned keyed by request id.\n" +
+				"Each request gets a resource when its name is provided, or lists the resources otherwise. Failed requests report their error without failing the rest of the batch.",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"requests": {
+						Type:        "array",
+						Description: fmt.Sprintf("Read requests to execute (up to %d)", batchGetMaxRequests),
+						MaxItems:    ptr.To(batchGetMaxRequests),
+						Items: &jsonschema.Schema{
+							Type: "object",
+							Properties: map[string]*jsonschema.Schema{
+								"id": {
+									Type:        "string",
+									Description: "Optional unique key of the request in the returned results. If not provided, the index of the request is used",
+								},
+								"apiVersion": {
+									Type:        "string",
+									Description: "apiVersion of the resources (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+								},
+								"kind": {
+									Type:        "string",
+									Description: "kind of the resources (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+								},
+								"namespace": {
+									Type:        "string",
+									Description: "Optional Namespace of the resources (ignored in case of cluster scoped resources). If not provided, get uses the configured namespace and list uses all namespaces",
+								},
+								"name": {
+									Type:        "string",
+									Description: "Optional name of the resource to get. If not provided, the resources are listed",
+								},
+								"labelSelector": {
+									Type:        "string",
+									Description: "Optional Kubernetes label selector to filter the listed resources (ignored when name is provided)",
+								},
+								"cluster": {
+									Type:        "string",
+									Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
+								},
+							},
+							Required: []string{"apiVersion", "kind"},
+						},
+					},
+				},
+				Required: []string{"requests"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Batch: Get",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: batchGet},
+	}
+}
+
+type batchGetArgs struct {
+	Requests []batchGetRequest `json:"requests"`
+}
+
+type batchGetRequest struct {
+	ID            string `json:"id"`
+	APIVersion    string `json:"apiVersion"`
+	Kind          string `json:"kind"`
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	LabelSelector string `json:"labelSelector"`
+	Cluster       string `json:"cluster"`
+}
+
+// GetArguments exposes the cluster of the request so that it's routed through the ACM proxy as a regular tool call
+func (r batchGetRequest) GetArguments() map[string]any {
+	return map[string]any{"cluster": r.Cluster}
+}
+
+type batchGetResult struct {
+	Result    any           `json:"result,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	ErrorCode api.ErrorCode `json:"errorCode,omitempty"`
+	Retryable bool          `json:"retryable,omitempty"`
+}
+
+func batchGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := batchGetArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resources, %w", err)), nil
+	}
+	if len(args.Requests) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resources, %w", &api.ArgumentError{Argument: "requests"})), nil
+	}
+	if len(args.Requests) > batchGetMaxRequests {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resources, %w",
+			&api.ArgumentError{Argument: "requests", Reason: fmt.Sprintf("expected at most %d requests, got %d", batchGetMaxRequests, len(args.Requests))})), nil
+	}
+	keys := make([]string, len(args.Requests))
+	seen := make(map[string]bool, len(args.Requests))
+	for i, request := range args.Requests {
+		keys[i] = request.ID
+		if keys[i] == "" {
+			keys[i] = strconv.Itoa(i)
+		}
+		if seen[keys[i]] {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get resources, %w",
+				&api.ArgumentError{Argument: "requests", Reason: fmt.Sprintf("duplicate request id %s", keys[i])})), nil
+		}
+		seen[keys[i]] = true
+	}
+
+	results := make([]batchGetResult, len(args.Requests))
+	group := errgroup.Group{}
+	group.SetLimit(batchGetConcurrency)
+	for i, request := range args.Requests {
+		group.Go(func() error {
+			ret, err := batchGetExecute(params, request)
+			if err != nil {
+				errorCode, retryable := api.ClassifyError(err)
+				results[i] = batchGetResult{Error: err.Error(), ErrorCode: errorCode, Retryable: retryable}
+			} else {
+				results[i] = batchGetResult{Result: ret}
+			}
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	keyed := make(map[string]batchGetResult, len(results))
+	for i, result := range results {
+		keyed[keys[i]] = result
+	}
+	yaml, err := output.MarshalYaml(keyed)
+	if err != nil {
+		err = fmt.Errorf("failed to get resources: %w", err)
+	}
+	return api.NewToolCallResult(yaml, err), nil
+}
+
+// batchGetExecute gets (or lists if no name is provided) the resources of a single batch_get request
+func batchGetExecute(params api.ToolHandlerParams, request batchGetRequest) (any, error) {
+	if request.APIVersion == "" {
+		return nil, &api.ArgumentError{Argument: "apiVersion"}
+	}
+	if request.Kind == "" {
+		return nil, &api.ArgumentError{Argument: "kind"}
+	}
+	gvk, err := parseGroupVersionKind(request.APIVersion, request.Kind)
+	if err != nil {
+		return nil, err
+	}
+	params.ToolCallRequest = request
+	var ret runtime.Unstructured
+	if request.Name != "" {
+		ret, err = params.ResourcesGet(params, gvk, request.Namespace, request.Name)
+	} else {
+		resourceListOptions := internalk8s.ResourceListOptions{}
+		resourceListOptions.LabelSelector = request.LabelSelector
+		ret, err = params.ResourcesList(params, gvk, request.Namespace, resourceListOptions)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch t := ret.(type) {
+	case *unstructured.Unstructured:
+		t.SetManagedFields(nil)
+		return t.Object, nil
+	case *unstructured.UnstructuredList:
+		items := make([]map[string]any, 0, len(t.Items))
+		for i := range t.Items {
+			t.Items[i].SetManagedFields(nil)
+			items = append(items, t.Items[i].Object)
+		}
+		return items, nil
+	}
+	return ret.UnstructuredContent(), nil
+}
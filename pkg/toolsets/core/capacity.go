@@ -0,0 +1,94 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initCapacity() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "capacity_report",
+			Description: "Report the schedulable headroom of the cluster per node pool: the allocatable CPU, memory, and pods of the ready and uncordoned nodes " +
+				"minus the requests of their pods, with the largest headroom of a single node (fragmentation). " +
+				"The node pools are the GKE, EKS, AKS, or Karpenter node pools, or the node roles, unless a poolLabel is provided. " +
+				"If a workload (kind and name) or a manifest is provided, simulates whether its pod fits on the nodes now (resources, node selector and affinity, " +
+				"taints, host ports, pod affinity, topology spread) and estimates how many more replicas the cluster can host, along with the constraints limiting them",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"poolLabel": {
+						Type:        "string",
+						Description: "Optional node label to group the nodes by (e.g. node.kubernetes.io/instance-type, topology.kubernetes.io/zone)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "Optional kind of the workload to simulate (Deployment, StatefulSet, ReplicaSet, Job, CronJob), requires name",
+						Enum:        []any{"Deployment", "StatefulSet", "ReplicaSet", "Job", "CronJob"},
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the workload or of the manifest to simulate, the configured namespace if not provided",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Optional name of the workload to simulate, requires kind",
+					},
+					"manifest": {
+						Type:        "string",
+						Description: "Optional YAML or JSON manifest of a Pod, or of a workload with a pod template, to simulate before creating it",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Capacity: Report",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: capacityReport, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "nodes", ClusterScoped: true},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+		}},
+	}
+}
+
+type capacityReportArgs struct {
+	PoolLabel string `json:"poolLabel"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Manifest  string `json:"manifest"`
+}
+
+func capacityReport(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := capacityReportArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get capacity report, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get capacity report, %w", err)), nil
+	}
+	if (args.Kind == "") != (args.Name == "") {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get capacity report, %w", errors.New("kind and name must be provided together"))), nil
+	}
+	ret, err := params.CapacityReport(params, kubernetes.CapacityReportOptions{
+		PoolLabel: args.PoolLabel,
+		Kind:      args.Kind,
+		Namespace: args.Namespace,
+		Name:      args.Name,
+		Manifest:  args.Manifest,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get capacity report: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initCertificates() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "certs_expiry",
+			Description: "Scan the TLS certificates of the cluster (or of several managed clusters in ACM mode) for their expiry: the kubernetes.io/tls Secrets, " +
+				"the cert-manager Certificates, and the certificates inlined in OpenShift Routes (if installed). " +
+				"Reports the certificates expiring within the days (or already expired) with their subject, issuer, DNS names, expiry date, and days left, " +
+				"the status and renewal time of the cert-manager Certificate issuing them, and the Ingresses, Routes, Gateways, and webhook configurations consuming them. " +
+				"The certificates that couldn't be read (e.g. invalid PEM, Certificate not issued yet) are also reported. The expired certificates come first",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to scan, all namespaces if not provided (the webhook configurations are always scanned)",
+					},
+					"days": {
+						Type:        "integer",
+						Description: "Optional number of days, the certificates expiring within are reported (defaults to 30)",
+						Minimum:     ptr.To(float64(0)),
+						Default:     api.ToRawMessage(30),
+					},
+					"clusters": {
+						Type:        "array",
+						Description: "Optional managed cluster names to scan in ACM mode, the report merges the certificates of all the clusters (takes precedence over cluster)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"cluster": {
+						Type:        "string",
+						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Certificates: Expiry",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: certsExpiry, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "secrets", AllNamespaces: true},
+			{Verb: "list", Group: "cert-manager.io", Resource: "certificates", AllNamespaces: true, Optional: true},
+			{Verb: "list", Group: "networking.k8s.io", Resource: "ingresses", AllNamespaces: true},
+			{Verb: "list", Group: "gateway.networking.k8s.io", Resource: "gateways", AllNamespaces: true, Optional: true},
+			{Verb: "list", Group: "route.openshift.io", Resource: "routes", AllNamespaces: true, Optional: true},
+			{Verb: "list", Group: "admissionregistration.k8s.io", Resource: "validatingwebhookconfigurations", ClusterScoped: true},
+			{Verb: "list", Group: "admissionregistration.k8s.io", Resource: "mutatingwebhookconfigurations", ClusterScoped: true},
+		}},
+	}
+}
+
+type certsExpiryArgs struct {
+	Namespace string   `json:"namespace"`
+	Days      int      `json:"days"`
+	Clusters  []string `json:"clusters"`
+}
+
+func certsExpiry(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := certsExpiryArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to scan certificates expiry, %w", err)), nil
+	}
+	var certificates []internalk8s.CertificateExpiry
+	err := forEachCluster(params, args.Clusters, "scan certificates expiry", func(params api.ToolHandlerParams, cluster string) error {
+		clusterCertificates, err := internalk8s.CertificatesExpiry(params, params.ResourcesList, args.Namespace, args.Days)
+		certificates = append(certificates, certificatesInCluster(clusterCertificates, cluster)...)
+		return err
+	})
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	return certsExpiryResult(internalk8s.FilterCertificatesExpiry(certificates, args.Days), args.Days)
+}
+
+func certificatesInCluster(certificates []internalk8s.CertificateExpiry, cluster string) []internalk8s.CertificateExpiry {
+	for i := range certificates {
+		certificates[i].Cluster = cluster
+	}
+	return certificates
+}
+
+func certsExpiryResult(certificates []internalk8s.CertificateExpiry, days int) (*api.ToolCallResult, error) {
+	if len(certificates) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No certificate expiring within %d days found", days), nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(certificates)), nil
+}
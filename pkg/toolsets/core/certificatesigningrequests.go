@@ -0,0 +1,161 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initCertificateSigningRequests() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "certificatesigningrequests_list",
+			Description: "List the Kubernetes CertificateSigningRequests (CSRs) pending approval, or all of them, with their requester (username and groups), signer, usages, " +
+				"and the subject decoded from the certificate request (common name, organizations, DNS names, and IP addresses). " +
+				"Useful to review the kubelet client and serving certificate requests (e.g. the requester is system:node:<node> or a bootstrap token, " +
+				"the common name is system:node:<node>, and the organization is system:nodes) before approving them",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"all": {
+						Type:        "boolean",
+						Description: "Optional, list the approved, issued, denied, and failed requests too (defaults to false, only the pending requests are listed)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "CertificateSigningRequests: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: certificateSigningRequestsList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "certificates.k8s.io", Resource: "certificatesigningrequests", ClusterScoped: true},
+		}},
+		{Tool: api.Tool{
+			Name: "certificatesigningrequests_approve",
+			Description: "Approve a Kubernetes CertificateSigningRequest (same as kubectl certificate approve), the signer then issues the certificate. " +
+				"The approval grants the requester a credential with the requested subject and usages, review the request with certificatesigningrequests_list first",
+			InputSchema: certificateSigningRequestSchema("Name of the CertificateSigningRequest to approve"),
+			Annotations: api.ToolAnnotations{
+				Title:           "CertificateSigningRequests: Approve",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: certificateSigningRequestsApprove, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "update", Group: "certificates.k8s.io", Resource: "certificatesigningrequests", Subresource: "approval", ClusterScoped: true},
+		}},
+		{Tool: api.Tool{
+			Name: "certificatesigningrequests_deny",
+			Description: "Deny a Kubernetes CertificateSigningRequest (same as kubectl certificate deny), the certificate is never issued. " +
+				"A denied request can't be approved afterwards, the requester has to create a new request",
+			InputSchema: certificateSigningRequestSchema("Name of the CertificateSigningRequest to deny"),
+			Annotations: api.ToolAnnotations{
+				Title:           "CertificateSigningRequests: Deny",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: certificateSigningRequestsDeny, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "update", Group: "certificates.k8s.io", Resource: "certificatesigningrequests", Subresource: "approval", ClusterScoped: true},
+		}},
+	}
+}
+
+// certificateSigningRequestSchema returns the input schema of the tools approving or denying a CertificateSigningRequest
+func certificateSigningRequestSchema(nameDescription string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"name": {
+				Type:        "string",
+				Description: nameDescription,
+			},
+			"reason": {
+				Type:        "string",
+				Description: "Optional brief CamelCase reason of the decision recorded in the condition of the request (e.g. NodeVerified)",
+			},
+			"message": {
+				Type:        "string",
+				Description: "Optional human-readable message of the decision recorded in the condition of the request",
+			},
+		},
+		Required: []string{"name"},
+	}
+}
+
+type certificateSigningRequestsListArgs struct {
+	All bool `json:"all"`
+}
+
+type certificateSigningRequestsApproveArgs struct {
+	Name    string `json:"name"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+func certificateSigningRequestsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := certificateSigningRequestsListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list certificate signing requests, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list certificate signing requests, %w", err)), nil
+	}
+	ret, err := params.CertificateSigningRequestsList(params, args.All)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list certificate signing requests: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		if args.All {
+			return api.NewToolCallResult("No certificate signing requests found", nil), nil
+		}
+		return api.NewToolCallResult("No pending certificate signing requests found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func certificateSigningRequestsApprove(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := certificateSigningRequestsApproveArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to approve certificate signing request, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to approve certificate signing request, %w", err)), nil
+	}
+	changed, err := params.CertificateSigningRequestsApprove(params, args.Name, true, args.Reason, args.Message)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to approve certificate signing request: %w", err)), nil
+	}
+	if !changed {
+		return api.NewToolCallResult(fmt.Sprintf("CertificateSigningRequest %s is already approved", args.Name), nil), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("CertificateSigningRequest %s approved successfully", args.Name), nil), nil
+}
+
+func certificateSigningRequestsDeny(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := certificateSigningRequestsApproveArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to deny certificate signing request, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to deny certificate signing request, %w", err)), nil
+	}
+	changed, err := params.CertificateSigningRequestsApprove(params, args.Name, false, args.Reason, args.Message)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to deny certificate signing request: %w", err)), nil
+	}
+	if !changed {
+		return api.NewToolCallResult(fmt.Sprintf("CertificateSigningRequest %s is already denied", args.Name), nil), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("CertificateSigningRequest %s denied successfully", args.Name), nil), nil
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initClusterHealth() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "cluster_health",
+			Description: "Summarize the health of the cluster (or of several managed clusters in ACM mode) in a score from 0 to 100 with a one-line summary. " +
+				"Rolls up the node conditions (not ready, pressure, cordoned), the control plane health (kube-system control plane Pods and OpenShift ClusterOperators), " +
+				"the pending, failed, and crash looping Pods, the firing alerts of the Prometheus of the cluster (if found, not for managed clusters), and the Warning events of the last hour. " +
+				"The status is Healthy (score >= 90), Degraded (score >= 70), or Critical, and the deductions explain the penalty of each problem",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"clusters": {
+						Type:        "array",
+						Description: "Optional managed cluster names to check in ACM mode, the report lists the health of each cluster, the least healthy first (takes precedence over cluster)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"cluster": {
+						Type:        "string",
+						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Cluster: Health",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterHealth, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "nodes", ClusterScoped: true},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+			{Verb: "list", Resource: "events", AllNamespaces: true},
+			{Verb: "list", Group: "config.openshift.io", Resource: "clusteroperators", ClusterScoped: true, Optional: true},
+			{Verb: "list", Resource: "services", AllNamespaces: true},
+			{Verb: "get", Resource: "services", Subresource: "proxy", Optional: true},
+		}},
+	}
+}
+
+type clusterHealthArgs struct {
+	Clusters []string `json:"clusters"`
+}
+
+func clusterHealth(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := clusterHealthArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check cluster health, %w", err)), nil
+	}
+	var healths []*internalk8s.ClusterHealth
+	err := forEachCluster(params, args.Clusters, "check cluster health", func(params api.ToolHandlerParams, cluster string) error {
+		// The alerts are retrieved through the service proxy of the API server, not routed through the ACM proxy
+		var alerts internalk8s.PrometheusAlertsGetter
+		if cluster == "" {
+			alerts = params.PrometheusAlerts
+		}
+		health, err := internalk8s.ClusterHealthCheck(params, params.ResourcesList, alerts)
+		if err != nil {
+			return err
+		}
+		health.Cluster = cluster
+		healths = append(healths, health)
+		return nil
+	})
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if len(args.Clusters) == 0 {
+		return api.NewToolCallResult(output.MarshalYaml(healths[0])), nil
+	}
+	slices.SortStableFunc(healths, func(a, b *internalk8s.ClusterHealth) int {
+		return cmp.Or(cmp.Compare(a.Score, b.Score), strings.Compare(a.Cluster, b.Cluster))
+	})
+	return api.NewToolCallResult(output.MarshalYaml(healths)), nil
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// clusterRequest routes the calls of the handler through the ACM proxy to the managed cluster, as a regular tool call with the cluster argument
+type clusterRequest string
+
+func (c clusterRequest) GetArguments() map[string]any {
+	return map[string]any{"cluster": string(c)}
+}
+
+// forEachCluster calls scan once with the params of the tool call, or with the params routed to each managed cluster
+// of the clusters argument in ACM mode (reporting the progress of the scan).
+// The cluster passed to scan labels its results, empty for the current cluster (the calls not routed through the ACM proxy).
+// The returned error is the tool call error, action describes the scan (e.g. "list images").
+func forEachCluster(params api.ToolHandlerParams, clusters []string, action string, scan func(params api.ToolHandlerParams, cluster string) error) error {
+	if len(clusters) > 0 && (!params.IsACMMode || params.ACMProxyClient == nil) {
+		return fmt.Errorf("failed to %s, %w", action, errors.New("clusters is only supported in ACM mode"))
+	}
+	if len(clusters) == 0 {
+		cluster, _ := api.ShouldUseACMProxy(params)
+		if err := scan(params, cluster); err != nil {
+			return fmt.Errorf("failed to %s: %w", action, err)
+		}
+		return nil
+	}
+	for i, cluster := range clusters {
+		params.NotifyProgress(float64(i), float64(len(clusters)), "Scanning cluster "+cluster)
+		clusterParams := params
+		clusterParams.ToolCallRequest = clusterRequest(cluster)
+		if err := scan(clusterParams, cluster); err != nil {
+			return fmt.Errorf("failed to %s in cluster %s: %w", action, cluster, err)
+		}
+	}
+	return nil
+}
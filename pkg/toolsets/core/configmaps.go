@@ -0,0 +1,128 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initConfigMaps() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "configmaps_create_or_update",
+			Description: "Create or update a Kubernetes ConfigMap in the current or provided namespace from key-value literals and file contents " +
+				"(same as kubectl create configmap --from-literal --from-file). Text files are stored as data and binary files as binaryData. " +
+				"Enable appendHash to suffix the name with a hash of the data, so that each change creates a new ConfigMap and rolls out the workloads referencing it",
+			InputSchema: configDataSchema("ConfigMap", nil),
+			Annotations: api.ToolAnnotations{
+				Title:           "ConfigMaps: Create or Update",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: configMapsCreateOrUpdate, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "patch", Resource: "configmaps"},
+		}},
+	}
+}
+
+// configDataSchema returns the input schema of the tools creating ConfigMaps and Secrets from literals and files
+func configDataSchema(kind string, properties map[string]*jsonschema.Schema) *jsonschema.Schema {
+	ret := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"namespace": {
+				Type:        "string",
+				Description: fmt.Sprintf("Optional Namespace to create or update the %s in, the configured namespace if not provided", kind),
+			},
+			"name": {
+				Type:        "string",
+				Description: fmt.Sprintf("Name of the %s (the prefix of the name if appendHash is enabled)", kind),
+			},
+			"literals": {
+				Type:                 "object",
+				Description:          "Optional key-value pairs to store (e.g. {\"LOG_LEVEL\": \"debug\"})",
+				AdditionalProperties: &jsonschema.Schema{Type: "string"},
+			},
+			"files": {
+				Type:        "array",
+				Description: "Optional files to store, each one as a key with its content",
+				Items: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"key":     {Type: "string", Description: "Key to store the file with, usually the file name (e.g. application.properties)"},
+						"content": {Type: "string", Description: "Content of the file"},
+						"base64":  {Type: "boolean", Description: "Optional, true if the content is base64 encoded (e.g. binary files)"},
+					},
+					Required: []string{"key", "content"},
+				},
+			},
+			"labels": {
+				Type:                 "object",
+				Description:          fmt.Sprintf("Optional labels of the %s", kind),
+				AdditionalProperties: &jsonschema.Schema{Type: "string"},
+			},
+			"immutable": {
+				Type:        "boolean",
+				Description: fmt.Sprintf("Optional, make the %s immutable, its data can't be updated afterwards (defaults to false)", kind),
+				Default:     api.ToRawMessage(false),
+			},
+			"appendHash": {
+				Type:        "boolean",
+				Description: "Optional, append a hash of the data to the name (same as kubectl create --append-hash) (defaults to false)",
+				Default:     api.ToRawMessage(false),
+			},
+		},
+		Required: []string{"name"},
+	}
+	maps.Copy(ret.Properties, properties)
+	return ret
+}
+
+type configDataArgs struct {
+	Namespace  string                      `json:"namespace"`
+	Name       string                      `json:"name"`
+	Literals   map[string]string           `json:"literals"`
+	Files      []kubernetes.ConfigDataFile `json:"files"`
+	Labels     map[string]string           `json:"labels"`
+	Immutable  bool                        `json:"immutable"`
+	AppendHash bool                        `json:"appendHash"`
+	Type       string                      `json:"type"`
+}
+
+func (a *configDataArgs) options() kubernetes.ConfigDataOptions {
+	return kubernetes.ConfigDataOptions{
+		Literals:   a.Literals,
+		Files:      a.Files,
+		Labels:     a.Labels,
+		Immutable:  a.Immutable,
+		AppendHash: a.AppendHash,
+		Type:       a.Type,
+	}
+}
+
+func configMapsCreateOrUpdate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := configDataArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update configmap, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update configmap, %w", err)), nil
+	}
+	ret, err := params.ConfigMapsCreateOrUpdate(params, args.Namespace, args.Name, args.options())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update configmap: %w", err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to create or update configmap: %w", err)
+	}
+	return api.NewToolCallResult("# The following resource (YAML) has been created or updated successfully\n"+marshalledYaml, err), nil
+}
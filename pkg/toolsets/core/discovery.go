@@ -0,0 +1,180 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initDiscovery() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "api_resources",
+			Description: "List the resource types served by the current cluster (same as kubectl api-resources), including custom resources, " +
+				"with their name, short names, preferred apiVersion, whether they are namespaced, kind, and supported verbs",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiGroup": {
+						Type:        "string",
+						Description: "Optional API group to list the resources from (e.g. apps, networking.k8s.io, or core for the legacy v1 group)",
+					},
+					"namespaced": {
+						Type:        "boolean",
+						Description: "Optional, list only the namespaced (true) or the cluster scoped (false) resources",
+					},
+					"verbs": {
+						Type:        "array",
+						Description: "Optional, list only the resources supporting all the provided verbs (e.g. [list, watch])",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "API Resources",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: apiResources},
+		{Tool: api.Tool{
+			Name: "api_object_counts",
+			Description: "Report the number of objects stored by the API server (in etcd) for each resource type of the current cluster, the most first, " +
+				"read from the object count metrics of the API server if allowed, or by listing each resource type with limit=1 and remainingItemCount otherwise. " +
+				"Highlights the resource types with a runaway growth: event storms, finished Jobs and old ReplicaSets piling up, and resource types with more than 10000 objects",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"top": {
+						Type:        "integer",
+						Description: "Optional number of resource types with the most objects to report (defaults to 30, 0 for all)",
+						Default:     api.ToRawMessage(30),
+						Minimum:     ptr.To(float64(0)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "API Object Counts",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: apiObjectCounts},
+		{Tool: api.Tool{
+			Name: "resources_explain",
+			Description: "Get the documentation of a resource kind or one of its fields from the OpenAPI schema published by the current cluster (same as kubectl explain), " +
+				"including the type, description, and fields (with their type and whether they are required). " +
+				"Use it to build valid manifests, especially for custom resources",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+					},
+					"field": {
+						Type:        "string",
+						Description: "Optional dot-separated path of the field to explain (e.g. spec.template.spec.containers), the kind is explained if not provided",
+					},
+				},
+				Required: []string{"apiVersion", "kind"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Explain",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesExplain},
+	}
+}
+
+type apiResourcesArgs struct {
+	APIGroup   string   `json:"apiGroup"`
+	Namespaced *bool    `json:"namespaced"`
+	Verbs      []string `json:"verbs"`
+}
+
+func apiResources(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := apiResourcesArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list api resources, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list api resources, %w", err)), nil
+	}
+	ret, err := params.APIResources(params, kubernetes.APIResourcesOptions{APIGroup: args.APIGroup, Namespaced: args.Namespaced, Verbs: args.Verbs})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list api resources: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No api resources found", nil), nil
+	}
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tSHORTNAMES\tAPIVERSION\tNAMESPACED\tKIND\tVERBS")
+	for _, r := range ret {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\n", r.Name, strings.Join(r.ShortNames, ","), r.APIVersion, r.Namespaced, r.Kind, strings.Join(r.Verbs, ","))
+	}
+	_ = w.Flush()
+	return api.NewToolCallResult(buf.String(), nil), nil
+}
+
+type apiObjectCountsArgs struct {
+	Top int `json:"top"`
+}
+
+func apiObjectCounts(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := apiObjectCountsArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to count api objects, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to count api objects, %w", err)), nil
+	}
+	ret, err := params.ObjectCounts(params, args.Top)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to count api objects: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+type resourcesExplainArgs struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Field      string `json:"field"`
+}
+
+func resourcesExplain(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := resourcesExplainArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain resource, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain resource, %w", err)), nil
+	}
+	gvk, err := parseGroupVersionKind(args.APIVersion, args.Kind)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain resource, %w", err)), nil
+	}
+	ret, err := params.ResourcesExplain(params, gvk, strings.TrimPrefix(args.Field, "."))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain resource: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initDisruptionBudgets() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "pdb_list",
+			Description: "List the Kubernetes PodDisruptionBudgets with their selector, minAvailable/maxUnavailable, expected and healthy pods, and allowed disruptions. " +
+				"The budgets allowing no disruptions are flagged as blocking node drains, with the pods involved and the reason",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to list the PodDisruptionBudgets from, all namespaces if not provided",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "PodDisruptionBudgets: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: pdbList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "policy", Resource: "poddisruptionbudgets", AllNamespaces: true},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name: "pdb_check",
+			Description: "Check which Kubernetes PodDisruptionBudgets would block a node drain, with the pods involved and the reason. " +
+				"If a node is provided, only the budgets protecting pods scheduled on the node are checked, " +
+				"and a budget blocks the drain if it allows fewer disruptions than the number of its pods on the node",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"node": {
+						Type:        "string",
+						Description: "Optional name of the Node to be drained",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to check the PodDisruptionBudgets from, all namespaces if not provided",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "PodDisruptionBudgets: Check",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: pdbCheck, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "policy", Resource: "poddisruptionbudgets", AllNamespaces: true},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+		}},
+	}
+}
+
+type pdbListArgs struct {
+	Namespace string `json:"namespace"`
+}
+
+func pdbList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := pdbListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pod disruption budgets, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pod disruption budgets, %w", err)), nil
+	}
+	ret, err := params.PodDisruptionBudgetsList(params, args.Namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pod disruption budgets: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No pod disruption budgets found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+type pdbCheckArgs struct {
+	Node      string `json:"node"`
+	Namespace string `json:"namespace"`
+}
+
+func pdbCheck(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := pdbCheckArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check pod disruption budgets, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check pod disruption budgets, %w", err)), nil
+	}
+	ret, err := params.PodDisruptionBudgetsCheck(params, args.Namespace, args.Node)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check pod disruption budgets: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No pod disruption budgets blocking node drains found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initDrift() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "drift_check",
+			Description: "Detect the drift of the live Kubernetes resources from their desired manifests, provided inline, stored in a ConfigMap, or downloaded from an HTTPS URL " +
+				"(e.g. the raw URL of a file of a Git repository at a given commit). " +
+				"Only the fields of the desired manifests are compared, so the defaults and the fields populated by the server (status, uid, resourceVersion, managedFields...) are ignored. " +
+				"Returns, for each resource, its status (in-sync, drifted, or missing), the desired fields whose live value differs, " +
+				"and the fields changed manually by imperative commands (kubectl edit, patch, label, scale...) according to the managedFields",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"resource": {
+						Type:        "string",
+						Description: "A JSON or YAML containing the desired Kubernetes resources (multiple YAML documents separated by --- are supported)",
+					},
+					"configMap": {
+						Type:        "string",
+						Description: "Name of the ConfigMap storing the desired manifests, its YAML and JSON keys are read (alternative to resource)",
+					},
+					"configMapNamespace": {
+						Type:        "string",
+						Description: "Optional namespace of the ConfigMap (defaults to the configured namespace)",
+					},
+					"configMapKey": {
+						Type:        "string",
+						Description: "Optional key of the ConfigMap storing the desired manifests, only this key is read",
+					},
+					"url": {
+						Type:        "string",
+						Description: "HTTPS URL of the desired manifests, e.g. the raw URL of a file of a Git repository at a given commit (alternative to resource)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Drift Check",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: driftCheck},
+	}
+}
+
+type driftCheckArgs struct {
+	Resource           string `json:"resource"`
+	ConfigMap          string `json:"configMap"`
+	ConfigMapNamespace string `json:"configMapNamespace"`
+	ConfigMapKey       string `json:"configMapKey"`
+	URL                string `json:"url"`
+}
+
+func driftCheck(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := driftCheckArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check drift, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check drift, %w", err)), nil
+	}
+	drifts, err := params.DriftCheck(params, internalk8s.DriftCheckOptions{
+		Manifests:          args.Resource,
+		ConfigMapNamespace: args.ConfigMapNamespace,
+		ConfigMapName:      args.ConfigMap,
+		ConfigMapKey:       args.ConfigMapKey,
+		URL:                args.URL,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check drift: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(drifts)), nil
+}
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func initEfficiency() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "workloads_efficiency",
+			Description: "Report the efficiency of the requests and limits of the workloads by comparing the pod specs with the current usage reported by the Metrics Server, " +
+				"aggregated per workload (Deployment, StatefulSet, DaemonSet, Job, or standalone Pod) and container, with a summary per namespace. " +
+				"Flags the containers using less than 20% of their requests (over-requested), more than their requests (under-requested), without requests or limits, " +
+				"close to their CPU limit (likely throttled), close to their memory limit, or OOM killed. " +
+				"The usage is a point-in-time sample, confirm chronic gaps and throttling with the metrics history before resizing",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to report, all namespaces if not provided",
+					},
+					"labelSelector": {
+						Type:        "string",
+						Description: "Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label",
+						Pattern:     "([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]",
+					},
+					"flaggedOnly": {
+						Type:        "boolean",
+						Description: "Optional, only report the flagged containers, the namespace summary still includes all the containers (defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workloads: Efficiency",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadsEfficiency, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "metrics.k8s.io", Resource: "pods"},
+			{Verb: "list", Resource: "pods"},
+		}},
+	}
+}
+
+type workloadsEfficiencyArgs struct {
+	Namespace     string `json:"namespace"`
+	LabelSelector string `json:"labelSelector"`
+	FlaggedOnly   bool   `json:"flaggedOnly"`
+}
+
+func workloadsEfficiency(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := workloadsEfficiencyArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workloads efficiency, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workloads efficiency, %w", err)), nil
+	}
+	options := kubernetes.PodsTopOptions{AllNamespaces: args.Namespace == "", Namespace: args.Namespace}
+	options.LabelSelector = args.LabelSelector
+	ret, err := params.WorkloadsEfficiency(params, options)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workloads efficiency: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No running pods with metrics found", nil), nil
+	}
+	return api.NewToolCallResult(printEfficiency(ret, args.FlaggedOnly), nil), nil
+}
+
+// printEfficiency prints the efficiency of the containers and the summary of the namespaces as tables
+func printEfficiency(containers []kubernetes.ContainerEfficiency, flaggedOnly bool) string {
+	buf := new(bytes.Buffer)
+	w := printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprintln(w, "NAMESPACE\tWORKLOAD\tCONTAINER\tPODS\tRESTARTS\tCPU(cores)\tCPU REQUEST\tCPU LIMIT\tCPU EFFICIENCY\tMEMORY(bytes)\tMEMORY REQUEST\tMEMORY LIMIT\tMEMORY EFFICIENCY\tFLAGS")
+	for _, c := range containers {
+		if flaggedOnly && len(c.Flags) == 0 {
+			continue
+		}
+		flags := make([]string, 0, len(c.Flags))
+		for _, flag := range c.Flags {
+			flags = append(flags, string(flag))
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s/%s\t%s\t%d\t%d\t%dm\t%s\t%s\t%s\t%dMi\t%s\t%s\t%s\t%s\n", c.Namespace, c.Kind, c.Workload, c.Container, c.Pods, c.Restarts,
+			c.CPU.Usage.MilliValue(), quantityOrNone(c.CPU.Request), quantityOrNone(c.CPU.Limit), efficiencyOrNone(c.CPU),
+			c.Memory.Usage.Value()/(1024*1024), quantityOrNone(c.Memory.Request), quantityOrNone(c.Memory.Limit), efficiencyOrNone(c.Memory),
+			valueOrNone(strings.Join(flags, ",")))
+	}
+	_ = w.Flush()
+	_, _ = fmt.Fprintln(buf)
+	w = printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprintln(w, "NAMESPACE\tCONTAINERS\tFLAGGED\tCPU(cores)\tCPU REQUESTS\tCPU EFFICIENCY\tMEMORY(bytes)\tMEMORY REQUESTS\tMEMORY EFFICIENCY")
+	for _, n := range kubernetes.WorkloadsEfficiencyByNamespace(containers) {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%dm\t%s\t%s\t%dMi\t%s\t%s\n", n.Namespace, n.Containers, n.FlaggedContainers,
+			n.CPU.Usage.MilliValue(), quantityOrNone(n.CPU.Request), efficiencyOrNone(n.CPU),
+			n.Memory.Usage.Value()/(1024*1024), quantityOrNone(n.Memory.Request), efficiencyOrNone(n.Memory))
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+// efficiencyOrNone returns the usage as a percentage of the request
+func efficiencyOrNone(resource kubernetes.ResourceEfficiency) string {
+	efficiency, ok := resource.Efficiency()
+	if !ok {
+		return "<none>"
+	}
+	return fmt.Sprintf("%.0f%%", efficiency*100)
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
@@ -2,19 +2,32 @@ package core
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/output"
 )
 
+const (
+	eventsWatchDefaultDuration = 30 * time.Second
+	eventsWatchMaxDuration     = 5 * time.Minute
+	eventsWatchDefaultSince    = 15 * time.Minute
+	eventsWatchDefaultTop      = 20
+	// eventsWatchTimeout leaves room after the longest watch to list the existing events and compute the digest
+	eventsWatchTimeout = eventsWatchMaxDuration + api.DefaultListTimeout
+)
+
 func initEvents() []api.ServerTool {
 	return []api.ServerTool{
 		{Tool: api.Tool{
-			Name:        "events_list",
-			Description: "List all the Kubernetes events in the current cluster from all namespaces",
+			Name: "events_list",
+			Description: "List all the Kubernetes events in the current cluster from all namespaces, optionally filtered by involved object, type, and time window. " +
+				"Repeated occurrences of the same event are deduplicated (with their Count) and the events are sorted by their last occurrence",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
@@ -22,10 +35,32 @@ func initEvents() []api.ServerTool {
 						Type:        "string",
 						Description: "Optional Namespace to retrieve the events from. If not provided, will list events from all namespaces",
 					},
+					"involvedObjectKind": {
+						Type:        "string",
+						Description: "Optional kind of the object the events are about (e.g. Pod, Deployment, Node)",
+					},
+					"involvedObjectName": {
+						Type:        "string",
+						Description: "Optional name of the object the events are about",
+					},
+					"type": {
+						Type:        "string",
+						Description: "Optional type of the events to retrieve",
+						Enum:        []any{"Normal", "Warning"},
+					},
+					"since": {
+						Type:        "string",
+						Description: "Optional time window of the events to retrieve as a duration relative to now (e.g. '15m', '2h'), older events are omitted",
+					},
 					"cluster": {
 						Type:        "string",
 						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
 					},
+					api.SummarizeArgument: {
+						Type: "boolean",
+						Description: "Return a summary of the events written by the LLM of the client (MCP sampling) instead of the raw events (Optional). " +
+							"The events are summarized in chunks, each summary reports its event offsets",
+					},
 				},
 			},
 			Annotations: api.ToolAnnotations{
@@ -35,25 +70,174 @@ func initEvents() []api.ServerTool {
 				IdempotentHint:  ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: eventsList},
+		}, Handler: eventsList, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "events", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name: "events_watch",
+			Description: "Watch the Kubernetes events in the current cluster from all namespaces for a bounded window and return a ranked digest of what is going wrong right now. " +
+				"The recent events and the events received during the watch are deduplicated and grouped by involved object, " +
+				"each object is assigned the highest severity of its events (critical, high, medium, or low, by well-known reason such as OOMKilling, NodeNotReady, BackOff, or FailedScheduling) " +
+				"and a score combining the severity, the number of occurrences, and whether the events are ongoing (received during the watch). " +
+				"Use events_list to retrieve the raw events",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to watch the events from. If not provided, will watch events from all namespaces",
+					},
+					"duration": {
+						Type:        "string",
+						Description: fmt.Sprintf("Optional duration of the watch (e.g. '30s', '2m'), at most %s (defaults to %s)", eventsWatchMaxDuration, eventsWatchDefaultDuration),
+						Default:     api.ToRawMessage(eventsWatchDefaultDuration.String()),
+					},
+					"since": {
+						Type:        "string",
+						Description: fmt.Sprintf("Optional time window of the existing events included in the digest as a duration relative to now (e.g. '15m', '1h', defaults to %s)", eventsWatchDefaultSince),
+						Default:     api.ToRawMessage(eventsWatchDefaultSince.String()),
+					},
+					"includeNormal": {
+						Type:        "boolean",
+						Description: "Optional, include the Normal events, only the Warning events are reported otherwise (defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"top": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Optional maximum number of involved objects returned, the highest scores first (defaults to %d)", eventsWatchDefaultTop),
+						Default:     api.ToRawMessage(eventsWatchDefaultTop),
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Events: Watch",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: eventsWatch, Timeout: eventsWatchTimeout, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "events", AllNamespaces: true},
+			{Verb: "watch", Resource: "events", AllNamespaces: true},
+		}},
 	}
 }
 
+type eventsListArgs struct {
+	Namespace          string `json:"namespace"`
+	InvolvedObjectKind string `json:"involvedObjectKind"`
+	InvolvedObjectName string `json:"involvedObjectName"`
+	Type               string `json:"type"`
+	Since              string `json:"since"`
+	Summarize          bool   `json:"summarize"`
+}
+
 func eventsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	namespace := params.GetArguments()["namespace"]
-	if namespace == nil {
-		namespace = ""
+	args := eventsListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list events, %w", err)), nil
+	}
+	eventsListOptions := kubernetes.EventsListOptions{
+		InvolvedObjectKind: args.InvolvedObjectKind,
+		InvolvedObjectName: args.InvolvedObjectName,
+		Type:               args.Type,
+	}
+	if args.Since != "" {
+		since, err := time.ParseDuration(args.Since)
+		if err != nil {
+			return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+				fmt.Errorf("failed to list events, invalid since %q: %w", args.Since, err))), nil
+		}
+		eventsListOptions.Since = since
 	}
-	eventMap, err := params.EventsList(params, namespace.(string))
+	eventMap, err := params.EventsList(params, args.Namespace, eventsListOptions)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to list events in all namespaces: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to list events in all namespaces: %w", err)), nil
 	}
 	if len(eventMap) == 0 {
 		return api.NewToolCallResult("# No events found", nil), nil
 	}
+	if args.Summarize {
+		return eventsSummary(params, eventMap)
+	}
 	yamlEvents, err := output.MarshalYaml(eventMap)
 	if err != nil {
-		err = fmt.Errorf("failed to list events in all namespaces: %v", err)
+		err = fmt.Errorf("failed to list events in all namespaces: %w", err)
 	}
 	return api.NewToolCallResult(fmt.Sprintf("# The following events (YAML format) were found:\n%s", yamlEvents), err), nil
 }
+
+type eventsWatchArgs struct {
+	Namespace     string `json:"namespace"`
+	Duration      string `json:"duration"`
+	Since         string `json:"since"`
+	IncludeNormal bool   `json:"includeNormal"`
+	Top           int    `json:"top"`
+}
+
+func eventsWatch(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := eventsWatchArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to watch events, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to watch events, %w", err)), nil
+	}
+	options := kubernetes.EventsWatchOptions{
+		Duration:      eventsWatchDefaultDuration,
+		Since:         eventsWatchDefaultSince,
+		IncludeNormal: args.IncludeNormal,
+		Top:           eventsWatchDefaultTop,
+	}
+	if args.Duration != "" {
+		duration, err := time.ParseDuration(args.Duration)
+		if err == nil && (duration <= 0 || duration > eventsWatchMaxDuration) {
+			err = fmt.Errorf("must be positive and at most %s", eventsWatchMaxDuration)
+		}
+		if err != nil {
+			return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+				fmt.Errorf("failed to watch events, invalid duration %q: %w", args.Duration, err))), nil
+		}
+		options.Duration = duration
+	}
+	if args.Since != "" {
+		since, err := time.ParseDuration(args.Since)
+		if err != nil {
+			return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+				fmt.Errorf("failed to watch events, invalid since %q: %w", args.Since, err))), nil
+		}
+		options.Since = since
+	}
+	if args.Top > 0 {
+		options.Top = args.Top
+	}
+	digest, err := params.EventsWatch(params, args.Namespace, options)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to watch events: %w", err)), nil
+	}
+	if digest.Objects == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("# No events found (watched %s, since %s)", digest.Watched, digest.Since), nil), nil
+	}
+	yamlDigest, err := output.MarshalYaml(digest)
+	if err != nil {
+		err = fmt.Errorf("failed to watch events: %w", err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following events digest (YAML format, ranked by score) was found:\n%s", yamlDigest), err), nil
+}
+
+func eventsSummary(params api.ToolHandlerParams, eventMap []map[string]any) (*api.ToolCallResult, error) {
+	records := make([]string, 0, len(eventMap))
+	for _, event := range eventMap {
+		record, err := output.MarshalYaml([]map[string]any{event})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to summarize events: %w", err)), nil
+		}
+		records = append(records, strings.TrimRight(record, "\n"))
+	}
+	chunks, err := api.Summarize(params, "events", records)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to summarize events: %w", err)), nil
+	}
+	return api.NewToolCallResult(api.SummaryContent(fmt.Sprintf("Summary of the %d events", len(eventMap)), "Events", chunks), nil), nil
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initImages() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "images_list",
+			Description: "List the container images used in the cluster (or in several managed clusters in ACM mode) as a deduplicated inventory. " +
+				"Each image includes the workloads (Deployments, StatefulSets, DaemonSets, CronJobs, and standalone Jobs and Pods) and containers using it, " +
+				"their pull policy, and whether their pod template references imagePullSecrets. " +
+				"Useful to find the workloads running a vulnerable or deprecated image, or images pulled from untrusted registries",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to scan, all namespaces if not provided",
+					},
+					"clusters": {
+						Type:        "array",
+						Description: "Optional managed cluster names to scan in ACM mode, the inventory merges the images of all the clusters (takes precedence over cluster)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"cluster": {
+						Type:        "string",
+						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Images: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: imagesList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "apps", Resource: "deployments", AllNamespaces: true},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+		}},
+	}
+}
+
+type imagesListArgs struct {
+	Namespace string   `json:"namespace"`
+	Clusters  []string `json:"clusters"`
+}
+
+func imagesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := imagesListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list images, %w", err)), nil
+	}
+	var workloads []internalk8s.ImageWorkload
+	err := forEachCluster(params, args.Clusters, "list images", func(params api.ToolHandlerParams, cluster string) error {
+		clusterWorkloads, err := internalk8s.ImagesList(params, params.ResourcesList, args.Namespace)
+		workloads = append(workloads, imagesInCluster(clusterWorkloads, cluster)...)
+		return err
+	})
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	return imagesListResult(workloads)
+}
+
+func imagesInCluster(workloads []internalk8s.ImageWorkload, cluster string) []internalk8s.ImageWorkload {
+	for i := range workloads {
+		workloads[i].Cluster = cluster
+	}
+	return workloads
+}
+
+func imagesListResult(workloads []internalk8s.ImageWorkload) (*api.ToolCallResult, error) {
+	if len(workloads) == 0 {
+		return api.NewToolCallResult("No images found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(internalk8s.ImagesInventory(workloads))), nil
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initKustomize() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "kustomize_build",
+			Description: "Render a kustomization (same as kustomize build) provided as an inline map of files or stored in a ConfigMap, and optionally apply the result. " +
+				"Plugins are disabled and files can't be loaded from outside the root of the kustomization. " +
+				"If apply is set, the rendered resources are server-side applied and the apply status of each object is returned, " +
+				"fields managed by a different field manager are reported as conflicts unless forceConflicts is set",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"files": {
+						Type: "object",
+						Description: "Optional kustomization files, each one as its path relative to the root of the kustomization with its content " +
+							"(e.g. {\"base/kustomization.yaml\": \"...\", \"base/deployment.yaml\": \"...\", \"overlays/prod/kustomization.yaml\": \"...\"})",
+						AdditionalProperties: &jsonschema.Schema{Type: "string"},
+					},
+					"configMap": {
+						Type: "object",
+						Description: "Optional ConfigMap storing the kustomization files, used if no files are provided. " +
+							"Each key is a file, with __ as the path separator (e.g. overlays__prod__kustomization.yaml)",
+						Properties: map[string]*jsonschema.Schema{
+							"namespace": {Type: "string", Description: "Optional Namespace of the ConfigMap, the configured namespace if not provided"},
+							"name":      {Type: "string", Description: "Name of the ConfigMap"},
+						},
+						Required: []string{"name"},
+					},
+					"path": {
+						Type:        "string",
+						Description: "Optional directory of the kustomization to build relative to the root (e.g. overlays/prod), the root if not provided",
+					},
+					"apply": {
+						Type:        "boolean",
+						Description: "Optional, server-side apply the rendered resources (defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"fieldManager": {
+						Type:        "string",
+						Description: "Optional name of the field manager owning the applied fields (defaults to kubernetes-mcp-server)",
+					},
+					"forceConflicts": {
+						Type:        "boolean",
+						Description: "Optional, take the ownership of the fields in conflict with other field managers and overwrite their values (defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Kustomize: Build",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: kustomizeBuild, DryRun: true},
+	}
+}
+
+type kustomizeBuildArgs struct {
+	Files     map[string]string `json:"files"`
+	ConfigMap struct {
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+	} `json:"configMap"`
+	Path           string `json:"path"`
+	Apply          bool   `json:"apply"`
+	FieldManager   string `json:"fieldManager"`
+	ForceConflicts bool   `json:"forceConflicts"`
+}
+
+func kustomizeBuild(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := kustomizeBuildArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to build kustomization, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to build kustomization, %w", err)), nil
+	}
+	ret, err := params.KustomizeBuild(params, internalk8s.KustomizeBuildOptions{
+		Files:              args.Files,
+		ConfigMapNamespace: args.ConfigMap.Namespace,
+		ConfigMapName:      args.ConfigMap.Name,
+		Path:               args.Path,
+		Apply:              args.Apply,
+		ApplyOptions:       internalk8s.ApplyOptions{FieldManager: args.FieldManager, Force: args.ForceConflicts},
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to build kustomization: %w", err)), nil
+	}
+	if !args.Apply {
+		return api.NewToolCallResult("# The kustomization has been rendered to the following resources (YAML)\n"+ret.Rendered, nil), nil
+	}
+	var failures []error
+	for _, result := range ret.Results {
+		if result.Err != nil {
+			failures = append(failures, fmt.Errorf("%s %s: %w", result.Kind, result.Name, result.Err))
+		}
+	}
+	if len(failures) > 0 && len(failures) == len(ret.Results) {
+		return api.NewToolCallResult("", fmt.Errorf("failed to apply kustomization: %w", errors.Join(failures...))), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret.Results)
+	if err != nil {
+		err = fmt.Errorf("failed to apply kustomization: %w", err)
+	}
+	return api.NewToolCallResult("# The kustomization has been applied, apply status of each resource (YAML)\n"+marshalledYaml, err), nil
+}
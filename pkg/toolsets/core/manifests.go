@@ -0,0 +1,118 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initManifests() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "resources_apply_url",
+			Description: fmt.Sprintf("Apply the Kubernetes manifests downloaded from an HTTPS URL (same as kubectl apply -f https://...), e.g. to install an operator. "+
+				"The download is limited to %d MiB and verified against the sha256 checksum if provided, the URLs resolving to loopback, private, or link-local addresses are refused. "+
+				"The ${NAME} placeholders of the manifests are substituted with the provided env values. "+
+				"Returns the diff against the live cluster state computed before applying, and the apply status of each resource. "+
+				"Resources are server-side applied, fields managed by a different field manager are reported as conflicts unless forceConflicts is set",
+				internalk8s.ManifestMaxSize/1024/1024),
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"url": {
+						Type:        "string",
+						Description: "HTTPS URL of the manifests (multiple YAML documents are supported)",
+					},
+					"sha256": {
+						Type:        "string",
+						Description: "Optional expected hex encoded sha256 checksum of the downloaded manifests, the apply fails if it doesn't match",
+					},
+					"env": {
+						Type:                 "object",
+						Description:          "Optional values to substitute for the ${NAME} placeholders of the manifests, the placeholders without value are kept",
+						AdditionalProperties: &jsonschema.Schema{Type: "string"},
+					},
+					"diffOnly": {
+						Type:        "boolean",
+						Description: "Optional, only show the diff against the live cluster state without applying the manifests (defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"fieldManager": {
+						Type:        "string",
+						Description: "Optional name of the field manager owning the applied fields (defaults to kubernetes-mcp-server)",
+					},
+					"forceConflicts": {
+						Type:        "boolean",
+						Description: "Optional, take the ownership of the fields in conflict with other field managers and overwrite their values (defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+				Required: []string{"url"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Apply from URL",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesApplyURL, DryRun: true},
+	}
+}
+
+type resourcesApplyURLArgs struct {
+	URL            string            `json:"url"`
+	SHA256         string            `json:"sha256"`
+	Env            map[string]string `json:"env"`
+	DiffOnly       bool              `json:"diffOnly"`
+	FieldManager   string            `json:"fieldManager"`
+	ForceConflicts bool              `json:"forceConflicts"`
+}
+
+func resourcesApplyURL(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := resourcesApplyURLArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to apply resources from url, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to apply resources from url, %w", err)), nil
+	}
+	ret, err := params.ResourcesApplyURL(params, internalk8s.ResourcesApplyURLOptions{
+		URL:          args.URL,
+		SHA256:       args.SHA256,
+		Env:          args.Env,
+		DiffOnly:     args.DiffOnly,
+		ApplyOptions: internalk8s.ApplyOptions{FieldManager: args.FieldManager, Force: args.ForceConflicts},
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to apply resources from url: %w", err)), nil
+	}
+	diffs, err := diffsOutput(ret.Diffs)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to apply resources from url: %w", err)), nil
+	}
+	out := "# Downloaded manifests sha256: " + ret.SHA256 + "\n" + diffs
+	if args.DiffOnly {
+		return api.NewToolCallResult(out, nil), nil
+	}
+	var failures []error
+	for _, result := range ret.Results {
+		if result.Err != nil {
+			failures = append(failures, fmt.Errorf("%s %s: %w", result.Kind, result.Name, result.Err))
+		}
+	}
+	if len(failures) > 0 && len(failures) == len(ret.Results) {
+		return api.NewToolCallResult("", fmt.Errorf("failed to apply resources from url: %w", errors.Join(failures...))), nil
+	}
+	results, err := output.MarshalYaml(ret.Results)
+	if err != nil {
+		err = fmt.Errorf("failed to apply resources from url: %w", err)
+	}
+	return api.NewToolCallResult(out+"# The manifests have been applied, apply status of each resource (YAML)\n"+results, err), nil
+}
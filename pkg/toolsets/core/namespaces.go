@@ -9,6 +9,7 @@ import (
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
 )
 
 func initNamespaces(o internalk8s.Openshift) []api.ServerTool {
@@ -19,12 +20,12 @@ func initNamespaces(o internalk8s.Openshift) []api.ServerTool {
 			Description: "List all the Kubernetes namespaces in the current cluster",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
+				Properties: api.WithListProperties(map[string]*jsonschema.Schema{
 					"cluster": {
 						Type:        "string",
 						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
 					},
-				},
+				}),
 			},
 			Annotations: api.ToolAnnotations{
 				Title:           "Namespaces: List",
@@ -33,7 +34,90 @@ func initNamespaces(o internalk8s.Openshift) []api.ServerTool {
 				IdempotentHint:  ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: namespacesList,
+		}, Handler: namespacesList, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "namespaces", ClusterScoped: true},
+		},
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "namespaces_create",
+			Description: "Create a Kubernetes namespace, or update its labels if it already exists",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the namespace to create",
+					},
+					"labels": {
+						Type:                 "object",
+						Description:          "Optional labels of the namespace (e.g. {\"pod-security.kubernetes.io/enforce\": \"restricted\"})",
+						AdditionalProperties: &jsonschema.Schema{Type: "string"},
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Namespaces: Create",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: namespacesCreate, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "patch", Resource: "namespaces", ClusterScoped: true},
+		},
+	}, api.ServerTool{
+		Tool: api.Tool{
+			Name: "namespaces_delete",
+			Description: "Delete a Kubernetes namespace and all the resources it contains. " +
+				"The deletion is asynchronous, the namespace status is returned and, if it stays Terminating, the resources and finalizers blocking the deletion",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the namespace to delete",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Namespaces: Delete",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: namespacesDelete, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "delete", Resource: "namespaces", ClusterScoped: true},
+		},
+	}, api.ServerTool{
+		Tool: api.Tool{
+			Name: "namespaces_status",
+			Description: "Get the lifecycle status of a Kubernetes namespace. " +
+				"If the namespace is stuck Terminating, returns the resources remaining in it across all the API groups with their finalizers, " +
+				"the unavailable API services, and the deletion conditions blocking the deletion",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the namespace",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Namespaces: Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: namespacesStatus, Access: []api.ResourceAccess{
+			{Verb: "get", Resource: "namespaces", ClusterScoped: true},
+		},
 	})
 	if o.IsOpenShift(context.Background()) {
 		ret = append(ret, api.ServerTool{
@@ -42,12 +126,12 @@ func initNamespaces(o internalk8s.Openshift) []api.ServerTool {
 				Description: "List all the OpenShift projects in the current cluster",
 				InputSchema: &jsonschema.Schema{
 					Type: "object",
-					Properties: map[string]*jsonschema.Schema{
+					Properties: api.WithListProperties(map[string]*jsonschema.Schema{
 						"cluster": {
 							Type:        "string",
 							Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
 						},
-					},
+					}),
 				},
 				Annotations: api.ToolAnnotations{
 					Title:           "Projects: List",
@@ -63,17 +147,95 @@ func initNamespaces(o internalk8s.Openshift) []api.ServerTool {
 }
 
 func namespacesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	ret, err := params.NamespacesList(params, internalk8s.ResourceListOptions{AsTable: params.ListOutput.AsTable()})
+	args := api.ListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list namespaces, %w", err)), nil
+	}
+	listOptions, err := args.ResourceListOptions("", params.ListOutput.AsTable())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list namespaces, %w", err)), nil
+	}
+	ret, err := params.NamespacesList(params, listOptions)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to list namespaces: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to list namespaces: %w", err)), nil
 	}
 	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
 }
 
+type namespacesCreateArgs struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+}
+
+func namespacesCreate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := namespacesCreateArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create namespace, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create namespace, %w", err)), nil
+	}
+	ret, err := params.NamespacesCreate(params, args.Name, args.Labels)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create namespace: %w", err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to create namespace: %w", err)
+	}
+	return api.NewToolCallResult("# The following resource (YAML) has been created or updated successfully\n"+marshalledYaml, err), nil
+}
+
+type namespacesNameArgs struct {
+	Name string `json:"name"`
+}
+
+func namespacesDelete(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := namespacesNameArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to delete namespace, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to delete namespace, %w", err)), nil
+	}
+	ret, err := params.NamespacesDelete(params, args.Name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to delete namespace: %w", err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to delete namespace: %w", err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# Deletion of namespace %s requested, current status (YAML)\n", args.Name)+marshalledYaml, err), nil
+}
+
+func namespacesStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := namespacesNameArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get namespace status, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get namespace status, %w", err)), nil
+	}
+	ret, err := params.NamespacesStatus(params, args.Name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get namespace status: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
 func projectsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	ret, err := params.ProjectsList(params, internalk8s.ResourceListOptions{AsTable: params.ListOutput.AsTable()})
+	args := api.ListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list projects, %w", err)), nil
+	}
+	listOptions, err := args.ResourceListOptions("", params.ListOutput.AsTable())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list projects, %w", err)), nil
+	}
+	ret, err := params.ProjectsList(params, listOptions)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to list projects: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to list projects: %w", err)), nil
 	}
 	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
 }
@@ -0,0 +1,388 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubectl/pkg/metricsutil"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initNodes() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "nodes_top",
+			Description: "List the resource consumption (CPU and memory) as recorded by the Kubernetes Metrics Server for the specified Kubernetes Nodes, along with the percentage of their allocatable resources",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Node to get the resource consumption from (Optional, all Nodes if not provided)",
+					},
+					"label_selector": {
+						Type:        "string",
+						Description: "Kubernetes label selector (e.g. 'node-role.kubernetes.io/worker='), use this option when you want to filter the nodes by label (Optional, only applicable when name is not provided)",
+						Pattern:     "([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]",
+					},
+					"sort_by": {
+						Type:        "string",
+						Description: "Sort the Nodes by their consumption of the provided resource, highest first (Optional)",
+						Enum:        []any{sortByCPU, sortByMemory},
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Nodes: Top",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: nodesTop, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "metrics.k8s.io", Resource: "nodes"},
+			{Verb: "list", Resource: "nodes"},
+		}},
+		{Tool: api.Tool{
+			Name: "nodes_health",
+			Description: "Report the health of the Kubernetes Nodes: the abnormal conditions (NotReady with its duration, MemoryPressure, DiskPressure, PIDPressure, NetworkUnavailable, and node problem conditions), " +
+				"the allocatable resources compared to the requests and limits of the running Pods (cpu, memory, and pods), and the kubelet, container runtime, and kernel versions. " +
+				"The Nodes not ready or under pressure, with more than 90% of their resources requested, with limits overcommitted above 200%, " +
+				"requested well above the average of the Nodes, or running a kubelet or runtime version different from most Nodes are flagged, and listed first",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Node to report (Optional, all Nodes if not provided)",
+					},
+					"label_selector": {
+						Type:        "string",
+						Description: "Kubernetes label selector (e.g. 'node-role.kubernetes.io/worker='), use this option when you want to filter the nodes by label (Optional, only applicable when name is not provided)",
+						Pattern:     "([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]",
+					},
+					"cluster": {
+						Type:        "string",
+						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Nodes: Health",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: nodesHealth, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "nodes", ClusterScoped: true},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name:        "nodes_cordon",
+			Description: "Cordon a Kubernetes Node, marking it as unschedulable so that no new Pods are scheduled on it (the Pods already running in the Node are not affected)",
+			InputSchema: nodeSchema("Name of the Node to cordon"),
+			Annotations: api.ToolAnnotations{
+				Title:           "Nodes: Cordon",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: nodesCordon, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "patch", Resource: "nodes", ClusterScoped: true},
+		}},
+		{Tool: api.Tool{
+			Name:        "nodes_uncordon",
+			Description: "Uncordon a Kubernetes Node, marking it as schedulable again after a cordon or a drain",
+			InputSchema: nodeSchema("Name of the Node to uncordon"),
+			Annotations: api.ToolAnnotations{
+				Title:           "Nodes: Uncordon",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: nodesUncordon, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "patch", Resource: "nodes", ClusterScoped: true},
+		}},
+		{Tool: api.Tool{
+			Name: "nodes_drain",
+			Description: "Drain a Kubernetes Node for maintenance (same as kubectl drain --ignore-daemonsets): cordons the Node and evicts its Pods through the Eviction API, honoring PodDisruptionBudgets. " +
+				"DaemonSet and mirror (static) Pods are skipped. Pods not managed by a controller or using emptyDir volumes block the drain unless explicitly allowed. " +
+				"Evictions blocked by PodDisruptionBudgets are retried until the timeout. Reports the progress of each evicted Pod and returns the evicted, skipped, and failed Pods",
+			InputSchema: nodeSchema("Name of the Node to drain", map[string]*jsonschema.Schema{
+				"gracePeriodSeconds": {
+					Type:        "integer",
+					Description: "Optional termination grace period of the evicted Pods in seconds, the grace period of each Pod is used if not provided",
+					Minimum:     ptr.To(float64(0)),
+				},
+				"force": {
+					Type:        "boolean",
+					Description: "Optional, evict the Pods not managed by a controller (ReplicaSet, StatefulSet, Job, etc.), they won't be recreated in another Node (defaults to false)",
+					Default:     api.ToRawMessage(false),
+				},
+				"deleteEmptyDirData": {
+					Type:        "boolean",
+					Description: "Optional, evict the Pods using emptyDir volumes, their data is lost (defaults to false)",
+					Default:     api.ToRawMessage(false),
+				},
+				"timeout": {
+					Type:        "integer",
+					Description: "Optional time in seconds to keep retrying the evictions blocked by PodDisruptionBudgets (defaults to 300)",
+					Minimum:     ptr.To(float64(1)),
+				},
+			}),
+			Annotations: api.ToolAnnotations{
+				Title:           "Nodes: Drain",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: nodesDrain, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "patch", Resource: "nodes", ClusterScoped: true},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+			{Verb: "create", Resource: "pods", Subresource: "eviction", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name: "nodes_taint",
+			Description: "Add, update, or remove taints of a Kubernetes Node (same as kubectl taint nodes). " +
+				"Returns the added, updated, and removed taints, the resulting taints, and the running Pods that don't tolerate the added NoExecute taints and will be evicted " +
+				"(use dryRun to preview the affected Pods before applying the taints)",
+			InputSchema: nodeSchema("Name of the Node to taint", map[string]*jsonschema.Schema{
+				"add": {
+					Type:        "array",
+					Description: "Optional taints to add, or to update if a taint with the same key and effect exists (e.g. [{\"key\": \"dedicated\", \"value\": \"gpu\", \"effect\": \"NoSchedule\"}])",
+					Items: &jsonschema.Schema{
+						Type: "object",
+						Properties: map[string]*jsonschema.Schema{
+							"key":    {Type: "string", Description: "Key of the taint"},
+							"value":  {Type: "string", Description: "Optional value of the taint"},
+							"effect": {Type: "string", Description: "Effect of the taint", Enum: taintEffects()},
+						},
+						Required: []string{"key", "effect"},
+					},
+				},
+				"remove": {
+					Type:        "array",
+					Description: "Optional taints to remove by key, and by effect if provided (all the effects of the key are removed otherwise), taints not set in the Node are ignored",
+					Items: &jsonschema.Schema{
+						Type: "object",
+						Properties: map[string]*jsonschema.Schema{
+							"key":    {Type: "string", Description: "Key of the taint"},
+							"effect": {Type: "string", Description: "Optional effect of the taint", Enum: taintEffects()},
+						},
+						Required: []string{"key"},
+					},
+				},
+				"overwrite": {
+					Type:        "boolean",
+					Description: "Optional, allow updating the value of taints already set with the same key and effect, the update fails otherwise (defaults to false)",
+					Default:     api.ToRawMessage(false),
+				},
+			}),
+			Annotations: api.ToolAnnotations{
+				Title:           "Nodes: Taint",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true), // NoExecute taints evict the Pods that don't tolerate them
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: nodesTaint, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "patch", Resource: "nodes", ClusterScoped: true},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+		}},
+	}
+}
+
+func taintEffects() []any {
+	ret := make([]any, 0, len(kubernetes.TaintEffects))
+	for _, effect := range kubernetes.TaintEffects {
+		ret = append(ret, effect)
+	}
+	return ret
+}
+
+// nodeSchema returns the input schema of the tools operating on a single Node, with the additional properties
+func nodeSchema(nameDescription string, properties ...map[string]*jsonschema.Schema) *jsonschema.Schema {
+	ret := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"name": {
+				Type:        "string",
+				Description: nameDescription,
+			},
+		},
+		Required: []string{"name"},
+	}
+	for _, p := range properties {
+		maps.Copy(ret.Properties, p)
+	}
+	return ret
+}
+
+type nodesTopArgs struct {
+	Name          string `json:"name"`
+	LabelSelector string `json:"label_selector"`
+	SortBy        string `json:"sort_by"`
+}
+
+func nodesTop(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := nodesTopArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get nodes top, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get nodes top, %w", err)), nil
+	}
+	nodesTopOptions := kubernetes.NodesTopOptions{Name: args.Name}
+	nodesTopOptions.LabelSelector = args.LabelSelector
+	ret, allocatable, err := params.NodesTop(params, nodesTopOptions)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get nodes top: %w", err)), nil
+	}
+	buf := new(bytes.Buffer)
+	printer := metricsutil.NewTopCmdPrinter(buf, true)
+	err = printer.PrintNodeMetrics(ret.Items, allocatable, false, args.SortBy)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get nodes top: %w", err)), nil
+	}
+	return api.NewToolCallResult(buf.String(), nil), nil
+}
+
+type nodesHealthArgs struct {
+	Name          string `json:"name"`
+	LabelSelector string `json:"label_selector"`
+}
+
+func nodesHealth(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := nodesHealthArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get nodes health, %w", err)), nil
+	}
+	ret, err := kubernetes.NodesHealthCheck(params, params.ResourcesList, args.Name, args.LabelSelector)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get nodes health: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+type nodesCordonArgs struct {
+	Name string `json:"name"`
+}
+
+func nodesCordon(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := nodesCordonArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to cordon node, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to cordon node, %w", err)), nil
+	}
+	changed, err := params.NodesCordon(params, args.Name, true)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to cordon node: %w", err)), nil
+	}
+	if !changed {
+		return api.NewToolCallResult(fmt.Sprintf("Node %s is already cordoned", args.Name), nil), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Node %s cordoned successfully", args.Name), nil), nil
+}
+
+func nodesUncordon(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := nodesCordonArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to uncordon node, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to uncordon node, %w", err)), nil
+	}
+	changed, err := params.NodesCordon(params, args.Name, false)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to uncordon node: %w", err)), nil
+	}
+	if !changed {
+		return api.NewToolCallResult(fmt.Sprintf("Node %s is already schedulable", args.Name), nil), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Node %s uncordoned successfully", args.Name), nil), nil
+}
+
+type nodesDrainArgs struct {
+	Name               string `json:"name"`
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds"`
+	Force              bool   `json:"force"`
+	DeleteEmptyDirData bool   `json:"deleteEmptyDirData"`
+	Timeout            int64  `json:"timeout"`
+}
+
+func nodesDrain(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := nodesDrainArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to drain node, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to drain node, %w", err)), nil
+	}
+	ret, err := params.NodesDrain(params, args.Name, kubernetes.NodesDrainOptions{
+		GracePeriodSeconds: args.GracePeriodSeconds,
+		Force:              args.Force,
+		DeleteEmptyDirData: args.DeleteEmptyDirData,
+		Timeout:            time.Duration(args.Timeout) * time.Second,
+		OnEvicted: func(pod kubernetes.DrainPod, processed, total int) {
+			message := fmt.Sprintf("pod %s/%s evicted", pod.Namespace, pod.Name)
+			if pod.Reason != "" {
+				message = fmt.Sprintf("pod %s/%s could not be evicted: %s", pod.Namespace, pod.Name, pod.Reason)
+			}
+			params.NotifyProgress(float64(processed), float64(total), message)
+		},
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to drain node: %w", err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to drain node: %w", err)), nil
+	}
+	if len(ret.Failed) > 0 {
+		return api.NewToolCallResult(fmt.Sprintf("# Node %s was partially drained, %d pods could not be evicted\n", args.Name, len(ret.Failed))+marshalledYaml, nil), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# Node %s drained successfully\n", args.Name)+marshalledYaml, nil), nil
+}
+
+type nodesTaintArgs struct {
+	Name      string     `json:"name"`
+	Add       []v1.Taint `json:"add"`
+	Remove    []v1.Taint `json:"remove"`
+	Overwrite bool       `json:"overwrite"`
+}
+
+func nodesTaint(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := nodesTaintArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to taint node, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to taint node, %w", err)), nil
+	}
+	ret, err := params.NodesTaint(params, args.Name, kubernetes.NodesTaintOptions{Add: args.Add, Remove: args.Remove, Overwrite: args.Overwrite})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to taint node: %w", err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to taint node: %w", err)), nil
+	}
+	if !ret.Changed() {
+		return api.NewToolCallResult(fmt.Sprintf("# Node %s already has the requested taints, no changes were made\n", args.Name)+marshalledYaml, nil), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following taints (YAML) of Node %s have been updated successfully\n", args.Name)+marshalledYaml, nil), nil
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initOwners() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "resources_owners",
+			Description: "Get the ownership tree of a Kubernetes resource by following its ownerReferences upward (e.g. Pod -> ReplicaSet -> Deployment, or up to a custom resource) " +
+				"and listing its dependents downward (e.g. Deployment -> ReplicaSets -> Pods). " +
+				"Returns the controller, the top-level owner to edit to change the resource durably (changes to the owned resources are reverted by their controllers). " +
+				"The dependents are searched in all the resources of the namespace of the resource (all namespaces for cluster scoped resources)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the resource (ignored in case of cluster scoped resources). If not provided, the configured namespace is used",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the resource",
+					},
+					"direction": {
+						Type:        "string",
+						Description: "Optional direction of the traversal: up (owners only), down (dependents only), or both (defaults to both)",
+						Enum:        []any{internalk8s.OwnersDirectionUp, internalk8s.OwnersDirectionDown, internalk8s.OwnersDirectionBoth},
+						Default:     api.ToRawMessage(internalk8s.OwnersDirectionBoth),
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Owners",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesOwners},
+	}
+}
+
+type resourcesOwnersArgs struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Direction  string `json:"direction"`
+}
+
+func resourcesOwners(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := resourcesOwnersArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resource owners, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resource owners, %w", err)), nil
+	}
+	gvk, err := parseGroupVersionKind(args.APIVersion, args.Kind)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resource owners, %w", err)), nil
+	}
+	ret, err := params.ResourcesOwners(params, gvk, args.Namespace, args.Name, args.Direction)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resource owners: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -4,8 +4,14 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/kubectl/pkg/metricsutil"
 	"k8s.io/utils/ptr"
 
@@ -21,7 +27,7 @@ func initPods() []api.ServerTool {
 			Description: "List all the Kubernetes pods in the current cluster from all namespaces",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
+				Properties: api.WithListProperties(map[string]*jsonschema.Schema{
 					"labelSelector": {
 						Type:        "string",
 						Description: "Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label",
@@ -31,7 +37,7 @@ func initPods() []api.ServerTool {
 						Type:        "string",
 						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
 					},
-				},
+				}),
 			},
 			Annotations: api.ToolAnnotations{
 				Title:           "Pods: List",
@@ -40,13 +46,15 @@ func initPods() []api.ServerTool {
 				IdempotentHint:  ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: podsListInAllNamespaces},
+		}, Handler: podsListInAllNamespaces, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+		}},
 		{Tool: api.Tool{
 			Name:        "pods_list_in_namespace",
 			Description: "List all the Kubernetes pods in the specified namespace in the current cluster",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
+				Properties: api.WithListProperties(map[string]*jsonschema.Schema{
 					"namespace": {
 						Type:        "string",
 						Description: "Namespace to list pods from",
@@ -60,7 +68,7 @@ func initPods() []api.ServerTool {
 						Type:        "string",
 						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
 					},
-				},
+				}),
 				Required: []string{"namespace"},
 			},
 			Annotations: api.ToolAnnotations{
@@ -70,7 +78,9 @@ func initPods() []api.ServerTool {
 				IdempotentHint:  ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: podsListInNamespace},
+		}, Handler: podsListInNamespace, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "pods"},
+		}},
 		{Tool: api.Tool{
 			Name:        "pods_get",
 			Description: "Get a Kubernetes Pod in the current or provided namespace with the provided name",
@@ -99,7 +109,9 @@ func initPods() []api.ServerTool {
 				IdempotentHint:  ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: podsGet},
+		}, Handler: podsGet, Access: []api.ResourceAccess{
+			{Verb: "get", Resource: "pods"},
+		}},
 		{Tool: api.Tool{
 			Name:        "pods_delete",
 			Description: "Delete a Kubernetes Pod in the current or provided namespace with the provided name",
@@ -128,7 +140,76 @@ func initPods() []api.ServerTool {
 				IdempotentHint:  ptr.To(true),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: podsDelete},
+		}, Handler: podsDelete, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "delete", Resource: "pods"},
+		}},
+		{Tool: api.Tool{
+			Name: "pods_evict",
+			Description: "Evict a Kubernetes Pod in the current or provided namespace with the provided name through the Eviction API (same as a node drain does), " +
+				"unlike pods_delete the PodDisruptionBudgets protecting the Pod are honored. " +
+				"If the eviction is refused, the PodDisruptionBudgets blocking it are returned with the reason they allow no disruptions",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to evict the Pod from",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod to evict",
+					},
+					"gracePeriodSeconds": {
+						Type:        "integer",
+						Description: "Optional termination grace period of the evicted Pod in seconds, the grace period of the Pod is used if not provided",
+						Minimum:     ptr.To(float64(0)),
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Evict",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsEvict, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "create", Resource: "pods", Subresource: "eviction"},
+		}},
+		{Tool: api.Tool{
+			Name: "pods_why_pending",
+			Description: "Explain why a Kubernetes Pod in the current or provided namespace is Pending and not scheduled: " +
+				"returns the scheduler condition and FailedScheduling events, the issues of its scheduling gates and PersistentVolumeClaims, " +
+				"and for each Node the constraints rejecting the Pod (cordon, nodeSelector, node affinity, untolerated taints, insufficient resources, host ports, " +
+				"pod affinity and anti-affinity, topology spread constraints, and volume node affinity), summarized per constraint with the constraints to relax",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pending Pod",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pending Pod",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Why Pending",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsWhyPending, Access: []api.ResourceAccess{
+			{Verb: "get", Resource: "pods"},
+			{Verb: "list", Resource: "nodes", ClusterScoped: true},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+			{Verb: "list", Resource: "events"},
+		}},
 		{Tool: api.Tool{
 			Name:        "pods_top",
 			Description: "List the resource consumption (CPU and memory) as recorded by the Kubernetes Metrics Server for the specified Kubernetes Pods in the all namespaces, the provided namespace, or the current namespace",
@@ -153,6 +234,21 @@ func initPods() []api.ServerTool {
 						Description: "Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label (Optional, only applicable when name is not provided)",
 						Pattern:     "([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]",
 					},
+					"sort_by": {
+						Type:        "string",
+						Description: "Sort the Pods by their consumption of the provided resource, highest first (Optional)",
+						Enum:        []any{sortByCPU, sortByMemory},
+					},
+					"compare_requests": {
+						Type: "boolean",
+						Description: "If true, compare the consumption of each container with its requests and limits and flag the over-provisioned, under-provisioned, near-limit, " +
+							"and without requests containers (Optional)",
+					},
+					"window": {
+						Type: "string",
+						Description: "Report the average, maximum, and last consumption of the Pods over this window (e.g. 15m, 1h) instead of the current consumption (Optional). " +
+							"Requires the metrics history sampler of the server (metrics_history_interval configuration), the window is limited to its retention",
+					},
 					"cluster": {
 						Type:        "string",
 						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
@@ -166,7 +262,9 @@ func initPods() []api.ServerTool {
 				IdempotentHint:  ptr.To(true),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: podsTop},
+		}, Handler: podsTop, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "metrics.k8s.io", Resource: "pods"},
+		}},
 		{Tool: api.Tool{
 			Name:        "pods_exec",
 			Description: "Execute a command in a Kubernetes Pod in the current or provided namespace with the provided name and command",
@@ -206,7 +304,9 @@ func initPods() []api.ServerTool {
 				IdempotentHint:  ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: podsExec},
+		}, Handler: podsExec, Timeout: api.DefaultExecTimeout, Access: []api.ResourceAccess{
+			{Verb: "create", Resource: "pods", Subresource: "exec"},
+		}},
 		{Tool: api.Tool{
 			Name:        "pods_log",
 			Description: "Get the logs of a Kubernetes Pod in the current or provided namespace with the provided name",
@@ -233,7 +333,30 @@ func initPods() []api.ServerTool {
 					},
 					"previous": {
 						Type:        "boolean",
-						Description: "Return previous terminated container logs (Optional)",
+						Description: "Return previous terminated container logs, useful for crashed or restarted containers (Optional)",
+					},
+					"allContainers": {
+						Type:        "boolean",
+						Description: "Return the logs of every init and regular container of the Pod, each line prefixed with [pod/<name>/<container>] (Optional, container is ignored if true)",
+					},
+					"sinceSeconds": {
+						Type:        "integer",
+						Description: "Only return logs newer than the provided number of seconds (Optional, mutually exclusive with sinceTime)",
+						Minimum:     ptr.To(float64(1)),
+					},
+					"sinceTime": {
+						Type:        "string",
+						Description: "Only return logs after the provided RFC3339 timestamp, e.g. '2025-01-02T15:04:05Z' (Optional, mutually exclusive with sinceSeconds)",
+						Format:      "date-time",
+					},
+					"timestamps": {
+						Type:        "boolean",
+						Description: "Prefix each log line with its RFC3339 timestamp (Optional)",
+					},
+					api.SummarizeArgument: {
+						Type: "boolean",
+						Description: "Return a summary of the logs written by the LLM of the client (MCP sampling) instead of the raw logs (Optional). " +
+							"The logs are summarized in chunks, each summary reports its line offsets within the retrieved lines",
 					},
 					"cluster": {
 						Type:        "string",
@@ -249,7 +372,9 @@ func initPods() []api.ServerTool {
 				IdempotentHint:  ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: podsLog},
+		}, Handler: podsLog, Timeout: api.DefaultLogsTimeout, Access: []api.ResourceAccess{
+			{Verb: "get", Resource: "pods", Subresource: "log"},
+		}},
 		{Tool: api.Tool{
 			Name:        "pods_run",
 			Description: "Run a Kubernetes Pod in the current or provided namespace with the provided container image and optional name",
@@ -286,222 +411,301 @@ func initPods() []api.ServerTool {
 				IdempotentHint:  ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: podsRun},
+		}, Handler: podsRun, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "create", Resource: "pods"},
+		}},
 	}
 }
 
+type podsListArgs struct {
+	Namespace     string `json:"namespace"`
+	LabelSelector string `json:"labelSelector"`
+	api.ListArgs
+}
+
 func podsListInAllNamespaces(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	labelSelector := params.GetArguments()["labelSelector"]
-	resourceListOptions := kubernetes.ResourceListOptions{
-		AsTable: params.ListOutput.AsTable(),
+	args := podsListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in all namespaces, %w", err)), nil
 	}
-	if labelSelector != nil {
-		resourceListOptions.LabelSelector = labelSelector.(string)
+	resourceListOptions, err := args.ResourceListOptions(args.LabelSelector, params.ListOutput.AsTable())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in all namespaces, %w", err)), nil
 	}
 	ret, err := params.PodsListInAllNamespaces(params, resourceListOptions)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in all namespaces: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in all namespaces: %w", err)), nil
 	}
 	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
 }
 
 func podsListInNamespace(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	fmt.Printf("DEBUG: ===== PODS LIST IN NAMESPACE HANDLER CALLED =====\n")
-	ns := params.GetArguments()["namespace"]
-	if ns == nil {
-		return api.NewToolCallResult("", errors.New("failed to list pods in namespace, missing argument namespace")), nil
+	args := podsListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace, %w", err)), nil
 	}
-	resourceListOptions := kubernetes.ResourceListOptions{
-		AsTable: params.ListOutput.AsTable(),
-	}
-	labelSelector := params.GetArguments()["labelSelector"]
-	if labelSelector != nil {
-		resourceListOptions.LabelSelector = labelSelector.(string)
+	resourceListOptions, err := args.ResourceListOptions(args.LabelSelector, params.ListOutput.AsTable())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace, %w", err)), nil
 	}
 
-	args := params.GetArguments()
-	fmt.Printf("DEBUG: arguments = %+v\n", args)
-	fmt.Printf("DEBUG: IsACMMode = %v\n", params.IsACMMode)
-	fmt.Printf("DEBUG: ACMProxyClient = %v\n", params.ACMProxyClient)
-
 	// Check for cluster parameter and route through ACM proxy if needed
 	if cluster, shouldUse := api.ShouldUseACMProxy(params); shouldUse {
-		fmt.Printf("DEBUG: Using ACM proxy for cluster=%s in podsListInNamespace\n", cluster)
-		ret, err := params.PodsListInNamespaceThroughProxy(params.Context, cluster, ns.(string), resourceListOptions)
+		ret, err := params.PodsListInNamespaceThroughProxy(params.Context, cluster, args.Namespace, resourceListOptions)
 		if err != nil {
-			return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace %s via ACM proxy: %v", ns, err)), nil
+			return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace %s via ACM proxy: %w", args.Namespace, err)), nil
 		}
 		return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
 	}
 
-	fmt.Printf("DEBUG: Using direct Kubernetes client in podsListInNamespace\n")
-	ret, err := params.PodsListInNamespace(params.Context, ns.(string), resourceListOptions)
+	ret, err := params.PodsListInNamespace(params.Context, args.Namespace, resourceListOptions)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace %s: %v", ns, err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace %s: %w", args.Namespace, err)), nil
 	}
 	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
 }
 
+type podArgs struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
 func podsGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	ns := params.GetArguments()["namespace"]
-	if ns == nil {
-		ns = ""
+	args := podArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pod, %w", err)), nil
 	}
-	name := params.GetArguments()["name"]
-	if name == nil {
-		return api.NewToolCallResult("", errors.New("failed to get pod, missing argument name")), nil
-	}
-	ret, err := params.PodsGet(params, ns.(string), name.(string))
+	ret, err := params.PodsGet(params, args.Namespace, args.Name)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to get pod %s in namespace %s: %v", name, ns, err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pod %s in namespace %s: %w", args.Name, args.Namespace, err)), nil
 	}
 	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
 }
 
 func podsDelete(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	ns := params.GetArguments()["namespace"]
-	if ns == nil {
-		ns = ""
-	}
-	name := params.GetArguments()["name"]
-	if name == nil {
-		return api.NewToolCallResult("", errors.New("failed to delete pod, missing argument name")), nil
+	args := podArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to delete pod, %w", err)), nil
 	}
-	ret, err := params.PodsDelete(params, ns.(string), name.(string))
+	ret, err := params.PodsDelete(params, args.Namespace, args.Name)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to delete pod %s in namespace %s: %v", name, ns, err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to delete pod %s in namespace %s: %w", args.Name, args.Namespace, err)), nil
 	}
 	return api.NewToolCallResult(ret, err), nil
 }
 
-func podsTop(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	podsTopOptions := kubernetes.PodsTopOptions{AllNamespaces: true}
-	if v, ok := params.GetArguments()["namespace"].(string); ok {
-		podsTopOptions.Namespace = v
+type podsEvictArgs struct {
+	Namespace          string `json:"namespace"`
+	Name               string `json:"name"`
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds"`
+}
+
+func podsEvict(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := podsEvictArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to evict pod, %w", err)), nil
 	}
-	if v, ok := params.GetArguments()["all_namespaces"].(bool); ok {
-		podsTopOptions.AllNamespaces = v
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to evict pod, %w", err)), nil
 	}
-	if v, ok := params.GetArguments()["name"].(string); ok {
-		podsTopOptions.Name = v
+	if err := params.PodsEvict(params, args.Namespace, args.Name, args.GracePeriodSeconds); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to evict pod %s in namespace %s: %w", args.Name, args.Namespace, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Pod %s evicted successfully", args.Name), nil), nil
+}
+
+func podsWhyPending(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := podArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to analyze pod scheduling, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to analyze pod scheduling, %w", err)), nil
+	}
+	ret, err := params.PodsWhyPending(params, args.Namespace, args.Name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to analyze the scheduling of pod %s in namespace %s: %w", args.Name, args.Namespace, err)), nil
+	}
+	if ret.NodeName != "" {
+		return api.NewToolCallResult(fmt.Sprintf("Pod %s is already scheduled on node %s (phase %s)", ret.Name, ret.NodeName, ret.Phase), nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+type podsTopArgs struct {
+	AllNamespaces   bool   `json:"all_namespaces"`
+	Namespace       string `json:"namespace"`
+	Name            string `json:"name"`
+	LabelSelector   string `json:"label_selector"`
+	SortBy          string `json:"sort_by"`
+	CompareRequests bool   `json:"compare_requests"`
+	Window          string `json:"window"`
+}
+
+func podsTop(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := podsTopArgs{AllNamespaces: true}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pods top, %w", err)), nil
+	}
+	podsTopOptions := kubernetes.PodsTopOptions{
+		AllNamespaces: args.AllNamespaces,
+		Namespace:     args.Namespace,
+		Name:          args.Name,
+	}
+	podsTopOptions.LabelSelector = args.LabelSelector
+	if args.Window != "" {
+		ret, err := params.PodsTopHistory(params, podsTopOptions, args.Window, args.SortBy)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get pods top: %w", err)), nil
+		}
+		return api.NewToolCallResult(output.MarshalYaml(ret)), nil
 	}
-	if v, ok := params.GetArguments()["label_selector"].(string); ok {
-		podsTopOptions.LabelSelector = v
+	if args.CompareRequests {
+		ret, err := params.PodsTopProvisioning(params, podsTopOptions)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get pods top: %w", err)), nil
+		}
+		return api.NewToolCallResult(printProvisioning(ret, args.SortBy), nil), nil
 	}
 	ret, err := params.PodsTop(params, podsTopOptions)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to get pods top: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pods top: %w", err)), nil
 	}
 	buf := new(bytes.Buffer)
 	printer := metricsutil.NewTopCmdPrinter(buf, true)
-	err = printer.PrintPodMetrics(ret.Items, true, true, false, "", true)
+	err = printer.PrintPodMetrics(ret.Items, true, true, false, args.SortBy, true)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to get pods top: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pods top: %w", err)), nil
 	}
 	return api.NewToolCallResult(buf.String(), nil), nil
 }
 
+type podsExecArgs struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Container string   `json:"container"`
+	Command   []string `json:"command"`
+}
+
 func podsExec(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	ns := params.GetArguments()["namespace"]
-	if ns == nil {
-		ns = ""
-	}
-	name := params.GetArguments()["name"]
-	if name == nil {
-		return api.NewToolCallResult("", errors.New("failed to exec in pod, missing argument name")), nil
-	}
-	container := params.GetArguments()["container"]
-	if container == nil {
-		container = ""
-	}
-	commandArg := params.GetArguments()["command"]
-	command := make([]string, 0)
-	if _, ok := commandArg.([]interface{}); ok {
-		for _, cmd := range commandArg.([]interface{}) {
-			if _, ok := cmd.(string); ok {
-				command = append(command, cmd.(string))
-			}
-		}
-	} else {
-		return api.NewToolCallResult("", errors.New("failed to exec in pod, invalid command argument")), nil
+	args := podsExecArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to exec in pod, %w", err)), nil
 	}
-	ret, err := params.PodsExec(params, ns.(string), name.(string), container.(string), command)
+	ret, err := params.PodsExec(params, args.Namespace, args.Name, args.Container, args.Command)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to exec in pod %s in namespace %s: %v", name, ns, err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to exec in pod %s in namespace %s: %w", args.Name, args.Namespace, err)), nil
 	} else if ret == "" {
-		ret = fmt.Sprintf("The executed command in pod %s in namespace %s has not produced any output", name, ns)
+		ret = fmt.Sprintf("The executed command in pod %s in namespace %s has not produced any output", args.Name, args.Namespace)
 	}
 	return api.NewToolCallResult(ret, err), nil
 }
 
+type podsLogArgs struct {
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	Container     string `json:"container"`
+	AllContainers bool   `json:"allContainers"`
+	Previous      bool   `json:"previous"`
+	Tail          int64  `json:"tail"`
+	SinceSeconds  int64  `json:"sinceSeconds"`
+	SinceTime     string `json:"sinceTime"`
+	Timestamps    bool   `json:"timestamps"`
+	Summarize     bool   `json:"summarize"`
+}
+
 func podsLog(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	ns := params.GetArguments()["namespace"]
-	if ns == nil {
-		ns = ""
-	}
-	name := params.GetArguments()["name"]
-	if name == nil {
-		return api.NewToolCallResult("", errors.New("failed to get pod log, missing argument name")), nil
-	}
-	container := params.GetArguments()["container"]
-	if container == nil {
-		container = ""
-	}
-	previous := params.GetArguments()["previous"]
-	var previousBool bool
-	if previous != nil {
-		previousBool = previous.(bool)
-	}
-	// Extract tailLines parameter
-	tail := params.GetArguments()["tail"]
-	var tailInt int64
-	if tail != nil {
-		// Convert to int64 - safely handle both float64 (JSON number) and int types
-		switch v := tail.(type) {
-		case float64:
-			tailInt = int64(v)
-		case int:
-			tailInt = int64(v)
-		case int64:
-			tailInt = v
-		default:
-			return api.NewToolCallResult("", fmt.Errorf("failed to parse tail parameter: expected integer, got %T", tail)), nil
+	args := podsLogArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pod log, %w", err)), nil
+	}
+	podsLogOptions := kubernetes.PodsLogOptions{
+		Container:     args.Container,
+		AllContainers: args.AllContainers,
+		Previous:      args.Previous,
+		Tail:          args.Tail,
+		SinceSeconds:  args.SinceSeconds,
+		Timestamps:    args.Timestamps,
+	}
+	if args.SinceTime != "" {
+		if args.SinceSeconds > 0 {
+			return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+				errors.New("failed to get pod log, sinceSeconds and sinceTime are mutually exclusive"))), nil
+		}
+		sinceTime, err := time.Parse(time.RFC3339, args.SinceTime)
+		if err != nil {
+			return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+				fmt.Errorf("failed to get pod log, invalid sinceTime %q: %w", args.SinceTime, err))), nil
 		}
+		podsLogOptions.SinceTime = ptr.To(metav1.NewTime(sinceTime))
 	}
-
-	ret, err := params.PodsLog(params.Context, ns.(string), name.(string), container.(string), previousBool, tailInt)
+	ret, err := params.PodsLog(params.Context, args.Namespace, args.Name, podsLogOptions)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to get pod %s log in namespace %s: %v", name, ns, err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pod %s log in namespace %s: %w", args.Name, args.Namespace, err)), nil
 	} else if ret == "" {
-		ret = fmt.Sprintf("The pod %s in namespace %s has not logged any message yet", name, ns)
+		ret = fmt.Sprintf("The pod %s in namespace %s has not logged any message yet", args.Name, args.Namespace)
+	} else if args.Summarize {
+		lines := strings.Split(strings.TrimRight(ret, "\n"), "\n")
+		chunks, err := api.Summarize(params, "logs", lines)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to summarize pod %s log in namespace %s: %w", args.Name, args.Namespace, err)), nil
+		}
+		ret = api.SummaryContent(fmt.Sprintf("Summary of the last %d log lines of pod %s in namespace %s", len(lines), args.Name, args.Namespace), "Lines", chunks)
 	}
 	return api.NewToolCallResult(ret, err), nil
 }
 
+type podsRunArgs struct {
+	Namespace string  `json:"namespace"`
+	Name      string  `json:"name"`
+	Image     string  `json:"image"`
+	Port      float64 `json:"port"`
+}
+
 func podsRun(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	ns := params.GetArguments()["namespace"]
-	if ns == nil {
-		ns = ""
-	}
-	name := params.GetArguments()["name"]
-	if name == nil {
-		name = ""
-	}
-	image := params.GetArguments()["image"]
-	if image == nil {
-		return api.NewToolCallResult("", errors.New("failed to run pod, missing argument image")), nil
+	args := podsRunArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to run pod, %w", err)), nil
 	}
-	port := params.GetArguments()["port"]
-	if port == nil {
-		port = float64(0)
-	}
-	resources, err := params.PodsRun(params, ns.(string), name.(string), image.(string), int32(port.(float64)))
+	resources, err := params.PodsRun(params, args.Namespace, args.Name, args.Image, int32(args.Port))
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to run pod %s in namespace %s: %v", name, ns, err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to run pod %s in namespace %s: %w", args.Name, args.Namespace, err)), nil
 	}
 	marshalledYaml, err := output.MarshalYaml(resources)
 	if err != nil {
-		err = fmt.Errorf("failed to run pod: %v", err)
+		err = fmt.Errorf("failed to run pod: %w", err)
 	}
 	return api.NewToolCallResult("# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
 }
+
+const (
+	sortByCPU    = "cpu"
+	sortByMemory = "memory"
+)
+
+// printProvisioning prints the consumption of the containers compared to their requests and limits as a table
+func printProvisioning(containers []kubernetes.ContainerProvisioning, sortBy string) string {
+	switch sortBy {
+	case sortByCPU:
+		slices.SortStableFunc(containers, func(a, b kubernetes.ContainerProvisioning) int { return b.CPU.Usage.Cmp(a.CPU.Usage) })
+	case sortByMemory:
+		slices.SortStableFunc(containers, func(a, b kubernetes.ContainerProvisioning) int { return b.Memory.Usage.Cmp(a.Memory.Usage) })
+	}
+	buf := new(bytes.Buffer)
+	w := printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tCPU(cores)\tCPU REQUEST\tCPU LIMIT\tCPU STATUS\tMEMORY(bytes)\tMEMORY REQUEST\tMEMORY LIMIT\tMEMORY STATUS")
+	for _, c := range containers {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%dm\t%s\t%s\t%s\t%dMi\t%s\t%s\t%s\n", c.Namespace, c.Pod, c.Container,
+			c.CPU.Usage.MilliValue(), quantityOrNone(c.CPU.Request), quantityOrNone(c.CPU.Limit), c.CPU.Status,
+			c.Memory.Usage.Value()/(1024*1024), quantityOrNone(c.Memory.Request), quantityOrNone(c.Memory.Limit), c.Memory.Status)
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+func quantityOrNone(quantity *resource.Quantity) string {
+	if quantity == nil {
+		return "<none>"
+	}
+	return quantity.String()
+}
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+const (
+	encodingText   = "text"
+	encodingBase64 = "base64"
+)
+
+func initPodsCopy() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "pods_cp_from",
+			Description: "Read a file from a container of a Kubernetes Pod in the current or provided namespace, the same way kubectl cp does (tar over exec). " +
+				"The container image must provide the tar binary",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod to read the file from",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod to read the file from",
+					},
+					"container": {
+						Type:        "string",
+						Description: "Name of the Pod container to read the file from (Optional)",
+					},
+					"path": {
+						Type:        "string",
+						Description: "Absolute path of the file in the container",
+					},
+					"encoding": {
+						Type:        "string",
+						Description: "Encoding of the returned content, use base64 for binary files (Optional, default: text)",
+						Enum:        []any{encodingText, encodingBase64},
+					},
+					"maxBytes": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum size of the file, larger files are rejected (Optional, default: %d)", kubernetes.DefaultCopyFromMaxBytes),
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(kubernetes.MaxCopyFromBytes)),
+					},
+				},
+				Required: []string{"name", "path"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Copy From",
+				ReadOnlyHint:    ptr.To(false), // Executes tar in the container
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsCopyFrom, Timeout: api.DefaultExecTimeout, Access: []api.ResourceAccess{
+			{Verb: "create", Resource: "pods", Subresource: "exec"},
+		}},
+		{Tool: api.Tool{
+			Name: "pods_cp_to",
+			Description: "Write a small file into a container of a Kubernetes Pod in the current or provided namespace, the same way kubectl cp does (tar over exec). " +
+				"The container image must provide the tar binary, the parent directory must exist, and an existing file is overwritten",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod to write the file to",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod to write the file to",
+					},
+					"container": {
+						Type:        "string",
+						Description: "Name of the Pod container to write the file to (Optional)",
+					},
+					"path": {
+						Type:        "string",
+						Description: "Absolute path of the file in the container",
+					},
+					"content": {
+						Type:        "string",
+						Description: fmt.Sprintf("Content of the file, at most %d bytes once decoded", kubernetes.MaxCopyToBytes),
+					},
+					"encoding": {
+						Type:        "string",
+						Description: "Encoding of the provided content, use base64 for binary files (Optional, default: text)",
+						Enum:        []any{encodingText, encodingBase64},
+					},
+				},
+				Required: []string{"name", "path", "content"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Copy To",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true), // Overwrites the existing file
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsCopyTo, Timeout: api.DefaultExecTimeout, Access: []api.ResourceAccess{
+			{Verb: "create", Resource: "pods", Subresource: "exec"},
+		}},
+	}
+}
+
+type podsCopyFromArgs struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Container string `json:"container"`
+	Path      string `json:"path"`
+	Encoding  string `json:"encoding"`
+	MaxBytes  int64  `json:"maxBytes"`
+}
+
+func podsCopyFrom(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := podsCopyFromArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy from pod, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy from pod, %w", err)), nil
+	}
+	content, err := params.PodsCopyFrom(params, args.Namespace, args.Name, args.Container, args.Path, args.MaxBytes)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy from pod %s in namespace %s: %w", args.Name, args.Namespace, err)), nil
+	}
+	if args.Encoding == encodingBase64 {
+		return api.NewToolCallResult(base64.StdEncoding.EncodeToString(content), nil), nil
+	}
+	if !utf8.Valid(content) {
+		return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+			fmt.Errorf("failed to copy from pod %s in namespace %s: %s is not a text file, use the base64 encoding", args.Name, args.Namespace, args.Path))), nil
+	}
+	if len(content) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("The file %s in pod %s in namespace %s is empty", args.Path, args.Name, args.Namespace), nil), nil
+	}
+	return api.NewToolCallResult(string(content), nil), nil
+}
+
+type podsCopyToArgs struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Container string `json:"container"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+	Encoding  string `json:"encoding"`
+}
+
+func podsCopyTo(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := podsCopyToArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy to pod, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy to pod, %w", err)), nil
+	}
+	content := []byte(args.Content)
+	if args.Encoding == encodingBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(args.Content)
+		if err != nil {
+			return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+				fmt.Errorf("failed to copy to pod %s in namespace %s, invalid base64 content: %w", args.Name, args.Namespace, err))), nil
+		}
+		content = decoded
+	}
+	if err := params.PodsCopyTo(params, args.Namespace, args.Name, args.Container, args.Path, content); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to copy to pod %s in namespace %s: %w", args.Name, args.Namespace, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("%d bytes written to %s in pod %s in namespace %s", len(content), args.Path, args.Name, args.Namespace), nil), nil
+}
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initPortForward() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "pods_port_forward",
+			Description: "Forward a local port of the MCP server to a port of a Kubernetes Pod, or of a Service, in the current or provided namespace. " +
+				"The forward is closed once the provided duration elapses, use pods_port_forward_close to close it earlier. " +
+				"Returns the ID of the forward and the local address it is bound to",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod or Service",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod to forward the port of (mutually exclusive with service)",
+					},
+					"service": {
+						Type:        "string",
+						Description: "Name of the Service to forward the port of, the port is forwarded to one of its running Pods (mutually exclusive with name)",
+					},
+					"port": {
+						Type:        "integer",
+						Description: "Port of the Pod, or of the Service, to forward",
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(65535)),
+					},
+					"localPort": {
+						Type:        "integer",
+						Description: "Local port to listen on (Optional, a random free port is used if not provided)",
+						Minimum:     ptr.To(float64(0)),
+						Maximum:     ptr.To(float64(65535)),
+					},
+					"address": {
+						Type:        "string",
+						Description: "Local address to listen on (Optional, default: " + kubernetes.DefaultPortForwardAddress + ")",
+					},
+					"duration": {
+						Type: "string",
+						Description: fmt.Sprintf("Duration after which the forward is closed, e.g. '30s', '10m' (Optional, default: %s, maximum: %s)",
+							kubernetes.DefaultPortForwardDuration, kubernetes.MaxPortForwardDuration),
+					},
+				},
+				Required: []string{"port"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Port Forward",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsPortForward, Access: []api.ResourceAccess{
+			{Verb: "create", Resource: "pods", Subresource: "portforward"},
+		}},
+		{Tool: api.Tool{
+			Name:        "pods_port_forward_list",
+			Description: "List the active port forwards opened with pods_port_forward, including their local address and expiration",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Port Forward List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: podsPortForwardList},
+		{Tool: api.Tool{
+			Name:        "pods_port_forward_close",
+			Description: "Close an active port forward opened with pods_port_forward",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {
+						Type:        "string",
+						Description: "ID of the port forward to close",
+					},
+				},
+				Required: []string{"id"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Port Forward Close",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: podsPortForwardClose},
+	}
+}
+
+type podsPortForwardArgs struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Service   string `json:"service"`
+	Port      int32  `json:"port"`
+	LocalPort int32  `json:"localPort"`
+	Address   string `json:"address"`
+	Duration  string `json:"duration"`
+}
+
+func podsPortForward(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := podsPortForwardArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to forward port, %w", err)), nil
+	}
+	options := kubernetes.PortForwardOptions{
+		Namespace: args.Namespace,
+		Pod:       args.Name,
+		Service:   args.Service,
+		Port:      args.Port,
+		LocalPort: args.LocalPort,
+		Address:   args.Address,
+	}
+	if args.Duration != "" {
+		duration, err := time.ParseDuration(args.Duration)
+		if err != nil {
+			return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+				fmt.Errorf("failed to forward port, invalid duration %q: %w", args.Duration, err))), nil
+		}
+		options.Duration = duration
+	}
+	forward, err := params.PortForwardOpen(params, options)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to forward port %d: %w", args.Port, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(forward)
+	if err != nil {
+		err = fmt.Errorf("failed to forward port: %w", err)
+	}
+	return api.NewToolCallResult("# The following port forward has been opened successfully\n"+marshalledYaml, err), nil
+}
+
+func podsPortForwardList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	forwards := params.PortForwardList()
+	if len(forwards) == 0 {
+		return api.NewToolCallResult("No active port forwards found", nil), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(forwards)
+	if err != nil {
+		err = fmt.Errorf("failed to list port forwards: %w", err)
+	}
+	return api.NewToolCallResult(marshalledYaml, err), nil
+}
+
+type podsPortForwardCloseArgs struct {
+	ID string `json:"id"`
+}
+
+func podsPortForwardClose(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := podsPortForwardCloseArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to close port forward, %w", err)), nil
+	}
+	forward, err := params.PortForwardClose(args.ID)
+	if err != nil {
+		return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeNotFound, false,
+			fmt.Errorf("failed to close port forward: %w", err))), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Port forward %s from %s to %s has been closed", forward.ID, forward.Address, forward.Target), nil), nil
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initQuotas() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "quotas_overview",
+			Description: "Get an overview of the Kubernetes ResourceQuotas and LimitRanges of a namespace: the quota usage compared to the hard limits, " +
+				"the LimitRange defaults, and the workload containers without CPU/memory requests or limits (reporting the ones that will be rejected by the quotas). " +
+				"Useful to answer capacity questions such as why pods are not created or how much room is left in a namespace",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to get the overview from, all the namespaces with a ResourceQuota or a LimitRange if not provided",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Quotas: Overview",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: quotasOverview, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "resourcequotas", AllNamespaces: true},
+			{Verb: "list", Resource: "limitranges", AllNamespaces: true},
+		}},
+	}
+}
+
+type quotasOverviewArgs struct {
+	Namespace string `json:"namespace"`
+}
+
+func quotasOverview(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := quotasOverviewArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get quotas overview, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get quotas overview, %w", err)), nil
+	}
+	ret, err := params.QuotasOverview(params, args.Namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get quotas overview: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No ResourceQuotas or LimitRanges found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
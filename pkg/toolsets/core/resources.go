@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 
@@ -26,7 +28,7 @@ func initResources(o internalk8s.Openshift) []api.ServerTool {
 			Description: "List Kubernetes resources and objects in the current cluster or managed cluster by providing their apiVersion and kind and optionally the namespace, cluster, and label selector\n" + commonApiVersion,
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
+				Properties: api.WithListProperties(map[string]*jsonschema.Schema{
 					"apiVersion": {
 						Type:        "string",
 						Description: "apiVersion of the resources (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
@@ -44,11 +46,19 @@ func initResources(o internalk8s.Openshift) []api.ServerTool {
 						Description: "Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label",
 						Pattern:     "([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]",
 					},
+					"jsonPath": {
+						Type:        "string",
+						Description: "Optional JSONPath expression to return only the selected fields of the list (same as kubectl -o jsonpath), e.g. '{.items[*].metadata.name}'. Braces and the leading dot can be omitted",
+					},
+					"goTemplate": {
+						Type:        "string",
+						Description: "Optional Go template to return only the selected fields of the list (same as kubectl -o go-template), e.g. '{{range .items}}{{.metadata.name}} {{end}}'",
+					},
 					"cluster": {
 						Type:        "string",
 						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
 					},
-				},
+				}),
 				Required: []string{"apiVersion", "kind"},
 			},
 			Annotations: api.ToolAnnotations{
@@ -81,6 +91,14 @@ func initResources(o internalk8s.Openshift) []api.ServerTool {
 						Type:        "string",
 						Description: "Name of the resource",
 					},
+					"jsonPath": {
+						Type:        "string",
+						Description: "Optional JSONPath expression to return only the selected fields of the resource (same as kubectl -o jsonpath), e.g. '{.spec.containers[*].image}'. Braces and the leading dot can be omitted",
+					},
+					"goTemplate": {
+						Type:        "string",
+						Description: "Optional Go template to return only the selected fields of the resource (same as kubectl -o go-template), e.g. '{{.spec.replicas}}'",
+					},
 					"cluster": {
 						Type:        "string",
 						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
@@ -97,14 +115,61 @@ func initResources(o internalk8s.Openshift) []api.ServerTool {
 			},
 		}, Handler: resourcesGet},
 		{Tool: api.Tool{
-			Name:        "resources_create_or_update",
-			Description: "Create or update a Kubernetes resource in the current cluster by providing a YAML or JSON representation of the resource\n" + commonApiVersion,
+			Name: "resources_describe",
+			Description: "Describe a Kubernetes resource in the current cluster or managed cluster by providing its apiVersion, kind, optionally the namespace and cluster, and its name. " +
+				"Mirrors kubectl describe with a structured (YAML) response combining the resource spec and status, its owner chain (e.g. Pod -> ReplicaSet -> Deployment), " +
+				"its related events, and for Pods the state, restarts, and probes of each container\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to retrieve the namespaced resource from (ignored in case of cluster scoped resources). If not provided, will get resource from configured namespace",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the resource",
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Describe",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesDescribe},
+		{Tool: api.Tool{
+			Name: "resources_create_or_update",
+			Description: "Create or update Kubernetes resources in the current cluster by providing a YAML or JSON representation of the resources " +
+				"(multiple YAML documents and JSON arrays are supported). " +
+				"Resources are server-side applied, fields managed by a different field manager are reported as conflicts unless forceConflicts is set. " +
+				"Each resource is applied independently and its status (created, updated, unchanged, or error) is reported\n" + commonApiVersion,
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"resource": {
 						Type:        "string",
-						Description: "A JSON or YAML containing a representation of the Kubernetes resource. Should include top-level fields such as apiVersion,kind,metadata, and spec",
+						Description: "A JSON or YAML containing a representation of the Kubernetes resources (multiple YAML documents or a JSON array). Should include top-level fields such as apiVersion,kind,metadata, and spec",
+					},
+					"fieldManager": {
+						Type:        "string",
+						Description: "Optional name of the field manager owning the applied fields (defaults to kubernetes-mcp-server)",
+					},
+					"forceConflicts": {
+						Type:        "boolean",
+						Description: "Optional, take the ownership of the fields in conflict with other field managers and overwrite their values (defaults to false)",
+						Default:     api.ToRawMessage(false),
 					},
 					"cluster": {
 						Type:        "string",
@@ -120,7 +185,39 @@ func initResources(o internalk8s.Openshift) []api.ServerTool {
 				IdempotentHint:  ptr.To(true),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: resourcesCreateOrUpdate},
+		}, Handler: resourcesCreateOrUpdate, DryRun: true},
+		{Tool: api.Tool{
+			Name: "resources_diff",
+			Description: "Compare a YAML or JSON representation of one or more Kubernetes resources against their live state in the current cluster, without persisting any change. " +
+				"Returns, for each resource, the operation an apply would perform (create, update, or unchanged) with the list of changed fields, " +
+				"and a unified diff of the live and the resulting resources (managedFields ignored), the same as kubectl diff --server-side\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"resource": {
+						Type:        "string",
+						Description: "A JSON or YAML containing a representation of the Kubernetes resources (multiple YAML documents separated by --- are supported)",
+					},
+					"fieldManager": {
+						Type:        "string",
+						Description: "Optional name of the field manager to compute the diff for (defaults to kubernetes-mcp-server)",
+					},
+					"forceConflicts": {
+						Type:        "boolean",
+						Description: "Optional, compute the diff as if the ownership of the fields in conflict with other field managers was taken (defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+				Required: []string{"resource"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Diff",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesDiff},
 		{Tool: api.Tool{
 			Name:        "resources_delete",
 			Description: "Delete a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, and its name\n" + commonApiVersion,
@@ -157,146 +254,506 @@ func initResources(o internalk8s.Openshift) []api.ServerTool {
 				IdempotentHint:  ptr.To(true),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: resourcesDelete},
+		}, Handler: resourcesDelete, DryRun: true},
+		{Tool: api.Tool{
+			Name: "resources_patch",
+			Description: "Patch a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, its name, and the patch. " +
+				"Supports strategic merge patches (default for built-in kinds), JSON merge patches (default for custom resources), and JSON patches. " +
+				"Returns a diff of the resource before and after the patch along with the patched resource\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the namespaced resource (ignored in case of cluster scoped resources). If not provided, will patch the resource in the configured namespace",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the resource",
+					},
+					"patchType": {
+						Type: "string",
+						Description: "Optional type of the patch: strategic (strategic merge patch, only for built-in kinds), merge (JSON merge patch, RFC 7386), or json (JSON patch, RFC 6902). " +
+							"If not provided, strategic is used for built-in kinds and merge for custom resources",
+						Enum: []any{internalk8s.PatchTypeStrategic, internalk8s.PatchTypeMerge, internalk8s.PatchTypeJSON},
+					},
+					"patch": {
+						Type: "string",
+						Description: "The patch as JSON or YAML (e.g. {\"spec\":{\"replicas\":2}} for strategic and merge patches, " +
+							"[{\"op\":\"replace\",\"path\":\"/spec/replicas\",\"value\":2}] for JSON patches)",
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name", "patch"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Patch",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesPatch, DryRun: true},
+		{Tool: api.Tool{
+			Name: "resources_scale",
+			Description: "Scale a Kubernetes resource in the current cluster by updating its scale subresource, works for any scalable resource " +
+				"(e.g. Deployment, ReplicaSet, StatefulSet, or custom resources with the scale subresource enabled). " +
+				"Returns the previous and the resulting desired replicas along with the current replicas\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: apps/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: Deployment, ReplicaSet, StatefulSet)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the resource. If not provided, will scale the resource in the configured namespace",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the resource",
+					},
+					"replicas": {
+						Type:        "integer",
+						Description: "Desired number of replicas",
+						Minimum:     ptr.To(float64(0)),
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name", "replicas"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Scale",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true), // Scaling down removes replicas
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesScale, DryRun: true},
+		resourcesMetadataTool(internalk8s.MetadataFieldLabels, commonApiVersion),
+		resourcesMetadataTool(internalk8s.MetadataFieldAnnotations, commonApiVersion),
 	}
 }
 
-func resourcesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	namespace := params.GetArguments()["namespace"]
-	if namespace == nil {
-		namespace = ""
-	}
-	labelSelector := params.GetArguments()["labelSelector"]
-	resourceListOptions := internalk8s.ResourceListOptions{
-		AsTable: params.ListOutput.AsTable(),
+// resourcesMetadataTool returns the resources_label or resources_annotate tool for the labels or annotations field
+func resourcesMetadataTool(field, commonApiVersion string) api.ServerTool {
+	name, title, handler := "resources_label", "Resources: Label", resourcesLabel
+	if field == internalk8s.MetadataFieldAnnotations {
+		name, title, handler = "resources_annotate", "Resources: Annotate", resourcesAnnotate
 	}
+	return api.ServerTool{Tool: api.Tool{
+		Name: name,
+		Description: fmt.Sprintf("Add, update, or remove %[1]s of any Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, and its name. "+
+			"Only the %[1]s that differ from the live resource are patched, so the operation can be safely repeated. "+
+			"Returns the added, updated, and removed %[1]s along with the resulting %[1]s of the resource\n", field) + commonApiVersion,
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"apiVersion": {
+					Type:        "string",
+					Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+				},
+				"kind": {
+					Type:        "string",
+					Description: "kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+				},
+				"namespace": {
+					Type:        "string",
+					Description: "Optional Namespace of the namespaced resource (ignored in case of cluster scoped resources). If not provided, will update the resource in the configured namespace",
+				},
+				"name": {
+					Type:        "string",
+					Description: "Name of the resource",
+				},
+				field: {
+					Type:                 "object",
+					Description:          fmt.Sprintf("Optional %s to add or update as key-value pairs (e.g. {\"app.kubernetes.io/part-of\": \"shop\"})", field),
+					AdditionalProperties: &jsonschema.Schema{Type: "string"},
+				},
+				"remove": {
+					Type:        "array",
+					Description: fmt.Sprintf("Optional keys of the %s to remove, keys not set in the resource are ignored", field),
+					Items:       &jsonschema.Schema{Type: "string"},
+				},
+				"overwrite": {
+					Type:        "boolean",
+					Description: fmt.Sprintf("Optional, allow updating %s already set with a different value, the update fails otherwise (defaults to false)", field),
+					Default:     api.ToRawMessage(false),
+				},
+			},
+			Required: []string{"apiVersion", "kind", "name"},
+		},
+		Annotations: api.ToolAnnotations{
+			Title:           title,
+			ReadOnlyHint:    ptr.To(false),
+			DestructiveHint: ptr.To(false),
+			IdempotentHint:  ptr.To(true),
+			OpenWorldHint:   ptr.To(true),
+		},
+	}, Handler: handler, DryRun: true}
+}
 
-	if labelSelector != nil {
-		l, ok := labelSelector.(string)
-		if !ok {
-			return api.NewToolCallResult("", fmt.Errorf("labelSelector is not a string")), nil
-		}
-		resourceListOptions.LabelSelector = l
+type resourceArgs struct {
+	APIVersion    string `json:"apiVersion"`
+	Kind          string `json:"kind"`
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	LabelSelector string `json:"labelSelector"`
+	JSONPath      string `json:"jsonPath"`
+	GoTemplate    string `json:"goTemplate"`
+	api.ListArgs
+}
+
+func resourcesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := resourceArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list resources, %w", err)), nil
 	}
-	gvk, err := parseGroupVersionKind(params.GetArguments())
+	// The projections apply to the objects, not to their table representation
+	projected := args.JSONPath != "" || args.GoTemplate != ""
+	resourceListOptions, err := args.ResourceListOptions(args.LabelSelector, params.ListOutput.AsTable() && !projected)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to list resources, %s", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to list resources, %w", err)), nil
 	}
-
-	ns, ok := namespace.(string)
-	if !ok {
-		return api.NewToolCallResult("", fmt.Errorf("namespace is not a string")), nil
+	gvk, err := parseGroupVersionKind(args.APIVersion, args.Kind)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list resources, %w", err)), nil
 	}
 
-	ret, err := params.ResourcesList(params, gvk, ns, resourceListOptions)
+	ret, err := params.ResourcesList(params, gvk, args.Namespace, resourceListOptions)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to list resources: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to list resources: %w", err)), nil
+	}
+	if projected {
+		projection, err := output.Project(ret, args.JSONPath, args.GoTemplate)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list resources, %w", err)), nil
+		}
+		return api.NewToolCallResult(projection, nil), nil
 	}
 	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
 }
 
 func resourcesGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	namespace := params.GetArguments()["namespace"]
-	if namespace == nil {
-		namespace = ""
+	args := resourceArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resource, %w", err)), nil
 	}
-	gvk, err := parseGroupVersionKind(params.GetArguments())
+	gvk, err := parseGroupVersionKind(args.APIVersion, args.Kind)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to get resource, %s", err)), nil
-	}
-	name := params.GetArguments()["name"]
-	if name == nil {
-		return api.NewToolCallResult("", errors.New("failed to get resource, missing argument name")), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resource, %w", err)), nil
 	}
 
-	ns, ok := namespace.(string)
-	if !ok {
-		return api.NewToolCallResult("", fmt.Errorf("namespace is not a string")), nil
+	ret, err := params.ResourcesGet(params, gvk, args.Namespace, args.Name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resource: %w", err)), nil
 	}
+	if args.JSONPath != "" || args.GoTemplate != "" {
+		projection, err := output.Project(ret, args.JSONPath, args.GoTemplate)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get resource, %w", err)), nil
+		}
+		return api.NewToolCallResult(projection, nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
 
-	n, ok := name.(string)
-	if !ok {
-		return api.NewToolCallResult("", fmt.Errorf("name is not a string")), nil
+func resourcesDescribe(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := resourceArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe resource, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe resource, %w", err)), nil
+	}
+	gvk, err := parseGroupVersionKind(args.APIVersion, args.Kind)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe resource, %w", err)), nil
 	}
 
-	ret, err := params.ResourcesGet(params, gvk, ns, n)
+	ret, err := params.ResourcesDescribe(params, gvk, args.Namespace, args.Name)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to get resource: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe resource: %w", err)), nil
 	}
 	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
 }
 
+type resourcesCreateOrUpdateArgs struct {
+	Resource       string `json:"resource"`
+	FieldManager   string `json:"fieldManager"`
+	ForceConflicts bool   `json:"forceConflicts"`
+}
+
 func resourcesCreateOrUpdate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	resource := params.GetArguments()["resource"]
-	if resource == nil || resource == "" {
-		return api.NewToolCallResult("", errors.New("failed to create or update resources, missing argument resource")), nil
+	args := resourcesCreateOrUpdateArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update resources, %w", err)), nil
 	}
 
-	r, ok := resource.(string)
-	if !ok {
-		return api.NewToolCallResult("", fmt.Errorf("resource is not a string")), nil
+	results, err := params.ResourcesCreateOrUpdate(params, args.Resource, internalk8s.ApplyOptions{FieldManager: args.FieldManager, Force: args.ForceConflicts})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update resources: %w", err)), nil
+	}
+	var resources []*unstructured.Unstructured
+	var failed []internalk8s.ApplyResult
+	var failures []error
+	for _, result := range results {
+		if result.Err != nil && result.Kind == "" {
+			failed = append(failed, result)
+			failures = append(failures, result.Err)
+		} else if result.Err != nil {
+			failed = append(failed, result)
+			failures = append(failures, fmt.Errorf("%s %s: %w", result.Kind, result.Name, result.Err))
+		} else if result.Object != nil {
+			resources = append(resources, result.Object)
+		}
 	}
+	if len(failed) == 1 && len(resources) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update resources: %w", failed[0].Err)), nil
+	} else if len(failed) > 0 && len(resources) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update resources: %w", errors.Join(failures...))), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(resources)
+	if err != nil {
+		err = fmt.Errorf("failed to create or update resources:: %w", err)
+	}
+	ret := "# The following resources (YAML) have been created or updated successfully\n" + marshalledYaml
+	if len(failed) > 0 && err == nil {
+		var failedYaml string
+		failedYaml, err = output.MarshalYaml(failed)
+		ret += "# The following resources (YAML) could not be created or updated\n" + failedYaml
+	}
+	return api.NewToolCallResult(ret+applyStatusSummary(results), err), nil
+}
+
+// applyStatusSummary returns the status of each of the applied objects as YAML comments
+func applyStatusSummary(results []internalk8s.ApplyResult) string {
+	var summary strings.Builder
+	summary.WriteString("# Status of each resource\n")
+	for _, result := range results {
+		name := strings.Trim(strings.Join([]string{result.Namespace, result.Name}, "/"), "/")
+		line := strings.TrimSpace(fmt.Sprintf("%s %s", result.Kind, name))
+		if result.Error != "" {
+			line = strings.TrimSpace(line + " " + strings.ReplaceAll(result.Error, "\n", " "))
+		}
+		summary.WriteString(fmt.Sprintf("# - %s: %s\n", result.Status, line))
+	}
+	return summary.String()
+}
 
-	resources, err := params.ResourcesCreateOrUpdate(params, r)
+func resourcesDiff(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := resourcesCreateOrUpdateArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff resources, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff resources, %w", err)), nil
+	}
+
+	diffs, err := params.ResourcesDiff(params, args.Resource, internalk8s.ApplyOptions{FieldManager: args.FieldManager, Force: args.ForceConflicts})
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to create or update resources: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff resources: %w", err)), nil
 	}
-	marshalledYaml, err := output.MarshalYaml(resources)
+	ret, err := diffsOutput(diffs)
 	if err != nil {
-		err = fmt.Errorf("failed to create or update resources:: %v", err)
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff resources: %w", err)), nil
 	}
-	return api.NewToolCallResult("# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+// diffsOutput returns the summary of the changes of the diffs followed by their unified diff against the live objects
+func diffsOutput(diffs []internalk8s.ResourceDiff) (string, error) {
+	summary, err := output.MarshalYaml(diffs)
+	if err != nil {
+		return "", err
+	}
+	ret := "# The following changes (YAML) would be applied to the resources\n" + summary
+	var unified strings.Builder
+	for _, diff := range diffs {
+		if diff.Merged == nil || diff.Operation == internalk8s.DiffOperationUnchanged {
+			continue
+		}
+		name := strings.Trim(strings.Join([]string{diff.Kind, diff.Namespace, diff.Name}, "/"), "/")
+		var live any
+		if diff.Live != nil {
+			live = diff.Live
+		}
+		fileDiff, err := output.DiffYaml("live/"+name, live, "merged/"+name, diff.Merged)
+		if err != nil {
+			return "", err
+		}
+		unified.WriteString(fileDiff)
+	}
+	if unified.Len() > 0 {
+		ret += "# Unified diff against the live cluster state\n" + unified.String()
+	}
+	return ret, nil
 }
 
 func resourcesDelete(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	namespace := params.GetArguments()["namespace"]
-	if namespace == nil {
-		namespace = ""
+	args := resourceArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to delete resource, %w", err)), nil
+	}
+	gvk, err := parseGroupVersionKind(args.APIVersion, args.Kind)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to delete resource, %w", err)), nil
+	}
+
+	err = params.ResourcesDelete(params, gvk, args.Namespace, args.Name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to delete resource: %w", err)), nil
+	}
+	return api.NewToolCallResult("Resource deleted successfully", err), nil
+}
+
+type resourcesPatchArgs struct {
+	resourceArgs
+	PatchType string `json:"patchType"`
+	Patch     string `json:"patch"`
+}
+
+func resourcesPatch(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := resourcesPatchArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to patch resource, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to patch resource, %w", err)), nil
+	}
+	gvk, err := parseGroupVersionKind(args.APIVersion, args.Kind)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to patch resource, %w", err)), nil
+	}
+
+	before, after, err := params.ResourcesPatch(params, gvk, args.Namespace, args.Name, args.PatchType, args.Patch)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to patch resource: %w", err)), nil
+	}
+	diff, err := output.DiffYaml("before", before, "after", after)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to patch resource: %w", err)), nil
+	}
+	if diff == "" {
+		diff = "# No changes\n"
 	}
-	gvk, err := parseGroupVersionKind(params.GetArguments())
+	marshalledYaml, err := output.MarshalYaml(after)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to delete resource, %s", err)), nil
+		err = fmt.Errorf("failed to patch resource: %w", err)
 	}
-	name := params.GetArguments()["name"]
-	if name == nil {
-		return api.NewToolCallResult("", errors.New("failed to delete resource, missing argument name")), nil
+	return api.NewToolCallResult("# The following changes (diff) have been applied to the resource\n"+diff+
+		"# The following resource (YAML) has been patched successfully\n"+marshalledYaml, err), nil
+}
+
+type resourcesScaleArgs struct {
+	resourceArgs
+	Replicas int32 `json:"replicas"`
+}
+
+func resourcesScale(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := resourcesScaleArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to scale resource, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to scale resource, %w", err)), nil
+	}
+	if args.Replicas < 0 {
+		return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+			errors.New("failed to scale resource, invalid argument replicas, must be greater than or equal to 0"))), nil
+	}
+	gvk, err := parseGroupVersionKind(args.APIVersion, args.Kind)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to scale resource, %w", err)), nil
 	}
 
-	ns, ok := namespace.(string)
-	if !ok {
-		return api.NewToolCallResult("", fmt.Errorf("namespace is not a string")), nil
+	ret, err := params.ResourcesScale(params, gvk, args.Namespace, args.Name, args.Replicas)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to scale resource: %w", err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to scale resource: %w", err)
 	}
+	return api.NewToolCallResult("# The following resource (YAML) has been scaled successfully\n"+marshalledYaml, err), nil
+}
+
+type resourcesMetadataArgs struct {
+	resourceArgs
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	Remove      []string          `json:"remove"`
+	Overwrite   bool              `json:"overwrite"`
+}
 
-	n, ok := name.(string)
-	if !ok {
-		return api.NewToolCallResult("", fmt.Errorf("name is not a string")), nil
+func resourcesLabel(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := resourcesMetadataArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to label resource, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to label resource, %w", err)), nil
+	}
+	gvk, err := parseGroupVersionKind(args.APIVersion, args.Kind)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to label resource, %w", err)), nil
 	}
 
-	err = params.ResourcesDelete(params, gvk, ns, n)
+	ret, err := params.ResourcesLabel(params, gvk, args.Namespace, args.Name,
+		internalk8s.MetadataOptions{Set: args.Labels, Remove: args.Remove, Overwrite: args.Overwrite})
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to delete resource: %v", err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to label resource: %w", err)), nil
 	}
-	return api.NewToolCallResult("Resource deleted successfully", err), nil
+	return resourcesMetadataResult(ret, internalk8s.MetadataFieldLabels)
 }
 
-func parseGroupVersionKind(arguments map[string]interface{}) (*schema.GroupVersionKind, error) {
-	apiVersion := arguments["apiVersion"]
-	if apiVersion == nil {
-		return nil, errors.New("missing argument apiVersion")
+func resourcesAnnotate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := resourcesMetadataArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to annotate resource, %w", err)), nil
 	}
-	kind := arguments["kind"]
-	if kind == nil {
-		return nil, errors.New("missing argument kind")
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to annotate resource, %w", err)), nil
+	}
+	gvk, err := parseGroupVersionKind(args.APIVersion, args.Kind)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to annotate resource, %w", err)), nil
 	}
 
-	a, ok := apiVersion.(string)
-	if !ok {
-		return nil, fmt.Errorf("name is not a string")
+	ret, err := params.ResourcesAnnotate(params, gvk, args.Namespace, args.Name,
+		internalk8s.MetadataOptions{Set: args.Annotations, Remove: args.Remove, Overwrite: args.Overwrite})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to annotate resource: %w", err)), nil
 	}
+	return resourcesMetadataResult(ret, internalk8s.MetadataFieldAnnotations)
+}
+
+func resourcesMetadataResult(update *internalk8s.MetadataUpdate, field string) (*api.ToolCallResult, error) {
+	marshalledYaml, err := output.MarshalYaml(update)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to update resource %s: %w", field, err)), nil
+	}
+	if !update.Changed() {
+		return api.NewToolCallResult(fmt.Sprintf("# The resource already has the requested %s, no changes were made\n", field)+marshalledYaml, nil), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following resource %s (YAML) have been updated successfully\n", field)+marshalledYaml, nil), nil
+}
 
-	gv, err := schema.ParseGroupVersion(a)
+func parseGroupVersionKind(apiVersion, kind string) (*schema.GroupVersionKind, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
 	if err != nil {
-		return nil, errors.New("invalid argument apiVersion")
+		return nil, api.NewToolError(api.ErrorCodeValidationFailed, false, errors.New("invalid argument apiVersion"))
 	}
-	return &schema.GroupVersionKind{Group: gv.Group, Version: gv.Version, Kind: kind.(string)}, nil
+	return &schema.GroupVersionKind{Group: gv.Group, Version: gv.Version, Kind: kind}, nil
 }
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initRestarts() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "workloads_restarts",
+			Description: "Report the restart and OOMKill hotspots of the cluster (or of several managed clusters in ACM mode) by scanning the container statuses of the Pods. " +
+				"The containers with at least minRestarts restarts or last terminated by an OOM kill are aggregated per workload (Deployment, StatefulSet, DaemonSet, Job, or standalone Pod), " +
+				"with their number of pods, restarts, and OOM killed pods, their memory request and limit, their most recent termination (pod, reason, exit code, and timestamps), " +
+				"and the reasons they are currently waiting (e.g. CrashLoopBackOff). The OOM killed containers come first, then the ones with the most restarts",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to scan, all namespaces if not provided",
+					},
+					"minRestarts": {
+						Type:        "integer",
+						Description: "Optional minimum number of restarts (summed across the pods of the workload) to report a container, OOM killed containers are always reported (defaults to 5)",
+						Minimum:     ptr.To(float64(0)),
+						Default:     api.ToRawMessage(5),
+					},
+					"clusters": {
+						Type:        "array",
+						Description: "Optional managed cluster names to scan in ACM mode, the report merges the hotspots of all the clusters (takes precedence over cluster)",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"cluster": {
+						Type:        "string",
+						Description: "Optional managed cluster name for multi-cluster operations via ACM proxy",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workloads: Restarts",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadsRestarts, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+		}},
+	}
+}
+
+type workloadsRestartsArgs struct {
+	Namespace   string   `json:"namespace"`
+	MinRestarts int32    `json:"minRestarts"`
+	Clusters    []string `json:"clusters"`
+}
+
+func workloadsRestarts(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := workloadsRestartsArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to report workloads restarts, %w", err)), nil
+	}
+	var restarts []internalk8s.ContainerRestarts
+	err := forEachCluster(params, args.Clusters, "report workloads restarts", func(params api.ToolHandlerParams, cluster string) error {
+		clusterRestarts, err := internalk8s.WorkloadsRestarts(params, params.ResourcesList, args.Namespace, args.MinRestarts)
+		restarts = append(restarts, restartsInCluster(clusterRestarts, cluster)...)
+		return err
+	})
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	internalk8s.SortRestarts(restarts)
+	return workloadsRestartsResult(restarts)
+}
+
+func restartsInCluster(restarts []internalk8s.ContainerRestarts, cluster string) []internalk8s.ContainerRestarts {
+	for i := range restarts {
+		restarts[i].Cluster = cluster
+	}
+	return restarts
+}
+
+func workloadsRestartsResult(restarts []internalk8s.ContainerRestarts) (*api.ToolCallResult, error) {
+	if len(restarts) == 0 {
+		return api.NewToolCallResult("No restart or OOMKill hotspots found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(restarts)), nil
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func rolloutSchema(properties map[string]*jsonschema.Schema) *jsonschema.Schema {
+	kinds := make([]any, 0, len(kubernetes.RolloutKinds))
+	for _, kind := range kubernetes.RolloutKinds {
+		kinds = append(kinds, kind)
+	}
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"kind": {
+				Type:        "string",
+				Description: "Kind of the workload",
+				Enum:        kinds,
+			},
+			"namespace": {
+				Type:        "string",
+				Description: "Namespace of the workload (Optional, current namespace if not provided)",
+			},
+			"name": {
+				Type:        "string",
+				Description: "Name of the workload",
+			},
+		},
+		Required: []string{"kind", "name"},
+	}
+	for name, property := range properties {
+		schema.Properties[name] = property
+	}
+	return schema
+}
+
+func initRollout() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "rollout_status",
+			Description: "Get the status of the latest rollout of a Deployment, StatefulSet, or DaemonSet (same message as kubectl rollout status) " +
+				"along with its conditions and the issues blocking the rollout (e.g. progress deadline exceeded, replica failures, paused)",
+			InputSchema: rolloutSchema(nil),
+			Annotations: api.ToolAnnotations{
+				Title:           "Rollout: Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rolloutStatus},
+		{Tool: api.Tool{
+			Name:        "rollout_history",
+			Description: "List the revisions of a Deployment, StatefulSet, or DaemonSet with their change cause and container images, the latest revision last",
+			InputSchema: rolloutSchema(nil),
+			Annotations: api.ToolAnnotations{
+				Title:           "Rollout: History",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rolloutHistory},
+		{Tool: api.Tool{
+			Name:        "rollout_restart",
+			Description: "Restart the Pods of a Deployment, StatefulSet, or DaemonSet with a new rollout, the same way kubectl rollout restart does (annotates the Pod template)",
+			InputSchema: rolloutSchema(nil),
+			Annotations: api.ToolAnnotations{
+				Title:           "Rollout: Restart",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true), // All the Pods of the workload are replaced
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rolloutRestart, DryRun: true},
+		{Tool: api.Tool{
+			Name:        "rollout_undo",
+			Description: "Roll a Deployment, StatefulSet, or DaemonSet back to a previous revision (use rollout_history to list the revisions)",
+			InputSchema: rolloutSchema(map[string]*jsonschema.Schema{
+				"toRevision": {
+					Type:        "integer",
+					Description: "Revision to roll back to (Optional, the revision before the latest one if not provided)",
+					Minimum:     ptr.To(float64(0)),
+				},
+			}),
+			Annotations: api.ToolAnnotations{
+				Title:           "Rollout: Undo",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: rolloutUndo, DryRun: true},
+	}
+}
+
+type rolloutArgs struct {
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	ToRevision int64  `json:"toRevision"`
+}
+
+func rolloutStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := rolloutArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout status, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout status, %w", err)), nil
+	}
+	ret, err := params.RolloutStatus(params, args.Kind, args.Namespace, args.Name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout status of %s %s: %w", args.Kind, args.Name, err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func rolloutHistory(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := rolloutArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout history, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout history, %w", err)), nil
+	}
+	ret, err := params.RolloutHistory(params, args.Kind, args.Namespace, args.Name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout history of %s %s: %w", args.Kind, args.Name, err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No rollout history found for %s %s", args.Kind, args.Name), nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func rolloutRestart(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := rolloutArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to restart rollout, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to restart rollout, %w", err)), nil
+	}
+	ret, err := params.RolloutRestart(params, args.Kind, args.Namespace, args.Name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to restart rollout of %s %s: %w", args.Kind, args.Name, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to restart rollout: %w", err)
+	}
+	return api.NewToolCallResult("# The following resource (YAML) has been restarted successfully\n"+marshalledYaml, err), nil
+}
+
+func rolloutUndo(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := rolloutArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to undo rollout, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to undo rollout, %w", err)), nil
+	}
+	ret, err := params.RolloutUndo(params, args.Kind, args.Namespace, args.Name, args.ToRevision)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to undo rollout of %s %s: %w", args.Kind, args.Name, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to undo rollout: %w", err)
+	}
+	return api.NewToolCallResult("# The following resource (YAML) has been rolled back successfully\n"+marshalledYaml, err), nil
+}
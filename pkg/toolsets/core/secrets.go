@@ -0,0 +1,116 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initSecrets() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "secrets_get",
+			Description: "Get a Kubernetes Secret in the current or provided namespace with its metadata, type, and key names (along with the size of their values). " +
+				"The values are only returned when revealValues is requested and the server configuration allows revealing them, " +
+				"the Secret data is redacted from the output of the rest of the tools",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to get the Secret from",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Secret",
+					},
+					"revealValues": {
+						Type:        "boolean",
+						Description: "Optional, return the decoded values of the Secret, only allowed when enabled in the server configuration (defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Secrets: Get",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: secretsGet, Access: []api.ResourceAccess{
+			{Verb: "get", Resource: "secrets"},
+		}},
+		{Tool: api.Tool{
+			Name: "secrets_create_or_update",
+			Description: "Create or update a Kubernetes Secret in the current or provided namespace from key-value literals and file contents " +
+				"(same as kubectl create secret generic --from-literal --from-file), the values are base64 encoded by the server. " +
+				"Enable appendHash to suffix the name with a hash of the data, so that each change creates a new Secret and rolls out the workloads referencing it. " +
+				"The values are redacted from the output",
+			InputSchema: configDataSchema("Secret", map[string]*jsonschema.Schema{
+				"type": {
+					Type:        "string",
+					Description: "Optional type of the Secret (e.g. Opaque, kubernetes.io/tls, kubernetes.io/dockerconfigjson) (defaults to Opaque)",
+				},
+			}),
+			Annotations: api.ToolAnnotations{
+				Title:           "Secrets: Create or Update",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: secretsCreateOrUpdate, DryRun: true, Access: []api.ResourceAccess{
+			{Verb: "patch", Resource: "secrets"},
+		}},
+	}
+}
+
+type secretsGetArgs struct {
+	Namespace    string `json:"namespace"`
+	Name         string `json:"name"`
+	RevealValues bool   `json:"revealValues"`
+}
+
+func secretsGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := secretsGetArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get secret, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get secret, %w", err)), nil
+	}
+	ret, err := params.SecretsGet(params, args.Namespace, args.Name, args.RevealValues)
+	if errors.Is(err, kubernetes.ErrSecretValuesNotAllowed) {
+		return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeForbidden, false, fmt.Errorf("failed to get secret: %w", err))), nil
+	} else if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get secret: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func secretsCreateOrUpdate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := configDataArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update secret, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update secret, %w", err)), nil
+	}
+	ret, err := params.SecretsCreateOrUpdate(params, args.Namespace, args.Name, args.options())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update secret: %w", err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to create or update secret: %w", err)
+	}
+	return api.NewToolCallResult("# The following resource (YAML) has been created or updated successfully\n"+marshalledYaml, err), nil
+}
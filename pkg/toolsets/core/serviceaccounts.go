@@ -0,0 +1,88 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initServiceAccounts() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "serviceaccounts_token",
+			Description: "Request a short-lived token for a Kubernetes ServiceAccount in the current or provided namespace with a TokenRequest (same as kubectl create token), " +
+				"bound to the provided audiences and expiration, for workflows that need credentials to authenticate to other systems. " +
+				"Only allowed when enabled in the server configuration. The token is returned in the output and can't be revoked before its expiration, except by deleting the ServiceAccount",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the ServiceAccount. If not provided, the configured namespace is used",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the ServiceAccount",
+					},
+					"audiences": {
+						Type:        "array",
+						Description: "Optional intended audiences of the token (e.g. vault), the audiences of the API server if not provided",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"expiration": {
+						Type: "string",
+						Description: fmt.Sprintf("Optional requested lifetime of the token, e.g. '30m', '2h' (default: %s, minimum: %s, maximum: %s), the API server may issue a shorter-lived token",
+							internalk8s.DefaultServiceAccountTokenExpiration, internalk8s.MinServiceAccountTokenExpiration, internalk8s.MaxServiceAccountTokenExpiration),
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "ServiceAccounts: Token",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: serviceAccountsToken, Access: []api.ResourceAccess{
+			{Verb: "create", Resource: "serviceaccounts", Subresource: "token"},
+		}},
+	}
+}
+
+type serviceAccountsTokenArgs struct {
+	Namespace  string   `json:"namespace"`
+	Name       string   `json:"name"`
+	Audiences  []string `json:"audiences"`
+	Expiration string   `json:"expiration"`
+}
+
+func serviceAccountsToken(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := serviceAccountsTokenArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to request ServiceAccount token, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to request ServiceAccount token, %w", err)), nil
+	}
+	options := internalk8s.ServiceAccountTokenOptions{Namespace: args.Namespace, Name: args.Name, Audiences: args.Audiences}
+	if args.Expiration != "" {
+		expiration, err := time.ParseDuration(args.Expiration)
+		if err != nil {
+			return api.NewToolCallResult("", api.NewToolError(api.ErrorCodeValidationFailed, false,
+				fmt.Errorf("failed to request ServiceAccount token, invalid expiration %q: %w", args.Expiration, err))), nil
+		}
+		options.Expiration = expiration
+	}
+	ret, err := params.ServiceAccountsToken(params, options)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to request ServiceAccount token: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
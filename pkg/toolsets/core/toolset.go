@@ -24,8 +24,33 @@ func (t *Toolset) GetTools(o internalk8s.Openshift) []api.ServerTool {
 	return slices.Concat(
 		initEvents(),
 		initNamespaces(o),
+		initNodes(),
 		initPods(),
+		initPodsCopy(),
+		initPortForward(),
 		initResources(o),
+		initKustomize(),
+		initManifests(),
+		initDrift(),
+		initOwners(),
+		initAuth(),
+		initConfigMaps(),
+		initSecrets(),
+		initServiceAccounts(),
+		initCertificateSigningRequests(),
+		initEfficiency(),
+		initRestarts(),
+		initCertificates(),
+		initClusterHealth(),
+		initQuotas(),
+		initImages(),
+		initDiscovery(),
+		initRollout(),
+		initAutoscaling(),
+		initDisruptionBudgets(),
+		initBatch(),
+		initWorkloads(),
+		initCapacity(),
 	)
 }
 
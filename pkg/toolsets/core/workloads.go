@@ -0,0 +1,112 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// workloadStates are the columns of the namespace summary of workloads_status
+var workloadStates = []kubernetes.WorkloadState{
+	kubernetes.WorkloadStateReady,
+	kubernetes.WorkloadStateProgressing,
+	kubernetes.WorkloadStateDegraded,
+	kubernetes.WorkloadStateStalled,
+	kubernetes.WorkloadStateScaledDown,
+}
+
+func initWorkloads() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "workloads_status",
+			Description: "Report the readiness of all the Deployments, StatefulSets, and DaemonSets in one compact table (desired, ready, available, and up-to-date replicas, " +
+				"like kube-state-metrics) with their state: ready, progressing (rollout in progress), degraded (up-to-date but unavailable replicas), " +
+				"stalled (progress deadline exceeded or replica failure), or scaled-down, and the reason of the workloads that aren't ready. " +
+				"Ends with the number of workloads per state and namespace. Use rollout_status to investigate a single workload",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to report, all namespaces if not provided",
+					},
+					"notReadyOnly": {
+						Type:        "boolean",
+						Description: "Optional, only list the workloads that aren't ready or scaled down, the namespace summary still includes all the workloads (defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workloads: Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadsStatus, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "apps", Resource: "deployments", AllNamespaces: true},
+			{Verb: "list", Group: "apps", Resource: "statefulsets", AllNamespaces: true},
+			{Verb: "list", Group: "apps", Resource: "daemonsets", AllNamespaces: true},
+		}},
+	}
+}
+
+type workloadsStatusArgs struct {
+	Namespace    string `json:"namespace"`
+	NotReadyOnly bool   `json:"notReadyOnly"`
+}
+
+func workloadsStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := workloadsStatusArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workloads status, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workloads status, %w", err)), nil
+	}
+	ret, err := params.WorkloadsStatus(params, args.Namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workloads status: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No workloads found", nil), nil
+	}
+	return api.NewToolCallResult(printWorkloadsStatus(ret, args.NotReadyOnly), nil), nil
+}
+
+func printWorkloadsStatus(workloads []kubernetes.WorkloadStatus, notReadyOnly bool) string {
+	buf := new(bytes.Buffer)
+	w := printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprintln(w, "NAMESPACE\tWORKLOAD\tDESIRED\tREADY\tAVAILABLE\tUP-TO-DATE\tSTATE\tMESSAGE")
+	for _, s := range workloads {
+		if notReadyOnly && (s.State == kubernetes.WorkloadStateReady || s.State == kubernetes.WorkloadStateScaledDown) {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s/%s\t%d\t%d\t%d\t%d\t%s\t%s\n", s.Namespace, s.Kind, s.Name, s.Desired, s.Ready, s.Available, s.UpToDate, s.State, valueOrNone(s.Message))
+	}
+	_ = w.Flush()
+	_, _ = fmt.Fprintln(buf)
+	w = printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprint(w, "NAMESPACE\tWORKLOADS")
+	for _, state := range workloadStates {
+		_, _ = fmt.Fprintf(w, "\t%s", strings.ToUpper(string(state)))
+	}
+	_, _ = fmt.Fprintln(w)
+	for _, n := range kubernetes.WorkloadsStatusByNamespace(workloads) {
+		_, _ = fmt.Fprintf(w, "%s\t%d", n.Namespace, n.Workloads)
+		for _, state := range workloadStates {
+			_, _ = fmt.Fprintf(w, "\t%d", n.States[state])
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+	_ = w.Flush()
+	return buf.String()
+}
@@ -0,0 +1,87 @@
+package cost
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initCost() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "cost_allocation",
+			Description: "Report the cost of the namespaces or of the workloads over a window (CPU, memory, GPU, storage, network, and other costs), the most expensive first, " +
+				"with their efficiency (usage over requests weighted by cost) and the idle cost of the nodes. " +
+				"The costs are the allocations of the OpenCost (or Kubecost) configured with opencost_url or discovered in the cluster. " +
+				"Without OpenCost, the costs are estimated from the current resource requests of the running Pods over the window, priced per node with cost_pricing " +
+				"(the default OpenCost pricing if not configured), reported with estimated: true",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to report the costs of, all namespaces if not provided",
+					},
+					"aggregate": {
+						Type:        "string",
+						Description: fmt.Sprintf("Optional aggregation of the costs, per namespace or per workload (defaults to %s)", internalk8s.CostAggregateNamespace),
+						Enum:        []any{internalk8s.CostAggregateNamespace, internalk8s.CostAggregateWorkload},
+						Default:     api.ToRawMessage(internalk8s.CostAggregateNamespace),
+					},
+					"window": {
+						Type:        "string",
+						Description: fmt.Sprintf("Optional window of the costs until now (e.g. 12h, 1d, 7d, 30d), defaults to %s", internalk8s.CostDefaultWindow),
+						Default:     api.ToRawMessage(internalk8s.CostDefaultWindow),
+					},
+					"top": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Optional maximum number of namespaces or workloads returned, the most expensive first (defaults to %d)", internalk8s.CostDefaultTop),
+						Default:     api.ToRawMessage(internalk8s.CostDefaultTop),
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Cost: Allocation",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: costAllocation, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "services", AllNamespaces: true},
+			{Verb: "get", Resource: "services", Subresource: "proxy"},
+			{Verb: "list", Resource: "nodes", ClusterScoped: true},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+		}},
+	}
+}
+
+type costAllocationArgs struct {
+	Namespace string `json:"namespace"`
+	Aggregate string `json:"aggregate"`
+	Window    string `json:"window"`
+	Top       int    `json:"top"`
+}
+
+func costAllocation(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := costAllocationArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get cost allocation, %w", err)), nil
+	}
+	ret, err := params.CostAllocation(params, internalk8s.CostAllocationOptions{
+		Namespace: args.Namespace,
+		Aggregate: args.Aggregate,
+		Window:    args.Window,
+		Top:       args.Top,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get cost allocation: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,31 @@
+package cost
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "cost"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Tools for analyzing the cost of the cluster (OpenCost or Kubecost allocations per namespace and workload, request-based estimates, etc.)"
+}
+
+func (t *Toolset) GetTools(_ internalk8s.Openshift) []api.ServerTool {
+	return slices.Concat(
+		initCost(),
+	)
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
@@ -0,0 +1,198 @@
+package crd
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initCrds() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "crds_list",
+			Description: "List the CustomResourceDefinitions (CRDs) in the current cluster with their group, kind, scope, versions (served, storage, deprecated), conditions, and detected issues",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"group": {
+						Type:        "string",
+						Description: "Optional API group to list the CRDs from (e.g. cert-manager.io)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "CRDs: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: crdsList},
+		{Tool: api.Tool{
+			Name:        "crds_schema",
+			Description: "Get the OpenAPI v3 schema of a version of a CustomResourceDefinition (CRD), use it to build valid custom resources",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the CRD (e.g. certificates.cert-manager.io)",
+					},
+					"version": {
+						Type:        "string",
+						Description: "Optional version of the CRD to get the schema from (e.g. v1), the storage version if not provided",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "CRDs: Schema",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: crdsSchema},
+		{Tool: api.Tool{
+			Name:        "crds_instances_list",
+			Description: "List the instances (custom resources) of a CustomResourceDefinition (CRD) in all namespaces or in the provided namespace",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: api.WithListProperties(map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the CRD (e.g. certificates.cert-manager.io)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to list the custom resources from (ignored for cluster scoped CRDs), all namespaces if not provided",
+					},
+					"labelSelector": {
+						Type:        "string",
+						Description: "Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the custom resources by label",
+						Pattern:     "([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]",
+					},
+				}),
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "CRDs: List Instances",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: crdsInstancesList},
+		{Tool: api.Tool{
+			Name: "crds_issues",
+			Description: "Detect the CustomResourceDefinitions (CRDs) with version issues in the current cluster: deprecated versions still served, " +
+				"storage versions deprecated or not served, stored versions pending migration, and CRDs not established or with non-structural schemas",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"group": {
+						Type:        "string",
+						Description: "Optional API group to check the CRDs from (e.g. cert-manager.io)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "CRDs: Issues",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: crdsIssues},
+	}
+}
+
+type crdsArgs struct {
+	Name          string `json:"name"`
+	Group         string `json:"group"`
+	Version       string `json:"version"`
+	Namespace     string `json:"namespace"`
+	LabelSelector string `json:"labelSelector"`
+	api.ListArgs
+}
+
+func crdsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := crdsArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list CRDs, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list CRDs, %w", err)), nil
+	}
+	ret, err := params.CustomResourceDefinitionsList(params, args.Group)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list CRDs: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No CRDs found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func crdsSchema(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := crdsArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get CRD schema, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get CRD schema, %w", err)), nil
+	}
+	ret, err := params.CustomResourceDefinitionSchema(params, args.Name, args.Version)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get CRD schema: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func crdsInstancesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := crdsArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list CRD instances, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list CRD instances, %w", err)), nil
+	}
+	resourceListOptions, err := args.ResourceListOptions(args.LabelSelector, params.ListOutput.AsTable())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list CRD instances, %w", err)), nil
+	}
+	ret, err := params.CustomResourcesList(params, args.Name, args.Namespace, resourceListOptions)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list CRD instances: %w", err)), nil
+	}
+	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
+}
+
+func crdsIssues(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := crdsArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to detect CRD issues, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to detect CRD issues, %w", err)), nil
+	}
+	crds, err := params.CustomResourceDefinitionsList(params, args.Group)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to detect CRD issues: %w", err)), nil
+	}
+	var ret []internalk8s.CustomResourceDefinition
+	for _, crd := range crds {
+		if len(crd.Issues) > 0 {
+			ret = append(ret, crd)
+		}
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No issues found in %d CRDs", len(crds)), nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
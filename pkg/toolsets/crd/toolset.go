@@ -0,0 +1,31 @@
+package crd
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "crd"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Tools for managing CustomResourceDefinitions (CRDs) and their instances (versions, schemas, deprecations, etc.)"
+}
+
+func (t *Toolset) GetTools(_ internalk8s.Openshift) []api.ServerTool {
+	return slices.Concat(
+		initCrds(),
+	)
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
@@ -1,31 +1,30 @@
 package helm
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/helm"
 )
 
+// helmHistoryDefaultMax is the default number of revisions returned by helm_history, the default history limit of Helm
+const helmHistoryDefaultMax = 10
+
 func initHelm() []api.ServerTool {
 	return []api.ServerTool{
 		{Tool: api.Tool{
-			Name:        "helm_install",
-			Description: "Install a Helm chart in the current or provided namespace",
+			Name: "helm_install",
+			Description: "Install a Helm chart in the current or provided namespace, from a configured repository, a repository URL, an OCI registry, or a local path. " +
+				"Returns the release with the resources of its manifest and its notes",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"chart": {
-						Type:        "string",
-						Description: "Chart reference to install (for example: stable/grafana, oci://ghcr.io/nginxinc/charts/nginx-ingress)",
-					},
-					"values": {
-						Type:        "object",
-						Description: "Values to pass to the Helm chart (Optional)",
-						Properties:  make(map[string]*jsonschema.Schema),
-					},
+				Properties: helmReleaseProperties(map[string]*jsonschema.Schema{
 					"name": {
 						Type:        "string",
 						Description: "Name of the Helm release (Optional, random name if not provided)",
@@ -34,7 +33,17 @@ func initHelm() []api.ServerTool {
 						Type:        "string",
 						Description: "Namespace to install the Helm chart in (Optional, current namespace if not provided)",
 					},
-				},
+					"createNamespace": {
+						Type:        "boolean",
+						Description: "Create the namespace if it doesn't exist (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"atomic": {
+						Type:        "boolean",
+						Description: "Uninstall the release if the installation fails, implies wait (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				}),
 				Required: []string{"chart"},
 			},
 			Annotations: api.ToolAnnotations{
@@ -44,7 +53,58 @@ func initHelm() []api.ServerTool {
 				IdempotentHint:  ptr.To(false), // TODO: consider replacing implementation with equivalent to: helm upgrade --install
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: helmInstall},
+		}, Handler: helmInstall, DryRun: true},
+		{Tool: api.Tool{
+			Name: "helm_upgrade",
+			Description: "Upgrade a Helm release in the current or provided namespace to a chart (a new version or new values), or install it if it doesn't exist and install is true. " +
+				"Returns the release with the resources of its manifest and its notes",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: helmReleaseProperties(map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release to upgrade",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Helm release (Optional, current namespace if not provided)",
+					},
+					"install": {
+						Type:        "boolean",
+						Description: "Install the release if it doesn't exist, like helm upgrade --install (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"createNamespace": {
+						Type:        "boolean",
+						Description: "Create the namespace if it doesn't exist when the release is installed (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"atomic": {
+						Type:        "boolean",
+						Description: "Roll back the release to its previous revision if the upgrade fails, implies wait (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"reuseValues": {
+						Type:        "boolean",
+						Description: "Merge the values with the ones of the current revision instead of the default values of the chart (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"resetValues": {
+						Type:        "boolean",
+						Description: "Reset the values to the default values of the chart (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				}),
+				Required: []string{"name", "chart"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: Upgrade",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmUpgrade, DryRun: true},
 		{Tool: api.Tool{
 			Name:        "helm_list",
 			Description: "List all the Helm releases in the current or provided namespace (or in all namespaces if specified)",
@@ -59,6 +119,11 @@ func initHelm() []api.ServerTool {
 						Type:        "boolean",
 						Description: "If true, lists all Helm releases in all namespaces ignoring the namespace argument (Optional)",
 					},
+					"status": {
+						Type:        "string",
+						Description: "Status of the Helm releases to list (Optional, deployed and failed releases if not provided)",
+						Enum:        helmListStatuses(),
+					},
 				},
 			},
 			Annotations: api.ToolAnnotations{
@@ -68,7 +133,154 @@ func initHelm() []api.ServerTool {
 				IdempotentHint:  ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: helmList},
+		}, Handler: helmList, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "secrets", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name: "helm_status",
+			Description: "Get the status of a Helm release in the current or provided namespace: its chart, status, and description, " +
+				"with the resources of its manifest and its notes",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Helm release (Optional, current namespace if not provided)",
+					},
+					"revision": {
+						Type:        "integer",
+						Description: "Revision of the Helm release (Optional, latest revision if not provided)",
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmStatus, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "secrets"},
+		}},
+		{Tool: api.Tool{
+			Name:        "helm_history",
+			Description: "Get the revisions of a Helm release in the current or provided namespace, the oldest first, with their chart, status, and description",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Helm release (Optional, current namespace if not provided)",
+					},
+					"max": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum number of revisions returned, the most recent ones (Optional, defaults to %d)", helmHistoryDefaultMax),
+						Default:     api.ToRawMessage(helmHistoryDefaultMax),
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: History",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmHistory, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "secrets"},
+		}},
+		{Tool: api.Tool{
+			Name: "helm_get_values",
+			Description: "Get the values of a Helm release in the current or provided namespace: the values supplied by the user, " +
+				"or the computed values (the user-supplied values merged with the default values of the chart)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Helm release (Optional, current namespace if not provided)",
+					},
+					"revision": {
+						Type:        "integer",
+						Description: "Revision of the Helm release (Optional, latest revision if not provided)",
+						Minimum:     ptr.To(float64(1)),
+					},
+					"all": {
+						Type:        "boolean",
+						Description: "If true, returns the computed values instead of the user-supplied ones (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: Get Values",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmGetValues, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "secrets"},
+		}},
+		{Tool: api.Tool{
+			Name: "helm_rollback",
+			Description: "Roll back a Helm release in the current or provided namespace to a previous revision, as a new revision of the release. " +
+				"Returns the new revision with the resources of its manifest",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Helm release to roll back",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Helm release (Optional, current namespace if not provided)",
+					},
+					"revision": {
+						Type:        "integer",
+						Description: "Revision to roll back to (Optional, previous revision if not provided)",
+						Minimum:     ptr.To(float64(1)),
+					},
+					"wait": {
+						Type:        "boolean",
+						Description: "Wait until the resources of the release are ready, up to the timeout (Optional, defaults to true)",
+						Default:     api.ToRawMessage(true),
+					},
+					"timeout": {
+						Type:        "string",
+						Description: fmt.Sprintf("Time to wait for the resources of the release (Optional, for example: 10m, defaults to %s)", helm.DefaultTimeout),
+						Default:     api.ToRawMessage(helm.DefaultTimeout.String()),
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Helm: Rollback",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: helmRollback, DryRun: true},
 		{Tool: api.Tool{
 			Name:        "helm_uninstall",
 			Description: "Uninstall a Helm release in the current or provided namespace",
@@ -83,6 +295,11 @@ func initHelm() []api.ServerTool {
 						Type:        "string",
 						Description: "Namespace to uninstall the Helm release from (Optional, current namespace if not provided)",
 					},
+					"keepHistory": {
+						Type:        "boolean",
+						Description: "Keep the history of the Helm release, so that it can be listed and rolled back (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
 				},
 				Required: []string{"name"},
 			},
@@ -93,64 +310,243 @@ func initHelm() []api.ServerTool {
 				IdempotentHint:  ptr.To(true),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: helmUninstall},
+		}, Handler: helmUninstall, DryRun: true},
 	}
 }
 
+// helmReleaseProperties returns the properties of the tools installing or upgrading a release along with the provided ones
+func helmReleaseProperties(properties map[string]*jsonschema.Schema) map[string]*jsonschema.Schema {
+	maps.Copy(properties, map[string]*jsonschema.Schema{
+		"chart": {
+			Type: "string",
+			Description: "Chart reference to install (for example: stable/grafana for a configured repository, grafana with repo, " +
+				"oci://ghcr.io/nginxinc/charts/nginx-ingress, or a local path)",
+		},
+		"version": {
+			Type:        "string",
+			Description: "Version (or version constraint) of the chart (Optional, latest version if not provided)",
+		},
+		"repo": {
+			Type:        "string",
+			Description: "URL of the chart repository the chart is located in (Optional, for example: https://grafana.github.io/helm-charts)",
+		},
+		"values": {
+			Type:        "object",
+			Description: "Values to pass to the Helm chart (Optional)",
+			Properties:  make(map[string]*jsonschema.Schema),
+		},
+		"wait": {
+			Type:        "boolean",
+			Description: "Wait until the resources of the release are ready, up to the timeout (Optional, defaults to true)",
+			Default:     api.ToRawMessage(true),
+		},
+		"timeout": {
+			Type:        "string",
+			Description: fmt.Sprintf("Time to wait for the resources of the release (Optional, for example: 10m, defaults to %s)", helm.DefaultTimeout),
+			Default:     api.ToRawMessage(helm.DefaultTimeout.String()),
+		},
+	})
+	return properties
+}
+
+type helmReleaseArgs struct {
+	Chart           string         `json:"chart"`
+	Version         string         `json:"version"`
+	Repo            string         `json:"repo"`
+	Values          map[string]any `json:"values"`
+	Name            string         `json:"name"`
+	Namespace       string         `json:"namespace"`
+	CreateNamespace bool           `json:"createNamespace"`
+	Atomic          bool           `json:"atomic"`
+	Wait            *bool          `json:"wait"`
+	Timeout         string         `json:"timeout"`
+	Install         bool           `json:"install"`
+	ReuseValues     bool           `json:"reuseValues"`
+	ResetValues     bool           `json:"resetValues"`
+	DryRun          bool           `json:"dryRun"`
+}
+
+func (args helmReleaseArgs) options() (helm.ReleaseOptions, error) {
+	options := helm.ReleaseOptions{
+		Chart:           args.Chart,
+		Version:         args.Version,
+		RepoURL:         args.Repo,
+		Values:          args.Values,
+		Name:            args.Name,
+		Namespace:       args.Namespace,
+		CreateNamespace: args.CreateNamespace,
+		Atomic:          args.Atomic,
+		Wait:            args.Wait == nil || *args.Wait,
+		Install:         args.Install,
+		ReuseValues:     args.ReuseValues,
+		ResetValues:     args.ResetValues,
+		DryRun:          args.DryRun,
+	}
+	if args.Timeout != "" {
+		timeout, err := time.ParseDuration(args.Timeout)
+		if err != nil {
+			return options, fmt.Errorf("invalid timeout %q: %w", args.Timeout, err)
+		}
+		options.Timeout = timeout
+	}
+	return options, nil
+}
+
 func helmInstall(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	var chart string
-	ok := false
-	if chart, ok = params.GetArguments()["chart"].(string); !ok {
-		return api.NewToolCallResult("", fmt.Errorf("failed to install helm chart, missing argument chart")), nil
+	args := helmReleaseArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to install helm chart, %w", err)), nil
 	}
-	values := map[string]interface{}{}
-	if v, ok := params.GetArguments()["values"].(map[string]interface{}); ok {
-		values = v
+	options, err := args.options()
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to install helm chart, %w", err)), nil
 	}
-	name := ""
-	if v, ok := params.GetArguments()["name"].(string); ok {
-		name = v
+	ret, err := params.NewHelm().Install(params, options)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to install helm chart '%s': %w", args.Chart, err)), nil
+	}
+	return api.NewToolCallResult(ret, err), nil
+}
+
+func helmUpgrade(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := helmReleaseArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to upgrade helm release, %w", err)), nil
 	}
-	namespace := ""
-	if v, ok := params.GetArguments()["namespace"].(string); ok {
-		namespace = v
+	if args.ReuseValues && args.ResetValues {
+		return api.NewToolCallResult("", fmt.Errorf("failed to upgrade helm release, %w", errors.New("reuseValues and resetValues are mutually exclusive"))), nil
 	}
-	ret, err := params.NewHelm().Install(params, chart, values, name, namespace)
+	options, err := args.options()
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to install helm chart '%s': %w", chart, err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to upgrade helm release, %w", err)), nil
+	}
+	ret, err := params.NewHelm().Upgrade(params, options)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to upgrade helm release '%s': %w", args.Name, err)), nil
 	}
 	return api.NewToolCallResult(ret, err), nil
 }
 
+func helmListStatuses() []any {
+	ret := make([]any, len(helm.ListStatuses))
+	for i, status := range helm.ListStatuses {
+		ret[i] = status
+	}
+	return ret
+}
+
+type helmListArgs struct {
+	AllNamespaces bool   `json:"all_namespaces"`
+	Namespace     string `json:"namespace"`
+	Status        string `json:"status"`
+}
+
 func helmList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	allNamespaces := false
-	if v, ok := params.GetArguments()["all_namespaces"].(bool); ok {
-		allNamespaces = v
+	args := helmListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list helm releases, %w", err)), nil
 	}
-	namespace := ""
-	if v, ok := params.GetArguments()["namespace"].(string); ok {
-		namespace = v
+	ret, err := params.NewHelm().List(args.Namespace, args.AllNamespaces, args.Status)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list helm releases in namespace '%s': %w", args.Namespace, err)), nil
 	}
-	ret, err := params.NewHelm().List(namespace, allNamespaces)
+	return api.NewToolCallResult(ret, err), nil
+}
+
+type helmReleaseInspectArgs struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Revision  int    `json:"revision"`
+	Max       *int   `json:"max"`
+	All       bool   `json:"all"`
+}
+
+func helmStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := helmReleaseInspectArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get helm release status, %w", err)), nil
+	}
+	ret, err := params.NewHelm().Status(args.Name, args.Namespace, args.Revision)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to list helm releases in namespace '%s': %w", namespace, err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to get helm release status '%s': %w", args.Name, err)), nil
 	}
 	return api.NewToolCallResult(ret, err), nil
 }
 
-func helmUninstall(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	var name string
-	ok := false
-	if name, ok = params.GetArguments()["name"].(string); !ok {
-		return api.NewToolCallResult("", fmt.Errorf("failed to uninstall helm chart, missing argument name")), nil
+func helmHistory(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := helmReleaseInspectArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get helm release history, %w", err)), nil
+	}
+	ret, err := params.NewHelm().History(args.Name, args.Namespace, ptr.Deref(args.Max, helmHistoryDefaultMax))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get helm release history '%s': %w", args.Name, err)), nil
 	}
-	namespace := ""
-	if v, ok := params.GetArguments()["namespace"].(string); ok {
-		namespace = v
+	return api.NewToolCallResult(ret, err), nil
+}
+
+func helmGetValues(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := helmReleaseInspectArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get helm release values, %w", err)), nil
+	}
+	ret, err := params.NewHelm().GetValues(args.Name, args.Namespace, args.Revision, args.All)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get helm release values '%s': %w", args.Name, err)), nil
+	}
+	return api.NewToolCallResult(ret, err), nil
+}
+
+type helmRollbackArgs struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Revision  int    `json:"revision"`
+	Wait      *bool  `json:"wait"`
+	Timeout   string `json:"timeout"`
+	DryRun    bool   `json:"dryRun"`
+}
+
+func helmRollback(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := helmRollbackArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to roll back helm release, %w", err)), nil
+	}
+	options := helm.RollbackOptions{
+		Name:      args.Name,
+		Namespace: args.Namespace,
+		Revision:  args.Revision,
+		Wait:      args.Wait == nil || *args.Wait,
+		DryRun:    args.DryRun,
+	}
+	if args.Timeout != "" {
+		timeout, err := time.ParseDuration(args.Timeout)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to roll back helm release, invalid timeout %q: %w", args.Timeout, err)), nil
+		}
+		options.Timeout = timeout
+	}
+	ret, err := params.NewHelm().Rollback(options)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to roll back helm release '%s': %w", args.Name, err)), nil
+	}
+	return api.NewToolCallResult(ret, err), nil
+}
+
+type helmUninstallArgs struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	KeepHistory bool   `json:"keepHistory"`
+	DryRun      bool   `json:"dryRun"`
+}
+
+func helmUninstall(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := helmUninstallArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to uninstall helm chart, %w", err)), nil
 	}
-	ret, err := params.NewHelm().Uninstall(name, namespace)
+	ret, err := params.NewHelm().Uninstall(args.Name, args.Namespace, args.KeepHistory, args.DryRun)
 	if err != nil {
-		return api.NewToolCallResult("", fmt.Errorf("failed to uninstall helm chart '%s': %w", name, err)), nil
+		return api.NewToolCallResult("", fmt.Errorf("failed to uninstall helm chart '%s': %w", args.Name, err)), nil
 	}
 	return api.NewToolCallResult(ret, err), nil
 }
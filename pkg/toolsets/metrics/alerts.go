@@ -0,0 +1,263 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// alertmanagerSource describes how the Alertmanager used by the tools is found
+const alertmanagerSource = "The Alertmanager is the one configured with alertmanager_url, or discovered in the cluster " +
+	"(OpenShift monitoring Route and Service, Prometheus Operator and Helm chart Services)"
+
+func initAlerts() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "alerts_list",
+			Description: "List the alerts currently firing in the Alertmanager of the cluster, the most severe and oldest first, " +
+				"with their severity, namespace, summary, fingerprint, and runbook URL. The silenced and inhibited alerts are excluded unless suppressed is set. " +
+				alertmanagerSource,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"severity": {
+						Type:        "string",
+						Description: "Optional severity of the alerts to list (e.g. critical, warning, info)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional namespace label of the alerts to list",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Optional name (alertname label) of the alerts to list",
+					},
+					"suppressed": {
+						Type:        "boolean",
+						Description: "Include the alerts silenced or inhibited (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Alerts: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: alertsList, Access: monitoringAccess("get")},
+		{Tool: api.Tool{
+			Name: "alerts_get",
+			Description: "Get the details of the alerts firing in the Alertmanager of the cluster by fingerprint or by name: " +
+				"all their labels and annotations (description, runbook_url...), the receivers notified, the silences and alerts suppressing them, " +
+				"and the URL of the alerting rule expression. Use the runbook to triage the alert. " + alertmanagerSource,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"fingerprint": {
+						Type:        "string",
+						Description: "Fingerprint of the alert, as returned by alerts_list",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name (alertname label) of the alerts, all the alerts with this name are returned (alternative to fingerprint)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional namespace label of the alerts with the name",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Alerts: Get",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: alertsGet, Access: monitoringAccess("get")},
+		{Tool: api.Tool{
+			Name:        "silences_list",
+			Description: "List the silences of the Alertmanager of the cluster, the active ones first, with their matchers, end time, creator, and comment. " + alertmanagerSource,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"expired": {
+						Type:        "boolean",
+						Description: "Include the expired silences (Optional, defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Silences: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: silencesList, Access: monitoringAccess("get")},
+		{Tool: api.Tool{
+			Name: "silences_create",
+			Description: "Create a silence in the Alertmanager of the cluster, the alerts with all the labels equal to the matchers aren't notified until the silence ends. " +
+				"Prefer narrow matchers (e.g. alertname and namespace) so that no other alert is silenced. " + alertmanagerSource,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"matchers": {
+						Type:                 "object",
+						Description:          "Labels of the alerts to silence (e.g. {\"alertname\": \"KubePodCrashLooping\", \"namespace\": \"my-app\"})",
+						AdditionalProperties: &jsonschema.Schema{Type: "string"},
+					},
+					"duration": {
+						Type:        "string",
+						Description: fmt.Sprintf("Optional duration of the silence starting now (e.g. 30m, 2h, 1d), defaults to %s", internalk8s.AlertmanagerDefaultSilenceDuration),
+						Default:     api.ToRawMessage(internalk8s.AlertmanagerDefaultSilenceDuration),
+					},
+					"comment": {
+						Type:        "string",
+						Description: "Reason of the silence (e.g. the ticket tracking the issue)",
+					},
+					"createdBy": {
+						Type:        "string",
+						Description: "Optional creator of the silence, defaults to kubernetes-mcp-server",
+					},
+				},
+				Required: []string{"matchers", "comment"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Silences: Create",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: silencesCreate, Access: monitoringAccess("create")},
+		{Tool: api.Tool{
+			Name:        "silences_expire",
+			Description: "Expire a silence of the Alertmanager of the cluster, the alerts it silenced are notified again. " + alertmanagerSource,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {
+						Type:        "string",
+						Description: "ID of the silence, as returned by silences_list or silences_create",
+					},
+				},
+				Required: []string{"id"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Silences: Expire",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: silencesExpire, Access: monitoringAccess("delete")},
+	}
+}
+
+type alertsArgs struct {
+	Severity    string `json:"severity"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint"`
+	Suppressed  bool   `json:"suppressed"`
+}
+
+func alertsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := alertsArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list alerts, %w", err)), nil
+	}
+	ret, err := params.AlertmanagerAlerts(params, internalk8s.AlertmanagerAlertsOptions{
+		Name:       args.Name,
+		Severity:   args.Severity,
+		Namespace:  args.Namespace,
+		Suppressed: args.Suppressed,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list alerts: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func alertsGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := alertsArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get alerts, %w", err)), nil
+	}
+	if (args.Fingerprint == "") == (args.Name == "") {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get alerts, %w", errors.New("exactly one of fingerprint or name must be provided"))), nil
+	}
+	ret, err := params.AlertmanagerAlerts(params, internalk8s.AlertmanagerAlertsOptions{
+		Name:        args.Name,
+		Namespace:   args.Namespace,
+		Fingerprint: args.Fingerprint,
+		Suppressed:  true,
+		Details:     true,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get alerts: %w", err)), nil
+	}
+	if len(ret.Alerts) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get alerts: no alert with fingerprint %q or name %q is firing", args.Fingerprint, args.Name)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret.Alerts)), nil
+}
+
+type silencesArgs struct {
+	Expired   bool              `json:"expired"`
+	Matchers  map[string]string `json:"matchers"`
+	Duration  string            `json:"duration"`
+	Comment   string            `json:"comment"`
+	CreatedBy string            `json:"createdBy"`
+	ID        string            `json:"id"`
+}
+
+func silencesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := silencesArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list silences, %w", err)), nil
+	}
+	ret, err := params.AlertmanagerSilences(params, args.Expired)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list silences: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func silencesCreate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := silencesArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence, %w", err)), nil
+	}
+	ret, err := params.AlertmanagerSilenceCreate(params, internalk8s.AlertmanagerSilenceOptions{
+		Matchers:  args.Matchers,
+		Duration:  args.Duration,
+		Comment:   args.Comment,
+		CreatedBy: args.CreatedBy,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create silence: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func silencesExpire(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := silencesArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to expire silence, %w", err)), nil
+	}
+	if err := params.AlertmanagerSilenceExpire(params, args.ID); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to expire silence: %w", err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("Silence %s expired successfully", args.ID), nil), nil
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initGrafana() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "grafana_links",
+			Description: "Generate the URLs of the Grafana dashboards showing a Pod, a workload, a Node, or a namespace over a time range, so that the answers can include clickable links to the right graphs. " +
+				"Returns the links from the narrowest scope to the broadest (e.g. the Pod, its namespace, and the cluster). " +
+				"The Grafana is the one configured with grafana_url, the dashboards are the kubernetes-mixin ones (kube-prometheus-stack) unless overridden with grafana_dashboards. " +
+				"No request is sent to Grafana or to the cluster",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the dashboards, the Pod and the workload default to the configured namespace",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "Optional kind of the workload (e.g. Deployment, StatefulSet, DaemonSet), required with name",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Optional name of the workload, required with kind",
+					},
+					"pod": {
+						Type:        "string",
+						Description: "Optional name of the Pod",
+					},
+					"node": {
+						Type:        "string",
+						Description: "Optional name of the Node",
+					},
+					"start": {
+						Type:        "string",
+						Description: fmt.Sprintf("Optional start of the time range, RFC3339, unix timestamp, or relative to now (e.g. -30m, -1d, now-6h), defaults to %s", internalk8s.GrafanaDefaultStart),
+						Default:     api.ToRawMessage(internalk8s.GrafanaDefaultStart),
+					},
+					"end": {
+						Type:        "string",
+						Description: "Optional end of the time range, RFC3339, unix timestamp, or relative to now, defaults to now",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Grafana: Links",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(false),
+			},
+		}, Handler: grafanaLinks},
+	}
+}
+
+type grafanaLinksArgs struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Pod       string `json:"pod"`
+	Node      string `json:"node"`
+	Start     string `json:"start"`
+	End       string `json:"end"`
+}
+
+func grafanaLinks(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := grafanaLinksArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to generate grafana links, %w", err)), nil
+	}
+	ret, err := params.GrafanaLinks(internalk8s.GrafanaLinksOptions{
+		Namespace: args.Namespace,
+		Kind:      args.Kind,
+		Name:      args.Name,
+		Pod:       args.Pod,
+		Node:      args.Node,
+		Start:     args.Start,
+		End:       args.End,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to generate grafana links: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initLogs() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "logs_query",
+			Description: "Run a LogQL query with the Loki of the cluster to search the historical logs across Pods, including the Pods already deleted or restarted " +
+				"(e.g. {namespace=\"my-app\"} |= \"error\", or {namespace=\"my-app\", container=\"api\"} | json | level=\"error\"). " +
+				"Returns the log lines grouped by stream with their labels, the newest first, limited to limit (narrow the selector, the filters, or the range if limited). " +
+				"Metric queries (e.g. sum by (pod) (count_over_time({namespace=\"my-app\"} |= \"error\" [5m]))) return their series like promql_range. " +
+				"The Loki is the one configured with loki_url (and loki_tenant for multi-tenant Loki), or discovered in the cluster (Loki Helm chart Services)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "LogQL query to run, a stream selector is required (e.g. {namespace=\"my-app\"} |= \"timeout\")",
+					},
+					"start": {
+						Type:        "string",
+						Description: fmt.Sprintf("Optional start of the range, RFC3339, unix timestamp, or relative to now (e.g. -30m, -1d), defaults to %s", internalk8s.LokiDefaultStart),
+						Default:     api.ToRawMessage(internalk8s.LokiDefaultStart),
+					},
+					"end": {
+						Type:        "string",
+						Description: "Optional end of the range, RFC3339, unix timestamp, or relative to now, defaults to now",
+					},
+					"limit": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Optional maximum number of log lines returned (defaults to %d)", internalk8s.LokiDefaultLimit),
+						Default:     api.ToRawMessage(internalk8s.LokiDefaultLimit),
+						Minimum:     ptr.To(float64(1)),
+					},
+					"direction": {
+						Type:        "string",
+						Description: "Optional order of the log lines, backward (the newest first) or forward (the oldest first), defaults to backward",
+						Enum:        []any{"backward", "forward"},
+						Default:     api.ToRawMessage("backward"),
+					},
+					"step": {
+						Type:        "string",
+						Description: "Optional resolution of the metric queries (e.g. 30s, 5m), computed from the range if not provided",
+					},
+					"tenant": {
+						Type:        "string",
+						Description: "Optional tenant of the multi-tenant Loki (X-Scope-OrgID header), defaults to the loki_tenant configuration",
+					},
+				},
+				Required: []string{"query"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Logs: Query",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: logsQuery, Access: monitoringAccess("get")},
+	}
+}
+
+type logsQueryArgs struct {
+	Query     string `json:"query"`
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	Limit     int    `json:"limit"`
+	Direction string `json:"direction"`
+	Step      string `json:"step"`
+	Tenant    string `json:"tenant"`
+}
+
+func logsQuery(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := logsQueryArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query logs, %w", err)), nil
+	}
+	ret, err := params.LokiQuery(params, internalk8s.LokiQueryOptions{
+		Query:     args.Query,
+		Start:     args.Start,
+		End:       args.End,
+		Limit:     args.Limit,
+		Direction: args.Direction,
+		Step:      args.Step,
+		Tenant:    args.Tenant,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query logs: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// promQLSource describes how the Prometheus queried by the tools is found
+const promQLSource = "The Prometheus is the one configured with prometheus_url, or discovered in the cluster " +
+	"(OpenShift monitoring Routes and Services, Prometheus Operator and Helm chart Services)"
+
+func initPromQL() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "promql_query",
+			Description: "Evaluate a PromQL instant query with the Prometheus of the cluster (e.g. sum by (namespace) (rate(container_cpu_usage_seconds_total[5m]))). " +
+				"Returns the value of each series, the highest first, limited to maxSeries (narrow the query with label matchers or aggregations if truncated). " +
+				promQLSource,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "PromQL query to evaluate",
+					},
+					"time": {
+						Type:        "string",
+						Description: "Optional evaluation time, RFC3339, unix timestamp, or relative to now (e.g. -1h), defaults to now",
+					},
+					"maxSeries": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Optional maximum number of series returned, the ones with the highest values are kept (defaults to %d)", internalk8s.PromQLDefaultMaxSeries),
+						Default:     api.ToRawMessage(internalk8s.PromQLDefaultMaxSeries),
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+				Required: []string{"query"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "PromQL: Query",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: promQLQuery, Access: monitoringAccess("get")},
+		{Tool: api.Tool{
+			Name: "promql_range",
+			Description: "Evaluate a PromQL range query with the Prometheus of the cluster over a time range (e.g. the memory usage of a Pod over the last 6 hours). " +
+				"Returns the points of each series with their minimum, maximum, and last value, the highest series first, limited to maxSeries. " +
+				"The step is computed to return at most maxPoints points if not provided, otherwise the points are downsampled (averaged) to fit maxPoints. " +
+				promQLSource,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"query": {
+						Type:        "string",
+						Description: "PromQL query to evaluate",
+					},
+					"start": {
+						Type:        "string",
+						Description: "Start of the range, RFC3339, unix timestamp, or relative to now (e.g. -1h, -7d)",
+					},
+					"end": {
+						Type:        "string",
+						Description: "Optional end of the range, RFC3339, unix timestamp, or relative to now, defaults to now",
+					},
+					"step": {
+						Type:        "string",
+						Description: "Optional resolution of the range (e.g. 30s, 5m, 1h), computed from maxPoints if not provided",
+					},
+					"maxSeries": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Optional maximum number of series returned, the ones with the highest maximum are kept (defaults to %d)", internalk8s.PromQLDefaultMaxSeries),
+						Default:     api.ToRawMessage(internalk8s.PromQLDefaultMaxSeries),
+						Minimum:     ptr.To(float64(1)),
+					},
+					"maxPoints": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Optional maximum number of points of each series (defaults to %d)", internalk8s.PromQLDefaultMaxPoints),
+						Default:     api.ToRawMessage(internalk8s.PromQLDefaultMaxPoints),
+						Minimum:     ptr.To(float64(2)),
+					},
+				},
+				Required: []string{"query", "start"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "PromQL: Range Query",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: promQLRange, Access: monitoringAccess("get")},
+	}
+}
+
+type promQLQueryArgs struct {
+	Query     string `json:"query"`
+	Time      string `json:"time"`
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	Step      string `json:"step"`
+	MaxSeries int    `json:"maxSeries"`
+	MaxPoints int    `json:"maxPoints"`
+}
+
+func promQLQuery(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := promQLQueryArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus, %w", err)), nil
+	}
+	ret, err := params.PromQLQuery(params, internalk8s.PromQLQueryOptions{Query: args.Query, Time: args.Time, MaxSeries: args.MaxSeries})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func promQLRange(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := promQLQueryArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus range, %w", err)), nil
+	}
+	ret, err := params.PromQLQuery(params, internalk8s.PromQLQueryOptions{
+		Query:     args.Query,
+		Start:     args.Start,
+		End:       args.End,
+		Step:      args.Step,
+		MaxSeries: args.MaxSeries,
+		MaxPoints: args.MaxPoints,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query prometheus range: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "metrics"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Tools for querying the observability stack of the cluster (Prometheus PromQL queries, Alertmanager alerts and silences, Loki logs, Tempo and Jaeger traces, etc.)"
+}
+
+func (t *Toolset) GetTools(_ internalk8s.Openshift) []api.ServerTool {
+	return slices.Concat(
+		initPromQL(),
+		initAlerts(),
+		initLogs(),
+		initTraces(),
+		initGrafana(),
+	)
+}
+
+// monitoringAccess is the access required to discover the monitoring stack of the cluster and to send it requests with the verb
+// through the API server service proxy
+func monitoringAccess(verb string) []api.ResourceAccess {
+	return []api.ResourceAccess{
+		{Verb: "list", Resource: "services", AllNamespaces: true},
+		{Verb: verb, Resource: "services", Subresource: "proxy"},
+	}
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initTraces() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "traces_search",
+			Description: "Search the distributed traces of a service with the Tempo (or Jaeger) of the cluster, by operation and duration (e.g. the requests of the checkout service slower than 2s). " +
+				"Returns the traces, the slowest first, with their duration, failed spans, the Pods (namespace/name) involved, and their condensed span tree: " +
+				"the spans shorter than 5% of the trace are counted in the condensed field of their parent unless they failed, so the tree shows where the time is spent. " +
+				"The Tempo is the one configured with tempo_url, the Jaeger the one configured with jaeger_url, or they are discovered in the cluster (Tempo Operator, Jaeger Operator, and Helm chart Services)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"service": {
+						Type:        "string",
+						Description: "Name of the service of the spans (service.name), required with Jaeger",
+					},
+					"operation": {
+						Type:        "string",
+						Description: "Optional name of the operation of the spans (e.g. GET /api/cart)",
+					},
+					"minDuration": {
+						Type:        "string",
+						Description: "Optional minimum duration of the traces (e.g. 500ms, 2s), to find the slow requests",
+					},
+					"maxDuration": {
+						Type:        "string",
+						Description: "Optional maximum duration of the traces (e.g. 100ms)",
+					},
+					"start": {
+						Type:        "string",
+						Description: fmt.Sprintf("Optional start of the range, RFC3339, unix timestamp, or relative to now (e.g. -30m, -1d), defaults to %s", internalk8s.TracesDefaultStart),
+						Default:     api.ToRawMessage(internalk8s.TracesDefaultStart),
+					},
+					"end": {
+						Type:        "string",
+						Description: "Optional end of the range, RFC3339, unix timestamp, or relative to now, defaults to now",
+					},
+					"limit": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Optional maximum number of traces returned (defaults to %d)", internalk8s.TracesDefaultLimit),
+						Default:     api.ToRawMessage(internalk8s.TracesDefaultLimit),
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Traces: Search",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: tracesSearch, Access: monitoringAccess("get")},
+	}
+}
+
+type tracesSearchArgs struct {
+	Service     string `json:"service"`
+	Operation   string `json:"operation"`
+	MinDuration string `json:"minDuration"`
+	MaxDuration string `json:"maxDuration"`
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	Limit       int    `json:"limit"`
+}
+
+func tracesSearch(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := tracesSearchArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to search traces, %w", err)), nil
+	}
+	ret, err := params.TracesSearch(params, internalk8s.TracesSearchOptions{
+		Service:     args.Service,
+		Operation:   args.Operation,
+		MinDuration: args.MinDuration,
+		MaxDuration: args.MaxDuration,
+		Start:       args.Start,
+		End:         args.End,
+		Limit:       args.Limit,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to search traces: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,142 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initConnectivity() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "connectivity_check",
+			Description: "Test the TCP or HTTP reachability of a Service, Pod, or host and port, from a source Pod (exec, its container must provide sh, and nc or wget) " +
+				"or from a short-lived probe Pod launched in the source namespace and deleted afterward (defaults to the probe_image configuration or " + internalk8s.ProbeDefaultImage + "). " +
+				"Returns whether the target is reachable with the output of nc or wget, the target Service with its endpoints, the verdict of the NetworkPolicies for the traffic, " +
+				"and the issues correlating the failures with the NetworkPolicies and the readiness of the endpoints",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional source Namespace, of the source Pod or the probe Pod, the configured namespace if not provided",
+					},
+					"pod": {
+						Type:        "string",
+						Description: "Optional source Pod to run the check in, a probe Pod is launched if not provided",
+					},
+					"container": {
+						Type:        "string",
+						Description: "Optional container of the source Pod, the first container if not provided",
+					},
+					"targetNamespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the target Service or Pod, the source namespace if not provided",
+					},
+					"targetService": {
+						Type:        "string",
+						Description: "Name of the target Service, connected to by its DNS name (exactly one of targetService, targetPod, or targetHost is required)",
+					},
+					"targetPod": {
+						Type:        "string",
+						Description: "Name of the target Pod, connected to by its IP",
+					},
+					"targetHost": {
+						Type:        "string",
+						Description: "Target host name or IP (e.g. an external endpoint)",
+					},
+					"port": {
+						Type:        "integer",
+						Description: "Target port, the first port of the target Service if not provided (required for Pod and host targets)",
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(65535)),
+					},
+					"protocol": {
+						Type:        "string",
+						Description: "Optional protocol of the check, tcp to only open a connection or http to send a GET request",
+						Enum:        []any{internalk8s.ConnectivityCheckTCP, internalk8s.ConnectivityCheckHTTP},
+						Default:     api.ToRawMessage(internalk8s.ConnectivityCheckTCP),
+					},
+					"path": {
+						Type:        "string",
+						Description: "Optional path of the HTTP request (defaults to /)",
+					},
+					"image": {
+						Type:        "string",
+						Description: "Optional image of the probe Pod, it must provide sh, nc, and wget",
+					},
+					"timeout": {
+						Type:        "integer",
+						Description: "Optional time to wait for the probe Pod to complete in seconds (defaults to 120)",
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Connectivity: Check",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: connectivityCheck, Access: []api.ResourceAccess{
+			{Verb: "get", Resource: "pods"},
+			{Verb: "create", Resource: "pods", Subresource: "exec"},
+			{Verb: "create", Resource: "pods"},
+			{Verb: "get", Resource: "pods", Subresource: "log"},
+			{Verb: "delete", Resource: "pods"},
+			{Verb: "get", Resource: "services"},
+			{Verb: "list", Group: "discovery.k8s.io", Resource: "endpointslices"},
+			{Verb: "list", Resource: "pods"},
+			{Verb: "get", Resource: "namespaces", ClusterScoped: true},
+			{Verb: "list", Group: "networking.k8s.io", Resource: "networkpolicies"},
+		}},
+	}
+}
+
+type connectivityCheckArgs struct {
+	Namespace       string `json:"namespace"`
+	Pod             string `json:"pod"`
+	Container       string `json:"container"`
+	TargetNamespace string `json:"targetNamespace"`
+	TargetService   string `json:"targetService"`
+	TargetPod       string `json:"targetPod"`
+	TargetHost      string `json:"targetHost"`
+	Port            int32  `json:"port"`
+	Protocol        string `json:"protocol"`
+	Path            string `json:"path"`
+	Image           string `json:"image"`
+	Timeout         int    `json:"timeout"`
+}
+
+func connectivityCheck(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := connectivityCheckArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check connectivity, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check connectivity, %w", err)), nil
+	}
+	ret, err := params.ConnectivityCheck(params, internalk8s.ConnectivityCheckOptions{
+		ProbeOptions:    internalk8s.ProbeOptions{Namespace: args.Namespace, Image: args.Image, Timeout: time.Duration(args.Timeout) * time.Second},
+		Pod:             args.Pod,
+		Container:       args.Container,
+		TargetNamespace: args.TargetNamespace,
+		TargetService:   args.TargetService,
+		TargetPod:       args.TargetPod,
+		TargetHost:      args.TargetHost,
+		Port:            args.Port,
+		Protocol:        args.Protocol,
+		Path:            args.Path,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check connectivity: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,91 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initDNS() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "dns_check",
+			Description: "Debug the cluster DNS by launching a short-lived probe Pod that looks up cluster and external names with nslookup, the Pod is deleted afterward. " +
+				"Returns the /etc/resolv.conf of the probe Pod, the resolution result and addresses of each name, the health of the cluster DNS " +
+				"(CoreDNS or OpenShift DNS Pods, their Service and endpoints, and the Corefile), and the issues correlating the failed lookups with the DNS health. " +
+				"The probe image must provide sh and nslookup (defaults to the probe_image configuration or " + internalk8s.ProbeDefaultImage + ")",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"names": {
+						Type:        "array",
+						Description: "Optional names to look up (e.g. my-service.my-namespace.svc, example.com), a cluster name and an external name if not provided",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"server": {
+						Type:        "string",
+						Description: "Optional DNS server to query instead of the nameserver of the probe Pod (e.g. the IP of a CoreDNS Pod)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to launch the probe Pod in, the configured namespace if not provided (the DNS policies and NetworkPolicies of the namespace apply)",
+					},
+					"image": {
+						Type:        "string",
+						Description: "Optional image of the probe Pod, it must provide sh and nslookup",
+					},
+					"timeout": {
+						Type:        "integer",
+						Description: "Optional time to wait for the probe Pod to complete in seconds (defaults to 120)",
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "DNS: Check",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: dnsCheck, Access: []api.ResourceAccess{
+			{Verb: "create", Resource: "pods"},
+			{Verb: "get", Resource: "pods", Subresource: "log"},
+			{Verb: "delete", Resource: "pods"},
+			{Verb: "list", Resource: "pods", AllNamespaces: true},
+		}},
+	}
+}
+
+type dnsCheckArgs struct {
+	Names     []string `json:"names"`
+	Server    string   `json:"server"`
+	Namespace string   `json:"namespace"`
+	Image     string   `json:"image"`
+	Timeout   int      `json:"timeout"`
+}
+
+func dnsCheck(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := dnsCheckArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check DNS, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check DNS, %w", err)), nil
+	}
+	ret, err := params.DNSCheck(params, internalk8s.DNSCheckOptions{
+		ProbeOptions: internalk8s.ProbeOptions{Namespace: args.Namespace, Image: args.Image, Timeout: time.Duration(args.Timeout) * time.Second},
+		Names:        args.Names,
+		Server:       args.Server,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check DNS: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,194 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initIngresses() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "ingresses_list",
+			Description: "List the Kubernetes Ingresses with their class, routing rules (host/path -> service:port), default backend, TLS hosts and load balancer addresses",
+			InputSchema: routesListSchema("Ingresses"),
+			Annotations: api.ToolAnnotations{
+				Title:           "Ingresses: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: ingressesList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "networking.k8s.io", Resource: "ingresses", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name: "gateways_list",
+			Description: "List the Gateway API Gateways with their class, addresses, listeners and number of attached routes. " +
+				"Reports the Gateway and listener conditions that are not healthy (not Accepted, not Programmed, unresolved references, conflicts)",
+			InputSchema: routesListSchema("Gateways"),
+			Annotations: api.ToolAnnotations{
+				Title:           "Gateways: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: gatewaysList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "gateway.networking.k8s.io", Resource: "gateways", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name: "httproutes_list",
+			Description: "List the Gateway API HTTPRoutes with their hostnames, rules (path -> service:port) and parent Gateways. " +
+				"Reports whether each parent Gateway accepted the route and resolved its backend references",
+			InputSchema: routesListSchema("HTTPRoutes"),
+			Annotations: api.ToolAnnotations{
+				Title:           "HTTPRoutes: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: httpRoutesList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "gateway.networking.k8s.io", Resource: "httproutes", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name: "routes_resolve",
+			Description: "Resolve which Ingresses and Gateway API HTTPRoutes serve a request to a host and path, in order of precedence, " +
+				"and the backend Services with their ready and not ready pods. " +
+				"Reports the issues preventing the request from being served (route not accepted by its Gateway, Service without ready endpoints, etc.)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"host": {
+						Type:        "string",
+						Description: "Host of the request (e.g. app.example.com)",
+					},
+					"path": {
+						Type:        "string",
+						Description: "Optional path of the request (defaults to /)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to look for the routes in, all namespaces if not provided",
+					},
+				},
+				Required: []string{"host"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Routes: Resolve",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: routesResolve, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "networking.k8s.io", Resource: "ingresses", AllNamespaces: true},
+			{Verb: "list", Group: "gateway.networking.k8s.io", Resource: "httproutes", AllNamespaces: true},
+			{Verb: "get", Resource: "services"},
+			{Verb: "list", Group: "discovery.k8s.io", Resource: "endpointslices"},
+			{Verb: "list", Resource: "pods"},
+		}},
+	}
+}
+
+func routesListSchema(kind string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"namespace": {
+				Type:        "string",
+				Description: "Optional Namespace to list the " + kind + " from, all namespaces if not provided",
+			},
+		},
+	}
+}
+
+type routesListArgs struct {
+	Namespace string `json:"namespace"`
+}
+
+func ingressesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := routesListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list ingresses, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list ingresses, %w", err)), nil
+	}
+	ret, err := params.IngressesList(params, args.Namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list ingresses: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No ingresses found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func gatewaysList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := routesListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list gateways, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list gateways, %w", err)), nil
+	}
+	ret, err := params.GatewaysList(params, args.Namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list gateways: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No gateways found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func httpRoutesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := routesListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list http routes, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list http routes, %w", err)), nil
+	}
+	ret, err := params.HTTPRoutesList(params, args.Namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list http routes: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No http routes found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+type routesResolveArgs struct {
+	Host      string `json:"host"`
+	Path      string `json:"path"`
+	Namespace string `json:"namespace"`
+}
+
+func routesResolve(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := routesResolveArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to resolve routes, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to resolve routes, %w", err)), nil
+	}
+	if args.Path == "" {
+		args.Path = "/"
+	}
+	ret, err := params.RoutesResolve(params, args.Namespace, args.Host, args.Path)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to resolve routes: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No ingresses or http routes match the host %s and path %s", args.Host, args.Path), nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,155 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initNetworkPolicies() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "networkpolicies_list",
+			Description: "List the Kubernetes NetworkPolicies with the pods they select, their policy types, and their ingress and egress rules described in plain text",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to list the NetworkPolicies from, all namespaces if not provided",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "NetworkPolicies: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: networkPoliciesList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "networking.k8s.io", Resource: "networkpolicies", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name: "networkpolicies_simulate",
+			Description: "Simulate whether the NetworkPolicies allow the traffic from a source pod to a destination pod and port. " +
+				"Evaluates the egress policies selecting the source and the ingress policies selecting the destination, and explains the verdict with the policies allowing or denying the traffic. " +
+				"The pods are identified by name, or by labels to simulate the traffic of the pods that would have them (named ports and ipBlock rules are only resolved for pods identified by name). " +
+				"The enforcement of the policies depends on the network plugin of the cluster",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"source":      endpointSchema("Source of the traffic"),
+					"destination": endpointSchema("Destination of the traffic"),
+					"port": {
+						Type:        "integer",
+						Description: "Destination port of the traffic",
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(65535)),
+					},
+					"protocol": {
+						Type:        "string",
+						Description: "Optional protocol of the traffic (defaults to TCP)",
+						Enum:        []any{"TCP", "UDP", "SCTP"},
+					},
+				},
+				Required: []string{"source", "destination", "port"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "NetworkPolicies: Simulate",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: networkPoliciesSimulate, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "networking.k8s.io", Resource: "networkpolicies"},
+		}},
+	}
+}
+
+func endpointSchema(description string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "object",
+		Description: description + ", a pod name or the labels of the pods",
+		Properties: map[string]*jsonschema.Schema{
+			"namespace": {
+				Type:        "string",
+				Description: "Optional Namespace of the pod, the configured namespace if not provided",
+			},
+			"pod": {
+				Type:        "string",
+				Description: "Optional name of the pod",
+			},
+			"labels": {
+				Type:                 "object",
+				Description:          "Optional labels of the pods, used if no pod name is provided (e.g. {\"app\": \"web\"})",
+				AdditionalProperties: &jsonschema.Schema{Type: "string"},
+			},
+		},
+	}
+}
+
+type networkPoliciesListArgs struct {
+	Namespace string `json:"namespace"`
+}
+
+func networkPoliciesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := networkPoliciesListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list network policies, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list network policies, %w", err)), nil
+	}
+	ret, err := params.NetworkPoliciesList(params, args.Namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list network policies: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No network policies found, all the traffic is allowed", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+type networkPolicyEndpointArgs struct {
+	Namespace string            `json:"namespace"`
+	Pod       string            `json:"pod"`
+	Labels    map[string]string `json:"labels"`
+}
+
+func (a networkPolicyEndpointArgs) endpoint() internalk8s.NetworkPolicyEndpoint {
+	return internalk8s.NetworkPolicyEndpoint{Namespace: a.Namespace, Pod: a.Pod, Labels: a.Labels}
+}
+
+type networkPoliciesSimulateArgs struct {
+	Source      networkPolicyEndpointArgs `json:"source"`
+	Destination networkPolicyEndpointArgs `json:"destination"`
+	Port        int32                     `json:"port"`
+	Protocol    string                    `json:"protocol"`
+}
+
+func networkPoliciesSimulate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := networkPoliciesSimulateArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to simulate network policies, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to simulate network policies, %w", err)), nil
+	}
+	ret, err := params.NetworkPoliciesSimulate(params, internalk8s.NetworkPolicySimulateOptions{
+		Source:      args.Source.endpoint(),
+		Destination: args.Destination.endpoint(),
+		Port:        args.Port,
+		Protocol:    args.Protocol,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to simulate network policies: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
@@ -0,0 +1,67 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initServices() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "services_describe",
+			Description: "Describe a Kubernetes Service joined with its EndpointSlices: ports and target ports, ready and not ready endpoints with their target pods and nodes, " +
+				"and the number of pods matching its selector. Reports the issues explaining why the Service has no endpoints " +
+				"(selector matching zero pods, pods not ready, target ports not exposed by the pods)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the Service, the configured namespace if not provided",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Service",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Services: Describe",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: servicesDescribe, Access: []api.ResourceAccess{
+			{Verb: "get", Resource: "services"},
+			{Verb: "list", Group: "discovery.k8s.io", Resource: "endpointslices"},
+			{Verb: "list", Resource: "pods"},
+		}},
+	}
+}
+
+type servicesDescribeArgs struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func servicesDescribe(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := servicesDescribeArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe service, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe service, %w", err)), nil
+	}
+	ret, err := params.ServicesDescribe(params, args.Namespace, args.Name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe service: %w", err)), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
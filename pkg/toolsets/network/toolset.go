@@ -0,0 +1,35 @@
+package network
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "network"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Tools for inspecting and troubleshooting cluster networking (NetworkPolicies, Services and their endpoints, Ingresses, Gateway API routes, etc.)"
+}
+
+func (t *Toolset) GetTools(_ internalk8s.Openshift) []api.ServerTool {
+	return slices.Concat(
+		initNetworkPolicies(),
+		initServices(),
+		initIngresses(),
+		initDNS(),
+		initConnectivity(),
+	)
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
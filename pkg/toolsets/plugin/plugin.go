@@ -0,0 +1,328 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+const (
+	// APIVersion is the version of the toolset plugin contract implemented by this server.
+	// It must be increased on any incompatible change of the handshake or of the api.Toolset interface.
+	APIVersion = 1
+	// HandshakeCapability is the experimental MCP server capability advertised by plugins during initialization
+	HandshakeCapability = "kubernetes-mcp-server/toolset"
+	// MagicCookieKey and MagicCookieValue are set in the environment of the plugin processes,
+	// they allow plugin executables to detect that they are not being executed directly by a user.
+	MagicCookieKey   = "KUBERNETES_MCP_SERVER_PLUGIN"
+	MagicCookieValue = "toolset"
+)
+
+// Handshake is the toolset description exchanged in the HandshakeCapability of the plugin initialize result
+type Handshake struct {
+	APIVersion  int    `json:"apiVersion"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// NewHandshakeHooks returns the server hooks a plugin must install to advertise the provided toolset to the host
+func NewHandshakeHooks(toolset api.Toolset) *server.Hooks {
+	hooks := &server.Hooks{}
+	hooks.AddAfterInitialize(func(_ context.Context, _ any, _ *mcp.InitializeRequest, result *mcp.InitializeResult) {
+		if result.Capabilities.Experimental == nil {
+			result.Capabilities.Experimental = map[string]any{}
+		}
+		result.Capabilities.Experimental[HandshakeCapability] = Handshake{
+			APIVersion:  APIVersion,
+			Name:        toolset.GetName(),
+			Description: toolset.GetDescription(),
+		}
+	})
+	return hooks
+}
+
+type connectFunc func(ctx context.Context) (*client.Client, error)
+
+// Toolset is an api.Toolset whose tools are provided by an external plugin process.
+// The plugin is an MCP server speaking over stdio, each tool call is forwarded to it.
+// If the plugin crashes, the affected call fails and the plugin is restarted on the next call.
+type Toolset struct {
+	plugin    string
+	connect   connectFunc
+	handshake Handshake
+	// trustedTools are the tools whose annotations advertised by the plugin are trusted
+	trustedTools []string
+	tools        []api.ServerTool
+	mu           sync.Mutex
+	client       *client.Client
+}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return t.handshake.Name
+}
+
+func (t *Toolset) GetDescription() string {
+	return t.handshake.Description
+}
+
+func (t *Toolset) GetTools(_ internalk8s.Openshift) []api.ServerTool {
+	return t.tools
+}
+
+// Load starts the configured plugins and registers their toolsets.
+// Callers are responsible for closing the returned toolsets once the server stops.
+func Load(ctx context.Context, plugins []config.Plugin) ([]*Toolset, error) {
+	loaded := make([]*Toolset, 0, len(plugins))
+	for _, p := range plugins {
+		t, err := start(ctx, p.Command, stdioConnector(p), p.TrustedTools)
+		if err == nil && toolsets.ToolsetFromString(t.GetName()) != nil {
+			_ = t.Close()
+			err = fmt.Errorf("plugin %s provides toolset %s which is already registered", p.Command, t.GetName())
+		}
+		if err == nil {
+			if err = checkToolNames(t, toolsets.Toolsets()); err != nil {
+				_ = t.Close()
+			}
+		}
+		if err != nil {
+			for _, l := range loaded {
+				_ = l.Close()
+			}
+			return nil, err
+		}
+		toolsets.Register(t)
+		loaded = append(loaded, t)
+	}
+	return loaded, nil
+}
+
+func stdioConnector(p config.Plugin) connectFunc {
+	env := append([]string{MagicCookieKey + "=" + MagicCookieValue}, p.Env...)
+	return func(_ context.Context) (*client.Client, error) {
+		return client.NewStdioMCPClient(p.Command, env, p.Args...)
+	}
+}
+
+// start connects to the plugin, performs the handshake, and retrieves its tools
+func start(ctx context.Context, plugin string, connect connectFunc, trustedTools []string) (*Toolset, error) {
+	t := &Toolset{plugin: plugin, connect: connect, trustedTools: trustedTools}
+	c, err := t.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	listed, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		_ = t.Close()
+		return nil, fmt.Errorf("failed to list tools of plugin %s: %v", plugin, err)
+	}
+	for _, tool := range listed.Tools {
+		serverTool, err := t.serverTool(tool)
+		if err != nil {
+			_ = t.Close()
+			return nil, err
+		}
+		t.tools = append(t.tools, serverTool)
+	}
+	return t, nil
+}
+
+type openshift bool
+
+func (o openshift) IsOpenShift(context.Context) bool { return bool(o) }
+
+// checkToolNames rejects a plugin providing a tool with the same name as a tool of the registered toolsets,
+// the plugin tool would otherwise shadow (or be shadowed by) the registered tool with different safety annotations
+func checkToolNames(t *Toolset, registered []api.Toolset) error {
+	for _, toolset := range registered {
+		for _, o := range []openshift{false, true} {
+			for _, tool := range toolset.GetTools(o) {
+				for _, pluginTool := range t.tools {
+					if pluginTool.Tool.Name == tool.Tool.Name {
+						return fmt.Errorf("plugin %s provides tool %s which is already provided by toolset %s", t.plugin, tool.Tool.Name, toolset.GetName())
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getClient returns the client of the running plugin, (re)starting the plugin process if needed
+func (t *Toolset) getClient(ctx context.Context) (*client.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.client != nil {
+		return t.client, nil
+	}
+	c, err := t.connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start plugin %s: %v", t.plugin, err)
+	}
+	if err = c.Start(ctx); err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("failed to start plugin %s: %v", t.plugin, err)
+	}
+	result, err := c.Initialize(ctx, mcp.InitializeRequest{Params: mcp.InitializeParams{
+		ProtocolVersion: mcp.LATEST_PROTOCOL_VERSION,
+		ClientInfo:      mcp.Implementation{Name: version.BinaryName, Version: version.Version},
+	}})
+	if err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("failed to initialize plugin %s: %v", t.plugin, err)
+	}
+	handshake, err := parseHandshake(t.plugin, result)
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	if t.handshake.Name != "" && t.handshake.Name != handshake.Name {
+		_ = c.Close()
+		return nil, fmt.Errorf("plugin %s provides toolset %s, expected %s", t.plugin, handshake.Name, t.handshake.Name)
+	}
+	t.handshake = *handshake
+	t.client = c
+	return c, nil
+}
+
+// reset discards the client of a plugin that failed, the next call restarts the plugin
+func (t *Toolset) reset(c *client.Client) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.client == c {
+		_ = t.client.Close()
+		t.client = nil
+	}
+}
+
+// Close stops the plugin process
+func (t *Toolset) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.client == nil {
+		return nil
+	}
+	err := t.client.Close()
+	t.client = nil
+	return err
+}
+
+func parseHandshake(plugin string, result *mcp.InitializeResult) (*Handshake, error) {
+	raw, ok := result.Capabilities.Experimental[HandshakeCapability]
+	if !ok {
+		return nil, fmt.Errorf("plugin %s does not advertise the %s capability", plugin, HandshakeCapability)
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid handshake from plugin %s: %v", plugin, err)
+	}
+	handshake := &Handshake{}
+	if err = json.Unmarshal(data, handshake); err != nil {
+		return nil, fmt.Errorf("invalid handshake from plugin %s: %v", plugin, err)
+	}
+	if handshake.APIVersion != APIVersion {
+		return nil, fmt.Errorf("plugin %s implements toolset API version %d, expected %d", plugin, handshake.APIVersion, APIVersion)
+	}
+	if handshake.Name == "" {
+		return nil, fmt.Errorf("invalid handshake from plugin %s: missing toolset name", plugin)
+	}
+	return handshake, nil
+}
+
+// serverTool converts a tool listed by the plugin into a tool forwarding its calls to the plugin
+func (t *Toolset) serverTool(tool mcp.Tool) (api.ServerTool, error) {
+	schema := &jsonschema.Schema{}
+	data := tool.RawInputSchema
+	if len(data) == 0 {
+		var err error
+		if data, err = json.Marshal(tool.InputSchema); err != nil {
+			return api.ServerTool{}, fmt.Errorf("failed to marshal input schema of tool %s from plugin %s: %v", tool.Name, t.plugin, err)
+		}
+	}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return api.ServerTool{}, fmt.Errorf("failed to parse input schema of tool %s from plugin %s: %v", tool.Name, t.plugin, err)
+	}
+	var outputSchema *jsonschema.Schema
+	data = tool.RawOutputSchema
+	if len(data) == 0 && tool.OutputSchema.Type != "" {
+		var err error
+		if data, err = json.Marshal(tool.OutputSchema); err != nil {
+			return api.ServerTool{}, fmt.Errorf("failed to marshal output schema of tool %s from plugin %s: %v", tool.Name, t.plugin, err)
+		}
+	}
+	if len(data) > 0 {
+		outputSchema = &jsonschema.Schema{}
+		if err := json.Unmarshal(data, outputSchema); err != nil {
+			return api.ServerTool{}, fmt.Errorf("failed to parse output schema of tool %s from plugin %s: %v", tool.Name, t.plugin, err)
+		}
+	}
+	annotations := api.ToolAnnotations{
+		Title:           tool.Annotations.Title,
+		ReadOnlyHint:    tool.Annotations.ReadOnlyHint,
+		DestructiveHint: tool.Annotations.DestructiveHint,
+		IdempotentHint:  tool.Annotations.IdempotentHint,
+		OpenWorldHint:   tool.Annotations.OpenWorldHint,
+	}
+	if !slices.Contains(t.trustedTools, tool.Name) {
+		// The read-only, disable-destructive and confirmation safety checks rely on the annotations,
+		// the annotations of a plugin are only trusted if the operator says so
+		annotations.ReadOnlyHint = ptr.To(false)
+		annotations.DestructiveHint = ptr.To(true)
+	}
+	return api.ServerTool{
+		Tool: api.Tool{
+			Name:         tool.Name,
+			Description:  tool.Description,
+			Annotations:  annotations,
+			InputSchema:  schema,
+			OutputSchema: outputSchema,
+		},
+		Handler: t.handler(tool.Name),
+	}, nil
+}
+
+func (t *Toolset) handler(name string) api.ToolHandlerFunc {
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		c, err := t.getClient(params.Context)
+		if err != nil {
+			return api.NewToolCallResult("", err), nil
+		}
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = params.GetArguments()
+		result, err := c.CallTool(params.Context, request)
+		if err != nil {
+			// The plugin may have crashed, restart it on the next call instead of failing forever
+			klog.V(1).Infof("plugin %s failed to call tool %s, restarting it: %v", t.plugin, name, err)
+			t.reset(c)
+			return api.NewToolCallResult("", fmt.Errorf("plugin %s failed to call tool %s: %w", t.plugin, name, err)), nil
+		}
+		texts := make([]string, 0, len(result.Content))
+		for _, content := range result.Content {
+			if text, ok := mcp.AsTextContent(content); ok {
+				texts = append(texts, text.Text)
+			}
+		}
+		if result.IsError {
+			return api.NewToolCallResult("", fmt.Errorf("%s", strings.Join(texts, "\n"))), nil
+		}
+		return api.NewStructuredToolCallResult(strings.Join(texts, "\n"), result.StructuredContent, nil), nil
+	}
+}
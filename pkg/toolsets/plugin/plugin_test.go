@@ -0,0 +1,201 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/suite"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+type PluginSuite struct {
+	suite.Suite
+	// connections counts the number of times the plugin was (re)started
+	connections int
+	// crash makes the next tool call fail with a protocol error, simulating a plugin crash
+	crash bool
+}
+
+func (s *PluginSuite) SetupTest() {
+	s.connections = 0
+	s.crash = false
+}
+
+type testToolset struct{}
+
+func (t *testToolset) GetName() string { return "kubevirt" }
+
+func (t *testToolset) GetDescription() string { return "Tools for KubeVirt virtual machines" }
+
+func (t *testToolset) GetTools(_ kubernetes.Openshift) []api.ServerTool { return nil }
+
+func (s *PluginSuite) newServer(hooks *server.Hooks) *server.MCPServer {
+	mcpServer := server.NewMCPServer("test-plugin", "0.0.1", server.WithToolCapabilities(true), server.WithHooks(hooks))
+	mcpServer.AddTool(mcp.NewTool("vms_list",
+		mcp.WithDescription("List virtual machines"),
+		mcp.WithString("namespace", mcp.Description("Namespace to list"), mcp.Required()),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithRawOutputSchema([]byte(`{"type":"object","properties":{"vms":{"type":"array","items":{"type":"string"}}}}`)),
+	), func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if s.crash {
+			s.crash = false
+			return nil, errors.New("plugin crashed")
+		}
+		namespace := request.GetString("namespace", "")
+		if namespace == "forbidden" {
+			return mcp.NewToolResultError("namespace forbidden is not accessible"), nil
+		}
+		return mcp.NewToolResultStructured(map[string]any{"vms": []string{"vm-1"}}, "vm-1 in "+namespace), nil
+	})
+	return mcpServer
+}
+
+func (s *PluginSuite) connector(hooks *server.Hooks) connectFunc {
+	return func(_ context.Context) (*client.Client, error) {
+		s.connections++
+		return client.NewInProcessClient(s.newServer(hooks))
+	}
+}
+
+func (s *PluginSuite) callTool(t *Toolset, arguments map[string]any) *api.ToolCallResult {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = arguments
+	result, err := t.GetTools(nil)[0].Handler(api.ToolHandlerParams{Context: s.T().Context(), ToolCallRequest: request})
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *PluginSuite) TestStart() {
+	t, err := start(s.T().Context(), "kubevirt-plugin", s.connector(NewHandshakeHooks(&testToolset{})), []string{"vms_list"})
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = t.Close() })
+	s.Run("reads toolset name from handshake", func() {
+		s.Equal("kubevirt", t.GetName())
+	})
+	s.Run("reads toolset description from handshake", func() {
+		s.Equal("Tools for KubeVirt virtual machines", t.GetDescription())
+	})
+	tools := t.GetTools(nil)
+	s.Require().Len(tools, 1)
+	s.Run("converts tool name and description", func() {
+		s.Equal("vms_list", tools[0].Tool.Name)
+		s.Equal("List virtual machines", tools[0].Tool.Description)
+	})
+	s.Run("converts tool annotations of trusted tools", func() {
+		s.True(ptr.Deref(tools[0].Tool.Annotations.ReadOnlyHint, false))
+	})
+	s.Run("converts tool input schema", func() {
+		s.Require().NotNil(tools[0].Tool.InputSchema)
+		s.Equal("object", tools[0].Tool.InputSchema.Type)
+		s.Contains(tools[0].Tool.InputSchema.Properties, "namespace")
+		s.Equal([]string{"namespace"}, tools[0].Tool.InputSchema.Required)
+	})
+	s.Run("converts tool output schema", func() {
+		s.Require().NotNil(tools[0].Tool.OutputSchema)
+		s.Equal("object", tools[0].Tool.OutputSchema.Type)
+		s.Contains(tools[0].Tool.OutputSchema.Properties, "vms")
+	})
+}
+
+func (s *PluginSuite) TestStartUntrustedTools() {
+	t, err := start(s.T().Context(), "kubevirt-plugin", s.connector(NewHandshakeHooks(&testToolset{})), nil)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = t.Close() })
+	tools := t.GetTools(nil)
+	s.Require().Len(tools, 1)
+	s.Run("treats untrusted tools as destructive", func() {
+		s.False(ptr.Deref(tools[0].Tool.Annotations.ReadOnlyHint, true))
+		s.True(ptr.Deref(tools[0].Tool.Annotations.DestructiveHint, false))
+	})
+}
+
+type registeredToolset struct{}
+
+func (t *registeredToolset) GetName() string { return "core" }
+
+func (t *registeredToolset) GetDescription() string { return "Core tools" }
+
+func (t *registeredToolset) GetTools(o kubernetes.Openshift) []api.ServerTool {
+	if o.IsOpenShift(context.Background()) {
+		return []api.ServerTool{{Tool: api.Tool{Name: "vms_list"}}}
+	}
+	return []api.ServerTool{{Tool: api.Tool{Name: "pods_list"}}}
+}
+
+func (s *PluginSuite) TestCheckToolNames() {
+	t, err := start(s.T().Context(), "kubevirt-plugin", s.connector(NewHandshakeHooks(&testToolset{})), nil)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = t.Close() })
+	s.Run("accepts tools with distinct names", func() {
+		s.NoError(checkToolNames(t, []api.Toolset{&testToolset{}}))
+	})
+	s.Run("rejects tools with the name of a registered tool", func() {
+		err := checkToolNames(t, []api.Toolset{&registeredToolset{}})
+		s.Require().Error(err)
+		s.Equal("plugin kubevirt-plugin provides tool vms_list which is already provided by toolset core", err.Error())
+	})
+}
+
+func (s *PluginSuite) TestStartInvalidHandshake() {
+	s.Run("fails if plugin does not advertise the toolset capability", func() {
+		_, err := start(s.T().Context(), "kubevirt-plugin", s.connector(&server.Hooks{}), nil)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "plugin kubevirt-plugin does not advertise the kubernetes-mcp-server/toolset capability")
+	})
+	s.Run("fails if plugin implements a different API version", func() {
+		hooks := &server.Hooks{}
+		hooks.AddAfterInitialize(func(_ context.Context, _ any, _ *mcp.InitializeRequest, result *mcp.InitializeResult) {
+			result.Capabilities.Experimental = map[string]any{
+				HandshakeCapability: Handshake{APIVersion: APIVersion + 1, Name: "kubevirt"},
+			}
+		})
+		_, err := start(s.T().Context(), "kubevirt-plugin", s.connector(hooks), nil)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "plugin kubevirt-plugin implements toolset API version 2, expected 1")
+	})
+}
+
+func (s *PluginSuite) TestToolCall() {
+	t, err := start(s.T().Context(), "kubevirt-plugin", s.connector(NewHandshakeHooks(&testToolset{})), nil)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = t.Close() })
+	s.Run("forwards arguments and returns plugin content", func() {
+		result := s.callTool(t, map[string]any{"namespace": "vms"})
+		s.Nil(result.Error)
+		s.Equal("vm-1 in vms", result.Content)
+	})
+	s.Run("forwards plugin structured content", func() {
+		result := s.callTool(t, map[string]any{"namespace": "vms"})
+		s.Equal(map[string]any{"vms": []any{"vm-1"}}, result.StructuredContent)
+	})
+	s.Run("returns plugin tool errors", func() {
+		result := s.callTool(t, map[string]any{"namespace": "forbidden"})
+		s.Require().NotNil(result.Error)
+		s.Equal("namespace forbidden is not accessible", result.Error.Error())
+	})
+	s.Run("plugin crash", func() {
+		s.crash = true
+		result := s.callTool(t, map[string]any{"namespace": "vms"})
+		s.Run("returns error for the failed call", func() {
+			s.Require().NotNil(result.Error)
+			s.Contains(result.Error.Error(), "plugin kubevirt-plugin failed to call tool vms_list")
+		})
+		s.Run("restarts the plugin on the next call", func() {
+			result = s.callTool(t, map[string]any{"namespace": "vms"})
+			s.Nil(result.Error)
+			s.Equal("vm-1 in vms", result.Content)
+			s.Equal(2, s.connections)
+		})
+	})
+}
+
+func TestPlugin(t *testing.T) {
+	suite.Run(t, new(PluginSuite))
+}
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initStorage() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "pvcs_list",
+			Description: "List the Kubernetes PersistentVolumeClaims (PVCs) with their phase, bound PersistentVolume, requested and actual capacity, access modes, and storage class. " +
+				"Pending PVCs include the provisioning events explaining why they are not bound (e.g. no default StorageClass, provisioner errors, waiting for the first consumer)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to list the PVCs from, all namespaces if not provided",
+					},
+					"pending": {
+						Type:        "boolean",
+						Description: "Optional, only list the Pending PVCs (defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "PVCs: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: pvcsList, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "persistentvolumeclaims", AllNamespaces: true},
+		}},
+		{Tool: api.Tool{
+			Name:        "pvs_list",
+			Description: "List the Kubernetes PersistentVolumes (PVs) with their phase, capacity, access modes, reclaim policy, storage class, and bound claim",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "PVs: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: pvsList, Access: []api.ResourceAccess{
+			{Verb: "list", Resource: "persistentvolumes", ClusterScoped: true},
+		}},
+		{Tool: api.Tool{
+			Name:        "storageclasses_list",
+			Description: "List the Kubernetes StorageClasses with their provisioner, whether they are the default StorageClass, reclaim policy, volume binding mode, and volume expansion support",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "StorageClasses: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: storageClassesList, Access: []api.ResourceAccess{
+			{Verb: "list", Group: "storage.k8s.io", Resource: "storageclasses", ClusterScoped: true},
+		}},
+	}
+}
+
+type pvcsListArgs struct {
+	Namespace string `json:"namespace"`
+	Pending   bool   `json:"pending"`
+}
+
+func pvcsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	args := pvcsListArgs{}
+	if err := api.BindArguments(params, &args); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list persistent volume claims, %w", err)), nil
+	}
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list persistent volume claims, %w", err)), nil
+	}
+	ret, err := params.PersistentVolumeClaimsList(params, args.Namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list persistent volume claims: %w", err)), nil
+	}
+	if args.Pending {
+		ret = slices.DeleteFunc(ret, func(pvc internalk8s.PersistentVolumeClaim) bool { return pvc.Phase != "Pending" })
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No persistent volume claims found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func pvsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list persistent volumes, %w", err)), nil
+	}
+	ret, err := params.PersistentVolumesList(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list persistent volumes: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No persistent volumes found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
+
+func storageClassesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	if err := api.RejectClusterParameter(params); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list storage classes, %w", err)), nil
+	}
+	ret, err := params.StorageClassesList(params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list storage classes: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No storage classes found", nil), nil
+	}
+	return api.NewToolCallResult(output.MarshalYaml(ret)), nil
+}
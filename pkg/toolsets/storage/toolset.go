@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"slices"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+)
+
+type Toolset struct{}
+
+var _ api.Toolset = (*Toolset)(nil)
+
+func (t *Toolset) GetName() string {
+	return "storage"
+}
+
+func (t *Toolset) GetDescription() string {
+	return "Tools for inspecting persistent storage (PersistentVolumeClaims, PersistentVolumes, StorageClasses, provisioning issues, etc.)"
+}
+
+func (t *Toolset) GetTools(_ internalk8s.Openshift) []api.ServerTool {
+	return slices.Concat(
+		initStorage(),
+	)
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
@@ -15,7 +15,12 @@ func Clear() {
 	toolsets = []api.Toolset{}
 }
 
+// Register adds a toolset to the registry, toolsets are expected to register themselves in their package init function.
+// Registering two toolsets with the same name is a programming error and panics.
 func Register(toolset api.Toolset) {
+	if ToolsetFromString(toolset.GetName()) != nil {
+		panic(fmt.Sprintf("toolset %s is already registered", toolset.GetName()))
+	}
 	toolsets = append(toolsets, toolset)
 }
 
@@ -41,6 +46,19 @@ func ToolsetFromString(name string) api.Toolset {
 	return nil
 }
 
+// Enabled returns the registered toolsets for the provided names, in the same order, skipping the disabled ones
+func Enabled(names []string, disabled []string) []api.Toolset {
+	enabled := make([]api.Toolset, 0, len(names))
+	for _, name := range names {
+		toolset := ToolsetFromString(name)
+		if toolset == nil || slices.ContainsFunc(disabled, func(d string) bool { return strings.TrimSpace(d) == toolset.GetName() }) {
+			continue
+		}
+		enabled = append(enabled, toolset)
+	}
+	return enabled
+}
+
 func Validate(toolsets []string) error {
 	for _, toolset := range toolsets {
 		if ToolsetFromString(toolset) == nil {
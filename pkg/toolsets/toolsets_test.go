@@ -51,6 +51,16 @@ func (s *ToolsetsSuite) TestToolsetNames() {
 	})
 }
 
+func (s *ToolsetsSuite) TestRegister() {
+	s.Run("Registers toolset", func() {
+		Register(&TestToolset{name: "registered"})
+		s.NotNil(ToolsetFromString("registered"), "Expected toolset to be registered")
+	})
+	s.Run("Panics if toolset with the same name is already registered", func() {
+		s.Panics(func() { Register(&TestToolset{name: "registered"}) }, "Expected panic for duplicate toolset")
+	})
+}
+
 func (s *ToolsetsSuite) TestToolsetFromString() {
 	s.Run("Returns nil if toolset not found", func() {
 		s.Nil(ToolsetFromString("non-existent"), "Expected nil for non-existent toolset")
@@ -69,6 +79,28 @@ func (s *ToolsetsSuite) TestToolsetFromString() {
 	})
 }
 
+func (s *ToolsetsSuite) TestEnabled() {
+	Register(&TestToolset{name: "core"})
+	Register(&TestToolset{name: "helm"})
+	Register(&TestToolset{name: "config"})
+	names := func(toolsets []api.Toolset) []string {
+		ret := make([]string, 0)
+		for _, toolset := range toolsets {
+			ret = append(ret, toolset.GetName())
+		}
+		return ret
+	}
+	s.Run("Returns toolsets in the provided order", func() {
+		s.Equal([]string{"helm", "core"}, names(Enabled([]string{"helm", "core"}, nil)))
+	})
+	s.Run("Skips disabled toolsets", func() {
+		s.Equal([]string{"core", "config"}, names(Enabled([]string{"core", "helm", "config"}, []string{" helm "})))
+	})
+	s.Run("Skips unknown toolsets", func() {
+		s.Equal([]string{"core"}, names(Enabled([]string{"core", "unknown"}, nil)))
+	})
+}
+
 func (s *ToolsetsSuite) TestValidate() {
 	s.Run("Returns nil for empty toolset list", func() {
 		s.Nil(Validate([]string{}), "Expected nil for empty toolset list")
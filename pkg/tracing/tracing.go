@@ -0,0 +1,79 @@
+// Package tracing provides the OpenTelemetry instrumentation of the MCP server: a span per tool call with child spans
+// for the Kubernetes and ACM proxy requests, exported via OTLP.
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+const instrumentationName = "github.com/containers/kubernetes-mcp-server"
+
+// Setup installs the global tracer provider exporting the spans to the configured OTLP/HTTP endpoint and the W3C
+// trace context propagator, it returns the function flushing and stopping the exporter.
+// Tracing is disabled (spans are not recorded) if no endpoint is configured.
+func Setup(ctx context.Context, staticConfig *config.StaticConfig) (func(context.Context) error, error) {
+	if staticConfig.TracingEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(staticConfig.TracingEndpoint))
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", version.BinaryName),
+		attribute.String("service.version", version.Version),
+	))
+	if err != nil {
+		return nil, err
+	}
+	sampleRatio := staticConfig.TracingSampleRatio
+	if sampleRatio <= 0 {
+		sampleRatio = 1
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// Traces started by the client (traceparent header) keep the client sampling decision
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the MCP server spans
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Extract returns a context carrying the trace context propagated by the client in the provided HTTP headers
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Transport instruments the provided round tripper: each request is recorded as a child span of the span in its
+// context and the trace context is propagated to the server.
+// The span is named after the provided name and the HTTP method (e.g. "kubernetes GET").
+func Transport(name string, rt http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(rt, otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return name + " " + r.Method
+	}))
+}
+
+// WrapKubernetesTransport instruments the client-go round trippers, it's meant to be used with rest.Config.Wrap
+func WrapKubernetesTransport(rt http.RoundTripper) http.RoundTripper {
+	return Transport("kubernetes", rt)
+}
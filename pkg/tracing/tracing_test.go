@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("Tracing is disabled without endpoint", func(t *testing.T) {
+		shutdown, err := Setup(context.Background(), &config.StaticConfig{})
+		if err != nil || shutdown(context.Background()) != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		_, span := Tracer().Start(context.Background(), "disabled")
+		defer span.End()
+		if span.IsRecording() {
+			t.Errorf("expected spans not to be recorded")
+		}
+	})
+	t.Run("Spans are exported to the endpoint", func(t *testing.T) {
+		previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+		t.Cleanup(func() {
+			otel.SetTracerProvider(previousProvider)
+			otel.SetTextMapPropagator(previousPropagator)
+		})
+		exported := ""
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			exported = r.URL.Path
+		}))
+		t.Cleanup(collector.Close)
+		shutdown, err := Setup(context.Background(), &config.StaticConfig{TracingEndpoint: collector.URL + "/v1/traces"})
+		if err != nil {
+			t.Fatalf("failed to set up tracing: %v", err)
+		}
+		_, span := Tracer().Start(context.Background(), "exported")
+		span.End()
+		if err = shutdown(context.Background()); err != nil {
+			t.Fatalf("failed to flush the spans: %v", err)
+		}
+		if exported != "/v1/traces" {
+			t.Errorf("expected the spans to be exported, got %q", exported)
+		}
+	})
+}
+
+func TestExtract(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	spanContext := trace.SpanContextFromContext(Extract(context.Background(), header))
+	if spanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || !spanContext.IsRemote() {
+		t.Errorf("expected the client trace context, got %v", spanContext.TraceID())
+	}
+}